@@ -556,6 +556,55 @@ func GetShardRootsHashByBlockHash(db incdb.Database, shardID byte, hash common.H
 	return bRH, err
 }
 
+// GetConfirmedShardStateByBeaconHeight returns the last block of a shard confirmed
+// by the beacon chain up to beaconHeight.
+func (blockchain *BlockChain) GetConfirmedShardStateByBeaconHeight(shardID byte, beaconHeight uint64) (*types.ShardState, error) {
+	return findConfirmedShardState(shardID, beaconHeight, blockchain.GetBeaconBlockByHeightV1)
+}
+
+// findConfirmedShardState walks back at most MaxConfirmedShardStateLookback beacon blocks
+// from beaconHeight to the last one which confirms a block of the shard.
+func findConfirmedShardState(
+	shardID byte,
+	beaconHeight uint64,
+	getBeaconBlock func(height uint64) (*types.BeaconBlock, error),
+) (*types.ShardState, error) {
+	lowestHeight := uint64(1)
+	if beaconHeight > MaxConfirmedShardStateLookback {
+		lowestHeight = beaconHeight - MaxConfirmedShardStateLookback + 1
+	}
+	for height := beaconHeight; height >= lowestHeight; height-- {
+		beaconBlock, err := getBeaconBlock(height)
+		if err != nil {
+			return nil, err
+		}
+		shardStates := beaconBlock.Body.ShardState[shardID]
+		if len(shardStates) > 0 {
+			return &shardStates[len(shardStates)-1], nil
+		}
+	}
+	return nil, fmt.Errorf("No block of shard %v is confirmed from beacon height %v to %v", shardID, lowestHeight, beaconHeight)
+}
+
+// GetTransactionStateDBByBeaconHeight returns the transaction state db of the shard block
+// confirmed by the beacon chain up to beaconHeight, together with the height of that block.
+func (blockchain *BlockChain) GetTransactionStateDBByBeaconHeight(shardID byte, beaconHeight uint64) (*statedb.StateDB, uint64, error) {
+	shardState, err := blockchain.GetConfirmedShardStateByBeaconHeight(shardID, beaconHeight)
+	if err != nil {
+		return nil, 0, err
+	}
+	db := blockchain.GetShardChainDatabase(shardID)
+	sRH, err := GetShardRootsHashByBlockHash(db, shardID, shardState.Hash)
+	if err != nil {
+		return nil, 0, err
+	}
+	stateDB, err := statedb.NewWithPrefixTrie(sRH.TransactionStateDBRootHash, statedb.NewDatabaseAccessWarper(db))
+	if err != nil {
+		return nil, 0, fmt.Errorf("Transaction State DB of shard %v not found, height %+v, error %+v", shardID, shardState.Height, err)
+	}
+	return stateDB, shardState.Height, nil
+}
+
 func (s *BlockChain) FetchNextCrossShard(fromSID, toSID int, currentHeight uint64) *NextCrossShardInfo {
 	b, err := rawdbv2.GetCrossShardNextHeight(s.GetBeaconChainDatabase(), byte(fromSID), byte(toSID), uint64(currentHeight))
 	if err != nil {
@@ -0,0 +1,59 @@
+package blockchain
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/blockchain/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindConfirmedShardState(t *testing.T) {
+	const tipHeight = MaxConfirmedShardStateLookback + 100
+	// shard 0 is confirmed at beacon heights 10 and 20 only, shard 1 at every height
+	confirmedHeights := map[uint64]bool{10: true, 20: true}
+	requested := 0
+	getBeaconBlock := func(height uint64) (*types.BeaconBlock, error) {
+		requested++
+		if height == 0 || height > tipHeight {
+			return nil, fmt.Errorf("Beacon Block Height %+v NOT FOUND", height)
+		}
+		block := &types.BeaconBlock{}
+		block.Body.ShardState = map[byte][]types.ShardState{
+			1: {{Height: height}},
+		}
+		if confirmedHeights[height] {
+			block.Body.ShardState[0] = []types.ShardState{{Height: height * 2}, {Height: height*2 + 1}}
+		}
+		return block, nil
+	}
+
+	tests := []struct {
+		name          string
+		shardID       byte
+		beaconHeight  uint64
+		wantHeight    uint64
+		wantErr       bool
+		wantRequested int
+	}{
+		{name: "confirmed at beacon height", shardID: 0, beaconHeight: 20, wantHeight: 41, wantRequested: 1},
+		{name: "confirmed below beacon height", shardID: 0, beaconHeight: 19, wantHeight: 21, wantRequested: 10},
+		{name: "every block confirms the shard", shardID: 1, beaconHeight: tipHeight, wantHeight: tipHeight, wantRequested: 1},
+		{name: "not confirmed down to genesis", shardID: 0, beaconHeight: 9, wantErr: true, wantRequested: 9},
+		{name: "not confirmed within the lookback", shardID: 0, beaconHeight: tipHeight, wantErr: true, wantRequested: MaxConfirmedShardStateLookback},
+		{name: "missing beacon block", shardID: 0, beaconHeight: tipHeight + 1, wantErr: true, wantRequested: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requested = 0
+			got, err := findConfirmedShardState(tt.shardID, tt.beaconHeight, getBeaconBlock)
+			assert.Equal(t, tt.wantRequested, requested)
+			if tt.wantErr {
+				assert.NotNil(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.wantHeight, got.Height)
+		})
+	}
+}
@@ -663,6 +663,46 @@ func (blockchain *BlockChain) TryGetAllOutputCoinsByKeyset(keyset *incognitokey.
 	return res, err
 }
 
+// TryGetAllOutputCoinsByKeysetAtHeight gets and decrypts all output coins of a tokenID given the keySet, as of the
+// shard block confirmed by the beacon chain up to beaconHeight. Coins v2 are read from the transaction state of that
+// block instead of the coin indexer, which only knows the latest state, so this query is slower.
+// Any coins that are failed to decrypt are skipped.
+func (blockchain *BlockChain) TryGetAllOutputCoinsByKeysetAtHeight(keyset *incognitokey.KeySet, shardID byte, tokenID *common.Hash, withVersion1 bool, beaconHeight uint64) ([]privacy.PlainCoin, error) {
+	if keyset == nil {
+		return nil, NewBlockChainError(GetListDecryptedOutputCoinsByKeysetError, fmt.Errorf("invalid key set, got keyset %+v", keyset))
+	}
+	transactionStateDB, shardHeight, err := blockchain.GetTransactionStateDBByBeaconHeight(shardID, beaconHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	var outCoins []privacy.Coin
+	if withVersion1 {
+		results, err := coinIndexer.QueryDbCoinVer1(keyset.PaymentAddress.Pk, tokenID, transactionStateDB)
+		if err != nil {
+			return nil, err
+		}
+		outCoins = append(outCoins, results...)
+	}
+	if keyset.OTAKey.GetOTASecretKey() != nil && keyset.OTAKey.GetPublicSpend() != nil {
+		filter := coinIndexer.GetCoinFilterByOTAKeyAndToken()
+		results, err := coinIndexer.QueryDbCoinVer2(keyset.OTAKey, tokenID, config.Param().CoinVersion2LowestHeight, shardHeight, transactionStateDB, filter)
+		if err != nil {
+			return nil, err
+		}
+		outCoins = append(outCoins, results...)
+	}
+
+	res := make([]privacy.PlainCoin, 0)
+	for _, outCoin := range outCoins {
+		decryptedOut, _ := DecryptOutputCoinByKey(transactionStateDB, outCoin, keyset, tokenID, shardID)
+		if decryptedOut != nil {
+			res = append(res, decryptedOut)
+		}
+	}
+	return res, nil
+}
+
 // CreateAndSaveTxViewPointFromBlock - fetch data from block, put into txviewpoint variable and save into db
 // still storage full data of commitments, serial number, snderivator to check double spend
 // this function only work for transaction transfer token/prv within shard
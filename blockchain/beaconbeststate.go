@@ -34,6 +34,7 @@ const (
 	BLOCKTIME_10                  = "blocktime10"
 	EPOCHV2                       = "epochparamv2"
 	INSTANT_FINALITY_FEATURE_V2   = "instantfinalityv2"
+	STATE_ROOTS_FEATURE           = "stateroots"
 )
 
 // BestState houses information about the current best block and other info
@@ -53,6 +54,14 @@ type BeaconRootHash struct {
 	SlashStateDBRootHash     common.Hash
 }
 
+// Hash is the hash of all state roots, committed by the next block header after feature stateroots
+func (bRH BeaconRootHash) Hash() common.Hash {
+	return common.HashH(append(append(append(bRH.ConsensusStateDBRootHash.GetBytes(),
+		bRH.FeatureStateDBRootHash.GetBytes()...),
+		bRH.RewardStateDBRootHash.GetBytes()...),
+		bRH.SlashStateDBRootHash.GetBytes()...))
+}
+
 type BeaconBestState struct {
 	BestBlockHash                    common.Hash          `json:"BestBlockHash"`         // The hash of the block.
 	PreviousBestBlockHash            common.Hash          `json:"PreviousBestBlockHash"` // The hash of the block.
@@ -196,6 +205,20 @@ func (beaconBestState *BeaconBestState) InitStateRootHash(bc *BlockChain) error
 	return nil
 }
 
+// parentStateRootsHash is the ParentStateRootsHash of the next block header, the hash of the state roots of this view
+// once feature stateroots is triggered, empty before
+func (beaconBestState *BeaconBestState) parentStateRootsHash() common.Hash {
+	if beaconBestState.TriggeredFeature[STATE_ROOTS_FEATURE] == 0 {
+		return common.Hash{}
+	}
+	return BeaconRootHash{
+		ConsensusStateDBRootHash: beaconBestState.ConsensusStateDBRootHash,
+		FeatureStateDBRootHash:   beaconBestState.FeatureStateDBRootHash,
+		RewardStateDBRootHash:    beaconBestState.RewardStateDBRootHash,
+		SlashStateDBRootHash:     beaconBestState.SlashStateDBRootHash,
+	}.Hash()
+}
+
 func (beaconBestState *BeaconBestState) MarshalJSON() ([]byte, error) {
 	type Alias BeaconBestState
 	b, err := json.Marshal(&struct {
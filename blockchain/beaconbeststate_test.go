@@ -1018,3 +1018,42 @@ func TestGetMaxCommitteeSize(t *testing.T) {
 		})
 	}
 }
+
+func TestBeaconBestState_parentStateRootsHash(t *testing.T) {
+	roots := BeaconRootHash{
+		ConsensusStateDBRootHash: common.HashH([]byte("consensus")),
+		FeatureStateDBRootHash:   common.HashH([]byte("feature")),
+		RewardStateDBRootHash:    common.HashH([]byte("reward")),
+		SlashStateDBRootHash:     common.HashH([]byte("slash")),
+	}
+	view := &BeaconBestState{
+		ConsensusStateDBRootHash: roots.ConsensusStateDBRootHash,
+		FeatureStateDBRootHash:   roots.FeatureStateDBRootHash,
+		RewardStateDBRootHash:    roots.RewardStateDBRootHash,
+		SlashStateDBRootHash:     roots.SlashStateDBRootHash,
+		TriggeredFeature:         map[string]uint64{},
+	}
+	header := types.BeaconHeader{Version: types.ADJUST_BLOCKTIME_VERSION, Height: 10}
+	legacyHash := header.Hash()
+
+	if got := view.parentStateRootsHash(); !got.IsZeroValue() {
+		t.Fatalf("Expect no parent state roots hash before feature %v, got %v", STATE_ROOTS_FEATURE, got)
+	}
+	header.ParentStateRootsHash = view.parentStateRootsHash()
+	if header.Hash() != legacyHash {
+		t.Fatal("Expect an empty parent state roots hash to keep the header hash")
+	}
+
+	view.TriggeredFeature[STATE_ROOTS_FEATURE] = 5
+	if got := view.parentStateRootsHash(); got != roots.Hash() {
+		t.Fatalf("Expect parent state roots hash %v, got %v", roots.Hash(), got)
+	}
+	header.ParentStateRootsHash = view.parentStateRootsHash()
+	if header.Hash() == legacyHash {
+		t.Fatal("Expect the parent state roots hash to be part of the header hash")
+	}
+	roots.SlashStateDBRootHash = common.Hash{}
+	if roots.Hash() == view.parentStateRootsHash() {
+		t.Fatal("Expect every state root to be part of the hash")
+	}
+}
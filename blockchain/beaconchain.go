@@ -48,6 +48,10 @@ func (chain *BeaconChain) GetAllViewHash() (res []common.Hash) {
 	return
 }
 
+func (chain *BeaconChain) GetInsertLock() *sync.Mutex {
+	return &chain.insertLock
+}
+
 func (chain *BeaconChain) GetDatabase() incdb.Database {
 	return chain.Blockchain.GetBeaconChainDatabase()
 }
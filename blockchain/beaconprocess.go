@@ -29,6 +29,7 @@ import (
 	portalprocessv3 "github.com/incognitochain/incognito-chain/portal/portalv3/portalprocess"
 	portalprocessv4 "github.com/incognitochain/incognito-chain/portal/portalv4/portalprocess"
 	"github.com/incognitochain/incognito-chain/pubsub"
+	"github.com/incognitochain/incognito-chain/tracing"
 	"github.com/incognitochain/incognito-chain/utils"
 )
 
@@ -178,7 +179,16 @@ func (blockchain *BlockChain) InsertBeaconBlock(beaconBlock *types.BeaconBlock,
 		return err2
 	}
 
+	blockchain.config.Server.InsertNewBeaconView(newBestState)
 	Logger.log.Infof("BEACON | Finish Insert new Beacon Block %+v, with hash %+v", beaconBlock.Header.Height, *beaconBlock.Hash())
+	if tracing.Enabled() {
+		for shardID, shardStates := range beaconBlock.Body.ShardState {
+			for _, shardState := range shardStates {
+				tracing.RecordBlockSpan(shardState.Hash, "beacon.confirm", time.Unix(shardState.ProposerTime, 0), nil,
+					tracing.Attr("shard", shardID), tracing.Attr("height", shardState.Height), tracing.Attr("beaconHeight", beaconBlock.Header.Height))
+			}
+		}
+	}
 
 	go blockchain.config.PubSubManager.PublishMessage(pubsub.NewMessage(pubsub.NewBeaconBlockTopic, beaconBlock))
 	go blockchain.config.PubSubManager.PublishMessage(pubsub.NewMessage(pubsub.BeaconBeststateTopic, newBestState))
@@ -238,6 +248,10 @@ func (blockchain *BlockChain) verifyPreProcessingBeaconBlock(beaconBlock *types.
 		return NewBlockChainError(WrongTimeslotError, fmt.Errorf("Propose timeslot must be greater than last propose timeslot (but get %v <= %v) ", curView.CalculateTimeSlot(beaconBlock.Header.ProposeTime), curView.CalculateTimeSlot(curView.BestBlock.GetProposeTime())))
 	}
 
+	if beaconBlock.Header.ParentStateRootsHash != curView.parentStateRootsHash() {
+		return NewBlockChainError(StateRootsHashError, fmt.Errorf("Expect parent state roots hash to be %+v but get %+v", curView.parentStateRootsHash(), beaconBlock.Header.ParentStateRootsHash))
+	}
+
 	if !verifyHashFromShardState(beaconBlock.Body.ShardState, beaconBlock.Header.ShardStateHash, curView.CommitteeStateVersion()) {
 		return NewBlockChainError(ShardStateHashError, fmt.Errorf("Expect shard state hash to be %+v", beaconBlock.Header.ShardStateHash))
 	}
@@ -346,6 +360,12 @@ func (blockchain *BlockChain) verifyPreProcessingBeaconBlockForSigning(curView *
 	enableFeatureInstructions := filterEnableFeatureInstruction(beaconBlock.Body.Instructions)
 	instructions = append(instructions, enableFeatureInstructions...)
 
+	slashEvidenceInstructions, err := curView.filterAndVerifySlashEvidenceInstructions(blockchain, beaconBlock.Body.Instructions)
+	if err != nil {
+		return NewBlockChainError(SlashEvidenceInstructionError, err)
+	}
+	instructions = append(instructions, slashEvidenceInstructions...)
+
 	if len(incurredInstructions) != 0 {
 		instructions = append(instructions, incurredInstructions...)
 	}
@@ -1019,6 +1039,12 @@ func (blockchain *BlockChain) processStoreBeaconBlock(
 	// execute, store token init instructions
 	blockchain.processTokenInitInstructions(newBestState.featureStateDB, beaconBlock)
 
+	// execute, store delegation instructions
+	err = blockchain.processDelegationInstructions(newBestState.consensusStateDB, beaconBlock, committeeChange)
+	if err != nil {
+		return err
+	}
+
 	// execute, store PDE instruction
 	pdeStateEnv := pdex.
 		NewStateEnvBuilder().
@@ -1295,6 +1321,15 @@ func (blockchain *BlockChain) processStoreBeaconBlock(
 			blockchain.GetBeaconChainDatabase().RemoveBackup(fmt.Sprintf("../../backup/beacon/%d", newBestState.Epoch))
 			return nil
 		}
+		if blockchain.config.LTCChain != nil {
+			err = blockchain.config.LTCChain.BackupDB(fmt.Sprintf("../backup/ltc/%d", newBestState.Epoch))
+			if err != nil {
+				blockchain.config.LTCChain.RemoveBackup(fmt.Sprintf("../backup/ltc/%d", newBestState.Epoch))
+				blockchain.config.BTCChain.RemoveBackup(fmt.Sprintf("../backup/btc/%d", newBestState.Epoch))
+				blockchain.GetBeaconChainDatabase().RemoveBackup(fmt.Sprintf("../../backup/beacon/%d", newBestState.Epoch))
+				return nil
+			}
+		}
 
 	}
 	return nil
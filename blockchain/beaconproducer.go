@@ -86,6 +86,7 @@ func (blockchain *BlockChain) NewBlockBeacon(
 			newBeaconBlock.Header.ProcessBridgeFromBlock = &processBridgeFromBlock
 		}
 	}
+	newBeaconBlock.Header.ParentStateRootsHash = copiedCurView.parentStateRootsHash()
 
 	BLogger.log.Infof("Producing block: %d (epoch %d)", newBeaconBlock.Header.Height, newBeaconBlock.Header.Epoch)
 	//=====END Build Header Essential Data=====
@@ -110,6 +111,9 @@ func (blockchain *BlockChain) NewBlockBeacon(
 	enableFeatureInstructions, _ := copiedCurView.generateEnableFeatureInstructions()
 	instructions = append(instructions, enableFeatureInstructions...)
 
+	slashEvidenceInstructions := blockchain.generateSlashEvidenceInstructions(copiedCurView)
+	instructions = append(instructions, slashEvidenceInstructions...)
+
 	newBeaconBlock.Body = types.NewBeaconBody(shardStates, instructions)
 
 	// Process new block with new view
@@ -384,6 +388,19 @@ func (blockchain *BlockChain) GenerateBeaconBlockBody(
 		return nil, nil, err
 	}
 
+	// build delegation instructions, pools reaching the staking amount are staked with the shard stakers
+	delegationInsts, err := blockchain.buildDelegationInstructions(
+		curView,
+		statefulActionsByShardID,
+		newBeaconBlock.Header.Height,
+		shardInstruction,
+		validStakePublicKeys,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	statefulInsts = append(statefulInsts, delegationInsts...)
+
 	// build bridge unshielding instruction
 	retrievedShardBlockForBridge := allShardBlocks
 	retrievedShardBlockForBridgeAgg := map[uint64]map[byte][]*types.ShardBlock{
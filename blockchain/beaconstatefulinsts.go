@@ -62,6 +62,7 @@ func collectStatefulActions(
 			metadata.PortalUnlockOverRateCollateralsMeta,
 			metadata.RelayingBNBHeaderMeta,
 			metadata.RelayingBTCHeaderMeta,
+			metadata.RelayingLTCHeaderMeta,
 			metadata.PortalCustodianWithdrawRequestMeta,
 			metadata.PortalRedeemRequestMeta,
 			metadata.PortalRequestUnlockCollateralMeta,
@@ -89,7 +90,10 @@ func collectStatefulActions(
 			metadataCommon.BridgeAggModifyParamMeta,
 			metadataCommon.BridgeAggConvertTokenToUnifiedTokenRequestMeta,
 			metadataCommon.IssuingUnifiedTokenRequestMeta,
-			metadataCommon.BurningUnifiedTokenRequestMeta:
+			metadataCommon.BurningUnifiedTokenRequestMeta,
+			metadata.CreateDelegationPoolMeta,
+			metadata.DelegateStakingMeta,
+			metadata.UnbondDelegationMeta:
 			statefulInsts = append(statefulInsts, inst)
 
 		default:
@@ -140,7 +144,7 @@ func (blockchain *BlockChain) buildStatefulInstructions(
 		Logger.log.Error(err)
 		return utils.EmptyStringMatrix, err
 	}
-	relayingHeaderState, err := portalrelaying.InitRelayingHeaderChainStateFromDB(blockchain.GetBNBHeaderChain(), blockchain.GetBTCHeaderChain())
+	relayingHeaderState, err := portalrelaying.InitRelayingHeaderChainStateFromDB(blockchain.GetBNBHeaderChain(), blockchain.GetBTCHeaderChain(), blockchain.GetLTCHeaderChain())
 	if err != nil {
 		Logger.log.Error(err)
 	}
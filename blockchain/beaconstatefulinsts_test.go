@@ -42,7 +42,7 @@ func TestBlockChain_buildStatefulInstructions(t *testing.T) {
 	config.Param().EthContractAddressStr = "0x7bebc8445c6223b41b7bb4b0ae9742e2fd2f47f3"
 	config.AbortUnifiedToken()
 	common.MaxShardNumber = 8
-	view := multiview.NewMultiView()
+	view := multiview.NewBeaconMultiView()
 	view.AddView(&BeaconBestState{
 		BestBlock: types.BeaconBlock{
 			Header: types.BeaconHeader{
@@ -50,13 +50,14 @@ func TestBlockChain_buildStatefulInstructions(t *testing.T) {
 			},
 		},
 	})
-	temp := map[uint64]map[common.Hash]map[uint]config.Vault{
+	incTokenID, _ := common.Hash{}.NewHashFromStr("375825bf838527610102c6943282642826901937679d8df5b5634d43d54a5769")
+	temp := map[uint64]map[common.Hash]map[common.Hash]config.Vault{
 		10: {
-			common.PRVCoinID: map[uint]config.Vault{
-				1: {
+			common.PRVCoinID: map[common.Hash]config.Vault{
+				*incTokenID: {
 					ExternalDecimal: 9,
 					ExternalTokenID: "0x0000000000000000000000000000000000000001",
-					IncTokenID:      "375825bf838527610102c6943282642826901937679d8df5b5634d43d54a5769",
+					NetworkID:       common.ETHNetworkID,
 				},
 			},
 		},
@@ -80,7 +81,7 @@ func TestBlockChain_buildStatefulInstructions(t *testing.T) {
 		rewardForCustodianByEpoch map[common.Hash]uint64
 		portalParams              portal.PortalParams
 		shardStates               map[byte][]types.ShardState
-		allPdexv3Txs              map[byte][]metadata.Transaction
+		allPdexv3Txs              map[uint]map[byte][]metadata.Transaction
 		pdexReward                uint64
 	}
 	tests := []struct {
@@ -91,7 +92,7 @@ func TestBlockChain_buildStatefulInstructions(t *testing.T) {
 		wantErr bool
 	}{
 		{
-			name: "addToken + shield pToken",
+			name: "shield pToken",
 			fields: fields{
 				ShardChain: []*ShardChain{
 					{
@@ -123,11 +124,11 @@ func TestBlockChain_buildStatefulInstructions(t *testing.T) {
 			args: args{
 				beaconHeight: 10,
 				beaconBestState: &BeaconBestState{
-					pdeStates:      map[uint]pdex.State{},
-					featureStateDB: sDB,
-					portalStateV3:  &portalprocessv3.CurrentPortalState{},
-					portalStateV4:  &portalprocessv4.CurrentPortalStateV4{},
-					bridgeAggState: bridgeagg.NewState(),
+					pdeStates:        map[uint]pdex.State{},
+					featureStateDB:   sDB,
+					portalStateV3:    &portalprocessv3.CurrentPortalState{},
+					portalStateV4:    &portalprocessv4.CurrentPortalStateV4{},
+					bridgeAggManager: bridgeagg.NewManager(),
 				},
 				portalParams: portal.PortalParams{
 					RelayingParam:  portalrelaying.RelayingParams{},
@@ -147,11 +148,8 @@ func TestBlockChain_buildStatefulInstructions(t *testing.T) {
 			},
 			want: [][]string{
 				{
-					"348",
-					"eyJOZXdMaXN0VG9rZW5zIjp7IjAwMDAwMDAwMDAwMDAwMDAwMDAwMDAwMDAwMDAwMDAwMDAwMDAwMDAwMDAwMDAwMDAwMDAwMDAwMDAwMDAwMDQiOnsiMSI6eyJFeHRlcm5hbERlY2ltYWwiOjksIkV4dGVybmFsVG9rZW5JRCI6IjB4MDAwMDAwMDAwMDAwMDAwMDAwMDAwMDAwMDAwMDAwMDAwMDAwMDAwMSIsIkluY1Rva2VuSUQiOiIzNzU4MjViZjgzODUyNzYxMDEwMmM2OTQzMjgyNjQyODI2OTAxOTM3Njc5ZDhkZjViNTYzNGQ0M2Q1NGE1NzY5In19fX0=",
-				},
-				{
-					"80", "0", "rejected", "13e56a4572aa7eeb5e7f658a8af036ed3ff8a1285452f30c741f4733bc8c7f9c",
+					"80", "0", "accepted",
+					"eyJzaGFyZElkIjowLCJpc3N1aW5nQW1vdW50Ijo1MDAwMCwicmVjZWl2ZXJBZGRyU3RyIjoiMTJzdmZrUDZ3NVVESkRTQ3dxSDk3OFB2cWlxQnhLbVVuQTllbTl5QVlXWUpWUnY3d3VYWTFxaGhZcFBBbTRCRHoybUxiRnJSbWRLM3lSaG5UcUpDWlhLSFVtb2k3TlY4M0hDSDJZRnBjdEhOYURka1NpUXNoc2p3MlVGVXV3ZEV2Y2lkZ2FLbUYzVkpwWTVmOFJkTiIsImluY1Rva2VuSWQiOiIzNzU4MjViZjgzODUyNzYxMDEwMmM2OTQzMjgyNjQyODI2OTAxOTM3Njc5ZDhkZjViNTYzNGQ0M2Q1NGE1NzY5IiwidHhSZXFJZCI6IjEzZTU2YTQ1NzJhYTdlZWI1ZTdmNjU4YThhZjAzNmVkM2ZmOGExMjg1NDUyZjMwYzc0MWY0NzMzYmM4YzdmOWMiLCJ1bmlxRVRIVHgiOiJLSThLanB3bXMvZWdsYytxSHptcFlWQ1hRUEdMYnRneG0rWVgxYkdBWkVBeiIsImV4dGVybmFsVG9rZW5JZCI6IkFBQUFBQUFBQUFBQUFBQUFBQUFBQUFBQUFBQT0ifQ==",
 				},
 			},
 			wantErr: false,
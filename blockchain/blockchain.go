@@ -1,11 +1,8 @@
 package blockchain
 
 import (
-	"bytes"
-	"encoding/binary"
 	"encoding/json"
 	"fmt"
-	"io"
 	"io/ioutil"
 	"strconv"
 	"sync"
@@ -51,11 +48,14 @@ type BlockChain struct {
 	beaconViewCache             *lru.Cache
 	committeeByEpochCache       *lru.Cache
 	committeeByEpochProcessLock sync.Mutex
+
+	incomingCoinSignal chan struct{}
 }
 
 // Config is a descriptor which specifies the blockchain instblockchain/beaconstatefulinsts.goance configuration.
 type Config struct {
 	BTCChain      *btcrelaying.BlockChain
+	LTCChain      *btcrelaying.BlockChain
 	BNBChainState *bnbrelaying.BNBChainState
 	DataBase      map[int]incdb.Database
 	MemCache      *memcache.MemoryCache
@@ -76,6 +76,8 @@ type Config struct {
 	OutCoinByOTAKeyDb *incdb.Database
 	IndexerWorkers    int64
 	IndexerToken      string
+	IndexerWebhook    string
+	WebhookRetries    int
 	PoolManager       *txpool.PoolManager
 
 	relayShardLck sync.Mutex
@@ -147,6 +149,13 @@ func (blockchain *BlockChain) Init(config *Config) error {
 			cfg := &coinIndexer.IndexerInitialConfig{TxDbs: txDbs, BestBlocks: bestBlocks}
 			go outcoinIndexer.Start(cfg)
 		}
+		if len(config.IndexerWebhook) > 0 {
+			webhook := coinIndexer.NewIncomingCoinWebhook(*config.OutCoinByOTAKeyDb, config.IndexerWebhook, config.WebhookRetries)
+			outcoinIndexer.SetWebhook(webhook)
+			go webhook.Start()
+		}
+		blockchain.incomingCoinSignal = make(chan struct{}, 1)
+		go blockchain.runIncomingCoinNotifier()
 	}
 	return nil
 }
@@ -507,99 +516,6 @@ func (blockchain *BlockChain) InitChannelBlockchain(cRemovedTxs chan metadata.Tr
 // -------------- End of Blockchain retriever's implementation --------------
 
 // -------------- Start of Blockchain BackUp And Restore --------------
-func CalculateNumberOfByteToRead(amountBytes int) []byte {
-	var result = make([]byte, 8)
-	binary.LittleEndian.PutUint32(result, uint32(amountBytes))
-	return result
-}
-
-func GetNumberOfByteToRead(value []byte) (int, error) {
-	var result uint32
-	err := binary.Read(bytes.NewBuffer(value), binary.LittleEndian, &result)
-	if err != nil {
-		return -1, err
-	}
-	return int(result), nil
-}
-
-func (blockchain *BlockChain) BackupShardChain(writer io.Writer, shardID byte) error {
-	bestStateBytes, err := rawdbv2.GetShardBestState(blockchain.GetShardChainDatabase(shardID), shardID)
-	if err != nil {
-		return err
-	}
-	shardBestState := &ShardBestState{}
-	err = json.Unmarshal(bestStateBytes, shardBestState)
-	bestShardHeight := shardBestState.ShardHeight
-	var i uint64
-	for i = 1; i < bestShardHeight; i++ {
-		shardBlocks, err := blockchain.GetShardBlockByHeight(i, shardID)
-		if err != nil {
-			return err
-		}
-		var shardBlock *types.ShardBlock
-		for _, v := range shardBlocks {
-			shardBlock = v
-		}
-		data, err := json.Marshal(shardBlocks)
-		if err != nil {
-			return err
-		}
-		_, err = writer.Write(CalculateNumberOfByteToRead(len(data)))
-		if err != nil {
-			return err
-		}
-		_, err = writer.Write(data)
-		if err != nil {
-			return err
-		}
-		if i%100 == 0 {
-			Logger.log.Infof("Backup Shard %+v Block %+v", shardBlock.Header.ShardID, i)
-		}
-		if i == bestShardHeight-1 {
-			Logger.log.Infof("Finish Backup Shard %+v with Block %+v", shardBlock.Header.ShardID, i)
-		}
-	}
-	return nil
-}
-
-func (blockchain *BlockChain) BackupBeaconChain(writer io.Writer) error {
-	bestStateBytes, err := rawdbv2.GetBeaconViews(blockchain.GetBeaconChainDatabase())
-	if err != nil {
-		return err
-	}
-	beaconBestState := &BeaconBestState{}
-	err = json.Unmarshal(bestStateBytes, beaconBestState)
-	bestBeaconHeight := beaconBestState.BeaconHeight
-	var i uint64
-	for i = 1; i < bestBeaconHeight; i++ {
-		beaconBlocks, err := blockchain.GetBeaconBlockByHeight(i)
-		if err != nil {
-			return err
-		}
-		beaconBlock := beaconBlocks[0]
-		data, err := json.Marshal(beaconBlock)
-		if err != nil {
-			return err
-		}
-		numOfByteToRead := CalculateNumberOfByteToRead(len(data))
-		_, err = writer.Write(numOfByteToRead)
-		if err != nil {
-			return err
-		}
-		_, err = writer.Write(data)
-		if err != nil {
-			return err
-		}
-		if i%100 == 0 {
-			Logger.log.Infof("Backup Beacon Block %+v", i)
-		}
-		if i == bestBeaconHeight-1 {
-			Logger.log.Infof("Finish Backup Beacon with Block %+v", i)
-		}
-	}
-	return nil
-}
-
 //TODO:
 // current implement: backup all view data
 // Optimize: backup view -> backup view hash instead of view
@@ -1249,6 +1165,20 @@ func (blockchain *BlockChain) GetPoolManager() *txpool.PoolManager {
 	return blockchain.config.PoolManager
 }
 
+// GetShardViewsForTxPool returns the views to validate the txs of the pool of a shard with,
+// as the views used to filter the pool when the best view of the shard changes.
+func (blockchain *BlockChain) GetShardViewsForTxPool(shardID byte) (metadata.ChainRetriever, metadata.ShardViewRetriever, metadata.BeaconViewRetriever, error) {
+	if int(shardID) >= len(blockchain.ShardChain) {
+		return nil, nil, nil, fmt.Errorf("Shard %v is not active", shardID)
+	}
+	sView := blockchain.ShardChain[shardID].GetBestState()
+	bcView, err := blockchain.GetBeaconViewStateDataFromBlockHash(sView.GetBeaconHash(), true, false, false)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return blockchain, sView, bcView, nil
+}
+
 func (blockchain *BlockChain) UsingNewPool() bool {
 	return blockchain.config.usingNewPool
 }
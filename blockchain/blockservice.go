@@ -64,6 +64,13 @@ func (bc *BlockChain) GetBlockByHash(
 			return nil, err
 		}
 		return types.CreateCrossShardBlock(blk, tocID)
+	case proto.BlkType_StateNode:
+		return bc.GetStateTrieNode(fromcID, *hash)
+	case proto.BlkType_FinalView:
+		if fromcID == common.BeaconChainSyncID {
+			return bc.GetBeaconFinalView()
+		}
+		return bc.GetShardFinalView(fromcID)
 	default:
 		return nil, errors.Errorf("Invalid block type")
 	}
@@ -128,7 +135,16 @@ func (bc *BlockChain) streamBlkByHash(
 	req *proto.BlockByHashRequest,
 	blkCh chan interface{},
 ) {
-	for _, blkHashByte := range req.GetHashes() {
+	hashes := req.GetHashes()
+	if proto.IsStateSyncType(req.Type) {
+		var ok bool
+		if hashes, ok = proto.StateSyncHashes(req); !ok {
+			Logger.log.Errorf("[stream] Netsync cannot serve snap sync request of another version, uuid %v", req.UUID)
+			close(blkCh)
+			return
+		}
+	}
+	for _, blkHashByte := range hashes {
 		blkHash := &common.Hash{}
 		blkHash.SetBytes(blkHashByte)
 		blk, err := bc.GetBlockByHash(req.Type, blkHash, byte(req.From), byte(req.To))
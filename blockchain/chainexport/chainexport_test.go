@@ -0,0 +1,202 @@
+package chainexport
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testBlock(height uint64) (common.Hash, []byte) {
+	data := []byte(fmt.Sprintf(`{"Height":%v}`, height))
+	return common.HashH(data), data
+}
+
+func writeBlocks(t *testing.T, w *Writer, toHeight uint64) {
+	for height := w.NextHeight(); height <= toHeight; height++ {
+		hash, data := testBlock(height)
+		require.NoError(t, w.WriteBlock(height, hash, data))
+	}
+}
+
+func readAll(r io.Reader) (*Reader, []*Block, error) {
+	reader, err := NewReader(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	blocks := []*Block{}
+	for {
+		block, err := reader.Next()
+		if err == io.EOF {
+			return reader, blocks, nil
+		}
+		if err != nil {
+			return reader, blocks, err
+		}
+		blocks = append(blocks, block)
+	}
+}
+
+func TestWriteAndRead(t *testing.T) {
+	buf := &bytes.Buffer{}
+	w, err := NewWriter(buf, common.BeaconChainID, 2)
+	require.NoError(t, err)
+	writeBlocks(t, w, 11)
+	require.NoError(t, w.Close())
+
+	reader, blocks, err := readAll(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	assert.Equal(t, Header{Version: FormatVersion, ChainID: common.BeaconChainID, FromHeight: 2}, reader.Header())
+	require.Len(t, blocks, 10)
+	for i, block := range blocks {
+		hash, data := testBlock(uint64(i + 2))
+		assert.Equal(t, uint64(i+2), block.Height)
+		assert.Equal(t, hash, block.Hash)
+		assert.Equal(t, data, block.Data)
+	}
+	require.NotNil(t, reader.Summary())
+	assert.Equal(t, uint64(10), reader.Summary().Count)
+	assert.Equal(t, uint64(11), reader.Summary().ToHeight)
+}
+
+func TestWriteUnexpectedHeight(t *testing.T) {
+	w, err := NewWriter(&bytes.Buffer{}, 0, 1)
+	require.NoError(t, err)
+	hash, data := testBlock(2)
+	err = w.WriteBlock(2, hash, data)
+	assert.Equal(t, ErrUnexpectedHeight, errors.Cause(err))
+}
+
+func TestReadInvalidFile(t *testing.T) {
+	_, err := NewReader(bytes.NewReader([]byte("NOTCHAIN0000000000000000")))
+	assert.Equal(t, ErrInvalidMagic, err)
+
+	buf := &bytes.Buffer{}
+	_, err = NewWriter(buf, 0, 1)
+	require.NoError(t, err)
+	raw := buf.Bytes()
+	raw[len(Magic)] = byte(FormatVersion + 1)
+	_, err = NewReader(bytes.NewReader(raw))
+	assert.Equal(t, ErrUnsupportedVersion, errors.Cause(err))
+}
+
+func TestReadDamagedFile(t *testing.T) {
+	buf := &bytes.Buffer{}
+	w, err := NewWriter(buf, 3, 1)
+	require.NoError(t, err)
+	writeBlocks(t, w, 5)
+	require.NoError(t, w.Close())
+	raw := buf.Bytes()
+
+	// flip one byte of the data of the first block
+	damaged := append([]byte{}, raw...)
+	damaged[headerSize+blockHeaderSize] ^= 0xff
+	_, blocks, err := readAll(bytes.NewReader(damaged))
+	assert.Equal(t, ErrChecksumMismatch, errors.Cause(err))
+	assert.Len(t, blocks, 0)
+
+	// cut the end record
+	_, blocks, err = readAll(bytes.NewReader(raw[:len(raw)-endRecordSize]))
+	assert.Equal(t, ErrTruncated, errors.Cause(err))
+	assert.Len(t, blocks, 5)
+
+	// change the digest of the end record
+	damaged = append([]byte{}, raw...)
+	damaged[len(damaged)-1] ^= 0xff
+	_, _, err = readAll(bytes.NewReader(damaged))
+	assert.Equal(t, ErrDigestMismatch, err)
+}
+
+func TestResume(t *testing.T) {
+	dir, err := ioutil.TempDir("", "chainexport")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "export")
+
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	w, err := NewWriter(f, 1, 1)
+	require.NoError(t, err)
+	writeBlocks(t, w, 4)
+	// interrupt the export in the middle of the fifth record
+	hash, data := testBlock(5)
+	record := &bytes.Buffer{}
+	partial, err := NewWriter(record, 1, 5)
+	require.NoError(t, err)
+	require.NoError(t, partial.WriteBlock(5, hash, data))
+	_, err = f.Write(record.Bytes()[headerSize : headerSize+blockHeaderSize+2])
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	f, err = os.OpenFile(path, os.O_RDWR, 0)
+	require.NoError(t, err)
+	w, err = Resume(f)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(5), w.NextHeight())
+	writeBlocks(t, w, 8)
+	require.NoError(t, w.Close())
+	require.NoError(t, f.Close())
+
+	// a complete export is extended with the blocks finalized later
+	f, err = os.OpenFile(path, os.O_RDWR, 0)
+	require.NoError(t, err)
+	w, err = Resume(f)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(9), w.NextHeight())
+	writeBlocks(t, w, 10)
+	require.NoError(t, w.Close())
+	require.NoError(t, f.Close())
+
+	raw, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	reader, blocks, err := readAll(bytes.NewReader(raw))
+	require.NoError(t, err)
+	assert.Len(t, blocks, 10)
+	assert.Equal(t, uint64(10), reader.Summary().ToHeight)
+}
+
+func TestResumeDamagedFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "chainexport")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	buf := &bytes.Buffer{}
+	w, err := NewWriter(buf, 1, 1)
+	require.NoError(t, err)
+	writeBlocks(t, w, 4)
+	raw := buf.Bytes()
+	recordSize := (len(raw) - headerSize) / 4
+
+	resume := func(name string, content []byte) (*Writer, error) {
+		path := filepath.Join(dir, name)
+		require.NoError(t, ioutil.WriteFile(path, content, 0600))
+		f, err := os.OpenFile(path, os.O_RDWR, 0)
+		require.NoError(t, err)
+		defer f.Close()
+		return Resume(f)
+	}
+
+	// the last record is fully written but its data is not, the torn record is written again
+	torn := append([]byte{}, raw...)
+	torn[len(torn)-common.HashSize-1] ^= 0xff
+	w, err = resume("torn", torn)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(4), w.NextHeight())
+
+	// a damaged record in the middle of the file must not drop the blocks after it
+	damaged := append([]byte{}, raw...)
+	damaged[headerSize+recordSize+blockHeaderSize] ^= 0xff
+	_, err = resume("damaged", damaged)
+	assert.Equal(t, ErrChecksumMismatch, errors.Cause(err))
+	info, err := os.Stat(filepath.Join(dir, "damaged"))
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(raw)), info.Size())
+}
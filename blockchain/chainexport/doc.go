@@ -0,0 +1,45 @@
+/*
+Package chainexport implements the versioned file format used to export the
+finalized blocks of one chain (the beacon chain or a shard chain) and to import
+them into another node without networking.
+
+An export file is a header followed by block records and an end record. All
+integers are little endian and all hashes are SHA3-256, as in common.HashH.
+
+	header:
+	  magic       [8]byte   "INCCHAIN"
+	  version     uint16    FormatVersion
+	  chainID     int32     -1 for the beacon chain, the shard ID otherwise
+	  fromHeight  uint64    height of the first block record
+
+	block record:
+	  kind        byte      'B'
+	  height      uint64    fromHeight for the first record, then increased by one
+	  hash        [32]byte  hash of the block, as returned by its Hash method
+	  length      uint32    length of data, at most MaxBlockSize
+	  data        []byte    JSON encoding of the block, see below
+	  checksum    [32]byte  hash of the record from kind to the end of data
+
+	end record:
+	  kind        byte      'E'
+	  count       uint64    number of block records
+	  toHeight    uint64    height of the last block record, fromHeight-1 if there is none
+	  digest      [32]byte  hash of the concatenated hashes of all block records
+
+The data of a beacon block record is the JSON encoding of the block. The data of
+a shard block record is the JSON object {"Block": ..., "CrossShardBlocks": [...]}
+holding the block and the cross shard blocks of its cross shard transactions, so
+that the importer can validate the block without requesting them from peers.
+
+A file without end record is an interrupted export: Reader reports it as
+ErrTruncated once all complete records were read, and Resume continues it from
+the last complete record. A last record failing its checksum is a torn write and
+is also written again, but Resume fails on a damaged record followed by other
+records rather than dropping them. Resume also reopens a complete file to append
+the blocks that were finalized after it was written.
+
+The checksums only detect damaged files. The block hashes bind each record to
+the chain, so the importer must still check that the decoded block hashes to
+the recorded hash, links to its parent and passes the usual block validation.
+*/
+package chainexport
@@ -0,0 +1,57 @@
+package chainexport
+
+import (
+	"errors"
+
+	"github.com/incognitochain/incognito-chain/common"
+)
+
+const (
+	// Magic is the first bytes of every export file.
+	Magic = "INCCHAIN"
+	// FormatVersion is the version of the format written by Writer. Version 2 records
+	// the cross shard blocks included by a shard block with the block.
+	FormatVersion uint16 = 2
+	// MaxBlockSize bounds the data of a block record, so that a damaged length
+	// can not make a reader allocate unbounded memory.
+	MaxBlockSize = 64 << 20
+
+	recordBlock byte = 'B'
+	recordEnd   byte = 'E'
+
+	headerSize      = len(Magic) + 2 + 4 + 8
+	blockHeaderSize = 1 + 8 + common.HashSize + 4
+	endRecordSize   = 1 + 8 + 8 + common.HashSize
+)
+
+var (
+	ErrInvalidMagic       = errors.New("not a chain export file")
+	ErrUnsupportedVersion = errors.New("unsupported chain export version")
+	ErrInvalidRecord      = errors.New("invalid chain export record")
+	ErrChecksumMismatch   = errors.New("chain export record checksum mismatch")
+	ErrUnexpectedHeight   = errors.New("chain export record height is not consecutive")
+	ErrDigestMismatch     = errors.New("chain export digest mismatch")
+	ErrTruncated          = errors.New("chain export file is truncated")
+	ErrClosed             = errors.New("chain export writer is closed")
+)
+
+// Header describes the blocks of an export file.
+type Header struct {
+	Version    uint16
+	ChainID    int
+	FromHeight uint64
+}
+
+// Block is one block record of an export file.
+type Block struct {
+	Height uint64
+	Hash   common.Hash
+	Data   []byte
+}
+
+// Summary is the content of the end record of an export file.
+type Summary struct {
+	Count    uint64
+	ToHeight uint64
+	Digest   common.Hash
+}
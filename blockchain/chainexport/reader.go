@@ -0,0 +1,160 @@
+package chainexport
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"hash"
+	"io"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/sha3"
+)
+
+// countingReader counts the bytes consumed from the underlying reader.
+type countingReader struct {
+	r *bufio.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// Reader reads and verifies an export file record by record.
+type Reader struct {
+	r       *countingReader
+	header  Header
+	next    uint64
+	count   uint64
+	digest  hash.Hash
+	summary *Summary
+	// offset is the size of the header and the complete block records read so far.
+	offset int64
+}
+
+// NewReader reads the header of an export file.
+func NewReader(r io.Reader) (*Reader, error) {
+	reader := &Reader{
+		r:      &countingReader{r: bufio.NewReader(r)},
+		digest: sha3.New256(),
+	}
+	buf := make([]byte, headerSize)
+	if _, err := io.ReadFull(reader.r, buf); err != nil {
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return nil, ErrInvalidMagic
+		}
+		return nil, err
+	}
+	if string(buf[:len(Magic)]) != Magic {
+		return nil, ErrInvalidMagic
+	}
+	buf = buf[len(Magic):]
+	reader.header.Version = binary.LittleEndian.Uint16(buf)
+	if reader.header.Version != FormatVersion {
+		return nil, errors.Wrapf(ErrUnsupportedVersion, "version %v", reader.header.Version)
+	}
+	reader.header.ChainID = int(int32(binary.LittleEndian.Uint32(buf[2:])))
+	reader.header.FromHeight = binary.LittleEndian.Uint64(buf[6:])
+	reader.next = reader.header.FromHeight
+	reader.offset = reader.r.n
+	return reader, nil
+}
+
+func (r *Reader) Header() Header {
+	return r.header
+}
+
+// NextHeight is the height expected for the next block record.
+func (r *Reader) NextHeight() uint64 {
+	return r.next
+}
+
+// Summary returns the end record, nil until Next has returned io.EOF.
+func (r *Reader) Summary() *Summary {
+	return r.summary
+}
+
+// Next returns the next block record once its checksum and height are verified.
+// It returns io.EOF after the end record was read and matched the block records.
+func (r *Reader) Next() (*Block, error) {
+	if r.summary != nil {
+		return nil, io.EOF
+	}
+	kind := make([]byte, 1)
+	if _, err := io.ReadFull(r.r, kind); err != nil {
+		return nil, r.readError(err)
+	}
+	switch kind[0] {
+	case recordBlock:
+		return r.readBlock()
+	case recordEnd:
+		return nil, r.readEnd()
+	default:
+		return nil, errors.Wrapf(ErrInvalidRecord, "record kind %v at height %v", kind[0], r.next)
+	}
+}
+
+func (r *Reader) readBlock() (*Block, error) {
+	buf := make([]byte, blockHeaderSize)
+	buf[0] = recordBlock
+	if _, err := io.ReadFull(r.r, buf[1:]); err != nil {
+		return nil, r.readError(err)
+	}
+	block := &Block{Height: binary.LittleEndian.Uint64(buf[1:])}
+	copy(block.Hash[:], buf[9:9+common.HashSize])
+	length := binary.LittleEndian.Uint32(buf[9+common.HashSize:])
+	if length > MaxBlockSize {
+		return nil, errors.Wrapf(ErrInvalidRecord, "block size %v at height %v", length, block.Height)
+	}
+	data := make([]byte, int(length)+common.HashSize)
+	if _, err := io.ReadFull(r.r, data); err != nil {
+		return nil, r.readError(err)
+	}
+	block.Data = data[:length]
+	checksum := sha3.New256()
+	checksum.Write(buf)
+	checksum.Write(block.Data)
+	if !bytes.Equal(checksum.Sum(nil), data[length:]) {
+		return nil, errors.Wrapf(ErrChecksumMismatch, "height %v", block.Height)
+	}
+	if block.Height != r.next {
+		return nil, errors.Wrapf(ErrUnexpectedHeight, "expect %v got %v", r.next, block.Height)
+	}
+	r.digest.Write(block.Hash[:])
+	r.count++
+	r.next++
+	r.offset = r.r.n
+	return block, nil
+}
+
+func (r *Reader) readEnd() error {
+	buf := make([]byte, endRecordSize-1)
+	if _, err := io.ReadFull(r.r, buf); err != nil {
+		return r.readError(err)
+	}
+	summary := &Summary{
+		Count:    binary.LittleEndian.Uint64(buf),
+		ToHeight: binary.LittleEndian.Uint64(buf[8:]),
+	}
+	copy(summary.Digest[:], buf[16:])
+	if summary.Count != r.count || summary.ToHeight != r.next-1 {
+		return errors.Wrapf(ErrInvalidRecord, "end record has %v blocks to height %v, read %v blocks to height %v",
+			summary.Count, summary.ToHeight, r.count, r.next-1)
+	}
+	if !bytes.Equal(r.digest.Sum(nil), summary.Digest[:]) {
+		return ErrDigestMismatch
+	}
+	r.summary = summary
+	return io.EOF
+}
+
+func (r *Reader) readError(err error) error {
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		return errors.Wrapf(ErrTruncated, "after height %v", r.next-1)
+	}
+	return err
+}
@@ -0,0 +1,150 @@
+package chainexport
+
+import (
+	"encoding/binary"
+	"hash"
+	"io"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/sha3"
+)
+
+// File is the subset of *os.File needed to resume an export.
+type File interface {
+	io.ReadWriteSeeker
+	Truncate(size int64) error
+}
+
+// Writer writes the block records of one chain to an export file. The end
+// record is only written by Close, so an interrupted export can be resumed.
+type Writer struct {
+	w      io.Writer
+	header Header
+	next   uint64
+	count  uint64
+	digest hash.Hash
+	closed bool
+}
+
+// NewWriter writes the header of an export file whose first block is at fromHeight.
+func NewWriter(w io.Writer, chainID int, fromHeight uint64) (*Writer, error) {
+	writer := &Writer{
+		w: w,
+		header: Header{
+			Version:    FormatVersion,
+			ChainID:    chainID,
+			FromHeight: fromHeight,
+		},
+		next:   fromHeight,
+		digest: sha3.New256(),
+	}
+	buf := make([]byte, headerSize)
+	copy(buf, Magic)
+	binary.LittleEndian.PutUint16(buf[len(Magic):], FormatVersion)
+	binary.LittleEndian.PutUint32(buf[len(Magic)+2:], uint32(int32(chainID)))
+	binary.LittleEndian.PutUint64(buf[len(Magic)+6:], fromHeight)
+	if _, err := w.Write(buf); err != nil {
+		return nil, err
+	}
+	return writer, nil
+}
+
+// Resume reopens an export file to append blocks after its last complete block
+// record. The end record and a torn tail, a last record cut or damaged by an
+// interrupted write, are truncated. A damaged record followed by other data fails.
+func Resume(f File) (*Writer, error) {
+	size, err := f.Seek(0, io.SeekEnd)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	reader, err := NewReader(f)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		_, err := reader.Next()
+		if err == nil {
+			continue
+		}
+		cause := errors.Cause(err)
+		if err == io.EOF || cause == ErrTruncated {
+			break
+		}
+		// the checksum of a record ending the file fails when its write was torn
+		if cause == ErrChecksumMismatch && reader.r.n == size {
+			break
+		}
+		return nil, err
+	}
+	if err := f.Truncate(reader.offset); err != nil {
+		return nil, err
+	}
+	if _, err := f.Seek(reader.offset, io.SeekStart); err != nil {
+		return nil, err
+	}
+	return &Writer{
+		w:      f,
+		header: reader.header,
+		next:   reader.next,
+		count:  reader.count,
+		digest: reader.digest,
+	}, nil
+}
+
+func (w *Writer) Header() Header {
+	return w.header
+}
+
+// NextHeight is the height of the next block to write.
+func (w *Writer) NextHeight() uint64 {
+	return w.next
+}
+
+// WriteBlock writes the block at NextHeight.
+func (w *Writer) WriteBlock(height uint64, blockHash common.Hash, data []byte) error {
+	if w.closed {
+		return ErrClosed
+	}
+	if height != w.next {
+		return errors.Wrapf(ErrUnexpectedHeight, "expect %v got %v", w.next, height)
+	}
+	if len(data) > MaxBlockSize {
+		return errors.Wrapf(ErrInvalidRecord, "block size %v at height %v", len(data), height)
+	}
+	buf := make([]byte, blockHeaderSize, blockHeaderSize+len(data)+common.HashSize)
+	buf[0] = recordBlock
+	binary.LittleEndian.PutUint64(buf[1:], height)
+	copy(buf[9:], blockHash[:])
+	binary.LittleEndian.PutUint32(buf[9+common.HashSize:], uint32(len(data)))
+	buf = append(buf, data...)
+	checksum := sha3.Sum256(buf)
+	buf = append(buf, checksum[:]...)
+	if _, err := w.w.Write(buf); err != nil {
+		return err
+	}
+	w.digest.Write(blockHash[:])
+	w.count++
+	w.next++
+	return nil
+}
+
+// Close writes the end record. It does not close the underlying writer.
+func (w *Writer) Close() error {
+	if w.closed {
+		return nil
+	}
+	buf := make([]byte, endRecordSize-common.HashSize, endRecordSize)
+	buf[0] = recordEnd
+	binary.LittleEndian.PutUint64(buf[1:], w.count)
+	binary.LittleEndian.PutUint64(buf[9:], w.next-1)
+	buf = append(buf, w.digest.Sum(nil)...)
+	if _, err := w.w.Write(buf); err != nil {
+		return err
+	}
+	w.closed = true
+	return nil
+}
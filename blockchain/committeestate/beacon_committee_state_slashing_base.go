@@ -485,3 +485,51 @@ func (b *beaconCommitteeStateSlashingBase) processUnstakeInstruction(
 
 	return committeeChange, returnStakingInstruction, nil
 }
+
+//processSlashEvidenceInstruction : process slash evidence instruction from beacon block
+// the validator proven to vote for two blocks in one timeslot is removed from its shard committee or substitute list
+// then slashed as slashing committee, its staking amount is returned and it is removed from staker list
+func (b *beaconCommitteeStateSlashingBase) processSlashEvidenceInstruction(
+	slashEvidenceInstruction *instruction.SlashEvidenceInstruction,
+	env *BeaconCommitteeStateEnvironment,
+	committeeChange *CommitteeChange,
+	returnStakingInstruction *instruction.ReturnStakeInstruction,
+) (*CommitteeChange, *instruction.ReturnStakeInstruction, error) {
+	shardID := byte(slashEvidenceInstruction.ChainID)
+	publicKey := slashEvidenceInstruction.PublicKey
+	found := false
+	if index := common.IndexOfStr(publicKey, b.shardCommittee[shardID]); index != -1 {
+		b.shardCommittee[shardID] = append(b.shardCommittee[shardID][:index:index], b.shardCommittee[shardID][index+1:]...)
+		committeeChange.AddShardCommitteeRemoved(shardID, []string{publicKey})
+		found = true
+	} else {
+		// validator may be swapped out to a substitute list by an instruction before in the same block
+		for substituteShardID, substitutes := range b.shardSubstitute {
+			if index := common.IndexOfStr(publicKey, substitutes); index != -1 {
+				b.shardSubstitute[substituteShardID] = append(substitutes[:index:index], substitutes[index+1:]...)
+				committeeChange.AddShardSubstituteRemoved(substituteShardID, []string{publicKey})
+				found = true
+				break
+			}
+		}
+	}
+	if !found {
+		// validator is already swapped out and unstaked by an instruction before in the same block
+		Logger.log.Infof("SHARD %+v, slash evidence of %+v, validator is not in committee or substitute", shardID, publicKey)
+		return committeeChange, returnStakingInstruction, nil
+	}
+
+	Logger.log.Infof("SHARD %+v, Epoch %+v, Slashing Committee %+v for voting two blocks in one timeslot", shardID, env.Epoch, publicKey)
+	returnStakingInstruction, committeeChange, err := b.processSlashing(
+		shardID,
+		env,
+		[]string{publicKey},
+		returnStakingInstruction,
+		committeeChange,
+	)
+	if err != nil {
+		return committeeChange, returnStakingInstruction, err
+	}
+
+	return committeeChange, returnStakingInstruction, nil
+}
@@ -441,3 +441,151 @@ func Test_beaconCommitteeStateSlashingBase_processSlashing(t *testing.T) {
 		})
 	}
 }
+
+func Test_beaconCommitteeStateSlashingBase_processSlashEvidenceInstruction(t *testing.T) {
+
+	initTestParams()
+
+	paymentAddress := privacy.GeneratePaymentAddress([]byte{1})
+	sDB, err := statedb.NewWithPrefixTrie(emptyRoot, wrarperDB)
+	assert.Nil(t, err)
+
+	hash, err := common.Hash{}.NewHashFromStr("123")
+	statedb.StoreStakerInfo(
+		sDB,
+		[]incognitokey.CommitteePublicKey{
+			*incKey0, *incKey2, *incKey4, *incKey6,
+		},
+		map[string]privacy.PaymentAddress{
+			incKey0.GetIncKeyBase58(): paymentAddress,
+			incKey2.GetIncKeyBase58(): paymentAddress,
+			incKey4.GetIncKeyBase58(): paymentAddress,
+			incKey6.GetIncKeyBase58(): paymentAddress,
+		},
+		map[string]bool{
+			key0: true,
+			key2: true,
+			key4: true,
+			key6: true,
+		},
+		map[string]common.Hash{
+			key0: *hash,
+			key2: *hash,
+			key4: *hash,
+			key6: *hash,
+		},
+		1,
+	)
+
+	newBeaconCommitteeStateBase := func() beaconCommitteeStateBase {
+		return beaconCommitteeStateBase{
+			shardCommittee: map[byte][]string{
+				0: []string{key0, key2},
+			},
+			shardSubstitute: map[byte][]string{
+				0: []string{key4},
+			},
+			stakingTx: map[string]common.Hash{
+				key0: *hash,
+				key2: *hash,
+				key4: *hash,
+			},
+			rewardReceiver: map[string]privacy.PaymentAddress{
+				incKey0.GetIncKeyBase58(): paymentAddress,
+				incKey2.GetIncKeyBase58(): paymentAddress,
+				incKey4.GetIncKeyBase58(): paymentAddress,
+			},
+			autoStake: map[string]bool{
+				key0: true,
+				key2: true,
+				key4: true,
+			},
+		}
+	}
+
+	tests := []struct {
+		name                string
+		publicKey           string
+		wantShardCommittee  map[byte][]string
+		wantShardSubstitute map[byte][]string
+		want                *CommitteeChange
+		want1               *instruction.ReturnStakeInstruction
+		wantErr             bool
+	}{
+		{
+			name:      "slash validator in committee",
+			publicKey: key2,
+			wantShardCommittee: map[byte][]string{
+				0: []string{key0},
+			},
+			wantShardSubstitute: map[byte][]string{
+				0: []string{key4},
+			},
+			want: NewCommitteeChange().
+				AddShardCommitteeRemoved(0, []string{key2}).
+				AddRemovedStaker(key2).
+				AddSlashingCommittees(0, []string{key2}),
+			want1:   instruction.NewReturnStakeInsWithValue([]string{key2}, []string{hash.String()}),
+			wantErr: false,
+		},
+		{
+			name:      "slash validator in substitute",
+			publicKey: key4,
+			wantShardCommittee: map[byte][]string{
+				0: []string{key0, key2},
+			},
+			wantShardSubstitute: map[byte][]string{
+				0: []string{},
+			},
+			want: NewCommitteeChange().
+				AddShardSubstituteRemoved(0, []string{key4}).
+				AddRemovedStaker(key4).
+				AddSlashingCommittees(0, []string{key4}),
+			want1:   instruction.NewReturnStakeInsWithValue([]string{key4}, []string{hash.String()}),
+			wantErr: false,
+		},
+		{
+			name:      "validator already unstaked",
+			publicKey: key6,
+			wantShardCommittee: map[byte][]string{
+				0: []string{key0, key2},
+			},
+			wantShardSubstitute: map[byte][]string{
+				0: []string{key4},
+			},
+			want:    NewCommitteeChange(),
+			want1:   instruction.NewReturnStakeIns(),
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := &beaconCommitteeStateSlashingBase{
+				beaconCommitteeStateBase: newBeaconCommitteeStateBase(),
+			}
+			slashEvidenceInstruction := instruction.NewSlashEvidenceInstruction().SetChainID(0).SetPublicKey(tt.publicKey)
+			got, got1, err := b.processSlashEvidenceInstruction(
+				slashEvidenceInstruction,
+				&BeaconCommitteeStateEnvironment{ConsensusStateDB: sDB},
+				NewCommitteeChange(),
+				instruction.NewReturnStakeIns(),
+			)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("processSlashEvidenceInstruction() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("processSlashEvidenceInstruction() got = %v, want %v", got, tt.want)
+			}
+			if !reflect.DeepEqual(got1, tt.want1) {
+				t.Errorf("processSlashEvidenceInstruction() got1 = %v, want %v", got1, tt.want1)
+			}
+			if !reflect.DeepEqual(b.shardCommittee, tt.wantShardCommittee) {
+				t.Errorf("processSlashEvidenceInstruction() shardCommittee = %v, want %v", b.shardCommittee, tt.wantShardCommittee)
+			}
+			if !reflect.DeepEqual(b.shardSubstitute, tt.wantShardSubstitute) {
+				t.Errorf("processSlashEvidenceInstruction() shardSubstitute = %v, want %v", b.shardSubstitute, tt.wantShardSubstitute)
+			}
+		})
+	}
+}
@@ -202,6 +202,17 @@ func (b *BeaconCommitteeStateV3) UpdateCommitteeState(env *BeaconCommitteeStateE
 			if err != nil {
 				return nil, nil, nil, NewCommitteeStateError(ErrUpdateCommitteeState, err)
 			}
+
+		case instruction.SLASH_EVIDENCE_ACTION:
+			slashEvidenceInstruction, err := instruction.ValidateAndImportSlashEvidenceInstructionFromString(inst)
+			if err != nil {
+				return nil, nil, nil, NewCommitteeStateError(ErrUpdateCommitteeState, err)
+			}
+			committeeChange, returnStakingInstruction, err = b.processSlashEvidenceInstruction(
+				slashEvidenceInstruction, env, committeeChange, returnStakingInstruction)
+			if err != nil {
+				return nil, nil, nil, NewCommitteeStateError(ErrUpdateCommitteeState, err)
+			}
 			//case instruction.DEQUEUE:
 			//	dequeueInstruction, err := instruction.ValidateAndImportDequeueInstructionFromString(inst)
 			//	if err != nil {
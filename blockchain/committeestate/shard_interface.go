@@ -1,6 +1,8 @@
 package committeestate
 
 import (
+	"fmt"
+
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/incognitokey"
 	"github.com/incognitochain/incognito-chain/instruction"
@@ -20,6 +22,18 @@ type ShardCommitteeState interface {
 	BuildTotalTxsFeeFromTxs(txs []metadata.Transaction) map[common.Hash]uint64
 }
 
+//HashShardCommitteeState returns the hashes of a shard committee state,
+//	which the shard block producing it commits in CommitteeRoot & PendingValidatorRoot
+func HashShardCommitteeState(s ShardCommitteeState) (*ShardCommitteeStateHash, error) {
+	hasher, ok := s.(interface {
+		hash() (*ShardCommitteeStateHash, error)
+	})
+	if !ok {
+		return nil, fmt.Errorf("Cannot hash shard committee state version %v", s.Version())
+	}
+	return hasher.hash()
+}
+
 type SwapInstructionGenerator interface {
 	GenerateSwapInstructions(env *ShardCommitteeStateEnvironment) (*instruction.SwapInstruction, []string, []string, error)
 }
@@ -1,6 +1,7 @@
 package blockchain
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/incognitochain/incognito-chain/metrics"
@@ -29,6 +30,7 @@ const (
 	Duration                           = 1000000
 	MaxSubsetCommittees                = 2
 	SFV3_MinShardCommitteeSize         = 8
+	MaxConfirmedShardStateLookback     = 1000 // beacon blocks searched for the last confirmed block of a shard
 
 	DEQUEUE_THRESHOLD_PERCENT = 0.5
 )
@@ -56,3 +58,8 @@ var (
 	beaconStoreBlockTimer                   = metrics.NewRegisteredTimer("beacon/storeblock", nil)
 	beaconUpdateBestStateTimer              = metrics.NewRegisteredTimer("beacon/updatebeststate", nil)
 )
+
+// shardInsertBlockTimerByShard returns the timer of block insertion of one shard, "shard/insert" covers every shard
+func shardInsertBlockTimerByShard(shardID byte) metrics.Timer {
+	return metrics.GetOrRegisterTimer(fmt.Sprintf("shard/%v/insert", shardID), nil)
+}
@@ -0,0 +1,471 @@
+package blockchain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"sort"
+	"strconv"
+
+	"github.com/incognitochain/incognito-chain/blockchain/committeestate"
+	"github.com/incognitochain/incognito-chain/blockchain/types"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/config"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/instruction"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/privacy"
+	"github.com/incognitochain/incognito-chain/transaction"
+	"github.com/incognitochain/incognito-chain/wallet"
+)
+
+// delegationPoolsView is the working copy of the delegation pools updated while building a beacon block
+type delegationPoolsView struct {
+	stateDB *statedb.StateDB
+	pools   map[string]*statedb.DelegationPoolState
+	// touched keeps the keys of the loaded pools in loading order
+	touched []string
+}
+
+func newDelegationPoolsView(stateDB *statedb.StateDB) *delegationPoolsView {
+	return &delegationPoolsView{
+		stateDB: stateDB,
+		pools:   make(map[string]*statedb.DelegationPoolState),
+	}
+}
+
+// get returns the pool of a committee public key, nil if not found
+func (v *delegationPoolsView) get(committeePublicKey string) (*statedb.DelegationPoolState, error) {
+	if pool, ok := v.pools[committeePublicKey]; ok {
+		return pool, nil
+	}
+	pool, has, err := statedb.GetDelegationPool(v.stateDB, committeePublicKey)
+	if err != nil {
+		return nil, err
+	}
+	if has {
+		pool = pool.Clone()
+	} else {
+		pool = nil
+	}
+	v.pools[committeePublicKey] = pool
+	v.touched = append(v.touched, committeePublicKey)
+	return pool, nil
+}
+
+func (v *delegationPoolsView) set(pool *statedb.DelegationPoolState) {
+	if _, ok := v.pools[pool.CommitteePublicKey()]; !ok {
+		v.touched = append(v.touched, pool.CommitteePublicKey())
+	}
+	v.pools[pool.CommitteePublicKey()] = pool
+}
+
+func buildDelegationInstruction(metaType int, status string, content *metadata.DelegationContent) ([]string, error) {
+	contentBytes, err := json.Marshal(content)
+	if err != nil {
+		return nil, err
+	}
+	return buildInstruction(metaType, content.ShardID, status, base64.StdEncoding.EncodeToString(contentBytes)), nil
+}
+
+// buildDelegationInstructions builds the instructions of the delegation requests of new shard blocks,
+// it adds stake instructions for the pools whose bonded delegations reach the staking amount and, at the first beacon height
+// of an epoch, release instructions for the unbonding delegations of the pools still covering the staking amount
+// without them, unstake instructions for the staked pools which do not
+func (blockchain *BlockChain) buildDelegationInstructions(
+	curView *BeaconBestState,
+	statefulActionsByShardID map[byte][][]string,
+	beaconHeight uint64,
+	shardInstruction *shardInstruction,
+	validStakePublicKeys []string,
+) ([][]string, error) {
+	if beaconHeight < config.Param().DelegationParam.DelegationHeight {
+		return [][]string{}, nil
+	}
+	instructions := [][]string{}
+	view := newDelegationPoolsView(curView.GetBeaconConsensusStateDB())
+	stakingAmount := config.Param().StakingAmountShard
+
+	isStaked := func(committeePublicKey string) (bool, error) {
+		if common.IndexOfStr(committeePublicKey, validStakePublicKeys) > -1 {
+			return true, nil
+		}
+		_, has, err := curView.GetStakerInfo(committeePublicKey)
+		return has, err
+	}
+
+	shardIDs := []int{}
+	for shardID := range statefulActionsByShardID {
+		shardIDs = append(shardIDs, int(shardID))
+	}
+	sort.Ints(shardIDs)
+
+	for _, v := range shardIDs {
+		for _, action := range statefulActionsByShardID[byte(v)] {
+			if len(action) != 2 {
+				continue
+			}
+			metaType, err := strconv.Atoi(action[0])
+			if err != nil {
+				continue
+			}
+			if metaType != metadata.CreateDelegationPoolMeta && metaType != metadata.DelegateStakingMeta && metaType != metadata.UnbondDelegationMeta {
+				continue
+			}
+			content, err := metadata.ParseDelegationContent(action[1])
+			if err != nil {
+				Logger.log.Error(err)
+				continue
+			}
+			pool, err := view.get(content.CommitteePublicKey)
+			if err != nil {
+				return nil, NewBlockChainError(BuildDelegationInstructionError, err)
+			}
+			status := metadata.DelegationRejectedStatus
+			switch metaType {
+			case metadata.CreateDelegationPoolMeta:
+				staked, err := isStaked(content.CommitteePublicKey)
+				if err != nil {
+					return nil, NewBlockChainError(BuildDelegationInstructionError, err)
+				}
+				if pool == nil && !staked && content.Amount <= stakingAmount &&
+					content.Commission <= config.Param().DelegationParam.MaxCommission {
+					status = metadata.DelegationAcceptedStatus
+					pool = statedb.NewDelegationPoolStateWithValue(
+						content.CommitteePublicKey, content.Address, content.Commission,
+						[]*statedb.DelegationState{}, false, false,
+					)
+					pool.AddDelegation(content.Address, content.Amount)
+					view.set(pool)
+				}
+			case metadata.DelegateStakingMeta:
+				if pool != nil {
+					delegation := pool.Delegation(content.Address)
+					if delegation == nil || !delegation.IsUnbonding() {
+						status = metadata.DelegationAcceptedStatus
+						pool.AddDelegation(content.Address, content.Amount)
+					}
+				}
+			case metadata.UnbondDelegationMeta:
+				if pool != nil {
+					delegation := pool.Delegation(content.Address)
+					if delegation != nil && !delegation.IsUnbonding() {
+						status = metadata.DelegationAcceptedStatus
+						delegation.SetUnbondTxID(content.TxReqID)
+					}
+				}
+			}
+			inst, err := buildDelegationInstruction(metaType, status, content)
+			if err != nil {
+				return nil, NewBlockChainError(BuildDelegationInstructionError, err)
+			}
+			instructions = append(instructions, inst)
+		}
+	}
+
+	allPools, err := statedb.GetAllDelegationPools(curView.GetBeaconConsensusStateDB())
+	if err != nil {
+		return nil, NewBlockChainError(BuildDelegationInstructionError, err)
+	}
+	if blockchain.IsFirstBeaconHeightInEpoch(beaconHeight) {
+		unstakeKeys := []string{}
+		for _, p := range allPools {
+			pool, err := view.get(p.CommitteePublicKey())
+			if err != nil {
+				return nil, NewBlockChainError(BuildDelegationInstructionError, err)
+			}
+			if pool == nil {
+				continue
+			}
+			if !hasUnbondingDelegation(pool) {
+				continue
+			}
+			// the validator stays staked as long as the bonded delegations cover the staking amount
+			if pool.IsStaked() && bondedAmount(pool) < stakingAmount {
+				if !pool.IsUnstaking() {
+					unstakeKeys = append(unstakeKeys, pool.CommitteePublicKey())
+					pool.SetIsUnstaking(true)
+				}
+				continue
+			}
+			// release the unbonding delegations of a pool which stopped validating or does not need them
+			for _, delegation := range append([]*statedb.DelegationState{}, pool.Delegations()...) {
+				if !delegation.IsUnbonding() {
+					continue
+				}
+				receiverShardID, err := getDelegationReceiverShardID(delegation.DelegatorAddress())
+				if err != nil {
+					Logger.log.Error(err)
+					continue
+				}
+				inst, err := buildDelegationInstruction(metadata.UnbondDelegationMeta, metadata.DelegationReleasedStatus, &metadata.DelegationContent{
+					CommitteePublicKey: pool.CommitteePublicKey(),
+					Address:            delegation.DelegatorAddress(),
+					Amount:             delegation.Amount(),
+					TxReqID:            delegation.UnbondTxID(),
+					ShardID:            receiverShardID,
+				})
+				if err != nil {
+					return nil, NewBlockChainError(BuildDelegationInstructionError, err)
+				}
+				instructions = append(instructions, inst)
+				pool.RemoveDelegation(delegation.DelegatorAddress())
+			}
+		}
+		if len(unstakeKeys) > 0 {
+			shardInstruction.unstakeInstructions = append(shardInstruction.unstakeInstructions, instruction.NewUnstakeInstructionWithValue(unstakeKeys))
+		}
+	}
+
+	// stake the validators of all the pools reaching the staking amount, including the pools
+	// of validators removed from the committee state which are still bonded enough
+	for _, p := range allPools {
+		if _, err := view.get(p.CommitteePublicKey()); err != nil {
+			return nil, NewBlockChainError(BuildDelegationInstructionError, err)
+		}
+	}
+	for _, key := range view.touched {
+		pool := view.pools[key]
+		if pool == nil || pool.IsStaked() || bondedAmount(pool) < stakingAmount {
+			continue
+		}
+		staked, err := isStaked(key)
+		if err != nil {
+			return nil, NewBlockChainError(BuildDelegationInstructionError, err)
+		}
+		if staked {
+			continue
+		}
+		txStakingID := metadata.GenDelegationPoolTxStakingID(key)
+		shardInstruction.stakeInstructions = append(shardInstruction.stakeInstructions, instruction.NewStakeInstructionWithValue(
+			[]string{key}, instruction.SHARD_INST, []string{txStakingID.String()}, []string{pool.OperatorAddress()}, []bool{true},
+		))
+		pool.SetIsStaked(true)
+	}
+	return instructions, nil
+}
+
+func hasUnbondingDelegation(pool *statedb.DelegationPoolState) bool {
+	for _, delegation := range pool.Delegations() {
+		if delegation.IsUnbonding() {
+			return true
+		}
+	}
+	return false
+}
+
+// bondedAmount returns the amount of the delegations of a pool which are not unbonding
+func bondedAmount(pool *statedb.DelegationPoolState) uint64 {
+	amount := uint64(0)
+	for _, delegation := range pool.Delegations() {
+		if !delegation.IsUnbonding() {
+			amount += delegation.Amount()
+		}
+	}
+	return amount
+}
+
+func getDelegationReceiverShardID(paymentAddress string) (byte, error) {
+	keyWallet, err := wallet.Base58CheckDeserialize(paymentAddress)
+	if err != nil {
+		return 0, err
+	}
+	pk := keyWallet.KeySet.PaymentAddress.Pk
+	return common.GetShardIDFromLastByte(pk[len(pk)-1]), nil
+}
+
+// processDelegationInstructions stores the delegation pools changed by the instructions of a beacon block
+func (blockchain *BlockChain) processDelegationInstructions(
+	consensusStateDB *statedb.StateDB,
+	beaconBlock *types.BeaconBlock,
+	committeeChange *committeestate.CommitteeChange,
+) error {
+	if beaconBlock.Header.Height < config.Param().DelegationParam.DelegationHeight {
+		return nil
+	}
+	getPool := func(committeePublicKey string) (*statedb.DelegationPoolState, bool, error) {
+		return statedb.GetDelegationPool(consensusStateDB, committeePublicKey)
+	}
+	for _, inst := range beaconBlock.Body.Instructions {
+		if len(inst) < 2 {
+			continue
+		}
+		switch inst[0] {
+		case strconv.Itoa(metadata.CreateDelegationPoolMeta),
+			strconv.Itoa(metadata.DelegateStakingMeta),
+			strconv.Itoa(metadata.UnbondDelegationMeta):
+			if len(inst) != 4 || inst[2] == metadata.DelegationRejectedStatus {
+				continue
+			}
+			content, err := metadata.ParseDelegationContent(inst[3])
+			if err != nil {
+				return NewBlockChainError(ProcessDelegationInstructionError, err)
+			}
+			pool, has, err := getPool(content.CommitteePublicKey)
+			if err != nil {
+				return NewBlockChainError(ProcessDelegationInstructionError, err)
+			}
+			switch {
+			case inst[0] == strconv.Itoa(metadata.CreateDelegationPoolMeta) && inst[2] == metadata.DelegationAcceptedStatus:
+				pool = statedb.NewDelegationPoolStateWithValue(
+					content.CommitteePublicKey, content.Address, content.Commission,
+					[]*statedb.DelegationState{}, false, false,
+				)
+				pool.AddDelegation(content.Address, content.Amount)
+			case !has:
+				Logger.log.Errorf("Delegation pool of %v not found for instruction %v", content.CommitteePublicKey, inst)
+				continue
+			case inst[0] == strconv.Itoa(metadata.DelegateStakingMeta) && inst[2] == metadata.DelegationAcceptedStatus:
+				pool.AddDelegation(content.Address, content.Amount)
+			case inst[0] == strconv.Itoa(metadata.UnbondDelegationMeta) && inst[2] == metadata.DelegationAcceptedStatus:
+				if delegation := pool.Delegation(content.Address); delegation != nil {
+					delegation.SetUnbondTxID(content.TxReqID)
+				}
+			case inst[0] == strconv.Itoa(metadata.UnbondDelegationMeta) && inst[2] == metadata.DelegationReleasedStatus:
+				pool.RemoveDelegation(content.Address)
+				if len(pool.Delegations()) == 0 {
+					statedb.DeleteDelegationPool(consensusStateDB, content.CommitteePublicKey)
+					continue
+				}
+			default:
+				continue
+			}
+			if err := statedb.StoreDelegationPool(consensusStateDB, pool); err != nil {
+				return NewBlockChainError(ProcessDelegationInstructionError, err)
+			}
+		case instruction.STAKE_ACTION, instruction.UNSTAKE_ACTION:
+			keys := []string{}
+			if inst[0] == instruction.STAKE_ACTION {
+				stakeInstruction, err := instruction.ValidateAndImportStakeInstructionFromString(inst)
+				if err != nil {
+					continue
+				}
+				keys = stakeInstruction.PublicKeys
+			} else {
+				unstakeInstruction, err := instruction.ValidateAndImportUnstakeInstructionFromString(inst)
+				if err != nil {
+					continue
+				}
+				keys = unstakeInstruction.CommitteePublicKeys
+			}
+			for _, key := range keys {
+				pool, has, err := getPool(key)
+				if err != nil {
+					return NewBlockChainError(ProcessDelegationInstructionError, err)
+				}
+				if !has {
+					continue
+				}
+				if inst[0] == instruction.STAKE_ACTION {
+					pool.SetIsStaked(true)
+					pool.SetIsUnstaking(false)
+				} else if pool.IsStaked() {
+					pool.SetIsUnstaking(true)
+				}
+				if err := statedb.StoreDelegationPool(consensusStateDB, pool); err != nil {
+					return NewBlockChainError(ProcessDelegationInstructionError, err)
+				}
+			}
+		}
+	}
+
+	// validators removed from the committee state get their staking amount back,
+	// the pools of these validators can release unbonding delegations from now on
+	for _, key := range committeeChange.RemovedStaker {
+		pool, has, err := getPool(key)
+		if err != nil {
+			return NewBlockChainError(ProcessDelegationInstructionError, err)
+		}
+		if !has || !pool.IsStaked() {
+			continue
+		}
+		pool.SetIsStaked(false)
+		pool.SetIsUnstaking(false)
+		if err := statedb.StoreDelegationPool(consensusStateDB, pool); err != nil {
+			return NewBlockChainError(ProcessDelegationInstructionError, err)
+		}
+	}
+	return nil
+}
+
+// buildDelegationResponseTx mints the PRV of a rejected delegation request or of a released delegation to its owner
+func (blockGenerator *BlockGenerator) buildDelegationResponseTx(
+	status string,
+	contentStr string,
+	producerPrivateKey *privacy.PrivateKey,
+	shardID byte,
+	curView *ShardBestState,
+) (metadata.Transaction, error) {
+	content, err := metadata.ParseDelegationContent(contentStr)
+	if err != nil {
+		Logger.log.Errorf("ParseDelegationContent(%v) error: %v\n", contentStr, err)
+		return nil, nil
+	}
+	if content.ShardID != shardID {
+		return nil, nil
+	}
+	keyWallet, err := wallet.Base58CheckDeserialize(content.Address)
+	if err != nil {
+		Logger.log.Errorf("Invalid delegation receiver address %v, error %v", content.Address, err)
+		return nil, nil
+	}
+	meta := metadata.NewDelegationResponse(status, content.TxReqID)
+	txParam := transaction.TxSalaryOutputParams{
+		Amount:          content.Amount,
+		ReceiverAddress: &keyWallet.KeySet.PaymentAddress,
+		TokenID:         &common.PRVCoinID,
+	}
+	makeMD := func(c privacy.Coin) metadata.Metadata {
+		if c != nil && c.GetSharedRandom() != nil {
+			meta.SetSharedRandom(c.GetSharedRandom().ToBytesS())
+		}
+		return meta
+	}
+	return txParam.BuildTxSalary(producerPrivateKey, curView.GetCopiedTransactionStateDB(), makeMD)
+}
+
+// splitDelegationPoolReward splits the reward of the validator of a delegation pool between the operator,
+// who keeps the commission and the remainder of the integer divisions, and the delegators pro-rata to their delegations
+func splitDelegationPoolReward(pool *statedb.DelegationPoolState, reward uint64) ([]privacy.PaymentAddress, []uint64, error) {
+	receivers := []privacy.PaymentAddress{}
+	amounts := []uint64{}
+	addReceiver := func(paymentAddress string, amount uint64) error {
+		if amount == 0 {
+			return nil
+		}
+		keyWallet, err := wallet.Base58CheckDeserialize(paymentAddress)
+		if err != nil {
+			return err
+		}
+		receivers = append(receivers, keyWallet.KeySet.PaymentAddress)
+		amounts = append(amounts, amount)
+		return nil
+	}
+	total := pool.TotalAmount()
+	if total == 0 {
+		if err := addReceiver(pool.OperatorAddress(), reward); err != nil {
+			return nil, nil, err
+		}
+		return receivers, amounts, nil
+	}
+	commission := reward * pool.Commission() / 10000
+	remaining := reward - commission
+	delegatorAmounts := make([]uint64, len(pool.Delegations()))
+	operatorAmount := reward
+	for i, delegation := range pool.Delegations() {
+		delegatorAmounts[i] = new(big.Int).Div(
+			new(big.Int).Mul(new(big.Int).SetUint64(remaining), new(big.Int).SetUint64(delegation.Amount())),
+			new(big.Int).SetUint64(total),
+		).Uint64()
+		operatorAmount -= delegatorAmounts[i]
+	}
+	if err := addReceiver(pool.OperatorAddress(), operatorAmount); err != nil {
+		return nil, nil, err
+	}
+	for i, delegation := range pool.Delegations() {
+		if err := addReceiver(delegation.DelegatorAddress(), delegatorAmounts[i]); err != nil {
+			return nil, nil, err
+		}
+	}
+	return receivers, amounts, nil
+}
@@ -0,0 +1,376 @@
+package blockchain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strconv"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/blockchain/committeestate"
+	"github.com/incognitochain/incognito-chain/blockchain/types"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/common/base58"
+	"github.com/incognitochain/incognito-chain/config"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/wallet"
+	"github.com/stretchr/testify/assert"
+)
+
+func newDelegationTestPaymentAddress(t *testing.T, seed string) string {
+	keyWallet, err := wallet.NewMasterKey(common.HashB([]byte(seed)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return keyWallet.Base58CheckSerialize(wallet.PaymentAddressType)
+}
+
+func Test_splitDelegationPoolReward(t *testing.T) {
+	operator := newDelegationTestPaymentAddress(t, "operator")
+	delegator1 := newDelegationTestPaymentAddress(t, "delegator1")
+	delegator2 := newDelegationTestPaymentAddress(t, "delegator2")
+
+	tests := []struct {
+		name        string
+		commission  uint64
+		delegations []*statedb.DelegationState
+		reward      uint64
+		want        map[string]uint64
+	}{
+		{
+			name:       "commission and pro-rata split",
+			commission: 1000,
+			delegations: []*statedb.DelegationState{
+				statedb.NewDelegationStateWithValue(operator, 250, common.Hash{}),
+				statedb.NewDelegationStateWithValue(delegator1, 500, common.Hash{}),
+				statedb.NewDelegationStateWithValue(delegator2, 250, common.Hash{}),
+			},
+			reward: 10000,
+			want: map[string]uint64{
+				operator:   1000 + 2250,
+				delegator1: 4500,
+				delegator2: 2250,
+			},
+		},
+		{
+			name:       "no commission",
+			commission: 0,
+			delegations: []*statedb.DelegationState{
+				statedb.NewDelegationStateWithValue(delegator1, 1, common.Hash{}),
+				statedb.NewDelegationStateWithValue(delegator2, 3, common.Hash{}),
+			},
+			reward: 100,
+			want: map[string]uint64{
+				delegator1: 25,
+				delegator2: 75,
+			},
+		},
+		{
+			name:       "remainder of the divisions goes to the operator",
+			commission: 0,
+			delegations: []*statedb.DelegationState{
+				statedb.NewDelegationStateWithValue(delegator1, 1, common.Hash{}),
+				statedb.NewDelegationStateWithValue(delegator2, 2, common.Hash{}),
+			},
+			reward: 10,
+			want: map[string]uint64{
+				operator:   1,
+				delegator1: 3,
+				delegator2: 6,
+			},
+		},
+		{
+			name:        "empty pool rewards the operator",
+			commission:  500,
+			delegations: []*statedb.DelegationState{},
+			reward:      100,
+			want: map[string]uint64{
+				operator: 100,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pool := statedb.NewDelegationPoolStateWithValue("key", operator, tt.commission, tt.delegations, true, false)
+			receivers, amounts, err := splitDelegationPoolReward(pool, tt.reward)
+			assert.Nil(t, err)
+			got := make(map[string]uint64)
+			total := uint64(0)
+			for i, receiver := range receivers {
+				keyWallet := wallet.KeyWallet{}
+				keyWallet.KeySet.PaymentAddress = receiver
+				got[keyWallet.Base58CheckSerialize(wallet.PaymentAddressType)] += amounts[i]
+				total += amounts[i]
+			}
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.reward, total)
+		})
+	}
+}
+
+// newDelegationTestView returns a beacon view with an empty consensus state, a staking amount of 1000
+// and epochs of 10 blocks, the first beacon heights of the epochs being 11, 21...
+func newDelegationTestView(t *testing.T) (*BlockChain, *BeaconBestState, func()) {
+	config.AbortParam()
+	common.MaxShardNumber = 8
+	config.Param().StakingAmountShard = 1000
+	config.Param().DelegationParam.DelegationHeight = 1
+	config.Param().DelegationParam.MaxCommission = 5000
+	config.Param().EpochParam.EpochV2BreakPoint = 1
+	config.Param().EpochParam.NumberOfBlockInEpochV2 = 10
+	bc, db, closeDB := newPreloadTestBlockChain(t)
+	stateDB, err := statedb.NewWithPrefixTrie(common.EmptyRoot, statedb.NewDatabaseAccessWarper(db))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return bc, &BeaconBestState{consensusStateDB: stateDB}, closeDB
+}
+
+func storeDelegationTestPools(t *testing.T, view *BeaconBestState, pools ...*statedb.DelegationPoolState) {
+	for _, pool := range pools {
+		if err := statedb.StoreDelegationPool(view.consensusStateDB, pool); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := view.consensusStateDB.Commit(true); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func newDelegationTestAction(t *testing.T, metaType int, content metadata.DelegationContent) []string {
+	contentBytes, err := json.Marshal(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return []string{strconv.Itoa(metaType), base64.StdEncoding.EncodeToString(contentBytes)}
+}
+
+// delegationTestInstructions returns the statuses of the delegation instructions by type and address
+func delegationTestInstructions(t *testing.T, insts [][]string) map[string]string {
+	res := make(map[string]string)
+	for _, inst := range insts {
+		content, err := metadata.ParseDelegationContent(inst[3])
+		if err != nil {
+			t.Fatal(err)
+		}
+		res[inst[0]+"-"+content.Address] = inst[2]
+	}
+	return res
+}
+
+func TestBuildDelegationInstructions(t *testing.T) {
+	operator := newDelegationTestPaymentAddress(t, "operator")
+	delegator1 := newDelegationTestPaymentAddress(t, "delegator1")
+	delegator2 := newDelegationTestPaymentAddress(t, "delegator2")
+	createPool := strconv.Itoa(metadata.CreateDelegationPoolMeta)
+	delegate := strconv.Itoa(metadata.DelegateStakingMeta)
+	unbond := strconv.Itoa(metadata.UnbondDelegationMeta)
+	unbonding := common.Hash{1}
+
+	tests := []struct {
+		name             string
+		pools            []*statedb.DelegationPoolState
+		actions          [][]string
+		validStakeKeys   []string
+		beaconHeight     uint64
+		wantInsts        map[string]string
+		wantStakeKeys    []string
+		wantUnstakeKeys  []string
+		wantDelegations  int
+		wantIsStaked     bool
+		wantIsUnstaking  bool
+		wantBondedAmount uint64
+	}{
+		{
+			name: "pool reaching the staking amount is staked",
+			actions: [][]string{
+				newDelegationTestAction(t, metadata.CreateDelegationPoolMeta, metadata.DelegationContent{CommitteePublicKey: keys[0], Address: operator, Commission: 1000, Amount: 600}),
+				newDelegationTestAction(t, metadata.DelegateStakingMeta, metadata.DelegationContent{CommitteePublicKey: keys[0], Address: delegator1, Amount: 400}),
+			},
+			beaconHeight:     5,
+			wantInsts:        map[string]string{createPool + "-" + operator: metadata.DelegationAcceptedStatus, delegate + "-" + delegator1: metadata.DelegationAcceptedStatus},
+			wantStakeKeys:    []string{keys[0]},
+			wantDelegations:  2,
+			wantIsStaked:     true,
+			wantBondedAmount: 1000,
+		},
+		{
+			name: "pool of a staked key is rejected",
+			actions: [][]string{
+				newDelegationTestAction(t, metadata.CreateDelegationPoolMeta, metadata.DelegationContent{CommitteePublicKey: keys[0], Address: operator, Commission: 1000, Amount: 1000}),
+			},
+			validStakeKeys: []string{keys[0]},
+			beaconHeight:   5,
+			wantInsts:      map[string]string{createPool + "-" + operator: metadata.DelegationRejectedStatus},
+		},
+		{
+			name: "pool over the staking amount stays staked and releases the unbonding delegation",
+			pools: []*statedb.DelegationPoolState{statedb.NewDelegationPoolStateWithValue(keys[0], operator, 1000, []*statedb.DelegationState{
+				statedb.NewDelegationStateWithValue(operator, 1000, common.Hash{}),
+				statedb.NewDelegationStateWithValue(delegator1, 300, unbonding),
+			}, true, false)},
+			beaconHeight:     11,
+			wantInsts:        map[string]string{unbond + "-" + delegator1: metadata.DelegationReleasedStatus},
+			wantDelegations:  1,
+			wantIsStaked:     true,
+			wantBondedAmount: 1000,
+		},
+		{
+			name: "pool dropping below the staking amount is unstaked",
+			pools: []*statedb.DelegationPoolState{statedb.NewDelegationPoolStateWithValue(keys[0], operator, 1000, []*statedb.DelegationState{
+				statedb.NewDelegationStateWithValue(operator, 700, common.Hash{}),
+				statedb.NewDelegationStateWithValue(delegator1, 300, unbonding),
+			}, true, false)},
+			beaconHeight:     11,
+			wantInsts:        map[string]string{},
+			wantUnstakeKeys:  []string{keys[0]},
+			wantDelegations:  2,
+			wantIsStaked:     true,
+			wantIsUnstaking:  true,
+			wantBondedAmount: 700,
+		},
+		{
+			name: "unbonding delegation waits for the epoch boundary",
+			pools: []*statedb.DelegationPoolState{statedb.NewDelegationPoolStateWithValue(keys[0], operator, 1000, []*statedb.DelegationState{
+				statedb.NewDelegationStateWithValue(operator, 700, common.Hash{}),
+				statedb.NewDelegationStateWithValue(delegator1, 300, unbonding),
+			}, true, false)},
+			actions: [][]string{
+				newDelegationTestAction(t, metadata.UnbondDelegationMeta, metadata.DelegationContent{CommitteePublicKey: keys[0], Address: delegator1}),
+				newDelegationTestAction(t, metadata.UnbondDelegationMeta, metadata.DelegationContent{CommitteePublicKey: keys[0], Address: delegator2}),
+			},
+			beaconHeight:     12,
+			wantInsts:        map[string]string{unbond + "-" + delegator1: metadata.DelegationRejectedStatus, unbond + "-" + delegator2: metadata.DelegationRejectedStatus},
+			wantDelegations:  2,
+			wantIsStaked:     true,
+			wantBondedAmount: 700,
+		},
+		{
+			name: "pool of a removed validator is staked again without new request",
+			pools: []*statedb.DelegationPoolState{statedb.NewDelegationPoolStateWithValue(keys[0], operator, 1000, []*statedb.DelegationState{
+				statedb.NewDelegationStateWithValue(operator, 1000, common.Hash{}),
+			}, false, false)},
+			beaconHeight:     5,
+			wantInsts:        map[string]string{},
+			wantStakeKeys:    []string{keys[0]},
+			wantDelegations:  1,
+			wantIsStaked:     true,
+			wantBondedAmount: 1000,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bc, view, closeDB := newDelegationTestView(t)
+			defer closeDB()
+			storeDelegationTestPools(t, view, tt.pools...)
+
+			shardInst := newShardInstruction()
+			insts, err := bc.buildDelegationInstructions(view, map[byte][][]string{0: tt.actions}, tt.beaconHeight, shardInst, tt.validStakeKeys)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.wantInsts, delegationTestInstructions(t, insts))
+			stakeKeys := []string{}
+			for _, inst := range shardInst.stakeInstructions {
+				stakeKeys = append(stakeKeys, inst.PublicKeys...)
+			}
+			unstakeKeys := []string{}
+			for _, inst := range shardInst.unstakeInstructions {
+				unstakeKeys = append(unstakeKeys, inst.CommitteePublicKeys...)
+			}
+			assert.ElementsMatch(t, tt.wantStakeKeys, stakeKeys)
+			assert.ElementsMatch(t, tt.wantUnstakeKeys, unstakeKeys)
+
+			// the beacon block of the instructions updates the stored pools like the build
+			block := types.NewBeaconBlock()
+			block.Header.Height = tt.beaconHeight
+			block.Body.Instructions = insts
+			for _, inst := range shardInst.stakeInstructions {
+				block.Body.Instructions = append(block.Body.Instructions, inst.ToString())
+			}
+			for _, inst := range shardInst.unstakeInstructions {
+				block.Body.Instructions = append(block.Body.Instructions, inst.ToString())
+			}
+			assert.Nil(t, bc.processDelegationInstructions(view.consensusStateDB, block, committeestate.NewCommitteeChange()))
+			pool, has, err := statedb.GetDelegationPool(view.consensusStateDB, keys[0])
+			assert.Nil(t, err)
+			if tt.wantDelegations == 0 {
+				assert.False(t, has)
+				return
+			}
+			assert.True(t, has)
+			assert.Equal(t, tt.wantDelegations, len(pool.Delegations()))
+			assert.Equal(t, tt.wantIsStaked, pool.IsStaked())
+			assert.Equal(t, tt.wantIsUnstaking, pool.IsUnstaking())
+			assert.Equal(t, tt.wantBondedAmount, bondedAmount(pool))
+		})
+	}
+}
+
+func TestProcessDelegationInstructionsRemovedStaker(t *testing.T) {
+	bc, view, closeDB := newDelegationTestView(t)
+	defer closeDB()
+	operator := newDelegationTestPaymentAddress(t, "operator")
+	delegator1 := newDelegationTestPaymentAddress(t, "delegator1")
+	storeDelegationTestPools(t, view, statedb.NewDelegationPoolStateWithValue(keys[0], operator, 1000, []*statedb.DelegationState{
+		statedb.NewDelegationStateWithValue(operator, 700, common.Hash{}),
+		statedb.NewDelegationStateWithValue(delegator1, 300, common.Hash{1}),
+	}, true, true))
+
+	block := types.NewBeaconBlock()
+	block.Header.Height = 21
+	committeeChange := committeestate.NewCommitteeChange().AddRemovedStaker(keys[0])
+	assert.Nil(t, bc.processDelegationInstructions(view.consensusStateDB, block, committeeChange))
+	pool, has, err := statedb.GetDelegationPool(view.consensusStateDB, keys[0])
+	assert.Nil(t, err)
+	assert.True(t, has)
+	assert.False(t, pool.IsStaked())
+	assert.False(t, pool.IsUnstaking())
+
+	// the next epoch boundary releases the unbonding delegation of the removed validator
+	if _, err := view.consensusStateDB.Commit(true); err != nil {
+		t.Fatal(err)
+	}
+	insts, err := bc.buildDelegationInstructions(view, map[byte][][]string{}, 31, newShardInstruction(), nil)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{strconv.Itoa(metadata.UnbondDelegationMeta) + "-" + delegator1: metadata.DelegationReleasedStatus}, delegationTestInstructions(t, insts))
+}
+
+func TestAddShardCommitteeRewardSlashingVersionDelegationPool(t *testing.T) {
+	bc, view, closeDB := newDelegationTestView(t)
+	defer closeDB()
+	operator := newDelegationTestPaymentAddress(t, "operator")
+	receiver := newDelegationTestPaymentAddress(t, "receiver")
+	committeePublicKey := "pool committee key"
+	storeDelegationTestPools(t, view, statedb.NewDelegationPoolStateWithValue(committeePublicKey, operator, 0, []*statedb.DelegationState{}, false, false))
+	receiverWallet, err := wallet.Base58CheckDeserialize(receiver)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stakers := []*statedb.StakerInfoSlashingVersion{
+		statedb.NewStakerInfoSlashingVersion(committeePublicKey, statedb.NewStakerInfoWithValue(receiverWallet.KeySet.PaymentAddress, true, common.Hash{}, 1)),
+	}
+
+	// committeeReward returns the PRV reward of an address after rewarding the stakers
+	committeeReward := func(beaconConsensusStateDB *statedb.StateDB, address string) uint64 {
+		keyWallet, err := wallet.Base58CheckDeserialize(address)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pk := keyWallet.KeySet.PaymentAddress.Pk
+		rewardStateDB, err := statedb.NewWithPrefixTrie(common.EmptyRoot, view.consensusStateDB.Database())
+		if err != nil {
+			t.Fatal(err)
+		}
+		shardID := common.GetShardIDFromLastByte(pk[common.PublicKeySize-1])
+		err = bc.addShardCommitteeRewardSlashingVersion(rewardStateDB, beaconConsensusStateDB, shardID, map[common.Hash]uint64{common.PRVCoinID: 100}, stakers)
+		assert.Nil(t, err)
+		reward, err := statedb.GetCommitteeReward(rewardStateDB, base58.Base58Check{}.Encode(pk, common.Base58Version), common.PRVCoinID)
+		assert.Nil(t, err)
+		return reward
+	}
+
+	// before the delegation height there is no pool to look up and the reward receiver is paid
+	assert.Equal(t, uint64(100), committeeReward(nil, receiver))
+	assert.Equal(t, uint64(0), committeeReward(nil, operator))
+	assert.Equal(t, uint64(100), committeeReward(view.consensusStateDB, operator))
+	assert.Equal(t, uint64(0), committeeReward(view.consensusStateDB, receiver))
+}
@@ -199,6 +199,10 @@ const (
 	UpdateBFTV3StatsError
 	FinishSyncInstructionError
 	OutdatedCodeError
+	SlashEvidenceInstructionError
+	BuildDelegationInstructionError
+	ProcessDelegationInstructionError
+	StateRootsHashError
 )
 
 var ErrCodeMessage = map[int]struct {
@@ -375,6 +379,10 @@ var ErrCodeMessage = map[int]struct {
 	FinishSyncInstructionError:                        {-1165, "Checking finish sync instruction error"},
 	BuildBridgeError:                                  {-1166, "Build bridge unshield instruction error"},
 	BuildBridgeAggError:                               {-1167, "Build bridge agg unshield instruction error"},
+	SlashEvidenceInstructionError:                     {-1168, "Checking slash evidence instruction error"},
+	BuildDelegationInstructionError:                   {-1169, "Build delegation instruction error"},
+	ProcessDelegationInstructionError:                 {-1170, "Process delegation instruction error"},
+	StateRootsHashError:                               {-1171, "State roots hash error"},
 
 	GetListOutputCoinsByKeysetError:                 {-2000, "Get List Output Coins By Keyset Error"},
 	GetTotalLockedCollateralError:                   {-3000, "Get Total Locked Collateral Error"},
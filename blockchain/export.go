@@ -0,0 +1,303 @@
+package blockchain
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/incognitochain/incognito-chain/blockchain/chainexport"
+	"github.com/incognitochain/incognito-chain/blockchain/types"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
+	"github.com/incognitochain/incognito-chain/multiview"
+	"github.com/incognitochain/incognito-chain/wire"
+	libp2p "github.com/libp2p/go-libp2p-core/peer"
+	"github.com/pkg/errors"
+)
+
+// ExportChain writes the finalized blocks of the chain of w, from w.NextHeight() to toHeight,
+// as block records of the chainexport format. toHeight is capped at the final view, 0 means
+// the final view. The export stops early without error when stop is closed.
+func (blockchain *BlockChain) ExportChain(w *chainexport.Writer, toHeight uint64, stop <-chan struct{}) error {
+	chainID := w.Header().ChainID
+	var finalHeight uint64
+	if chainID == common.BeaconChainID {
+		finalHeight = blockchain.BeaconChain.GetFinalViewHeight()
+	} else {
+		if chainID < 0 || chainID >= blockchain.GetActiveShardNumber() {
+			return fmt.Errorf("Shard %v is not active", chainID)
+		}
+		finalHeight = blockchain.ShardChain[chainID].GetFinalViewHeight()
+	}
+	if toHeight == 0 || toHeight > finalHeight {
+		toHeight = finalHeight
+	}
+	for height := w.NextHeight(); height <= toHeight; height++ {
+		select {
+		case <-stop:
+			Logger.log.Infof("Export chain %v stopped at height %v", chainID, height-1)
+			return nil
+		default:
+		}
+		var hash *common.Hash
+		var block interface{}
+		var err error
+		if chainID == common.BeaconChainID {
+			hash, err = rawdbv2.GetFinalizedBeaconBlockHashByIndex(blockchain.GetBeaconChainDatabase(), height)
+			if err == nil {
+				block, _, err = blockchain.GetBeaconBlockByHash(*hash)
+			}
+		} else {
+			shardID := byte(chainID)
+			hash, err = rawdbv2.GetFinalizedShardBlockHashByIndex(blockchain.GetShardChainDatabase(shardID), shardID, height)
+			if err == nil {
+				block, err = blockchain.getExportedShardBlock(shardID, *hash)
+			}
+		}
+		if err != nil {
+			return errors.Wrapf(err, "get block %v of chain %v", height, chainID)
+		}
+		data, err := json.Marshal(block)
+		if err != nil {
+			return err
+		}
+		if err := w.WriteBlock(height, *hash, data); err != nil {
+			return err
+		}
+		if height%1000 == 0 {
+			Logger.log.Infof("Export chain %v block %v", chainID, height)
+		}
+	}
+	Logger.log.Infof("Finish export chain %v to block %v", chainID, toHeight)
+	return nil
+}
+
+// exportedShardBlock is the data of a shard block record. It carries the cross shard
+// blocks the block includes, which an offline import can not request from peers.
+type exportedShardBlock struct {
+	Block            *types.ShardBlock
+	CrossShardBlocks []*types.CrossShardBlock
+}
+
+func (blockchain *BlockChain) getExportedShardBlock(shardID byte, hash common.Hash) (*exportedShardBlock, error) {
+	block, _, err := blockchain.GetShardBlockByHashWithShardID(hash, shardID)
+	if err != nil {
+		return nil, err
+	}
+	exported := &exportedShardBlock{Block: block}
+	for fromShard, crossTransactions := range block.Body.CrossTransactions {
+		for _, crossTransaction := range crossTransactions {
+			fromBlock, _, err := blockchain.GetShardBlockByHashWithShardID(crossTransaction.BlockHash, fromShard)
+			if err != nil {
+				return nil, errors.Wrapf(err, "get cross shard block %v of shard %v", crossTransaction.BlockHeight, fromShard)
+			}
+			crossShardBlock, err := types.CreateCrossShardBlock(fromBlock, shardID)
+			if err != nil {
+				return nil, err
+			}
+			exported.CrossShardBlocks = append(exported.CrossShardBlocks, crossShardBlock)
+		}
+	}
+	return exported, nil
+}
+
+// ImportChain inserts the blocks read from r through InsertBeaconBlock or InsertShardBlock,
+// so they are validated as blocks received from peers. Blocks the node already has are
+// skipped, which makes an interrupted import resumable. A shard chain can only be imported
+// up to the blocks whose beacon block is already inserted. The import stops early without
+// error when stop is closed. It returns the number of inserted blocks.
+//
+// A chain initialized without Server and Syncker is offline: the import then announces
+// nothing and takes the cross shard blocks from the export file.
+func (blockchain *BlockChain) ImportChain(r *chainexport.Reader, stop <-chan struct{}) (uint64, error) {
+	chainID := r.Header().ChainID
+	if chainID != common.BeaconChainID && (chainID < 0 || chainID >= blockchain.GetActiveShardNumber()) {
+		return 0, fmt.Errorf("Shard %v is not active", chainID)
+	}
+	if blockchain.config.Server == nil {
+		blockchain.config.Server = offlineServer{}
+	}
+	if blockchain.config.Syncker == nil {
+		blockchain.config.Syncker = &importSyncker{}
+	}
+	inserted := uint64(0)
+	for {
+		select {
+		case <-stop:
+			Logger.log.Infof("Import chain %v stopped at height %v", chainID, r.NextHeight()-1)
+			return inserted, nil
+		default:
+		}
+		record, err := r.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return inserted, err
+		}
+		if chainID == common.BeaconChainID {
+			err = blockchain.importBeaconBlock(record)
+		} else {
+			err = blockchain.importShardBlock(byte(chainID), record)
+		}
+		if err == errBlockExisted {
+			continue
+		}
+		if err != nil {
+			return inserted, errors.Wrapf(err, "import block %v of chain %v", record.Height, chainID)
+		}
+		inserted++
+		if record.Height%1000 == 0 {
+			Logger.log.Infof("Import chain %v block %v", chainID, record.Height)
+		}
+	}
+	Logger.log.Infof("Finish import chain %v, insert %v blocks", chainID, inserted)
+	return inserted, nil
+}
+
+var errBlockExisted = errors.New("block existed")
+
+func (blockchain *BlockChain) importBeaconBlock(record *chainexport.Block) error {
+	block := &types.BeaconBlock{}
+	if err := json.Unmarshal(record.Data, block); err != nil {
+		return err
+	}
+	if err := checkImportedBlock(record, block.Hash(), block.GetHeight()); err != nil {
+		return err
+	}
+	chain := blockchain.BeaconChain
+	if err := checkImportedBlockLink(record, chain.GetFinalView(), chain.GetViewByHash(*block.Hash()), chain.GetViewByHash(block.GetPrevHash()),
+		func() (*common.Hash, error) {
+			return rawdbv2.GetFinalizedBeaconBlockHashByIndex(blockchain.GetBeaconChainDatabase(), record.Height)
+		}); err != nil {
+		return err
+	}
+	return blockchain.InsertBeaconBlock(block, true)
+}
+
+func (blockchain *BlockChain) importShardBlock(shardID byte, record *chainexport.Block) error {
+	exported := &exportedShardBlock{}
+	if err := json.Unmarshal(record.Data, exported); err != nil {
+		return err
+	}
+	block := exported.Block
+	if block == nil {
+		return fmt.Errorf("Record has no shard block")
+	}
+	if err := checkImportedBlock(record, block.Hash(), block.GetHeight()); err != nil {
+		return err
+	}
+	if block.Header.ShardID != shardID {
+		return fmt.Errorf("Block belongs to shard %v", block.Header.ShardID)
+	}
+	chain := blockchain.ShardChain[shardID]
+	if err := checkImportedBlockLink(record, chain.GetFinalView(), chain.GetViewByHash(*block.Hash()), chain.GetViewByHash(block.GetPrevHash()),
+		func() (*common.Hash, error) {
+			return rawdbv2.GetFinalizedShardBlockHashByIndex(blockchain.GetShardChainDatabase(shardID), shardID, record.Height)
+		}); err != nil {
+		return err
+	}
+	if beaconHeight := blockchain.BeaconChain.GetBestViewHeight(); beaconHeight < block.Header.BeaconHeight {
+		return fmt.Errorf("Block needs beacon block %v, import the beacon chain first (beacon height %v)", block.Header.BeaconHeight, beaconHeight)
+	}
+	if syncker, ok := blockchain.config.Syncker.(*importSyncker); ok {
+		// the cross shard transactions of the block are checked against these blocks
+		syncker.crossShardBlocks = exported.CrossShardBlocks
+		defer func() { syncker.crossShardBlocks = nil }()
+	}
+	return blockchain.InsertShardBlock(block, true)
+}
+
+// checkImportedBlock checks that a decoded block is the block of its record.
+func checkImportedBlock(record *chainexport.Block, hash *common.Hash, height uint64) error {
+	if !hash.IsEqual(&record.Hash) {
+		return fmt.Errorf("Block hash %v is different from record hash %v", hash.String(), record.Hash.String())
+	}
+	if height != record.Height {
+		return fmt.Errorf("Block height %v is different from record height %v", height, record.Height)
+	}
+	return nil
+}
+
+// checkImportedBlockLink returns errBlockExisted for a block the chain already has, and an
+// error when the block does not belong to the chain or does not link to one of its views.
+func checkImportedBlockLink(record *chainexport.Block, finalView multiview.View, view multiview.View, preView multiview.View, finalizedHash func() (*common.Hash, error)) error {
+	if view != nil {
+		return errBlockExisted
+	}
+	if record.Height <= finalView.GetHeight() {
+		hash, err := finalizedHash()
+		if err != nil {
+			return err
+		}
+		if !hash.IsEqual(&record.Hash) {
+			return fmt.Errorf("Block %v is not the finalized block %v", record.Hash.String(), hash.String())
+		}
+		return errBlockExisted
+	}
+	if preView == nil {
+		return fmt.Errorf("Block does not link to any view of the chain, the export file does not follow the chain")
+	}
+	return nil
+}
+
+// offlineServer is the Server of a chain importing blocks without network, it has no
+// peer to push blocks and views to.
+type offlineServer struct{}
+
+func (offlineServer) PushBlockToAll(block types.BlockInterface, previousValidationData string, isBeacon bool) error {
+	return nil
+}
+
+func (offlineServer) PushMessageToBeacon(msg wire.Message, exclusivePeerIDs map[libp2p.ID]bool) error {
+	return nil
+}
+
+func (offlineServer) RequestMissingViewViaStream(peerID string, hashes [][]byte, fromCID int, chainName string) error {
+	return nil
+}
+
+func (offlineServer) InsertNewShardView(*ShardBestState) {}
+
+func (offlineServer) InsertNewBeaconView(*BeaconBestState) {}
+
+// importSyncker is the Syncker of a chain importing blocks without network. It serves
+// the cross shard blocks read from the export file with the shard block being inserted.
+type importSyncker struct {
+	crossShardBlocks []*types.CrossShardBlock
+}
+
+func (s *importSyncker) GetCrossShardBlocksForShardProducer(view *ShardBestState, list map[byte][]uint64) map[byte][]interface{} {
+	res := make(map[byte][]interface{})
+	for fromShard, heights := range list {
+		for _, height := range heights {
+			for _, block := range s.crossShardBlocks {
+				if byte(block.GetShardID()) == fromShard && block.GetHeight() == height && block.ToShardID == view.ShardID {
+					res[fromShard] = append(res[fromShard], block)
+					break
+				}
+			}
+		}
+	}
+	return res
+}
+
+func (s *importSyncker) GetCrossShardBlocksForShardValidator(view *ShardBestState, list map[byte][]uint64) (map[byte][]interface{}, error) {
+	res := s.GetCrossShardBlocksForShardProducer(view, list)
+	for fromShard, heights := range list {
+		if len(res[fromShard]) != len(heights) {
+			return nil, fmt.Errorf("Export file misses cross shard blocks %v of shard %v", heights, fromShard)
+		}
+	}
+	return res, nil
+}
+
+func (s *importSyncker) SyncMissingBeaconBlock(ctx context.Context, peerID string, fromHash common.Hash) {
+}
+
+func (s *importSyncker) SyncMissingShardBlock(ctx context.Context, peerID string, sid byte, fromHash common.Hash) {
+}
+
+func (s *importSyncker) ReceiveBlock(block interface{}, previousValidationData string, peerID string) {
+}
@@ -0,0 +1,179 @@
+package blockchain
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/incognitochain/incognito-chain/blockchain/types"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/common/base58"
+	"github.com/incognitochain/incognito-chain/privacy"
+	"github.com/incognitochain/incognito-chain/pubsub"
+	"github.com/incognitochain/incognito-chain/transaction"
+	coinIndexer "github.com/incognitochain/incognito-chain/transaction/coin_indexer"
+)
+
+// wakeIncomingCoinNotifier asks the incoming coin notifier to scan the newly finalized shard blocks.
+// It never blocks the block insertion.
+func (blockchain *BlockChain) wakeIncomingCoinNotifier() {
+	if blockchain.incomingCoinSignal == nil {
+		return
+	}
+	select {
+	case blockchain.incomingCoinSignal <- struct{}{}:
+	default:
+	}
+}
+
+// runIncomingCoinNotifier scans the finalized shard blocks for output coins of the OTA keys submitted to the coin
+// indexer, and emits an IncomingCoin event for each of them. The blocks are scanned in order from the cursor persisted
+// by the indexer, so that no block is skipped after a restart.
+func (blockchain *BlockChain) runIncomingCoinNotifier() {
+	for {
+		for shardID := 0; shardID < common.MaxShardNumber; shardID++ {
+			if err := blockchain.notifyIncomingCoinsOfShard(byte(shardID)); err != nil {
+				Logger.log.Errorf("SHARD %+v | Notify incoming coins error: %v", shardID, err)
+			}
+		}
+		select {
+		case <-blockchain.incomingCoinSignal:
+		case <-blockchain.cQuitSync:
+			return
+		}
+	}
+}
+
+func (blockchain *BlockChain) notifyIncomingCoinsOfShard(shardID byte) error {
+	finalView := blockchain.ShardChain[shardID].GetFinalView()
+	finalHeight := finalView.GetHeight()
+	cursor, ok, err := outcoinIndexer.GetIncomingCoinCursor(shardID)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		// start with the blocks finalized from now on
+		return outcoinIndexer.SaveIncomingCoins(shardID, finalHeight, nil)
+	}
+
+	for height := cursor + 1; height <= finalHeight; height++ {
+		var incomingCoins []*coinIndexer.IncomingCoin
+		if outcoinIndexer.HasManagedOTAKeys(shardID) {
+			shardBlock, err := blockchain.GetShardBlockByView(finalView, height, shardID)
+			if err != nil {
+				return err
+			}
+			incomingCoins = blockchain.findIncomingCoins(shardBlock)
+		}
+		if err := outcoinIndexer.SaveIncomingCoins(shardID, height, incomingCoins); err != nil {
+			return err
+		}
+		if len(incomingCoins) > 0 {
+			Logger.log.Infof("SHARD %+v | Found %v incoming coin(s) of submitted OTA keys in block %v", shardID, len(incomingCoins), height)
+			blockchain.config.PubSubManager.PublishMessage(pubsub.NewMessage(pubsub.IncomingCoinTopic, incomingCoins))
+		}
+	}
+	return nil
+}
+
+// findIncomingCoins returns the IncomingCoin events of a shard block. The transaction hash of a cross-shard coin is
+// left empty if the block of the sender shard is not available on this node.
+func (blockchain *BlockChain) findIncomingCoins(shardBlock *types.ShardBlock) []*coinIndexer.IncomingCoin {
+	incomingCoins := outcoinIndexer.FindIncomingCoins(getBlockOutputCoins(shardBlock), shardBlock.Header.ShardID, shardBlock.Header.Height, shardBlock.Header.Hash())
+	for _, incomingCoin := range incomingCoins {
+		if incomingCoin.IsCrossShard {
+			txHash, err := blockchain.findCrossShardTxHash(shardBlock, incomingCoin.CoinPublicKey)
+			if err != nil {
+				Logger.log.Warnf("Cannot find the transaction of cross-shard coin %v: %v", incomingCoin.CoinPublicKey, err)
+				continue
+			}
+			incomingCoin.TxHash = txHash.String()
+		}
+	}
+	return incomingCoins
+}
+
+// getBlockOutputCoins returns the output coins created by the transactions and cross-shard transactions of a shard block.
+func getBlockOutputCoins(shardBlock *types.ShardBlock) []coinIndexer.BlockOutputCoin {
+	res := make([]coinIndexer.BlockOutputCoin, 0)
+	appendCoins := func(proof privacy.Proof, txHash common.Hash, tokenID *common.Hash) {
+		if proof == nil {
+			return
+		}
+		for _, outputCoin := range proof.GetOutputCoins() {
+			res = append(res, coinIndexer.BlockOutputCoin{Coin: outputCoin, TxHash: txHash, TokenID: tokenID})
+		}
+	}
+	for _, tx := range shardBlock.Body.Transactions {
+		switch tx.GetType() {
+		case common.TxNormalType, common.TxRewardType, common.TxReturnStakingType, common.TxConversionType:
+			appendCoins(tx.GetProof(), *tx.Hash(), &common.PRVCoinID)
+		case common.TxCustomTokenPrivacyType, common.TxTokenConversionType:
+			txToken, ok := tx.(transaction.TransactionToken)
+			if !ok {
+				continue
+			}
+			tokenData := txToken.GetTxTokenData()
+			tokenID := tokenData.PropertyID
+			appendCoins(txToken.GetTxBase().GetProof(), *tx.Hash(), &common.PRVCoinID)
+			if tokenData.TxNormal != nil {
+				appendCoins(tokenData.TxNormal.GetProof(), *tx.Hash(), &tokenID)
+			}
+		}
+	}
+
+	senderShardIDs := make([]int, 0)
+	for senderShardID := range shardBlock.Body.CrossTransactions {
+		senderShardIDs = append(senderShardIDs, int(senderShardID))
+	}
+	sort.Ints(senderShardIDs)
+	for _, senderShardID := range senderShardIDs {
+		for _, crossTransaction := range shardBlock.Body.CrossTransactions[byte(senderShardID)] {
+			for _, outputCoin := range crossTransaction.OutputCoin {
+				res = append(res, coinIndexer.BlockOutputCoin{Coin: outputCoin, TokenID: &common.PRVCoinID, IsCrossShard: true})
+			}
+			for _, tokenPrivacyData := range crossTransaction.TokenPrivacyData {
+				tokenID := tokenPrivacyData.PropertyID
+				for _, outputCoin := range tokenPrivacyData.OutputCoin {
+					res = append(res, coinIndexer.BlockOutputCoin{Coin: outputCoin, TokenID: &tokenID, IsCrossShard: true})
+				}
+			}
+		}
+	}
+	return res
+}
+
+// findCrossShardTxHash looks for the transaction of the sender shard block creating the output coin with the given public key.
+func (blockchain *BlockChain) findCrossShardTxHash(shardBlock *types.ShardBlock, coinPublicKey string) (*common.Hash, error) {
+	isWantedCoin := func(outputCoin privacy.Coin) bool {
+		return base58.Base58Check{}.Encode(outputCoin.GetPublicKey().ToBytesS(), common.ZeroByte) == coinPublicKey
+	}
+	for _, crossTransactions := range shardBlock.Body.CrossTransactions {
+		for _, crossTransaction := range crossTransactions {
+			found := false
+			for _, outputCoin := range crossTransaction.OutputCoin {
+				found = found || isWantedCoin(outputCoin)
+			}
+			for _, tokenPrivacyData := range crossTransaction.TokenPrivacyData {
+				for _, outputCoin := range tokenPrivacyData.OutputCoin {
+					found = found || isWantedCoin(outputCoin)
+				}
+			}
+			if !found {
+				continue
+			}
+
+			senderBlock, _, err := blockchain.GetShardBlockByHash(crossTransaction.BlockHash)
+			if err != nil {
+				return nil, err
+			}
+			for _, blockOutputCoin := range getBlockOutputCoins(senderBlock) {
+				if !blockOutputCoin.IsCrossShard && isWantedCoin(blockOutputCoin.Coin) {
+					txHash := blockOutputCoin.TxHash
+					return &txHash, nil
+				}
+			}
+			return nil, fmt.Errorf("output coin %v not found in block %v", coinPublicKey, crossTransaction.BlockHash.String())
+		}
+	}
+	return nil, fmt.Errorf("output coin %v not found in cross-shard transactions", coinPublicKey)
+}
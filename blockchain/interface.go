@@ -55,6 +55,7 @@ type Server interface {
 	PushMessageToBeacon(msg wire.Message, exclusivePeerIDs map[libp2p.ID]bool) error
 	RequestMissingViewViaStream(peerID string, hashes [][]byte, fromCID int, chainName string) (err error)
 	InsertNewShardView(*ShardBestState)
+	InsertNewBeaconView(*BeaconBestState)
 }
 
 type Highway interface {
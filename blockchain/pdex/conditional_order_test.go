@@ -0,0 +1,207 @@
+package pdex
+
+import (
+	"math/big"
+	"strconv"
+	"testing"
+
+	v2 "github.com/incognitochain/incognito-chain/blockchain/pdex/v2utils"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
+	instruction "github.com/incognitochain/incognito-chain/instruction/pdexv3"
+	metadataCommon "github.com/incognitochain/incognito-chain/metadata/common"
+	metadataPdexv3 "github.com/incognitochain/incognito-chain/metadata/pdexv3"
+	"github.com/incognitochain/incognito-chain/privacy"
+	. "github.com/stretchr/testify/assert"
+)
+
+func newConditionalOrderTestRequest(
+	t *testing.T, sellAmount, minAcceptableAmount, expiryHeight uint64, timeInForce byte,
+) *metadataPdexv3.AddOrderRequest {
+	recv := privacy.OTAReceiver{}
+	NoError(t, recv.FromString(validOTAReceiver0))
+	md, _ := metadataPdexv3.NewAddOrderRequest(
+		simulationTokenA, "A-B", sellAmount, minAcceptableAmount,
+		map[common.Hash]privacy.OTAReceiver{simulationTokenA: recv, simulationTokenB: recv},
+		common.HashH([]byte("nft")), metadataCommon.Pdexv3AddOrderRequestMeta,
+	)
+	md.ExpiryHeight = expiryHeight
+	md.TimeInForce = timeInForce
+	return md
+}
+
+func produceConditionalOrder(
+	t *testing.T, md *metadataPdexv3.AddOrderRequest, pairs map[string]*PoolPairState, beaconHeight uint64,
+) [][]string {
+	env := skipToProduce([]metadataCommon.Metadata{md}, 0)
+	params := newSimulationTestParams()
+	params.MaxOrdersPerNft = 10
+	sp := &stateProducerV2{}
+	insts, _, err := sp.addOrder(
+		env.ListTxs()[0], pairs, map[string]uint64{md.NftID.String(): 100},
+		params, map[string]uint{}, beaconHeight,
+	)
+	NoError(t, err)
+	return insts
+}
+
+func tradingFeeOf(accepted *metadataPdexv3.AcceptedTrade) uint64 {
+	fee := uint64(0)
+	for _, amount := range accepted.RewardEarned[0] {
+		fee += amount
+	}
+	return fee
+}
+
+func orderIDsOf(orderChanges map[string][2]*big.Int) []string {
+	result := []string{}
+	for id := range orderChanges {
+		result = append(result, id)
+	}
+	return result
+}
+
+func TestProduceImmediateOrder(t *testing.T) {
+	setTestTradeConfig()
+	pairs := map[string]*PoolPairState{
+		"A-B": newSimulationTestPair(simulationTokenA, simulationTokenB, 1000000, 1000000),
+	}
+
+	// the limit is reached in the pool before selling everything
+	insts := produceConditionalOrder(t, newConditionalOrderTestRequest(t, 10000, 9800, 0, metadataPdexv3.OrderImmediateOrCancel), pairs, 10)
+	Equal(t, 2, len(insts))
+	Equal(t, strconv.Itoa(metadataCommon.Pdexv3TradeRequestMeta), insts[0][0])
+	Equal(t, strconv.Itoa(metadataPdexv3.TradeAcceptedStatus), insts[0][1])
+	accepted := &metadataPdexv3.AcceptedTrade{}
+	NoError(t, (&instruction.Action{Content: accepted}).FromStringSlice(insts[0]))
+	Equal(t, strconv.Itoa(metadataPdexv3.OrderRefundedStatus), insts[1][1])
+	refunded := &metadataPdexv3.RefundedAddOrder{}
+	NoError(t, (&instruction.Action{Content: refunded}).FromStringSlice(insts[1]))
+	Equal(t, simulationTokenA, refunded.TokenID)
+
+	sold := accepted.PairChanges[0][0].Uint64()
+	fee := tradingFeeOf(accepted)
+	Equal(t, uint64(10000), sold+fee+refunded.Amount)
+	Equal(t, (sold*30+BPS-1)/BPS, fee)
+	// the rate for the amount paid is not worse than the limit
+	True(t, accepted.Amount*10000 >= 9800*(sold+fee))
+	// the pool state is updated for the next requests in the block
+	Equal(t, 1000000+sold, pairs["A-B"].state.Token0RealAmount())
+
+	// a fill-or-kill order that cannot be matched in full is refunded
+	pairs["A-B"] = newSimulationTestPair(simulationTokenA, simulationTokenB, 1000000, 1000000)
+	insts = produceConditionalOrder(t, newConditionalOrderTestRequest(t, 10000, 9800, 0, metadataPdexv3.OrderFillOrKill), pairs, 10)
+	Equal(t, 1, len(insts))
+	NoError(t, (&instruction.Action{Content: refunded}).FromStringSlice(insts[0]))
+	Equal(t, uint64(10000), refunded.Amount)
+	Equal(t, uint64(1000000), pairs["A-B"].state.Token0RealAmount())
+
+	// a fill-or-kill order within the pool's depth is matched in full, fee included
+	insts = produceConditionalOrder(t, newConditionalOrderTestRequest(t, 10000, 9000, 0, metadataPdexv3.OrderFillOrKill), pairs, 10)
+	Equal(t, 1, len(insts))
+	NoError(t, (&instruction.Action{Content: accepted}).FromStringSlice(insts[0]))
+	Equal(t, uint64(9970), accepted.PairChanges[0][0].Uint64())
+	Equal(t, uint64(30), tradingFeeOf(accepted))
+}
+
+func TestImmediateOrderMatchesOrderbook(t *testing.T) {
+	setTestTradeConfig()
+	// sell1 orders at 2 tokenB per tokenA, then at 0.5 tokenB per tokenA
+	goodOrder := rawdbv2.NewPdexv3OrderWithValue("good", common.HashH([]byte("nft")), 1000, 2000, 0, 2000, v2.TradeDirectionSell1, [2]string{})
+	badOrder := rawdbv2.NewPdexv3OrderWithValue("bad", common.HashH([]byte("nft")), 2000, 1000, 0, 1000, v2.TradeDirectionSell1, [2]string{})
+	pair := newSimulationTestPair(simulationTokenA, simulationTokenB, 1000000, 1000000)
+	pair.orderbook.InsertOrder(goodOrder)
+	pair.orderbook.InsertOrder(badOrder)
+	pairs := map[string]*PoolPairState{"A-B": pair}
+
+	insts := produceConditionalOrder(t, newConditionalOrderTestRequest(t, 100000, 98000, 0, metadataPdexv3.OrderImmediateOrCancel), pairs, 10)
+	accepted := &metadataPdexv3.AcceptedTrade{}
+	NoError(t, (&instruction.Action{Content: accepted}).FromStringSlice(insts[0]))
+	// only the order above the limit is matched, then the pool is swapped down to the limit
+	Equal(t, []string{"good"}, orderIDsOf(accepted.OrderChanges[0]))
+	Equal(t, big.NewInt(1000), accepted.OrderChanges[0]["good"][0])
+	True(t, accepted.PairChanges[0][0].Sign() > 0)
+	for _, ord := range pairs["A-B"].orderbook.Orders() {
+		if ord.Id() == "good" {
+			Equal(t, uint64(0), ord.Token1Balance())
+		} else {
+			Equal(t, uint64(1000), ord.Token1Balance())
+		}
+	}
+	Equal(t, uint64(2000), goodOrder.Token1Balance()) // the previous state is not changed
+}
+
+func TestProduceExpiredOrder(t *testing.T) {
+	setTestTradeConfig()
+	pairs := map[string]*PoolPairState{
+		"A-B": newSimulationTestPair(simulationTokenA, simulationTokenB, 1000000, 1000000),
+	}
+	insts := produceConditionalOrder(t, newConditionalOrderTestRequest(t, 10000, 9000, 10, metadataPdexv3.OrderGoodTillCancelled), pairs, 10)
+	Equal(t, 1, len(insts))
+	Equal(t, strconv.Itoa(metadataPdexv3.OrderRefundedStatus), insts[0][1])
+
+	insts = produceConditionalOrder(t, newConditionalOrderTestRequest(t, 10000, 9000, 11, metadataPdexv3.OrderGoodTillCancelled), pairs, 10)
+	Equal(t, 1, len(insts))
+	accepted := &metadataPdexv3.AcceptedAddOrder{}
+	NoError(t, (&instruction.Action{Content: accepted}).FromStringSlice(insts[0]))
+	Equal(t, uint64(11), accepted.ExpiryHeight)
+}
+
+func TestAutoWithdrawExpiredOrder(t *testing.T) {
+	setTestTradeConfig()
+	orderID := common.HashH([]byte("order")).String()
+	order := rawdbv2.NewPdexv3OrderWithValue(orderID, common.HashH([]byte("nft")), 1000, 900, 1000, 0, v2.TradeDirectionSell0,
+		[2]string{validOTAReceiver0, validOTAReceiver0})
+	order.SetExpiryHeight(20)
+	pairs := map[string]*PoolPairState{
+		"A-B": newSimulationTestPair(simulationTokenA, simulationTokenB, 1000000, 1000000, order),
+	}
+	sp := &stateProducerV2{}
+	insts, _, err := sp.withdrawAllMatchedOrders(pairs, 10, 19)
+	NoError(t, err)
+	Empty(t, insts)
+
+	insts, _, err = sp.withdrawAllMatchedOrders(pairs, 10, 20)
+	NoError(t, err)
+	Equal(t, 1, len(insts))
+	withdrawn := &metadataPdexv3.AcceptedWithdrawOrder{}
+	NoError(t, (&instruction.Action{Content: withdrawn}).FromStringSlice(insts[0]))
+	Equal(t, orderID, withdrawn.OrderID)
+	Equal(t, uint64(1000), withdrawn.Amount)
+	Equal(t, uint64(0), order.Token0Balance())
+}
+
+func TestExpiredOrderIsNotMatched(t *testing.T) {
+	setTestTradeConfig()
+	newPairs := func() (map[string]*PoolPairState, *Order) {
+		expiringOrder := rawdbv2.NewPdexv3OrderWithValue("expiring", common.HashH([]byte("nft")), 1000, 2000, 0, 2000, v2.TradeDirectionSell1, [2]string{})
+		expiringOrder.SetExpiryHeight(10)
+		pair := newSimulationTestPair(simulationTokenA, simulationTokenB, 1000000, 1000000)
+		pair.orderbook.InsertOrder(expiringOrder)
+		return map[string]*PoolPairState{"A-B": pair}, expiringOrder
+	}
+
+	// the order is matched before its expiry height
+	pairs, _ := newPairs()
+	insts := produceConditionalOrder(t, newConditionalOrderTestRequest(t, 100000, 98000, 0, metadataPdexv3.OrderImmediateOrCancel), pairs, 9)
+	accepted := &metadataPdexv3.AcceptedTrade{}
+	NoError(t, (&instruction.Action{Content: accepted}).FromStringSlice(insts[0]))
+	Equal(t, []string{"expiring"}, orderIDsOf(accepted.OrderChanges[0]))
+
+	// an expired order that is not withdrawn yet stays in the orderbook, but is not matched
+	pairs, expiringOrder := newPairs()
+	insts = produceConditionalOrder(t, newConditionalOrderTestRequest(t, 100000, 98000, 0, metadataPdexv3.OrderImmediateOrCancel), pairs, 10)
+	accepted = &metadataPdexv3.AcceptedTrade{}
+	NoError(t, (&instruction.Action{Content: accepted}).FromStringSlice(insts[0]))
+	Empty(t, accepted.OrderChanges[0])
+	ord, exists := pairs["A-B"].orderbook.Order("expiring")
+	True(t, exists)
+	Equal(t, uint64(2000), ord.Token1Balance())
+	Equal(t, uint64(2000), expiringOrder.Token1Balance())
+	Equal(t, uint64(0), pairs["A-B"].orderbook.beaconHeight)
+
+	pairs, _ = newPairs()
+	result, err := SimulateTrade(simulationTokenA, []string{"A-B"}, 1000, false, pairs, newSimulationTestParams(), 10)
+	NoError(t, err)
+	Empty(t, result.MatchedOrders)
+}
@@ -23,6 +23,11 @@ const (
 	MaxPRVDiscountPercent = 75
 )
 
+// trade path search
+const (
+	MaxTradePathPoolsPerToken = 5 // pools with the most liquidity of a token tried at each intermediate hop
+)
+
 // PDEX token
 const (
 	MintingBlockReward = 45000000                 // without multiply with denominating rate
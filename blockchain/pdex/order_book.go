@@ -1,159 +1,317 @@
-package pdex
-
-import (
-	"encoding/json"
-	"fmt"
-	"math/big"
-	"reflect"
-	"sort"
-	"strings"
-
-	v2 "github.com/incognitochain/incognito-chain/blockchain/pdex/v2utils"
-	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
-)
-
-type Order = rawdbv2.Pdexv3Order
-
-type Orderbook struct {
-	orders []*Order
-}
-
-func (ob Orderbook) MarshalJSON() ([]byte, error) {
-	temp := struct {
-		Orders []*Order `json:"orders,omitempty"`
-	}{ob.orders}
-	return json.Marshal(temp)
-}
-
-func (ob *Orderbook) UnmarshalJSON(data []byte) error {
-	var temp struct {
-		Orders []*Order `json:"orders"`
-	}
-	err := json.Unmarshal(data, &temp)
-	if err != nil {
-		return err
-	}
-	ob.orders = temp.Orders
-	return nil
-}
-
-// InsertOrder() appends a new order while keeping the list sorted (ascending by Token1Rate / Token0Rate)
-func (ob *Orderbook) InsertOrder(ord *Order) {
-	insertAt := func(lst []*Order, i int, newItem *Order) []*Order {
-		if i == len(lst) {
-			return append(lst, newItem)
-		}
-		lst = append(lst[:i+1], lst[i:]...)
-		lst[i] = newItem
-		return lst
-	}
-	index := sort.Search(len(ob.orders), func(i int) bool {
-		ordRate := big.NewInt(0).SetUint64(ob.orders[i].Token0Rate())
-		ordRate.Mul(ordRate, big.NewInt(0).SetUint64(ord.Token1Rate()))
-		myRate := big.NewInt(0).SetUint64(ob.orders[i].Token1Rate())
-		myRate.Mul(myRate, big.NewInt(0).SetUint64(ord.Token0Rate()))
-
-		// compare Token1Rate / Token0Rate of current order in the list to ord
-		rateCmp := ordRate.Cmp(myRate)
-		// break equality of rate by comparing ID
-		if rateCmp == 0 {
-			// sell0 orders must precede sell1 orders of the same rate
-			if ord.TradeDirection() != ob.orders[i].TradeDirection() {
-				return ord.TradeDirection() == v2.TradeDirectionSell0
-			}
-			// no equality for ID since duplicate ID was handled in addOrder flow
-			idCmp := strings.Compare(ord.Id(), ob.orders[i].Id())
-			// best rate for sell0 is at start of list, so we put smaller ID first to match. The opposite is true for sell1
-			if ord.TradeDirection() == v2.TradeDirectionSell0 {
-				return idCmp < 0
-			} else {
-				return idCmp > 0
-			}
-		}
-
-		return ordRate.Cmp(myRate) < 0
-	})
-	ob.orders = insertAt(ob.orders, index, ord)
-}
-
-// NextOrder() returns the matchable order with the best rate that has any outstanding balance to sell
-func (ob *Orderbook) NextOrder(tradeDirection byte) (*v2.MatchingOrder, string, error) {
-	lstLen := len(ob.orders)
-	switch tradeDirection {
-	case v2.TradeDirectionSell0:
-		for i := lstLen - 1; i >= 0; i-- {
-			currentOrder := &v2.MatchingOrder{ob.orders[i]}
-			if check, err := currentOrder.CanMatch(tradeDirection); check && err == nil {
-				return currentOrder, ob.orders[i].Id(), nil
-			}
-		}
-		// no active order
-		return nil, "", nil
-	case v2.TradeDirectionSell1:
-		for i := 0; i < lstLen; i++ {
-			currentOrder := &v2.MatchingOrder{ob.orders[i]}
-			if check, err := currentOrder.CanMatch(tradeDirection); check && err == nil {
-				return currentOrder, ob.orders[i].Id(), nil
-			}
-		}
-		// no active order
-		return nil, "", nil
-	default:
-		return nil, "", fmt.Errorf("Invalid trade direction %d", tradeDirection)
-	}
-}
-
-// RemoveOrder() removes one order by its index
-func (ob *Orderbook) RemoveOrder(index int) error {
-	if index < 0 || index >= len(ob.orders) {
-		return fmt.Errorf("Invalid order index %d for orderbook length %d", index, len(ob.orders))
-	}
-	ob.orders = append(ob.orders[:index], ob.orders[index+1:]...)
-	return nil
-}
-
-func (ob *Orderbook) getDiff(otherBook *Orderbook,
-	poolPairChange *v2.PoolPairChange) *v2.PoolPairChange {
-	newPoolPairChange := poolPairChange
-	theirOrdersByID := make(map[string]*Order)
-	for _, ord := range otherBook.orders {
-		theirOrdersByID[ord.Id()] = ord
-	}
-	myOrdersByID := make(map[string]*Order)
-	for _, ord := range ob.orders {
-		myOrdersByID[ord.Id()] = ord
-	}
-
-	// mark new & updated orders as changed
-	for _, ord := range ob.orders {
-		if existingOrder, exists := theirOrdersByID[ord.Id()]; !exists ||
-			!reflect.DeepEqual(*ord, *existingOrder) {
-			newPoolPairChange.OrderIDs[ord.Id()] = true
-		}
-	}
-
-	// mark deleted orders as changed
-	for _, ord := range otherBook.orders {
-		if _, exists := myOrdersByID[ord.Id()]; !exists {
-			newPoolPairChange.OrderIDs[ord.Id()] = true
-		}
-	}
-	return newPoolPairChange
-}
-
-func (ob *Orderbook) Clone() Orderbook {
-	result := &Orderbook{make([]*Order, len(ob.orders))}
-	for index, item := range ob.orders {
-		var temp Order = *item
-		result.orders[index] = &temp
-	}
-	return *result
-}
-
-func (ob *Orderbook) NftIDs() map[string]string {
-	result := make(map[string]string)
-	for _, ord := range ob.orders {
-		result[ord.Id()] = ord.NftID().String()
-	}
-	return result
-}
+package pdex
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math/bits"
+	"reflect"
+	"strings"
+
+	v2 "github.com/incognitochain/incognito-chain/blockchain/pdex/v2utils"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
+)
+
+type Order = rawdbv2.Pdexv3Order
+
+// Orderbook keeps the orders of a pool pair in one treap per trade direction, so that inserting, removing
+// & finding the best order to match take O(log n). Node priorities are derived from order IDs, which makes the shape
+// of a treap depend only on the orders it holds. The zero value is an empty orderbook;
+// an orderbook must be cloned before being changed apart from its copies
+type Orderbook struct {
+	sell0 *orderNode
+	sell1 *orderNode
+	byID  map[string]*Order // nil when empty
+	// beacon height at which the orderbook is matched; orders expired at this height are not matched.
+	// Only set on the orderbooks of a trade path (see TradePathFromState())
+	beaconHeight uint64
+}
+
+type orderNode struct {
+	order    *Order
+	priority uint64
+	left     *orderNode
+	right    *orderNode
+}
+
+func newOrderNode(ord *Order) *orderNode {
+	h := common.HashH([]byte(ord.Id()))
+	return &orderNode{order: ord, priority: binary.BigEndian.Uint64(h[:8])}
+}
+
+func (ob Orderbook) MarshalJSON() ([]byte, error) {
+	temp := struct {
+		Orders []*Order `json:"orders,omitempty"`
+	}{ob.Orders()}
+	return json.Marshal(temp)
+}
+
+func (ob *Orderbook) UnmarshalJSON(data []byte) error {
+	var temp struct {
+		Orders []*Order `json:"orders"`
+	}
+	err := json.Unmarshal(data, &temp)
+	if err != nil {
+		return err
+	}
+	*ob = Orderbook{}
+	for _, ord := range temp.Orders {
+		ob.InsertOrder(ord)
+	}
+	return nil
+}
+
+// InsertOrder() adds a new order to the orderbook, replacing any order of the same ID
+// (duplicate IDs are handled in addOrder flow)
+func (ob *Orderbook) InsertOrder(ord *Order) {
+	if _, exists := ob.byID[ord.Id()]; exists {
+		ob.RemoveOrder(ord.Id())
+	}
+	if ob.byID == nil {
+		ob.byID = make(map[string]*Order)
+	}
+	ob.byID[ord.Id()] = ord
+	root := ob.root(ord.TradeDirection())
+	left, right := splitOrderNodes(*root, ord)
+	*root = mergeOrderNodes(mergeOrderNodes(left, newOrderNode(ord)), right)
+}
+
+// NextOrder() returns the unexpired matchable order with the best rate that has any outstanding balance to sell
+func (ob *Orderbook) NextOrder(tradeDirection byte) (*v2.MatchingOrder, string, error) {
+	var result *v2.MatchingOrder
+	canMatch := func(ord *Order) bool {
+		if isOrderExpired(ord, ob.beaconHeight) {
+			// left in the orderbook until withdrawAllMatchedOrders() withdraws it
+			return true
+		}
+		currentOrder := &v2.MatchingOrder{ord}
+		if check, err := currentOrder.CanMatch(tradeDirection); check && err == nil {
+			result = currentOrder
+			return false
+		}
+		return true
+	}
+	switch tradeDirection {
+	case v2.TradeDirectionSell0:
+		// sell1 orders with the highest Token1Rate / Token0Rate first
+		walkOrderNodes(ob.sell1, true, canMatch)
+	case v2.TradeDirectionSell1:
+		// sell0 orders with the lowest Token1Rate / Token0Rate first
+		walkOrderNodes(ob.sell0, false, canMatch)
+	default:
+		return nil, "", fmt.Errorf("Invalid trade direction %d", tradeDirection)
+	}
+	if result == nil {
+		// no active order
+		return nil, "", nil
+	}
+	return result, result.Id(), nil
+}
+
+// isOrderExpired() checks if an order with an expiry height has expired at beaconHeight
+func isOrderExpired(ord *Order, beaconHeight uint64) bool {
+	return ord.ExpiryHeight() != 0 && beaconHeight >= ord.ExpiryHeight()
+}
+
+// RemoveOrder() removes one order by its ID
+func (ob *Orderbook) RemoveOrder(id string) error {
+	ord, exists := ob.byID[id]
+	if !exists {
+		return fmt.Errorf("Cannot find order ID %s in orderbook", id)
+	}
+	root := ob.root(ord.TradeDirection())
+	*root = removeOrderNode(*root, ord)
+	delete(ob.byID, id)
+	if len(ob.byID) == 0 {
+		ob.byID = nil
+	}
+	return nil
+}
+
+// Order() returns the order of an ID
+func (ob *Orderbook) Order(id string) (*Order, bool) {
+	ord, exists := ob.byID[id]
+	return ord, exists
+}
+
+func (ob *Orderbook) Len() int {
+	return len(ob.byID)
+}
+
+// Orders() returns all orders sorted ascending by Token1Rate / Token0Rate (see lessOrder())
+func (ob *Orderbook) Orders() []*Order {
+	if len(ob.byID) == 0 {
+		return nil
+	}
+	var sell0, sell1 []*Order
+	walkOrderNodes(ob.sell0, false, func(ord *Order) bool {
+		sell0 = append(sell0, ord)
+		return true
+	})
+	walkOrderNodes(ob.sell1, false, func(ord *Order) bool {
+		sell1 = append(sell1, ord)
+		return true
+	})
+	result := make([]*Order, 0, len(sell0)+len(sell1))
+	for len(sell0) > 0 && len(sell1) > 0 {
+		if lessOrder(sell1[0], sell0[0]) {
+			result = append(result, sell1[0])
+			sell1 = sell1[1:]
+		} else {
+			result = append(result, sell0[0])
+			sell0 = sell0[1:]
+		}
+	}
+	result = append(result, sell0...)
+	return append(result, sell1...)
+}
+
+func (ob *Orderbook) getDiff(otherBook *Orderbook,
+	poolPairChange *v2.PoolPairChange) *v2.PoolPairChange {
+	newPoolPairChange := poolPairChange
+
+	// mark new & updated orders as changed
+	for id, ord := range ob.byID {
+		if existingOrder, exists := otherBook.byID[id]; !exists ||
+			!reflect.DeepEqual(*ord, *existingOrder) {
+			newPoolPairChange.OrderIDs[id] = true
+		}
+	}
+
+	// mark deleted orders as changed
+	for id := range otherBook.byID {
+		if _, exists := ob.byID[id]; !exists {
+			newPoolPairChange.OrderIDs[id] = true
+		}
+	}
+	return newPoolPairChange
+}
+
+func (ob *Orderbook) Clone() Orderbook {
+	result := Orderbook{}
+	if len(ob.byID) != 0 {
+		result.byID = make(map[string]*Order, len(ob.byID))
+	}
+	result.sell0 = cloneOrderNodes(ob.sell0, result.byID)
+	result.sell1 = cloneOrderNodes(ob.sell1, result.byID)
+	return result
+}
+
+func (ob *Orderbook) NftIDs() map[string]string {
+	result := make(map[string]string)
+	for id, ord := range ob.byID {
+		result[id] = ord.NftID().String()
+	}
+	return result
+}
+
+func (ob *Orderbook) root(tradeDirection byte) **orderNode {
+	if tradeDirection == v2.TradeDirectionSell0 {
+		return &ob.sell0
+	}
+	return &ob.sell1
+}
+
+// lessOrder() sorts orders ascending by Token1Rate / Token0Rate. Among orders of the same rate, sell0 orders precede
+// sell1 orders, and the smaller ID is matched first: it comes first for sell0 orders, which are matched from the start,
+// and last for sell1 orders, which are matched from the end
+func lessOrder(a, b *Order) bool {
+	// compare Token1Rate / Token0Rate by comparing 128-bit products
+	aHi, aLo := bits.Mul64(a.Token1Rate(), b.Token0Rate())
+	bHi, bLo := bits.Mul64(b.Token1Rate(), a.Token0Rate())
+	if aHi != bHi {
+		return aHi < bHi
+	}
+	if aLo != bLo {
+		return aLo < bLo
+	}
+	if a.TradeDirection() != b.TradeDirection() {
+		return a.TradeDirection() == v2.TradeDirectionSell0
+	}
+	idCmp := strings.Compare(a.Id(), b.Id())
+	if a.TradeDirection() == v2.TradeDirectionSell0 {
+		return idCmp < 0
+	}
+	return idCmp > 0
+}
+
+// hasHigherPriority() breaks equal priorities by ID so that the shape of a treap is unique
+func (node *orderNode) hasHigherPriority(other *orderNode) bool {
+	if node.priority != other.priority {
+		return node.priority > other.priority
+	}
+	return node.order.Id() < other.order.Id()
+}
+
+// splitOrderNodes() divides a treap into the orders before ord & the others
+func splitOrderNodes(node *orderNode, ord *Order) (*orderNode, *orderNode) {
+	if node == nil {
+		return nil, nil
+	}
+	if lessOrder(node.order, ord) {
+		left, right := splitOrderNodes(node.right, ord)
+		node.right = left
+		return node, right
+	}
+	left, right := splitOrderNodes(node.left, ord)
+	node.left = right
+	return left, node
+}
+
+// mergeOrderNodes() joins two treaps where all orders of left precede those of right
+func mergeOrderNodes(left, right *orderNode) *orderNode {
+	if left == nil {
+		return right
+	}
+	if right == nil {
+		return left
+	}
+	if left.hasHigherPriority(right) {
+		left.right = mergeOrderNodes(left.right, right)
+		return left
+	}
+	right.left = mergeOrderNodes(left, right.left)
+	return right
+}
+
+func removeOrderNode(node *orderNode, ord *Order) *orderNode {
+	if node == nil {
+		return nil
+	}
+	if node.order.Id() == ord.Id() {
+		return mergeOrderNodes(node.left, node.right)
+	}
+	if lessOrder(ord, node.order) {
+		node.left = removeOrderNode(node.left, ord)
+	} else {
+		node.right = removeOrderNode(node.right, ord)
+	}
+	return node
+}
+
+// walkOrderNodes() visits the orders of a treap in ascending (or descending) order until visit() returns false
+func walkOrderNodes(node *orderNode, descending bool, visit func(*Order) bool) bool {
+	if node == nil {
+		return true
+	}
+	first, second := node.left, node.right
+	if descending {
+		first, second = second, first
+	}
+	return walkOrderNodes(first, descending, visit) && visit(node.order) && walkOrderNodes(second, descending, visit)
+}
+
+func cloneOrderNodes(node *orderNode, byID map[string]*Order) *orderNode {
+	if node == nil {
+		return nil
+	}
+	var temp Order = *node.order
+	byID[temp.Id()] = &temp
+	return &orderNode{
+		order:    &temp,
+		priority: node.priority,
+		left:     cloneOrderNodes(node.left, byID),
+		right:    cloneOrderNodes(node.right, byID),
+	}
+}
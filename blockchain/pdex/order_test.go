@@ -3,12 +3,19 @@ package pdex
 import (
 	"encoding/json"
 	"fmt"
+	"math/big"
 	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/incognitochain/incognito-chain/blockchain/pdex/v2utils"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
 	metadataCommon "github.com/incognitochain/incognito-chain/metadata/common"
 	metadataPdexv3 "github.com/incognitochain/incognito-chain/metadata/pdexv3"
+	"github.com/incognitochain/incognito-chain/privacy"
 	. "github.com/stretchr/testify/assert"
 )
 
@@ -34,7 +41,7 @@ func TestSortOrder(t *testing.T) {
 			testState := newStateV2WithValue(nil, nil, make(map[string]*PoolPairState),
 				&Params{}, nil, map[string]uint64{})
 			blankPairID := "pair0"
-			testState.poolPairs[blankPairID] = &PoolPairState{orderbook: Orderbook{[]*Order{}}}
+			testState.poolPairs[blankPairID] = &PoolPairState{orderbook: Orderbook{}}
 
 			var testdata TestData
 			err := json.Unmarshal(testcase.Data, &testdata)
@@ -57,7 +64,7 @@ func TestSortOrder(t *testing.T) {
 				testState.poolPairs[blankPairID] = pair
 			}
 
-			result := TestResult{Orders: testState.poolPairs[blankPairID].orderbook.orders}
+			result := TestResult{Orders: testState.poolPairs[blankPairID].orderbook.Orders()}
 			// test the outputs of NextOrder()
 			ord, id, err := testState.poolPairs[blankPairID].orderbook.NextOrder(v2utils.TradeDirectionSell0)
 			NoError(t, err)
@@ -130,7 +137,7 @@ func TestAutoWithdraw(t *testing.T) {
 				DefaultFeeRateBPS: 30,
 			})
 
-			instructions, _, err := testState.producer.withdrawAllMatchedOrders(testState.poolPairs, testdata.Limit)
+			instructions, _, err := testState.producer.withdrawAllMatchedOrders(testState.poolPairs, testdata.Limit, 0)
 			NoError(t, err)
 			Equal(t, expected, TestResult{instructions})
 		})
@@ -209,3 +216,295 @@ func TestProcessOrder(t *testing.T) {
 		})
 	}
 }
+
+func newTestOrderbook(orders ...*Order) Orderbook {
+	result := Orderbook{}
+	for _, ord := range orders {
+		result.InsertOrder(ord)
+	}
+	return result
+}
+
+// legacyOrderbook is the sorted-slice orderbook that Orderbook replaced, kept to check that both match orders alike
+type legacyOrderbook struct {
+	orders []*Order
+}
+
+func (ob *legacyOrderbook) InsertOrder(ord *Order) {
+	index := sort.Search(len(ob.orders), func(i int) bool {
+		ordRate := big.NewInt(0).SetUint64(ob.orders[i].Token0Rate())
+		ordRate.Mul(ordRate, big.NewInt(0).SetUint64(ord.Token1Rate()))
+		myRate := big.NewInt(0).SetUint64(ob.orders[i].Token1Rate())
+		myRate.Mul(myRate, big.NewInt(0).SetUint64(ord.Token0Rate()))
+		rateCmp := ordRate.Cmp(myRate)
+		if rateCmp == 0 {
+			if ord.TradeDirection() != ob.orders[i].TradeDirection() {
+				return ord.TradeDirection() == v2utils.TradeDirectionSell0
+			}
+			idCmp := strings.Compare(ord.Id(), ob.orders[i].Id())
+			if ord.TradeDirection() == v2utils.TradeDirectionSell0 {
+				return idCmp < 0
+			}
+			return idCmp > 0
+		}
+		return rateCmp < 0
+	})
+	ob.orders = append(ob.orders, nil)
+	copy(ob.orders[index+1:], ob.orders[index:])
+	ob.orders[index] = ord
+}
+
+func (ob *legacyOrderbook) NextOrder(tradeDirection byte) (*v2utils.MatchingOrder, string, error) {
+	lstLen := len(ob.orders)
+	switch tradeDirection {
+	case v2utils.TradeDirectionSell0:
+		for i := lstLen - 1; i >= 0; i-- {
+			currentOrder := &v2utils.MatchingOrder{ob.orders[i]}
+			if check, err := currentOrder.CanMatch(tradeDirection); check && err == nil {
+				return currentOrder, ob.orders[i].Id(), nil
+			}
+		}
+		return nil, "", nil
+	case v2utils.TradeDirectionSell1:
+		for i := 0; i < lstLen; i++ {
+			currentOrder := &v2utils.MatchingOrder{ob.orders[i]}
+			if check, err := currentOrder.CanMatch(tradeDirection); check && err == nil {
+				return currentOrder, ob.orders[i].Id(), nil
+			}
+		}
+		return nil, "", nil
+	default:
+		return nil, "", fmt.Errorf("Invalid trade direction %d", tradeDirection)
+	}
+}
+
+func (ob *legacyOrderbook) RemoveOrder(index int) {
+	ob.orders = append(ob.orders[:index], ob.orders[index+1:]...)
+}
+
+func (ob *legacyOrderbook) NftIDs() map[string]string {
+	result := make(map[string]string)
+	for _, ord := range ob.orders {
+		result[ord.Id()] = ord.NftID().String()
+	}
+	return result
+}
+
+// newRandomTestOrder() uses few distinct rates so that many orders tie on rate
+func newRandomTestOrder(r *rand.Rand, id int) *Order {
+	token0Rate := uint64(r.Intn(20) + 1)
+	token1Rate := uint64(r.Intn(20) + 1)
+	tradeDirection := byte(r.Intn(2))
+	var token0Balance, token1Balance uint64
+	if r.Intn(5) > 0 {
+		if tradeDirection == v2utils.TradeDirectionSell0 {
+			token0Balance = token0Rate * uint64(r.Intn(1000)+1)
+		} else {
+			token1Balance = token1Rate * uint64(r.Intn(1000)+1)
+		}
+	}
+	return rawdbv2.NewPdexv3OrderWithValue(
+		common.HashH([]byte(strconv.Itoa(id))).String(), common.HashH([]byte("nft")),
+		token0Rate, token1Rate, token0Balance, token1Balance, tradeDirection, [2]string{},
+	)
+}
+
+func mustMatchLegacyOrderbook(t *testing.T, ob *Orderbook, legacy *legacyOrderbook) {
+	Equal(t, len(legacy.orders), ob.Len())
+	orders := ob.Orders()
+	for i := range legacy.orders {
+		Equal(t, *legacy.orders[i], *orders[i])
+	}
+	for _, tradeDirection := range []byte{v2utils.TradeDirectionSell0, v2utils.TradeDirectionSell1} {
+		_, id, err := ob.NextOrder(tradeDirection)
+		NoError(t, err)
+		_, legacyID, _ := legacy.NextOrder(tradeDirection)
+		Equal(t, legacyID, id)
+	}
+	data, err := json.Marshal(ob)
+	NoError(t, err)
+	legacyData, _ := json.Marshal(struct {
+		Orders []*Order `json:"orders,omitempty"`
+	}{legacy.orders})
+	Equal(t, string(legacyData), string(data))
+}
+
+func TestOrderbookConsensusEquivalence(t *testing.T) {
+	setTestTradeConfig()
+	r := rand.New(rand.NewSource(1))
+	ob := &Orderbook{}
+	legacy := &legacyOrderbook{}
+	nextID := 0
+	for step := 0; step < 3000; step++ {
+		switch op := r.Intn(10); {
+		case op < 6 || legacy.orders == nil:
+			ord := newRandomTestOrder(r, nextID)
+			nextID++
+			temp := *ord
+			ob.InsertOrder(ord)
+			legacy.InsertOrder(&temp)
+		case op < 8:
+			index := r.Intn(len(legacy.orders))
+			NoError(t, ob.RemoveOrder(legacy.orders[index].Id()))
+			legacy.RemoveOrder(index)
+		default:
+			// fill the next order of a random direction
+			tradeDirection := byte(r.Intn(2))
+			ord, id, err := ob.NextOrder(tradeDirection)
+			NoError(t, err)
+			legacyOrd, _, _ := legacy.NextOrder(tradeDirection)
+			if ord == nil {
+				Nil(t, legacyOrd)
+				continue
+			}
+			Equal(t, legacyOrd.Id(), id)
+			for _, o := range []*v2utils.MatchingOrder{ord, legacyOrd} {
+				if o.TradeDirection() == v2utils.TradeDirectionSell0 {
+					o.SetToken1Balance(o.Token0Balance())
+					o.SetToken0Balance(0)
+				} else {
+					o.SetToken0Balance(o.Token1Balance())
+					o.SetToken1Balance(0)
+				}
+			}
+		}
+		mustMatchLegacyOrderbook(t, ob, legacy)
+	}
+	Error(t, ob.RemoveOrder("missing"))
+
+	// JSON round trip & clone keep the same orderbook
+	data, err := json.Marshal(ob)
+	NoError(t, err)
+	decoded := &Orderbook{}
+	NoError(t, json.Unmarshal(data, decoded))
+	Equal(t, ob, decoded)
+	cloned := ob.Clone()
+	Equal(t, *ob, cloned)
+	mustMatchLegacyOrderbook(t, &cloned, legacy)
+
+	// trades through both orderbooks produce the same results
+	for _, tradeDirection := range []byte{v2utils.TradeDirectionSell0, v2utils.TradeDirectionSell1} {
+		for _, amount := range []uint64{100, 100000, 10000000} {
+			book := ob.Clone()
+			legacyBook := &legacyOrderbook{}
+			for _, ord := range legacy.orders {
+				temp := *ord
+				legacyBook.orders = append(legacyBook.orders, &temp)
+			}
+			trade := func(orderbook v2utils.OrderBookIterator) (*metadataPdexv3.AcceptedTrade, *rawdbv2.Pdexv3PoolPair) {
+				reserve := newSimulationTestPair(simulationTokenA, simulationTokenB, 1000000, 2000000).state
+				accepted, _, err := v2utils.MaybeAcceptTrade(
+					amount, 0, []string{"A-B"}, privacy.OTAReceiver{}, []*rawdbv2.Pdexv3PoolPair{&reserve},
+					[]map[common.Hash]*big.Int{{}}, []map[common.Hash]uint64{{}}, []map[common.Hash]uint64{{}},
+					[]byte{tradeDirection}, simulationTokenB, 0, []v2utils.OrderBookIterator{orderbook},
+				)
+				NoError(t, err)
+				return accepted, &reserve
+			}
+			accepted, reserve := trade(&book)
+			legacyAccepted, legacyReserve := trade(legacyBook)
+			Equal(t, legacyAccepted, accepted)
+			Equal(t, legacyReserve, reserve)
+			mustMatchLegacyOrderbook(t, &book, legacyBook)
+		}
+	}
+}
+
+func newBenchmarkOrders(n int) []*Order {
+	r := rand.New(rand.NewSource(1))
+	result := make([]*Order, n)
+	for i := range result {
+		result[i] = rawdbv2.NewPdexv3OrderWithValue(
+			common.HashH([]byte(strconv.Itoa(i))).String(), common.HashH([]byte("nft")),
+			uint64(r.Intn(1000000)+1), uint64(r.Intn(1000000)+1), 1000000, 1000000, byte(i%2), [2]string{},
+		)
+	}
+	return result
+}
+
+func benchmarkInsertOrder(b *testing.B, n int, insert func([]*Order)) {
+	orders := newBenchmarkOrders(n)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		insert(orders)
+	}
+}
+
+func BenchmarkOrderbookInsertOrder(b *testing.B) {
+	benchmarkInsertOrder(b, 10000, func(orders []*Order) {
+		ob := Orderbook{}
+		for _, ord := range orders {
+			ob.InsertOrder(ord)
+		}
+	})
+}
+
+func BenchmarkLegacyOrderbookInsertOrder(b *testing.B) {
+	benchmarkInsertOrder(b, 10000, func(orders []*Order) {
+		ob := legacyOrderbook{}
+		for _, ord := range orders {
+			ob.InsertOrder(ord)
+		}
+	})
+}
+
+// benchmarkNextOrder() looks up the next order of each direction in a book where
+// most orders are filled, the common state before the filled orders are withdrawn
+func benchmarkNextOrder(b *testing.B, orderbook v2utils.OrderBookIterator, orders []*Order) {
+	for i, ord := range orders {
+		if i%10 != 0 {
+			ord.SetToken0Balance(0)
+			ord.SetToken1Balance(0)
+		}
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		orderbook.NextOrder(v2utils.TradeDirectionSell0)
+		orderbook.NextOrder(v2utils.TradeDirectionSell1)
+	}
+}
+
+func BenchmarkOrderbookNextOrder(b *testing.B) {
+	orders := newBenchmarkOrders(10000)
+	ob := newTestOrderbook(orders...)
+	benchmarkNextOrder(b, &ob, orders)
+}
+
+func BenchmarkLegacyOrderbookNextOrder(b *testing.B) {
+	orders := newBenchmarkOrders(10000)
+	ob := &legacyOrderbook{}
+	for _, ord := range orders {
+		ob.InsertOrder(ord)
+	}
+	benchmarkNextOrder(b, ob, orders)
+}
+
+func BenchmarkOrderbookRemoveOrder(b *testing.B) {
+	orders := newBenchmarkOrders(10000)
+	ob := newTestOrderbook(orders...)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ord := orders[i%len(orders)]
+		ob.RemoveOrder(ord.Id())
+		ob.InsertOrder(ord)
+	}
+}
+
+func BenchmarkLegacyOrderbookRemoveOrder(b *testing.B) {
+	orders := newBenchmarkOrders(10000)
+	ob := &legacyOrderbook{}
+	for _, ord := range orders {
+		ob.InsertOrder(ord)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ord := orders[i%len(orders)]
+		for index, item := range ob.orders {
+			if item.Id() == ord.Id() {
+				ob.RemoveOrder(index)
+				break
+			}
+		}
+		ob.InsertOrder(ord)
+	}
+}
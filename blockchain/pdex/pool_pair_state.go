@@ -34,7 +34,7 @@ func NewPoolPairState() *PoolPairState {
 		orderRewards:      make(map[string]*OrderReward),
 		shares:            make(map[string]*Share),
 		state:             *rawdbv2.NewPdexv3PoolPair(),
-		orderbook:         Orderbook{[]*Order{}},
+		orderbook:         Orderbook{},
 		lpFeesPerShare:    make(map[common.Hash]*big.Int),
 		lmRewardsPerShare: make(map[common.Hash]*big.Int),
 		protocolFees:      make(map[common.Hash]uint64),
@@ -227,7 +227,7 @@ func initPoolPairState(contribution0, contribution1 rawdbv2.Pdexv3Contribution)
 	return NewPoolPairStateWithValue(
 		*poolPairState,
 		make(map[string]*Share),
-		Orderbook{[]*Order{}},
+		Orderbook{},
 		make(map[common.Hash]*big.Int), make(map[common.Hash]*big.Int),
 		make(map[common.Hash]uint64), make(map[common.Hash]uint64),
 		make(map[common.Hash]*MakingVolume), make(map[string]*OrderReward),
@@ -378,8 +378,8 @@ func (p *PoolPairState) getDiff(
 			makingVolumeChange = makingVolume.getDiff(nil, makingVolumeChange)
 			poolPairChange.MakingVolume[tokenID.String()] = makingVolumeChange
 		}
-		for _, ord := range p.orderbook.orders {
-			newPoolPairChange.OrderIDs[ord.Id()] = true
+		for orderID := range p.orderbook.byID {
+			newPoolPairChange.OrderIDs[orderID] = true
 		}
 		for tokenID := range p.lmRewardsPerShare {
 			newPoolPairChange.LmRewardsPerShare[tokenID.String()] = true
@@ -831,13 +831,9 @@ func (p *PoolPairState) updateToDB(
 	}
 
 	// store / delete orders
-	ordersByID := make(map[string]*Order)
-	for _, ord := range p.orderbook.orders {
-		ordersByID[ord.Id()] = ord
-	}
 	for orderID, changed := range poolPairChange.OrderIDs {
 		if changed {
-			if order, exists := ordersByID[orderID]; exists {
+			if order, exists := p.orderbook.Order(orderID); exists {
 				// update order in db
 				orderState := statedb.NewPdexv3OrderStateWithValue(poolPairID, *order)
 				err = statedb.StorePdexv3Order(env.StateDB(), *orderState)
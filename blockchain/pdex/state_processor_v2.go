@@ -499,13 +499,8 @@ func (sp *stateProcessorV2) trade(
 				)
 			}
 
-			orderbook := pair.orderbook
-			ordersById := make(map[string]*Order)
-			for _, ord := range orderbook.orders {
-				ordersById[ord.Id()] = ord
-			}
 			for id, change := range md.OrderChanges[index] {
-				currentOrder, exists := ordersById[id]
+				currentOrder, exists := pair.orderbook.Order(id)
 				if !exists {
 					return pairs, fmt.Errorf("Cannot find order ID %s for trade", id)
 				}
@@ -674,6 +669,7 @@ func (sp *stateProcessorV2) addOrder(
 		// starts from 0 and will accumulate over time
 		newOrder := rawdbv2.NewPdexv3OrderWithValue(md.OrderID, md.NftID, md.Token0Rate, md.Token1Rate,
 			md.Token0Balance, md.Token1Balance, md.TradeDirection, md.Receiver)
+		newOrder.SetExpiryHeight(md.ExpiryHeight)
 		pair.orderbook.InsertOrder(newOrder)
 		// write changes to state
 		pairs[md.PoolPairID] = pair
@@ -732,29 +728,31 @@ func (sp *stateProcessorV2) withdrawOrder(
 			return pairs, fmt.Errorf("Cannot find pair %s for new order", md.PoolPairID)
 		}
 
-		for index, ord := range pair.orderbook.orders {
-			if ord.Id() == md.OrderID {
-				if md.TokenID == pair.state.Token0ID() {
-					newBalance := ord.Token0Balance() - md.Amount
-					if newBalance > ord.Token0Balance() {
-						return pairs, fmt.Errorf("Cannot withdraw more than current token0 balance from order %s",
-							md.OrderID)
-					}
-					ord.SetToken0Balance(newBalance)
-					// remove order when both balances are cleared
-					if newBalance == 0 && ord.Token1Balance() == 0 {
-						pair.orderbook.RemoveOrder(index)
-					}
-				} else if md.TokenID == pair.state.Token1ID() {
-					newBalance := ord.Token1Balance() - md.Amount
-					if newBalance > ord.Token1Balance() {
-						return pairs, fmt.Errorf("Cannot withdraw more than current token1 balance from order %s",
-							md.OrderID)
+		if ord, exists := pair.orderbook.Order(md.OrderID); exists {
+			if md.TokenID == pair.state.Token0ID() {
+				newBalance := ord.Token0Balance() - md.Amount
+				if newBalance > ord.Token0Balance() {
+					return pairs, fmt.Errorf("Cannot withdraw more than current token0 balance from order %s",
+						md.OrderID)
+				}
+				ord.SetToken0Balance(newBalance)
+				// remove order when both balances are cleared
+				if newBalance == 0 && ord.Token1Balance() == 0 {
+					if err := pair.orderbook.RemoveOrder(md.OrderID); err != nil {
+						return pairs, err
 					}
-					ord.SetToken1Balance(newBalance)
-					// remove order when both balances are cleared
-					if newBalance == 0 && ord.Token0Balance() == 0 {
-						pair.orderbook.RemoveOrder(index)
+				}
+			} else if md.TokenID == pair.state.Token1ID() {
+				newBalance := ord.Token1Balance() - md.Amount
+				if newBalance > ord.Token1Balance() {
+					return pairs, fmt.Errorf("Cannot withdraw more than current token1 balance from order %s",
+						md.OrderID)
+				}
+				ord.SetToken1Balance(newBalance)
+				// remove order when both balances are cleared
+				if newBalance == 0 && ord.Token0Balance() == 0 {
+					if err := pair.orderbook.RemoveOrder(md.OrderID); err != nil {
+						return pairs, err
 					}
 				}
 			}
@@ -460,7 +460,7 @@ func Test_stateProcessorV2_matchContribution(t *testing.T) {
 					},
 					orderRewards:  map[string]*OrderReward{},
 					makingVolume:  map[common.Hash]*MakingVolume{},
-					orderbook:     Orderbook{[]*Order{}},
+					orderbook:     Orderbook{},
 					lmLockedShare: map[string]map[uint64]uint64{},
 				},
 			},
@@ -877,7 +877,7 @@ func Test_stateProcessorV2_acceptWithdrawLiquidity(t *testing.T) {
 								lastLmRewardsPerShare: map[common.Hash]*big.Int{},
 							},
 						},
-						orderbook:     Orderbook{[]*Order{}},
+						orderbook:     Orderbook{},
 						lmLockedShare: map[string]map[uint64]uint64{},
 					},
 				},
@@ -902,7 +902,7 @@ func Test_stateProcessorV2_acceptWithdrawLiquidity(t *testing.T) {
 							lastLmRewardsPerShare: map[common.Hash]*big.Int{},
 						},
 					},
-					orderbook:     Orderbook{[]*Order{}},
+					orderbook:     Orderbook{},
 					lmLockedShare: map[string]map[uint64]uint64{},
 				},
 			},
@@ -933,7 +933,7 @@ func Test_stateProcessorV2_acceptWithdrawLiquidity(t *testing.T) {
 								lastLmRewardsPerShare: map[common.Hash]*big.Int{},
 							},
 						},
-						orderbook:     Orderbook{[]*Order{}},
+						orderbook:     Orderbook{},
 						lmLockedShare: map[string]map[uint64]uint64{},
 					},
 				},
@@ -958,7 +958,7 @@ func Test_stateProcessorV2_acceptWithdrawLiquidity(t *testing.T) {
 							lastLmRewardsPerShare: map[common.Hash]*big.Int{},
 						},
 					},
-					orderbook:     Orderbook{[]*Order{}},
+					orderbook:     Orderbook{},
 					lmLockedShare: map[string]map[uint64]uint64{},
 				},
 			},
@@ -989,7 +989,7 @@ func Test_stateProcessorV2_acceptWithdrawLiquidity(t *testing.T) {
 								lastLmRewardsPerShare: map[common.Hash]*big.Int{},
 							},
 						},
-						orderbook:     Orderbook{[]*Order{}},
+						orderbook:     Orderbook{},
 						lmLockedShare: map[string]map[uint64]uint64{},
 					},
 				},
@@ -1014,7 +1014,7 @@ func Test_stateProcessorV2_acceptWithdrawLiquidity(t *testing.T) {
 							lastLmRewardsPerShare: map[common.Hash]*big.Int{},
 						},
 					},
-					orderbook:     Orderbook{[]*Order{}},
+					orderbook:     Orderbook{},
 					lmLockedShare: map[string]map[uint64]uint64{},
 				},
 			},
@@ -1045,7 +1045,7 @@ func Test_stateProcessorV2_acceptWithdrawLiquidity(t *testing.T) {
 								lastLmRewardsPerShare: map[common.Hash]*big.Int{},
 							},
 						},
-						orderbook:     Orderbook{[]*Order{}},
+						orderbook:     Orderbook{},
 						lmLockedShare: map[string]map[uint64]uint64{},
 					},
 				},
@@ -1070,7 +1070,7 @@ func Test_stateProcessorV2_acceptWithdrawLiquidity(t *testing.T) {
 							lastLmRewardsPerShare: map[common.Hash]*big.Int{},
 						},
 					},
-					orderbook:     Orderbook{[]*Order{}},
+					orderbook:     Orderbook{},
 					lmLockedShare: map[string]map[uint64]uint64{},
 				},
 			},
@@ -1100,7 +1100,7 @@ func Test_stateProcessorV2_acceptWithdrawLiquidity(t *testing.T) {
 								lastLmRewardsPerShare: map[common.Hash]*big.Int{},
 							},
 						},
-						orderbook:         Orderbook{[]*Order{}},
+						orderbook:         Orderbook{},
 						lpFeesPerShare:    map[common.Hash]*big.Int{},
 						lmRewardsPerShare: map[common.Hash]*big.Int{},
 						protocolFees:      map[common.Hash]uint64{},
@@ -1125,7 +1125,7 @@ func Test_stateProcessorV2_acceptWithdrawLiquidity(t *testing.T) {
 							lastLmRewardsPerShare: map[common.Hash]*big.Int{},
 						},
 					},
-					orderbook:         Orderbook{[]*Order{}},
+					orderbook:         Orderbook{},
 					lpFeesPerShare:    map[common.Hash]*big.Int{},
 					lmRewardsPerShare: map[common.Hash]*big.Int{},
 					protocolFees:      map[common.Hash]uint64{},
@@ -1165,7 +1165,7 @@ func Test_stateProcessorV2_acceptWithdrawLiquidity(t *testing.T) {
 								lastLmRewardsPerShare: map[common.Hash]*big.Int{},
 							},
 						},
-						orderbook:     Orderbook{[]*Order{}},
+						orderbook:     Orderbook{},
 						lmLockedShare: map[string]map[uint64]uint64{},
 					},
 				},
@@ -1190,7 +1190,7 @@ func Test_stateProcessorV2_acceptWithdrawLiquidity(t *testing.T) {
 							lastLmRewardsPerShare: map[common.Hash]*big.Int{},
 						},
 					},
-					orderbook:     Orderbook{[]*Order{}},
+					orderbook:     Orderbook{},
 					lmLockedShare: map[string]map[uint64]uint64{},
 				},
 			},
@@ -1621,7 +1621,7 @@ func Test_stateProcessorV2_feeTrackingAddLiquidity(t *testing.T) {
 					},
 					orderRewards: map[string]*OrderReward{},
 					makingVolume: map[common.Hash]*MakingVolume{},
-					orderbook:    Orderbook{[]*Order{}},
+					orderbook:    Orderbook{},
 					lmLockedShare: map[string]map[uint64]uint64{
 						nftID: {
 							11: 200,
@@ -1752,7 +1752,7 @@ func Test_stateProcessorV2_feeTrackingWithdrawLiquidity(t *testing.T) {
 								lastLmRewardsPerShare: map[common.Hash]*big.Int{},
 							},
 						},
-						orderbook:     Orderbook{[]*Order{}},
+						orderbook:     Orderbook{},
 						lmLockedShare: map[string]map[uint64]uint64{},
 					},
 				},
@@ -1797,7 +1797,7 @@ func Test_stateProcessorV2_feeTrackingWithdrawLiquidity(t *testing.T) {
 							},
 						},
 					},
-					orderbook:     Orderbook{[]*Order{}},
+					orderbook:     Orderbook{},
 					lmLockedShare: map[string]map[uint64]uint64{},
 				},
 			},
@@ -1833,7 +1833,7 @@ func Test_stateProcessorV2_feeTrackingWithdrawLiquidity(t *testing.T) {
 								lastLmRewardsPerShare: map[common.Hash]*big.Int{},
 							},
 						},
-						orderbook:     Orderbook{[]*Order{}},
+						orderbook:     Orderbook{},
 						lmLockedShare: map[string]map[uint64]uint64{},
 					},
 				},
@@ -1873,7 +1873,7 @@ func Test_stateProcessorV2_feeTrackingWithdrawLiquidity(t *testing.T) {
 							lastLmRewardsPerShare: map[common.Hash]*big.Int{},
 						},
 					},
-					orderbook:     Orderbook{[]*Order{}},
+					orderbook:     Orderbook{},
 					lmLockedShare: map[string]map[uint64]uint64{},
 				},
 			},
@@ -1916,7 +1916,7 @@ func Test_stateProcessorV2_feeTrackingWithdrawLiquidity(t *testing.T) {
 							},
 						},
 						lmLockedShare: map[string]map[uint64]uint64{},
-						orderbook:     Orderbook{[]*Order{}},
+						orderbook:     Orderbook{},
 					},
 				},
 				beaconHeight: 20,
@@ -1972,7 +1972,7 @@ func Test_stateProcessorV2_feeTrackingWithdrawLiquidity(t *testing.T) {
 						},
 					},
 					lmLockedShare: map[string]map[uint64]uint64{},
-					orderbook:     Orderbook{[]*Order{}},
+					orderbook:     Orderbook{},
 				},
 			},
 			wantErr: false,
@@ -2021,7 +2021,7 @@ func Test_stateProcessorV2_feeTrackingWithdrawLiquidity(t *testing.T) {
 							},
 						},
 						lmLockedShare: map[string]map[uint64]uint64{},
-						orderbook:     Orderbook{[]*Order{}},
+						orderbook:     Orderbook{},
 					},
 				},
 				beaconHeight: 20,
@@ -2077,7 +2077,7 @@ func Test_stateProcessorV2_feeTrackingWithdrawLiquidity(t *testing.T) {
 						},
 					},
 					lmLockedShare: map[string]map[uint64]uint64{},
-					orderbook:     Orderbook{[]*Order{}},
+					orderbook:     Orderbook{},
 				},
 			},
 			wantErr: false,
@@ -2311,7 +2311,7 @@ func Test_stateProcessorV2_distributeMiningOrderReward(t *testing.T) {
 								lastLPFeesPerShare: map[common.Hash]*big.Int{},
 							},
 						},
-						orderbook:    Orderbook{[]*Order{}},
+						orderbook:    Orderbook{},
 						orderRewards: map[string]*OrderReward{},
 						makingVolume: map[common.Hash]*MakingVolume{
 							*token0ID: &MakingVolume{
@@ -2341,7 +2341,7 @@ func Test_stateProcessorV2_distributeMiningOrderReward(t *testing.T) {
 							lastLPFeesPerShare: map[common.Hash]*big.Int{},
 						},
 					},
-					orderbook: Orderbook{[]*Order{}},
+					orderbook: Orderbook{},
 					orderRewards: map[string]*OrderReward{
 						nftID: {
 							uncollectedRewards: map[common.Hash]uint64{
@@ -2382,7 +2382,7 @@ func Test_stateProcessorV2_distributeMiningOrderReward(t *testing.T) {
 								lastLPFeesPerShare: map[common.Hash]*big.Int{},
 							},
 						},
-						orderbook:    Orderbook{[]*Order{}},
+						orderbook:    Orderbook{},
 						orderRewards: map[string]*OrderReward{},
 						makingVolume: map[common.Hash]*MakingVolume{
 							*token0ID: &MakingVolume{
@@ -2418,7 +2418,7 @@ func Test_stateProcessorV2_distributeMiningOrderReward(t *testing.T) {
 							lastLPFeesPerShare: map[common.Hash]*big.Int{},
 						},
 					},
-					orderbook: Orderbook{[]*Order{}},
+					orderbook: Orderbook{},
 					orderRewards: map[string]*OrderReward{
 						nftID: {
 							uncollectedRewards: map[common.Hash]uint64{
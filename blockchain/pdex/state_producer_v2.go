@@ -418,6 +418,7 @@ func (sp *stateProducerV2) trade(
 	txs []metadata.Transaction,
 	pairs map[string]*PoolPairState,
 	params *Params,
+	beaconHeight uint64,
 ) ([][]string, map[string]*PoolPairState, error) {
 	result := [][]string{}
 	var invalidTxs []metadataCommon.Transaction
@@ -480,7 +481,7 @@ func (sp *stateProducerV2) trade(
 
 		// get relevant, cloned data from state for the trade path
 		reserves, lpFeesPerShares, protocolFees, stakingPoolFees, orderbookList, tradeDirections, tokenToBuy, err :=
-			TradePathFromState(currentTrade.TokenToSell, currentTrade.TradePath, pairs)
+			TradePathFromState(currentTrade.TokenToSell, currentTrade.TradePath, pairs, beaconHeight)
 		tradeOutputReceiver, exists := currentTrade.Receiver[tokenToBuy]
 		// anytime the trade handler fails, add a refund instruction
 		if err != nil || !exists {
@@ -520,20 +521,10 @@ func (sp *stateProducerV2) trade(
 		}
 
 		// apply state changes for trade consistency in the same block
-		for index, pairID := range currentTrade.TradePath {
-			changedPair := pairs[pairID]
-			changedPair.state = *reserves[index]
-			addOrderReward(changedPair.orderRewards, orderRewardsChanges[index])
-			if _, ok := params.PDEXRewardPoolPairsShare[pairID]; ok && params.DAOContributingPercent > 0 {
-				addMakingVolume(changedPair.makingVolume, orderMakingChanges[index])
-			}
-			changedPair.lpFeesPerShare = lpFeesPerShares[index]
-			changedPair.protocolFees = protocolFees[index]
-			changedPair.stakingPoolFees = stakingPoolFees[index]
-			orderbook, _ := orderbookList[index].(*Orderbook) // type is determined; see TradePathFromState()
-			changedPair.orderbook = *orderbook
-			pairs[pairID] = changedPair
-		}
+		pairs = applyTradeChanges(
+			pairs, currentTrade.TradePath, reserves, lpFeesPerShares, protocolFees, stakingPoolFees,
+			orderbookList, orderRewardsChanges, orderMakingChanges, params,
+		)
 		// "accept" instruction
 		action := instruction.NewAction(
 			acceptedTradeMd,
@@ -560,12 +551,43 @@ func (sp *stateProducerV2) trade(
 	return result, pairs, nil
 }
 
+// applyTradeChanges() writes the cloned trade path data changed by a trade back to pairs
+func applyTradeChanges(
+	pairs map[string]*PoolPairState,
+	tradePath []string,
+	reserves []*rawdbv2.Pdexv3PoolPair,
+	lpFeesPerShares []map[common.Hash]*big.Int,
+	protocolFees, stakingPoolFees []map[common.Hash]uint64,
+	orderbookList []v2.OrderBookIterator,
+	orderRewardsChanges []map[string]map[common.Hash]uint64,
+	orderMakingChanges []map[common.Hash]map[string]*big.Int,
+	params *Params,
+) map[string]*PoolPairState {
+	for index, pairID := range tradePath {
+		changedPair := pairs[pairID]
+		changedPair.state = *reserves[index]
+		addOrderReward(changedPair.orderRewards, orderRewardsChanges[index])
+		if _, ok := params.PDEXRewardPoolPairsShare[pairID]; ok && params.DAOContributingPercent > 0 {
+			addMakingVolume(changedPair.makingVolume, orderMakingChanges[index])
+		}
+		changedPair.lpFeesPerShare = lpFeesPerShares[index]
+		changedPair.protocolFees = protocolFees[index]
+		changedPair.stakingPoolFees = stakingPoolFees[index]
+		orderbook, _ := orderbookList[index].(*Orderbook) // type is determined; see TradePathFromState()
+		changedPair.orderbook = *orderbook
+		changedPair.orderbook.beaconHeight = 0
+		pairs[pairID] = changedPair
+	}
+	return pairs
+}
+
 func (sp *stateProducerV2) addOrder(
 	txs []metadata.Transaction,
 	pairs map[string]*PoolPairState,
 	nftIDs map[string]uint64,
 	params *Params,
 	orderCountByNftID map[string]uint,
+	beaconHeight uint64,
 ) ([][]string, map[string]*PoolPairState, error) {
 	result := [][]string{}
 
@@ -588,13 +610,19 @@ TransactionLoop:
 			result = append(result, refundInstructions...)
 			continue TransactionLoop
 		}
-		// check that the nftID has not exceeded its order count limit
-		if orderCountByNftID[currentOrderReq.NftID.String()] >= params.MaxOrdersPerNft {
+		isImmediateOrder := currentOrderReq.TimeInForce != metadataPdexv3.OrderGoodTillCancelled
+		// check that the nftID has not exceeded its order count limit; immediate orders never rest in the orderbook
+		if !isImmediateOrder && orderCountByNftID[currentOrderReq.NftID.String()] >= params.MaxOrdersPerNft {
 			Logger.log.Warnf("AddOrder: NftID %s has reached order count limit of %d",
 				currentOrderReq.NftID.String(), params.MaxOrdersPerNft)
 			result = append(result, refundInstructions...)
 			continue TransactionLoop
 		}
+		if currentOrderReq.ExpiryHeight != 0 && currentOrderReq.ExpiryHeight <= beaconHeight {
+			Logger.log.Warnf("AddOrder: order expired at beacon height %d", currentOrderReq.ExpiryHeight)
+			result = append(result, refundInstructions...)
+			continue TransactionLoop
+		}
 
 		pair, exists := pairs[currentOrderReq.PoolPairID]
 		if !exists {
@@ -609,14 +637,11 @@ TransactionLoop:
 		}
 
 		orderID := tx.Hash().String()
-		orderbook := pair.orderbook
-		for _, ord := range orderbook.orders {
-			if ord.Id() == orderID {
-				Logger.log.Warnf("Cannot add existing order ID %s", orderID)
-				// on any error, append a refund instruction & continue to next tx
-				result = append(result, refundInstructions...)
-				continue TransactionLoop
-			}
+		if _, exists := pair.orderbook.Order(orderID); exists {
+			Logger.log.Warnf("Cannot add existing order ID %s", orderID)
+			// on any error, append a refund instruction & continue to next tx
+			result = append(result, refundInstructions...)
+			continue TransactionLoop
 		}
 
 		// prepare order data
@@ -651,6 +676,17 @@ TransactionLoop:
 			result = append(result, refundInstructions...)
 			continue TransactionLoop
 		}
+		if isImmediateOrder {
+			var immediateInstructions [][]string
+			immediateInstructions, pairs, err = matchImmediateOrder(tx, currentOrderReq, tokenToBuy, pairs, params, beaconHeight)
+			if err != nil {
+				Logger.log.Warnf("AddOrder: immediate order %s not matched: %v", orderID, err)
+				result = append(result, refundInstructions...)
+				continue TransactionLoop
+			}
+			result = append(result, immediateInstructions...)
+			continue TransactionLoop
+		}
 		token0RecvStr, _ := currentOrderReq.Receiver[pair.state.Token0ID()].String()
 		token1RecvStr, _ := currentOrderReq.Receiver[pair.state.Token1ID()].String()
 
@@ -667,6 +703,7 @@ TransactionLoop:
 			Token1Balance:  token1Balance,
 			TradeDirection: tradeDirection,
 			Receiver:       [2]string{token0RecvStr, token1RecvStr},
+			ExpiryHeight:   currentOrderReq.ExpiryHeight,
 		}
 
 		acceptedAction := instruction.NewAction(
@@ -681,6 +718,102 @@ TransactionLoop:
 	return result, pairs, nil
 }
 
+// matchImmediateOrder() trades an immediate-or-cancel or fill-or-kill order against the pool & orderbook of its pair,
+// at a rate not worse than MinAcceptableAmount for the whole SellAmount. The pool's trading fee is paid out of SellAmount.
+// The matched part is accepted as a trade of this request & the rest of SellAmount is refunded.
+// An error means the request is refunded in full
+func matchImmediateOrder(
+	tx metadata.Transaction,
+	req *metadataPdexv3.AddOrderRequest,
+	tokenToBuy common.Hash,
+	pairs map[string]*PoolPairState,
+	params *Params,
+	beaconHeight uint64,
+) ([][]string, map[string]*PoolPairState, error) {
+	feeRateBPS := params.DefaultFeeRateBPS
+	if customizedFee, ok := params.FeeRateBPS[req.PoolPairID]; ok {
+		feeRateBPS = customizedFee
+	}
+	// keep room for the fee: maxSellAmount + maxSellAmount * feeRateBPS / BPS <= SellAmount
+	maxSellAmount := new(big.Int).Mul(new(big.Int).SetUint64(req.SellAmount), big.NewInt(BPS))
+	maxSellAmount.Div(maxSellAmount, new(big.Int).SetUint64(uint64(BPS+feeRateBPS)))
+	if maxSellAmount.Sign() == 0 {
+		return nil, pairs, fmt.Errorf("Sell amount %d insufficient for trading fee", req.SellAmount)
+	}
+
+	// get relevant, cloned data from state for the order's pair
+	tradePath := []string{req.PoolPairID}
+	reserves, lpFeesPerShares, protocolFees, stakingPoolFees, orderbookList, tradeDirections, _, err :=
+		TradePathFromState(req.TokenToSell, tradePath, pairs, beaconHeight)
+	if err != nil {
+		return nil, pairs, err
+	}
+	// the limit rate applies to the amount sold after fee, so that SellAmount gets at least MinAcceptableAmount
+	acceptedTradeMd, sellAmountRemain, err := v2.MatchLimitOrder(
+		maxSellAmount.Uint64(), maxSellAmount.Uint64(), req.MinAcceptableAmount, req.PoolPairID,
+		req.Receiver[tokenToBuy], reserves[0], tradeDirections[0], tokenToBuy, orderbookList[0],
+	)
+	if err != nil {
+		return nil, pairs, err
+	}
+	soldAmount := maxSellAmount.Uint64() - sellAmountRemain
+	if acceptedTradeMd.Amount == 0 {
+		return nil, pairs, fmt.Errorf("No liquidity within the order rate")
+	}
+	if req.TimeInForce == metadataPdexv3.OrderFillOrKill &&
+		(sellAmountRemain > 0 || acceptedTradeMd.Amount < req.MinAcceptableAmount) {
+		return nil, pairs, fmt.Errorf("Fill-or-kill order matched %d of %d", soldAmount, maxSellAmount.Uint64())
+	}
+
+	// fee = ceil(soldAmount * feeRateBPS / BPS), which cannot exceed the amount left after selling
+	fee := new(big.Int).Mul(new(big.Int).SetUint64(soldAmount), new(big.Int).SetUint64(uint64(feeRateBPS)))
+	fee.Add(fee, big.NewInt(BPS-1))
+	fee.Div(fee, big.NewInt(BPS))
+	tradingFee := req.SellAmount - soldAmount
+	if fee.Uint64() < tradingFee {
+		tradingFee = fee.Uint64()
+	}
+
+	orderRewardsChanges := []map[string]map[common.Hash]uint64{{}}
+	orderMakingChanges := []map[common.Hash]map[string]*big.Int{{}}
+	if feeRateBPS == 0 {
+		acceptedTradeMd.RewardEarned = []map[common.Hash]uint64{{}}
+	} else {
+		acceptedTradeMd, orderRewardsChanges, orderMakingChanges, err = v2.TrackFee(
+			tradingFee, false, req.TokenToSell, BaseLPFeesPerShare, BPS,
+			tradePath, reserves, lpFeesPerShares, protocolFees, stakingPoolFees,
+			tradeDirections, orderbookList,
+			[]uint{feeRateBPS}, feeRateBPS,
+			acceptedTradeMd,
+			params.TradingProtocolFeePercent, params.TradingStakingPoolRewardPercent, params.StakingRewardTokens,
+			params.DefaultOrderTradingRewardRatioBPS, params.OrderTradingRewardRatioBPS,
+		)
+		if err != nil {
+			return nil, pairs, err
+		}
+	}
+	pairs = applyTradeChanges(
+		pairs, tradePath, reserves, lpFeesPerShares, protocolFees, stakingPoolFees,
+		orderbookList, orderRewardsChanges, orderMakingChanges, params,
+	)
+
+	shardID := byte(tx.GetValidationEnv().ShardID()) // sender & receiver shard must be the same
+	result := [][]string{instruction.NewAction(acceptedTradeMd, *tx.Hash(), shardID).StringSlice()}
+	if refundAmount := req.SellAmount - soldAmount - tradingFee; refundAmount > 0 {
+		refundAction := instruction.NewAction(
+			&metadataPdexv3.RefundedAddOrder{
+				Receiver: req.Receiver[req.TokenToSell],
+				TokenID:  req.TokenToSell,
+				Amount:   refundAmount,
+			},
+			*tx.Hash(),
+			shardID,
+		)
+		result = append(result, refundAction.StringSlice())
+	}
+	return result, pairs, nil
+}
+
 func (sp *stateProducerV2) withdrawOrder(
 	txs []metadata.Transaction,
 	pairs map[string]*PoolPairState,
@@ -726,91 +859,89 @@ TransactionLoop:
 		}
 
 		orderID := currentOrderReq.OrderID
-		for _, ord := range pair.orderbook.orders {
-			if ord.Id() == orderID {
-				if ord.NftID() == currentOrderReq.NftID {
-					withdrawResults := make(map[common.Hash]uint64)
-					_, withdrawToken0 := currentOrderReq.Receiver[pair.state.Token0ID()]
-					_, withdrawToken1 := currentOrderReq.Receiver[pair.state.Token1ID()]
-					accepted := false
-
-					if withdrawToken0 && withdrawToken1 {
-						if currentOrderReq.Amount != 0 {
-							Logger.log.Warnf("Invalid amount %v withdrawing both tokens from order %s (expect %d)",
-								currentOrderReq.Amount, orderID, 0)
-							result = append(result, refundAction.StringSlice())
-							continue TransactionLoop
-						}
+		if ord, exists := pair.orderbook.Order(orderID); exists {
+			if ord.NftID() == currentOrderReq.NftID {
+				withdrawResults := make(map[common.Hash]uint64)
+				_, withdrawToken0 := currentOrderReq.Receiver[pair.state.Token0ID()]
+				_, withdrawToken1 := currentOrderReq.Receiver[pair.state.Token1ID()]
+				accepted := false
+
+				if withdrawToken0 && withdrawToken1 {
+					if currentOrderReq.Amount != 0 {
+						Logger.log.Warnf("Invalid amount %v withdrawing both tokens from order %s (expect %d)",
+							currentOrderReq.Amount, orderID, 0)
+						result = append(result, refundAction.StringSlice())
+						continue TransactionLoop
 					}
+				}
 
-					// for each token in pool that will be withdrawn, cap withdrawAmount & set new balance in state
-					if withdrawToken0 {
-						currentBalance := ord.Token0Balance()
-						amt := currentOrderReq.Amount
-						if currentBalance < amt || amt == 0 {
-							amt = currentBalance
-						}
-						if amt > 0 {
-							ord.SetToken0Balance(currentBalance - amt)
-							withdrawResults[pair.state.Token0ID()] = amt
-							accepted = true
-						}
+				// for each token in pool that will be withdrawn, cap withdrawAmount & set new balance in state
+				if withdrawToken0 {
+					currentBalance := ord.Token0Balance()
+					amt := currentOrderReq.Amount
+					if currentBalance < amt || amt == 0 {
+						amt = currentBalance
 					}
-					if withdrawToken1 {
-						currentBalance := ord.Token1Balance()
-						amt := currentOrderReq.Amount
-						if currentBalance < amt || amt == 0 {
-							amt = currentBalance
-						}
-						if amt > 0 {
-							ord.SetToken1Balance(currentBalance - amt)
-							withdrawResults[pair.state.Token1ID()] = amt
-							accepted = true
-						}
-					}
-
-					if !accepted {
-						Logger.log.Warnf("Invalid withdraw tokenID %v for order %s",
-							currentOrderReq.Receiver, orderID)
-						result = append(result, refundAction.StringSlice())
-						continue TransactionLoop
+					if amt > 0 {
+						ord.SetToken0Balance(currentBalance - amt)
+						withdrawResults[pair.state.Token0ID()] = amt
+						accepted = true
 					}
-					// apply orderbook changes for withdraw consistency in the same block
-					pairs[currentOrderReq.PoolPairID] = pair
-
-					// To store the keys in slice in sorted order
-					keys := make([]common.Hash, len(withdrawResults))
-					i := 0
-					for key := range withdrawResults {
-						keys[i] = key
-						i++
+				}
+				if withdrawToken1 {
+					currentBalance := ord.Token1Balance()
+					amt := currentOrderReq.Amount
+					if currentBalance < amt || amt == 0 {
+						amt = currentBalance
 					}
-					sort.SliceStable(keys, func(i, j int) bool {
-						return keys[i].String() < keys[j].String()
-					})
-
-					// "accepted" metadata
-					for _, key := range keys {
-						acceptedAction := instruction.NewAction(
-							&metadataPdexv3.AcceptedWithdrawOrder{
-								PoolPairID: currentOrderReq.PoolPairID,
-								OrderID:    currentOrderReq.OrderID,
-								Receiver:   currentOrderReq.Receiver[key],
-								TokenID:    key,
-								Amount:     withdrawResults[key],
-							},
-							*tx.Hash(),
-							byte(tx.GetValidationEnv().ShardID()),
-						)
-						result = append(result, acceptedAction.StringSlice())
+					if amt > 0 {
+						ord.SetToken1Balance(currentBalance - amt)
+						withdrawResults[pair.state.Token1ID()] = amt
+						accepted = true
 					}
-				} else {
-					Logger.log.Warnf("Incorrect NftID %v for withdrawing order %s",
-						currentOrderReq.NftID, orderID)
+				}
+
+				if !accepted {
+					Logger.log.Warnf("Invalid withdraw tokenID %v for order %s",
+						currentOrderReq.Receiver, orderID)
 					result = append(result, refundAction.StringSlice())
+					continue TransactionLoop
 				}
-				continue TransactionLoop
+				// apply orderbook changes for withdraw consistency in the same block
+				pairs[currentOrderReq.PoolPairID] = pair
+
+				// To store the keys in slice in sorted order
+				keys := make([]common.Hash, len(withdrawResults))
+				i := 0
+				for key := range withdrawResults {
+					keys[i] = key
+					i++
+				}
+				sort.SliceStable(keys, func(i, j int) bool {
+					return keys[i].String() < keys[j].String()
+				})
+
+				// "accepted" metadata
+				for _, key := range keys {
+					acceptedAction := instruction.NewAction(
+						&metadataPdexv3.AcceptedWithdrawOrder{
+							PoolPairID: currentOrderReq.PoolPairID,
+							OrderID:    currentOrderReq.OrderID,
+							Receiver:   currentOrderReq.Receiver[key],
+							TokenID:    key,
+							Amount:     withdrawResults[key],
+						},
+						*tx.Hash(),
+						byte(tx.GetValidationEnv().ShardID()),
+					)
+					result = append(result, acceptedAction.StringSlice())
+				}
+			} else {
+				Logger.log.Warnf("Incorrect NftID %v for withdrawing order %s",
+					currentOrderReq.NftID, orderID)
+				result = append(result, refundAction.StringSlice())
 			}
+			continue TransactionLoop
 		}
 
 		Logger.log.Warnf("No order with ID %s found for withdrawal", orderID)
@@ -822,21 +953,22 @@ TransactionLoop:
 }
 
 func (sp *stateProducerV2) withdrawAllMatchedOrders(
-	pairs map[string]*PoolPairState, limitTxsPerShard uint,
+	pairs map[string]*PoolPairState, limitTxsPerShard uint, beaconHeight uint64,
 ) ([][]string, map[string]*PoolPairState, error) {
 	result := [][]string{}
 	numberTxsPerShard := make(map[byte]uint)
 	pairIDs := getSortedPoolPairIDs(pairs)
 	for _, pairID := range pairIDs {
 		pair := pairs[pairID] // no need to check found sorted from poolPairs list before
-		for _, ord := range pair.orderbook.orders {
+		for _, ord := range pair.orderbook.Orders() {
 			temp := &v2utils.MatchingOrder{ord}
-			// check if this order can be matched any further
-			if canMatch, err := temp.CanMatch(1 - ord.TradeDirection()); canMatch || err != nil {
+			// check if this order has expired or can be matched any further
+			isExpired := isOrderExpired(ord, beaconHeight)
+			if canMatch, err := temp.CanMatch(1 - ord.TradeDirection()); !isExpired && (canMatch || err != nil) {
 				continue
 			}
 
-			// an order that isn't further matchable is eligible for automatic withdrawal
+			// an order that is expired or isn't further matchable is eligible for automatic withdrawal
 			token0Recv := privacy.OTAReceiver{}
 			token0Recv.FromString(ord.Token0Receiver()) // error ignored (handled when adding this order)
 			token1Recv := privacy.OTAReceiver{}
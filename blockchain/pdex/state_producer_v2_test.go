@@ -391,7 +391,7 @@ func Test_stateProducerV2_addLiquidity(t *testing.T) {
 					},
 					orderRewards:  map[string]*OrderReward{},
 					makingVolume:  map[common.Hash]*MakingVolume{},
-					orderbook:     Orderbook{[]*Order{}},
+					orderbook:     Orderbook{},
 					lmLockedShare: map[string]map[uint64]uint64{},
 				},
 			},
@@ -432,7 +432,7 @@ func Test_stateProducerV2_addLiquidity(t *testing.T) {
 						},
 						orderRewards:  map[string]*OrderReward{},
 						makingVolume:  map[common.Hash]*MakingVolume{},
-						orderbook:     Orderbook{[]*Order{}},
+						orderbook:     Orderbook{},
 						lmLockedShare: map[string]map[uint64]uint64{},
 					},
 				},
@@ -475,7 +475,7 @@ func Test_stateProducerV2_addLiquidity(t *testing.T) {
 					},
 					orderRewards:  map[string]*OrderReward{},
 					makingVolume:  map[common.Hash]*MakingVolume{},
-					orderbook:     Orderbook{[]*Order{}},
+					orderbook:     Orderbook{},
 					lmLockedShare: map[string]map[uint64]uint64{},
 				},
 				poolPairID: &PoolPairState{
@@ -498,7 +498,7 @@ func Test_stateProducerV2_addLiquidity(t *testing.T) {
 					},
 					orderRewards:  map[string]*OrderReward{},
 					makingVolume:  map[common.Hash]*MakingVolume{},
-					orderbook:     Orderbook{[]*Order{}},
+					orderbook:     Orderbook{},
 					lmLockedShare: map[string]map[uint64]uint64{},
 				},
 			},
@@ -619,7 +619,7 @@ func Test_stateProducerV2_addLiquidity(t *testing.T) {
 						},
 						orderRewards:  map[string]*OrderReward{},
 						makingVolume:  map[common.Hash]*MakingVolume{},
-						orderbook:     Orderbook{[]*Order{}},
+						orderbook:     Orderbook{},
 						lmLockedShare: map[string]map[uint64]uint64{},
 					},
 				},
@@ -667,7 +667,7 @@ func Test_stateProducerV2_addLiquidity(t *testing.T) {
 					},
 					orderRewards:  map[string]*OrderReward{},
 					makingVolume:  map[common.Hash]*MakingVolume{},
-					orderbook:     Orderbook{[]*Order{}},
+					orderbook:     Orderbook{},
 					lmLockedShare: map[string]map[uint64]uint64{},
 				},
 			},
@@ -708,7 +708,7 @@ func Test_stateProducerV2_addLiquidity(t *testing.T) {
 						},
 						orderRewards:  map[string]*OrderReward{},
 						makingVolume:  map[common.Hash]*MakingVolume{},
-						orderbook:     Orderbook{[]*Order{}},
+						orderbook:     Orderbook{},
 						lmLockedShare: map[string]map[uint64]uint64{},
 					},
 				},
@@ -756,7 +756,7 @@ func Test_stateProducerV2_addLiquidity(t *testing.T) {
 					},
 					orderRewards:  map[string]*OrderReward{},
 					makingVolume:  map[common.Hash]*MakingVolume{},
-					orderbook:     Orderbook{[]*Order{}},
+					orderbook:     Orderbook{},
 					lmLockedShare: map[string]map[uint64]uint64{},
 				},
 			},
@@ -798,7 +798,7 @@ func Test_stateProducerV2_addLiquidity(t *testing.T) {
 						},
 						orderRewards:  map[string]*OrderReward{},
 						makingVolume:  map[common.Hash]*MakingVolume{},
-						orderbook:     Orderbook{[]*Order{}},
+						orderbook:     Orderbook{},
 						lmLockedShare: map[string]map[uint64]uint64{},
 					},
 				},
@@ -847,7 +847,7 @@ func Test_stateProducerV2_addLiquidity(t *testing.T) {
 					},
 					orderRewards:  map[string]*OrderReward{},
 					makingVolume:  map[common.Hash]*MakingVolume{},
-					orderbook:     Orderbook{[]*Order{}},
+					orderbook:     Orderbook{},
 					lmLockedShare: map[string]map[uint64]uint64{},
 				},
 			},
@@ -1060,7 +1060,7 @@ func Test_stateProducerV2_withdrawLiquidity(t *testing.T) {
 						},
 						orderRewards:  map[string]*OrderReward{},
 						makingVolume:  map[common.Hash]*MakingVolume{},
-						orderbook:     Orderbook{[]*Order{}},
+						orderbook:     Orderbook{},
 						lmLockedShare: map[string]map[uint64]uint64{},
 					},
 				},
@@ -1091,7 +1091,7 @@ func Test_stateProducerV2_withdrawLiquidity(t *testing.T) {
 					},
 					orderRewards:  map[string]*OrderReward{},
 					makingVolume:  map[common.Hash]*MakingVolume{},
-					orderbook:     Orderbook{[]*Order{}},
+					orderbook:     Orderbook{},
 					lmLockedShare: map[string]map[uint64]uint64{},
 				},
 			},
@@ -1123,7 +1123,7 @@ func Test_stateProducerV2_withdrawLiquidity(t *testing.T) {
 						},
 						orderRewards:  map[string]*OrderReward{},
 						makingVolume:  map[common.Hash]*MakingVolume{},
-						orderbook:     Orderbook{[]*Order{}},
+						orderbook:     Orderbook{},
 						lmLockedShare: map[string]map[uint64]uint64{},
 					},
 				},
@@ -1154,7 +1154,7 @@ func Test_stateProducerV2_withdrawLiquidity(t *testing.T) {
 					},
 					orderRewards:  map[string]*OrderReward{},
 					makingVolume:  map[common.Hash]*MakingVolume{},
-					orderbook:     Orderbook{[]*Order{}},
+					orderbook:     Orderbook{},
 					lmLockedShare: map[string]map[uint64]uint64{},
 				},
 			},
@@ -1186,7 +1186,7 @@ func Test_stateProducerV2_withdrawLiquidity(t *testing.T) {
 						},
 						orderRewards:  map[string]*OrderReward{},
 						makingVolume:  map[common.Hash]*MakingVolume{},
-						orderbook:     Orderbook{[]*Order{}},
+						orderbook:     Orderbook{},
 						lmLockedShare: map[string]map[uint64]uint64{},
 					},
 				},
@@ -1217,7 +1217,7 @@ func Test_stateProducerV2_withdrawLiquidity(t *testing.T) {
 					},
 					orderRewards:  map[string]*OrderReward{},
 					makingVolume:  map[common.Hash]*MakingVolume{},
-					orderbook:     Orderbook{[]*Order{}},
+					orderbook:     Orderbook{},
 					lmLockedShare: map[string]map[uint64]uint64{},
 				},
 			},
@@ -1249,7 +1249,7 @@ func Test_stateProducerV2_withdrawLiquidity(t *testing.T) {
 						},
 						orderRewards:  map[string]*OrderReward{},
 						makingVolume:  map[common.Hash]*MakingVolume{},
-						orderbook:     Orderbook{[]*Order{}},
+						orderbook:     Orderbook{},
 						lmLockedShare: map[string]map[uint64]uint64{},
 					},
 				},
@@ -1280,7 +1280,7 @@ func Test_stateProducerV2_withdrawLiquidity(t *testing.T) {
 					},
 					orderRewards:  map[string]*OrderReward{},
 					makingVolume:  map[common.Hash]*MakingVolume{},
-					orderbook:     Orderbook{[]*Order{}},
+					orderbook:     Orderbook{},
 					lmLockedShare: map[string]map[uint64]uint64{},
 				},
 			},
@@ -1313,7 +1313,7 @@ func Test_stateProducerV2_withdrawLiquidity(t *testing.T) {
 						},
 						orderRewards:  map[string]*OrderReward{},
 						makingVolume:  map[common.Hash]*MakingVolume{},
-						orderbook:     Orderbook{[]*Order{}},
+						orderbook:     Orderbook{},
 						lmLockedShare: map[string]map[uint64]uint64{},
 					},
 				},
@@ -1344,7 +1344,7 @@ func Test_stateProducerV2_withdrawLiquidity(t *testing.T) {
 					},
 					orderRewards:  map[string]*OrderReward{},
 					makingVolume:  map[common.Hash]*MakingVolume{},
-					orderbook:     Orderbook{[]*Order{}},
+					orderbook:     Orderbook{},
 					lmLockedShare: map[string]map[uint64]uint64{},
 				},
 			},
@@ -1374,7 +1374,7 @@ func Test_stateProducerV2_withdrawLiquidity(t *testing.T) {
 								lastLmRewardsPerShare: map[common.Hash]*big.Int{},
 							},
 						},
-						orderbook:     Orderbook{[]*Order{}},
+						orderbook:     Orderbook{},
 						lmLockedShare: map[string]map[uint64]uint64{},
 					},
 				},
@@ -1403,7 +1403,7 @@ func Test_stateProducerV2_withdrawLiquidity(t *testing.T) {
 							lastLmRewardsPerShare: map[common.Hash]*big.Int{},
 						},
 					},
-					orderbook:     Orderbook{[]*Order{}},
+					orderbook:     Orderbook{},
 					lmLockedShare: map[string]map[uint64]uint64{},
 				},
 			},
@@ -1601,7 +1601,7 @@ func Test_stateProducerV2_withdrawLPFee(t *testing.T) {
 						},
 						orderRewards:  map[string]*OrderReward{},
 						makingVolume:  map[common.Hash]*MakingVolume{},
-						orderbook:     Orderbook{[]*Order{}},
+						orderbook:     Orderbook{},
 						lmLockedShare: map[string]map[uint64]uint64{},
 					},
 				},
@@ -1631,7 +1631,7 @@ func Test_stateProducerV2_withdrawLPFee(t *testing.T) {
 					},
 					orderRewards:  map[string]*OrderReward{},
 					makingVolume:  map[common.Hash]*MakingVolume{},
-					orderbook:     Orderbook{[]*Order{}},
+					orderbook:     Orderbook{},
 					lmLockedShare: map[string]map[uint64]uint64{},
 				},
 			},
@@ -1663,7 +1663,7 @@ func Test_stateProducerV2_withdrawLPFee(t *testing.T) {
 						},
 						orderRewards:  map[string]*OrderReward{},
 						makingVolume:  map[common.Hash]*MakingVolume{},
-						orderbook:     Orderbook{[]*Order{}},
+						orderbook:     Orderbook{},
 						lmLockedShare: map[string]map[uint64]uint64{},
 					},
 				},
@@ -1693,7 +1693,7 @@ func Test_stateProducerV2_withdrawLPFee(t *testing.T) {
 					},
 					orderRewards:  map[string]*OrderReward{},
 					makingVolume:  map[common.Hash]*MakingVolume{},
-					orderbook:     Orderbook{[]*Order{}},
+					orderbook:     Orderbook{},
 					lmLockedShare: map[string]map[uint64]uint64{},
 				},
 			},
@@ -1740,7 +1740,7 @@ func Test_stateProducerV2_withdrawLPFee(t *testing.T) {
 						},
 						orderRewards: map[string]*OrderReward{},
 						makingVolume: map[common.Hash]*MakingVolume{},
-						orderbook:    Orderbook{[]*Order{}},
+						orderbook:    Orderbook{},
 					},
 				},
 				nftIDs: map[string]uint64{
@@ -1784,7 +1784,7 @@ func Test_stateProducerV2_withdrawLPFee(t *testing.T) {
 					},
 					orderRewards: map[string]*OrderReward{},
 					makingVolume: map[common.Hash]*MakingVolume{},
-					orderbook:    Orderbook{[]*Order{}},
+					orderbook:    Orderbook{},
 				},
 			},
 			wantErr: false,
@@ -1818,7 +1818,7 @@ func Test_stateProducerV2_withdrawLPFee(t *testing.T) {
 							},
 						},
 						makingVolume: map[common.Hash]*MakingVolume{},
-						orderbook:    Orderbook{[]*Order{}},
+						orderbook:    Orderbook{},
 					},
 				},
 				nftIDs: map[string]uint64{
@@ -1843,7 +1843,7 @@ func Test_stateProducerV2_withdrawLPFee(t *testing.T) {
 					shares:            map[string]*Share{},
 					orderRewards:      map[string]*OrderReward{},
 					makingVolume:      map[common.Hash]*MakingVolume{},
-					orderbook:         Orderbook{[]*Order{}},
+					orderbook:         Orderbook{},
 				},
 			},
 			wantErr: false,
@@ -1890,7 +1890,7 @@ func Test_stateProducerV2_withdrawLPFee(t *testing.T) {
 							},
 						},
 						makingVolume: map[common.Hash]*MakingVolume{},
-						orderbook:    Orderbook{[]*Order{}},
+						orderbook:    Orderbook{},
 					},
 				},
 				nftIDs: map[string]uint64{
@@ -1928,7 +1928,7 @@ func Test_stateProducerV2_withdrawLPFee(t *testing.T) {
 					},
 					orderRewards: map[string]*OrderReward{},
 					makingVolume: map[common.Hash]*MakingVolume{},
-					orderbook:    Orderbook{[]*Order{}},
+					orderbook:    Orderbook{},
 				},
 			},
 			wantErr: false,
@@ -2057,7 +2057,7 @@ func Test_stateProducerV2_withdrawProtocolFee(t *testing.T) {
 						},
 						orderRewards:  map[string]*OrderReward{},
 						makingVolume:  map[common.Hash]*MakingVolume{},
-						orderbook:     Orderbook{[]*Order{}},
+						orderbook:     Orderbook{},
 						lmLockedShare: map[string]map[uint64]uint64{},
 					},
 				},
@@ -2087,7 +2087,7 @@ func Test_stateProducerV2_withdrawProtocolFee(t *testing.T) {
 					},
 					orderRewards:  map[string]*OrderReward{},
 					makingVolume:  map[common.Hash]*MakingVolume{},
-					orderbook:     Orderbook{[]*Order{}},
+					orderbook:     Orderbook{},
 					lmLockedShare: map[string]map[uint64]uint64{},
 				},
 			},
@@ -2131,7 +2131,7 @@ func Test_stateProducerV2_withdrawProtocolFee(t *testing.T) {
 						},
 						orderRewards:  map[string]*OrderReward{},
 						makingVolume:  map[common.Hash]*MakingVolume{},
-						orderbook:     Orderbook{[]*Order{}},
+						orderbook:     Orderbook{},
 						lmLockedShare: map[string]map[uint64]uint64{},
 					},
 				},
@@ -2173,7 +2173,7 @@ func Test_stateProducerV2_withdrawProtocolFee(t *testing.T) {
 					},
 					orderRewards:  map[string]*OrderReward{},
 					makingVolume:  map[common.Hash]*MakingVolume{},
-					orderbook:     Orderbook{[]*Order{}},
+					orderbook:     Orderbook{},
 					lmLockedShare: map[string]map[uint64]uint64{},
 				},
 			},
@@ -2906,7 +2906,7 @@ func Test_stateProducerV2_liquidityMining(t *testing.T) {
 						},
 						orderRewards:  map[string]*OrderReward{},
 						makingVolume:  map[common.Hash]*MakingVolume{},
-						orderbook:     Orderbook{[]*Order{}},
+						orderbook:     Orderbook{},
 						lmLockedShare: map[string]map[uint64]uint64{},
 					},
 				},
@@ -2949,7 +2949,7 @@ func Test_stateProducerV2_liquidityMining(t *testing.T) {
 					},
 					orderRewards:  map[string]*OrderReward{},
 					makingVolume:  map[common.Hash]*MakingVolume{},
-					orderbook:     Orderbook{[]*Order{}},
+					orderbook:     Orderbook{},
 					lmLockedShare: map[string]map[uint64]uint64{},
 				},
 			},
@@ -2994,7 +2994,7 @@ func Test_stateProducerV2_liquidityMining(t *testing.T) {
 								},
 							},
 						},
-						orderbook:     Orderbook{[]*Order{}},
+						orderbook:     Orderbook{},
 						lmLockedShare: map[string]map[uint64]uint64{},
 					},
 				},
@@ -3042,7 +3042,7 @@ func Test_stateProducerV2_liquidityMining(t *testing.T) {
 						},
 					},
 					makingVolume:  map[common.Hash]*MakingVolume{},
-					orderbook:     Orderbook{[]*Order{}},
+					orderbook:     Orderbook{},
 					lmLockedShare: map[string]map[uint64]uint64{},
 				},
 			},
@@ -3092,7 +3092,7 @@ func Test_stateProducerV2_liquidityMining(t *testing.T) {
 								},
 							},
 						},
-						orderbook:     Orderbook{[]*Order{}},
+						orderbook:     Orderbook{},
 						lmLockedShare: map[string]map[uint64]uint64{},
 					},
 				},
@@ -3140,7 +3140,7 @@ func Test_stateProducerV2_liquidityMining(t *testing.T) {
 						},
 					},
 					makingVolume:  map[common.Hash]*MakingVolume{},
-					orderbook:     Orderbook{[]*Order{}},
+					orderbook:     Orderbook{},
 					lmLockedShare: map[string]map[uint64]uint64{},
 				},
 			},
@@ -301,7 +301,7 @@ func (s *stateV2) BuildInstructions(env StateEnvironment) ([][]string, error) {
 		poolPair.stakingPoolFees[poolPair.state.Token1ID()] = 0
 
 		// get order count per NftID
-		for _, ord := range poolPair.orderbook.orders {
+		for _, ord := range poolPair.orderbook.byID {
 			// increment counter by NftID (orderCountByNftID[ord.NftID()] is 0 if no entry)
 			orderCountByNftID[ord.NftID().String()] = orderCountByNftID[ord.NftID().String()] + 1
 		}
@@ -384,6 +384,7 @@ func (s *stateV2) BuildInstructions(env StateEnvironment) ([][]string, error) {
 		tradeTxs,
 		s.poolPairs,
 		s.params,
+		beaconHeight,
 	)
 	if err != nil {
 		return instructions, err
@@ -392,7 +393,7 @@ func (s *stateV2) BuildInstructions(env StateEnvironment) ([][]string, error) {
 
 	var matchedWithdrawInstructions [][]string
 	matchedWithdrawInstructions, s.poolPairs, err = s.producer.withdrawAllMatchedOrders(
-		s.poolPairs, s.params.AutoWithdrawOrderLimitAmount,
+		s.poolPairs, s.params.AutoWithdrawOrderLimitAmount, beaconHeight,
 	)
 	if err != nil {
 		return instructions, err
@@ -440,6 +441,7 @@ func (s *stateV2) BuildInstructions(env StateEnvironment) ([][]string, error) {
 		s.nftIDs,
 		s.params,
 		orderCountByNftID,
+		beaconHeight,
 	)
 	if err != nil {
 		return instructions, err
@@ -597,7 +597,7 @@ func Test_stateV2_BuildInstructions(t *testing.T) {
 						},
 						orderRewards:  map[string]*OrderReward{},
 						makingVolume:  map[common.Hash]*MakingVolume{},
-						orderbook:     Orderbook{[]*Order{}},
+						orderbook:     Orderbook{},
 						lmLockedShare: map[string]map[uint64]uint64{},
 					},
 				},
@@ -757,7 +757,7 @@ func Test_stateV2_BuildInstructions(t *testing.T) {
 						},
 						orderRewards:  map[string]*OrderReward{},
 						makingVolume:  map[common.Hash]*MakingVolume{},
-						orderbook:     Orderbook{[]*Order{}},
+						orderbook:     Orderbook{},
 						lmLockedShare: map[string]map[uint64]uint64{},
 					},
 				},
@@ -801,7 +801,7 @@ func Test_stateV2_BuildInstructions(t *testing.T) {
 						},
 						orderRewards:  map[string]*OrderReward{},
 						makingVolume:  map[common.Hash]*MakingVolume{},
-						orderbook:     Orderbook{[]*Order{}},
+						orderbook:     Orderbook{},
 						lmLockedShare: map[string]map[uint64]uint64{},
 					},
 				},
@@ -928,7 +928,7 @@ func Test_stateV2_BuildInstructions(t *testing.T) {
 						},
 						orderRewards: map[string]*OrderReward{},
 						makingVolume: map[common.Hash]*MakingVolume{},
-						orderbook: Orderbook{[]*Order{
+						orderbook: newTestOrderbook(
 							rawdbv2.NewPdexv3OrderWithValue(
 								txReqID.String(),
 								*nftHash1,
@@ -938,7 +938,7 @@ func Test_stateV2_BuildInstructions(t *testing.T) {
 									validOTAReceiver1,
 								},
 							),
-						}},
+						),
 						lmLockedShare: map[string]map[uint64]uint64{},
 					},
 					poolPairPRV: &PoolPairState{
@@ -961,7 +961,7 @@ func Test_stateV2_BuildInstructions(t *testing.T) {
 						},
 						orderRewards:  map[string]*OrderReward{},
 						makingVolume:  map[common.Hash]*MakingVolume{},
-						orderbook:     Orderbook{[]*Order{}},
+						orderbook:     Orderbook{},
 						lmLockedShare: map[string]map[uint64]uint64{},
 					},
 				},
@@ -1050,7 +1050,7 @@ func Test_stateV2_BuildInstructions(t *testing.T) {
 						},
 						orderRewards: map[string]*OrderReward{},
 						makingVolume: map[common.Hash]*MakingVolume{},
-						orderbook: Orderbook{[]*Order{
+						orderbook: newTestOrderbook(
 							rawdbv2.NewPdexv3OrderWithValue(
 								txReqID.String(),
 								*nftHash1,
@@ -1060,7 +1060,7 @@ func Test_stateV2_BuildInstructions(t *testing.T) {
 									validOTAReceiver1,
 								},
 							),
-						}},
+						),
 						lmLockedShare: map[string]map[uint64]uint64{},
 					},
 					poolPairPRV: &PoolPairState{
@@ -1086,7 +1086,7 @@ func Test_stateV2_BuildInstructions(t *testing.T) {
 						},
 						orderRewards:  map[string]*OrderReward{},
 						makingVolume:  map[common.Hash]*MakingVolume{},
-						orderbook:     Orderbook{[]*Order{}},
+						orderbook:     Orderbook{},
 						lmLockedShare: map[string]map[uint64]uint64{},
 					},
 					newPoolPairID: &PoolPairState{
@@ -1109,7 +1109,7 @@ func Test_stateV2_BuildInstructions(t *testing.T) {
 						},
 						orderRewards:  map[string]*OrderReward{},
 						makingVolume:  map[common.Hash]*MakingVolume{},
-						orderbook:     Orderbook{[]*Order{}},
+						orderbook:     Orderbook{},
 						lmLockedShare: map[string]map[uint64]uint64{},
 					},
 				},
@@ -1648,7 +1648,7 @@ func Test_stateV2_Process(t *testing.T) {
 						},
 						orderRewards:  map[string]*OrderReward{},
 						makingVolume:  map[common.Hash]*MakingVolume{},
-						orderbook:     Orderbook{[]*Order{}},
+						orderbook:     Orderbook{},
 						lmLockedShare: map[string]map[uint64]uint64{},
 					},
 				},
@@ -1880,7 +1880,7 @@ func Test_stateV2_Process(t *testing.T) {
 						},
 						orderRewards: map[string]*OrderReward{},
 						makingVolume: map[common.Hash]*MakingVolume{},
-						orderbook: Orderbook{[]*Order{
+						orderbook: newTestOrderbook(
 							rawdbv2.NewPdexv3OrderWithValue(
 								txReqID.String(),
 								*nftHash1,
@@ -1890,7 +1890,7 @@ func Test_stateV2_Process(t *testing.T) {
 									validOTAReceiver1,
 								},
 							),
-						}},
+						),
 						lmLockedShare: map[string]map[uint64]uint64{},
 					},
 					poolPairPRV: &PoolPairState{
@@ -1913,7 +1913,7 @@ func Test_stateV2_Process(t *testing.T) {
 						},
 						orderRewards:  map[string]*OrderReward{},
 						makingVolume:  map[common.Hash]*MakingVolume{},
-						orderbook:     Orderbook{[]*Order{}},
+						orderbook:     Orderbook{},
 						lmLockedShare: map[string]map[uint64]uint64{},
 					},
 				},
@@ -2037,7 +2037,7 @@ func Test_stateV2_Process(t *testing.T) {
 						},
 						orderRewards: map[string]*OrderReward{},
 						makingVolume: map[common.Hash]*MakingVolume{},
-						orderbook: Orderbook{[]*Order{
+						orderbook: newTestOrderbook(
 							rawdbv2.NewPdexv3OrderWithValue(
 								firstTxHash.String(),
 								*nftHash1,
@@ -2056,7 +2056,7 @@ func Test_stateV2_Process(t *testing.T) {
 									validOTAReceiver1,
 								},
 							),
-						}},
+						),
 						lmLockedShare: map[string]map[uint64]uint64{},
 					},
 					poolPairPRV: &PoolPairState{
@@ -2082,7 +2082,7 @@ func Test_stateV2_Process(t *testing.T) {
 						},
 						orderRewards:  map[string]*OrderReward{},
 						makingVolume:  map[common.Hash]*MakingVolume{},
-						orderbook:     Orderbook{[]*Order{}},
+						orderbook:     Orderbook{},
 						lmLockedShare: map[string]map[uint64]uint64{},
 					},
 					newPoolPairID: &PoolPairState{
@@ -2105,7 +2105,7 @@ func Test_stateV2_Process(t *testing.T) {
 						},
 						orderRewards:  map[string]*OrderReward{},
 						makingVolume:  map[common.Hash]*MakingVolume{},
-						orderbook:     Orderbook{[]*Order{}},
+						orderbook:     Orderbook{},
 						lmLockedShare: map[string]map[uint64]uint64{},
 					},
 				},
@@ -2281,7 +2281,7 @@ func Test_stateV2_StoreToDB(t *testing.T) {
 						},
 						orderRewards: map[string]*OrderReward{},
 						makingVolume: map[common.Hash]*MakingVolume{},
-						orderbook:    Orderbook{[]*Order{}},
+						orderbook:    Orderbook{},
 					},
 				},
 				params: &Params{},
@@ -2389,7 +2389,7 @@ func Test_stateV2_GetDiff(t *testing.T) {
 						},
 						orderRewards:  map[string]*OrderReward{},
 						makingVolume:  map[common.Hash]*MakingVolume{},
-						orderbook:     Orderbook{[]*Order{}},
+						orderbook:     Orderbook{},
 						lmLockedShare: map[string]map[uint64]uint64{},
 					},
 				},
@@ -2473,7 +2473,7 @@ func Test_stateV2_GetDiff(t *testing.T) {
 						},
 						orderRewards:  map[string]*OrderReward{},
 						makingVolume:  map[common.Hash]*MakingVolume{},
-						orderbook:     Orderbook{[]*Order{}},
+						orderbook:     Orderbook{},
 						lmLockedShare: map[string]map[uint64]uint64{},
 					},
 				},
@@ -2543,7 +2543,7 @@ func Test_stateV2_GetDiff(t *testing.T) {
 							},
 						},
 						makingVolume:  map[common.Hash]*MakingVolume{},
-						orderbook:     Orderbook{[]*Order{}},
+						orderbook:     Orderbook{},
 						lmLockedShare: map[string]map[uint64]uint64{},
 					},
 				},
@@ -2593,7 +2593,7 @@ func Test_stateV2_GetDiff(t *testing.T) {
 								},
 							},
 							makingVolume:  map[common.Hash]*MakingVolume{},
-							orderbook:     Orderbook{[]*Order{}},
+							orderbook:     Orderbook{},
 							lmLockedShare: map[string]map[uint64]uint64{},
 						},
 					},
@@ -2653,7 +2653,7 @@ func Test_stateV2_GetDiff(t *testing.T) {
 							},
 						},
 						makingVolume:  map[common.Hash]*MakingVolume{},
-						orderbook:     Orderbook{[]*Order{}},
+						orderbook:     Orderbook{},
 						lmLockedShare: map[string]map[uint64]uint64{},
 					},
 				},
@@ -2722,7 +2722,7 @@ func Test_stateV2_GetDiff(t *testing.T) {
 						},
 						orderRewards:  map[string]*OrderReward{},
 						makingVolume:  map[common.Hash]*MakingVolume{},
-						orderbook:     Orderbook{[]*Order{}},
+						orderbook:     Orderbook{},
 						lmLockedShare: map[string]map[uint64]uint64{},
 					},
 				},
@@ -2773,7 +2773,7 @@ func Test_stateV2_GetDiff(t *testing.T) {
 							},
 							orderRewards:  map[string]*OrderReward{},
 							makingVolume:  map[common.Hash]*MakingVolume{},
-							orderbook:     Orderbook{[]*Order{}},
+							orderbook:     Orderbook{},
 							lmLockedShare: map[string]map[uint64]uint64{},
 						},
 					},
@@ -2830,7 +2830,7 @@ func Test_stateV2_GetDiff(t *testing.T) {
 						},
 						orderRewards:  map[string]*OrderReward{},
 						makingVolume:  map[common.Hash]*MakingVolume{},
-						orderbook:     Orderbook{[]*Order{}},
+						orderbook:     Orderbook{},
 						lmLockedShare: map[string]map[uint64]uint64{},
 					},
 				},
@@ -2895,7 +2895,7 @@ func Test_stateV2_GetDiff(t *testing.T) {
 						},
 						orderRewards:  map[string]*OrderReward{},
 						makingVolume:  map[common.Hash]*MakingVolume{},
-						orderbook:     Orderbook{[]*Order{}},
+						orderbook:     Orderbook{},
 						lmLockedShare: map[string]map[uint64]uint64{},
 					},
 				},
@@ -2977,7 +2977,7 @@ func Test_stateV2_GetDiff(t *testing.T) {
 						},
 						orderRewards:  map[string]*OrderReward{},
 						makingVolume:  map[common.Hash]*MakingVolume{},
-						orderbook:     Orderbook{[]*Order{}},
+						orderbook:     Orderbook{},
 						lmLockedShare: map[string]map[uint64]uint64{},
 					},
 				},
@@ -137,7 +137,7 @@ func initPoolPairStatesFromDB(stateDB *statedb.StateDB) (map[string]*PoolPairSta
 			return nil, err
 		}
 
-		orderbook := &Orderbook{[]*Order{}}
+		orderbook := &Orderbook{}
 		orderMap, err := statedb.GetPdexv3Orders(stateDB, poolPairState.PoolPairID())
 		if err != nil {
 			return nil, err
@@ -199,7 +199,7 @@ func initPoolPairStatesFromDBLite(stateDB *statedb.StateDB) (map[string]*PoolPai
 	res := make(map[string]*PoolPairState)
 	for poolPairID, poolPairState := range poolPairsStates {
 
-		orderbook := &Orderbook{[]*Order{}}
+		orderbook := &Orderbook{}
 		orderMap, err := statedb.GetPdexv3Orders(stateDB, poolPairState.PoolPairID())
 		if err != nil {
 			return nil, err
@@ -344,7 +344,7 @@ func InitLiquidityPoolPairStatesFromDB(stateDB *statedb.StateDB) (map[string]*Po
 		return nil, err
 	}
 	for poolPairID, poolPairState := range poolPairsStates {
-		orderbook := &Orderbook{[]*Order{}}
+		orderbook := &Orderbook{}
 		orderMap, err := statedb.GetPdexv3Orders(stateDB, poolPairID)
 		if err != nil {
 			return nil, err
@@ -409,7 +409,7 @@ func InitFullPoolPairStatesFromDB(stateDB *statedb.StateDB) (map[string]*PoolPai
 				orderReward[nftID].uncollectedRewards[tokenID] = amount
 			}
 		}
-		orderbook := &Orderbook{[]*Order{}}
+		orderbook := &Orderbook{}
 		orderMap, err := statedb.GetPdexv3Orders(stateDB, poolPairID)
 		if err != nil {
 			return nil, err
@@ -521,7 +521,7 @@ func InitPoolPair(stateDB *statedb.StateDB, poolPairID string) (*PoolPairState,
 			orderReward[nftID].uncollectedRewards[tokenID] = amount
 		}
 	}
-	orderbook := &Orderbook{[]*Order{}}
+	orderbook := &Orderbook{}
 	orderMap, err := statedb.GetPdexv3Orders(stateDB, poolPairID)
 	if err != nil {
 		return nil, err
@@ -559,7 +559,7 @@ func InitPoolPairShares(stateDB *statedb.StateDB, poolPairID string) (map[string
 }
 
 func InitPoolPairOrders(stateDB *statedb.StateDB, poolPairID string) (*Orderbook, error) {
-	orderbook := &Orderbook{[]*Order{}}
+	orderbook := &Orderbook{}
 	orderMap, err := statedb.GetPdexv3Orders(stateDB, poolPairID)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,288 @@
+package pdex
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	v2 "github.com/incognitochain/incognito-chain/blockchain/pdex/v2utils"
+	"github.com/incognitochain/incognito-chain/common"
+	metadataPdexv3 "github.com/incognitochain/incognito-chain/metadata/pdexv3"
+	"github.com/incognitochain/incognito-chain/privacy"
+)
+
+// MatchedOrder is the part of an order matched by a simulated trade, amounts are from the trade's view
+type MatchedOrder struct {
+	PoolPairID string
+	OrderID    string
+	NftID      string
+	SellAmount uint64 // amount of the token sold to the order
+	BuyAmount  uint64 // amount of the token bought from the order
+}
+
+// TradeSimulation is the result of a trade computed like in the beacon block producer, without changing the state
+type TradeSimulation struct {
+	TokenToSell   common.Hash
+	TokenToBuy    common.Hash
+	TradePath     []string
+	SellAmount    uint64
+	AmountOut     uint64
+	PriceImpact   float64 // percent the trade rate is below the rate of the pools before the trade
+	PoolFeesBPS   []uint  // fee rate of each pool of TradePath
+	FeeRateBPS    uint    // sum of PoolFeesBPS
+	FeeInPRV      bool
+	MinTradingFee uint64 // minimum TradingFee of the trade request, in PRV if FeeInPRV, in TokenToSell otherwise
+	MatchedOrders []MatchedOrder
+}
+
+// SimulateTrade() runs a trade of sellAmount along tradePath on cloned pairs with the same swap & order matching as the producer.
+// The trade must pay at least MinTradingFee to be accepted, assuming no other trade in the same block.
+// beaconHeight is the height of the block the trade is expected in; orders expired at this height are not matched
+func SimulateTrade(
+	tokenToSell common.Hash, tradePath []string, sellAmount uint64, feeInPRV bool,
+	pairs map[string]*PoolPairState, params *Params, beaconHeight uint64,
+) (*TradeSimulation, error) {
+	if len(tradePath) == 0 || len(tradePath) > metadataPdexv3.MaxTradePathLength {
+		return nil, fmt.Errorf("Trade path length must be between 1 and %d", metadataPdexv3.MaxTradePathLength)
+	}
+	if sellAmount == 0 {
+		return nil, fmt.Errorf("Sell amount must be greater than 0")
+	}
+	if tokenToSell == common.PRVCoinID {
+		feeInPRV = true
+	}
+	reserves, lpFeesPerShares, protocolFees, stakingPoolFees, orderbookList, tradeDirections, tokenToBuy, err :=
+		TradePathFromState(tokenToSell, tradePath, pairs, beaconHeight)
+	if err != nil {
+		return nil, err
+	}
+	spotRate := new(big.Float).SetInt64(1)
+	for i, reserve := range reserves {
+		if v2.HasInsufficientLiquidity(*reserve) {
+			return nil, fmt.Errorf("No liquidity in pool %s", tradePath[i])
+		}
+		vIn, vOut := reserve.Token0VirtualAmount(), reserve.Token1VirtualAmount()
+		if tradeDirections[i] == v2.TradeDirectionSell1 {
+			vIn, vOut = vOut, vIn
+		}
+		spotRate.Mul(spotRate, new(big.Float).Quo(new(big.Float).SetInt(vOut), new(big.Float).SetInt(vIn)))
+	}
+
+	acceptedTrade, _, err := v2.MaybeAcceptTrade(
+		sellAmount, 0, tradePath, privacy.OTAReceiver{}, reserves,
+		lpFeesPerShares, protocolFees, stakingPoolFees,
+		tradeDirections, tokenToBuy, 0, orderbookList,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &TradeSimulation{
+		TokenToSell:   tokenToSell,
+		TokenToBuy:    tokenToBuy,
+		TradePath:     tradePath,
+		SellAmount:    sellAmount,
+		AmountOut:     acceptedTrade.Amount,
+		FeeInPRV:      feeInPRV,
+		MatchedOrders: []MatchedOrder{},
+	}
+	for _, pairID := range tradePath {
+		poolFee := params.DefaultFeeRateBPS
+		if customizedFee, ok := params.FeeRateBPS[pairID]; ok {
+			poolFee = customizedFee
+		}
+		result.PoolFeesBPS = append(result.PoolFeesBPS, poolFee)
+		result.FeeRateBPS += poolFee
+	}
+	result.MinTradingFee, err = getMinTradingFee(tokenToSell, sellAmount, result.FeeRateBPS, feeInPRV, pairs, params)
+	if err != nil {
+		return nil, err
+	}
+
+	tradeRate := new(big.Float).Quo(
+		new(big.Float).SetUint64(result.AmountOut),
+		new(big.Float).SetUint64(sellAmount),
+	)
+	priceImpact, _ := new(big.Float).Mul(
+		new(big.Float).Sub(big.NewFloat(1), new(big.Float).Quo(tradeRate, spotRate)),
+		big.NewFloat(100),
+	).Float64()
+	result.PriceImpact = priceImpact
+
+	for i, orderChanges := range acceptedTrade.OrderChanges {
+		nftIDs := orderbookList[i].NftIDs()
+		orderIDs := []string{}
+		for orderID := range orderChanges {
+			orderIDs = append(orderIDs, orderID)
+		}
+		sort.Strings(orderIDs)
+		for _, orderID := range orderIDs {
+			sold, bought := orderChanges[orderID][0], orderChanges[orderID][1]
+			if tradeDirections[i] == v2.TradeDirectionSell1 {
+				sold, bought = bought, sold
+			}
+			result.MatchedOrders = append(result.MatchedOrders, MatchedOrder{
+				PoolPairID: tradePath[i],
+				OrderID:    orderID,
+				NftID:      nftIDs[orderID],
+				SellAmount: sold.Uint64(),
+				BuyAmount:  new(big.Int).Neg(bought).Uint64(),
+			})
+		}
+	}
+	return result, nil
+}
+
+// FindBestTradePath() simulates the trade on the paths of up to maxPathLength pools from tokenToSell to tokenToBuy
+// (a token is visited once) and returns the one with the highest output amount,
+// then the lowest fee rate, then the fewest pools.
+// The search is bounded: at each intermediate hop only the MaxTradePathPoolsPerToken pools with the most
+// liquidity of the token are followed, while every pool reaching tokenToBuy is tried
+func FindBestTradePath(
+	tokenToSell, tokenToBuy common.Hash, sellAmount uint64, maxPathLength int, feeInPRV bool,
+	pairs map[string]*PoolPairState, params *Params, beaconHeight uint64,
+) (*TradeSimulation, error) {
+	if tokenToSell == tokenToBuy {
+		return nil, fmt.Errorf("Cannot trade token %s with itself", tokenToSell.String())
+	}
+	if maxPathLength <= 0 || maxPathLength > metadataPdexv3.MaxTradePathLength {
+		maxPathLength = metadataPdexv3.MaxTradePathLength
+	}
+
+	// pool pairs with liquidity by token, sorted for a deterministic result.
+	// Only reserves & orderbooks are kept since every simulation clones the pairs of its path
+	tradingPairs := make(map[string]*PoolPairState)
+	pairIDsByToken := make(map[common.Hash][]string)
+	for pairID, pair := range pairs {
+		if v2.HasInsufficientLiquidity(pair.state) {
+			continue
+		}
+		tradingPair := NewPoolPairState()
+		tradingPair.state = pair.state
+		tradingPair.orderbook = pair.orderbook
+		tradingPairs[pairID] = tradingPair
+		pairIDsByToken[pair.state.Token0ID()] = append(pairIDsByToken[pair.state.Token0ID()], pairID)
+		pairIDsByToken[pair.state.Token1ID()] = append(pairIDsByToken[pair.state.Token1ID()], pairID)
+	}
+	for token, pairIDs := range pairIDsByToken {
+		sortPairIDsByLiquidity(token, pairIDs, tradingPairs)
+	}
+
+	var best *TradeSimulation
+	visited := map[common.Hash]bool{tokenToSell: true}
+	path := []string{}
+	var search func(token common.Hash)
+	search = func(token common.Hash) {
+		hops := 0
+		for _, pairID := range pairIDsByToken[token] {
+			nextToken := tradingPairs[pairID].state.Token0ID()
+			if nextToken == token {
+				nextToken = tradingPairs[pairID].state.Token1ID()
+			}
+			if visited[nextToken] {
+				continue
+			}
+			if nextToken != tokenToBuy {
+				if hops >= MaxTradePathPoolsPerToken || len(path)+1 >= maxPathLength {
+					continue
+				}
+				hops++
+			}
+			path = append(path, pairID)
+			if nextToken == tokenToBuy {
+				tradePath := make([]string, len(path))
+				copy(tradePath, path)
+				result, err := SimulateTrade(tokenToSell, tradePath, sellAmount, feeInPRV, tradingPairs, params, beaconHeight)
+				if err == nil && isBetterTrade(result, best) {
+					best = result
+				}
+			} else {
+				visited[nextToken] = true
+				search(nextToken)
+				visited[nextToken] = false
+			}
+			path = path[:len(path)-1]
+		}
+	}
+	search(tokenToSell)
+
+	if best == nil {
+		return nil, fmt.Errorf("No trade path from %s to %s", tokenToSell.String(), tokenToBuy.String())
+	}
+	return best, nil
+}
+
+// sortPairIDsByLiquidity() sorts the pools of token by their virtual reserve of token, the deepest first
+func sortPairIDsByLiquidity(token common.Hash, pairIDs []string, pairs map[string]*PoolPairState) {
+	reserve := func(pairID string) *big.Int {
+		state := pairs[pairID].state
+		if state.Token0ID() == token {
+			return state.Token0VirtualAmount()
+		}
+		return state.Token1VirtualAmount()
+	}
+	sort.Slice(pairIDs, func(i, j int) bool {
+		if c := reserve(pairIDs[i]).Cmp(reserve(pairIDs[j])); c != 0 {
+			return c > 0
+		}
+		return pairIDs[i] < pairIDs[j]
+	})
+}
+
+func isBetterTrade(trade, other *TradeSimulation) bool {
+	if other == nil {
+		return trade.AmountOut > 0
+	}
+	if trade.AmountOut != other.AmountOut {
+		return trade.AmountOut > other.AmountOut
+	}
+	if trade.FeeRateBPS != other.FeeRateBPS {
+		return trade.FeeRateBPS < other.FeeRateBPS
+	}
+	if len(trade.TradePath) != len(other.TradePath) {
+		return len(trade.TradePath) < len(other.TradePath)
+	}
+	return strings.Join(trade.TradePath, ",") < strings.Join(other.TradePath, ",")
+}
+
+// getMinTradingFee() reverses the conversion of getWeightedFee(): it returns the lowest fee whose
+// equivalent amount in the selling token reaches sellAmount * feeRateBPS / BPS
+func getMinTradingFee(
+	tokenToSell common.Hash, sellAmount uint64, feeRateBPS uint, feeInPRV bool,
+	pairs map[string]*PoolPairState, params *Params,
+) (uint64, error) {
+	if params.PRVDiscountPercent > 100 {
+		return 0, fmt.Errorf("PRV Discount percent invalid")
+	}
+	discountPercent := big.NewInt(0).SetUint64(uint64(100 - params.PRVDiscountPercent))
+
+	// fee * BPS >= sellAmount * feeRateBPS
+	num := new(big.Int).Mul(new(big.Int).SetUint64(sellAmount), new(big.Int).SetUint64(uint64(feeRateBPS)))
+	den := big.NewInt(BPS)
+	if tokenToSell == common.PRVCoinID {
+		// fee * 100 / discountPercent >= minimum fee
+		num.Mul(num, discountPercent)
+		den.Mul(den, big.NewInt(100))
+	} else if feeInPRV {
+		// fee * tokenReserve * 100 / discountPercent / prvReserve >= minimum fee
+		rates, exists := getTokenPricesAgainstPRV(pairs, params.MinPRVReserveTradingRate)[tokenToSell]
+		if !exists {
+			return 0, fmt.Errorf("Cannot get price of token %s against PRV", tokenToSell.String())
+		}
+		num.Mul(num, rates[1])
+		num.Mul(num, discountPercent)
+		den.Mul(den, rates[0])
+		den.Mul(den, big.NewInt(100))
+	}
+	if den.Sign() == 0 {
+		return 0, fmt.Errorf("Cannot convert trading fee of token %s", tokenToSell.String())
+	}
+	// round up
+	num.Add(num, new(big.Int).Sub(den, big.NewInt(1)))
+	num.Div(num, den)
+	if !num.IsUint64() {
+		return 0, fmt.Errorf("Trading fee out of uint64 range")
+	}
+	return num.Uint64(), nil
+}
@@ -0,0 +1,140 @@
+package pdex
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	v2 "github.com/incognitochain/incognito-chain/blockchain/pdex/v2utils"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
+	metadataPdexv3 "github.com/incognitochain/incognito-chain/metadata/pdexv3"
+	. "github.com/stretchr/testify/assert"
+)
+
+var (
+	simulationTokenA = common.HashH([]byte("tokenA"))
+	simulationTokenB = common.HashH([]byte("tokenB"))
+)
+
+func newSimulationTestPair(token0, token1 common.Hash, amount0, amount1 uint64, orders ...*Order) *PoolPairState {
+	state := rawdbv2.NewPdexv3PoolPairWithValue(
+		token0, token1, 0, 0, amount0, amount1,
+		new(big.Int).SetUint64(amount0), new(big.Int).SetUint64(amount1),
+		metadataPdexv3.BaseAmplifier,
+	)
+	pair := NewPoolPairState()
+	pair.state = *state
+	pair.orderbook = newTestOrderbook(orders...)
+	return pair
+}
+
+func newSimulationTestParams() *Params {
+	params := NewParams()
+	params.DefaultFeeRateBPS = 30
+	params.PRVDiscountPercent = 25
+	return params
+}
+
+func TestSimulateTrade(t *testing.T) {
+	params := newSimulationTestParams()
+	pairs := map[string]*PoolPairState{
+		"A-B": newSimulationTestPair(simulationTokenA, simulationTokenB, 1000000, 1000000),
+	}
+	result, err := SimulateTrade(simulationTokenA, []string{"A-B"}, 10000, false, pairs, params, 1)
+	NoError(t, err)
+	Equal(t, simulationTokenB, result.TokenToBuy)
+	expected, _ := v2.NewTradingPairWithValue(&pairs["A-B"].state).BuyAmount(10000, v2.TradeDirectionSell0)
+	Equal(t, expected, result.AmountOut)
+	Equal(t, uint64(30), result.MinTradingFee)
+	Equal(t, uint(30), result.FeeRateBPS)
+	InDelta(t, 1, result.PriceImpact, 0.01)
+	Empty(t, result.MatchedOrders)
+	// the state is not changed
+	Equal(t, uint64(1000000), pairs["A-B"].state.Token0RealAmount())
+
+	// a sell1 order at a better rate than the pool is matched first
+	order := rawdbv2.NewPdexv3OrderWithValue("order1", common.HashH([]byte("nft")), 1000, 2000, 0, 1000, v2.TradeDirectionSell1, [2]string{})
+	pairs["A-B"] = newSimulationTestPair(simulationTokenA, simulationTokenB, 1000000, 1000000, order)
+	result, err = SimulateTrade(simulationTokenA, []string{"A-B"}, 100, false, pairs, params, 1)
+	NoError(t, err)
+	Equal(t, uint64(200), result.AmountOut)
+	Equal(t, []MatchedOrder{{
+		PoolPairID: "A-B",
+		OrderID:    "order1",
+		NftID:      common.HashH([]byte("nft")).String(),
+		SellAmount: 100,
+		BuyAmount:  200,
+	}}, result.MatchedOrders)
+	Less(t, result.PriceImpact, float64(0))
+	Equal(t, uint64(1000), order.Token1Balance())
+
+	_, err = SimulateTrade(simulationTokenB, []string{"A-B", "A-B"}, 100, false, pairs, params, 1)
+	Error(t, err)
+	_, err = SimulateTrade(common.PRVCoinID, []string{"A-B"}, 100, false, pairs, params, 1)
+	Error(t, err)
+}
+
+func TestMinTradingFee(t *testing.T) {
+	params := newSimulationTestParams()
+	pairs := map[string]*PoolPairState{
+		"PRV-A": newSimulationTestPair(common.PRVCoinID, simulationTokenA, 1000000, 2000000),
+	}
+	// 30 PRV is worth 40 PRV with the discount of 25%
+	fee, err := getMinTradingFee(common.PRVCoinID, 10000, 30, true, pairs, params)
+	NoError(t, err)
+	Equal(t, uint64(23), fee)
+	// 30 tokenA is worth 15 PRV, 20 PRV with the discount
+	fee, err = getMinTradingFee(simulationTokenA, 10000, 30, true, pairs, params)
+	NoError(t, err)
+	Equal(t, uint64(12), fee)
+	_, err = getMinTradingFee(simulationTokenB, 10000, 30, true, pairs, params)
+	Error(t, err)
+}
+
+func TestFindBestTradePath(t *testing.T) {
+	params := newSimulationTestParams()
+	pairs := map[string]*PoolPairState{
+		"A-B":   newSimulationTestPair(simulationTokenA, simulationTokenB, 1000, 1000),
+		"PRV-A": newSimulationTestPair(common.PRVCoinID, simulationTokenA, 1000000000, 1000000000),
+		"PRV-B": newSimulationTestPair(common.PRVCoinID, simulationTokenB, 1000000000, 1000000000),
+		"empty": newSimulationTestPair(common.PRVCoinID, simulationTokenB, 0, 0),
+	}
+	result, err := FindBestTradePath(simulationTokenA, simulationTokenB, 100, 0, false, pairs, params, 1)
+	NoError(t, err)
+	Equal(t, []string{"PRV-A", "PRV-B"}, result.TradePath)
+	Equal(t, uint64(98), result.AmountOut)
+	Equal(t, []uint{30, 30}, result.PoolFeesBPS)
+
+	result, err = FindBestTradePath(simulationTokenA, simulationTokenB, 100, 1, false, pairs, params, 1)
+	NoError(t, err)
+	Equal(t, []string{"A-B"}, result.TradePath)
+	Equal(t, uint64(90), result.AmountOut)
+
+	_, err = FindBestTradePath(simulationTokenA, common.HashH([]byte("tokenC")), 100, 0, false, pairs, params, 1)
+	Error(t, err)
+}
+
+func TestFindBestTradePathBounded(t *testing.T) {
+	params := newSimulationTestParams()
+	pairs := map[string]*PoolPairState{
+		// the best rate, through the pool with the least liquidity of tokenA
+		"A-T1": newSimulationTestPair(simulationTokenA, common.HashH([]byte("T1")), 1000000, 100000000),
+		"T1-B": newSimulationTestPair(common.HashH([]byte("T1")), simulationTokenB, 1000000000, 1000000000),
+	}
+	for i := 2; i <= MaxTradePathPoolsPerToken+1; i++ {
+		token := common.HashH([]byte(fmt.Sprintf("T%d", i)))
+		pairs[fmt.Sprintf("A-T%d", i)] = newSimulationTestPair(simulationTokenA, token, uint64(i)*1000000, uint64(i)*1000000)
+		pairs[fmt.Sprintf("T%d-B", i)] = newSimulationTestPair(token, simulationTokenB, 1000000000, 1000000000)
+	}
+	// only the pools with the most liquidity of tokenA are followed
+	result, err := FindBestTradePath(simulationTokenA, simulationTokenB, 100000, 0, false, pairs, params, 1)
+	NoError(t, err)
+	Equal(t, []string{fmt.Sprintf("A-T%d", MaxTradePathPoolsPerToken+1), fmt.Sprintf("T%d-B", MaxTradePathPoolsPerToken+1)}, result.TradePath)
+
+	// a pool reaching tokenB is tried whatever its liquidity
+	pairs["A-B"] = newSimulationTestPair(simulationTokenA, simulationTokenB, 1000, 1000000)
+	result, err = FindBestTradePath(simulationTokenA, simulationTokenB, 100000, 0, false, pairs, params, 1)
+	NoError(t, err)
+	Equal(t, []string{"A-B"}, result.TradePath)
+}
@@ -613,6 +613,7 @@ func TradePathFromState(
 	sellToken common.Hash,
 	tradePath []string,
 	pairs map[string]*PoolPairState,
+	beaconHeight uint64,
 ) (
 	[]*rawdbv2.Pdexv3PoolPair, []map[common.Hash]*big.Int, []map[common.Hash]uint64, []map[common.Hash]uint64,
 	[]v2.OrderBookIterator, []byte, common.Hash, error,
@@ -640,6 +641,7 @@ func TradePathFromState(
 			protocolFees = append(protocolFees, pair.protocolFees)
 			stakingPoolFees = append(stakingPoolFees, pair.stakingPoolFees)
 			ob := pair.orderbook
+			ob.beaconHeight = beaconHeight
 			orderbookList = append(orderbookList, &ob)
 			var td byte
 			switch nextTokenToSell {
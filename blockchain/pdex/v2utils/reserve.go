@@ -265,6 +265,102 @@ func MaybeAcceptTrade(amountIn, fee uint64, tradePath []string, receiver privacy
 	return &acceptedMeta, reserves, nil
 }
 
+// MatchLimitOrder() sells up to maxSellAmount in one pool & its orderbook without going below the limit rate
+// limitBuyAmount / limitSellAmount: the pool is swapped until its price reaches the limit, and only orders
+// with a rate not worse than the limit are matched. Upon success, state changes are applied in memory & collected
+// in an instruction; the sell amount left unmatched is returned
+func MatchLimitOrder(maxSellAmount, limitSellAmount, limitBuyAmount uint64, poolPairID string, receiver privacy.OTAReceiver,
+	reserve *rawdbv2.Pdexv3PoolPair, tradeDirection byte, tokenToBuy common.Hash, orderbook OrderBookIterator,
+) (*metadataPdexv3.AcceptedTrade, uint64, error) {
+	if limitSellAmount == 0 || limitBuyAmount == 0 {
+		return nil, 0, fmt.Errorf("Invalid limit rate %d / %d", limitBuyAmount, limitSellAmount)
+	}
+	// the limit acts as an order of opposite direction, bounding the pool swap once no better order is left
+	var token0Rate, token1Rate uint64
+	switch tradeDirection {
+	case TradeDirectionSell0:
+		token0Rate, token1Rate = limitSellAmount, limitBuyAmount
+	case TradeDirectionSell1:
+		token0Rate, token1Rate = limitBuyAmount, limitSellAmount
+	default:
+		return nil, 0, fmt.Errorf("Invalid trade direction %d", tradeDirection)
+	}
+	limit := &MatchingOrder{rawdbv2.NewPdexv3OrderWithValue(
+		"", common.Hash{}, token0Rate, token1Rate, 0, 0, 1-tradeDirection, [2]string{},
+	)}
+
+	acceptedMeta := metadataPdexv3.AcceptedTrade{
+		Receiver:     receiver,
+		TradePath:    []string{poolPairID},
+		PairChanges:  make([][2]*big.Int, 1),
+		OrderChanges: []map[string][2]*big.Int{make(map[string][2]*big.Int)},
+		TokenToBuy:   tokenToBuy,
+	}
+	accumulatedToken0Change := big.NewInt(0)
+	accumulatedToken1Change := big.NewInt(0)
+	sellAmountRemain := maxSellAmount
+	var totalBuyAmount uint64
+	for sellAmountRemain > 0 {
+		order, ordID, err := orderbook.NextOrder(tradeDirection)
+		if err != nil {
+			return nil, 0, err
+		}
+		// orders come sorted by rate, so the first one below the limit ends the matching
+		if order != nil && !reachesLimit(order, limit, tradeDirection) {
+			order = nil
+		}
+		bound := order
+		if bound == nil {
+			bound = limit
+		}
+		buyAmount, temp, token0Change, token1Change, err := NewTradingPairWithValue(
+			reserve,
+		).SwapToReachOrderRate(sellAmountRemain, tradeDirection, bound)
+		if err != nil {
+			return nil, 0, err
+		}
+		sellAmountRemain = temp
+		if totalBuyAmount+buyAmount < totalBuyAmount {
+			return nil, 0, fmt.Errorf("Sum exceeds uint64 range after swapping in pool")
+		}
+		totalBuyAmount += buyAmount
+		accumulatedToken0Change.Add(accumulatedToken0Change, token0Change)
+		accumulatedToken1Change.Add(accumulatedToken1Change, token1Change)
+		if sellAmountRemain == 0 || order == nil {
+			break
+		}
+		buyAmount, temp, token0Change, token1Change, err = order.Match(sellAmountRemain, tradeDirection)
+		if err != nil {
+			return nil, 0, err
+		}
+		sellAmountRemain = temp
+		if totalBuyAmount+buyAmount < totalBuyAmount {
+			return nil, 0, fmt.Errorf("Sum exceeds uint64 range after matching order")
+		}
+		totalBuyAmount += buyAmount
+		// add order balance changes to "accepted" instruction
+		acceptedMeta.OrderChanges[0][ordID] = [2]*big.Int{token0Change, token1Change}
+	}
+
+	acceptedMeta.PairChanges[0] = [2]*big.Int{accumulatedToken0Change, accumulatedToken1Change}
+	acceptedMeta.Amount = totalBuyAmount
+	return &acceptedMeta, sellAmountRemain, nil
+}
+
+// reachesLimit() returns true if an order's rate, from the incoming trade's view, is not worse than the limit's
+func reachesLimit(order, limit *MatchingOrder, tradeDirection byte) bool {
+	orderSell, orderBuy := order.Token0Rate(), order.Token1Rate()
+	limitSell, limitBuy := limit.Token0Rate(), limit.Token1Rate()
+	if tradeDirection == TradeDirectionSell1 {
+		orderSell, orderBuy = orderBuy, orderSell
+		limitSell, limitBuy = limitBuy, limitSell
+	}
+	// compare orderBuy / orderSell with limitBuy / limitSell by comparing products
+	orderSide := new(big.Int).Mul(new(big.Int).SetUint64(orderBuy), new(big.Int).SetUint64(limitSell))
+	limitSide := new(big.Int).Mul(new(big.Int).SetUint64(limitBuy), new(big.Int).SetUint64(orderSell))
+	return orderSide.Cmp(limitSide) >= 0
+}
+
 func TrackFee(
 	fee uint64, feeInPRV bool, sellingTokenID common.Hash, baseLPPerShare *big.Int, bps uint,
 	tradePath []string, reserves []*rawdbv2.Pdexv3PoolPair,
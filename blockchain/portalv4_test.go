@@ -531,6 +531,10 @@ func (s *PortalTestSuiteV4) TestShieldingRequest() {
 	networkName := "test3"
 	genesisBlockHeight := 1970227
 	chainParams, err := setGenesisBlockToChainParams(networkName, genesisBlockHeight)
+	if err != nil {
+		s.FailNow(fmt.Sprintf("Could not setGenesisBlockToChainParams with err: %v", err), nil)
+		return
+	}
 	dbName := "btc-blocks-test"
 	btcChain, err := btcrelaying.GetChainV2(dbName, chainParams, int32(genesisBlockHeight))
 	defer os.RemoveAll(dbName)
@@ -606,6 +610,10 @@ func (s *PortalTestSuiteV4) TestShieldingProof() {
 	networkName := "test3"
 	genesisBlockHeight := 2092170
 	chainParams, err := setGenesisBlockToChainParams(networkName, genesisBlockHeight)
+	if err != nil {
+		s.FailNow(fmt.Sprintf("Could not setGenesisBlockToChainParams with err: %v", err), nil)
+		return
+	}
 	dbName := "btc-blocks-test"
 	btcChain, err := btcrelaying.GetChainV2(dbName, chainParams, int32(genesisBlockHeight))
 	defer os.RemoveAll(dbName)
@@ -1771,6 +1779,10 @@ func (s *PortalTestSuiteV4) TestSubmitConfirmedTx() {
 	networkName := "test3"
 	genesisBlockHeight := 1970927
 	chainParams, err := setGenesisBlockToChainParams(networkName, genesisBlockHeight)
+	if err != nil {
+		s.FailNow(fmt.Sprintf("Could not setGenesisBlockToChainParams with err: %v", err), nil)
+		return
+	}
 	dbName := "btc-blocks-test"
 	btcChain, err := btcrelaying.GetChainV2(dbName, chainParams, int32(genesisBlockHeight))
 	defer os.RemoveAll(dbName)
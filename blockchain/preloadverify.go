@@ -0,0 +1,453 @@
+package blockchain
+
+import (
+	"fmt"
+
+	"github.com/incognitochain/incognito-chain/blockchain/committeestate"
+	"github.com/incognitochain/incognito-chain/blockchain/types"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/config"
+	"github.com/incognitochain/incognito-chain/incdb"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/trie"
+)
+
+// maxVerifiedBlocks bounds the number of blocks walked back from a view to verify it
+const maxVerifiedBlocks = 1000
+
+// VerifyBeaconViews checks the beacon views restored from a database this node did not build, e.g. a preloaded backup.
+// checkpoint is the hash of a trusted beacon block (genesis, or a block the node has finalized itself):
+// the last blocks up to each view, at most maxVerifiedBlocks, must be signed by the beacon committee of their previous block,
+// whose committee state must hash to the committee roots of that block header, and reach the checkpoint
+// or a block with the committee of the checkpoint.
+// The committee state of every view must hash to the committee roots of its best block,
+// and the state roots of every view must match the roots stored for its best block and resolve to complete tries.
+// The stored state roots of the previous block of every walked block must hash to its ParentStateRootsHash,
+// so the state roots of a view are committed once the view has a next block
+func (blockchain *BlockChain) VerifyBeaconViews(checkpoint common.Hash) error {
+	db := blockchain.GetBeaconChainDatabase()
+	verified := map[common.Hash]struct{}{checkpoint: {}}
+	committees := map[common.Hash][]incognitokey.CommitteePublicKey{}
+	for _, v := range blockchain.BeaconChain.multiView.GetAllViewsWithBFS() {
+		view := v.(*BeaconBestState)
+		if *view.BestBlock.Hash() != view.BestBlockHash {
+			return NewBlockChainError(HashError, fmt.Errorf("Beacon view %v has best block %v", view.BestBlockHash.String(), view.BestBlock.Hash().String()))
+		}
+		hashes, err := view.beaconCommitteeState.Hash(committeestate.NewCommitteeChange())
+		if err != nil {
+			return NewBlockChainError(BeaconError, err)
+		}
+		if err := view.verifyPostProcessingBeaconBlock(&view.BestBlock, hashes); err != nil {
+			return err
+		}
+		if err := blockchain.verifyBeaconChainFrom(view.BestBlockHash, checkpoint, verified, committees); err != nil {
+			return err
+		}
+
+		bRH, err := GetBeaconRootsHashByBlockHash(db, view.BestBlockHash)
+		if err != nil {
+			return NewBlockChainError(BeaconError, err)
+		}
+		roots := BeaconRootHash{
+			ConsensusStateDBRootHash: view.ConsensusStateDBRootHash,
+			FeatureStateDBRootHash:   view.FeatureStateDBRootHash,
+			RewardStateDBRootHash:    view.RewardStateDBRootHash,
+			SlashStateDBRootHash:     view.SlashStateDBRootHash,
+		}
+		if *bRH != roots {
+			return NewBlockChainError(BeaconError, fmt.Errorf("Beacon view %v has roots %+v, stored %+v", view.BestBlockHash.String(), roots, *bRH))
+		}
+		if err := verifyStateRoots(db, roots.ConsensusStateDBRootHash, roots.FeatureStateDBRootHash, roots.RewardStateDBRootHash, roots.SlashStateDBRootHash); err != nil {
+			return NewBlockChainError(BeaconError, fmt.Errorf("Beacon view %v: %v", view.BestBlockHash.String(), err))
+		}
+	}
+	return nil
+}
+
+// verifyBeaconChainFrom walks back from the block hash to the checkpoint or to a block in verified,
+// checking the signature & parent state roots of every block on the way, then adds the walked blocks to verified.
+// After maxVerifiedBlocks, the walk stops at a block with the committee of the checkpoint:
+// the trusted committee only signs blocks on top of a valid chain.
+// committees caches the beacon committees by BeaconCommitteeAndValidatorRoot
+func (blockchain *BlockChain) verifyBeaconChainFrom(
+	hash, checkpoint common.Hash,
+	verified map[common.Hash]struct{},
+	committees map[common.Hash][]incognitokey.CommitteePublicKey,
+) error {
+	checkpointBlock, err := blockchain.getVerifiedBeaconBlock(checkpoint)
+	if err != nil {
+		return NewBlockChainError(BeaconError, fmt.Errorf("Cannot get checkpoint %v: %v", checkpoint.String(), err))
+	}
+	checkpointCommitteeRoot, err := beaconCommitteeRoot(checkpointBlock)
+	if err != nil {
+		return NewBlockChainError(GenerateBeaconCommitteeAndValidatorRootError, err)
+	}
+	walked := []common.Hash{}
+	if _, ok := verified[hash]; ok {
+		return nil
+	}
+	block, err := blockchain.getVerifiedBeaconBlock(hash)
+	if err != nil {
+		return err
+	}
+	for {
+		if block.GetHeight() <= checkpointBlock.GetHeight() {
+			return NewBlockChainError(BeaconError, fmt.Errorf("Beacon block %v does not descend from checkpoint %v", hash.String(), checkpoint.String()))
+		}
+		prevBlock, err := blockchain.getVerifiedBeaconBlock(block.GetPrevHash())
+		if err != nil {
+			return err
+		}
+		committee, ok := committees[prevBlock.Header.BeaconCommitteeAndValidatorRoot]
+		if !ok {
+			prevView, err := blockchain.GetBeaconViewStateDataFromBlockHash(block.GetPrevHash(), true, false, false)
+			if err != nil {
+				return NewBlockChainError(BeaconError, err)
+			}
+			hashes, err := prevView.beaconCommitteeState.Hash(committeestate.NewCommitteeChange())
+			if err != nil {
+				return NewBlockChainError(BeaconError, err)
+			}
+			if !hashes.BeaconCommitteeAndValidatorHash.IsEqual(&prevBlock.Header.BeaconCommitteeAndValidatorRoot) {
+				return NewBlockChainError(BeaconCommitteeAndPendingValidatorRootError, fmt.Errorf("Beacon block %v expect %v but get %v",
+					block.GetPrevHash().String(), prevBlock.Header.BeaconCommitteeAndValidatorRoot, hashes.BeaconCommitteeAndValidatorHash))
+			}
+			committee = prevView.GetBeaconCommittee()
+			committees[prevBlock.Header.BeaconCommitteeAndValidatorRoot] = committee
+		}
+		if err := blockchain.BeaconChain.ValidateBlockSignatures(block, committee); err != nil {
+			return NewBlockChainError(SignatureError, fmt.Errorf("Beacon block %v: %v", block.Hash().String(), err))
+		}
+		if err := verifyBeaconParentStateRoots(blockchain.GetBeaconChainDatabase(), block); err != nil {
+			return err
+		}
+		walked = append(walked, *block.Hash())
+		if _, ok := verified[block.GetPrevHash()]; ok {
+			break
+		}
+		if len(walked) >= maxVerifiedBlocks {
+			if prevBlock.GetHeight() <= checkpointBlock.GetHeight() || prevBlock.Header.BeaconCommitteeAndValidatorRoot != checkpointCommitteeRoot {
+				return NewBlockChainError(BeaconError, fmt.Errorf("Beacon block %v is %v blocks above a committee change since checkpoint %v",
+					hash.String(), maxVerifiedBlocks, checkpoint.String()))
+			}
+			break
+		}
+		block = prevBlock
+	}
+	for _, h := range walked {
+		verified[h] = struct{}{}
+	}
+	return nil
+}
+
+// beaconCommitteeRoot returns the BeaconCommitteeAndValidatorRoot of a beacon block,
+// which the genesis block does not commit: its committee is the genesis one
+func beaconCommitteeRoot(block *types.BeaconBlock) (common.Hash, error) {
+	if block.GetHeight() > 1 {
+		return block.Header.BeaconCommitteeAndValidatorRoot, nil
+	}
+	return common.GenerateHashFromStringArray(config.Param().GenesisParam.PreSelectBeaconNodeSerializedPubkey)
+}
+
+// verifyBeaconParentStateRoots checks that the state roots stored for the previous block of a beacon block
+// hash to its ParentStateRootsHash, which is empty before feature stateroots
+func verifyBeaconParentStateRoots(db incdb.Database, block *types.BeaconBlock) error {
+	if block.Header.ParentStateRootsHash.IsZeroValue() {
+		return nil
+	}
+	bRH, err := GetBeaconRootsHashByBlockHash(db, block.GetPrevHash())
+	if err != nil {
+		return NewBlockChainError(BeaconError, err)
+	}
+	if bRH.Hash() != block.Header.ParentStateRootsHash {
+		return NewBlockChainError(StateRootsHashError, fmt.Errorf("Beacon block %v commits state roots %v, stored %+v",
+			block.Hash().String(), block.Header.ParentStateRootsHash.String(), *bRH))
+	}
+	return nil
+}
+
+// getVerifiedBeaconBlock gets the beacon block of hash from the database and checks that its content matches hash
+func (blockchain *BlockChain) getVerifiedBeaconBlock(hash common.Hash) (*types.BeaconBlock, error) {
+	block, _, err := blockchain.GetBeaconBlockByHash(hash)
+	if err != nil {
+		return nil, err
+	}
+	if *block.Hash() != hash {
+		return nil, NewBlockChainError(HashError, fmt.Errorf("Beacon block %v has hash %v", hash.String(), block.Hash().String()))
+	}
+	return block, nil
+}
+
+// VerifyShardViews checks the shard views restored from a database this node did not build, like VerifyBeaconViews,
+// against the beacon chain, which must be verified first:
+// the best block of every view must be confirmed by the beacon chain, or descend from a confirmed block
+// through at most maxVerifiedBlocks blocks signed by the committee the beacon chain assigned to them.
+// The committee state of every view must hash to CommitteeRoot & PendingValidatorRoot of its best block,
+// and the state roots of every view must match the roots stored for its best block and resolve to complete tries.
+// Like the beacon chain, the stored state roots are checked against the ParentStateRootsHash of the walked blocks
+func (blockchain *BlockChain) VerifyShardViews(shardID byte) error {
+	if int(shardID) >= len(blockchain.ShardChain) {
+		return NewBlockChainError(ShardStateError, fmt.Errorf("Invalid shard ID %v", shardID))
+	}
+	chain := blockchain.ShardChain[shardID]
+	db := blockchain.GetShardChainDatabase(shardID)
+	beaconChain := newBeaconAncestors(blockchain, blockchain.GetBeaconBestState())
+	verified := map[common.Hash]struct{}{}
+	for _, v := range chain.multiView.GetAllViewsWithBFS() {
+		view := v.(*ShardBestState)
+		if *view.BestBlock.Hash() != view.BestBlockHash {
+			return NewBlockChainError(HashError, fmt.Errorf("Shard %v view %v has best block %v", shardID, view.BestBlockHash.String(), view.BestBlock.Hash().String()))
+		}
+		hashes, err := committeestate.HashShardCommitteeState(view.shardCommitteeState)
+		if err != nil {
+			return NewBlockChainError(ShardStateError, err)
+		}
+		if err := view.verifyPostProcessingShardBlock(view.BestBlock, shardID, hashes); err != nil {
+			return err
+		}
+		if err := blockchain.verifyShardChainFrom(shardID, view.BestBlockHash, beaconChain, verified); err != nil {
+			return err
+		}
+
+		sRH, err := GetShardRootsHashByBlockHash(db, shardID, view.BestBlockHash)
+		if err != nil {
+			return NewBlockChainError(ShardStateError, err)
+		}
+		roots := ShardRootHash{
+			ConsensusStateDBRootHash:   view.ConsensusStateDBRootHash,
+			TransactionStateDBRootHash: view.TransactionStateDBRootHash,
+			FeatureStateDBRootHash:     view.FeatureStateDBRootHash,
+			RewardStateDBRootHash:      view.RewardStateDBRootHash,
+			SlashStateDBRootHash:       view.SlashStateDBRootHash,
+		}
+		if *sRH != roots {
+			return NewBlockChainError(ShardStateError, fmt.Errorf("Shard %v view %v has roots %+v, stored %+v", shardID, view.BestBlockHash.String(), roots, *sRH))
+		}
+		if err := verifyStateRoots(db, roots.ConsensusStateDBRootHash, roots.TransactionStateDBRootHash, roots.FeatureStateDBRootHash, roots.RewardStateDBRootHash, roots.SlashStateDBRootHash); err != nil {
+			return NewBlockChainError(ShardStateError, fmt.Errorf("Shard %v view %v: %v", shardID, view.BestBlockHash.String(), err))
+		}
+	}
+	return nil
+}
+
+// verifyShardChainFrom walks back from the shard block hash to the last block confirmed by the beacon chain,
+// checking the signature & parent state roots of every block on the way, then adds the walked blocks to verified
+func (blockchain *BlockChain) verifyShardChainFrom(shardID byte, hash common.Hash, beaconChain *beaconAncestors, verified map[common.Hash]struct{}) error {
+	if _, ok := verified[hash]; ok {
+		return nil
+	}
+	chain := blockchain.ShardChain[shardID]
+	block, err := blockchain.getVerifiedShardBlock(hash, shardID)
+	if err != nil {
+		return err
+	}
+	confirmed, err := beaconChain.confirmedShardState(shardID, block.GetHeight())
+	if err != nil {
+		return err
+	}
+	walked := []common.Hash{}
+	for block.GetHeight() > confirmed.Height {
+		if _, ok := verified[*block.Hash()]; ok {
+			break
+		}
+		if len(walked) >= maxVerifiedBlocks {
+			return NewBlockChainError(ShardStateError, fmt.Errorf("Shard %v block %v is more than %v blocks above the last block confirmed by beacon",
+				shardID, hash.String(), maxVerifiedBlocks))
+		}
+		receiver, ok := chain.GetViewByHash(block.GetPrevHash()).(*ShardBestState)
+		if !ok {
+			if block.Header.CommitteeFromBlock.IsZeroValue() || block.Header.Version == types.BFT_VERSION {
+				return NewBlockChainError(ShardStateError, fmt.Errorf("Shard %v block %v has no previous view to take its committee from", shardID, block.Hash().String()))
+			}
+			receiver = chain.GetFinalView().(*ShardBestState)
+		}
+		if !block.Header.CommitteeFromBlock.IsZeroValue() && block.Header.Version != types.BFT_VERSION {
+			if err := beaconChain.verifyShardCommittee(block.Header.CommitteeFromBlock, shardID); err != nil {
+				return err
+			}
+		}
+		_, signingCommittees, err := receiver.getSigningCommittees(block, blockchain)
+		if err != nil {
+			return NewBlockChainError(ShardStateError, err)
+		}
+		if err := chain.ValidateBlockSignatures(block, signingCommittees); err != nil {
+			return NewBlockChainError(SignatureError, fmt.Errorf("Shard %v block %v: %v", shardID, block.Hash().String(), err))
+		}
+		if err := verifyShardParentStateRoots(blockchain.GetShardChainDatabase(shardID), block); err != nil {
+			return err
+		}
+		walked = append(walked, *block.Hash())
+		if block, err = blockchain.getVerifiedShardBlock(block.GetPrevHash(), shardID); err != nil {
+			return err
+		}
+	}
+	if _, ok := verified[*block.Hash()]; !ok && (block.GetHeight() != confirmed.Height || *block.Hash() != confirmed.Hash) {
+		return NewBlockChainError(ShardStateError, fmt.Errorf("Shard %v block %v at height %v is not confirmed by beacon, expect %v",
+			shardID, block.Hash().String(), block.GetHeight(), confirmed.Hash.String()))
+	}
+	verified[*block.Hash()] = struct{}{}
+	for _, h := range walked {
+		verified[h] = struct{}{}
+	}
+	return nil
+}
+
+// verifyShardParentStateRoots checks that the state roots stored for the previous block of a shard block
+// hash to its ParentStateRootsHash, like verifyBeaconParentStateRoots
+func verifyShardParentStateRoots(db incdb.Database, block *types.ShardBlock) error {
+	if block.Header.ParentStateRootsHash.IsZeroValue() {
+		return nil
+	}
+	sRH, err := GetShardRootsHashByBlockHash(db, block.Header.ShardID, block.GetPrevHash())
+	if err != nil {
+		return NewBlockChainError(ShardStateError, err)
+	}
+	if sRH.Hash() != block.Header.ParentStateRootsHash {
+		return NewBlockChainError(StateRootsHashError, fmt.Errorf("Shard %v block %v commits state roots %v, stored %+v",
+			block.Header.ShardID, block.Hash().String(), block.Header.ParentStateRootsHash.String(), *sRH))
+	}
+	return nil
+}
+
+// getVerifiedShardBlock gets the shard block of hash from the database and checks that its content matches hash
+func (blockchain *BlockChain) getVerifiedShardBlock(hash common.Hash, shardID byte) (*types.ShardBlock, error) {
+	block, _, err := blockchain.GetShardBlockByHashWithShardID(hash, shardID)
+	if err != nil {
+		return nil, err
+	}
+	if *block.Hash() != hash || block.Header.ShardID != shardID {
+		return nil, NewBlockChainError(HashError, fmt.Errorf("Shard %v block %v has hash %v", shardID, hash.String(), block.Hash().String()))
+	}
+	return block, nil
+}
+
+// beaconAncestors walks back the beacon chain from a beacon view, which is trusted,
+// so every block reached through previous block hashes is trusted too
+type beaconAncestors struct {
+	blockchain        *BlockChain
+	head              *types.BeaconBlock
+	blocks            map[common.Hash]*types.BeaconBlock
+	tail              *types.BeaconBlock
+	verifiedCommittee map[common.Hash]struct{}
+}
+
+func newBeaconAncestors(blockchain *BlockChain, view *BeaconBestState) *beaconAncestors {
+	return &beaconAncestors{
+		blockchain:        blockchain,
+		head:              &view.BestBlock,
+		blocks:            map[common.Hash]*types.BeaconBlock{view.BestBlockHash: &view.BestBlock},
+		tail:              &view.BestBlock,
+		verifiedCommittee: map[common.Hash]struct{}{},
+	}
+}
+
+// next extends the walk by one block and returns it, nil at genesis
+func (b *beaconAncestors) next() (*types.BeaconBlock, error) {
+	if b.tail.GetHeight() <= 1 {
+		return nil, nil
+	}
+	block, err := b.blockchain.getVerifiedBeaconBlock(b.tail.GetPrevHash())
+	if err != nil {
+		return nil, err
+	}
+	b.blocks[b.tail.GetPrevHash()] = block
+	b.tail = block
+	return block, nil
+}
+
+// getBlock returns the beacon block of hash if it is an ancestor of the walked view
+func (b *beaconAncestors) getBlock(hash common.Hash) (*types.BeaconBlock, error) {
+	if block, ok := b.blocks[hash]; ok {
+		return block, nil
+	}
+	block, _, err := b.blockchain.GetBeaconBlockByHash(hash)
+	if err != nil {
+		return nil, err
+	}
+	for b.tail.GetHeight() > block.GetHeight() {
+		if _, err := b.next(); err != nil {
+			return nil, err
+		}
+	}
+	if block, ok := b.blocks[hash]; ok {
+		return block, nil
+	}
+	return nil, NewBlockChainError(BeaconError, fmt.Errorf("Beacon block %v is not in the beacon chain", hash.String()))
+}
+
+// confirmedShardState returns the state of the shard block at height if the beacon chain confirmed it,
+// otherwise the state of the last shard block below height confirmed by the last maxVerifiedBlocks beacon blocks
+func (b *beaconAncestors) confirmedShardState(shardID byte, height uint64) (*types.ShardState, error) {
+	block := b.head
+	for block != nil && b.head.GetHeight()-block.GetHeight() < maxVerifiedBlocks {
+		if !verifyHashFromShardState(block.Body.ShardState, block.Header.ShardStateHash, committeestate.SELF_SWAP_SHARD_VERSION) &&
+			!verifyHashFromShardState(block.Body.ShardState, block.Header.ShardStateHash, committeestate.STAKING_FLOW_V2) {
+			return nil, NewBlockChainError(ShardStateHashError, fmt.Errorf("Beacon block %v has invalid shard states", block.Hash().String()))
+		}
+		states := block.Body.ShardState[shardID]
+		for i := len(states) - 1; i >= 0; i-- {
+			if states[i].Height <= height {
+				return &states[i], nil
+			}
+		}
+		prevHash := block.GetPrevHash()
+		var ok bool
+		if block, ok = b.blocks[prevHash]; !ok {
+			var err error
+			if block, err = b.next(); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return nil, NewBlockChainError(ShardStateError, fmt.Errorf("No block of shard %v up to height %v is confirmed by the last %v beacon blocks",
+		shardID, height, maxVerifiedBlocks))
+}
+
+// verifyShardCommittee checks that the shard committees stored for the beacon block of hash
+// hash to the ShardCommitteeAndValidatorRoot of its header
+func (b *beaconAncestors) verifyShardCommittee(hash common.Hash, shardID byte) error {
+	if _, ok := b.verifiedCommittee[hash]; ok {
+		return nil
+	}
+	block, err := b.getBlock(hash)
+	if err != nil {
+		return err
+	}
+	view, err := b.blockchain.GetBeaconViewStateDataFromBlockHash(hash, true, false, false)
+	if err != nil {
+		return NewBlockChainError(BeaconError, err)
+	}
+	hashes, err := view.beaconCommitteeState.Hash(committeestate.NewCommitteeChange())
+	if err != nil {
+		return NewBlockChainError(BeaconError, err)
+	}
+	if !hashes.ShardCommitteeAndValidatorHash.IsEqual(&block.Header.ShardCommitteeAndValidatorRoot) {
+		return NewBlockChainError(ShardCommitteeAndPendingValidatorRootError, fmt.Errorf("Beacon block %v expect %v but get %v",
+			hash.String(), block.Header.ShardCommitteeAndValidatorRoot, hashes.ShardCommitteeAndValidatorHash))
+	}
+	committees, err := b.blockchain.getShardCommitteeForBlockProducing(hash, shardID)
+	if err != nil {
+		return NewBlockChainError(BeaconError, err)
+	}
+	viewCommittees := view.GetShardCommittee()[shardID]
+	sameCommittees := len(committees) == len(viewCommittees)
+	for i := 0; sameCommittees && i < len(committees); i++ {
+		sameCommittees = committees[i].IsEqual(viewCommittees[i])
+	}
+	if !sameCommittees {
+		return NewBlockChainError(ShardCommitteeAndPendingValidatorRootError, fmt.Errorf("Beacon block %v has unexpected shard %v committee", hash.String(), shardID))
+	}
+	b.verifiedCommittee[hash] = struct{}{}
+	return nil
+}
+
+// verifyStateRoots checks that every node of the state tries of roots is stored in db and matches its hash,
+// so the tries read from db are complete and consistent with the roots of the views
+func verifyStateRoots(db incdb.Database, roots ...common.Hash) error {
+	for _, root := range roots {
+		if err := trie.VerifyTrie(root, db); err != nil {
+			return fmt.Errorf("Invalid state trie %v: %v", root.String(), err)
+		}
+	}
+	return nil
+}
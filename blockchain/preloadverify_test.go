@@ -0,0 +1,185 @@
+package blockchain
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/blockchain/types"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/config"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/incdb"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+)
+
+func newPreloadTestBlockChain(t *testing.T) (*BlockChain, incdb.Database, func()) {
+	dbPath, err := ioutil.TempDir(os.TempDir(), "preload_verify_")
+	if err != nil {
+		t.Fatal(err)
+	}
+	db, err := incdb.Open("leveldb", dbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bc := &BlockChain{config: Config{DataBase: map[int]incdb.Database{common.BeaconChainID: db}}}
+	return bc, db, func() {
+		db.Close()
+		os.RemoveAll(dbPath)
+	}
+}
+
+func newPreloadTestBeaconBlock(height uint64, prevHash common.Hash) *types.BeaconBlock {
+	block := types.NewBeaconBlock()
+	block.Header.Height = height
+	block.Header.PreviousBlockHash = prevHash
+	return block
+}
+
+func TestGetVerifiedBeaconBlock(t *testing.T) {
+	bc, db, closeDB := newPreloadTestBlockChain(t)
+	defer closeDB()
+
+	block := newPreloadTestBeaconBlock(10, common.Hash{1})
+	if err := rawdbv2.StoreBeaconBlockByHash(db, *block.Hash(), block); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := bc.getVerifiedBeaconBlock(*block.Hash()); err != nil {
+		t.Fatalf("Expect a valid block, got %v", err)
+	}
+
+	// a block whose content does not match the hash it is stored with
+	tampered := newPreloadTestBeaconBlock(10, common.Hash{2})
+	if err := rawdbv2.StoreBeaconBlockByHash(db, *block.Hash(), tampered); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := bc.getVerifiedBeaconBlock(*block.Hash()); err == nil {
+		t.Fatal("Expect a tampered block to be rejected")
+	}
+}
+
+func TestVerifyBeaconChainFromCheckpoint(t *testing.T) {
+	bc, db, closeDB := newPreloadTestBlockChain(t)
+	defer closeDB()
+
+	checkpoint := newPreloadTestBeaconBlock(5, common.Hash{1})
+	fork := newPreloadTestBeaconBlock(5, common.Hash{2})
+	for _, block := range []*types.BeaconBlock{checkpoint, fork} {
+		if err := rawdbv2.StoreBeaconBlockByHash(db, *block.Hash(), block); err != nil {
+			t.Fatal(err)
+		}
+	}
+	verified := map[common.Hash]struct{}{*checkpoint.Hash(): {}}
+	committees := map[common.Hash][]incognitokey.CommitteePublicKey{}
+	if err := bc.verifyBeaconChainFrom(*checkpoint.Hash(), *checkpoint.Hash(), verified, committees); err != nil {
+		t.Fatalf("Expect the checkpoint to be verified, got %v", err)
+	}
+	if err := bc.verifyBeaconChainFrom(*fork.Hash(), *checkpoint.Hash(), verified, committees); err == nil {
+		t.Fatal("Expect a block not descending from the checkpoint to be rejected")
+	}
+	if err := bc.verifyBeaconChainFrom(*fork.Hash(), common.Hash{3}, verified, committees); err == nil {
+		t.Fatal("Expect an unknown checkpoint to be rejected")
+	}
+}
+
+func TestVerifyParentStateRoots(t *testing.T) {
+	_, db, closeDB := newPreloadTestBlockChain(t)
+	defer closeDB()
+
+	bRH := BeaconRootHash{ConsensusStateDBRootHash: common.Hash{1}}
+	if err := rawdbv2.StoreBeaconRootsHash(db, common.Hash{2}, bRH); err != nil {
+		t.Fatal(err)
+	}
+	sRH := ShardRootHash{TransactionStateDBRootHash: common.Hash{1}}
+	if err := rawdbv2.StoreShardRootsHash(db, 0, common.Hash{2}, sRH); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name                 string
+		prevHash             common.Hash
+		parentStateRootsHash common.Hash
+		wantErr              bool
+	}{
+		{"roots not committed", common.Hash{3}, common.Hash{}, false},
+		{"stored roots", common.Hash{2}, bRH.Hash(), false},
+		{"tampered roots", common.Hash{2}, common.Hash{4}, true},
+		{"missing roots", common.Hash{3}, bRH.Hash(), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			beaconBlock := newPreloadTestBeaconBlock(10, tt.prevHash)
+			beaconBlock.Header.ParentStateRootsHash = tt.parentStateRootsHash
+			if err := verifyBeaconParentStateRoots(db, beaconBlock); (err != nil) != tt.wantErr {
+				t.Fatalf("Beacon: expect error %v, got %v", tt.wantErr, err)
+			}
+			shardBlock := types.NewShardBlock()
+			shardBlock.Header.Height = 10
+			shardBlock.Header.PreviousBlockHash = tt.prevHash
+			shardBlock.Header.ParentStateRootsHash = tt.parentStateRootsHash
+			if tt.parentStateRootsHash == bRH.Hash() {
+				shardBlock.Header.ParentStateRootsHash = sRH.Hash()
+			}
+			if err := verifyShardParentStateRoots(db, shardBlock); (err != nil) != tt.wantErr {
+				t.Fatalf("Shard: expect error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestBeaconCommitteeRoot(t *testing.T) {
+	block := newPreloadTestBeaconBlock(10, common.Hash{1})
+	block.Header.BeaconCommitteeAndValidatorRoot = common.Hash{2}
+	if root, err := beaconCommitteeRoot(block); err != nil || root != (common.Hash{2}) {
+		t.Fatalf("Expect the root of the header, got %v %v", root.String(), err)
+	}
+
+	config.AbortParam()
+	genesisParam := *config.MainnetParam.GenesisParam
+	genesisParam.PreSelectBeaconNodeSerializedPubkey = keys[0:4]
+	config.Param().GenesisParam = &genesisParam
+	expect, _ := common.GenerateHashFromStringArray(keys[0:4])
+	genesis := newPreloadTestBeaconBlock(1, common.Hash{})
+	if root, err := beaconCommitteeRoot(genesis); err != nil || root != expect {
+		t.Fatalf("Expect the root of the genesis committee %v, got %v %v", expect.String(), root.String(), err)
+	}
+}
+
+func TestVerifyStateRoots(t *testing.T) {
+	_, db, closeDB := newPreloadTestBlockChain(t)
+	defer closeDB()
+
+	stateDB, err := statedb.NewWithPrefixTrie(common.EmptyRoot, statedb.NewDatabaseAccessWarper(db))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := statedb.StoreBeaconCommittee(stateDB, incognitoKeys); err != nil {
+		t.Fatal(err)
+	}
+	root, err := stateDB.Commit(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := stateDB.Database().TrieDB().Commit(root, false); err != nil {
+		t.Fatal(err)
+	}
+	if err := verifyStateRoots(db, root, common.EmptyRoot); err != nil {
+		t.Fatalf("Expect valid state roots, got %v", err)
+	}
+
+	// tamper the root node
+	enc, err := db.Get(root[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	enc[len(enc)-1] ^= 1
+	if err := db.Put(root[:], enc); err != nil {
+		t.Fatal(err)
+	}
+	if err := verifyStateRoots(db, root); err == nil {
+		t.Fatal("Expect a tampered state trie to be rejected")
+	}
+	if err := verifyStateRoots(db, common.Hash{1}); err == nil {
+		t.Fatal("Expect a missing state trie to be rejected")
+	}
+}
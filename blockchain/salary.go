@@ -137,6 +137,22 @@ func (blockchain *BlockChain) addShardRewardRequestToBeacon(beaconBlock *types.B
 
 func (blockchain *BlockChain) processSalaryInstructions(rewardStateDB *statedb.StateDB, beaconBlocks []*types.BeaconBlock, confirmBeaconHeight uint64, shardID byte) error {
 	for _, beaconBlock := range beaconBlocks {
+		// the delegation pools of the beacon block, loaded once for all its reward instructions,
+		// there is none before the delegation height
+		var delegationStateDB *statedb.StateDB
+		getDelegationStateDB := func() (*statedb.StateDB, error) {
+			if beaconBlock.Header.Height < config.Param().DelegationParam.DelegationHeight {
+				return nil, nil
+			}
+			if delegationStateDB == nil {
+				stateDB, err := getBeaconConsensusStateDB(blockchain.GetBeaconChainDatabase(), beaconBlock.Header.Hash())
+				if err != nil {
+					return nil, NewBlockChainError(ProcessSalaryInstructionsError, err)
+				}
+				delegationStateDB = stateDB
+			}
+			return delegationStateDB, nil
+		}
 		for _, l := range beaconBlock.Body.Instructions {
 
 			if len(l) <= 2 {
@@ -171,7 +187,11 @@ func (blockchain *BlockChain) processSalaryInstructions(rewardStateDB *statedb.S
 						if err != nil {
 							return NewBlockChainError(ProcessSalaryInstructionsError, err)
 						}
-						err = blockchain.addShardCommitteeRewardSlashingVersion(rewardStateDB, shardID, shardReceiveRewardV3.Reward(), nonSlashingCInfosV2)
+						beaconConsensusStateDB, err := getDelegationStateDB()
+						if err != nil {
+							return err
+						}
+						err = blockchain.addShardCommitteeRewardSlashingVersion(rewardStateDB, beaconConsensusStateDB, shardID, shardReceiveRewardV3.Reward(), nonSlashingCInfosV2)
 						if err != nil {
 							return err
 						}
@@ -253,7 +273,11 @@ func (blockchain *BlockChain) processSalaryInstructions(rewardStateDB *statedb.S
 					if err != nil {
 						return NewBlockChainError(ProcessSalaryInstructionsError, err)
 					}
-					err = blockchain.addShardCommitteeRewardSlashingVersion(rewardStateDB, shardID, shardRewardInfo.ShardReward, nonSlashingCInfosV2)
+					beaconConsensusStateDB, err := getDelegationStateDB()
+					if err != nil {
+						return err
+					}
+					err = blockchain.addShardCommitteeRewardSlashingVersion(rewardStateDB, beaconConsensusStateDB, shardID, shardRewardInfo.ShardReward, nonSlashingCInfosV2)
 					if err != nil {
 						return err
 					}
@@ -318,6 +342,7 @@ func (blockchain *BlockChain) addShardCommitteeReward(
 
 func (blockchain *BlockChain) addShardCommitteeRewardSlashingVersion(
 	rewardStateDB *statedb.StateDB,
+	beaconConsensusStateDB *statedb.StateDB,
 	shardID byte,
 	reward map[common.Hash]uint64,
 	cStakeInfos []*statedb.StakerInfoSlashingVersion,
@@ -326,6 +351,35 @@ func (blockchain *BlockChain) addShardCommitteeRewardSlashingVersion(
 ) {
 	committeeSize := len(cStakeInfos)
 	for _, candidate := range cStakeInfos {
+		// the reward of a delegation pool validator is split between its operator and delegators
+		var pool *statedb.DelegationPoolState
+		hasPool := false
+		if beaconConsensusStateDB != nil {
+			pool, hasPool, err = statedb.GetDelegationPool(beaconConsensusStateDB, candidate.CommitteePublicKey())
+			if err != nil {
+				return NewBlockChainError(ProcessSalaryInstructionsError, err)
+			}
+		}
+		if hasPool {
+			for key, value := range reward {
+				receivers, amounts, err := splitDelegationPoolReward(pool, value/uint64(committeeSize))
+				if err != nil {
+					return NewBlockChainError(ProcessSalaryInstructionsError, err)
+				}
+				for i, receiver := range receivers {
+					if common.GetShardIDFromLastByte(receiver.Pk[common.PublicKeySize-1]) != shardID {
+						continue
+					}
+					tempPK := base58.Base58Check{}.Encode(receiver.Pk, common.Base58Version)
+					Logger.log.Criticalf("Add Committee Reward Delegation, Public Key %+v, reward %+v, token %+v", tempPK, amounts[i], key)
+					err = statedb.AddCommitteeReward(rewardStateDB, tempPK, amounts[i], key)
+					if err != nil {
+						return NewBlockChainError(ProcessSalaryInstructionsError, err)
+					}
+				}
+			}
+			continue
+		}
 		if common.GetShardIDFromLastByte(candidate.RewardReceiver().Pk[common.PublicKeySize-1]) == shardID {
 			for key, value := range reward {
 				tempPK := base58.Base58Check{}.Encode(candidate.RewardReceiver().Pk, common.Base58Version)
@@ -17,6 +17,7 @@ import (
 	"github.com/incognitochain/incognito-chain/incognitokey"
 	"github.com/incognitochain/incognito-chain/instruction"
 	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/multiview"
 	"github.com/incognitochain/incognito-chain/trie"
 	"github.com/incognitochain/incognito-chain/wallet"
 )
@@ -516,9 +517,14 @@ func TestBlockChain_addShardRewardRequestToBeacon(t *testing.T) {
 			wantErr: false,
 		},
 	}
+	beaconView := NewBeaconBestState()
+	beaconView.ActiveShards = 1
+	beaconMultiView := multiview.NewBeaconMultiView()
+	beaconMultiView.AddView(beaconView)
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			blockchain := &BlockChain{}
+			blockchain.BeaconChain = NewBeaconChain(beaconMultiView, nil, blockchain, common.BeaconChainKey)
 			if err := blockchain.addShardRewardRequestToBeacon(tt.args.beaconBlock, sDB, &BeaconBestState{}); (err != nil) != tt.wantErr {
 				t.Errorf("addShardRewardRequestToBeacon() error = %v, wantErr %v", err, tt.wantErr)
 			}
@@ -38,6 +38,15 @@ type ShardRootHash struct {
 	SlashStateDBRootHash       common.Hash
 }
 
+// Hash is the hash of all state roots, committed by the next block header after feature stateroots
+func (sRH ShardRootHash) Hash() common.Hash {
+	return common.HashH(append(append(append(append(sRH.ConsensusStateDBRootHash.GetBytes(),
+		sRH.TransactionStateDBRootHash.GetBytes()...),
+		sRH.FeatureStateDBRootHash.GetBytes()...),
+		sRH.RewardStateDBRootHash.GetBytes()...),
+		sRH.SlashStateDBRootHash.GetBytes()...))
+}
+
 type ShardBestState struct {
 	blockChain                       *BlockChain
 	BestBlockHash                    common.Hash       `json:"BestBlockHash"` // hash of block.
@@ -113,6 +122,10 @@ func (shardBestState *ShardBestState) GetShardRewardStateDB() *statedb.StateDB {
 	return shardBestState.rewardStateDB.Copy()
 }
 
+func (shardBestState *ShardBestState) GetCopiedSlashStateDB() *statedb.StateDB {
+	return shardBestState.slashStateDB.Copy()
+}
+
 func (shardBestState *ShardBestState) GetHash() *common.Hash {
 	return shardBestState.BestBlock.Hash()
 }
@@ -204,6 +217,21 @@ func (shardBestState *ShardBestState) InitStateRootHash(db incdb.Database) error
 	return nil
 }
 
+// parentStateRootsHash is the ParentStateRootsHash of the next block header, the hash of the state roots of this view
+// once feature stateroots is triggered, empty before
+func (shardBestState *ShardBestState) parentStateRootsHash() common.Hash {
+	if shardBestState.TriggeredFeature[STATE_ROOTS_FEATURE] == 0 {
+		return common.Hash{}
+	}
+	return ShardRootHash{
+		ConsensusStateDBRootHash:   shardBestState.ConsensusStateDBRootHash,
+		TransactionStateDBRootHash: shardBestState.TransactionStateDBRootHash,
+		FeatureStateDBRootHash:     shardBestState.FeatureStateDBRootHash,
+		RewardStateDBRootHash:      shardBestState.RewardStateDBRootHash,
+		SlashStateDBRootHash:       shardBestState.SlashStateDBRootHash,
+	}.Hash()
+}
+
 // Get role of a public key base on best state shard
 func (shardBestState *ShardBestState) GetBytes() []byte {
 	res := []byte{}
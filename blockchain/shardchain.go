@@ -62,8 +62,7 @@ func NewShardChain(
 }
 
 func (chain *ShardChain) GetInsertLock() *sync.Mutex {
-	insertLock := chain.insertLock
-	return &insertLock
+	return &chain.insertLock
 }
 
 func (chain *ShardChain) GetDatabase() incdb.Database {
@@ -25,6 +25,7 @@ import (
 	metadataBridge "github.com/incognitochain/incognito-chain/metadata/bridge"
 	metadataCommon "github.com/incognitochain/incognito-chain/metadata/common"
 	"github.com/incognitochain/incognito-chain/pubsub"
+	"github.com/incognitochain/incognito-chain/tracing"
 )
 
 // VerifyPreSignShardBlock Verify Shard Block Before Signing
@@ -132,7 +133,15 @@ func (blockchain *BlockChain) VerifyPreSignShardBlock(
 // InsertShardBlock Insert Shard Block into blockchain
 // this block must have full information (complete block)
 func (blockchain *BlockChain) InsertShardBlock(shardBlock *types.ShardBlock, shouldValidate bool) error {
-	//startTimeInsertShardBlock := time.Now()
+	span := tracing.StartBlockSpan(*shardBlock.Hash(), "shard.insert",
+		tracing.Attr("shard", shardBlock.Header.ShardID), tracing.Attr("height", shardBlock.Header.Height), tracing.Attr("validate", shouldValidate))
+	err := blockchain.insertShardBlock(shardBlock, shouldValidate)
+	span.End(err)
+	return err
+}
+
+func (blockchain *BlockChain) insertShardBlock(shardBlock *types.ShardBlock, shouldValidate bool) error {
+	startTimeInsertShardBlock := time.Now()
 	blockHash := shardBlock.Header.Hash()
 	blockHeight := shardBlock.Header.Height
 	shardID := shardBlock.Header.ShardID
@@ -211,7 +220,10 @@ func (blockchain *BlockChain) InsertShardBlock(shardBlock *types.ShardBlock, sho
 
 	if shouldValidate {
 		Logger.log.Infof("SHARD %+v | Verify Pre Processing, block height %+v with hash %+v", shardID, blockHeight, blockHash)
-		if err := blockchain.verifyPreProcessingShardBlock(curView, shardBlock, beaconBlocks, shardID, false, signingCommittees); err != nil {
+		span := tracing.StartBlockSpan(blockHash, "shard.verifyPreProcessing")
+		err := blockchain.verifyPreProcessingShardBlock(curView, shardBlock, beaconBlocks, shardID, false, signingCommittees)
+		span.End(err)
+		if err != nil {
 			return err
 		}
 	} else {
@@ -221,7 +233,10 @@ func (blockchain *BlockChain) InsertShardBlock(shardBlock *types.ShardBlock, sho
 	if shouldValidate {
 		// Verify block with previous best state
 		Logger.log.Infof("SHARD %+v | Verify BestState With Shard Block, block height %+v with hash %+v", shardBlock.Header.ShardID, shardBlock.Header.Height, blockHash)
-		if err := curView.verifyBestStateWithShardBlock(blockchain, shardBlock, signingCommittees, committees); err != nil {
+		span := tracing.StartBlockSpan(blockHash, "shard.verifyBestState")
+		err := curView.verifyBestStateWithShardBlock(blockchain, shardBlock, signingCommittees, committees)
+		span.End(err)
+		if err != nil {
 			return err
 		}
 	} else {
@@ -243,7 +258,9 @@ func (blockchain *BlockChain) InsertShardBlock(shardBlock *types.ShardBlock, sho
 		}
 	}
 
+	span := tracing.StartBlockSpan(blockHash, "shard.updateBestState")
 	newBestState, hashes, committeeChange, err := curView.updateShardBestState(blockchain, shardBlock, beaconBlocks, committees)
+	span.End(err)
 	if err != nil {
 		return err
 	}
@@ -251,7 +268,10 @@ func (blockchain *BlockChain) InsertShardBlock(shardBlock *types.ShardBlock, sho
 	//========Post verification: verify new beaconstate with corresponding block
 	if shouldValidate {
 		Logger.log.Debugf("SHARD %+v | Verify Post Processing, block height %+v with hash %+v", shardBlock.Header.ShardID, shardBlock.Header.Height, blockHash)
-		if err = newBestState.verifyPostProcessingShardBlock(shardBlock, shardID, hashes); err != nil {
+		span := tracing.StartBlockSpan(blockHash, "shard.verifyPostProcessing")
+		err = newBestState.verifyPostProcessingShardBlock(shardBlock, shardID, hashes)
+		span.End(err)
+		if err != nil {
 			return err
 		}
 	} else {
@@ -265,7 +285,9 @@ func (blockchain *BlockChain) InsertShardBlock(shardBlock *types.ShardBlock, sho
 	}
 
 	Logger.log.Infof("SHARD %+v | Store New Shard Block And Update Data, block height %+v with hash %+v \n", shardID, blockHeight, blockHash)
+	span = tracing.StartBlockSpan(blockHash, "shard.store")
 	err = blockchain.processStoreShardBlock(newBestState, shardBlock, committeeChange, beaconBlocks)
+	span.End(err)
 	if err != nil {
 		return err
 	}
@@ -274,12 +296,15 @@ func (blockchain *BlockChain) InsertShardBlock(shardBlock *types.ShardBlock, sho
 	blockchain.removeOldDataAfterProcessingShardBlock(shardBlock, shardID)
 	go blockchain.config.PubSubManager.PublishMessage(pubsub.NewMessage(pubsub.NewShardblockTopic, shardBlock))
 	go blockchain.config.PubSubManager.PublishMessage(pubsub.NewMessage(pubsub.ShardBeststateTopic, newBestState))
+	blockchain.wakeIncomingCoinNotifier()
 	Logger.log.Infof("SHARD %+v | Finish Insert new block %d, with hash %+v 🔗, "+
 		"Found 🔎 %+v transactions, "+
 		"%+v cross shard transactions, "+
 		"%+v instruction",
 		shardBlock.Header.ShardID, shardBlock.Header.Height, blockHash,
 		len(shardBlock.Body.Transactions), len(shardBlock.Body.CrossTransactions), len(shardBlock.Body.Instructions))
+	shardInsertBlockTimer.UpdateSince(startTimeInsertShardBlock)
+	shardInsertBlockTimerByShard(shardID).UpdateSince(startTimeInsertShardBlock)
 	return nil
 }
 
@@ -359,6 +384,10 @@ func (blockchain *BlockChain) verifyPreProcessingShardBlock(curView *ShardBestSt
 		return NewBlockChainError(WrongTimeslotError, fmt.Errorf("Propose timeslot must be greater than last propose timeslot (but get %v <= %v) ", curView.CalculateTimeSlot(shardBlock.Header.ProposeTime), curView.CalculateTimeSlot(curView.BestBlock.GetProposeTime())))
 	}
 
+	if shardBlock.Header.ParentStateRootsHash != curView.parentStateRootsHash() {
+		return NewBlockChainError(StateRootsHashError, fmt.Errorf("Expect parent state roots hash to be %+v but get %+v", curView.parentStateRootsHash(), shardBlock.Header.ParentStateRootsHash))
+	}
+
 	// Verify transaction root
 	txMerkleTree := types.Merkle{}.BuildMerkleTreeStore(shardBlock.Body.Transactions)
 	txRoot := &common.Hash{}
@@ -621,7 +650,9 @@ func (blockchain *BlockChain) verifyPreProcessingShardBlockForSigning(curView *S
 			crossShardRequired[fromShard] = append(crossShardRequired[fromShard], crossTransaction.BlockHeight)
 		}
 	}
+	span := tracing.StartBlockSpan(*shardBlock.Hash(), "shard.waitCrossShard", tracing.Attr("crossShards", len(crossShardRequired)))
 	crossShardBlksFromPool, err := blockchain.config.Syncker.GetCrossShardBlocksForShardValidator(curView, crossShardRequired)
+	span.End(err)
 	if err != nil {
 		return NewBlockChainError(CrossShardBlockError, fmt.Errorf("Unable to get required crossShard blocks from pool in time"))
 	}
@@ -1025,7 +1056,8 @@ func hasCommitteeRelatedTx(txs ...metadata.Transaction) bool {
 	for _, tx := range txs {
 		if tx.GetMetadata() != nil {
 			switch tx.GetMetadata().GetType() {
-			case metadata.BeaconStakingMeta, metadata.ShardStakingMeta, metadata.StopAutoStakingMeta, metadata.UnStakingMeta:
+			case metadata.BeaconStakingMeta, metadata.ShardStakingMeta, metadata.StopAutoStakingMeta, metadata.UnStakingMeta,
+				metadata.CreateDelegationPoolMeta:
 				return true
 			}
 		}
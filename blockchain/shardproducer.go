@@ -304,6 +304,7 @@ func (blockchain *BlockChain) NewBlockShard(curView *ShardBestState,
 		ConsensusType:      shardBestState.ConsensusAlgorithm,
 		CommitteeFromBlock: committeeFromBlockHash,
 	}
+	newShardBlock.Header.ParentStateRootsHash = shardBestState.parentStateRootsHash()
 	//============Update Shard BestState=============
 	// startStep = time.Now()
 	_, hashes, _, err := shardBestState.updateShardBestState(blockchain, newShardBlock, beaconBlocks, currentCommitteePublicKeysStructs)
@@ -591,6 +592,16 @@ func (blockGenerator *BlockGenerator) buildResponseTxsFromBeaconInstructions(
 					newTx, err = curView.buildPortalRefundedUnshieldingRequestTx(blockGenerator.chain.GetBeaconBestState(), inst[3], producerPrivateKey, shardID)
 				}
 
+			// delegation
+			case metadata.CreateDelegationPoolMeta, metadata.DelegateStakingMeta:
+				if len(inst) == 4 && inst[2] == metadata.DelegationRejectedStatus {
+					newTx, err = blockGenerator.buildDelegationResponseTx(inst[2], inst[3], producerPrivateKey, shardID, curView)
+				}
+			case metadata.UnbondDelegationMeta:
+				if len(inst) == 4 && inst[2] == metadata.DelegationReleasedStatus {
+					newTx, err = blockGenerator.buildDelegationResponseTx(inst[2], inst[3], producerPrivateKey, shardID, curView)
+				}
+
 			default:
 				if metadataCommon.IsPDEType(metaType) {
 					pdeTxBuilderV1 := pdex.TxBuilderV1{}
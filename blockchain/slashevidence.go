@@ -0,0 +1,271 @@
+package blockchain
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/incognitochain/incognito-chain/blockchain/types"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/config"
+	"github.com/incognitochain/incognito-chain/consensus_v2/signatureschemes/blsmultisig"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/instruction"
+)
+
+// MAX_SLASH_EVIDENCE_PER_BLOCK is the maximum number of slash evidence instructions in a beacon block
+const MAX_SLASH_EVIDENCE_PER_BLOCK = 4
+
+// slashEvidencePool keeps the slash evidences received by this beacon node until they are included in a beacon block
+type slashEvidencePool struct {
+	mu        sync.RWMutex
+	evidences map[string][]string // committee public key => slash evidence instruction
+}
+
+var defaultSlashEvidencePool = &slashEvidencePool{
+	evidences: make(map[string][]string),
+}
+
+func (pool *slashEvidencePool) add(publicKey string, inst []string) bool {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+	if _, ok := pool.evidences[publicKey]; ok {
+		return false
+	}
+	pool.evidences[publicKey] = inst
+	return true
+}
+
+func (pool *slashEvidencePool) remove(publicKey string) {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+	delete(pool.evidences, publicKey)
+}
+
+func (pool *slashEvidencePool) getAll() map[string][]string {
+	pool.mu.RLock()
+	defer pool.mu.RUnlock()
+	res := make(map[string][]string, len(pool.evidences))
+	for k, v := range pool.evidences {
+		res[k] = v
+	}
+	return res
+}
+
+// AddSlashEvidence verifies a slash evidence received from the network with the beacon best view
+// and keeps it to be included in a next beacon block
+func (blockchain *BlockChain) AddSlashEvidence(inst []string) error {
+	slashEvidenceInstruction, err := instruction.ValidateAndImportSlashEvidenceInstructionFromString(inst)
+	if err != nil {
+		return err
+	}
+	bestView := blockchain.GetBeaconBestState()
+	if err := bestView.verifySlashEvidence(blockchain, slashEvidenceInstruction); err != nil {
+		return err
+	}
+	if defaultSlashEvidencePool.add(slashEvidenceInstruction.PublicKey, inst) {
+		Logger.log.Infof("Add slash evidence of shard %v validator %v", slashEvidenceInstruction.ChainID, slashEvidenceInstruction.PublicKey)
+	}
+	return nil
+}
+
+// generateSlashEvidenceInstructions returns the slash evidences in pool still valid with curView,
+// invalid ones (e.g. validator already slashed) are removed from pool
+func (blockchain *BlockChain) generateSlashEvidenceInstructions(curView *BeaconBestState) [][]string {
+	instructions := [][]string{}
+	if curView.BeaconHeight+1 < config.Param().ConsensusParam.SlashEvidenceHeight {
+		return instructions
+	}
+	for publicKey, inst := range defaultSlashEvidencePool.getAll() {
+		if len(instructions) >= MAX_SLASH_EVIDENCE_PER_BLOCK {
+			break
+		}
+		slashEvidenceInstruction, err := instruction.ValidateAndImportSlashEvidenceInstructionFromString(inst)
+		if err == nil {
+			err = curView.verifySlashEvidence(blockchain, slashEvidenceInstruction)
+		}
+		if err != nil {
+			Logger.log.Infof("Remove slash evidence of %v, error %v", publicKey, err)
+			defaultSlashEvidencePool.remove(publicKey)
+			continue
+		}
+		instructions = append(instructions, inst)
+	}
+	return instructions
+}
+
+// filterAndVerifySlashEvidenceInstructions returns the slash evidence instructions of a beacon block,
+// error if one of them is not valid with curView, the view before the block
+func (curView *BeaconBestState) filterAndVerifySlashEvidenceInstructions(blockchain *BlockChain, instructions [][]string) ([][]string, error) {
+	slashEvidenceInstructions := [][]string{}
+	slashedValidators := make(map[string]bool)
+	for _, v := range instructions {
+		if len(v) == 0 || v[0] != instruction.SLASH_EVIDENCE_ACTION {
+			continue
+		}
+		if curView.BeaconHeight+1 < config.Param().ConsensusParam.SlashEvidenceHeight {
+			return nil, fmt.Errorf("Slash evidence is not enabled before beacon height %v", config.Param().ConsensusParam.SlashEvidenceHeight)
+		}
+		inst, err := instruction.ValidateAndImportSlashEvidenceInstructionFromString(v)
+		if err != nil {
+			return nil, err
+		}
+		if slashedValidators[inst.PublicKey] {
+			return nil, fmt.Errorf("Duplicate slash evidence of validator %v", inst.PublicKey)
+		}
+		if err := curView.verifySlashEvidence(blockchain, inst); err != nil {
+			return nil, err
+		}
+		slashedValidators[inst.PublicKey] = true
+		slashEvidenceInstructions = append(slashEvidenceInstructions, v)
+	}
+	if len(slashEvidenceInstructions) > MAX_SLASH_EVIDENCE_PER_BLOCK {
+		return nil, fmt.Errorf("Too many slash evidences %v, max %v", len(slashEvidenceInstructions), MAX_SLASH_EVIDENCE_PER_BLOCK)
+	}
+	return slashEvidenceInstructions, nil
+}
+
+// verifySlashEvidence checks that the validator of a slash evidence still stakes in the shard and is not a fixed validator,
+// and both votes of the evidence are its valid signatures on different blocks of the same height, committee,
+// produce timeslot and propose timeslot
+func (curView *BeaconBestState) verifySlashEvidence(blockchain *BlockChain, inst *instruction.SlashEvidenceInstruction) error {
+	if inst.ChainID < 0 || inst.ChainID >= curView.ActiveShards {
+		return fmt.Errorf("Invalid shard %v of slash evidence", inst.ChainID)
+	}
+	shardID := byte(inst.ChainID)
+
+	committees, err := incognitokey.CommitteeKeyListToString(curView.GetAShardCommittee(shardID))
+	if err != nil {
+		return err
+	}
+	index := common.IndexOfStr(inst.PublicKey, committees)
+	if index != -1 && index < curView.NumberOfFixedShardBlockValidator {
+		return fmt.Errorf("Validator %v is a fixed validator of shard %v", inst.PublicKey, shardID)
+	}
+	if index == -1 && !curView.isShardSubstitute(inst.PublicKey) {
+		return fmt.Errorf("Validator %v is not in committee or substitute", inst.PublicKey)
+	}
+
+	headers := [2]*types.ShardHeader{}
+	for i, vote := range inst.Votes {
+		header := &types.ShardHeader{}
+		if err := json.Unmarshal(vote.BlockHeader, header); err != nil {
+			return err
+		}
+		if header.ShardID != shardID {
+			return fmt.Errorf("Vote for block of shard %v, expect shard %v", header.ShardID, shardID)
+		}
+		// propose hash of older blocks does not commit to the propose time
+		if header.Version < types.INSTANT_FINALITY_VERSION {
+			return fmt.Errorf("Vote for block version %v, expect version >= %v", header.Version, types.INSTANT_FINALITY_VERSION)
+		}
+		// evidence of offences in older epochs is expired
+		if header.Epoch+1 < curView.Epoch {
+			return fmt.Errorf("Vote for block of epoch %v, current epoch %v", header.Epoch, curView.Epoch)
+		}
+		signingCommittees, err := blockchain.getShardSigningCommitteesForSlashEvidence(curView, header)
+		if err != nil {
+			return err
+		}
+		if err := verifySlashEvidenceVote(header.ProposeHash(), vote.Signature, inst.PublicKeyStruct, signingCommittees); err != nil {
+			return err
+		}
+		headers[i] = header
+	}
+
+	proposeHash0, proposeHash1 := headers[0].ProposeHash(), headers[1].ProposeHash()
+	if proposeHash0.IsEqual(&proposeHash1) {
+		return errors.New("Votes of slash evidence are for the same block")
+	}
+	// an honest validator votes again at a height only for a block produced in an older timeslot,
+	// proposed in a newer timeslot or with another committee (see ConsensusValidatorLemma2)
+	if headers[0].Height != headers[1].Height {
+		return fmt.Errorf("Votes of slash evidence are for blocks of height %v and %v", headers[0].Height, headers[1].Height)
+	}
+	if !headers[0].CommitteeFromBlock.IsEqual(&headers[1].CommitteeFromBlock) {
+		return errors.New("Votes of slash evidence are for blocks of different committees")
+	}
+	proposeTimeSlot0 := curView.shardTimeSlot(shardID, headers[0].ProposeTime)
+	proposeTimeSlot1 := curView.shardTimeSlot(shardID, headers[1].ProposeTime)
+	if proposeTimeSlot0 != proposeTimeSlot1 {
+		return fmt.Errorf("Votes of slash evidence are for blocks proposed in timeslot %v and %v", proposeTimeSlot0, proposeTimeSlot1)
+	}
+	produceTimeSlot0 := curView.shardTimeSlot(shardID, headers[0].Timestamp)
+	produceTimeSlot1 := curView.shardTimeSlot(shardID, headers[1].Timestamp)
+	if produceTimeSlot0 != produceTimeSlot1 {
+		return fmt.Errorf("Votes of slash evidence are for blocks produced in timeslot %v and %v", produceTimeSlot0, produceTimeSlot1)
+	}
+	return nil
+}
+
+func (curView *BeaconBestState) isShardSubstitute(publicKey string) bool {
+	for _, substitutes := range curView.GetShardPendingValidator() {
+		substituteStrs, _ := incognitokey.CommitteeKeyListToString(substitutes)
+		if common.IndexOfStr(publicKey, substituteStrs) != -1 {
+			return true
+		}
+	}
+	return false
+}
+
+// shardTimeSlot returns the timeslot of shard shardID at time t
+func (curView *BeaconBestState) shardTimeSlot(shardID byte, t int64) int64 {
+	tsManager, ok := curView.ShardTSManager[shardID]
+	if !ok || tsManager == nil {
+		tsManager = new(TSManager)
+	}
+	return tsManager.calculateTimeslot(t)
+}
+
+// getShardSigningCommitteesForSlashEvidence returns the committee signing a shard block, as ShardBestState.getSigningCommittees
+func (blockchain *BlockChain) getShardSigningCommitteesForSlashEvidence(
+	curView *BeaconBestState, header *types.ShardHeader,
+) ([]incognitokey.CommitteePublicKey, error) {
+	committees, err := blockchain.getShardCommitteeForBlockProducing(header.CommitteeFromBlock, header.ShardID)
+	if err != nil {
+		return nil, NewBlockChainError(CommitteeFromBlockNotFoundError, err)
+	}
+	if len(committees) == 0 {
+		return nil, fmt.Errorf("No committee of shard %v from beacon block %v", header.ShardID, header.CommitteeFromBlock.String())
+	}
+	if header.Version >= types.BLOCK_PRODUCINGV3_VERSION && header.Version <= types.INSTANT_FINALITY_VERSION {
+		proposerLength := curView.GetShardProposerLength()
+		if proposerLength <= 0 || proposerLength > len(committees) {
+			return nil, fmt.Errorf("Invalid proposer length %v of %v committees", proposerLength, len(committees))
+		}
+		timeSlot := curView.shardTimeSlot(header.ShardID, header.ProposeTime)
+		_, proposerIndex := GetProposer(timeSlot, committees, proposerLength)
+		return FilterSigningCommitteeV3(committees, proposerIndex), nil
+	}
+	return committees, nil
+}
+
+// verifySlashEvidenceVote checks that signature is the BLS signature of validator, as a member of signingCommittees, on proposeHash
+func verifySlashEvidenceVote(
+	proposeHash common.Hash,
+	signature []byte,
+	validator incognitokey.CommitteePublicKey,
+	signingCommittees []incognitokey.CommitteePublicKey,
+) error {
+	validatorBLSKey := validator.GetMiningKeyBase58(common.BlsConsensus)
+	index := -1
+	committeeBLSKeys := []blsmultisig.PublicKey{}
+	for i, v := range signingCommittees {
+		if v.GetMiningKeyBase58(common.BlsConsensus) == validatorBLSKey {
+			index = i
+		}
+		committeeBLSKeys = append(committeeBLSKeys, v.MiningPubKey[common.BlsConsensus])
+	}
+	if index == -1 {
+		return fmt.Errorf("Validator %v is not in signing committee of block %v", validatorBLSKey, proposeHash.String())
+	}
+	ok, err := blsmultisig.Verify(signature, proposeHash.GetBytes(), []int{index}, committeeBLSKeys)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("Invalid signature of validator %v on block %v", validatorBLSKey, proposeHash.String())
+	}
+	return nil
+}
@@ -0,0 +1,315 @@
+package blockchain
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/incognitochain/incognito-chain/blockchain/committeestate"
+	"github.com/incognitochain/incognito-chain/blockchain/types"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/config"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
+	"github.com/incognitochain/incognito-chain/incdb"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/instruction"
+	"github.com/incognitochain/incognito-chain/trie"
+)
+
+// GetStateTrieNode returns a state trie node by hash from the database of chain cID
+// (common.BeaconChainSyncID for the beacon chain), to serve snap sync clients
+func (blockchain *BlockChain) GetStateTrieNode(cID byte, hash common.Hash) ([]byte, error) {
+	var db incdb.Database
+	if cID == common.BeaconChainSyncID {
+		db = blockchain.GetBeaconChainDatabase()
+	} else {
+		db = blockchain.GetShardChainDatabase(cID)
+	}
+	if db == nil {
+		return nil, fmt.Errorf("Cannot find database of chain %v", cID)
+	}
+	node, err := db.Get(hash[:])
+	if err != nil {
+		return nil, err
+	}
+	// the database also holds blocks, views & indexes by 32 bytes keys, only serve what hashes to its key
+	if !trie.IsTrieNode(hash, node) {
+		return nil, fmt.Errorf("Cannot find state trie node %v", hash.String())
+	}
+	return node, nil
+}
+
+// GetBeaconFinalView returns the final view of the beacon chain, to serve snap sync clients
+func (blockchain *BlockChain) GetBeaconFinalView() (*BeaconBestState, error) {
+	view, ok := blockchain.BeaconChain.multiView.GetFinalView().(*BeaconBestState)
+	if !ok || view == nil {
+		return nil, fmt.Errorf("Cannot get final view of beacon")
+	}
+	return view, nil
+}
+
+// GetShardFinalView returns the final view of a shard, to serve snap sync clients
+func (blockchain *BlockChain) GetShardFinalView(shardID byte) (*ShardBestState, error) {
+	if int(shardID) >= len(blockchain.ShardChain) {
+		return nil, fmt.Errorf("Invalid shard ID %v", shardID)
+	}
+	view, ok := blockchain.ShardChain[shardID].multiView.GetFinalView().(*ShardBestState)
+	if !ok || view == nil || view.BestBlock == nil {
+		return nil, fmt.Errorf("Cannot get final view of shard %v", shardID)
+	}
+	return view, nil
+}
+
+// ImportShardSnapshot sets a shard to a final view downloaded by snap sync, whose state tries are already
+// in the shard database: the view & its best block are stored as the only view of the shard,
+// then the shard views are restored from it. Blocks before the view are not in the database.
+// The best block of the view must be confirmed by the final beacon view, and the committee state of the view
+// must hash to the committee roots of its best block. The other state roots are committed by the ParentStateRootsHash
+// of child, the next block of the view, which must be signed by the committee of the view
+func (blockchain *BlockChain) ImportShardSnapshot(view *ShardBestState, child *types.ShardBlock) error {
+	shardID := view.ShardID
+	if int(shardID) >= len(blockchain.ShardChain) {
+		return NewBlockChainError(ShardStateError, fmt.Errorf("Invalid shard ID %v", shardID))
+	}
+	block := view.BestBlock
+	if block == nil || *block.Hash() != view.BestBlockHash ||
+		block.Header.ShardID != shardID || block.GetHeight() != view.ShardHeight ||
+		block.Header.BeaconHash != view.BestBeaconHash || block.Header.BeaconHeight != view.BeaconHeight {
+		return NewBlockChainError(ShardStateError, fmt.Errorf("Best block of snapshot does not match view %v", view.BestBlockHash.String()))
+	}
+	if child == nil || child.Header.ShardID != shardID || child.GetPrevHash() != view.BestBlockHash || child.GetHeight() != view.ShardHeight+1 {
+		return NewBlockChainError(ShardStateError, fmt.Errorf("Child block of snapshot does not follow view %v", view.BestBlockHash.String()))
+	}
+	confirmed, err := newBeaconAncestors(blockchain, blockchain.BeaconChain.GetFinalViewState()).confirmedShardState(shardID, view.ShardHeight)
+	if err != nil {
+		return err
+	}
+	if confirmed.Height != view.ShardHeight || confirmed.Hash != view.BestBlockHash {
+		return NewBlockChainError(ShardStateError, fmt.Errorf("Snapshot %v of shard %v at height %v is not confirmed by the final beacon view",
+			view.BestBlockHash.String(), shardID, view.ShardHeight))
+	}
+
+	db := blockchain.GetShardChainDatabase(shardID)
+	// every state root must be complete before the view is stored
+	if err := view.InitStateRootHash(db); err != nil {
+		return NewBlockChainError(ShardStateError, err)
+	}
+	if err := verifyStateRoots(db, view.ConsensusStateDBRootHash, view.TransactionStateDBRootHash,
+		view.FeatureStateDBRootHash, view.RewardStateDBRootHash, view.SlashStateDBRootHash); err != nil {
+		return NewBlockChainError(ShardStateError, err)
+	}
+	view.blockChain = blockchain
+	if err := restoreSnapshotCommitteeState(view); err != nil {
+		return NewBlockChainError(ShardStateError, err)
+	}
+	hashes, err := committeestate.HashShardCommitteeState(view.shardCommitteeState)
+	if err != nil {
+		return NewBlockChainError(ShardStateError, err)
+	}
+	if err := view.verifyPostProcessingShardBlock(block, shardID, hashes); err != nil {
+		return err
+	}
+	sRH := ShardRootHash{
+		ConsensusStateDBRootHash:   view.ConsensusStateDBRootHash,
+		TransactionStateDBRootHash: view.TransactionStateDBRootHash,
+		FeatureStateDBRootHash:     view.FeatureStateDBRootHash,
+		RewardStateDBRootHash:      view.RewardStateDBRootHash,
+		SlashStateDBRootHash:       view.SlashStateDBRootHash,
+	}
+	if err := verifySnapshotStateRoots(child.Header.ParentStateRootsHash, sRH.Hash(), view.BestBlockHash); err != nil {
+		return err
+	}
+	_, signingCommittee, err := view.getSigningCommittees(child, blockchain)
+	if err != nil {
+		return NewBlockChainError(ShardStateError, err)
+	}
+	if err := blockchain.ShardChain[shardID].ValidateBlockSignatures(child, signingCommittee); err != nil {
+		return NewBlockChainError(SignatureError, fmt.Errorf("Shard block %v: %v", child.Hash().String(), err))
+	}
+
+	batch := db.NewBatch()
+	if err := rawdbv2.StoreShardRootsHash(batch, shardID, view.BestBlockHash, sRH); err != nil {
+		return NewBlockChainError(StoreShardBlockError, err)
+	}
+	if err := rawdbv2.StoreShardBlock(batch, view.BestBlockHash, block); err != nil {
+		return NewBlockChainError(StoreShardBlockError, err)
+	}
+	if err := rawdbv2.StoreFinalizedShardBlockHashByIndex(batch, shardID, block.GetHeight(), view.BestBlockHash); err != nil {
+		return NewBlockChainError(StoreShardBlockError, err)
+	}
+	if err := rawdbv2.StoreShardBestState(batch, shardID, []*ShardBestState{view}); err != nil {
+		return NewBlockChainError(StoreShardBlockError, err)
+	}
+	if err := batch.Write(); err != nil {
+		return NewBlockChainError(StoreShardBlockError, err)
+	}
+	Logger.log.Infof("Import snapshot of shard %v at height %v, hash %v", shardID, view.ShardHeight, view.BestBlockHash.String())
+	return blockchain.RestoreShardViews(shardID)
+}
+
+// verifySnapshotStateRoots checks that the state roots of a snapshot, hashed to rootsHash, are the ones committed
+// by the ParentStateRootsHash of the child of its best block
+func verifySnapshotStateRoots(parentStateRootsHash, rootsHash, bestBlockHash common.Hash) error {
+	if parentStateRootsHash.IsZeroValue() {
+		return NewBlockChainError(StateRootsHashError, fmt.Errorf("State roots of block %v are not committed, feature %v is not triggered",
+			bestBlockHash.String(), STATE_ROOTS_FEATURE))
+	}
+	if parentStateRootsHash != rootsHash {
+		return NewBlockChainError(StateRootsHashError, fmt.Errorf("State roots of snapshot %v hash to %v but its child commits %v",
+			bestBlockHash.String(), rootsHash.String(), parentStateRootsHash.String()))
+	}
+	return nil
+}
+
+// restoreSnapshotCommitteeState restores the committee state of a shard view downloaded by snap sync like RestoreShardViews,
+// which panics on some invalid data
+func restoreSnapshotCommitteeState(view *ShardBestState) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+	version := committeestate.VersionByBeaconHeight(view.BeaconHeight,
+		config.Param().ConsensusParam.StakingFlowV2Height,
+		config.Param().ConsensusParam.StakingFlowV3Height,
+	)
+	view.shardCommitteeState = InitShardCommitteeState(version,
+		view.consensusStateDB,
+		view.ShardHeight, view.ShardID,
+		view.BestBlock, view.blockChain)
+	return view.tryUpgradeCommitteeState(view.blockChain)
+}
+
+// ImportBeaconSnapshot sets the beacon chain to a final view downloaded by snap sync, whose state tries are already
+// in the beacon database, like ImportShardSnapshot. checkpoint is the hash of a trusted beacon block, blocks are
+// the beacon blocks from the checkpoint to the best block of the view and child is the next block of the view.
+// The committee of each block is derived from the committee of the view by reverting the beacon swap instructions,
+// and must hash to the committee root of its header: starting from the trusted checkpoint, every block is signed
+// by the committee of its previous block, up to child which commits to the state roots of the view
+func (blockchain *BlockChain) ImportBeaconSnapshot(view *BeaconBestState, checkpoint common.Hash, blocks []*types.BeaconBlock, child *types.BeaconBlock) error {
+	block := &view.BestBlock
+	if *block.Hash() != view.BestBlockHash || block.GetHeight() != view.BeaconHeight {
+		return NewBlockChainError(BeaconError, fmt.Errorf("Best block of snapshot does not match view %v", view.BestBlockHash.String()))
+	}
+	if len(blocks) == 0 || *blocks[0].Hash() != checkpoint || *blocks[len(blocks)-1].Hash() != view.BestBlockHash {
+		return NewBlockChainError(BeaconError, fmt.Errorf("Blocks of snapshot %v do not start at checkpoint %v", view.BestBlockHash.String(), checkpoint.String()))
+	}
+	if child == nil || child.GetPrevHash() != view.BestBlockHash || child.GetHeight() != view.BeaconHeight+1 {
+		return NewBlockChainError(BeaconError, fmt.Errorf("Child block of snapshot does not follow view %v", view.BestBlockHash.String()))
+	}
+
+	db := blockchain.GetBeaconChainDatabase()
+	if err := view.InitStateRootHash(blockchain); err != nil {
+		return NewBlockChainError(BeaconError, err)
+	}
+	if err := verifyStateRoots(db, view.ConsensusStateDBRootHash, view.FeatureStateDBRootHash,
+		view.RewardStateDBRootHash, view.SlashStateDBRootHash); err != nil {
+		return NewBlockChainError(BeaconError, err)
+	}
+	if err := view.restoreCommitteeState(blockchain); err != nil {
+		return NewBlockChainError(BeaconError, err)
+	}
+	hashes, err := view.beaconCommitteeState.Hash(committeestate.NewCommitteeChange())
+	if err != nil {
+		return NewBlockChainError(BeaconError, err)
+	}
+	if err := view.verifyPostProcessingBeaconBlock(block, hashes); err != nil {
+		return err
+	}
+	committees, err := snapshotBeaconCommittees(view.GetBeaconCommittee(), blocks)
+	if err != nil {
+		return err
+	}
+	for i, blk := range blocks[1:] {
+		if blk.GetPrevHash() != *blocks[i].Hash() {
+			return NewBlockChainError(BeaconError, fmt.Errorf("Beacon block %v does not follow %v", blk.Hash().String(), blocks[i].Hash().String()))
+		}
+		if err := blockchain.BeaconChain.ValidateBlockSignatures(blk, committees[i]); err != nil {
+			return NewBlockChainError(SignatureError, fmt.Errorf("Beacon block %v: %v", blk.Hash().String(), err))
+		}
+	}
+	bRH := BeaconRootHash{
+		ConsensusStateDBRootHash: view.ConsensusStateDBRootHash,
+		FeatureStateDBRootHash:   view.FeatureStateDBRootHash,
+		RewardStateDBRootHash:    view.RewardStateDBRootHash,
+		SlashStateDBRootHash:     view.SlashStateDBRootHash,
+	}
+	if err := verifySnapshotStateRoots(child.Header.ParentStateRootsHash, bRH.Hash(), view.BestBlockHash); err != nil {
+		return err
+	}
+	if err := blockchain.BeaconChain.ValidateBlockSignatures(child, committees[len(committees)-1]); err != nil {
+		return NewBlockChainError(SignatureError, fmt.Errorf("Beacon block %v: %v", child.Hash().String(), err))
+	}
+
+	batch := db.NewBatch()
+	if err := rawdbv2.StoreBeaconRootsHash(batch, view.BestBlockHash, bRH); err != nil {
+		return NewBlockChainError(StoreBeaconBlockError, err)
+	}
+	if err := rawdbv2.StoreBeaconBlockByHash(batch, view.BestBlockHash, block); err != nil {
+		return NewBlockChainError(StoreBeaconBlockError, err)
+	}
+	if err := rawdbv2.StoreFinalizedBeaconBlockHashByIndex(batch, block.GetHeight(), view.BestBlockHash); err != nil {
+		return NewBlockChainError(StoreBeaconBlockError, err)
+	}
+	b, err := json.Marshal([]*BeaconBestState{view})
+	if err != nil {
+		return NewBlockChainError(BeaconError, err)
+	}
+	if err := rawdbv2.StoreBeaconViews(batch, b); err != nil {
+		return NewBlockChainError(StoreBeaconBlockError, err)
+	}
+	if err := batch.Write(); err != nil {
+		return NewBlockChainError(StoreBeaconBlockError, err)
+	}
+	Logger.log.Infof("Import snapshot of beacon at height %v, hash %v", view.BeaconHeight, view.BestBlockHash.String())
+	return blockchain.RestoreBeaconViews()
+}
+
+// snapshotBeaconCommittees returns the beacon committee of each block of blocks, the last one being committee.
+// Going back from the last block, the committee only changes where the committee root of the headers does,
+// by the beacon swap instructions of the block which replace the first keys of the committee by their in keys.
+// Every committee must hash to the root of its block, including the first one, which must commit to its committee
+func snapshotBeaconCommittees(committee []incognitokey.CommitteePublicKey, blocks []*types.BeaconBlock) ([][]incognitokey.CommitteePublicKey, error) {
+	keys, err := incognitokey.CommitteeKeyListToString(committee)
+	if err != nil {
+		return nil, NewBlockChainError(BeaconError, err)
+	}
+	if blocks[0].Header.BeaconCommitteeAndValidatorRoot.IsZeroValue() {
+		return nil, NewBlockChainError(BeaconCommitteeAndPendingValidatorRootError, fmt.Errorf("Checkpoint %v does not commit to its committee",
+			blocks[0].Hash().String()))
+	}
+	committees := make([][]incognitokey.CommitteePublicKey, len(blocks))
+	for i := len(blocks) - 1; i >= 0; i-- {
+		header := blocks[i].Header
+		if i < len(blocks)-1 && header.BeaconCommitteeAndValidatorRoot != blocks[i+1].Header.BeaconCommitteeAndValidatorRoot {
+			keys = revertBeaconSwaps(keys, blocks[i+1].Body.Instructions)
+		}
+		root, err := common.GenerateHashFromStringArray(keys)
+		if err != nil {
+			return nil, NewBlockChainError(GenerateBeaconCommitteeAndValidatorRootError, err)
+		}
+		if root != header.BeaconCommitteeAndValidatorRoot {
+			return nil, NewBlockChainError(BeaconCommitteeAndPendingValidatorRootError, fmt.Errorf("Beacon committee of block %v hashes to %v, expect %v",
+				blocks[i].Hash().String(), root.String(), header.BeaconCommitteeAndValidatorRoot.String()))
+		}
+		if committees[i], err = incognitokey.CommitteeBase58KeyListToStruct(keys); err != nil {
+			return nil, NewBlockChainError(BeaconError, err)
+		}
+	}
+	return committees, nil
+}
+
+// revertBeaconSwaps returns the beacon committee keys before the beacon swap instructions of a block
+func revertBeaconSwaps(keys []string, instructions [][]string) []string {
+	for i := len(instructions) - 1; i >= 0; i-- {
+		inst := instructions[i]
+		if len(inst) == 0 || inst[0] != instruction.SWAP_ACTION {
+			continue
+		}
+		swap, err := instruction.ValidateAndImportSwapInstructionFromString(inst)
+		if err != nil || swap.ChainID != instruction.BEACON_CHAIN_ID || len(swap.InPublicKeys) > len(keys) {
+			continue
+		}
+		keys = append(append([]string{}, swap.OutPublicKeys...), keys[len(swap.InPublicKeys):]...)
+	}
+	return keys
+}
@@ -0,0 +1,147 @@
+package blockchain
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/blockchain/types"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/instruction"
+)
+
+func TestGetStateTrieNode(t *testing.T) {
+	bc, db, closeDB := newPreloadTestBlockChain(t)
+	defer closeDB()
+
+	stateDB, err := statedb.NewWithPrefixTrie(common.EmptyRoot, statedb.NewDatabaseAccessWarper(db))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := statedb.StoreBeaconCommittee(stateDB, incognitoKeys); err != nil {
+		t.Fatal(err)
+	}
+	root, err := stateDB.Commit(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := stateDB.Database().TrieDB().Commit(root, false); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := bc.GetStateTrieNode(common.BeaconChainSyncID, root); err != nil {
+		t.Fatalf("Expect the root node to be served, got %v", err)
+	}
+
+	// other data stored by a 32 bytes hash is not served
+	block := newPreloadTestBeaconBlock(10, common.Hash{1})
+	if err := rawdbv2.StoreBeaconBlockByHash(db, *block.Hash(), block); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Put(common.Hash{2}.Bytes(), []byte("value")); err != nil {
+		t.Fatal(err)
+	}
+	for _, hash := range []common.Hash{*block.Hash(), {2}, {3}} {
+		if _, err := bc.GetStateTrieNode(common.BeaconChainSyncID, hash); err == nil {
+			t.Fatalf("Expect key %v to be rejected", hash.String())
+		}
+	}
+}
+
+func TestImportBeaconSnapshotCheckpoint(t *testing.T) {
+	bc, _, closeDB := newPreloadTestBlockChain(t)
+	defer closeDB()
+
+	checkpoint := newPreloadTestBeaconBlock(5, common.Hash{1})
+	best := newPreloadTestBeaconBlock(6, *checkpoint.Hash())
+	child := newPreloadTestBeaconBlock(7, *best.Hash())
+	view := &BeaconBestState{BestBlock: *best, BestBlockHash: *best.Hash(), BeaconHeight: 6}
+
+	tests := []struct {
+		name   string
+		view   *BeaconBestState
+		blocks []*types.BeaconBlock
+		child  *types.BeaconBlock
+	}{
+		{"view not matching its block", &BeaconBestState{BestBlock: *best, BestBlockHash: common.Hash{2}, BeaconHeight: 6}, []*types.BeaconBlock{checkpoint, best}, child},
+		{"no block", view, nil, child},
+		{"not starting at the checkpoint", view, []*types.BeaconBlock{best}, child},
+		{"not ending at the view", view, []*types.BeaconBlock{checkpoint}, child},
+		{"no child", view, []*types.BeaconBlock{checkpoint, best}, nil},
+		{"child not following the view", view, []*types.BeaconBlock{checkpoint, best}, newPreloadTestBeaconBlock(7, common.Hash{2})},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := bc.ImportBeaconSnapshot(tt.view, *checkpoint.Hash(), tt.blocks, tt.child); err == nil {
+				t.Fatal("Expect the snapshot to be rejected")
+			}
+		})
+	}
+}
+
+func TestVerifySnapshotStateRoots(t *testing.T) {
+	rootsHash := BeaconRootHash{ConsensusStateDBRootHash: common.Hash{1}}.Hash()
+	if err := verifySnapshotStateRoots(rootsHash, rootsHash, common.Hash{}); err != nil {
+		t.Fatal(err)
+	}
+	for _, parentStateRootsHash := range []common.Hash{{}, {2}} {
+		if err := verifySnapshotStateRoots(parentStateRootsHash, rootsHash, common.Hash{}); err == nil {
+			t.Fatalf("Expect roots committed as %v to be rejected", parentStateRootsHash.String())
+		}
+	}
+}
+
+func TestSnapshotBeaconCommittees(t *testing.T) {
+	before := keys[0:4]
+	after := append([]string{keys[4]}, keys[1:4]...)
+	swap := instruction.NewSwapInstructionWithValue(keys[4:5], keys[0:1], instruction.BEACON_CHAIN_ID).ToString()
+	newBlocks := func(roots []common.Hash, swapAt int) []*types.BeaconBlock {
+		blocks := []*types.BeaconBlock{}
+		prevHash := common.Hash{1}
+		for i, root := range roots {
+			block := newPreloadTestBeaconBlock(uint64(5+i), prevHash)
+			block.Header.BeaconCommitteeAndValidatorRoot = root
+			if i == swapAt {
+				block.Body.Instructions = [][]string{swap}
+			}
+			blocks = append(blocks, block)
+			prevHash = *block.Hash()
+		}
+		return blocks
+	}
+	beforeRoot, _ := common.GenerateHashFromStringArray(before)
+	afterRoot, _ := common.GenerateHashFromStringArray(after)
+	afterKeys, _ := incognitokey.CommitteeBase58KeyListToStruct(after)
+
+	committees, err := snapshotBeaconCommittees(afterKeys, newBlocks([]common.Hash{beforeRoot, beforeRoot, afterRoot, afterRoot}, 2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, expect := range [][]string{before, before, after, after} {
+		got, _ := incognitokey.CommitteeKeyListToString(committees[i])
+		if !reflect.DeepEqual(got, expect) {
+			t.Fatalf("Committee of block %v: expect %v, got %v", i, expect, got)
+		}
+	}
+
+	tests := []struct {
+		name      string
+		committee []string
+		roots     []common.Hash
+		swapAt    int
+	}{
+		{"committee not matching the view", before, []common.Hash{beforeRoot, afterRoot}, 1},
+		{"no swap where the root changes", after, []common.Hash{beforeRoot, afterRoot}, -1},
+		{"swap in another block", after, []common.Hash{beforeRoot, beforeRoot, afterRoot}, 1},
+		{"checkpoint not committing to its committee", after, []common.Hash{{}, afterRoot}, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			committee, _ := incognitokey.CommitteeBase58KeyListToStruct(tt.committee)
+			if _, err := snapshotBeaconCommittees(committee, newBlocks(tt.roots, tt.swapAt)); err == nil {
+				t.Fatal("Expect the committees to be rejected")
+			}
+		})
+	}
+}
@@ -69,6 +69,9 @@ type BeaconHeader struct {
 
 	//for version 8, instant finality
 	ProcessBridgeFromBlock *uint64 `json:"integer,omitempty"`
+
+	//after feature stateroots, hash of the state roots of the previous block
+	ParentStateRootsHash common.Hash `json:"ParentStateRootsHash"`
 }
 
 func NewBeaconHeader(version int, height uint64, epoch uint64, round int, timestamp int64, previousBlockHash common.Hash, consensusType string, producer string, producerPubKeyStr string) BeaconHeader {
@@ -341,6 +344,11 @@ func (header *BeaconHeader) toString() string {
 		}
 	}
 
+	//empty before feature stateroots, keep the hash of older blocks
+	if !header.ParentStateRootsHash.IsZeroValue() {
+		res += header.ParentStateRootsHash.String()
+	}
+
 	return res
 }
 
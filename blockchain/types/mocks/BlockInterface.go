@@ -12,19 +12,48 @@ type BlockInterface struct {
 	mock.Mock
 }
 
+// GetBeaconHeight provides a mock function with given fields:
 func (_m *BlockInterface) GetBeaconHeight() uint64 {
-	//TODO implement me
-	panic("implement me")
+	ret := _m.Called()
+
+	var r0 uint64
+	if rf, ok := ret.Get(0).(func() uint64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	return r0
 }
 
+// ProposeHash provides a mock function with given fields:
 func (_m *BlockInterface) ProposeHash() *common.Hash {
-	//TODO implement me
-	panic("implement me")
+	ret := _m.Called()
+
+	var r0 *common.Hash
+	if rf, ok := ret.Get(0).(func() *common.Hash); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*common.Hash)
+		}
+	}
+
+	return r0
 }
 
+// FullHashString provides a mock function with given fields:
 func (_m *BlockInterface) FullHashString() string {
-	//TODO implement me
-	panic("implement me")
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
 }
 
 // BodyHash provides a mock function with given fields:
@@ -52,6 +52,9 @@ type ShardHeader struct {
 
 	//for version 6
 	FinalityHeight uint64 `json:"FinalityHeight"`
+
+	//after feature stateroots, hash of the state roots of the previous block
+	ParentStateRootsHash common.Hash `json:"ParentStateRootsHash"`
 }
 
 type ShardBody struct {
@@ -375,6 +378,11 @@ func (shardHeader *ShardHeader) String() string {
 			res += fmt.Sprintf("%v", shardHeader.FinalityHeight)
 		}
 	}
+
+	//empty before feature stateroots, keep the hash of older blocks
+	if !shardHeader.ParentStateRootsHash.IsZeroValue() {
+		res += shardHeader.ParentStateRootsHash.String()
+	}
 	return res
 }
 
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/blockchain/chainexport"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/config"
+	testsuite "github.com/incognitochain/incognito-chain/testsuite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestExportImportChain exports the chains of a simulated node, which include a cross
+// shard transfer, and imports them into an offline chain built as the import command does.
+func TestExportImportChain(t *testing.T) {
+	dir := t.TempDir()
+	node := testsuite.InitChainParam(testsuite.Config{
+		DataDir: filepath.Join(dir, "source"),
+		Network: testsuite.ID_TESTNET2,
+		ResetDB: true,
+	}, func() {
+		config.Param().ActiveShards = 2
+		// the simulated node only feeds the block producer from the v1 mempool
+		config.Param().TxPoolVersion = 0
+		config.Param().TransactionInBlockParam = config.TxsPerBlock{Lower: 100, Upper: 1000}
+		config.Param().BCHeightBreakPointNewZKP = 1
+		config.Param().BCHeightBreakPointPrivacyV2 = 1e9
+		config.Param().BeaconHeightBreakPointBurnAddr = 1
+		// cross shard blocks cannot be verified against the self swap committee state
+		config.Param().ConsensusParam.StakingFlowV2Height = 5
+		config.Param().PDexParams.Pdexv3BreakPointHeight = 1e9
+		config.Param().BlockTimeParam = map[string]int64{blockchain.BLOCKTIME_DEFAULT: int64(config.Param().ConsensusParam.Timeslot)}
+		config.Param().EpochParam.NumberOfBlockInEpoch = 20
+		config.Param().EpochParam.RandomTime = 10
+		config.Config().LimitFee = 0
+	}, func(*testsuite.NodeEngine) {})
+
+	for i := 0; i < 5; i++ {
+		node.GenerateBlock().NextRound()
+	}
+	sender := node.GenesisAccount
+	receiver := node.NewAccountFromShard(1 - sender.ShardID(2))
+	_, err := node.RPC.API_SubmitKey(receiver.PrivateKey)
+	require.NoError(t, err)
+	_, err = node.RPC.API_SendTxPRV(sender.PrivateKey, map[string]uint64{receiver.PaymentAddress: 1e14}, -1, true)
+	require.NoError(t, err)
+	for i := 0; i < 10; i++ {
+		node.GenerateBlock().NextRound()
+	}
+	balance, err := node.RPC.API_GetBalance(receiver)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1e14), balance["PRV"])
+	source := node.GetBlockchain()
+
+	bc, err := makeBlockChain(filepath.Join(dir, "target"))
+	require.NoError(t, err)
+	crossShardBlocks := 0
+	for _, chainID := range []int{common.BeaconChainID, 0, 1} {
+		buf := &bytes.Buffer{}
+		writer, err := chainexport.NewWriter(buf, chainID, 1)
+		require.NoError(t, err)
+		require.NoError(t, source.ExportChain(writer, 0, nil))
+		require.NoError(t, writer.Close())
+
+		reader, err := chainexport.NewReader(buf)
+		require.NoError(t, err)
+		inserted, err := bc.ImportChain(reader, nil)
+		require.NoError(t, err, "chain %v", chainID)
+		// the genesis block is already in the target database
+		assert.Equal(t, writer.NextHeight()-2, inserted, "chain %v", chainID)
+
+		want := source.GetChain(chainID).(testsuite.Chain)
+		got := bc.GetChain(chainID).(testsuite.Chain)
+		assert.Equal(t, want.GetFinalViewHeight(), got.GetBestViewHeight(), "chain %v", chainID)
+		assert.Equal(t, want.GetFinalViewHash(), got.GetBestViewHash(), "chain %v", chainID)
+		if chainID != common.BeaconChainID {
+			for height := uint64(2); height <= got.GetBestViewHeight(); height++ {
+				block, err := bc.GetShardBlockByHeightV1(height, byte(chainID))
+				require.NoError(t, err)
+				crossShardBlocks += len(block.Body.CrossTransactions)
+			}
+		}
+	}
+	assert.NotZero(t, crossShardBlocks, "no imported shard block includes a cross shard transfer")
+}
@@ -6,8 +6,8 @@ import (
 	"path/filepath"
 
 	"github.com/0xsirrush/color"
-	"github.com/incognitochain/incognito-chain/blockchain"
 	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/config"
 	"github.com/jessevdk/go-flags"
 )
 
@@ -39,6 +39,9 @@ type params struct {
 	ChainDataDir string `long:"chaindatadir" description:"Directory of Stored Blockchain Database"`
 	OutDataDir   string `long:"outdatadir" description:"Directory of Export Blockchain Data"`
 	FileName     string `long:"filename" description:"Filename of Backup Blockchin Data"`
+	FromHeight   uint64 `long:"fromheight" description:"First block height of Backup Blockchain Data, default is 1"`
+	ToHeight     uint64 `long:"toheight" description:"Last block height of Backup Blockchain Data, default is the final block"`
+	Resume       bool   `long:"resume" description:"Append blocks to an existing Backup Blockchain Data file"`
 	// wallet
 	WalletName        string `long:"wallet" description:"Wallet Database Name file, default is 'wallet'"`
 	WalletPassphrase  string `long:"walletpassphrase" description:"Wallet passphrase"`
@@ -48,6 +51,10 @@ type params struct {
 	// pToken
 	PNetwork string `long:"pNetwork" description:"Bridge network"`
 	PToken   string `long:"pToken" description:"Bridge token"`
+
+	// offline signer
+	PrivateKey string `long:"privatekey" description:"Private key of the sender, in base58check"`
+	UnsignedTx string `long:"unsignedtx" description:"File of the unsigned transaction"`
 }
 
 // newConfigParser returns a new command line flags parser.
@@ -75,11 +82,16 @@ func loadParams() (*params, error) {
 		}
 	}
 	cfg.DataDir = common.CleanAndExpandPath(cfg.DataDir, defaultHomeDir)
+	// the chain params are the ones of the network of the node, see config.LoadParam
+	network := config.MainnetNetwork
 	if cfg.TestNet {
-		cfg.DataDir = filepath.Join(cfg.DataDir, blockchain.ChainTestParam.Name)
-	} else {
-		cfg.DataDir = filepath.Join(cfg.DataDir, blockchain.ChainMainParam.Name)
+		network = config.TestNetNetwork
+	}
+	if err := os.Setenv(config.NetworkKey, network); err != nil {
+		return nil, err
 	}
+	config.LoadParam()
+	cfg.DataDir = filepath.Join(cfg.DataDir, config.Param().Name)
 
 	return &cfg, nil
 }
@@ -8,6 +8,7 @@ const (
 	getPrivacyTokenID      = "getprivacytokenid"
 	backupChain            = "backupchain"
 	restoreChain           = "restorechain"
+	signTx                 = "signtx"
 )
 
 var CmdList = []string{
@@ -18,4 +19,5 @@ var CmdList = []string{
 	getPrivacyTokenID,
 	backupChain,
 	restoreChain,
+	signTx,
 }
@@ -4,11 +4,12 @@ import (
 	"encoding/json"
 	"github.com/incognitochain/incognito-chain/privacy"
 	"log"
+	"os"
 	"strconv"
 	"strings"
 
-	"github.com/incognitochain/incognito-chain/blockchain"
 	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/config"
 )
 
 func parseToJsonString(data interface{}) ([]byte, error) {
@@ -130,28 +131,20 @@ func processCmd() {
 				log.Println("No Expected Params")
 				return
 			}
-			bc, err := makeBlockChain(cfg.ChainDataDir, cfg.TestNet)
+			bc, err := makeBlockChain(cfg.ChainDataDir)
 			if err != nil {
 				log.Println("Error create blockchain variable ", err)
 				return
 			}
+			var chainIDs = []int{}
 			if cfg.Beacon {
-				err := backupBeaconChain(bc, cfg.OutDataDir, cfg.FileName)
-				if err != nil {
-					log.Printf("Beacon Beackup failed, err %+v", err)
-				}
+				chainIDs = append(chainIDs, common.BeaconChainID)
 			}
 			var shardIDs = []byte{}
 			if cfg.ShardIDs != "" {
 				// all shard
 				if cfg.ShardIDs == "all" {
-					var numberOfShards int
-					if cfg.TestNet {
-						numberOfShards = blockchain.ChainTestParam.ActiveShards
-					} else {
-						numberOfShards = blockchain.ChainMainParam.ActiveShards
-					}
-					for i := 0; i < numberOfShards; i++ {
+					for i := 0; i < config.Param().ActiveShards; i++ {
 						shardIDs = append(shardIDs, byte(i))
 					}
 				} else {
@@ -178,12 +171,18 @@ func processCmd() {
 						shardIDs = append(shardIDs, shardID)
 					}
 				}
-				//backup shard
-				for _, shardID := range shardIDs {
-					err := backupShardChain(bc, shardID, cfg.OutDataDir, cfg.FileName)
-					if err != nil {
-						log.Printf("Shard %+v back up failed, err %+v", shardID, err)
-					}
+			}
+			for _, shardID := range shardIDs {
+				chainIDs = append(chainIDs, int(shardID))
+			}
+			if cfg.FileName != "" && len(chainIDs) > 1 {
+				log.Println("Filename can only be set when backup one chain")
+				return
+			}
+			for _, chainID := range chainIDs {
+				err := exportChain(bc, chainID, cfg.OutDataDir, cfg.FileName, cfg.FromHeight, cfg.ToHeight, cfg.Resume)
+				if err != nil {
+					log.Printf("Chain %+v back up failed, err %+v", chainID, err)
 				}
 			}
 		}
@@ -193,25 +192,33 @@ func processCmd() {
 				log.Println("No Backup File to Process")
 				return
 			}
-			bc, err := makeBlockChain(cfg.ChainDataDir, cfg.TestNet)
+			bc, err := makeBlockChain(cfg.ChainDataDir)
 			if err != nil {
 				log.Println("Error create blockchain variable ", err)
 				return
 			}
-			if cfg.Beacon {
-				err := restoreBeaconChain(bc, cfg.FileName)
+			// files are restored in order, the beacon chain must be restored before the shard chains
+			filenames := strings.Split(cfg.FileName, ",")
+			for _, filename := range filenames {
+				err := importChain(bc, filename)
 				if err != nil {
-					log.Printf("Beacon Restore failed, err %+v", err)
-				}
-			} else {
-				filenames := strings.Split(cfg.FileName, ",")
-				for _, filename := range filenames {
-					err := RestoreShardChain(bc, filename)
-					if err != nil {
-						log.Printf("File %+v back up failed, err %+v", filename, err)
-					}
+					log.Printf("File %+v restore failed, err %+v", filename, err)
+					return
 				}
 			}
 		}
+	case signTx:
+		{
+			if cfg.PrivateKey == "" || cfg.UnsignedTx == "" {
+				log.Println("Wrong param")
+				return
+			}
+			result, err := signUnsignedTx(cfg.UnsignedTx, cfg.PrivateKey, os.Stdin, os.Stdout)
+			if err != nil {
+				log.Println(err)
+				return
+			}
+			log.Println(string(result))
+		}
 	}
 }
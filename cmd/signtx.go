@@ -0,0 +1,123 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"github.com/incognitochain/incognito-chain/common/base58"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/privacy"
+	"github.com/incognitochain/incognito-chain/transaction"
+	"github.com/incognitochain/incognito-chain/wallet"
+)
+
+var errSigningNotConfirmed = errors.New("signing is not confirmed")
+
+type signedTx struct {
+	TxID            string
+	Base58CheckData string
+}
+
+// signUnsignedTx signs the unsigned transaction in filename, returned by createunsignedtransaction or
+// createunsignedprivacycustomtokentransaction. It needs no connection to a node,
+// the signed data is sent with sendtransaction or sendrawprivacycustomtokentransaction.
+// The receivers, the amounts, the fee and the metadata are written to out and the transaction is only
+// signed once the answer read from in confirms them.
+func signUnsignedTx(filename string, privateKeyStr string, in io.Reader, out io.Writer) ([]byte, error) {
+	keyWallet, err := wallet.Base58CheckDeserialize(privateKeyStr)
+	if err != nil {
+		return nil, err
+	}
+	if len(keyWallet.KeySet.PrivateKey) == 0 {
+		return nil, errors.New("private key is not valid")
+	}
+	var senderKeySet incognitokey.KeySet
+	if err := senderKeySet.InitFromPrivateKey(&keyWallet.KeySet.PrivateKey); err != nil {
+		return nil, err
+	}
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	// accept the whole result of the RPC as well as the unsigned transaction only
+	rpcResult := struct {
+		UnsignedTx json.RawMessage
+	}{}
+	if err := json.Unmarshal(data, &rpcResult); err == nil && len(rpcResult.UnsignedTx) > 0 {
+		data = rpcResult.UnsignedTx
+	}
+	unsignedTx := new(transaction.UnsignedTx)
+	if err := json.Unmarshal(data, unsignedTx); err != nil {
+		return nil, err
+	}
+
+	description, err := describeUnsignedTx(unsignedTx, senderKeySet.PaymentAddress)
+	if err != nil {
+		return nil, err
+	}
+	fmt.Fprint(out, description)
+	fmt.Fprint(out, "Sign this transaction? [y/N]: ")
+	answer, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	if answer = strings.ToLower(strings.TrimSpace(answer)); answer != "y" && answer != "yes" {
+		return nil, errSigningNotConfirmed
+	}
+
+	tx, err := unsignedTx.Sign(&keyWallet.KeySet.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+	txBytes, err := json.Marshal(tx)
+	if err != nil {
+		return nil, err
+	}
+	return parseToJsonString(signedTx{
+		TxID:            tx.Hash().String(),
+		Base58CheckData: base58.Base58Check{}.Encode(txBytes, 0x00),
+	})
+}
+
+// describeUnsignedTx lists what the transaction sends, the outputs back to the sender are its change
+func describeUnsignedTx(unsignedTx *transaction.UnsignedTx, sender privacy.PaymentAddress) (string, error) {
+	if unsignedTx.PRV == nil {
+		return "", errors.New("unsigned transaction does not pay the fee")
+	}
+	description := &strings.Builder{}
+	fmt.Fprintf(description, "Transaction type: %v\n", unsignedTx.Type)
+	fmt.Fprintf(description, "Fee: %v nano PRV\n", unsignedTx.Fee)
+	fmt.Fprintln(description, "PRV receivers (nano PRV):")
+	describePaymentInfo(description, unsignedTx.PRV.PaymentInfo, sender)
+	if unsignedTx.Token != nil {
+		fmt.Fprintf(description, "Token %v receivers:\n", unsignedTx.Token.PropertyID.String())
+		describePaymentInfo(description, unsignedTx.Token.PaymentInfo, sender)
+	}
+	if unsignedTx.Metadata == nil {
+		fmt.Fprintln(description, "Metadata: none")
+	} else {
+		metaBytes, err := json.Marshal(unsignedTx.Metadata)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(description, "Metadata (type %v): %s\n", unsignedTx.Metadata.GetType(), metaBytes)
+	}
+	return description.String(), nil
+}
+
+func describePaymentInfo(description io.Writer, paymentInfo []*privacy.PaymentInfo, sender privacy.PaymentAddress) {
+	for _, info := range paymentInfo {
+		if bytes.Equal(info.PaymentAddress.Pk, sender.Pk) {
+			fmt.Fprintf(description, "  change to the sender: %v\n", info.Amount)
+			continue
+		}
+		receiver := wallet.KeyWallet{KeySet: incognitokey.KeySet{PaymentAddress: info.PaymentAddress}}
+		fmt.Fprintf(description, "  %v: %v\n", receiver.Base58CheckSerialize(wallet.PaymentAddressType), info.Amount)
+	}
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/privacy"
+	"github.com/incognitochain/incognito-chain/transaction"
+	"github.com/incognitochain/incognito-chain/wallet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignUnsignedTxConfirmation(t *testing.T) {
+	sender, err := wallet.NewMasterKey([]byte("signtx test sender"))
+	require.NoError(t, err)
+	receiver, err := wallet.NewMasterKey([]byte("signtx test receiver"))
+	require.NoError(t, err)
+	receiverAddress := receiver.Base58CheckSerialize(wallet.PaymentAddressType)
+
+	// the ring is left empty : signing fails after the confirmation
+	unsignedTx := transaction.UnsignedTx{
+		Version: 2,
+		Type:    common.TxNormalType,
+		Fee:     100,
+		PRV: &transaction.UnsignedTransfer{
+			PaymentInfo: []*privacy.PaymentInfo{
+				{PaymentAddress: receiver.KeySet.PaymentAddress, Amount: 1000},
+				{PaymentAddress: sender.KeySet.PaymentAddress, Amount: 500},
+			},
+		},
+	}
+	data, err := json.Marshal(unsignedTx)
+	require.NoError(t, err)
+	dir, err := ioutil.TempDir("", "signtx")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	filename := filepath.Join(dir, "unsigned.json")
+	require.NoError(t, ioutil.WriteFile(filename, data, 0600))
+
+	tests := []struct {
+		name      string
+		answer    string
+		confirmed bool
+	}{
+		{name: "refused", answer: "n\n"},
+		{name: "no answer", answer: ""},
+		{name: "confirmed", answer: "y\n", confirmed: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			_, err := signUnsignedTx(filename, sender.Base58CheckSerialize(wallet.PriKeyType), strings.NewReader(tt.answer), out)
+			assert.Contains(t, out.String(), "Fee: 100 nano PRV")
+			assert.Contains(t, out.String(), receiverAddress+": 1000")
+			assert.Contains(t, out.String(), "change to the sender: 500")
+			assert.Contains(t, out.String(), "Metadata: none")
+			require.Error(t, err)
+			if tt.confirmed {
+				assert.NotEqual(t, errSigningNotConfirmed, err)
+			} else {
+				assert.Equal(t, errSigningNotConfirmed, err)
+			}
+		})
+	}
+}
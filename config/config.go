@@ -29,12 +29,13 @@ func AbortConfig() {
 
 type config struct {
 	//Basic config
-	DataDir     string `mapstructure:"data_dir" short:"d" long:"datadir" description:"Directory to store data"`
-	DatabaseDir string `mapstructure:"database_dir" long:"datapre" description:"Database dir"`
-	MempoolDir  string `mapstructure:"mempool_dir" short:"m" long:"mempooldir" description:"Mempool Directory"`
-	LogDir      string `mapstructure:"log_dir" short:"l" long:"logdir" description:"Directory to log output."`
-	LogLevel    string `mapstructure:"log_level" long:"loglevel" description:"Logging level for all subsystems {trace, debug, info, warn, error, critical} -- You may also specify <subsystem>=<level>,<subsystem2>=<level>,... to set the log level for individual subsystems -- Use show to list available subsystems"`
-	LogFileName string `mapstructure:"log_file_name" long:"logfilename" description:"log file name"`
+	DataDir      string `mapstructure:"data_dir" short:"d" long:"datadir" description:"Directory to store data"`
+	DatabaseDir  string `mapstructure:"database_dir" long:"datapre" description:"Database dir"`
+	DatabaseType string `mapstructure:"database_type" long:"dbtype" description:"Database driver used to store chain data {leveldb, badgerdb}"`
+	MempoolDir   string `mapstructure:"mempool_dir" short:"m" long:"mempooldir" description:"Mempool Directory"`
+	LogDir       string `mapstructure:"log_dir" short:"l" long:"logdir" description:"Directory to log output."`
+	LogLevel     string `mapstructure:"log_level" long:"loglevel" description:"Logging level for all subsystems {trace, debug, info, warn, error, critical} -- You may also specify <subsystem>=<level>,<subsystem2>=<level>,... to set the log level for individual subsystems -- Use show to list available subsystems"`
+	LogFileName  string `mapstructure:"log_file_name" long:"logfilename" description:"log file name"`
 
 	//Peer Config
 	AddPeers             []string `mapstructure:"add_peers" short:"a" long:"addpeer" description:"Add a peer to connect with at startup"`
@@ -71,6 +72,11 @@ type config struct {
 	DisableRPC                  bool     `mapstructure:"disable_rpc" long:"norpc" description:"Disable built-in RPC server -- NOTE: The RPC server is disabled by default if no rpcuser/rpcpass or rpclimituser/rpclimitpass is specified"`
 	DisableTLS                  bool     `mapstructure:"disable_tls" long:"notls" description:"Disable TLS for the RPC server -- NOTE: This is only allowed if the RPC server is bound to localhost"`
 	Proxy                       string   `mapstructure:"proxy" long:"proxy" description:"Connect via SOCKS5 proxy (eg. 127.0.0.1:9050)"`
+	MetricsListener             string   `mapstructure:"metrics_listener" long:"metricslisten" description:"Add an interface/port to serve Prometheus metrics on /metrics, empty to disable"`
+	TraceFile                   string   `mapstructure:"trace_file" long:"tracefile" description:"File to append the spans of the block lifecycle to, one JSON object per line, empty to disable"`
+	TraceOTLPEndpoint           string   `mapstructure:"trace_otlp_endpoint" long:"traceotlpendpoint" description:"OTLP/HTTP traces endpoint to export the spans of the block lifecycle to (eg. http://localhost:4318/v1/traces), empty to disable"`
+	// API keys can only be set in the config file
+	RPCAPIKeys []RPCAPIKey `mapstructure:"rpc_api_keys" description:"Named API keys allowed to call a list of RPC methods, with their own request limits"`
 
 	//Network Config
 	IsLocal        bool `description:"Use the local network"`
@@ -90,9 +96,10 @@ type config struct {
 	FastStartup bool `mapstructure:"fast_start_up" long:"faststartup" description:"Load existed shard/chain dependencies instead of rebuild from block data"`
 
 	//Txpool config
-	TxPoolTTL   uint   `mapstructure:"tx_pool_ttl" long:"txpoolttl" description:"Set Time To Live (TTL) Value for transaction that enter pool"`
-	TxPoolMaxTx uint64 `mapstructure:"tx_pool_max_tx" long:"txpoolmaxtx" description:"Set Maximum number of transaction in pool"`
-	LimitFee    uint64 `mapstructure:"limit_fee" long:"limitfee" description:"Limited fee for tx(per Kb data), default is 0.00 PRV"`
+	TxPoolTTL              uint   `mapstructure:"tx_pool_ttl" long:"txpoolttl" description:"Set Time To Live (TTL) Value for transaction that enter pool"`
+	TxPoolMaxTx            uint64 `mapstructure:"tx_pool_max_tx" long:"txpoolmaxtx" description:"Set Maximum number of transaction in pool"`
+	TxPoolSnapshotInterval uint   `mapstructure:"tx_pool_snapshot_interval" long:"txpoolsnapshotinterval" description:"Interval in seconds to save the transactions in pool into mempool database, used with persistmempool"`
+	LimitFee               uint64 `mapstructure:"limit_fee" long:"limitfee" description:"Limited fee for tx(per Kb data), default is 0.00 PRV"`
 
 	//Mempool config
 	IsLoadFromMempool bool `mapstructure:"is_load_from_mem_pool" long:"loadmempool" description:"Load transactions from Mempool database"`
@@ -108,20 +115,39 @@ type config struct {
 	Libp2pPrivateKey string `mapstructure:"p2p_private_key" long:"libp2pprivatekey" description:"Private key used to create node's PeerID, empty to generate random key each run"`
 
 	//backup
-	PreloadAddress   string `mapstructure:"preload_address" yaml:"preload_address" long:"preloadaddress" description:"Endpoint of fullnode to download backup database"`
-	ForceBackup      bool   `mapstructure:"force_backup" long:"forcebackup" description:"Force node to backup"`
-	IsFullValidation bool   `mapstructure:"is_full_validation" long:"is_full_validation" description:"fully validation data"`
+	PreloadAddress     string `mapstructure:"preload_address" yaml:"preload_address" long:"preloadaddress" description:"Endpoint of fullnode to download backup database"`
+	PreloadCheckpoint  string `mapstructure:"preload_checkpoint" yaml:"preload_checkpoint" long:"preloadcheckpoint" description:"Hash of a trusted beacon block from which a preloaded database is verified, the final beacon block of the node if empty"`
+	ForceBackup        bool   `mapstructure:"force_backup" long:"forcebackup" description:"Force node to backup"`
+	IsFullValidation   bool   `mapstructure:"is_full_validation" long:"is_full_validation" description:"fully validation data"`
+	SnapSync           bool   `mapstructure:"snap_sync" long:"snapsync" description:"Start an empty shard from the state of a recent final block downloaded from block providers, instead of replaying every block"`
+	SnapSyncCheckpoint string `mapstructure:"snap_sync_checkpoint" long:"snapsynccheckpoint" description:"Hash of a trusted recent beacon block, from which an empty beacon chain is snap synced if snap sync is enabled"`
 
 	// Optional : db to store coin by OTA key (for v2)
 	OutcoinDatabaseDir   string `mapstructure:"coin_data_pre" long:"coindatapre" description:"Output coins by OTA key database dir"`
 	NumIndexerWorkers    int64  `mapstructure:"num_indexer_workers" long:"numindexerworkers" description:"Number of workers for caching output coins"`
 	IndexerAccessTokens  string `mapstructure:"indexer_access_token" long:"indexeraccesstoken" description:"The access token for caching output coins"`
 	UseOutcoinDatabase   []bool `mapstructure:"use_coin_data" long:"usecoindata" description:"Store output coins by known OTA keys"`
+	IndexerWebhook       string `mapstructure:"indexer_webhook" long:"indexerwebhook" description:"URL to which incoming coins of submitted OTA keys are posted"`
+	NumWebhookRetries    int    `mapstructure:"num_webhook_retries" long:"numwebhookretries" description:"Number of retries for a failed incoming coin webhook"`
 	AllowStatePruneByRPC bool   `mapstructure:"allow_state_prune_by_rpc" long:"allowstateprunebyrpc" description:"allow state pruning flag"`
 	OfflinePrune         bool   `mapstructure:"offline_prune" long:"offlineprune" description:"offline pruning flag"`
 	StateBloomSize       uint64 `mapstructure:"state_bloom_size" long:"statebloomsize" description:"state pruning bloom size"`
 	EnableAutoPrune      bool   `mapstructure:"enable_auto_prune" long:"enableautoprune" description:"enable auto prune"`
 	NumBlockTriggerPrune uint64 `mapstructure:"num_block_trigger_prune" long:"numblocktriggerprune" description:"number block trigger prune"`
+	ArchiveMode          bool   `mapstructure:"archive_mode" long:"archivemode" description:"keep the state of every block readable, state pruning is disabled"`
+}
+
+// RPCAPIKey grants the RPC client authenticated with the Basic auth Name:Key
+// access to Methods ("*" for every method but the limited ones, which must be listed by name).
+// The limits replace rpc_limit_request_per_day and rpc_limit_request_error_per_hour for the
+// requests of this key, 0 means no limit. When keys are configured, the websocket only
+// upgrades the connections authenticated with one of them and checks their subscriptions.
+type RPCAPIKey struct {
+	Name                     string   `mapstructure:"name"`
+	Key                      string   `mapstructure:"key"`
+	Methods                  []string `mapstructure:"methods"`
+	LimitRequestPerDay       int      `mapstructure:"limit_request_per_day"`
+	LimitRequestErrorPerHour int      `mapstructure:"limit_request_error_per_hour"`
 }
 
 // normalizeAddresses returns a new slice with all the passed peer addresses
@@ -204,6 +230,10 @@ func (c *config) verify() {
 		os.Exit(utils.ExitCodeUnknow)
 	}
 
+	if c.DatabaseType == utils.EmptyString {
+		c.DatabaseType = DefaultDatabaseType
+	}
+
 	// Append the network type to the data directory so it is "namespaced"
 	// per network.  In addition to the block database, there are other
 	// pieces of data that are saved to disk such as address manager state.
@@ -224,6 +254,13 @@ func (c *config) verify() {
 		panic(str)
 	}
 
+	// --archivemode keeps every state root, so it can not be mixed with pruning.
+	if c.ArchiveMode && (c.OfflinePrune || c.EnableAutoPrune || c.AllowStatePruneByRPC) {
+		str := "the --archivemode option can not be mixed with state pruning options"
+		fmt.Fprintln(os.Stderr, errors.New(str))
+		panic(str)
+	}
+
 	// --proxy or --connect without --listen disables listening.
 	if (c.Proxy != utils.EmptyString || len(c.ConnectPeers) > 0) &&
 		len(c.Listener) == 0 {
@@ -251,9 +288,29 @@ func (c *config) verify() {
 			panic(str)
 		}
 
+		apiKeyNames := make(map[string]bool)
+		for _, apiKey := range c.RPCAPIKeys {
+			if apiKey.Name == "" || apiKey.Key == "" {
+				str := "rpc_api_keys: every API key must specify a name and a key"
+				fmt.Fprintln(os.Stderr, errors.New(str))
+				panic(str)
+			}
+			if apiKeyNames[apiKey.Name] || apiKey.Name == c.RPCUser || apiKey.Name == c.RPCLimitUser {
+				str := "rpc_api_keys: the API key name " + apiKey.Name + " is already used"
+				fmt.Fprintln(os.Stderr, errors.New(str))
+				panic(str)
+			}
+			if len(apiKey.Methods) == 0 {
+				str := "rpc_api_keys: the API key " + apiKey.Name + " must allow at least one method"
+				fmt.Fprintln(os.Stderr, errors.New(str))
+				panic(str)
+			}
+			apiKeyNames[apiKey.Name] = true
+		}
+
 		// The RPC server is disabled if no username or password is provided.
 		if (c.RPCUser == "" || c.RPCPass == "") &&
-			(c.RPCLimitUser == "" || c.RPCLimitPass == "") {
+			(c.RPCLimitUser == "" || c.RPCLimitPass == "") && len(c.RPCAPIKeys) == 0 {
 			log.Println("The RPC server is disabled if no username or password is provided.")
 			c.DisableRPC = true
 		}
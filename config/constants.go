@@ -22,6 +22,7 @@ const (
 const (
 	DefaultDataDirname                 = "data"
 	DefaultDatabaseDirname             = "block"
+	DefaultDatabaseType                = "leveldb"
 	DefaultMempoolDirname              = "mempool"
 	DefaultLogLevel                    = "info"
 	DefaultLogDirname                  = "logs"
@@ -41,10 +42,11 @@ const (
 	DefaultDisableRpcTLS               = true
 	DefaultFastStartup                 = true
 	// DefaultNodeMode                    = common.NodeModeRelay
-	DefaultEnableMining = true
-	DefaultTxPoolTTL    = uint(15 * 60) // 15 minutes
-	DefaultTxPoolMaxTx  = uint64(100000)
-	DefaultLimitFee     = uint64(1) // 1 nano PRV = 10^-9 PRV
+	DefaultEnableMining           = true
+	DefaultTxPoolTTL              = uint(15 * 60) // 15 minutes
+	DefaultTxPoolMaxTx            = uint64(100000)
+	DefaultTxPoolSnapshotInterval = uint(60)
+	DefaultLimitFee               = uint64(1) // 1 nano PRV = 10^-9 PRV
 	//DefaultLimitFee = uint64(100000) // 100000 nano PRV = 100000 * 10^-9 PRV
 	// For wallet
 	DefaultWalletName           = "wallet"
@@ -65,6 +65,7 @@ var MainnetParam = &param{
 		BlockProducingV3Height:    1846560,
 		Lemma2Height:              1816555,
 		ByzantineDetectorHeight:   1e9,
+		SlashEvidenceHeight:       1e9,
 		Timeslot:                  40,
 		EpochBreakPointSwapNewKey: []uint64{1917},
 	},
@@ -89,6 +90,11 @@ var MainnetParam = &param{
 	PLGParam: plgParam{
 		Host: []string{"https://polygon-mainnet.infura.io/v3/9bc873177cf74a03a35739e45755a9ac"},
 	},
+	DelegationParam: delegationParam{
+		DelegationHeight:    1e9,
+		MinDelegationAmount: 1e10,
+		MaxCommission:       2000,
+	},
 	IsBackup: false,
 }
 
@@ -152,6 +158,7 @@ var Testnet1Param = &param{
 		BlockProducingV3Height:    1e9,
 		Lemma2Height:              2868685,
 		ByzantineDetectorHeight:   1e9,
+		SlashEvidenceHeight:       1e9,
 		Timeslot:                  10,
 		EpochBreakPointSwapNewKey: []uint64{1280},
 	},
@@ -170,6 +177,11 @@ var Testnet1Param = &param{
 	BSCParam: bscParam{
 		Host: []string{"https://data-seed-prebsc-2-s1.binance.org:8545"},
 	},
+	DelegationParam: delegationParam{
+		DelegationHeight:    1e9,
+		MinDelegationAmount: 1e10,
+		MaxCommission:       2000,
+	},
 	IsBackup: false,
 }
 
@@ -234,6 +246,7 @@ var Tesnet2Param = &param{
 		BlockProducingV3Height:    1e9,
 		Lemma2Height:              1e9,
 		ByzantineDetectorHeight:   1e9,
+		SlashEvidenceHeight:       1e9,
 		Timeslot:                  10,
 		EpochBreakPointSwapNewKey: []uint64{1280},
 	},
@@ -252,6 +265,11 @@ var Tesnet2Param = &param{
 	BSCParam: bscParam{
 		Host: []string{"https://data-seed-prebsc-2-s1.binance.org:8545"},
 	},
+	DelegationParam: delegationParam{
+		DelegationHeight:    1e9,
+		MinDelegationAmount: 1e10,
+		MaxCommission:       2000,
+	},
 	IsBackup: false,
 }
 
@@ -316,6 +334,7 @@ var LocalParam = &param{
 		BlockProducingV3Height:    1e9,
 		Lemma2Height:              1e9,
 		ByzantineDetectorHeight:   1e9,
+		SlashEvidenceHeight:       1e9,
 		Timeslot:                  10,
 		EpochBreakPointSwapNewKey: []uint64{1280},
 	},
@@ -333,6 +352,11 @@ var LocalParam = &param{
 	BSCParam: bscParam{
 		Host: []string{"https://data-seed-prebsc-2-s1.binance.org:8545"},
 	},
+	DelegationParam: delegationParam{
+		DelegationHeight:    1e9,
+		MinDelegationAmount: 1e10,
+		MaxCommission:       2000,
+	},
 	IsBackup: false,
 }
 
@@ -397,6 +421,7 @@ var LocalDCSParam = &param{
 		Lemma2Height:              50,
 		BlockProducingV3Height:    1e9,
 		ByzantineDetectorHeight:   1e9,
+		SlashEvidenceHeight:       1e9,
 		Timeslot:                  10,
 		EpochBreakPointSwapNewKey: []uint64{1280},
 	},
@@ -414,6 +439,11 @@ var LocalDCSParam = &param{
 	BSCParam: bscParam{
 		Host: []string{"https://data-seed-prebsc-2-s1.binance.org:8545"},
 	},
+	DelegationParam: delegationParam{
+		DelegationHeight:    1e9,
+		MinDelegationAmount: 1e10,
+		MaxCommission:       2000,
+	},
 	IsBackup: false,
 }
 
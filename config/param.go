@@ -74,6 +74,7 @@ type param struct {
 	PDexParams                       pdexParam                    `mapstructure:"pdex_param"`
 	IsEnableBPV3Stats                bool                         `mapstructure:"is_enable_bpv3_stats"`
 	BridgeAggParam                   bridgeAggParam               `mapstructure:"bridge_agg_param"`
+	DelegationParam                  delegationParam              `mapstructure:"delegation_param"`
 	BlockTimeParam                   map[string]int64             `mapstructure:"blocktime_param"`
 	FeatureVersion                   map[string]int64             `mapstructure:"feature_version"`
 	TransactionInBlockParam          TxsPerBlock                  `mapstructure:"transactions_param"`
@@ -144,6 +145,7 @@ type consensusParam struct {
 	NotUseBurnedCoins         uint64   `mapstructure:"force_not_use_burned_coins"`
 	Lemma2Height              uint64   `mapstructure:"lemma2_height"`
 	ByzantineDetectorHeight   uint64   `mapstructure:"byzantine_detector_height"`
+	SlashEvidenceHeight       uint64   `mapstructure:"slash_evidence_height"`
 	BlockProducingV3Height    uint64   `mapstructure:"block_producing_v3_height"`
 	Timeslot                  uint64   `mapstructure:"timeslot"`
 	EpochBreakPointSwapNewKey []uint64 `mapstructure:"epoch_break_point_swap_new_key"`
@@ -320,10 +322,11 @@ type bscParam struct {
 }
 
 type pdexParam struct {
-	Pdexv3BreakPointHeight uint64 `mapstructure:"pdex_v3_break_point_height"`
-	ProtocolFundAddress    string `mapstructure:"protocol_fund_address"`
-	AdminAddress           string `mapstructure:"admin_address"`
-	Params                 struct {
+	Pdexv3BreakPointHeight           uint64 `mapstructure:"pdex_v3_break_point_height"`
+	ConditionalOrderBreakPointHeight uint64 `mapstructure:"conditional_order_break_point_height"`
+	ProtocolFundAddress              string `mapstructure:"protocol_fund_address"`
+	AdminAddress                     string `mapstructure:"admin_address"`
+	Params                           struct {
 		DefaultFeeRateBPS               uint            `mapstructure:"default_fee_rate_bps"`
 		PRVDiscountPercent              uint            `mapstructure:"prv_discount_percent"`
 		TradingProtocolFeePercent       uint            `mapstructure:"trading_protocol_fee_percent"`
@@ -369,3 +372,9 @@ type bridgeAggParam struct {
 	PercentFeeDecimal            uint64 `mapstructure:"percent_fee_decimal"`
 	DefaultPercentFeeWithDecimal uint64 `mapstructure:"default_percent_fee_with_decimal"`
 }
+
+type delegationParam struct {
+	DelegationHeight    uint64 `mapstructure:"delegation_height"`
+	MinDelegationAmount uint64 `mapstructure:"min_delegation_amount"`
+	MaxCommission       uint64 `mapstructure:"max_commission" description:"max commission of delegation pool operators in basis points"`
+}
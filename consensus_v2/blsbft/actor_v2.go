@@ -576,6 +576,7 @@ func (a *actorV2) run() error {
 				//set round for monitor
 				round := a.currentTimeSlot - bestView.CalculateTimeSlot(bestView.GetBlock().GetProposeTime())
 				monitor.SetGlobalParam("RoundKey", fmt.Sprintf("%d_%d", bestView.GetHeight(), round))
+				updateRoundMetric(a.chainKey, round)
 
 				signingCommittees, committees, proposerPk, committeeViewHash, err := a.getCommitteesAndCommitteeViewHash()
 				if err != nil {
@@ -632,6 +633,7 @@ func (a *actorV2) run() error {
 							a.currentTimeSlot, bestView.GetHeight()+1)
 					}
 
+					proposeStart := time.Now()
 					if createdBlk, err := a.proposeBlock(
 						userProposeKey,
 						proposerPk,
@@ -662,6 +664,7 @@ func (a *actorV2) run() error {
 							if err != nil {
 								a.logger.Error("Send BFT Propose Message Failed", err)
 							}
+							tracePropose(a.chainKey, createdBlk, proposeStart, err)
 							a.logger.Infof("[dcs] proposer block %v round %v time slot %v blockTimeSlot %v with hash %v", createdBlk.GetHeight(), createdBlk.GetRound(), a.currentTimeSlot, bestView.CalculateTimeSlot(createdBlk.GetProduceTime()), createdBlk.FullHashString())
 						}
 					}
@@ -769,6 +772,8 @@ func (a *actorV2) processIfBlockGetEnoughVote(proposeBlockInfo *ProposeBlockInfo
 				return
 			}
 			proposeBlockInfo.IsCommitted = true
+			updateCommitMetric(a.chainKey, proposeBlockInfo)
+			traceCollectVotes(a.chainKey, proposeBlockInfo)
 		}
 	}
 }
@@ -926,6 +931,8 @@ func (a *actorV2) voteValidBlock(
 			} else {
 				if !proposeBlockInfo.IsVoted { //not update database if field is already set
 					proposeBlockInfo.IsVoted = true
+					updateVoteMetric(a.chainKey, proposeBlockInfo)
+					traceVote(a.chainKey, proposeBlockInfo)
 					if err := a.AddReceiveBlockByHash(proposeBlockInfo.block.ProposeHash().String(), proposeBlockInfo); err != nil {
 						return err
 					}
@@ -1351,10 +1358,12 @@ func (a *actorV2) handleNewProposeMsg(
 func (a *actorV2) handleVoteMsg(voteMsg BFTVote) error {
 
 	if a.chainID != common.BeaconChainID {
-		if err := ByzantineDetectorObject.Validate(
+		err := ByzantineDetectorObject.Validate(
 			a.chain.GetBestViewHeight(),
 			&voteMsg,
-		); err != nil {
+		)
+		a.sendSlashEvidences()
+		if err != nil {
 			a.logger.Errorf("Found byzantine validator %+v, err %+v", voteMsg.Validator, err)
 			return err
 		}
@@ -1538,7 +1547,7 @@ func (a *actorV2) validatePreSignBlock(proposeBlockInfo *ProposeBlockInfo) error
 	defer cancel()
 
 	a.logger.Infof("validate block: %+v \n", proposeBlockInfo.block.ProposeHash().String())
-	if err := a.chain.ValidatePreSignBlock(proposeBlockInfo.block, proposeBlockInfo.SigningCommittees, proposeBlockInfo.Committees); err != nil {
+	if err := validatePreSignBlock(a.chain, proposeBlockInfo); err != nil {
 		a.logger.Error(err)
 		return err
 	}
@@ -27,6 +27,13 @@ var defaultTimeSlotTTL = int64(100)
 
 type VoteMessageHandler func(bftVote *BFTVote) error
 
+// DoubleSign is a pair of votes of a validator for different blocks of the same height, committee,
+// produce timeslot and propose timeslot, that an honest validator never sends
+type DoubleSign struct {
+	FirstVote  *BFTVote
+	SecondVote *BFTVote
+}
+
 func NewBlackListValidator(reason error) *rawdb_consensus.BlackListValidator {
 	return &rawdb_consensus.BlackListValidator{
 		Error:     reason.Error(),
@@ -41,6 +48,7 @@ type ByzantineDetector struct {
 	voteInTimeSlot               map[string]map[int64]*BFTVote                  // validator => timeslot => vote
 	validRecentVote              map[string]*BFTVote
 	smallestBlockProduceTimeSlot map[string]map[uint64]*BFTVote // validator => height => timeslot
+	doubleSigns                  []*DoubleSign                  // double signs waiting to be sent as slash evidence
 	logger                       common.Logger
 	mu                           *sync.RWMutex
 }
@@ -129,6 +137,8 @@ func (b *ByzantineDetector) Validate(bestViewHeight uint64, vote *BFTVote) error
 		}
 	}
 
+	b.recordDoubleSign(vote)
+
 	for _, handler := range handlers {
 		err = handler(vote)
 		if err != nil {
@@ -244,13 +254,56 @@ func (b ByzantineDetector) voteMoreThanOneTimesInATimeSlot(newVote *BFTVote) err
 		if !reflect.DeepEqual(vote, newVote) {
 			return fmt.Errorf("error name: %+v,"+
 				"first bftvote: %+v, latter bftvote: %+v",
-				ErrVoteForHigherTimeSlot, vote, newVote)
+				ErrDuplicateVoteInOneTimeSlot, vote, newVote)
 		}
 	}
 
 	return nil
 }
 
+// recordDoubleSign keeps newVote and the vote of the same validator in the same timeslot if they are a double sign
+func (b *ByzantineDetector) recordDoubleSign(newVote *BFTVote) {
+
+	if newVote.ChainID < 0 || len(newVote.BLS) == 0 {
+		return
+	}
+
+	vote, ok := b.voteInTimeSlot[newVote.Validator][newVote.ProposeTimeSlot]
+	if !ok || len(vote.BLS) == 0 {
+		return
+	}
+
+	if vote.ChainID != newVote.ChainID ||
+		vote.BlockHeight != newVote.BlockHeight ||
+		vote.ProduceTimeSlot != newVote.ProduceTimeSlot ||
+		!vote.CommitteeFromBlock.IsEqual(&newVote.CommitteeFromBlock) ||
+		vote.BlockHash == newVote.BlockHash {
+		return
+	}
+
+	b.doubleSigns = append(b.doubleSigns, &DoubleSign{FirstVote: vote, SecondVote: newVote})
+}
+
+// PopDoubleSigns returns and removes the double signs detected in chain chainID
+func (b *ByzantineDetector) PopDoubleSigns(chainID int) []*DoubleSign {
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	res := []*DoubleSign{}
+	remain := []*DoubleSign{}
+	for _, doubleSign := range b.doubleSigns {
+		if doubleSign.SecondVote.ChainID == chainID {
+			res = append(res, doubleSign)
+		} else {
+			remain = append(remain, doubleSign)
+		}
+	}
+	b.doubleSigns = remain
+
+	return res
+}
+
 func (b ByzantineDetector) voteForHigherTimeSlotSameHeight(newVote *BFTVote) error {
 
 	smallestTimeSlotBlock, ok := b.smallestBlockProduceTimeSlot[newVote.Validator]
@@ -4,6 +4,7 @@ import (
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdb_consensus"
 	"reflect"
+	"sync"
 	"testing"
 )
 
@@ -157,6 +158,107 @@ func TestByzantineDetector_voteMoreThanOneTimesInATimeSlot(t *testing.T) {
 	}
 }
 
+func TestByzantineDetector_recordDoubleSign(t *testing.T) {
+	firstVote := &BFTVote{
+		Validator:       blsKeys[0],
+		BlockHash:       "hash1",
+		BLS:             []byte{1},
+		BlockHeight:     10,
+		ProduceTimeSlot: 163394558,
+		ProposeTimeSlot: 163394559,
+		ChainID:         1,
+	}
+	type args struct {
+		bftVote *BFTVote
+	}
+	tests := []struct {
+		name           string
+		args           args
+		wantDoubleSign bool
+	}{
+		{
+			name: "same vote",
+			args: args{
+				&BFTVote{
+					Validator:       blsKeys[0],
+					BlockHash:       "hash1",
+					BLS:             []byte{1},
+					BlockHeight:     10,
+					ProduceTimeSlot: 163394558,
+					ProposeTimeSlot: 163394559,
+					ChainID:         1,
+				},
+			},
+			wantDoubleSign: false,
+		},
+		{
+			name: "vote for block produced in older timeslot",
+			args: args{
+				&BFTVote{
+					Validator:       blsKeys[0],
+					BlockHash:       "hash2",
+					BLS:             []byte{2},
+					BlockHeight:     10,
+					ProduceTimeSlot: 163394557,
+					ProposeTimeSlot: 163394559,
+					ChainID:         1,
+				},
+			},
+			wantDoubleSign: false,
+		},
+		{
+			name: "vote for next height",
+			args: args{
+				&BFTVote{
+					Validator:       blsKeys[0],
+					BlockHash:       "hash2",
+					BLS:             []byte{2},
+					BlockHeight:     11,
+					ProduceTimeSlot: 163394558,
+					ProposeTimeSlot: 163394559,
+					ChainID:         1,
+				},
+			},
+			wantDoubleSign: false,
+		},
+		{
+			name: "vote for another block of same height and timeslots",
+			args: args{
+				&BFTVote{
+					Validator:       blsKeys[0],
+					BlockHash:       "hash2",
+					BLS:             []byte{2},
+					BlockHeight:     10,
+					ProduceTimeSlot: 163394558,
+					ProposeTimeSlot: 163394559,
+					ChainID:         1,
+				},
+			},
+			wantDoubleSign: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := ByzantineDetector{
+				voteInTimeSlot: map[string]map[int64]*BFTVote{
+					blsKeys[0]: {
+						firstVote.ProposeTimeSlot: firstVote,
+					},
+				},
+				mu: new(sync.RWMutex),
+			}
+			b.recordDoubleSign(tt.args.bftVote)
+			doubleSigns := b.PopDoubleSigns(1)
+			if (len(doubleSigns) == 1) != tt.wantDoubleSign {
+				t.Errorf("recordDoubleSign() double signs = %v, wantDoubleSign %v", doubleSigns, tt.wantDoubleSign)
+			}
+			if len(b.PopDoubleSigns(1)) != 0 {
+				t.Errorf("PopDoubleSigns() does not remove double signs")
+			}
+		})
+	}
+}
+
 func TestByzantineDetector_voteForHigherTimeSlotSameHeight(t *testing.T) {
 	type fields struct {
 		blackList        map[string]*rawdb_consensus.BlackListValidator
@@ -131,7 +131,7 @@ func (c ConsensusValidatorLemma2) ValidateBlock(lastVotedBlock types.BlockInterf
 
 	if !proposeBlockInfo.IsValid {
 		c.logger.Infof("validate block: %+v \n", proposeBlockInfo.block.FullHashString())
-		if err := c.chain.ValidatePreSignBlock(proposeBlockInfo.block, proposeBlockInfo.SigningCommittees, proposeBlockInfo.Committees); err != nil {
+		if err := validatePreSignBlock(c.chain, proposeBlockInfo); err != nil {
 			c.logger.Error(err)
 			return false, err
 		}
@@ -274,7 +274,7 @@ func (c ConsensusValidatorLemma1) ValidateBlock(lastVotedBlock types.BlockInterf
 
 	if !proposeBlockInfo.IsValid {
 		c.logger.Infof("validate block: %+v \n", proposeBlockInfo.block.FullHashString())
-		if err := c.chain.ValidatePreSignBlock(proposeBlockInfo.block, proposeBlockInfo.SigningCommittees, proposeBlockInfo.Committees); err != nil {
+		if err := validatePreSignBlock(c.chain, proposeBlockInfo); err != nil {
 			c.logger.Error(err)
 			return false, err
 		}
@@ -1,14 +1,55 @@
 package blsbft
 
 import (
-	mocksTypes "github.com/incognitochain/incognito-chain/blockchain/types/mocks"
-	"github.com/incognitochain/incognito-chain/common"
 	"reflect"
 	"testing"
 	"time"
+
+	"github.com/incognitochain/incognito-chain/blockchain/types"
+	mocksTypes "github.com/incognitochain/incognito-chain/blockchain/types/mocks"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/consensus_v2/blsbft/mocks"
+	"github.com/incognitochain/incognito-chain/multiview"
+	mocksView "github.com/incognitochain/incognito-chain/multiview/mocks"
+	"github.com/stretchr/testify/mock"
 )
 
+var testPrevHash = common.HashH([]byte("0"))
+
+// testMultiView serves the previous view of the propose blocks
+type testMultiView struct {
+	multiview.MultiView
+	view multiview.View
+}
+
+func (m testMultiView) GetViewByHash(hash common.Hash) multiview.View {
+	if !hash.IsEqual(&testPrevHash) {
+		return nil
+	}
+	return m.view
+}
+
+// newRuleValidatorTestChain returns a chain whose best view is at testPrevHash.
+// The block of that view is proposed and produced in the same time slot.
+func newRuleValidatorTestChain(proposeTime int64) *mocks.Chain {
+	prevBlock := &mocksTypes.BlockInterface{}
+	prevBlock.On("GetVersion").Return(types.INSTANT_FINALITY_VERSION)
+	prevBlock.On("GetProposeTime").Return(proposeTime - int64(common.TIMESLOT))
+	prevBlock.On("GetProduceTime").Return(proposeTime - int64(common.TIMESLOT))
+	prevBlock.On("GetFinalityHeight").Return(uint64(0))
+	prevView := &mocksView.View{}
+	prevView.On("CalculateTimeSlot", mock.Anything).Return(calculateTimeSlot)
+	prevView.On("GetBlock").Return(prevBlock)
+	chain := &mocks.Chain{}
+	chain.On("GetViewByHash", testPrevHash).Return(prevView)
+	chain.On("GetMultiView").Return(testMultiView{view: prevView})
+	chain.On("GetBestViewHash").Return(testPrevHash.String())
+	return chain
+}
+
 func TestConsensusValidatorLemma1_FilterValidProposeBlockInfo(t *testing.T) {
+	chain := newRuleValidatorTestChain(int64(1626755704))
+
 	tc1ProposeTime := int64(1626755704)
 	tc1BestViewHeight := uint64(9)
 	tc1BestViewHash := common.HashH([]byte("2"))
@@ -16,11 +57,12 @@ func TestConsensusValidatorLemma1_FilterValidProposeBlockInfo(t *testing.T) {
 	tc1BlockHeight := uint64(10)
 	tc1BlockHash := common.HashH([]byte("1"))
 	tc1Block := &mocksTypes.BlockInterface{}
-	tc1Block.On("GetProposeTime").Return(tc1ProposeTime).Times(2)
-	tc1Block.On("GetProduceTime").Return(tc1ProposeTime).Times(2)
-	tc1Block.On("GetHeight").Return(tc1BlockHeight).Times(4)
-	tc1Block.On("Hash").Return(&tc1BlockHash).Times(4)
-	tc1CurrentTimeSlot := common.CalculateTimeSlot(tc1ProposeTime)
+	tc1Block.On("GetProposeTime").Return(tc1ProposeTime)
+	tc1Block.On("GetProduceTime").Return(tc1ProposeTime)
+	tc1Block.On("GetHeight").Return(tc1BlockHeight)
+	tc1Block.On("Hash").Return(&tc1BlockHash)
+	tc1Block.On("GetPrevHash").Return(testPrevHash)
+	tc1CurrentTimeSlot := calculateTimeSlot(tc1ProposeTime)
 	tc1BlockProposeInfo := &ProposeBlockInfo{
 		block:            tc1Block,
 		IsVoted:          true,
@@ -39,11 +81,12 @@ func TestConsensusValidatorLemma1_FilterValidProposeBlockInfo(t *testing.T) {
 	tc2FinalViewHeight := uint64(8)
 	tc2BlockHash := common.HashH([]byte("1"))
 	tc2Block := &mocksTypes.BlockInterface{}
-	tc2Block.On("GetProposeTime").Return(tc2ProposeTime).Times(2)
-	tc2Block.On("GetProduceTime").Return(tc2ProposeTime).Times(2)
-	tc2Block.On("GetHeight").Return(tc2BlockHeight).Times(4)
-	tc2Block.On("Hash").Return(&tc2BlockHash).Times(4)
-	tc2CurrentTimeSlot := common.CalculateTimeSlot(tc2ProposeTime + int64(common.TIMESLOT))
+	tc2Block.On("GetProposeTime").Return(tc2ProposeTime)
+	tc2Block.On("GetProduceTime").Return(tc2ProposeTime)
+	tc2Block.On("GetHeight").Return(tc2BlockHeight)
+	tc2Block.On("Hash").Return(&tc2BlockHash)
+	tc2Block.On("GetPrevHash").Return(testPrevHash)
+	tc2CurrentTimeSlot := calculateTimeSlot(tc2ProposeTime + int64(common.TIMESLOT))
 	tc2BlockProposeInfo := &ProposeBlockInfo{
 		block:            tc2Block,
 		IsVoted:          true,
@@ -60,11 +103,12 @@ func TestConsensusValidatorLemma1_FilterValidProposeBlockInfo(t *testing.T) {
 	tc3BestViewHash := common.HashH([]byte("2"))
 	tc3FinalViewHeight := uint64(8)
 	tc3Block := &mocksTypes.BlockInterface{}
-	tc3Block.On("GetProposeTime").Return(tc3ProposeTime).Times(2)
-	tc3Block.On("GetProduceTime").Return(tc3ProposeTime).Times(2)
-	tc3Block.On("GetHeight").Return(tc3BlockHeight).Times(4)
-	tc3Block.On("Hash").Return(&tc3BlockHash).Times(4)
-	tc3CurrentTimeSlot := common.CalculateTimeSlot(tc3ProposeTime)
+	tc3Block.On("GetProposeTime").Return(tc3ProposeTime)
+	tc3Block.On("GetProduceTime").Return(tc3ProposeTime)
+	tc3Block.On("GetHeight").Return(tc3BlockHeight)
+	tc3Block.On("Hash").Return(&tc3BlockHash)
+	tc3Block.On("GetPrevHash").Return(testPrevHash)
+	tc3CurrentTimeSlot := calculateTimeSlot(tc3ProposeTime)
 	tc3BlockProposeInfo := &ProposeBlockInfo{
 		block:            tc3Block,
 		IsVoted:          true,
@@ -81,11 +125,12 @@ func TestConsensusValidatorLemma1_FilterValidProposeBlockInfo(t *testing.T) {
 	tc4BestViewHash := common.HashH([]byte("2"))
 	tc4FinalViewHeight := uint64(8)
 	tc4Block := &mocksTypes.BlockInterface{}
-	tc4Block.On("GetProposeTime").Return(tc4ProposeTime).Times(2)
-	tc4Block.On("GetProduceTime").Return(tc4ProposeTime + int64(common.TIMESLOT)).Times(2)
-	tc4Block.On("GetHeight").Return(tc4BlockHeight + 1).Times(4)
-	tc4Block.On("Hash").Return(&tc4BlockHash).Times(4)
-	tc4CurrentTimeSlot := common.CalculateTimeSlot(tc4ProposeTime)
+	tc4Block.On("GetProposeTime").Return(tc4ProposeTime)
+	tc4Block.On("GetProduceTime").Return(tc4ProposeTime + int64(common.TIMESLOT))
+	tc4Block.On("GetHeight").Return(tc4BlockHeight + 1)
+	tc4Block.On("Hash").Return(&tc4BlockHash)
+	tc4Block.On("GetPrevHash").Return(testPrevHash)
+	tc4CurrentTimeSlot := calculateTimeSlot(tc4ProposeTime)
 	tc4BlockProposeInfo := &ProposeBlockInfo{
 		block:            tc4Block,
 		IsVoted:          true,
@@ -102,11 +147,12 @@ func TestConsensusValidatorLemma1_FilterValidProposeBlockInfo(t *testing.T) {
 	tc5BestViewHash := common.HashH([]byte("2"))
 	tc5FinalViewHeight := uint64(11)
 	tc5Block := &mocksTypes.BlockInterface{}
-	tc5Block.On("GetProposeTime").Return(tc5ProposeTime).Times(2)
-	tc5Block.On("GetProduceTime").Return(tc5ProposeTime).Times(2)
-	tc5Block.On("GetHeight").Return(tc5BlockHeight).Times(4)
-	tc5Block.On("Hash").Return(&tc5BlockHash).Times(4)
-	tc5CurrentTimeSlot := common.CalculateTimeSlot(tc5ProposeTime)
+	tc5Block.On("GetProposeTime").Return(tc5ProposeTime)
+	tc5Block.On("GetProduceTime").Return(tc5ProposeTime)
+	tc5Block.On("GetHeight").Return(tc5BlockHeight)
+	tc5Block.On("Hash").Return(&tc5BlockHash)
+	tc5Block.On("GetPrevHash").Return(testPrevHash)
+	tc5CurrentTimeSlot := calculateTimeSlot(tc5ProposeTime)
 	tc5BlockProposeInfo := &ProposeBlockInfo{
 		block:            tc5Block,
 		IsVoted:          true,
@@ -124,18 +170,20 @@ func TestConsensusValidatorLemma1_FilterValidProposeBlockInfo(t *testing.T) {
 	tc6BlockHash := common.HashH([]byte("1"))
 	tc6BlockHash2 := common.HashH([]byte("2"))
 	tc6Block := &mocksTypes.BlockInterface{}
-	tc6Block.On("GetProposeTime").Return(tc6ProposeTime).Times(2)
-	tc6Block.On("GetProduceTime").Return(tc6ProposeTime).Times(6)
-	tc6Block.On("GetHeight").Return(tc6BlockHeight + 1).Times(6)
-	tc6Block.On("Hash").Return(&tc6BlockHash).Times(4)
+	tc6Block.On("GetProposeTime").Return(tc6ProposeTime)
+	tc6Block.On("GetProduceTime").Return(tc6ProposeTime)
+	tc6Block.On("GetHeight").Return(tc6BlockHeight + 1)
+	tc6Block.On("Hash").Return(&tc6BlockHash)
+	tc6Block.On("GetPrevHash").Return(testPrevHash)
 
 	tc6Block2 := &mocksTypes.BlockInterface{}
-	tc6Block2.On("GetProposeTime").Return(tc6ProposeTime).Times(2)
-	tc6Block2.On("GetProduceTime").Return(tc6ProposeTime - int64(common.TIMESLOT)).Times(6)
-	tc6Block2.On("GetHeight").Return(tc6BlockHeight + 1).Times(6)
-	tc6Block2.On("Hash").Return(&tc6BlockHash2).Times(4)
+	tc6Block2.On("GetProposeTime").Return(tc6ProposeTime)
+	tc6Block2.On("GetProduceTime").Return(tc6ProposeTime - int64(common.TIMESLOT))
+	tc6Block2.On("GetHeight").Return(tc6BlockHeight + 1)
+	tc6Block2.On("Hash").Return(&tc6BlockHash2)
+	tc6Block2.On("GetPrevHash").Return(testPrevHash)
 
-	tc6CurrentTimeSlot := common.CalculateTimeSlot(tc6ProposeTime)
+	tc6CurrentTimeSlot := calculateTimeSlot(tc6ProposeTime)
 	tc6BlockProposeInfo := &ProposeBlockInfo{
 		block:            tc6Block,
 		IsVoted:          true,
@@ -158,11 +206,12 @@ func TestConsensusValidatorLemma1_FilterValidProposeBlockInfo(t *testing.T) {
 	tc7BlockHeight := uint64(10)
 	tc7BlockHash := common.HashH([]byte("1"))
 	tc7Block := &mocksTypes.BlockInterface{}
-	tc7Block.On("GetProposeTime").Return(tc7ProposeTime).Times(2)
-	tc7Block.On("GetProduceTime").Return(tc7ProposeTime).Times(2)
-	tc7Block.On("GetHeight").Return(tc7BlockHeight).Times(4)
-	tc7Block.On("Hash").Return(&tc7BlockHash).Times(4)
-	tc7CurrentTimeSlot := common.CalculateTimeSlot(tc7ProposeTime)
+	tc7Block.On("GetProposeTime").Return(tc7ProposeTime)
+	tc7Block.On("GetProduceTime").Return(tc7ProposeTime)
+	tc7Block.On("GetHeight").Return(tc7BlockHeight)
+	tc7Block.On("Hash").Return(&tc7BlockHash)
+	tc7Block.On("GetPrevHash").Return(testPrevHash)
+	tc7CurrentTimeSlot := calculateTimeSlot(tc7ProposeTime)
 	tc7BlockProposeInfo := &ProposeBlockInfo{
 		block:            tc7Block,
 		IsVoted:          false,
@@ -192,8 +241,8 @@ func TestConsensusValidatorLemma1_FilterValidProposeBlockInfo(t *testing.T) {
 		wantInvalid []string
 	}{
 		{
-			name:   "tc1: just validate recently",
-			fields: fields{},
+			name:   "tc1: valid propose block info",
+			fields: fields{chain: chain},
 			args: args{
 				bestViewHash:      tc1BestViewHash,
 				bestViewHeight:    tc1BestViewHeight,
@@ -201,13 +250,13 @@ func TestConsensusValidatorLemma1_FilterValidProposeBlockInfo(t *testing.T) {
 				currentTimeSlot:   tc1CurrentTimeSlot,
 				proposeBlockInfos: tc1ReceiveBlockByHash,
 			},
-			wantValid:   []*ProposeBlockInfo{},
+			wantValid:   []*ProposeBlockInfo{tc1BlockProposeInfo},
 			wantReVote:  []*ProposeBlockInfo{},
 			wantInvalid: []string{},
 		},
 		{
 			name:   "tc2: not propose in time slot",
-			fields: fields{},
+			fields: fields{chain: chain},
 			args: args{
 				bestViewHash:      tc2BestViewHash,
 				bestViewHeight:    tc2BestViewHeight,
@@ -221,7 +270,7 @@ func TestConsensusValidatorLemma1_FilterValidProposeBlockInfo(t *testing.T) {
 		},
 		{
 			name:   "tc3: not connect to best height",
-			fields: fields{},
+			fields: fields{chain: chain},
 			args: args{
 				bestViewHash:      tc3BestViewHash,
 				bestViewHeight:    tc3BestViewHeight,
@@ -235,7 +284,7 @@ func TestConsensusValidatorLemma1_FilterValidProposeBlockInfo(t *testing.T) {
 		},
 		{
 			name:   "tc4: producer time < current timeslot",
-			fields: fields{},
+			fields: fields{chain: chain},
 			args: args{
 				bestViewHash:      tc4BestViewHash,
 				bestViewHeight:    tc4BestViewHeight,
@@ -249,7 +298,7 @@ func TestConsensusValidatorLemma1_FilterValidProposeBlockInfo(t *testing.T) {
 		},
 		{
 			name:   "tc5: propose block info height < final view",
-			fields: fields{},
+			fields: fields{chain: chain},
 			args: args{
 				bestViewHash:      tc5BestViewHash,
 				bestViewHeight:    tc5BestViewHeight,
@@ -263,7 +312,7 @@ func TestConsensusValidatorLemma1_FilterValidProposeBlockInfo(t *testing.T) {
 		},
 		{
 			name:   "tc6: add valid propose block info",
-			fields: fields{},
+			fields: fields{chain: chain},
 			args: args{
 				bestViewHash:      tc6BestViewHash,
 				bestViewHeight:    tc6BestViewHeight,
@@ -277,7 +326,7 @@ func TestConsensusValidatorLemma1_FilterValidProposeBlockInfo(t *testing.T) {
 		},
 		{
 			name:   "tc7: re-vote insert block",
-			fields: fields{},
+			fields: fields{chain: chain},
 			args: args{
 				bestViewHash:      tc7BestViewHash,
 				bestViewHeight:    tc7BestViewHeight,
@@ -311,6 +360,8 @@ func TestConsensusValidatorLemma1_FilterValidProposeBlockInfo(t *testing.T) {
 }
 
 func TestConsensusValidatorLemma2_FilterValidProposeBlockInfo(t *testing.T) {
+	chain := newRuleValidatorTestChain(int64(1626755704))
+
 	tc1ProposeTime := int64(1626755704)
 	tc1BestViewHeight := uint64(9)
 	tc1BestViewHash := common.HashH([]byte("2"))
@@ -318,11 +369,13 @@ func TestConsensusValidatorLemma2_FilterValidProposeBlockInfo(t *testing.T) {
 	tc1BlockHeight := uint64(10)
 	tc1BlockHash := common.HashH([]byte("1"))
 	tc1Block := &mocksTypes.BlockInterface{}
-	tc1Block.On("GetProposeTime").Return(tc1ProposeTime).Times(2)
-	tc1Block.On("GetProduceTime").Return(tc1ProposeTime).Times(2)
-	tc1Block.On("GetHeight").Return(tc1BlockHeight).Times(4)
-	tc1Block.On("Hash").Return(&tc1BlockHash).Times(4)
-	tc1CurrentTimeSlot := common.CalculateTimeSlot(tc1ProposeTime)
+	tc1Block.On("GetProposeTime").Return(tc1ProposeTime)
+	tc1Block.On("GetProduceTime").Return(tc1ProposeTime)
+	tc1Block.On("GetHeight").Return(tc1BlockHeight)
+	tc1Block.On("Hash").Return(&tc1BlockHash)
+	tc1Block.On("GetPrevHash").Return(testPrevHash)
+	tc1Block.On("GetFinalityHeight").Return(uint64(0))
+	tc1CurrentTimeSlot := calculateTimeSlot(tc1ProposeTime)
 	tc1BlockProposeInfo := &ProposeBlockInfo{
 		block:            tc1Block,
 		IsVoted:          true,
@@ -341,11 +394,12 @@ func TestConsensusValidatorLemma2_FilterValidProposeBlockInfo(t *testing.T) {
 	tc2FinalViewHeight := uint64(8)
 	tc2BlockHash := common.HashH([]byte("1"))
 	tc2Block := &mocksTypes.BlockInterface{}
-	tc2Block.On("GetProposeTime").Return(tc2ProposeTime).Times(2)
-	tc2Block.On("GetProduceTime").Return(tc2ProposeTime).Times(2)
-	tc2Block.On("GetHeight").Return(tc2BlockHeight).Times(4)
-	tc2Block.On("Hash").Return(&tc2BlockHash).Times(4)
-	tc2CurrentTimeSlot := common.CalculateTimeSlot(tc2ProposeTime + int64(common.TIMESLOT))
+	tc2Block.On("GetProposeTime").Return(tc2ProposeTime)
+	tc2Block.On("GetProduceTime").Return(tc2ProposeTime)
+	tc2Block.On("GetHeight").Return(tc2BlockHeight)
+	tc2Block.On("Hash").Return(&tc2BlockHash)
+	tc2Block.On("GetPrevHash").Return(testPrevHash)
+	tc2CurrentTimeSlot := calculateTimeSlot(tc2ProposeTime + int64(common.TIMESLOT))
 	tc2BlockProposeInfo := &ProposeBlockInfo{
 		block:            tc2Block,
 		IsVoted:          true,
@@ -362,11 +416,12 @@ func TestConsensusValidatorLemma2_FilterValidProposeBlockInfo(t *testing.T) {
 	tc3BestViewHash := common.HashH([]byte("2"))
 	tc3FinalViewHeight := uint64(8)
 	tc3Block := &mocksTypes.BlockInterface{}
-	tc3Block.On("GetProposeTime").Return(tc3ProposeTime).Times(2)
-	tc3Block.On("GetProduceTime").Return(tc3ProposeTime).Times(2)
-	tc3Block.On("GetHeight").Return(tc3BlockHeight).Times(4)
-	tc3Block.On("Hash").Return(&tc3BlockHash).Times(4)
-	tc3CurrentTimeSlot := common.CalculateTimeSlot(tc3ProposeTime)
+	tc3Block.On("GetProposeTime").Return(tc3ProposeTime)
+	tc3Block.On("GetProduceTime").Return(tc3ProposeTime)
+	tc3Block.On("GetHeight").Return(tc3BlockHeight)
+	tc3Block.On("Hash").Return(&tc3BlockHash)
+	tc3Block.On("GetPrevHash").Return(testPrevHash)
+	tc3CurrentTimeSlot := calculateTimeSlot(tc3ProposeTime)
 	tc3BlockProposeInfo := &ProposeBlockInfo{
 		block:            tc3Block,
 		IsVoted:          true,
@@ -383,11 +438,12 @@ func TestConsensusValidatorLemma2_FilterValidProposeBlockInfo(t *testing.T) {
 	tc4BestViewHash := common.HashH([]byte("2"))
 	tc4FinalViewHeight := uint64(8)
 	tc4Block := &mocksTypes.BlockInterface{}
-	tc4Block.On("GetProposeTime").Return(tc4ProposeTime).Times(2)
-	tc4Block.On("GetProduceTime").Return(tc4ProposeTime + int64(common.TIMESLOT)).Times(2)
-	tc4Block.On("GetHeight").Return(tc4BlockHeight + 1).Times(4)
-	tc4Block.On("Hash").Return(&tc4BlockHash).Times(4)
-	tc4CurrentTimeSlot := common.CalculateTimeSlot(tc4ProposeTime)
+	tc4Block.On("GetProposeTime").Return(tc4ProposeTime)
+	tc4Block.On("GetProduceTime").Return(tc4ProposeTime + int64(common.TIMESLOT))
+	tc4Block.On("GetHeight").Return(tc4BlockHeight + 1)
+	tc4Block.On("Hash").Return(&tc4BlockHash)
+	tc4Block.On("GetPrevHash").Return(testPrevHash)
+	tc4CurrentTimeSlot := calculateTimeSlot(tc4ProposeTime)
 	tc4BlockProposeInfo := &ProposeBlockInfo{
 		block:            tc4Block,
 		IsVoted:          true,
@@ -404,14 +460,14 @@ func TestConsensusValidatorLemma2_FilterValidProposeBlockInfo(t *testing.T) {
 	tc5BestViewHash := common.HashH([]byte("2"))
 	tc5FinalViewHeight := uint64(11)
 	tc5Block := &mocksTypes.BlockInterface{}
-	tc5Block.On("GetProposeTime").Return(tc5ProposeTime).Times(2)
-	tc5Block.On("GetProduceTime").Return(tc5ProposeTime).Times(2)
-	tc5Block.On("GetHeight").Return(tc5BlockHeight).Times(4)
-	tc5Block.On("Hash").Return(&tc5BlockHash).Times(4)
-	tc5Block.On("GetPrevHash").Return(tc5BlockHash).Times(10)
-	tc5Block.On("GetAggregateRootHash").Return(tc5BlockHash).Times(10)
-	tc5Block.On("GetFinalityHeight").Return(uint64(0)).Times(10)
-	tc5CurrentTimeSlot := common.CalculateTimeSlot(tc5ProposeTime)
+	tc5Block.On("GetProposeTime").Return(tc5ProposeTime)
+	tc5Block.On("GetProduceTime").Return(tc5ProposeTime)
+	tc5Block.On("GetHeight").Return(tc5BlockHeight)
+	tc5Block.On("Hash").Return(&tc5BlockHash)
+	tc5Block.On("GetPrevHash").Return(testPrevHash)
+	tc5Block.On("GetAggregateRootHash").Return(tc5BlockHash)
+	tc5Block.On("GetFinalityHeight").Return(uint64(0))
+	tc5CurrentTimeSlot := calculateTimeSlot(tc5ProposeTime)
 	tc5BlockProposeInfo := &ProposeBlockInfo{
 		block:              tc5Block,
 		IsVoted:            true,
@@ -430,24 +486,24 @@ func TestConsensusValidatorLemma2_FilterValidProposeBlockInfo(t *testing.T) {
 	tc6BlockHash := common.HashH([]byte("1"))
 	tc6BlockHash2 := common.HashH([]byte("2"))
 	tc6Block := &mocksTypes.BlockInterface{}
-	tc6Block.On("GetProposeTime").Return(tc6ProposeTime).Times(10)
-	tc6Block.On("GetProduceTime").Return(tc6ProposeTime).Times(10)
-	tc6Block.On("GetHeight").Return(tc6BlockHeight + 1).Times(10)
-	tc6Block.On("Hash").Return(&tc6BlockHash).Times(10)
-	tc6Block.On("GetPrevHash").Return(tc6BlockHash).Times(10)
-	tc6Block.On("GetAggregateRootHash").Return(tc6BlockHash).Times(10)
-	tc6Block.On("GetFinalityHeight").Return(uint64(tc6BlockHeight)).Times(10)
+	tc6Block.On("GetProposeTime").Return(tc6ProposeTime)
+	tc6Block.On("GetProduceTime").Return(tc6ProposeTime)
+	tc6Block.On("GetHeight").Return(tc6BlockHeight + 1)
+	tc6Block.On("Hash").Return(&tc6BlockHash)
+	tc6Block.On("GetPrevHash").Return(testPrevHash)
+	tc6Block.On("GetAggregateRootHash").Return(tc6BlockHash)
+	tc6Block.On("GetFinalityHeight").Return(uint64(tc6BlockHeight))
 
 	tc6Block2 := &mocksTypes.BlockInterface{}
-	tc6Block2.On("GetProposeTime").Return(tc6ProposeTime).Times(10)
-	tc6Block2.On("GetProduceTime").Return(tc6ProposeTime - int64(common.TIMESLOT)).Times(10)
-	tc6Block2.On("GetHeight").Return(tc6BlockHeight + 1).Times(10)
-	tc6Block2.On("Hash").Return(&tc6BlockHash2).Times(10)
-	tc6Block2.On("GetPrevHash").Return(tc6BlockHash2).Times(10)
-	tc6Block2.On("GetAggregateRootHash").Return(tc6BlockHash2).Times(10)
-	tc6Block2.On("GetFinalityHeight").Return(uint64(0)).Times(10)
-
-	tc6CurrentTimeSlot := common.CalculateTimeSlot(tc6ProposeTime)
+	tc6Block2.On("GetProposeTime").Return(tc6ProposeTime)
+	tc6Block2.On("GetProduceTime").Return(tc6ProposeTime - int64(common.TIMESLOT))
+	tc6Block2.On("GetHeight").Return(tc6BlockHeight + 1)
+	tc6Block2.On("Hash").Return(&tc6BlockHash2)
+	tc6Block2.On("GetPrevHash").Return(testPrevHash)
+	tc6Block2.On("GetAggregateRootHash").Return(tc6BlockHash2)
+	tc6Block2.On("GetFinalityHeight").Return(uint64(0))
+
+	tc6CurrentTimeSlot := calculateTimeSlot(tc6ProposeTime)
 	tc6BlockProposeInfo := &ProposeBlockInfo{
 		block:              tc6Block,
 		IsVoted:            true,
@@ -472,11 +528,13 @@ func TestConsensusValidatorLemma2_FilterValidProposeBlockInfo(t *testing.T) {
 	tc7BlockHeight := uint64(10)
 	tc7BlockHash := common.HashH([]byte("1"))
 	tc7Block := &mocksTypes.BlockInterface{}
-	tc7Block.On("GetProposeTime").Return(tc7ProposeTime).Times(2)
-	tc7Block.On("GetProduceTime").Return(tc7ProposeTime).Times(2)
-	tc7Block.On("GetHeight").Return(tc7BlockHeight).Times(4)
-	tc7Block.On("Hash").Return(&tc7BlockHash).Times(4)
-	tc7CurrentTimeSlot := common.CalculateTimeSlot(tc7ProposeTime)
+	tc7Block.On("GetProposeTime").Return(tc7ProposeTime)
+	tc7Block.On("GetProduceTime").Return(tc7ProposeTime)
+	tc7Block.On("GetHeight").Return(tc7BlockHeight)
+	tc7Block.On("Hash").Return(&tc7BlockHash)
+	tc7Block.On("GetPrevHash").Return(testPrevHash)
+	tc7Block.On("ProposeHash").Return(&tc7BlockHash)
+	tc7CurrentTimeSlot := calculateTimeSlot(tc7ProposeTime)
 	tc7BlockProposeInfo := &ProposeBlockInfo{
 		block:            tc7Block,
 		IsVoted:          false,
@@ -493,15 +551,16 @@ func TestConsensusValidatorLemma2_FilterValidProposeBlockInfo(t *testing.T) {
 	tc8FinalViewHeight := uint64(8)
 	tc8BlockHash := common.HashH([]byte("1"))
 	tc8Block := &mocksTypes.BlockInterface{}
-	tc8Block.On("GetProposeTime").Return(tc8ProposeTime).Times(10)
-	tc8Block.On("GetProduceTime").Return(tc8ProposeTime).Times(10)
-	tc8Block.On("GetHeight").Return(tc8BlockHeight + 1).Times(10)
-	tc8Block.On("Hash").Return(&tc8BlockHash).Times(10)
-	tc8Block.On("GetPrevHash").Return(&tc8BlockHash).Times(10)
-	tc8Block.On("GetAggregateRootHash").Return(&tc8BlockHash).Times(10)
-	tc8Block.On("GetFinalityHeight").Return(uint64(0)).Times(10)
-
-	tc8CurrentTimeSlot := common.CalculateTimeSlot(tc8ProposeTime)
+	tc8Block.On("GetProposeTime").Return(tc8ProposeTime)
+	tc8Block.On("GetProduceTime").Return(tc8ProposeTime)
+	tc8Block.On("GetHeight").Return(tc8BlockHeight + 1)
+	tc8Block.On("Hash").Return(&tc8BlockHash)
+	tc8Block.On("GetPrevHash").Return(testPrevHash)
+	tc8Block.On("FullHashString").Return(tc8BlockHash.String())
+	tc8Block.On("GetAggregateRootHash").Return(&tc8BlockHash)
+	tc8Block.On("GetFinalityHeight").Return(uint64(0))
+
+	tc8CurrentTimeSlot := calculateTimeSlot(tc8ProposeTime)
 	tc8BlockProposeInfo := &ProposeBlockInfo{
 		block:              tc8Block,
 		IsVoted:            true,
@@ -519,15 +578,16 @@ func TestConsensusValidatorLemma2_FilterValidProposeBlockInfo(t *testing.T) {
 	tc9FinalViewHeight := uint64(8)
 	tc9BlockHash := common.HashH([]byte("1"))
 	tc9Block := &mocksTypes.BlockInterface{}
-	tc9Block.On("GetProposeTime").Return(tc9ProposeTime).Times(10)
-	tc9Block.On("GetProduceTime").Return(tc9ProposeTime).Times(10)
-	tc9Block.On("GetHeight").Return(tc9BlockHeight + 1).Times(10)
-	tc9Block.On("Hash").Return(&tc9BlockHash).Times(10)
-	tc9Block.On("GetPrevHash").Return(tc9BlockHash).Times(10)
-	tc9Block.On("GetAggregateRootHash").Return(tc9BlockHash).Times(10)
-	tc9Block.On("GetFinalityHeight").Return(uint64(tc9BlockHeight)).Times(10)
-
-	tc9CurrentTimeSlot := common.CalculateTimeSlot(tc9ProposeTime)
+	tc9Block.On("GetProposeTime").Return(tc9ProposeTime)
+	tc9Block.On("GetProduceTime").Return(tc9ProposeTime)
+	tc9Block.On("GetHeight").Return(tc9BlockHeight + 1)
+	tc9Block.On("Hash").Return(&tc9BlockHash)
+	tc9Block.On("GetPrevHash").Return(testPrevHash)
+	tc9Block.On("FullHashString").Return(tc9BlockHash.String())
+	tc9Block.On("GetAggregateRootHash").Return(tc9BlockHash)
+	tc9Block.On("GetFinalityHeight").Return(uint64(tc9BlockHeight))
+
+	tc9CurrentTimeSlot := calculateTimeSlot(tc9ProposeTime)
 	tc9BlockProposeInfo := &ProposeBlockInfo{
 		block:              tc9Block,
 		IsVoted:            true,
@@ -558,8 +618,8 @@ func TestConsensusValidatorLemma2_FilterValidProposeBlockInfo(t *testing.T) {
 		wantInvalid []string
 	}{
 		{
-			name:   "tc1: just validate recently",
-			fields: fields{},
+			name:   "tc1: valid propose block info",
+			fields: fields{chain: chain},
 			args: args{
 				bestViewHash:      tc1BestViewHash,
 				bestViewHeight:    tc1BestViewHeight,
@@ -567,13 +627,13 @@ func TestConsensusValidatorLemma2_FilterValidProposeBlockInfo(t *testing.T) {
 				currentTimeSlot:   tc1CurrentTimeSlot,
 				proposeBlockInfos: tc1ReceiveBlockByHash,
 			},
-			wantValid:   []*ProposeBlockInfo{},
+			wantValid:   []*ProposeBlockInfo{tc1BlockProposeInfo},
 			wantReVote:  []*ProposeBlockInfo{},
 			wantInvalid: []string{},
 		},
 		{
 			name:   "tc2: not propose in time slot",
-			fields: fields{},
+			fields: fields{chain: chain},
 			args: args{
 				bestViewHash:      tc2BestViewHash,
 				bestViewHeight:    tc2BestViewHeight,
@@ -587,7 +647,7 @@ func TestConsensusValidatorLemma2_FilterValidProposeBlockInfo(t *testing.T) {
 		},
 		{
 			name:   "tc3: not connect to best height",
-			fields: fields{},
+			fields: fields{chain: chain},
 			args: args{
 				bestViewHash:      tc3BestViewHash,
 				bestViewHeight:    tc3BestViewHeight,
@@ -601,7 +661,7 @@ func TestConsensusValidatorLemma2_FilterValidProposeBlockInfo(t *testing.T) {
 		},
 		{
 			name:   "tc4: producer time < current timeslot",
-			fields: fields{},
+			fields: fields{chain: chain},
 			args: args{
 				bestViewHash:      tc4BestViewHash,
 				bestViewHeight:    tc4BestViewHeight,
@@ -615,7 +675,7 @@ func TestConsensusValidatorLemma2_FilterValidProposeBlockInfo(t *testing.T) {
 		},
 		{
 			name:   "tc5: propose block info height < final view",
-			fields: fields{},
+			fields: fields{chain: chain},
 			args: args{
 				bestViewHash:      tc5BestViewHash,
 				bestViewHeight:    tc5BestViewHeight,
@@ -629,7 +689,7 @@ func TestConsensusValidatorLemma2_FilterValidProposeBlockInfo(t *testing.T) {
 		},
 		{
 			name:   "tc6: add valid propose block info",
-			fields: fields{},
+			fields: fields{chain: chain},
 			args: args{
 				bestViewHash:      tc6BestViewHash,
 				bestViewHeight:    tc6BestViewHeight,
@@ -643,7 +703,7 @@ func TestConsensusValidatorLemma2_FilterValidProposeBlockInfo(t *testing.T) {
 		},
 		{
 			name:   "tc7: re-vote insert block",
-			fields: fields{},
+			fields: fields{chain: chain},
 			args: args{
 				bestViewHash:      tc7BestViewHash,
 				bestViewHeight:    tc7BestViewHeight,
@@ -657,7 +717,7 @@ func TestConsensusValidatorLemma2_FilterValidProposeBlockInfo(t *testing.T) {
 		},
 		{
 			name:   "tc8: lemma2 = true but finality height = 0",
-			fields: fields{},
+			fields: fields{chain: chain},
 			args: args{
 				bestViewHash:      tc8BestViewHash,
 				bestViewHeight:    tc8BestViewHeight,
@@ -671,7 +731,7 @@ func TestConsensusValidatorLemma2_FilterValidProposeBlockInfo(t *testing.T) {
 		},
 		{
 			name:   "tc9: lemma2 = false but finality height != 0",
-			fields: fields{},
+			fields: fields{chain: chain},
 			args: args{
 				bestViewHash:      tc9BestViewHash,
 				bestViewHeight:    tc9BestViewHeight,
@@ -700,6 +700,7 @@ func (a *actorV3) run() error {
 				//set round for monitor
 				round := a.currentTimeSlot - bestView.CalculateTimeSlot(bestView.GetBlock().GetProposeTime())
 				monitor.SetGlobalParam("RoundKey", fmt.Sprintf("%d_%d", bestView.GetHeight(), round))
+				updateRoundMetric(a.chainKey, round)
 
 				if newTimeSlot {
 					a.logger.Info("")
@@ -799,7 +800,7 @@ func (a *actorV3) validateBlock(proposeBlockInfo *ProposeBlockInfo) error {
 	}
 
 	proposeBlockInfo.LastValidateTime = time.Now()
-	err := a.chain.ValidatePreSignBlock(proposeBlockInfo.block, proposeBlockInfo.SigningCommittees, proposeBlockInfo.Committees)
+	err := validatePreSignBlock(a.chain, proposeBlockInfo)
 	if err != nil {
 		return errors.New("Block is invalidated!")
 	}
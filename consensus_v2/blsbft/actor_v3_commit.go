@@ -28,6 +28,8 @@ func (a *actorV3) maybeCommit() {
 					proposeBlockInfo.block.FullHashString(), proposeBlockInfo.ValidVotes, 2*len(proposeBlockInfo.SigningCommittees)/3, len(proposeBlockInfo.SigningCommittees))
 				a.commitBlock(proposeBlockInfo)
 				proposeBlockInfo.IsCommitted = true
+				updateCommitMetric(a.chainKey, proposeBlockInfo)
+				traceCollectVotes(a.chainKey, proposeBlockInfo)
 			}
 		}
 	}
@@ -71,6 +71,10 @@ func (a *actorV3) sendVote(
 				}
 			case "vote":
 				//set isVote = true (lock), so that, if at same block height next time, we dont pre vote for different block hash
+				if !proposeBlockInfo.IsVoted {
+					updateVoteMetric(a.chainKey, proposeBlockInfo)
+					traceVote(a.chainKey, proposeBlockInfo)
+				}
 				proposeBlockInfo.IsVoted = true
 				if err := a.AddReceiveBlockByHash(proposeBlockInfo.block.ProposeHash().String(), proposeBlockInfo); err != nil {
 					return NewConsensusError(UnExpectedError, err)
@@ -130,6 +130,7 @@ func (a *actorV3) maybeProposeBlock() error {
 	if err != nil {
 		a.logger.Error("Send BFT Propose Message Failed", err)
 	}
+	tracePropose(a.chainKey, block, time1, err)
 	return nil
 }
 
@@ -1,9 +1,11 @@
 package blsbft
 
 import (
-	"github.com/incognitochain/incognito-chain/blockchain"
 	"github.com/incognitochain/incognito-chain/blockchain/types"
 	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/consensus_v2/blsbft/mocks"
+	mocksView "github.com/incognitochain/incognito-chain/multiview/mocks"
+	"github.com/stretchr/testify/mock"
 	"testing"
 )
 
@@ -15,11 +17,16 @@ import (
 func TestActorV3_ReproposeBlock(t *testing.T) {
 	backendLog := common.NewBackend(logWriter{})
 	logger := backendLog.Logger("Consensus log", false)
-	chain := blockchain.NewBeaconChain(nil, nil, nil, "beacon")
-	bftactor := NewActorV3WithValue(chain, chain, "beacon", 9, -1, nil, logger)
+	bestViewHash := common.HashH([]byte("best view"))
+	bestView := &mocksView.View{}
+	bestView.On("GetHash").Return(&bestViewHash)
+	bestView.On("CalculateTimeSlot", mock.Anything).Return(calculateTimeSlot)
+	chain := &mocks.Chain{}
+	chain.On("GetBestView").Return(bestView)
+	bftactor := NewActorV3WithValue(chain, &mocks.CommitteeChainHandler{}, "beacon", 9, -1, nil, logger)
 	bftactor.Stop()
 	bftactor.currentBestViewHeight = 1
-	bftactor.currentTimeSlot = common.CalculateTimeSlot(100)
+	bftactor.currentTimeSlot = calculateTimeSlot(100)
 
 	x, _ := InitReceiveBlockByHash(-1)
 	if len(x) != 0 {
@@ -30,7 +37,7 @@ func TestActorV3_ReproposeBlock(t *testing.T) {
 		t.Error("Should not get repropose block if there is no record!")
 	}
 
-	block := &types.BeaconBlock{Header: types.BeaconHeader{Version: 9, Height: 2, Timestamp: 100, ProposeTime: 100}}
+	block := &types.BeaconBlock{Header: types.BeaconHeader{Version: 9, Height: 2, Timestamp: 100, ProposeTime: 100, PreviousBlockHash: bestViewHash}}
 
 	proposeBlockInfo := &ProposeBlockInfo{
 		block:    block,
@@ -49,8 +56,8 @@ func TestActorV3_ReproposeBlock(t *testing.T) {
 		t.Error("Should get repropose block if block is valid!")
 	}
 
-	bftactor.currentTimeSlot = common.CalculateTimeSlot(110)
-	block = &types.BeaconBlock{Header: types.BeaconHeader{Version: 9, Height: 2, Timestamp: 110, ProposeTime: 110}}
+	bftactor.currentTimeSlot = calculateTimeSlot(110)
+	block = &types.BeaconBlock{Header: types.BeaconHeader{Version: 9, Height: 2, Timestamp: 110, ProposeTime: 110, PreviousBlockHash: bestViewHash}}
 	proposeLockBlockInfo := &ProposeBlockInfo{
 		block:    block,
 		Votes:    make(map[string]*BFTVote),
@@ -69,7 +76,7 @@ func TestActorV3_ReproposeBlock(t *testing.T) {
 		t.Error("Locked blockhash should be block that is voted")
 	}
 
-	bftactor.currentTimeSlot = common.CalculateTimeSlot(120)
+	bftactor.currentTimeSlot = calculateTimeSlot(120)
 	block.Header.ProposeTime = 120
 	proposeLockBlockInfo1 := &ProposeBlockInfo{
 		block:    block,
@@ -0,0 +1,22 @@
+package blsbft
+
+import (
+	"fmt"
+
+	"github.com/incognitochain/incognito-chain/metrics"
+)
+
+// updateRoundMetric exports the round of the block being agreed on chainKey, 1 if it is proposed in the time slot right after the best view
+func updateRoundMetric(chainKey string, round int64) {
+	metrics.GetOrRegisterGauge(fmt.Sprintf("consensus/%v/round", chainKey), nil).Update(round)
+}
+
+// updateVoteMetric exports the time between receiving a propose block and voting for it
+func updateVoteMetric(chainKey string, proposeBlockInfo *ProposeBlockInfo) {
+	metrics.GetOrRegisterTimer(fmt.Sprintf("consensus/%v/vote", chainKey), nil).UpdateSince(proposeBlockInfo.ReceiveTime)
+}
+
+// updateCommitMetric exports the time between receiving a propose block and committing it with enough votes
+func updateCommitMetric(chainKey string, proposeBlockInfo *ProposeBlockInfo) {
+	metrics.GetOrRegisterTimer(fmt.Sprintf("consensus/%v/commit", chainKey), nil).UpdateSince(proposeBlockInfo.ReceiveTime)
+}
@@ -0,0 +1,70 @@
+package blsbft
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/incognitochain/incognito-chain/blockchain/types"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/instruction"
+	"github.com/incognitochain/incognito-chain/wire"
+)
+
+// sendSlashEvidences sends the double signs detected in this chain to the beacon committee as slash evidences
+func (a *actorV2) sendSlashEvidences() {
+	if ByzantineDetectorObject == nil {
+		return
+	}
+	beaconChain, ok := a.committeeChain.(common.ChainInterface)
+	if !ok {
+		return
+	}
+	for _, doubleSign := range ByzantineDetectorObject.PopDoubleSigns(a.chainID) {
+		slashEvidenceInstruction, err := a.buildSlashEvidence(doubleSign)
+		if err != nil {
+			a.logger.Errorf("Build slash evidence of validator %+v error %+v", doubleSign.SecondVote.Validator, err)
+			continue
+		}
+		a.logger.Infof("Send slash evidence of validator %+v, blocks %+v and %+v",
+			slashEvidenceInstruction.PublicKey, doubleSign.FirstVote.BlockHash, doubleSign.SecondVote.BlockHash)
+		msg := wire.NewMessageSlashEvidence(slashEvidenceInstruction.ToString())
+		go a.node.PushMessageToChain(msg, beaconChain)
+	}
+}
+
+// buildSlashEvidence builds a slash evidence instruction from the votes of a double sign and the blocks they are for
+func (a *actorV2) buildSlashEvidence(doubleSign *DoubleSign) (*instruction.SlashEvidenceInstruction, error) {
+	publicKey := ""
+	votes := [2]instruction.SlashEvidenceVote{}
+	for i, vote := range []*BFTVote{doubleSign.FirstVote, doubleSign.SecondVote} {
+		proposeBlockInfo, ok := a.GetReceiveBlockByHash(vote.BlockHash)
+		if !ok || proposeBlockInfo.block == nil {
+			return nil, fmt.Errorf("block %+v not found", vote.BlockHash)
+		}
+		shardBlock, ok := proposeBlockInfo.block.(*types.ShardBlock)
+		if !ok {
+			return nil, fmt.Errorf("block %+v is not a shard block", vote.BlockHash)
+		}
+		header, err := json.Marshal(shardBlock.Header)
+		if err != nil {
+			return nil, err
+		}
+		votes[i] = instruction.SlashEvidenceVote{
+			BlockHeader: header,
+			Signature:   vote.BLS,
+		}
+		for _, v := range proposeBlockInfo.SigningCommittees {
+			if v.GetMiningKeyBase58(common.BlsConsensus) == vote.Validator {
+				publicKey, err = v.ToBase58()
+				if err != nil {
+					return nil, err
+				}
+				break
+			}
+		}
+	}
+	if publicKey == "" {
+		return nil, fmt.Errorf("validator %+v not found in signing committees", doubleSign.SecondVote.Validator)
+	}
+	return instruction.NewSlashEvidenceInstructionWithValue(a.chainID, publicKey, votes), nil
+}
@@ -0,0 +1,35 @@
+package blsbft
+
+import (
+	"time"
+
+	"github.com/incognitochain/incognito-chain/blockchain/types"
+	"github.com/incognitochain/incognito-chain/tracing"
+)
+
+// validatePreSignBlock validates a propose block before voting for it, traced as "consensus.validate"
+func validatePreSignBlock(chain Chain, proposeBlockInfo *ProposeBlockInfo) error {
+	span := tracing.StartBlockSpan(*proposeBlockInfo.block.Hash(), "consensus.validate", tracing.Attr("height", proposeBlockInfo.block.GetHeight()))
+	err := chain.ValidatePreSignBlock(proposeBlockInfo.block, proposeBlockInfo.SigningCommittees, proposeBlockInfo.Committees)
+	span.End(err)
+	return err
+}
+
+// tracePropose traces the creation of a block by this node, from start until it is sent
+func tracePropose(chainKey string, block types.BlockInterface, start time.Time, err error) {
+	tracing.RecordBlockSpan(*block.Hash(), "consensus.propose", start, err,
+		tracing.Attr("chain", chainKey), tracing.Attr("height", block.GetHeight()), tracing.Attr("round", block.GetRound()))
+}
+
+// traceVote traces the time between receiving a propose block and voting for it
+func traceVote(chainKey string, proposeBlockInfo *ProposeBlockInfo) {
+	tracing.RecordBlockSpan(*proposeBlockInfo.block.Hash(), "consensus.vote", proposeBlockInfo.ReceiveTime, nil,
+		tracing.Attr("chain", chainKey), tracing.Attr("height", proposeBlockInfo.block.GetHeight()))
+}
+
+// traceCollectVotes traces the time between receiving a propose block and committing it with enough votes
+func traceCollectVotes(chainKey string, proposeBlockInfo *ProposeBlockInfo) {
+	tracing.RecordBlockSpan(*proposeBlockInfo.block.Hash(), "consensus.collectVotes", proposeBlockInfo.ReceiveTime, nil,
+		tracing.Attr("chain", chainKey), tracing.Attr("height", proposeBlockInfo.block.GetHeight()),
+		tracing.Attr("votes", proposeBlockInfo.ValidVotes), tracing.Attr("committee", len(proposeBlockInfo.SigningCommittees)))
+}
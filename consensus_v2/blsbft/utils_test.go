@@ -846,3 +846,8 @@ func (logWriter) Write(p []byte) (n int, err error) {
 	os.Stdout.Write(p)
 	return len(p), nil
 }
+
+// calculateTimeSlot returns the time slot of t for the default block time
+func calculateTimeSlot(t int64) int64 {
+	return t / int64(common.TIMESLOT)
+}
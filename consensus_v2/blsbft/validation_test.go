@@ -29,6 +29,7 @@ func TestValidateCommitteeSig(t *testing.T) {
 	block1 := &mocks.BlockInterface{}
 	block1.On("GetValidationField").Return(valData1).Once()
 	block1.On("Hash").Return(hash1).Once()
+	block1.On("ProposeHash").Return(hash1).Once()
 	type args struct {
 		block     types.BlockInterface
 		committee []incognitokey.CommitteePublicKey
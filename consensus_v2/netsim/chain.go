@@ -0,0 +1,771 @@
+package netsim
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/blockchain/types"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/consensus_v2/blsbft"
+	"github.com/incognitochain/incognito-chain/consensus_v2/consensustypes"
+	"github.com/incognitochain/incognito-chain/incdb"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/multiview"
+	"github.com/incognitochain/incognito-chain/portal/portalv4"
+)
+
+var (
+	_ blsbft.Chain                 = (*Chain)(nil)
+	_ blsbft.CommitteeChainHandler = (*Chain)(nil)
+)
+
+// maxShardStates is the number of shard blocks a beacon block confirms at most for every shard
+const maxShardStates = 10
+
+type blockPusher interface {
+	PushBlockToAll(block types.BlockInterface, previousValidationData string, isBeacon bool) error
+}
+
+// Chain is the beacon chain or a shard chain of a simulated node. Blocks carry no transaction nor
+// instruction and the committees never change. Everything else is checked like the chains of the
+// blockchain package do: signatures, proposers, finality, and the beacon chain confirms the shard
+// blocks, which finalizes them from the instant finality version
+type Chain struct {
+	chainID    int
+	name       string
+	timeSlot   int64
+	committees map[int][]incognitokey.CommitteePublicKey
+	server     blockPusher
+
+	// beacon is the beacon chain of the node, shards are set on the beacon chain only
+	beacon *Chain
+	shards []*Chain
+
+	multiView  multiview.MultiView
+	insertLock sync.Mutex
+
+	// blocks are all the inserted blocks, they survive a restart of the node like its database does
+	blockLock sync.RWMutex
+	blocks    map[common.Hash]types.BlockInterface
+
+	isReady bool
+}
+
+func newChain(chainID int, name string, multiView multiview.MultiView, committees map[int][]incognitokey.CommitteePublicKey, timeSlot int64, server blockPusher) *Chain {
+	return &Chain{
+		chainID:    chainID,
+		name:       name,
+		timeSlot:   timeSlot,
+		committees: committees,
+		server:     server,
+		multiView:  multiView,
+		blocks:     make(map[common.Hash]types.BlockInterface),
+		isReady:    true,
+	}
+}
+
+// NewBeaconChain creates the beacon chain of a node and its shard chains from their genesis blocks
+func NewBeaconChain(
+	genesis *types.BeaconBlock, shardGenesis []*types.ShardBlock,
+	committees map[int][]incognitokey.CommitteePublicKey, timeSlot int64, server blockPusher,
+) *Chain {
+	chain := newChain(common.BeaconChainID, common.BeaconChainKey, multiview.NewBeaconMultiView(), committees, timeSlot, server)
+	chain.beacon = chain
+	genesisView := newView(genesis, committees[common.BeaconChainID], timeSlot)
+	for sid, block := range shardGenesis {
+		genesisView.bestShardHash[byte(sid)] = *block.Hash()
+		genesisView.bestShardHeight[byte(sid)] = block.GetHeight()
+
+		shard := newChain(sid, common.GetShardChainKey(byte(sid)), multiview.NewShardMultiView(), committees, timeSlot, server)
+		shard.beacon = chain
+		shard.addGenesis(block, newView(block, committees[sid], timeSlot))
+		chain.shards = append(chain.shards, shard)
+	}
+	chain.addGenesis(genesis, genesisView)
+	return chain
+}
+
+func (chain *Chain) addGenesis(genesis types.BlockInterface, genesisView *view) {
+	chain.multiView.AddView(genesisView)
+	chain.blocks[*genesis.Hash()] = genesis
+}
+
+// Shards returns the shard chains of the node, on the beacon chain
+func (chain *Chain) Shards() []*Chain {
+	return append([]*Chain{}, chain.shards...)
+}
+
+func (chain *Chain) VerifyFinalityAndReplaceBlockConsensusData(consensusData types.BlockConsensusData) error {
+	return nil
+}
+
+func (chain *Chain) BestViewCommitteeFromBlock() common.Hash {
+	return chain.GetBestView().GetBlock().CommitteeFromBlock()
+}
+
+func (chain *Chain) GetMultiView() multiview.MultiView {
+	return chain.multiView
+}
+
+func (chain *Chain) GetFinalView() multiview.View {
+	return chain.multiView.GetFinalView()
+}
+
+func (chain *Chain) GetBestView() multiview.View {
+	return chain.multiView.GetBestView()
+}
+
+func (chain *Chain) GetEpoch() uint64 {
+	return 1
+}
+
+func (chain *Chain) GetChainName() string {
+	return chain.name
+}
+
+func (chain *Chain) GetConsensusType() string {
+	return common.BlsConsensus
+}
+
+// GetBlockConsensusData returns no data, the actors only replace the consensus data of the chains
+// of the blockchain package
+func (chain *Chain) GetBlockConsensusData() map[int]types.BlockConsensusData {
+	return nil
+}
+
+func (chain *Chain) GetLastBlockTimeStamp() int64 {
+	return chain.GetBestView().GetBlock().GetProduceTime()
+}
+
+func (chain *Chain) GetMinBlkInterval() time.Duration {
+	return time.Duration(chain.timeSlot) * time.Second
+}
+
+func (chain *Chain) GetMaxBlkCreateTime() time.Duration {
+	return time.Duration(chain.timeSlot) * time.Second
+}
+
+func (chain *Chain) IsReady() bool {
+	return chain.isReady
+}
+
+func (chain *Chain) SetReady(ready bool) {
+	chain.isReady = ready
+}
+
+func (chain *Chain) GetActiveShardNumber() int {
+	return len(chain.beacon.shards)
+}
+
+func (chain *Chain) CurrentHeight() uint64 {
+	return chain.GetBestView().GetHeight()
+}
+
+func (chain *Chain) GetCommitteeSize() int {
+	return len(chain.committees[chain.chainID])
+}
+
+func (chain *Chain) IsBeaconChain() bool {
+	return chain.chainID == common.BeaconChainID
+}
+
+func (chain *Chain) GetCommittee() []incognitokey.CommitteePublicKey {
+	return append([]incognitokey.CommitteePublicKey{}, chain.committees[chain.chainID]...)
+}
+
+func (chain *Chain) GetPendingCommittee() []incognitokey.CommitteePublicKey {
+	return []incognitokey.CommitteePublicKey{}
+}
+
+func (chain *Chain) GetPubKeyCommitteeIndex(pubkey string) int {
+	for index, key := range chain.committees[chain.chainID] {
+		if key.GetMiningKeyBase58(common.BlsConsensus) == pubkey {
+			return index
+		}
+	}
+	return -1
+}
+
+func (chain *Chain) GetLastProposerIndex() int {
+	bestView := chain.GetBestView()
+	_, id := bestView.GetProposerByTimeSlot(bestView.CalculateTimeSlot(bestView.GetBlock().GetProposeTime()), 0)
+	return id
+}
+
+func (chain *Chain) UnmarshalBlock(blockString []byte) (types.BlockInterface, error) {
+	var block types.BlockInterface = types.NewShardBlock()
+	if chain.IsBeaconChain() {
+		block = types.NewBeaconBlock()
+	}
+	if err := json.Unmarshal(blockString, block); err != nil {
+		return nil, err
+	}
+	return block, nil
+}
+
+func (chain *Chain) CreateNewBlock(
+	version int, proposer string, round int, startTime int64,
+	committees []incognitokey.CommitteePublicKey,
+	committeeViewHash common.Hash,
+) (types.BlockInterface, error) {
+	return chain.createBlock(chain.GetBestView().(*view), version, proposer, round, startTime, committeeViewHash)
+}
+
+func (chain *Chain) createBlock(preView *view, version int, proposer string, round int, startTime int64, committeeViewHash common.Hash) (types.BlockInterface, error) {
+	if chain.IsBeaconChain() {
+		return chain.newBeaconBlock(preView, version, proposer, round, startTime), nil
+	}
+	return chain.newShardBlock(preView, version, proposer, round, startTime, committeeViewHash)
+}
+
+// newBeaconBlock creates a beacon block on top of a view, it confirms the shard blocks which follow the
+// ones confirmed by the view on the best chains of the shards
+func (chain *Chain) newBeaconBlock(preView *view, version int, proposer string, round int, startTime int64) *types.BeaconBlock {
+	newBlock := types.NewBeaconBlock()
+	newBlock.Header = types.NewBeaconHeader(
+		version,
+		preView.GetHeight()+1,
+		chain.GetEpoch(),
+		round,
+		startTime,
+		*preView.GetHash(),
+		common.BlsConsensus,
+		proposer,
+		proposer,
+	)
+	newBlock.Header.Proposer = proposer
+	newBlock.Header.ProposeTime = startTime
+	newBlock.Body.ShardState = make(map[byte][]types.ShardState)
+	for sid, shard := range chain.shards {
+		states := shard.newShardStates(preView.bestShardHash[byte(sid)], preView.bestShardHeight[byte(sid)])
+		if len(states) > 0 {
+			newBlock.Body.ShardState[byte(sid)] = states
+		}
+	}
+	newBlock.Header.ShardStateHash = hashShardStates(newBlock.Body.ShardState)
+
+	// same rule as the beacon chain: the block is proposed by its producer the timeslot
+	// after the previous block
+	if preView.CalculateTimeSlot(preView.GetBlock().GetProposeTime())+1 == preView.CalculateTimeSlot(startTime) {
+		newBlock.Header.FinalityHeight = finalityHeight(newBlock, preView)
+	}
+	return newBlock
+}
+
+// newShardBlock creates a shard block on top of a view, which must follow the shard block confirmed by
+// the final beacon view like the shard producer requires
+func (chain *Chain) newShardBlock(preView *view, version int, proposer string, round int, startTime int64, committeeViewHash common.Hash) (*types.ShardBlock, error) {
+	beaconView := chain.beacon.GetFinalView().(*view)
+	confirmedHash := beaconView.bestShardHash[byte(chain.chainID)]
+	if hash, ok := chain.getBlockHashFrom(preView, beaconView.bestShardHeight[byte(chain.chainID)]); !ok || hash != confirmedHash {
+		return nil, fmt.Errorf("Shard %v | Best view %v is not on the branch of the confirmed block %v",
+			chain.chainID, preView.GetHash().String(), confirmedHash.String())
+	}
+	newBlock := types.NewShardBlock()
+	newBlock.Header = types.ShardHeader{
+		Producer:           proposer,
+		ShardID:            byte(chain.chainID),
+		Version:            version,
+		PreviousBlockHash:  *preView.GetHash(),
+		Height:             preView.GetHeight() + 1,
+		Round:              round,
+		Epoch:              chain.GetEpoch(),
+		BeaconHeight:       beaconView.GetHeight(),
+		BeaconHash:         *beaconView.GetHash(),
+		ConsensusType:      common.BlsConsensus,
+		Timestamp:          startTime,
+		Proposer:           proposer,
+		ProposeTime:        startTime,
+		CommitteeFromBlock: committeeViewHash,
+		CommitteeRoot:      hashCommittee(preView.committee),
+		TotalTxsFee:        make(map[common.Hash]uint64),
+	}
+	if preView.CalculateTimeSlot(preView.GetBlock().GetProposeTime())+1 == preView.CalculateTimeSlot(startTime) {
+		newBlock.Header.FinalityHeight = finalityHeight(newBlock, preView)
+	}
+	return newBlock, nil
+}
+
+// finalityHeight returns the finality height of a block proposed the timeslot after its previous block
+func finalityHeight(block types.BlockInterface, preView multiview.View) uint64 {
+	version := block.GetVersion()
+	if version < types.LEMMA2_VERSION {
+		return 0
+	}
+	if version < types.INSTANT_FINALITY_VERSION {
+		return block.GetHeight() - 1
+	}
+	previousBlock := preView.GetBlock()
+	previousProposeTimeSlot := preView.CalculateTimeSlot(previousBlock.GetProposeTime())
+	previousProduceTimeSlot := preView.CalculateTimeSlot(previousBlock.GetProduceTime())
+	if previousBlock.GetFinalityHeight() != 0 || previousProduceTimeSlot == previousProposeTimeSlot {
+		return block.GetHeight()
+	}
+	return 0
+}
+
+func (chain *Chain) CreateNewBlockFromOldBlock(oldBlock types.BlockInterface, proposer string, startTime int64, isValidRePropose bool) (types.BlockInterface, error) {
+	newBlock := cloneBlock(oldBlock)
+	var finality uint64
+	if isValidRePropose {
+		preView := chain.GetViewByHash(newBlock.GetPrevHash())
+		if preView == nil {
+			return nil, fmt.Errorf("Cannot find previous view %v", newBlock.GetPrevHash().String())
+		}
+		finality = finalityHeight(newBlock, preView)
+	}
+	switch block := newBlock.(type) {
+	case *types.BeaconBlock:
+		block.Header.Proposer = proposer
+		block.Header.ProposeTime = startTime
+		block.Header.FinalityHeight = finality
+	case *types.ShardBlock:
+		block.Header.Proposer = proposer
+		block.Header.ProposeTime = startTime
+		block.Header.FinalityHeight = finality
+	}
+	return newBlock, nil
+}
+
+// InsertBlock adds a block on top of one of the views, the multiview then picks the best view
+// and finalizes the views like it does for the chains of the node
+func (chain *Chain) InsertBlock(block types.BlockInterface, shouldValidate bool) error {
+	if block.GetShardID() != chain.chainID {
+		return fmt.Errorf("Expect block of chain %v, got %v", chain.chainID, block.GetShardID())
+	}
+	chain.insertLock.Lock()
+	defer chain.insertLock.Unlock()
+
+	if chain.multiView.GetViewByHash(*block.Hash()) != nil {
+		return nil
+	}
+	preView, ok := chain.multiView.GetViewByHash(block.GetPrevHash()).(*view)
+	if !ok {
+		return fmt.Errorf("Block %v of chain %v link to wrong view (%s)", block.GetHeight(), chain.chainID, block.GetPrevHash().String())
+	}
+	if block.GetHeight() != preView.GetHeight()+1 {
+		return errors.New("Not expected height")
+	}
+	if shouldValidate {
+		if err := chain.ValidateBlockSignatures(block, chain.signingCommittee(block, preView)); err != nil {
+			return err
+		}
+	}
+
+	blockView := newView(block, preView.committee, chain.timeSlot)
+	if beaconBlock, ok := block.(*types.BeaconBlock); ok {
+		if err := chain.checkShardStates(beaconBlock, preView, false); err != nil {
+			return err
+		}
+		blockView = newBeaconView(beaconBlock, preView)
+	}
+	chain.blockLock.Lock()
+	chain.blocks[*block.Hash()] = block
+	chain.blockLock.Unlock()
+	if _, err := chain.multiView.AddView(blockView); err != nil {
+		return err
+	}
+
+	if chain.IsBeaconChain() {
+		finalView := chain.GetFinalView().(*view)
+		for sid, shard := range chain.shards {
+			shard.confirm(finalView.bestShardHash[byte(sid)])
+		}
+	} else {
+		chain.confirmLocked(chain.beacon.GetFinalView().(*view).bestShardHash[byte(chain.chainID)])
+	}
+	return nil
+}
+
+// signingCommittee returns the validators who sign a block
+func (chain *Chain) signingCommittee(block types.BlockInterface, preView multiview.View) []incognitokey.CommitteePublicKey {
+	committee := chain.committees[chain.chainID]
+	_, proposerIndex := chain.GetProposerByTimeSlotFromCommitteeList(preView.CalculateTimeSlot(block.GetProposeTime()), committee)
+	return chain.GetSigningCommittees(proposerIndex, committee, block.GetVersion())
+}
+
+// confirm finalizes a shard block confirmed by the final beacon view, if it is on the best chain of the shard
+func (chain *Chain) confirm(hash common.Hash) {
+	chain.insertLock.Lock()
+	defer chain.insertLock.Unlock()
+	chain.confirmLocked(hash)
+}
+
+func (chain *Chain) confirmLocked(hash common.Hash) {
+	confirmedView := chain.multiView.GetViewByHash(hash)
+	if confirmedView == nil {
+		return
+	}
+	// the multiview can only finalize a view on the branch of its best view
+	if bestHash, ok := chain.getBlockHashFrom(chain.GetBestView(), confirmedView.GetHeight()); !ok || bestHash != hash {
+		return
+	}
+	chain.multiView.FinalizeView(hash)
+}
+
+// newShardStates returns the states of the blocks which follow a confirmed block on the best chain of a shard
+func (chain *Chain) newShardStates(confirmedHash common.Hash, confirmedHeight uint64) []types.ShardState {
+	blocks := chain.getBranchBlocks(chain.GetBestView(), confirmedHash, confirmedHeight)
+	if len(blocks) > maxShardStates {
+		blocks = blocks[:maxShardStates]
+	}
+	states := []types.ShardState{}
+	for _, block := range blocks {
+		shardBlock := block.(*types.ShardBlock)
+		states = append(states, types.ShardState{
+			ValidationData:     shardBlock.ValidationData,
+			CommitteeFromBlock: shardBlock.Header.CommitteeFromBlock,
+			Height:             shardBlock.GetHeight(),
+			Hash:               *shardBlock.Hash(),
+			ProposerTime:       shardBlock.GetProposeTime(),
+			Version:            shardBlock.GetVersion(),
+		})
+	}
+	return states
+}
+
+// checkShardStates checks that the shard states of a beacon block follow the shard blocks confirmed by
+// the previous view. Validators also require the shard blocks, the blocks synced from peers are
+// already signed by the beacon committee
+func (chain *Chain) checkShardStates(block *types.BeaconBlock, preView *view, requireBlocks bool) error {
+	if hashShardStates(block.Body.ShardState) != block.Header.ShardStateHash {
+		return errors.New("Shard state hash is not correct")
+	}
+	for sid, states := range block.Body.ShardState {
+		if int(sid) >= len(chain.shards) {
+			return fmt.Errorf("Shard %v does not exist", sid)
+		}
+		hash, height := preView.bestShardHash[sid], preView.bestShardHeight[sid]
+		for _, state := range states {
+			if state.Height != height+1 {
+				return fmt.Errorf("Shard %v | Expect shard state of height %v, got %v", sid, height+1, state.Height)
+			}
+			if requireBlocks {
+				shardBlock, err := chain.shards[sid].GetBlockByHash(state.Hash)
+				if err != nil {
+					return err
+				}
+				if shardBlock.GetPrevHash() != hash {
+					return fmt.Errorf("Shard %v | Block %v does not follow %v", sid, state.Hash.String(), hash.String())
+				}
+			}
+			hash, height = state.Hash, state.Height
+		}
+	}
+	return nil
+}
+
+func (chain *Chain) InsertAndBroadcastBlock(block types.BlockInterface) error {
+	go chain.server.PushBlockToAll(block, "", chain.IsBeaconChain())
+	return chain.InsertBlock(block, true)
+}
+
+func (chain *Chain) InsertWithPrevValidationData(block types.BlockInterface, newValidationData string) error {
+	if chain.IsBeaconChain() {
+		return errors.New("this function is not supported on beacon chain")
+	}
+	if newValidationData != "" {
+		linkView := chain.GetViewByHash(block.GetPrevHash())
+		if linkView == nil {
+			return errors.New("InsertWithPrevValidationData fail! Cannot find previous block hash" + block.GetPrevHash().String())
+		}
+		if err := chain.ReplacePreviousValidationData(block.GetPrevHash(), *linkView.GetBlock().ProposeHash(), newValidationData); err != nil {
+			return err
+		}
+	}
+	return chain.InsertBlock(block, true)
+}
+
+func (chain *Chain) InsertAndBroadcastBlockWithPrevValidationData(block types.BlockInterface, newValidationData string) error {
+	if chain.IsBeaconChain() {
+		return chain.InsertAndBroadcastBlock(block)
+	}
+	go chain.server.PushBlockToAll(block, newValidationData, false)
+	return chain.InsertWithPrevValidationData(block, newValidationData)
+}
+
+func (chain *Chain) ValidateBlockSignatures(block types.BlockInterface, committees []incognitokey.CommitteePublicKey) error {
+	if err := blsbft.ValidateProducerSigV2(block); err != nil {
+		return err
+	}
+	return blsbft.ValidateCommitteeSig(block, committees)
+}
+
+// ValidatePreSignBlock checks what a validator checks before voting: the block is proposed on a known
+// view, by the proposer of its timeslot, and signed by this proposer. The beacon blocks it refers to,
+// or the shard blocks it confirms, must be known too
+func (chain *Chain) ValidatePreSignBlock(block types.BlockInterface, signingCommittees, committees []incognitokey.CommitteePublicKey) error {
+	preView, ok := chain.GetViewByHash(block.GetPrevHash()).(*view)
+	if !ok {
+		return fmt.Errorf("Cannot find previous view %v", block.GetPrevHash().String())
+	}
+	if block.GetHeight() != preView.GetHeight()+1 {
+		return errors.New("Not expected height")
+	}
+	proposer, _ := chain.GetProposerByTimeSlotFromCommitteeList(preView.CalculateTimeSlot(block.GetProposeTime()), committees)
+	proposerStr, _ := proposer.ToBase58()
+	if proposerStr != block.GetProposer() {
+		return fmt.Errorf("Expect proposer %v, got %v", proposerStr, block.GetProposer())
+	}
+	if err := blsbft.ValidateProducerSigV2(block); err != nil {
+		return err
+	}
+
+	switch block := block.(type) {
+	case *types.BeaconBlock:
+		return chain.checkShardStates(block, preView, true)
+	case *types.ShardBlock:
+		if _, err := chain.beacon.GetBlockByHash(block.Header.BeaconHash); err != nil {
+			return err
+		}
+		if block.Header.BeaconHeight < preView.GetBeaconHeight() {
+			return fmt.Errorf("Beacon height %v is lower than the one of the previous block %v", block.Header.BeaconHeight, preView.GetBeaconHeight())
+		}
+	}
+	return nil
+}
+
+func (chain *Chain) GetShardID() int {
+	return chain.chainID
+}
+
+func (chain *Chain) GetChainDatabase() incdb.Database {
+	return nil
+}
+
+func (chain *Chain) GetBestViewHeight() uint64 {
+	return chain.GetBestView().GetHeight()
+}
+
+func (chain *Chain) GetFinalViewHeight() uint64 {
+	return chain.GetFinalView().GetHeight()
+}
+
+func (chain *Chain) GetBestViewHash() string {
+	return chain.GetBestView().GetHash().String()
+}
+
+func (chain *Chain) GetFinalViewHash() string {
+	return chain.GetFinalView().GetHash().String()
+}
+
+func (chain *Chain) GetViewByHash(hash common.Hash) multiview.View {
+	v := chain.multiView.GetViewByHash(hash)
+	if v == nil {
+		return nil
+	}
+	return v
+}
+
+func (chain *Chain) CommitteeEngineVersion() int {
+	return 0
+}
+
+func (chain *Chain) GetProposerByTimeSlotFromCommitteeList(ts int64, committees []incognitokey.CommitteePublicKey) (incognitokey.CommitteePublicKey, int) {
+	return blockchain.GetProposer(ts, committees, len(committees))
+}
+
+// ReplacePreviousValidationData replaces the validation data of a shard block by one with more signatures
+func (chain *Chain) ReplacePreviousValidationData(previousBlockHash common.Hash, previousProposeHash common.Hash, newValidationData string) error {
+	if chain.IsBeaconChain() {
+		return errors.New("this function is not supported on beacon chain")
+	}
+	chain.insertLock.Lock()
+	defer chain.insertLock.Unlock()
+	previousBlock, err := chain.GetBlockByHash(previousBlockHash)
+	if err != nil {
+		// the block is not inserted yet, no need to replace
+		return nil
+	}
+	if !previousProposeHash.IsEqual(previousBlock.ProposeHash()) {
+		return nil
+	}
+	oldValidationData, err := consensustypes.DecodeValidationData(previousBlock.GetValidationField())
+	if err != nil {
+		return err
+	}
+	validationData, err := consensustypes.DecodeValidationData(newValidationData)
+	if err != nil {
+		return err
+	}
+	preView := chain.GetViewByHash(previousBlock.GetPrevHash())
+	if len(validationData.ValidatiorsIdx) <= len(oldValidationData.ValidatiorsIdx) || preView == nil {
+		return nil
+	}
+
+	shardBlock := cloneBlock(previousBlock).(*types.ShardBlock)
+	shardBlock.ValidationData = newValidationData
+	if err := chain.ValidateBlockSignatures(shardBlock, chain.signingCommittee(shardBlock, preView)); err != nil {
+		return err
+	}
+	chain.blockLock.Lock()
+	chain.blocks[previousBlockHash] = shardBlock
+	chain.blockLock.Unlock()
+	if v := chain.multiView.GetViewByHash(previousBlockHash); v != nil {
+		v.ReplaceBlock(shardBlock)
+	}
+	return nil
+}
+
+func (chain *Chain) GetSigningCommittees(
+	proposerIndex int,
+	committees []incognitokey.CommitteePublicKey,
+	blockVersion int,
+) []incognitokey.CommitteePublicKey {
+	if !chain.IsBeaconChain() && blockVersion >= types.BLOCK_PRODUCINGV3_VERSION && blockVersion < types.INSTANT_FINALITY_VERSION_V2 {
+		return blockchain.FilterSigningCommitteeV3(committees, proposerIndex)
+	}
+	return append([]incognitokey.CommitteePublicKey{}, committees...)
+}
+
+func (chain *Chain) GetPortalParamsV4(beaconHeight uint64) portalv4.PortalParams {
+	return portalv4.PortalParams{}
+}
+
+func (chain *Chain) GetBlockByHash(hash common.Hash) (types.BlockInterface, error) {
+	chain.blockLock.RLock()
+	defer chain.blockLock.RUnlock()
+	block, ok := chain.blocks[hash]
+	if !ok {
+		return nil, fmt.Errorf("Cannot find block %v of chain %v", hash.String(), chain.chainID)
+	}
+	return block, nil
+}
+
+// CommitteesFromViewHashForShard returns the committee of a shard from a beacon block, the committees
+// never change but the block must be known
+func (chain *Chain) CommitteesFromViewHashForShard(committeeHash common.Hash, shardID byte) ([]incognitokey.CommitteePublicKey, error) {
+	if _, err := chain.beacon.GetBlockByHash(committeeHash); err != nil {
+		return nil, err
+	}
+	committee, ok := chain.committees[int(shardID)]
+	if !ok {
+		return nil, fmt.Errorf("Shard %v does not exist", shardID)
+	}
+	return append([]incognitokey.CommitteePublicKey{}, committee...), nil
+}
+
+func (chain *Chain) FinalView() multiview.View {
+	return chain.GetFinalView()
+}
+
+// GetFinalizedBlockHash returns the hash of the finalized block at a height
+func (chain *Chain) GetFinalizedBlockHash(height uint64) (common.Hash, bool) {
+	return chain.getBlockHashFrom(chain.GetFinalView(), height)
+}
+
+// GetBestBlockHash returns the hash of the block at a height on the best chain
+func (chain *Chain) GetBestBlockHash(height uint64) (common.Hash, bool) {
+	return chain.getBlockHashFrom(chain.GetBestView(), height)
+}
+
+func (chain *Chain) getBlockHashFrom(view multiview.View, height uint64) (common.Hash, bool) {
+	if view == nil || height > view.GetHeight() {
+		return common.Hash{}, false
+	}
+	chain.blockLock.RLock()
+	defer chain.blockLock.RUnlock()
+	hash := *view.GetHash()
+	for {
+		block, ok := chain.blocks[hash]
+		if !ok {
+			return common.Hash{}, false
+		}
+		if block.GetHeight() == height {
+			return hash, true
+		}
+		hash = block.GetPrevHash()
+	}
+}
+
+// getBranchBlocks returns the blocks from the one after a block to a view, if the view is on its branch
+func (chain *Chain) getBranchBlocks(to multiview.View, fromHash common.Hash, fromHeight uint64) []types.BlockInterface {
+	chain.blockLock.RLock()
+	defer chain.blockLock.RUnlock()
+	res := []types.BlockInterface{}
+	hash := *to.GetHash()
+	for {
+		block, ok := chain.blocks[hash]
+		if !ok || block.GetHeight() < fromHeight {
+			return nil
+		}
+		if block.GetHeight() == fromHeight {
+			if hash != fromHash {
+				return nil
+			}
+			return res
+		}
+		res = append([]types.BlockInterface{block}, res...)
+		hash = block.GetPrevHash()
+	}
+}
+
+// getBestChainBlocks returns copies of the blocks of the best chain from a height to another
+func (chain *Chain) getBestChainBlocks(from, to uint64) []types.BlockInterface {
+	bestView := chain.GetBestView()
+	if to > bestView.GetHeight() {
+		to = bestView.GetHeight()
+	}
+	res := []types.BlockInterface{}
+	if from > to {
+		return res
+	}
+	chain.blockLock.RLock()
+	defer chain.blockLock.RUnlock()
+	hash := *bestView.GetHash()
+	for {
+		block, ok := chain.blocks[hash]
+		if !ok || block.GetHeight() < from {
+			break
+		}
+		if block.GetHeight() <= to {
+			res = append([]types.BlockInterface{cloneBlock(block)}, res...)
+		}
+		hash = block.GetPrevHash()
+	}
+	return res
+}
+
+func (chain *Chain) getBlocksByHash(hashes [][]byte) []types.BlockInterface {
+	chain.blockLock.RLock()
+	defer chain.blockLock.RUnlock()
+	res := []types.BlockInterface{}
+	for _, b := range hashes {
+		hash, err := common.Hash{}.NewHash(b)
+		if err != nil {
+			continue
+		}
+		if block, ok := chain.blocks[*hash]; ok {
+			res = append(res, cloneBlock(block))
+		}
+	}
+	return res
+}
+
+// hashShardStates stands in for the shard state hash of the beacon header
+func hashShardStates(states map[byte][]types.ShardState) common.Hash {
+	b, _ := json.Marshal(states)
+	return common.HashH(b)
+}
+
+// hashCommittee stands in for the committee root of the shard header
+func hashCommittee(committee []incognitokey.CommitteePublicKey) common.Hash {
+	keys, _ := incognitokey.CommitteeKeyListToString(committee)
+	b, _ := json.Marshal(keys)
+	return common.HashH(b)
+}
+
+// cloneBlock copies a block like the wire does, receivers may change the consensus fields of their block
+func cloneBlock(block types.BlockInterface) types.BlockInterface {
+	b, _ := json.Marshal(block)
+	var newBlock types.BlockInterface = types.NewShardBlock()
+	if _, ok := block.(*types.BeaconBlock); ok {
+		newBlock = types.NewBeaconBlock()
+	}
+	json.Unmarshal(b, newBlock)
+	return newBlock
+}
@@ -0,0 +1,209 @@
+package netsim
+
+import (
+	"testing"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/blockchain/types"
+	"github.com/incognitochain/incognito-chain/common"
+)
+
+const beacon = common.BeaconChainID
+
+func newTestSimulator(t *testing.T, cfg Config) *Simulator {
+	if testing.Short() {
+		t.Skip("consensus simulation runs on the wall clock")
+	}
+	cfg.Seed = 1
+	sim, err := NewSimulator(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sim.Network.SetDelay(10*time.Millisecond, 50*time.Millisecond)
+	return sim
+}
+
+func startTestSimulator(t *testing.T, cfg Config) *Simulator {
+	sim := newTestSimulator(t, cfg)
+	if err := sim.Start(); err != nil {
+		sim.Close()
+		t.Fatal(err)
+	}
+	return sim
+}
+
+func closeTestSimulator(t *testing.T, sim *Simulator) {
+	if err := sim.CheckSafety(); err != nil {
+		t.Error(err)
+	}
+	sim.Close()
+}
+
+func waitFinalHeight(t *testing.T, sim *Simulator, chainID int, height uint64, timeout time.Duration, indexes ...int) {
+	if !sim.WaitFor(timeout, func() bool { return sim.MinFinalHeight(chainID, indexes...) >= height }) {
+		t.Fatalf("Final heights %v of chain %v did not reach %v", sim.FinalHeights(chainID), chainID, height)
+	}
+}
+
+func shardConfig(blockVersion int, timeSlot int64) Config {
+	return Config{
+		Validators:      4,
+		Shards:          2,
+		ShardValidators: 4,
+		FullNodes:       1,
+		BlockVersion:    blockVersion,
+		TimeSlot:        timeSlot,
+	}
+}
+
+func beaconConfig() Config {
+	return Config{
+		Validators:   4,
+		BlockVersion: types.LEMMA2_VERSION,
+		TimeSlot:     1,
+	}
+}
+
+func TestLivenessActorV2(t *testing.T) {
+	sim := startTestSimulator(t, shardConfig(types.LEMMA2_VERSION, 1))
+	defer closeTestSimulator(t, sim)
+	for _, chainID := range []int{beacon, 0, 1} {
+		waitFinalHeight(t, sim, chainID, 5, 60*time.Second)
+	}
+}
+
+// TestLivenessActorV3 runs actor v3 on the beacon chain and actor v2 on the shards, which are
+// finalized when the beacon chain confirms them
+func TestLivenessActorV3(t *testing.T) {
+	sim := startTestSimulator(t, shardConfig(types.INSTANT_FINALITY_VERSION_V2, 2))
+	defer closeTestSimulator(t, sim)
+	for _, chainID := range []int{beacon, 0, 1} {
+		waitFinalHeight(t, sim, chainID, 5, 90*time.Second)
+	}
+}
+
+func TestPartitionAndHeal(t *testing.T) {
+	sim := startTestSimulator(t, beaconConfig())
+	defer closeTestSimulator(t, sim)
+	waitFinalHeight(t, sim, beacon, 3, 30*time.Second)
+
+	// no half of the committee can gather enough votes
+	sim.Network.Partition(sim.NodeIDs(0, 1), sim.NodeIDs(2, 3))
+	time.Sleep(time.Second)
+	stuck := sim.FinalHeights(beacon)
+	time.Sleep(5 * time.Second)
+	for i, height := range sim.FinalHeights(beacon) {
+		if height > stuck[i]+1 {
+			t.Fatalf("Node %v finalized from %v to %v during the partition", i, stuck[i], height)
+		}
+	}
+	if err := sim.CheckSafety(); err != nil {
+		t.Fatal(err)
+	}
+
+	sim.Network.Heal()
+	waitFinalHeight(t, sim, beacon, stuck[0]+3, 30*time.Second)
+}
+
+func TestCrashAndRestart(t *testing.T) {
+	sim := startTestSimulator(t, beaconConfig())
+	defer closeTestSimulator(t, sim)
+	waitFinalHeight(t, sim, beacon, 3, 30*time.Second)
+
+	if err := sim.Crash(3); err != nil {
+		t.Fatal(err)
+	}
+	crashedHeight := sim.Node(3).Chain(beacon).GetFinalViewHeight()
+	// three validators out of four are enough to finalize
+	waitFinalHeight(t, sim, beacon, crashedHeight+4, 30*time.Second, 0, 1, 2)
+	if sim.Node(3).Chain(beacon).GetFinalViewHeight() != crashedHeight {
+		t.Fatal("Crashed node kept inserting blocks")
+	}
+
+	if err := sim.Restart(3); err != nil {
+		t.Fatal(err)
+	}
+	waitFinalHeight(t, sim, beacon, sim.MinFinalHeight(beacon, 0, 1, 2)+2, 30*time.Second)
+}
+
+func TestLivenessWithDroppedMessages(t *testing.T) {
+	sim := startTestSimulator(t, beaconConfig())
+	defer closeTestSimulator(t, sim)
+	sim.Network.SetDropRate(0.2)
+	waitFinalHeight(t, sim, beacon, 5, 60*time.Second)
+	sent, dropped := sim.Network.Stats()
+	if dropped == 0 || dropped == sent {
+		t.Fatalf("Expect some of the %v messages to be dropped, got %v", sent, dropped)
+	}
+}
+
+// TestForkChoice inserts two competing branches in different orders on the nodes: they pick the
+// block with the smaller produce time at the same height, then switch to the longer branch and
+// finalize it once it has blocks in consecutive timeslots
+func TestForkChoice(t *testing.T) {
+	sim := newTestSimulator(t, beaconConfig())
+	defer closeTestSimulator(t, sim)
+	g := sim.GenesisTimeSlot()
+	genesisHash := *sim.Node(0).Chain(beacon).GetBestView().GetHash()
+	voters := []int{0, 1, 2, 3}
+	buildBlock := func(prevHash common.Hash, timeSlot int64) types.BlockInterface {
+		block, err := sim.BuildBlock(beacon, prevHash, timeSlot, voters)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return block
+	}
+	insert := func(i int, blocks ...types.BlockInterface) {
+		for _, block := range blocks {
+			if err := sim.Node(i).Chain(beacon).InsertBlock(cloneBlock(block), true); err != nil {
+				t.Fatalf("Node %v cannot insert block %v: %v", i, block.Hash().String(), err)
+			}
+		}
+	}
+	checkViews := func(best, final types.BlockInterface) {
+		for i, node := range sim.Nodes() {
+			chain := node.Chain(beacon)
+			if chain.GetBestViewHash() != best.Hash().String() {
+				t.Fatalf("Node %v | Expect best view %v, got %v", i, best.Hash().String(), chain.GetBestViewHash())
+			}
+			if chain.GetFinalViewHash() != final.Hash().String() {
+				t.Fatalf("Node %v | Expect final view %v, got %v", i, final.Hash().String(), chain.GetFinalViewHash())
+			}
+		}
+	}
+	genesis, err := sim.Node(0).Chain(beacon).GetBlockByHash(genesisHash)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	a1 := buildBlock(genesisHash, g+1)
+	b1 := buildBlock(genesisHash, g+2)
+	insert(0, a1, b1)
+	insert(1, a1, b1)
+	insert(2, b1, a1)
+	insert(3, b1, a1)
+	checkViews(a1, genesis)
+
+	b2 := buildBlock(*b1.Hash(), g+3)
+	for i := range sim.Nodes() {
+		insert(i, b2)
+	}
+	checkViews(b2, b1)
+
+	// a block of the same height produced later does not take over the best view
+	a2 := buildBlock(*a1.Hash(), g+4)
+	for i := range sim.Nodes() {
+		insert(i, a2)
+	}
+	checkViews(b2, b1)
+
+	if err := sim.Start(); err != nil {
+		t.Fatal(err)
+	}
+	waitFinalHeight(t, sim, beacon, 5, 30*time.Second)
+	for i, node := range sim.Nodes() {
+		if hash, ok := node.Chain(beacon).GetFinalizedBlockHash(2); !ok || hash != *b1.Hash() {
+			t.Fatalf("Node %v finalized %v at height 2, expect %v", i, hash.String(), b1.Hash().String())
+		}
+	}
+}
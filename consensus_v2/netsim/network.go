@@ -0,0 +1,162 @@
+// Package netsim runs a network of nodes in one process to test the consensus actors.
+//
+// Every node follows the beacon chain and all the shard chains, and the validators run a real blsbft
+// actor (v2 or v3, chosen by the block version) for the committee they are in; the other nodes are
+// full nodes. The simulated chains keep their views in a real multiview, so the fork choice and the
+// finality rules are the ones of the node, and the beacon chain confirms the shard blocks. The rest of
+// the node is replaced by stand-ins: the blocks carry no transaction nor instruction, so there is no
+// state, no cross shard block and no snap sync, and the committees never change.
+// Nodes talk through an in-memory network which implements the publish functions of the peerv2
+// connection manager and syncker.Network. The syncker itself needs the blockchain package, so a node
+// publishes its peer state every timeslot and pulls the chains of the peers which are ahead instead.
+// The network can partition the nodes, delay and drop messages, and nodes can be crashed and
+// restarted. The fault decisions are drawn from a seeded source, so a scenario replays the same
+// faults for the same seed, while the actors still run on the wall clock.
+package netsim
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// Network delivers the messages between the nodes of a simulation
+type Network struct {
+	lock  sync.Mutex
+	rnd   *rand.Rand
+	nodes map[string]*Node
+	order []string
+
+	// group is the partition of every node, nodes of different groups can not reach each other
+	group    map[string]int
+	dropRate float64
+	minDelay time.Duration
+	maxDelay time.Duration
+
+	sent    uint64
+	dropped uint64
+}
+
+func NewNetwork(seed int64) *Network {
+	return &Network{
+		rnd:   rand.New(rand.NewSource(seed)),
+		nodes: make(map[string]*Node),
+		group: make(map[string]int),
+	}
+}
+
+func (n *Network) addNode(node *Node) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	n.nodes[node.ID] = node
+	n.order = append(n.order, node.ID)
+}
+
+func (n *Network) getNode(id string) *Node {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	return n.nodes[id]
+}
+
+// getNodeByPeerID finds a node from the peer id the actors put in their messages
+func (n *Network) getNodeByPeerID(peerID string) *Node {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	for _, node := range n.nodes {
+		if node.ID == peerID || node.GetSelfPeerID().String() == peerID {
+			return node
+		}
+	}
+	return nil
+}
+
+// peers returns the other nodes in the order they joined the network
+func (n *Network) peers(self string) []*Node {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	res := []*Node{}
+	for _, id := range n.order {
+		if id != self {
+			res = append(res, n.nodes[id])
+		}
+	}
+	return res
+}
+
+// SetDropRate sets the probability for every message to be lost, from 0 to 1
+func (n *Network) SetDropRate(rate float64) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	n.dropRate = rate
+}
+
+// SetDelay sets the range of the delay of every message
+func (n *Network) SetDelay(min, max time.Duration) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	if max < min {
+		max = min
+	}
+	n.minDelay, n.maxDelay = min, max
+}
+
+// Partition splits the network into groups of nodes which can only reach the nodes of their group.
+// Nodes which are not listed form one more group
+func (n *Network) Partition(groups ...[]string) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	n.group = make(map[string]int)
+	for i, ids := range groups {
+		for _, id := range ids {
+			n.group[id] = i + 1
+		}
+	}
+}
+
+// Heal removes the partitions
+func (n *Network) Heal() {
+	n.Partition()
+}
+
+// Reachable returns whether messages from a node can reach another one, apart from random drops
+func (n *Network) Reachable(from, to string) bool {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	return n.group[from] == n.group[to]
+}
+
+// Stats returns the number of messages sent and the number of them which were dropped
+func (n *Network) Stats() (sent uint64, dropped uint64) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	return n.sent, n.dropped
+}
+
+// route decides the fate of one message, it returns its delay and false if it is dropped
+func (n *Network) route(from, to string) (time.Duration, bool) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	n.sent++
+	if n.group[from] != n.group[to] || (n.dropRate > 0 && n.rnd.Float64() < n.dropRate) {
+		n.dropped++
+		return 0, false
+	}
+	delay := n.minDelay
+	if n.maxDelay > n.minDelay {
+		delay += time.Duration(n.rnd.Int63n(int64(n.maxDelay - n.minDelay)))
+	}
+	return delay, true
+}
+
+// send delivers a message to a node after the delay of the link, if the link does not drop it
+func (n *Network) send(from string, to *Node, deliver func()) {
+	delay, ok := n.route(from, to.ID)
+	if !ok {
+		return
+	}
+	if delay == 0 {
+		go deliver()
+		return
+	}
+	time.AfterFunc(delay, deliver)
+}
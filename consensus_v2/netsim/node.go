@@ -0,0 +1,478 @@
+package netsim
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/blockchain/types"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/consensus_v2/blsbft"
+	signatureschemes2 "github.com/incognitochain/incognito-chain/consensus_v2/signatureschemes"
+	"github.com/incognitochain/incognito-chain/syncker"
+	"github.com/incognitochain/incognito-chain/wire"
+	peer "github.com/libp2p/go-libp2p-peer"
+)
+
+var (
+	_ blsbft.NodeInterface = (*Node)(nil)
+	_ syncker.Network      = (*Node)(nil)
+)
+
+const (
+	inboxSize     = 1024
+	syncBatchSize = 50
+	// peerStateTTL is the number of timeslots a node syncs from a peer after its last peer state
+	peerStateTTL = 3
+	// noCommittee is the chain id of the nodes which are in no committee
+	noCommittee = -2
+)
+
+var errCrossShardNotSimulated = errors.New("the simulated blocks carry no transaction, there is no cross shard block")
+var errSnapSyncNotSimulated = errors.New("the simulated chains keep no state trie, snap sync is not simulated")
+
+type peerState struct {
+	state      *wire.MessagePeerState
+	receivedAt time.Time
+}
+
+// Node is one node of the simulation: its beacon and shard chains, the consensus actor of the
+// committee it is in, if any, and its link to the network
+type Node struct {
+	ID string
+
+	net          *Network
+	miningKey    signatureschemes2.MiningKey
+	chainID      int
+	beacon       *Chain
+	blockVersion int
+	logger       common.Logger
+
+	lock       sync.RWMutex
+	actor      blsbft.Actor
+	crashed    bool
+	inbox      chan *wire.MessageBFT
+	stopCh     chan struct{}
+	syncMode   string
+	peerStates map[string]peerState
+}
+
+func newNode(id string, net *Network, miningKey signatureschemes2.MiningKey, chainID int, blockVersion int, logger common.Logger) *Node {
+	return &Node{
+		ID:           id,
+		net:          net,
+		miningKey:    miningKey,
+		chainID:      chainID,
+		blockVersion: blockVersion,
+		logger:       logger,
+		crashed:      true,
+	}
+}
+
+// ChainID returns the chain of the committee the node is in, or -2 for a full node
+func (n *Node) ChainID() int {
+	return n.chainID
+}
+
+// IsValidator returns whether the node is in a committee and runs a consensus actor
+func (n *Node) IsValidator() bool {
+	return n.chainID != noCommittee
+}
+
+// Chain returns the beacon chain of the node for common.BeaconChainID, or one of its shard chains
+func (n *Node) Chain(chainID int) *Chain {
+	if chainID == common.BeaconChainID {
+		return n.beacon
+	}
+	if chainID < 0 || chainID >= len(n.beacon.shards) {
+		return nil
+	}
+	return n.beacon.shards[chainID]
+}
+
+// chains returns the beacon chain then the shard chains of the node
+func (n *Node) chains() []*Chain {
+	return append([]*Chain{n.beacon}, n.beacon.shards...)
+}
+
+// IsCrashed returns whether the node is down
+func (n *Node) IsCrashed() bool {
+	n.lock.RLock()
+	defer n.lock.RUnlock()
+	return n.crashed
+}
+
+// Start connects the node to the network, and creates a new consensus actor for its committee
+func (n *Node) Start() error {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	if !n.crashed {
+		return fmt.Errorf("Node %v is already running", n.ID)
+	}
+	n.actor = nil
+	if n.IsValidator() {
+		chainKey := common.BeaconChainKey
+		if n.chainID != common.BeaconChainID {
+			chainKey = common.GetShardChainKey(byte(n.chainID))
+		}
+		n.actor = blsbft.NewActorWithValue(
+			n.Chain(n.chainID), n.beacon,
+			n.blockVersion, n.blockVersion,
+			n.chainID, chainKey, n, n.logger)
+		n.actor.LoadUserKeys([]signatureschemes2.MiningKey{n.miningKey})
+		if err := n.actor.Start(); err != nil {
+			return err
+		}
+	}
+	n.crashed = false
+	n.inbox = make(chan *wire.MessageBFT, inboxSize)
+	n.stopCh = make(chan struct{})
+	n.peerStates = make(map[string]peerState)
+	if n.actor != nil {
+		go n.processInbox(n.actor, n.inbox, n.stopCh)
+	}
+	go n.syncLoop(n.stopCh)
+	return nil
+}
+
+// Crash disconnects the node and stops its actor, everything the actor kept in memory is lost.
+// The actor is not destroyed: Destroy closes the channels the actor may still be sending to,
+// so the stopped actor is left idle instead
+func (n *Node) Crash() error {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	if n.crashed {
+		return fmt.Errorf("Node %v is not running", n.ID)
+	}
+	n.crashed = true
+	close(n.stopCh)
+	if n.actor == nil {
+		return nil
+	}
+	return n.actor.Stop()
+}
+
+// Restart brings a crashed node back with a new actor, which reloads its history from the consensus
+// database, and the blocks the node had inserted before the crash
+func (n *Node) Restart() error {
+	return n.Start()
+}
+
+func (n *Node) processInbox(actor blsbft.Actor, inbox chan *wire.MessageBFT, stopCh chan struct{}) {
+	for {
+		select {
+		case <-stopCh:
+			return
+		case msg := <-inbox:
+			actor.ProcessBFTMsg(msg)
+		}
+	}
+}
+
+// syncLoop stands in for the syncker: every timeslot the node publishes its peer state, and pulls the
+// chains of the peers whose peer state shows a better chain
+func (n *Node) syncLoop(stopCh chan struct{}) {
+	ticker := time.NewTicker(n.beacon.GetMinBlkInterval())
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stopCh:
+			return
+		case <-ticker.C:
+		}
+		n.PublishMessage(n.peerState())
+		for peerID, state := range n.freshPeerStates() {
+			n.syncChain(peerID, n.beacon, state.Beacon)
+			for sid, shard := range n.beacon.shards {
+				if shardState, ok := state.Shards[byte(sid)]; ok {
+					n.syncChain(peerID, shard, shardState)
+				}
+			}
+		}
+	}
+}
+
+func (n *Node) peerState() *wire.MessagePeerState {
+	chainState := func(chain *Chain) wire.ChainState {
+		bestView := chain.GetBestView()
+		return wire.ChainState{
+			Timestamp: bestView.GetBlock().GetProduceTime(),
+			Height:    bestView.GetHeight(),
+			BlockHash: *bestView.GetHash(),
+		}
+	}
+	msg := &wire.MessagePeerState{
+		Beacon:    chainState(n.beacon),
+		Shards:    make(map[byte]wire.ChainState),
+		Timestamp: time.Now().Unix(),
+		SenderID:  n.GetSelfPeerID().String(),
+	}
+	for sid, shard := range n.beacon.shards {
+		msg.Shards[byte(sid)] = chainState(shard)
+	}
+	return msg
+}
+
+// freshPeerStates returns the last peer state of the peers heard from in the last timeslots
+func (n *Node) freshPeerStates() map[string]*wire.MessagePeerState {
+	n.lock.RLock()
+	defer n.lock.RUnlock()
+	res := make(map[string]*wire.MessagePeerState)
+	for peerID, ps := range n.peerStates {
+		if time.Since(ps.receivedAt) < peerStateTTL*n.beacon.GetMinBlkInterval() {
+			res[peerID] = ps.state
+		}
+	}
+	return res
+}
+
+// syncChain pulls the blocks after the final view of a chain from a peer with a better chain
+func (n *Node) syncChain(peerID string, chain *Chain, state wire.ChainState) {
+	bestView := chain.GetBestView()
+	if state.Height < bestView.GetHeight() || (state.Height == bestView.GetHeight() && state.BlockHash == *bestView.GetHash()) {
+		return
+	}
+	from := chain.GetFinalViewHeight() + 1
+	to := state.Height
+	if to > from+syncBatchSize {
+		to = from + syncBatchSize
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), chain.GetMinBlkInterval())
+	defer cancel()
+	var blockCh chan types.BlockInterface
+	var err error
+	if chain.IsBeaconChain() {
+		blockCh, err = n.RequestBeaconBlocksViaStream(ctx, peerID, from, to)
+	} else {
+		blockCh, err = n.RequestShardBlocksViaStream(ctx, peerID, chain.GetShardID(), from, to)
+	}
+	if err != nil {
+		n.logger.Debugf("Cannot sync chain %v from %v: %v", chain.GetShardID(), peerID, err)
+		return
+	}
+	n.insertBlocks(chain, blockCh)
+}
+
+func (n *Node) insertBlocks(chain *Chain, blockCh chan types.BlockInterface) {
+	for block := range blockCh {
+		if err := chain.InsertBlock(block, true); err != nil {
+			n.logger.Debugf("Cannot insert block %v of chain %v: %v", block.GetHeight(), chain.GetShardID(), err)
+			return
+		}
+	}
+}
+
+// receive handles a message published on the network
+func (n *Node) receive(msg wire.Message) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	if n.crashed {
+		return
+	}
+	switch msg := msg.(type) {
+	case *wire.MessageBFT:
+		if n.actor == nil || msg.ChainKey != n.actor.GetChainKey() {
+			return
+		}
+		select {
+		case n.inbox <- msg:
+		default:
+			n.logger.Debugf("Inbox is full, drop %v message", msg.Type)
+		}
+	case *wire.MessagePeerState:
+		if msg.SenderID != n.GetSelfPeerID().String() {
+			n.peerStates[msg.SenderID] = peerState{state: msg, receivedAt: time.Now()}
+		}
+	case *wire.MessageBlockBeacon:
+		go func(block types.BlockInterface) {
+			if err := n.beacon.InsertBlock(block, true); err != nil {
+				n.logger.Debugf("Cannot insert beacon block %v: %v", block.GetHeight(), err)
+			}
+		}(cloneBlock(msg.Block))
+	case *wire.MessageBlockShard:
+		chain := n.Chain(int(msg.Block.GetShardID()))
+		if chain == nil {
+			return
+		}
+		go func(block types.BlockInterface, previousValidationData string) {
+			if err := chain.InsertWithPrevValidationData(block, previousValidationData); err != nil {
+				n.logger.Debugf("Cannot insert shard block %v: %v", block.GetHeight(), err)
+			}
+		}(cloneBlock(msg.Block), msg.PreviousValidationData)
+	}
+}
+
+// PublishMessage sends a message to all the nodes, like the peerv2 connection manager does.
+// The pubsub delivers a message to its publisher too and the actors count their own vote this way
+func (n *Node) PublishMessage(msg wire.Message) error {
+	if n.IsCrashed() {
+		return fmt.Errorf("Node %v is not running", n.ID)
+	}
+	go n.receive(msg)
+	for _, p := range n.net.peers(n.ID) {
+		p := p
+		n.net.send(n.ID, p, func() { p.receive(msg) })
+	}
+	return nil
+}
+
+// PublishMessageToShard sends a message to all the nodes, every node of the simulation follows every shard
+func (n *Node) PublishMessageToShard(msg wire.Message, shardID byte) error {
+	return n.PublishMessage(msg)
+}
+
+func (n *Node) PushMessageToChain(msg wire.Message, chain common.ChainInterface) error {
+	return n.PublishMessage(msg)
+}
+
+func (n *Node) PushBlockToAll(block types.BlockInterface, previousValidationData string, isBeacon bool) error {
+	switch block := cloneBlock(block).(type) {
+	case *types.BeaconBlock:
+		return n.PublishMessage(&wire.MessageBlockBeacon{Block: block})
+	case *types.ShardBlock:
+		return n.PublishMessage(&wire.MessageBlockShard{Block: block, PreviousValidationData: previousValidationData})
+	}
+	return fmt.Errorf("Unknown block type %T", block)
+}
+
+func (n *Node) IsEnableMining() bool {
+	return n.IsValidator()
+}
+
+func (n *Node) GetMiningKeys() string {
+	return ""
+}
+
+func (n *Node) GetPrivateKey() string {
+	return ""
+}
+
+func (n *Node) GetUserMiningState() (role string, chainID int) {
+	if !n.IsValidator() {
+		return "", noCommittee
+	}
+	return common.CommitteeRole, n.chainID
+}
+
+func (n *Node) GetSelfPeerID() peer.ID {
+	return peer.ID(n.ID)
+}
+
+// RequestMissingViewViaStream fetches blocks by hash from a peer, or from every peer if none is given
+func (n *Node) RequestMissingViewViaStream(peerID string, hashes [][]byte, fromCID int, chainName string) error {
+	chain := n.Chain(fromCID)
+	if chain == nil {
+		return fmt.Errorf("Chain %v does not exist", fromCID)
+	}
+	peerIDs := []string{peerID}
+	if peerID == "" {
+		peerIDs = []string{}
+		for _, p := range n.net.peers(n.ID) {
+			peerIDs = append(peerIDs, p.GetSelfPeerID().String())
+		}
+	}
+	for _, peerID := range peerIDs {
+		ctx, cancel := context.WithTimeout(context.Background(), chain.GetMinBlkInterval())
+		var blockCh chan types.BlockInterface
+		var err error
+		if chain.IsBeaconChain() {
+			blockCh, err = n.RequestBeaconBlocksByHashViaStream(ctx, peerID, hashes)
+		} else {
+			blockCh, err = n.RequestShardBlocksByHashViaStream(ctx, peerID, fromCID, hashes)
+		}
+		if err != nil {
+			cancel()
+			continue
+		}
+		go func() {
+			defer cancel()
+			n.insertBlocks(chain, blockCh)
+		}()
+	}
+	return nil
+}
+
+// stream opens a stream to a peer: the blocks are read when the request reaches the peer and
+// arrive after the delay of the link
+func (n *Node) stream(ctx context.Context, peerID string, read func(p *Node) []types.BlockInterface) (chan types.BlockInterface, error) {
+	p := n.net.getNodeByPeerID(peerID)
+	if p == nil {
+		return nil, fmt.Errorf("Cannot find peer %v", peerID)
+	}
+	delay, ok := n.net.route(n.ID, p.ID)
+	if !ok || p.IsCrashed() {
+		return nil, fmt.Errorf("Cannot reach peer %v", peerID)
+	}
+	blocks := read(p)
+	blockCh := make(chan types.BlockInterface, len(blocks))
+	go func() {
+		defer close(blockCh)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+		for _, block := range blocks {
+			blockCh <- block
+		}
+	}()
+	return blockCh, nil
+}
+
+// shardStream opens a stream to read the blocks of a shard chain of a peer
+func (n *Node) shardStream(ctx context.Context, peerID string, sid int, read func(chain *Chain) []types.BlockInterface) (chan types.BlockInterface, error) {
+	if n.Chain(sid) == nil || sid == common.BeaconChainID {
+		return nil, fmt.Errorf("Shard %v does not exist", sid)
+	}
+	return n.stream(ctx, peerID, func(p *Node) []types.BlockInterface {
+		return read(p.Chain(sid))
+	})
+}
+
+func (n *Node) RequestBeaconBlocksViaStream(ctx context.Context, peerID string, from uint64, to uint64) (chan types.BlockInterface, error) {
+	return n.stream(ctx, peerID, func(p *Node) []types.BlockInterface {
+		return p.beacon.getBestChainBlocks(from, to)
+	})
+}
+
+func (n *Node) RequestBeaconBlocksByHashViaStream(ctx context.Context, peerID string, hashes [][]byte) (chan types.BlockInterface, error) {
+	return n.stream(ctx, peerID, func(p *Node) []types.BlockInterface {
+		return p.beacon.getBlocksByHash(hashes)
+	})
+}
+
+func (n *Node) RequestShardBlocksViaStream(ctx context.Context, peerID string, fromSID int, from uint64, to uint64) (chan types.BlockInterface, error) {
+	return n.shardStream(ctx, peerID, fromSID, func(chain *Chain) []types.BlockInterface {
+		return chain.getBestChainBlocks(from, to)
+	})
+}
+
+func (n *Node) RequestShardBlocksByHashViaStream(ctx context.Context, peerID string, fromSID int, hashes [][]byte) (chan types.BlockInterface, error) {
+	return n.shardStream(ctx, peerID, fromSID, func(chain *Chain) []types.BlockInterface {
+		return chain.getBlocksByHash(hashes)
+	})
+}
+
+func (n *Node) RequestCrossShardBlocksViaStream(ctx context.Context, peerID string, fromSID int, toSID int, heights []uint64) (chan types.BlockInterface, error) {
+	return nil, errCrossShardNotSimulated
+}
+
+func (n *Node) RequestCrossShardBlocksByHashViaStream(ctx context.Context, peerID string, fromSID int, toSID int, hashes [][]byte) (chan types.BlockInterface, error) {
+	return nil, errCrossShardNotSimulated
+}
+
+func (n *Node) RequestFinalViewViaStream(ctx context.Context, peerID string, fromSID int) (chan []byte, error) {
+	return nil, errSnapSyncNotSimulated
+}
+
+func (n *Node) RequestStateNodesViaStream(ctx context.Context, peerID string, fromSID int, hashes [][]byte) (chan []byte, error) {
+	return nil, errSnapSyncNotSimulated
+}
+
+func (n *Node) SetSyncMode(mode string) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	n.syncMode = mode
+}
@@ -0,0 +1,383 @@
+package netsim
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"os"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/blockchain/types"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/common/base58"
+	"github.com/incognitochain/incognito-chain/config"
+	consensus "github.com/incognitochain/incognito-chain/consensus_v2"
+	"github.com/incognitochain/incognito-chain/consensus_v2/blsbft"
+	"github.com/incognitochain/incognito-chain/consensus_v2/consensustypes"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdb_consensus"
+	"github.com/incognitochain/incognito-chain/incdb"
+	_ "github.com/incognitochain/incognito-chain/incdb/lvdb"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/portal/portalv4"
+)
+
+const (
+	defaultTimeSlot = 2
+	// genesisAge is the number of timeslots between the genesis blocks and the start of the simulation,
+	// scenarios can build blocks in these timeslots before they start the nodes
+	genesisAge = 10
+)
+
+// Config describes a simulation
+type Config struct {
+	// Validators is the size of the beacon committee, every validator runs on its own node
+	Validators int
+	// Shards is the number of shard chains
+	Shards int
+	// ShardValidators is the size of the committee of every shard
+	ShardValidators int
+	// FullNodes is the number of nodes which are in no committee and only sync the chains
+	FullNodes int
+	// BlockVersion selects the actors: from types.MULTI_VIEW_VERSION the nodes run actor v2,
+	// from types.INSTANT_FINALITY_VERSION_V2 the beacon validators run actor v3
+	BlockVersion int
+	// TimeSlot is the length of a timeslot in seconds
+	TimeSlot int64
+	// Seed derives the mining keys and the fault decisions of the network
+	Seed int64
+	// LogWriter receives the logs of the actors, they are discarded if it is nil
+	LogWriter io.Writer
+}
+
+// Simulator runs the nodes of a simulation.
+// The actors keep their propose and vote history in the global consensus database, and the shard
+// actors share the global byzantine detector, so only one simulator can run at a time in a process
+// and the nodes share this database
+type Simulator struct {
+	Network *Network
+
+	cfg             Config
+	nodes           []*Node
+	committees      map[int][]incognitokey.CommitteePublicKey
+	genesisTimeSlot int64
+	dbDir           string
+	db              incdb.Database
+}
+
+func NewSimulator(cfg Config) (*Simulator, error) {
+	if cfg.Validators <= 0 {
+		return nil, errors.New("Simulation needs at least one beacon validator")
+	}
+	if cfg.Shards < 0 || (cfg.Shards > 0 && cfg.ShardValidators <= 0) {
+		return nil, errors.New("Every shard needs at least one validator")
+	}
+	if cfg.BlockVersion < types.MULTI_VIEW_VERSION {
+		return nil, fmt.Errorf("Block version %v is not supported", cfg.BlockVersion)
+	}
+	if cfg.TimeSlot <= 0 {
+		cfg.TimeSlot = defaultTimeSlot
+	}
+
+	common.TIMESLOT = uint64(cfg.TimeSlot)
+	if config.Param() == nil {
+		config.AbortParam()
+	}
+	// every node of the simulation votes on the same blocks, the process-wide detector would take
+	// their votes for double votes
+	config.Param().ConsensusParam.ByzantineDetectorHeight = math.MaxUint64
+	dbDir, err := ioutil.TempDir("", "netsim")
+	if err != nil {
+		return nil, err
+	}
+	db, err := incdb.Open("leveldb", dbDir)
+	if err != nil {
+		os.RemoveAll(dbDir)
+		return nil, err
+	}
+	rawdb_consensus.SetConsensusDatabase(db)
+
+	sim := &Simulator{
+		Network:         NewNetwork(cfg.Seed),
+		cfg:             cfg,
+		committees:      make(map[int][]incognitokey.CommitteePublicKey),
+		genesisTimeSlot: time.Now().Unix()/cfg.TimeSlot - genesisAge,
+		dbDir:           dbDir,
+		db:              db,
+	}
+	disableLog := cfg.LogWriter == nil
+	if disableLog {
+		cfg.LogWriter = ioutil.Discard
+	}
+	backend := common.NewBackend(cfg.LogWriter)
+	blsbft.ByzantineDetectorObject = blsbft.NewByzantineDetector(backend.Logger("byzantine", disableLog))
+
+	addNode := func(chainID int) error {
+		i := len(sim.nodes)
+		seed := common.HashB([]byte(fmt.Sprintf("netsim-%v-%v", cfg.Seed, i)))
+		miningKey, err := consensus.GetMiningKeyFromPrivateSeed(base58.Base58Check{}.Encode(seed, common.ZeroByte))
+		if err != nil {
+			return err
+		}
+		id := fmt.Sprintf("node-%v", i)
+		node := newNode(id, sim.Network, *miningKey, chainID, cfg.BlockVersion, backend.Logger(id, disableLog))
+		sim.nodes = append(sim.nodes, node)
+		if chainID != noCommittee {
+			sim.committees[chainID] = append(sim.committees[chainID], *miningKey.GetPublicKey())
+		}
+		sim.Network.addNode(node)
+		return nil
+	}
+	chainIDs := []int{}
+	for i := 0; i < cfg.Validators; i++ {
+		chainIDs = append(chainIDs, common.BeaconChainID)
+	}
+	for sid := 0; sid < cfg.Shards; sid++ {
+		for i := 0; i < cfg.ShardValidators; i++ {
+			chainIDs = append(chainIDs, sid)
+		}
+	}
+	for i := 0; i < cfg.FullNodes; i++ {
+		chainIDs = append(chainIDs, noCommittee)
+	}
+	for _, chainID := range chainIDs {
+		if err := addNode(chainID); err != nil {
+			sim.Close()
+			return nil, err
+		}
+	}
+
+	genesis, shardGenesis := sim.genesisBlocks()
+	for _, node := range sim.nodes {
+		blocks := []*types.ShardBlock{}
+		for _, block := range shardGenesis {
+			blocks = append(blocks, cloneBlock(block).(*types.ShardBlock))
+		}
+		node.beacon = NewBeaconChain(cloneBlock(genesis).(*types.BeaconBlock), blocks, sim.committees, cfg.TimeSlot, node)
+	}
+	return sim, nil
+}
+
+// genesisBlocks are proposed by the first validator of every committee some timeslots ago
+func (sim *Simulator) genesisBlocks() (*types.BeaconBlock, []*types.ShardBlock) {
+	startTime := sim.GenesisTimeSlot() * sim.cfg.TimeSlot
+	proposer, _ := sim.committees[common.BeaconChainID][0].ToBase58()
+	genesis := types.NewBeaconBlock()
+	genesis.Header = types.NewBeaconHeader(
+		sim.cfg.BlockVersion, 1, 1, 1, startTime, common.Hash{}, common.BlsConsensus, proposer, proposer)
+	genesis.Header.Proposer = proposer
+	genesis.Header.ProposeTime = startTime
+
+	shardGenesis := []*types.ShardBlock{}
+	for sid := 0; sid < sim.cfg.Shards; sid++ {
+		proposer, _ := sim.committees[sid][0].ToBase58()
+		block := types.NewShardBlock()
+		block.Header = types.ShardHeader{
+			Producer:      proposer,
+			ShardID:       byte(sid),
+			Version:       sim.cfg.BlockVersion,
+			Height:        1,
+			Round:         1,
+			Epoch:         1,
+			BeaconHeight:  1,
+			BeaconHash:    *genesis.Hash(),
+			ConsensusType: common.BlsConsensus,
+			Timestamp:     startTime,
+			Proposer:      proposer,
+			ProposeTime:   startTime,
+			TotalTxsFee:   make(map[common.Hash]uint64),
+		}
+		shardGenesis = append(shardGenesis, block)
+	}
+	return genesis, shardGenesis
+}
+
+// GenesisTimeSlot returns the timeslot of the genesis blocks
+func (sim *Simulator) GenesisTimeSlot() int64 {
+	return sim.genesisTimeSlot
+}
+
+// Start starts all the nodes
+func (sim *Simulator) Start() error {
+	for _, node := range sim.nodes {
+		if err := node.Start(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close stops the nodes which are still running and removes the consensus database.
+// A stopped actor may finish its current round, so the closed database stays the global one
+// until the next simulator replaces it
+func (sim *Simulator) Close() error {
+	for _, node := range sim.nodes {
+		if !node.IsCrashed() {
+			node.Crash()
+		}
+	}
+	err := sim.db.Close()
+	os.RemoveAll(sim.dbDir)
+	return err
+}
+
+// Nodes returns the beacon validators, then the validators of every shard, then the full nodes
+func (sim *Simulator) Nodes() []*Node {
+	return append([]*Node{}, sim.nodes...)
+}
+
+func (sim *Simulator) Node(i int) *Node {
+	return sim.nodes[i]
+}
+
+// Committee returns the indexes of the nodes in the committee of a chain, in the committee order
+func (sim *Simulator) Committee(chainID int) []int {
+	res := []int{}
+	for i, node := range sim.nodes {
+		if node.chainID == chainID {
+			res = append(res, i)
+		}
+	}
+	return res
+}
+
+// NodeIDs returns the ids of some nodes, to build the groups of a partition
+func (sim *Simulator) NodeIDs(indexes ...int) []string {
+	res := []string{}
+	for _, i := range indexes {
+		res = append(res, sim.nodes[i].ID)
+	}
+	return res
+}
+
+func (sim *Simulator) Crash(i int) error {
+	return sim.nodes[i].Crash()
+}
+
+func (sim *Simulator) Restart(i int) error {
+	return sim.nodes[i].Restart()
+}
+
+// FinalHeights returns the final view height of a chain on every node
+func (sim *Simulator) FinalHeights(chainID int) []uint64 {
+	res := []uint64{}
+	for _, node := range sim.nodes {
+		res = append(res, node.Chain(chainID).GetFinalViewHeight())
+	}
+	return res
+}
+
+// MinFinalHeight returns the lowest final view height of a chain on the nodes selected by indexes, or on all nodes
+func (sim *Simulator) MinFinalHeight(chainID int, indexes ...int) uint64 {
+	if len(indexes) == 0 {
+		for i := range sim.nodes {
+			indexes = append(indexes, i)
+		}
+	}
+	var res uint64
+	for j, i := range indexes {
+		height := sim.nodes[i].Chain(chainID).GetFinalViewHeight()
+		if j == 0 || height < res {
+			res = height
+		}
+	}
+	return res
+}
+
+// WaitFor polls a condition every 100 milliseconds until it holds, it returns false on timeout
+func (sim *Simulator) WaitFor(timeout time.Duration, cond func() bool) bool {
+	deadline := time.Now().Add(timeout)
+	for {
+		if cond() {
+			return true
+		}
+		if time.Now().After(deadline) {
+			return false
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
+// BuildBlock creates a block of a chain on top of a view, proposed in a timeslot by the proposer of this
+// timeslot and signed by some validators, given by their index in the committee. The view must be known
+// by the proposer. Scenarios insert these blocks on the nodes to set up forks
+func (sim *Simulator) BuildBlock(chainID int, prevHash common.Hash, timeSlot int64, voters []int) (types.BlockInterface, error) {
+	committee := sim.committees[chainID]
+	if len(committee) == 0 {
+		return nil, fmt.Errorf("Chain %v does not exist", chainID)
+	}
+	proposerKey, proposerIndex := sim.nodes[0].Chain(chainID).GetProposerByTimeSlotFromCommitteeList(timeSlot, committee)
+	proposer := sim.nodes[sim.Committee(chainID)[proposerIndex]]
+	chain := proposer.Chain(chainID)
+	preView, ok := chain.GetViewByHash(prevHash).(*view)
+	if !ok {
+		return nil, fmt.Errorf("Cannot find view %v", prevHash.String())
+	}
+	proposerStr, _ := proposerKey.ToBase58()
+	startTime := timeSlot * sim.cfg.TimeSlot
+	block, err := chain.createBlock(preView, sim.cfg.BlockVersion, proposerStr, 1, startTime, *chain.beacon.GetFinalView().GetHash())
+	if err != nil {
+		return nil, err
+	}
+
+	var validationData consensustypes.ValidationData
+	validationData.ProducerBLSSig, _ = proposer.miningKey.BriSignData(block.ProposeHash().GetBytes())
+	signingCommittee := chain.signingCommittee(block, preView)
+	votes := make(map[string]*blsbft.BFTVote)
+	for _, i := range voters {
+		miningKey := sim.nodes[sim.Committee(chainID)[i]].miningKey
+		vote, err := blsbft.CreateVote(chain, &miningKey, block, signingCommittee, portalv4.PortalParams{})
+		if err != nil {
+			return nil, err
+		}
+		vote.IsValid = 1
+		votes[vote.Validator] = vote
+	}
+	committeeBLSString, _ := incognitokey.ExtractPublickeysFromCommitteeKeyList(signingCommittee, common.BlsConsensus)
+	validationData.AggSig, validationData.BridgeSig, validationData.ValidatiorsIdx, validationData.PortalSig, err = blsbft.CombineVotes(votes, committeeBLSString)
+	if err != nil {
+		return nil, err
+	}
+	validationDataString, _ := consensustypes.EncodeValidationData(validationData)
+	block.(blsbft.BlockValidation).AddValidationField(validationDataString)
+	return block, nil
+}
+
+// CheckSafety verifies that the nodes never finalized different blocks at the same height of a chain
+func (sim *Simulator) CheckSafety() error {
+	for chainID := common.BeaconChainID; chainID < sim.cfg.Shards; chainID++ {
+		if err := sim.checkSafety(chainID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (sim *Simulator) checkSafety(chainID int) error {
+	maxHeight := uint64(0)
+	for _, height := range sim.FinalHeights(chainID) {
+		if height > maxHeight {
+			maxHeight = height
+		}
+	}
+	for height := uint64(1); height <= maxHeight; height++ {
+		var finalized common.Hash
+		finalizedBy := ""
+		for _, node := range sim.nodes {
+			hash, ok := node.Chain(chainID).GetFinalizedBlockHash(height)
+			if !ok {
+				continue
+			}
+			if finalizedBy == "" {
+				finalized, finalizedBy = hash, node.ID
+				continue
+			}
+			if !hash.IsEqual(&finalized) {
+				return fmt.Errorf("Chain %v | Height %v is finalized as %v by %v and as %v by %v",
+					chainID, height, finalized.String(), finalizedBy, hash.String(), node.ID)
+			}
+		}
+	}
+	return nil
+}
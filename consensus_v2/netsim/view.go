@@ -0,0 +1,109 @@
+package netsim
+
+import (
+	"github.com/incognitochain/incognito-chain/blockchain/types"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/incdb"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/multiview"
+)
+
+// view is the state of a simulated chain after a block. The committees never change, so the state
+// of a shard is only its block, and the state of the beacon chain is its block and the last shard
+// blocks it confirms
+type view struct {
+	block     types.BlockInterface
+	committee []incognitokey.CommitteePublicKey
+	timeSlot  int64
+
+	bestShardHash   map[byte]common.Hash
+	bestShardHeight map[byte]uint64
+}
+
+func newView(block types.BlockInterface, committee []incognitokey.CommitteePublicKey, timeSlot int64) *view {
+	return &view{
+		block:           block,
+		committee:       committee,
+		timeSlot:        timeSlot,
+		bestShardHash:   make(map[byte]common.Hash),
+		bestShardHeight: make(map[byte]uint64),
+	}
+}
+
+// newBeaconView applies the shard states of a beacon block to the view of its previous block
+func newBeaconView(block *types.BeaconBlock, preView *view) *view {
+	v := newView(block, preView.committee, preView.timeSlot)
+	for sid, hash := range preView.bestShardHash {
+		v.bestShardHash[sid] = hash
+		v.bestShardHeight[sid] = preView.bestShardHeight[sid]
+	}
+	for sid, states := range block.Body.ShardState {
+		if len(states) == 0 {
+			continue
+		}
+		v.bestShardHash[sid] = states[len(states)-1].Hash
+		v.bestShardHeight[sid] = states[len(states)-1].Height
+	}
+	return v
+}
+
+func (v *view) CalculateTimeSlot(t int64) int64 {
+	return t / v.timeSlot
+}
+
+func (v *view) GetCurrentTimeSlot() int64 {
+	return v.timeSlot
+}
+
+func (v *view) GetHash() *common.Hash {
+	return v.block.Hash()
+}
+
+func (v *view) GetPreviousHash() *common.Hash {
+	hash := v.block.GetPrevHash()
+	return &hash
+}
+
+func (v *view) GetHeight() uint64 {
+	return v.block.GetHeight()
+}
+
+func (v *view) GetCommittee() []incognitokey.CommitteePublicKey {
+	return append([]incognitokey.CommitteePublicKey{}, v.committee...)
+}
+
+func (v *view) GetPreviousBlockCommittee(db incdb.Database) ([]incognitokey.CommitteePublicKey, error) {
+	return v.GetCommittee(), nil
+}
+
+func (v *view) CommitteeStateVersion() int {
+	return 0
+}
+
+func (v *view) GetBlock() types.BlockInterface {
+	return v.block
+}
+
+func (v *view) ReplaceBlock(blk types.BlockInterface) {
+	v.block = blk
+}
+
+func (v *view) GetBeaconHeight() uint64 {
+	if shardBlock, ok := v.block.(*types.ShardBlock); ok {
+		return shardBlock.Header.BeaconHeight
+	}
+	return v.block.GetHeight()
+}
+
+func (v *view) GetProposerByTimeSlot(ts int64, version int) (incognitokey.CommitteePublicKey, int) {
+	id := int(ts) % len(v.committee)
+	return v.committee[id], id
+}
+
+func (v *view) GetProposerLength() int {
+	return len(v.committee)
+}
+
+func (v *view) CompareCommitteeFromBlock(_ multiview.View) int {
+	return 0
+}
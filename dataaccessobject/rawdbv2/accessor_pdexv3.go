@@ -308,6 +308,7 @@ type Pdexv3Order struct {
 	token1Balance  uint64
 	tradeDirection byte
 	receiver       [2]string
+	expiryHeight   uint64
 }
 
 func (o *Pdexv3Order) Id() string             { return o.id }
@@ -319,12 +320,17 @@ func (o *Pdexv3Order) Token1Balance() uint64  { return o.token1Balance }
 func (o *Pdexv3Order) TradeDirection() byte   { return o.tradeDirection }
 func (o *Pdexv3Order) Token0Receiver() string { return o.receiver[0] }
 func (o *Pdexv3Order) Token1Receiver() string { return o.receiver[1] }
+func (o *Pdexv3Order) ExpiryHeight() uint64   { return o.expiryHeight }
 
 // SetToken0Balance() changes the token0 balance of this order. Only balances can be updated,
 // while rates, id & trade direction cannot
 func (o *Pdexv3Order) SetToken0Balance(b uint64) { o.token0Balance = b }
 func (o *Pdexv3Order) SetToken1Balance(b uint64) { o.token1Balance = b }
 
+// SetExpiryHeight() sets the beacon height from which this order is withdrawn automatically.
+// It is set once when the order is added; 0 means the order never expires
+func (o *Pdexv3Order) SetExpiryHeight(h uint64) { o.expiryHeight = h }
+
 func NewPdexv3OrderWithValue(
 	id string, nftID common.Hash,
 	token0Rate, token1Rate, token0Balance, token1Balance uint64,
@@ -353,6 +359,7 @@ func (o *Pdexv3Order) MarshalJSON() ([]byte, error) {
 		Token1Balance  uint64      `json:"Token1Balance"`
 		TradeDirection byte        `json:"TradeDirection"`
 		Receiver       [2]string   `json:"Receiver"`
+		ExpiryHeight   uint64      `json:"ExpiryHeight,omitempty"`
 	}{
 		Id:             o.id,
 		NftID:          o.nftID,
@@ -362,6 +369,7 @@ func (o *Pdexv3Order) MarshalJSON() ([]byte, error) {
 		Token1Balance:  o.token1Balance,
 		TradeDirection: o.tradeDirection,
 		Receiver:       o.receiver,
+		ExpiryHeight:   o.expiryHeight,
 	})
 	if err != nil {
 		return []byte{}, err
@@ -379,6 +387,7 @@ func (o *Pdexv3Order) UnmarshalJSON(data []byte) error {
 		Token1Balance  uint64      `json:"Token1Balance"`
 		TradeDirection byte        `json:"TradeDirection"`
 		Receiver       [2]string   `json:"Receiver"`
+		ExpiryHeight   uint64      `json:"ExpiryHeight,omitempty"`
 	}
 	err := json.Unmarshal(data, &temp)
 	if err != nil {
@@ -394,11 +403,14 @@ func (o *Pdexv3Order) UnmarshalJSON(data []byte) error {
 		token1Balance:  temp.Token1Balance,
 		tradeDirection: temp.TradeDirection,
 		receiver:       temp.Receiver,
+		expiryHeight:   temp.ExpiryHeight,
 	}
 	return nil
 }
 
 func (o *Pdexv3Order) Clone() *Pdexv3Order {
-	return NewPdexv3OrderWithValue(o.id, o.nftID, o.token0Rate, o.token1Rate,
+	result := NewPdexv3OrderWithValue(o.id, o.nftID, o.token0Rate, o.token1Rate,
 		o.token0Balance, o.token1Balance, o.tradeDirection, o.receiver)
+	result.expiryHeight = o.expiryHeight
+	return result
 }
@@ -1,6 +1,7 @@
 package rawdbv2
 
 import (
+	"encoding/binary"
 	"fmt"
 	"strconv"
 	"strings"
@@ -205,6 +206,88 @@ func GetCachedCoinHashes(db incdb.Database) ([][]byte, error) {
 	return otaKeys, nil
 }
 
+// IncomingCoinRecord holds the serialized incoming coin events found in a shard block.
+type IncomingCoinRecord struct {
+	ShardID     byte
+	BlockHeight uint64
+	Data        []byte
+}
+
+func StoreIncomingCoins(db incdb.Database, shardID byte, height uint64, data []byte) error {
+	key := generateIncomingCoinObjectKey(shardID, height)
+	err := db.Put(key, data)
+	if err != nil {
+		return NewRawdbError(StoreIncomingCoinError, err)
+	}
+	return nil
+}
+
+func DeleteIncomingCoins(db incdb.Database, shardID byte, height uint64) error {
+	key := generateIncomingCoinObjectKey(shardID, height)
+	err := db.Delete(key)
+	if err != nil {
+		return NewRawdbError(DeleteIncomingCoinError, err)
+	}
+	return nil
+}
+
+// GetIncomingCoins returns at most limit records, ordered by shard then by block height.
+func GetIncomingCoins(db incdb.Database, limit int) ([]IncomingCoinRecord, error) {
+	it := db.NewIteratorWithPrefix(incomingCoinPrefix)
+	defer it.Release()
+	var records []IncomingCoinRecord
+	for it.Next() && len(records) < limit {
+		key := it.Key()
+		if len(key) != len(incomingCoinPrefix)+9 {
+			return nil, NewRawdbError(GetIncomingCoinError, fmt.Errorf("invalid incoming coin key %x", key))
+		}
+		value := it.Value()
+		newValue := make([]byte, len(value))
+		copy(newValue, value)
+		records = append(records, IncomingCoinRecord{
+			ShardID:     key[len(incomingCoinPrefix)],
+			BlockHeight: binary.BigEndian.Uint64(key[len(incomingCoinPrefix)+1:]),
+			Data:        newValue,
+		})
+	}
+	if err := it.Error(); err != nil {
+		return nil, NewRawdbError(GetIncomingCoinError, err)
+	}
+	return records, nil
+}
+
+// StoreIncomingCoinCursor stores the height of the last shard block scanned for incoming coins.
+func StoreIncomingCoinCursor(db incdb.Database, shardID byte, height uint64) error {
+	key := generateIncomingCoinCursorObjectKey(shardID)
+	err := db.Put(key, common.Uint64ToBytes(height))
+	if err != nil {
+		return NewRawdbError(StoreIncomingCoinError, err)
+	}
+	return nil
+}
+
+// GetIncomingCoinCursor returns the height of the last shard block scanned for incoming coins, and false if
+// no block has been scanned yet.
+func GetIncomingCoinCursor(db incdb.Database, shardID byte) (uint64, bool, error) {
+	key := generateIncomingCoinCursorObjectKey(shardID)
+	has, err := db.Has(key)
+	if err != nil {
+		return 0, false, NewRawdbError(GetIncomingCoinError, err)
+	}
+	if !has {
+		return 0, false, nil
+	}
+	value, err := db.Get(key)
+	if err != nil {
+		return 0, false, NewRawdbError(GetIncomingCoinError, err)
+	}
+	height, err := common.BytesToUint64(value)
+	if err != nil {
+		return 0, false, NewRawdbError(GetIncomingCoinError, err)
+	}
+	return height, true, nil
+}
+
 //These functions are used for storing and getting a transaction by an output coin index
 //TODO: refactor these functions for more efficient read/write
 func StoreTxByCoinIndex(db incdb.Database, index []byte, tokenID common.Hash, shardID byte, txID common.Hash) error {
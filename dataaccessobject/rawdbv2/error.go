@@ -97,6 +97,9 @@ const (
 	GetOTAKeyError
 	StoreCoinHashError
 	GetCoinHashError
+	StoreIncomingCoinError
+	GetIncomingCoinError
+	DeleteIncomingCoinError
 
 	//Tx by input or output
 	StoreTxByCoinIndexError
@@ -199,6 +202,9 @@ var ErrCodeMessage = map[int]struct {
 	DeleteOTAKeyError:          {-6005, "Delete OTA keys error"},
 	StoreCoinHashError:         {-6006, "Store coin hash error"},
 	GetCoinHashError:           {-6007, "Get coin hash error"},
+	StoreIncomingCoinError:     {-6008, "Store incoming coin error"},
+	GetIncomingCoinError:       {-6009, "Get incoming coin error"},
+	DeleteIncomingCoinError:    {-6010, "Delete incoming coin error"},
 	StoreShardPruneStatusError: {-7001, "Store shard prune status error"},
 }
 
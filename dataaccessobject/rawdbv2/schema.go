@@ -1,6 +1,8 @@
 package rawdbv2
 
 import (
+	"encoding/binary"
+
 	"github.com/incognitochain/incognito-chain/common"
 )
 
@@ -45,6 +47,8 @@ var (
 	coinHashKeysPrefix        = []byte("coinhash-key" + string(splitter))
 	txByCoinIndexPrefix       = []byte("tx-index" + string(splitter))
 	txBySerialNumberPrefix    = []byte("tx-sn" + string(splitter))
+	incomingCoinPrefix        = []byte("incoming-coin-event" + string(splitter))
+	incomingCoinCursorPrefix  = []byte("incoming-coin-cursor" + string(splitter))
 	pruneStatusPrefix         = []byte("p-s")
 )
 
@@ -432,6 +436,24 @@ func generateTxBySerialNumberObjectKey(serialNumber []byte, tokenID common.Hash,
 	return append(prefixHash, valueHash[:][:txBySerialNumberPrefixKeyLength]...)
 }
 
+// ============================= Incoming coins =======================================
+
+// the height is stored in big endian so that the events of a shard are iterated by height
+func generateIncomingCoinObjectKey(shardID byte, height uint64) []byte {
+	temp := make([]byte, 0, len(incomingCoinPrefix)+9)
+	temp = append(temp, incomingCoinPrefix...)
+	temp = append(temp, shardID)
+	heightBytes := make([]byte, 8)
+	binary.BigEndian.PutUint64(heightBytes, height)
+	return append(temp, heightBytes...)
+}
+
+func generateIncomingCoinCursorObjectKey(shardID byte) []byte {
+	temp := make([]byte, 0, len(incomingCoinCursorPrefix)+1)
+	temp = append(temp, incomingCoinCursorPrefix...)
+	return append(temp, shardID)
+}
+
 // ============================= State prune =======================================
 
 func GetPruneStatusKey() []byte {
@@ -447,3 +469,10 @@ func GetShardRootsHashPrefix(shardID byte) []byte {
 	key = append(key, splitter...)
 	return key
 }
+
+func GetBeaconRootsHashPrefix() []byte {
+	temp := make([]byte, 0, len(beaconRootHashPrefix))
+	temp = append(temp, beaconRootHashPrefix...)
+	key := append(temp, splitter...)
+	return key
+}
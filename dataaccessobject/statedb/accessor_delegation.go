@@ -0,0 +1,34 @@
+package statedb
+
+func StoreDelegationPool(stateDB *StateDB, pool *DelegationPoolState) error {
+	key := GenerateDelegationPoolObjectKey(pool.committeePublicKey)
+	err := stateDB.SetStateObject(DelegationPoolObjectType, key, pool)
+	if err != nil {
+		return NewStatedbError(StoreDelegationPoolError, err)
+	}
+	return nil
+}
+
+func DeleteDelegationPool(stateDB *StateDB, committeePublicKey string) {
+	key := GenerateDelegationPoolObjectKey(committeePublicKey)
+	stateDB.MarkDeleteStateObject(DelegationPoolObjectType, key)
+}
+
+// GetDelegationPool returns the delegation pool staking committeePublicKey
+func GetDelegationPool(stateDB *StateDB, committeePublicKey string) (*DelegationPoolState, bool, error) {
+	key := GenerateDelegationPoolObjectKey(committeePublicKey)
+	pool, has, err := stateDB.getDelegationPoolState(key)
+	if err != nil {
+		return nil, false, NewStatedbError(GetDelegationPoolError, err)
+	}
+	return pool, has, nil
+}
+
+// GetAllDelegationPools returns all delegation pools sorted by committee public key
+func GetAllDelegationPools(stateDB *StateDB) ([]*DelegationPoolState, error) {
+	pools, err := stateDB.iterateDelegationPools(GetDelegationPoolPrefix())
+	if err != nil {
+		return nil, NewStatedbError(GetDelegationPoolError, err)
+	}
+	return pools, nil
+}
@@ -110,6 +110,9 @@ const (
 	BridgeAggVaultObjectType              = 74
 	BridgeAggWaitingUnshieldReqObjectType = 75
 	BridgeAggParamObjectType              = 76
+
+	// delegation
+	DelegationPoolObjectType = 77
 )
 
 // Prefix length
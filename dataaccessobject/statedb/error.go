@@ -89,6 +89,8 @@ const (
 	ErrInvalidBridgeAggVaultStateType          = "invalid bridge agg vault state type"
 	ErrInvalidBridgeAggWaitingUnshieldReqType  = "invalid bridge agg waiting unshield request state type"
 	ErrInvalidBridgeAggParamStateType          = "invalid bridge agg param state type"
+	// delegation
+	ErrInvalidDelegationPoolStateType = "invalid delegation pool state type"
 )
 const (
 	InvalidByteArrayTypeError = iota
@@ -305,6 +307,10 @@ const (
 	// Bridge Agg
 	GetBridgeAggStatusError
 	StoreBridgeAggStatusError
+
+	// Delegation
+	StoreDelegationPoolError
+	GetDelegationPoolError
 )
 
 var ErrCodeMessage = map[int]struct {
@@ -489,6 +495,10 @@ var ErrCodeMessage = map[int]struct {
 	// bridge agg
 	GetBridgeAggStatusError:   {-15108, "Get bridge agg status error"},
 	StoreBridgeAggStatusError: {-15109, "Store bridge agg status Error"},
+
+	// delegation
+	StoreDelegationPoolError: {-15110, "Store delegation pool error"},
+	GetDelegationPoolError:   {-15111, "Get delegation pool error"},
 }
 
 type StatedbError struct {
@@ -0,0 +1,28 @@
+package statedb
+
+import (
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/trie"
+)
+
+// GetProof returns the value stored at key & the Merkle proof of it against the state root
+// stateDB was opened at (changes not committed yet are not included).
+// If key is not in the state, the value is nil & the proof shows its absence
+func (stateDB *StateDB) GetProof(key common.Hash) ([]byte, trie.ProofList, error) {
+	proof := trie.ProofList{}
+	if err := stateDB.trie.Prove(key[:], 0, &proof); err != nil {
+		return nil, nil, err
+	}
+	value, err := stateDB.trie.TryGet(key[:])
+	if err != nil {
+		return nil, nil, err
+	}
+	return value, proof, nil
+}
+
+// VerifyProof checks a proof returned by GetProof against a state root & returns the proven value,
+// which is nil if the proof shows that key is not in the state
+func VerifyProof(root common.Hash, key common.Hash, proof trie.ProofList) ([]byte, error) {
+	value, _, err := trie.VerifyProof(root, key[:], proof.ProofSet())
+	return value, err
+}
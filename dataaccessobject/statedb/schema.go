@@ -55,6 +55,7 @@ var (
 	burnPrefix                         = []byte("burn-")
 	syncingValidatorsPrefix            = []byte("syncing-validators-")
 	stakerInfoPrefix                   = common.HashB([]byte("stk-info-"))[:prefixHashKeyLength]
+	delegationPoolPrefix               = []byte("delegation-pool-")
 
 	// pdex v3
 	pdexv3StatusPrefix                      = []byte("pdexv3-status-")
@@ -209,6 +210,11 @@ func GetStakerInfoPrefix() []byte {
 	return h[:][:prefixHashKeyLength]
 }
 
+func GetDelegationPoolPrefix() []byte {
+	h := common.HashH(delegationPoolPrefix)
+	return h[:][:prefixHashKeyLength]
+}
+
 func GetCommitteeTermKey(stakerPublicKey []byte) common.Hash {
 	h := common.HashH(stakerInfoPrefix)
 	final := append(h[:][:prefixHashKeyLength], common.HashH(stakerPublicKey).Bytes()[:prefixKeyLength]...)
@@ -2639,3 +2639,36 @@ func (stateDB *StateDB) getBridgeFTMTxState(key common.Hash) (*BridgeFTMTxState,
 	}
 	return NewBridgeFTMTxState(), false, nil
 }
+
+// ================================= Delegation OBJECT =======================================
+func (stateDB *StateDB) getDelegationPoolState(key common.Hash) (*DelegationPoolState, bool, error) {
+	delegationPoolState, err := stateDB.getStateObject(DelegationPoolObjectType, key)
+	if err != nil {
+		return nil, false, err
+	}
+	if delegationPoolState != nil {
+		return delegationPoolState.GetValue().(*DelegationPoolState), true, nil
+	}
+	return NewDelegationPoolState(), false, nil
+}
+
+func (stateDB *StateDB) iterateDelegationPools(prefix []byte) ([]*DelegationPoolState, error) {
+	res := []*DelegationPoolState{}
+	temp := stateDB.trie.NodeIterator(prefix)
+	it := trie.NewIterator(temp)
+	for it.Next(true, false, true) {
+		value := it.Value
+		newValue := make([]byte, len(value))
+		copy(newValue, value)
+		pool := NewDelegationPoolState()
+		err := json.Unmarshal(newValue, pool)
+		if err != nil {
+			return res, err
+		}
+		res = append(res, pool)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].committeePublicKey < res[j].committeePublicKey
+	})
+	return res, nil
+}
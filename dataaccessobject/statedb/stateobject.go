@@ -173,6 +173,8 @@ func newStateObjectWithValue(db *StateDB, objectType int, hash common.Hash, valu
 		return newBridgeAggWaitingUnshieldReqObjectWithValue(db, hash, value)
 	case BridgeAggParamObjectType:
 		return newBridgeAggParamObjectWithValue(db, hash, value)
+	case DelegationPoolObjectType:
+		return newDelegationPoolObjectWithValue(db, hash, value)
 
 	default:
 		panic("state object type not exist")
@@ -327,6 +329,8 @@ func newStateObject(db *StateDB, objectType int, hash common.Hash) StateObject {
 		return newBridgeAggWaitingUnshieldReqObject(db, hash)
 	case BridgeAggParamObjectType:
 		return newBridgeAggParamObject(db, hash)
+	case DelegationPoolObjectType:
+		return newDelegationPoolObject(db, hash)
 	default:
 		panic("state object type not exist")
 	}
@@ -0,0 +1,375 @@
+package statedb
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/incognitochain/incognito-chain/common"
+)
+
+// DelegationState is the PRV amount a delegator contributes to a delegation pool
+type DelegationState struct {
+	delegatorAddress string
+	amount           uint64
+	// unbondTxID is the hash of the request tx to unbond this delegation, empty if the delegation is bonded
+	unbondTxID common.Hash
+}
+
+func (d *DelegationState) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(struct {
+		DelegatorAddress string      `json:"DelegatorAddress"`
+		Amount           uint64      `json:"Amount"`
+		UnbondTxID       common.Hash `json:"UnbondTxID"`
+	}{
+		DelegatorAddress: d.delegatorAddress,
+		Amount:           d.amount,
+		UnbondTxID:       d.unbondTxID,
+	})
+	if err != nil {
+		return []byte{}, err
+	}
+	return data, nil
+}
+
+func (d *DelegationState) UnmarshalJSON(data []byte) error {
+	temp := struct {
+		DelegatorAddress string      `json:"DelegatorAddress"`
+		Amount           uint64      `json:"Amount"`
+		UnbondTxID       common.Hash `json:"UnbondTxID"`
+	}{}
+	err := json.Unmarshal(data, &temp)
+	if err != nil {
+		return err
+	}
+	d.delegatorAddress = temp.DelegatorAddress
+	d.amount = temp.Amount
+	d.unbondTxID = temp.UnbondTxID
+	return nil
+}
+
+func NewDelegationStateWithValue(delegatorAddress string, amount uint64, unbondTxID common.Hash) *DelegationState {
+	return &DelegationState{
+		delegatorAddress: delegatorAddress,
+		amount:           amount,
+		unbondTxID:       unbondTxID,
+	}
+}
+
+func (d *DelegationState) DelegatorAddress() string {
+	return d.delegatorAddress
+}
+
+func (d *DelegationState) Amount() uint64 {
+	return d.amount
+}
+
+func (d *DelegationState) UnbondTxID() common.Hash {
+	return d.unbondTxID
+}
+
+func (d *DelegationState) IsUnbonding() bool {
+	return d.unbondTxID != common.Hash{}
+}
+
+func (d *DelegationState) SetAmount(amount uint64) {
+	d.amount = amount
+}
+
+func (d *DelegationState) SetUnbondTxID(unbondTxID common.Hash) {
+	d.unbondTxID = unbondTxID
+}
+
+func (d *DelegationState) Clone() *DelegationState {
+	return NewDelegationStateWithValue(d.delegatorAddress, d.amount, d.unbondTxID)
+}
+
+// DelegationPoolState is the PRV pooled by delegators to stake a shard validator run by an operator
+type DelegationPoolState struct {
+	committeePublicKey string
+	operatorAddress    string
+	// commission is the part of the pool's reward kept by the operator, in basis points
+	commission  uint64
+	delegations []*DelegationState
+	// isStaked is true from the time the pool stakes its validator until the staking amount is returned
+	isStaked bool
+	// isUnstaking is true when the pool has unstaked its validator to release the unbonding delegations
+	isUnstaking bool
+}
+
+func (p *DelegationPoolState) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(struct {
+		CommitteePublicKey string             `json:"CommitteePublicKey"`
+		OperatorAddress    string             `json:"OperatorAddress"`
+		Commission         uint64             `json:"Commission"`
+		Delegations        []*DelegationState `json:"Delegations"`
+		IsStaked           bool               `json:"IsStaked"`
+		IsUnstaking        bool               `json:"IsUnstaking"`
+	}{
+		CommitteePublicKey: p.committeePublicKey,
+		OperatorAddress:    p.operatorAddress,
+		Commission:         p.commission,
+		Delegations:        p.delegations,
+		IsStaked:           p.isStaked,
+		IsUnstaking:        p.isUnstaking,
+	})
+	if err != nil {
+		return []byte{}, err
+	}
+	return data, nil
+}
+
+func (p *DelegationPoolState) UnmarshalJSON(data []byte) error {
+	temp := struct {
+		CommitteePublicKey string             `json:"CommitteePublicKey"`
+		OperatorAddress    string             `json:"OperatorAddress"`
+		Commission         uint64             `json:"Commission"`
+		Delegations        []*DelegationState `json:"Delegations"`
+		IsStaked           bool               `json:"IsStaked"`
+		IsUnstaking        bool               `json:"IsUnstaking"`
+	}{}
+	err := json.Unmarshal(data, &temp)
+	if err != nil {
+		return err
+	}
+	p.committeePublicKey = temp.CommitteePublicKey
+	p.operatorAddress = temp.OperatorAddress
+	p.commission = temp.Commission
+	p.delegations = temp.Delegations
+	p.isStaked = temp.IsStaked
+	p.isUnstaking = temp.IsUnstaking
+	return nil
+}
+
+func NewDelegationPoolState() *DelegationPoolState {
+	return &DelegationPoolState{}
+}
+
+func NewDelegationPoolStateWithValue(
+	committeePublicKey, operatorAddress string, commission uint64,
+	delegations []*DelegationState, isStaked, isUnstaking bool,
+) *DelegationPoolState {
+	return &DelegationPoolState{
+		committeePublicKey: committeePublicKey,
+		operatorAddress:    operatorAddress,
+		commission:         commission,
+		delegations:        delegations,
+		isStaked:           isStaked,
+		isUnstaking:        isUnstaking,
+	}
+}
+
+func (p *DelegationPoolState) CommitteePublicKey() string {
+	return p.committeePublicKey
+}
+
+func (p *DelegationPoolState) OperatorAddress() string {
+	return p.operatorAddress
+}
+
+func (p *DelegationPoolState) Commission() uint64 {
+	return p.commission
+}
+
+func (p *DelegationPoolState) Delegations() []*DelegationState {
+	return p.delegations
+}
+
+func (p *DelegationPoolState) IsStaked() bool {
+	return p.isStaked
+}
+
+func (p *DelegationPoolState) IsUnstaking() bool {
+	return p.isUnstaking
+}
+
+func (p *DelegationPoolState) SetDelegations(delegations []*DelegationState) {
+	p.delegations = delegations
+}
+
+func (p *DelegationPoolState) SetIsStaked(isStaked bool) {
+	p.isStaked = isStaked
+}
+
+func (p *DelegationPoolState) SetIsUnstaking(isUnstaking bool) {
+	p.isUnstaking = isUnstaking
+}
+
+// Delegation returns the delegation of a delegator address in the pool, nil if not found
+func (p *DelegationPoolState) Delegation(delegatorAddress string) *DelegationState {
+	for _, d := range p.delegations {
+		if d.delegatorAddress == delegatorAddress {
+			return d
+		}
+	}
+	return nil
+}
+
+// TotalAmount returns the total PRV amount of all delegations in the pool
+func (p *DelegationPoolState) TotalAmount() uint64 {
+	total := uint64(0)
+	for _, d := range p.delegations {
+		total += d.amount
+	}
+	return total
+}
+
+// AddDelegation adds amount to the delegation of delegatorAddress, a new delegation is appended if not found
+func (p *DelegationPoolState) AddDelegation(delegatorAddress string, amount uint64) {
+	if d := p.Delegation(delegatorAddress); d != nil {
+		d.amount += amount
+		return
+	}
+	p.delegations = append(p.delegations, NewDelegationStateWithValue(delegatorAddress, amount, common.Hash{}))
+}
+
+// RemoveDelegation removes the delegation of delegatorAddress from the pool
+func (p *DelegationPoolState) RemoveDelegation(delegatorAddress string) {
+	for i, d := range p.delegations {
+		if d.delegatorAddress == delegatorAddress {
+			p.delegations = append(p.delegations[:i:i], p.delegations[i+1:]...)
+			return
+		}
+	}
+}
+
+func (p *DelegationPoolState) Clone() *DelegationPoolState {
+	delegations := make([]*DelegationState, len(p.delegations))
+	for i, d := range p.delegations {
+		delegations[i] = d.Clone()
+	}
+	return NewDelegationPoolStateWithValue(
+		p.committeePublicKey, p.operatorAddress, p.commission,
+		delegations, p.isStaked, p.isUnstaking,
+	)
+}
+
+type DelegationPoolObject struct {
+	db *StateDB
+	// Write caches.
+	trie Trie // storage trie, which becomes non-nil on first access
+
+	version    int
+	hash       common.Hash
+	state      *DelegationPoolState
+	objectType int
+	deleted    bool
+
+	// DB error.
+	// State objects are used by the consensus core and VM which are
+	// unable to deal with database-level errors. Any error that occurs
+	// during a database read is memoized here and will eventually be returned
+	// by StateDB.Commit.
+	dbErr error
+}
+
+func newDelegationPoolObject(db *StateDB, hash common.Hash) *DelegationPoolObject {
+	return &DelegationPoolObject{
+		version:    defaultVersion,
+		db:         db,
+		hash:       hash,
+		state:      NewDelegationPoolState(),
+		objectType: DelegationPoolObjectType,
+		deleted:    false,
+	}
+}
+
+func newDelegationPoolObjectWithValue(db *StateDB, key common.Hash, data interface{}) (*DelegationPoolObject, error) {
+	var newDelegationPoolState = NewDelegationPoolState()
+	var ok bool
+	var dataBytes []byte
+	if dataBytes, ok = data.([]byte); ok {
+		err := json.Unmarshal(dataBytes, newDelegationPoolState)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		newDelegationPoolState, ok = data.(*DelegationPoolState)
+		if !ok {
+			return nil, fmt.Errorf("%+v, got type %+v", ErrInvalidDelegationPoolStateType, reflect.TypeOf(data))
+		}
+	}
+	return &DelegationPoolObject{
+		version:    defaultVersion,
+		hash:       key,
+		state:      newDelegationPoolState,
+		db:         db,
+		objectType: DelegationPoolObjectType,
+		deleted:    false,
+	}, nil
+}
+
+func GenerateDelegationPoolObjectKey(committeePublicKey string) common.Hash {
+	prefixHash := GetDelegationPoolPrefix()
+	valueHash := common.HashH([]byte(committeePublicKey))
+	return common.BytesToHash(append(prefixHash, valueHash[:prefixKeyLength]...))
+}
+
+func (object *DelegationPoolObject) GetVersion() int {
+	return object.version
+}
+
+// setError remembers the first non-nil error it is called with.
+func (object *DelegationPoolObject) SetError(err error) {
+	if object.dbErr == nil {
+		object.dbErr = err
+	}
+}
+
+func (object *DelegationPoolObject) GetTrie(db DatabaseAccessWarper) Trie {
+	return object.trie
+}
+
+func (object *DelegationPoolObject) SetValue(data interface{}) error {
+	newDelegationPoolState, ok := data.(*DelegationPoolState)
+	if !ok {
+		return fmt.Errorf("%+v, got type %+v", ErrInvalidDelegationPoolStateType, reflect.TypeOf(data))
+	}
+	object.state = newDelegationPoolState
+	return nil
+}
+
+func (object *DelegationPoolObject) GetValue() interface{} {
+	return object.state
+}
+
+func (object *DelegationPoolObject) GetValueBytes() []byte {
+	state, ok := object.GetValue().(*DelegationPoolState)
+	if !ok {
+		panic("wrong expected value type")
+	}
+	value, err := json.Marshal(state)
+	if err != nil {
+		panic("failed to marshal delegation pool state")
+	}
+	return value
+}
+
+func (object *DelegationPoolObject) GetHash() common.Hash {
+	return object.hash
+}
+
+func (object *DelegationPoolObject) GetType() int {
+	return object.objectType
+}
+
+// MarkDelete will delete an object in trie
+func (object *DelegationPoolObject) MarkDelete() {
+	object.deleted = true
+}
+
+// reset all shard committee value into default value
+func (object *DelegationPoolObject) Reset() bool {
+	object.state = NewDelegationPoolState()
+	return true
+}
+
+func (object *DelegationPoolObject) IsDeleted() bool {
+	return object.deleted
+}
+
+// value is either default or nil
+func (object *DelegationPoolObject) IsEmpty() bool {
+	temp := NewDelegationPoolState()
+	return reflect.DeepEqual(temp, object.state) || object.state == nil
+}
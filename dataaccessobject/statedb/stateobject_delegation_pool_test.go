@@ -0,0 +1,94 @@
+package statedb
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+)
+
+func TestDelegationPoolState_Delegations(t *testing.T) {
+	pool := NewDelegationPoolStateWithValue("key", "operator", 1000, []*DelegationState{}, false, false)
+	pool.AddDelegation("operator", 500)
+	pool.AddDelegation("delegator1", 200)
+	pool.AddDelegation("delegator2", 100)
+	pool.AddDelegation("delegator1", 300)
+	if len(pool.Delegations()) != 3 || pool.Delegation("delegator1").Amount() != 500 || pool.TotalAmount() != 1100 {
+		t.Fatalf("Expect delegations of the same address to be merged, got %+v", pool.Delegations())
+	}
+
+	pool.Delegation("delegator1").SetUnbondTxID(common.Hash{1})
+	if !pool.Delegation("delegator1").IsUnbonding() || pool.Delegation("delegator2").IsUnbonding() {
+		t.Fatal("Expect only delegator1 to be unbonding")
+	}
+
+	clone := pool.Clone()
+	pool.RemoveDelegation("delegator1")
+	pool.RemoveDelegation("unknown")
+	if pool.Delegation("delegator1") != nil || len(pool.Delegations()) != 2 || pool.TotalAmount() != 600 {
+		t.Fatalf("Expect delegator1 to be removed, got %+v", pool.Delegations())
+	}
+	// the clone does not share delegations with the pool
+	clone.Delegation("operator").SetAmount(1)
+	if len(clone.Delegations()) != 3 || pool.Delegation("operator").Amount() != 500 {
+		t.Fatal("Expect the clone to be independent of the pool")
+	}
+}
+
+func TestDelegationPoolState_MarshalJSON(t *testing.T) {
+	pool := NewDelegationPoolStateWithValue("key", "operator", 1000, []*DelegationState{
+		NewDelegationStateWithValue("operator", 500, common.Hash{}),
+		NewDelegationStateWithValue("delegator1", 300, common.Hash{1}),
+	}, true, true)
+	data, err := json.Marshal(pool)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := NewDelegationPoolState()
+	if err := json.Unmarshal(data, got); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(pool, got) {
+		t.Fatalf("Expect %+v, got %+v", pool, got)
+	}
+}
+
+func TestStoreDelegationPool(t *testing.T) {
+	sDB, err := NewWithPrefixTrie(emptyRoot, wrarperDB)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pools := []*DelegationPoolState{
+		NewDelegationPoolStateWithValue("key2", "operator2", 0, []*DelegationState{NewDelegationStateWithValue("operator2", 100, common.Hash{})}, false, false),
+		NewDelegationPoolStateWithValue("key1", "operator1", 500, []*DelegationState{NewDelegationStateWithValue("operator1", 1000, common.Hash{})}, true, false),
+	}
+	for _, pool := range pools {
+		if err := StoreDelegationPool(sDB, pool); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := sDB.Commit(true); err != nil {
+		t.Fatal(err)
+	}
+
+	got, has, err := GetDelegationPool(sDB, "key1")
+	if err != nil || !has || !reflect.DeepEqual(got, pools[1]) {
+		t.Fatalf("GetDelegationPool() = %+v, %v, %v", got, has, err)
+	}
+	all, err := GetAllDelegationPools(sDB)
+	if err != nil || len(all) != 2 || all[0].CommitteePublicKey() != "key1" || all[1].CommitteePublicKey() != "key2" {
+		t.Fatalf("Expect pools sorted by committee public key, got %+v, %v", all, err)
+	}
+
+	DeleteDelegationPool(sDB, "key1")
+	if _, err := sDB.Commit(true); err != nil {
+		t.Fatal(err)
+	}
+	if _, has, err := GetDelegationPool(sDB, "key1"); err != nil || has {
+		t.Fatalf("Expect the pool to be deleted, got %v, %v", has, err)
+	}
+	if all, err := GetAllDelegationPools(sDB); err != nil || len(all) != 1 {
+		t.Fatalf("Expect one pool left, got %+v, %v", all, err)
+	}
+}
@@ -17,6 +17,7 @@ require (
 	github.com/davecgh/go-spew v1.1.1
 	github.com/dchest/siphash v1.2.1 // indirect
 	github.com/deckarep/golang-set v1.7.1 // indirect
+	github.com/dgraph-io/badger v1.6.1
 	github.com/dgryski/go-identicon v0.0.0-20140725220403-371855927d74
 	github.com/ebfe/keccak v0.0.0-20150115210727-5cc570678d1b
 	github.com/edsrzf/mmap-go v1.0.0 // indirect
@@ -0,0 +1,149 @@
+package incdb
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/incognitochain/incognito-chain/common"
+)
+
+// The helpers below implement the on-disk backup layout shared by every driver:
+// a backup folder holds one compressed snapshot per epoch, named by the epoch
+// number. Drivers close their store, compress the whole db directory and
+// reopen it, so the format does not depend on the underlying engine.
+
+// LatestBackup returns the highest backup epoch found in backupFolder and the
+// path to its snapshot file.
+func LatestBackup(backupFolder string) (int, string) {
+	files, err := ioutil.ReadDir(backupFolder)
+	if err != nil {
+		return 0, ""
+	}
+	if len(files) == 0 {
+		return 0, ""
+	}
+	latestBackupEpoch := 0
+	//Get max epoch
+	for _, file := range files {
+		epoch, err := strconv.Atoi(file.Name())
+		if err != nil {
+			return 0, ""
+		}
+		if epoch > latestBackupEpoch {
+			latestBackupEpoch = epoch
+		}
+	}
+
+	return latestBackupEpoch, fmt.Sprintf("%v/%v", backupFolder, latestBackupEpoch)
+}
+
+// CompressBackup compresses the db directory dbPath into backupFile and removes
+// every older snapshot except the previous epoch.
+func CompressBackup(dbPath string, backupFile string) error {
+	if err := os.MkdirAll(filepath.Dir(backupFile), 0700); err != nil {
+		return err
+	}
+	if err := common.CompressDatabase(dbPath, backupFile); err != nil {
+		return err
+	}
+	return RemoveUnusedBackups(backupFile)
+}
+
+// RemoveUnusedBackups removes the snapshots in the folder of filePath whose epoch
+// is neither the epoch of filePath nor the one right before it.
+func RemoveUnusedBackups(filePath string) error {
+	//Get latest epoch
+	latestEpoch, err := strconv.Atoi(filepath.Base(filePath))
+	if err != nil {
+		return err
+	}
+
+	//Get needed epoch to download
+	dir := filepath.Dir(filePath)
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+
+	//Get file name and compare with latest epoch
+	for _, file := range files {
+		epoch, err := strconv.Atoi(file.Name())
+		if err != nil {
+			return err
+		}
+		if epoch != latestEpoch && epoch != latestEpoch-1 {
+			err = os.Remove(filepath.Join(dir, file.Name()))
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// RestoreBackup uncompresses backupFile next to dbPath and swaps it in place of
+// the current db directory, which is kept until CommitRestore or RevertRestore.
+// The store must be closed before calling it.
+func RestoreBackup(backupFile string, dbPath string) error {
+	tmpPath := dbPath + "_"
+	fmt.Println("start decompress", backupFile)
+	if err := os.RemoveAll(tmpPath); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(tmpPath, 0700); err != nil {
+		return err
+	}
+	if err := common.DecompressDatabaseBackup(backupFile, tmpPath); err != nil {
+		return err
+	}
+	fmt.Println("done decompress", tmpPath)
+
+	oldPath := replacedPath(dbPath)
+	if err := os.RemoveAll(oldPath); err != nil {
+		return err
+	}
+	if err := os.Rename(dbPath, oldPath); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, dbPath)
+}
+
+// CommitRestore removes the db directory replaced by the last RestoreBackup.
+func CommitRestore(dbPath string) error {
+	return os.RemoveAll(replacedPath(dbPath))
+}
+
+// RevertRestore puts back the db directory replaced by the last RestoreBackup.
+// The store must be closed before calling it.
+func RevertRestore(dbPath string) error {
+	oldPath := replacedPath(dbPath)
+	if _, err := os.Stat(oldPath); err != nil {
+		return err
+	}
+	if err := os.RemoveAll(dbPath); err != nil {
+		return err
+	}
+	return os.Rename(oldPath, dbPath)
+}
+
+func replacedPath(dbPath string) string {
+	return dbPath + "_old"
+}
+
+// ClearDirectory removes every file under dbPath but keeps the directory itself.
+func ClearDirectory(dbPath string) error {
+	files, err := filepath.Glob(filepath.Join(dbPath, "*"))
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		err = os.RemoveAll(file)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,325 @@
+package badgerdb
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/dgraph-io/badger"
+	"github.com/pkg/errors"
+
+	"github.com/incognitochain/incognito-chain/incdb"
+)
+
+const (
+	// DbType is the name this driver registers itself with in incdb.
+	DbType = "badgerdb"
+
+	// valueLogGCDiscardRatio is the ratio of stale data a value log file must hold
+	// before Compact rewrites it.
+	valueLogGCDiscardRatio = 0.5
+)
+
+type db struct {
+	fn     string // filename for reporting
+	dbPath string
+	bdb    *badger.DB
+	lock   sync.RWMutex
+}
+
+func init() {
+	driver := incdb.Driver{
+		DbType: DbType,
+		Open:   openDriver,
+	}
+	if err := incdb.RegisterDriver(driver); err != nil {
+		panic("failed to register db driver")
+	}
+}
+
+func openDriver(args ...interface{}) (incdb.Database, error) {
+	if len(args) != 1 {
+		return nil, errors.New("invalid arguments")
+	}
+	dbPath, ok := args[0].(string)
+	if !ok {
+		return nil, errors.New("expected db path")
+	}
+	return open(dbPath)
+}
+
+func open(dbPath string) (incdb.Database, error) {
+	bdb, err := openBadger(dbPath)
+	if err != nil {
+		return nil, err
+	}
+	return &db{fn: dbPath, bdb: bdb, dbPath: dbPath}, nil
+}
+
+func openBadger(dbPath string) (*badger.DB, error) {
+	if err := os.MkdirAll(dbPath, 0700); err != nil {
+		return nil, errors.Wrapf(err, "os.MkdirAll %s", dbPath)
+	}
+	opts := badger.DefaultOptions(dbPath).
+		WithLogger(nil).
+		WithTruncate(true)
+	bdb, err := badger.Open(opts)
+	if err != nil {
+		return nil, errors.Wrapf(err, "badger.Open %s", dbPath)
+	}
+	return bdb, nil
+}
+
+func (db *db) GetPath() string {
+	return db.fn
+}
+
+func (db *db) Close() error {
+	return errors.Wrap(db.bdb.Close(), "db.bdb.Close")
+}
+
+func (db *db) ReOpen() error {
+	bdb, err := openBadger(db.dbPath)
+	if err != nil {
+		return err
+	}
+	db.bdb = bdb
+	return nil
+}
+
+func (db *db) Has(key []byte) (bool, error) {
+	err := db.bdb.View(func(txn *badger.Txn) error {
+		_, err := txn.Get(key)
+		return err
+	})
+	if err == badger.ErrKeyNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (db *db) Get(key []byte) ([]byte, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+	var value []byte
+	err := db.bdb.View(func(txn *badger.Txn) error {
+		item, err := txn.Get(key)
+		if err != nil {
+			return err
+		}
+		value, err = item.ValueCopy(nil)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+func (db *db) Put(key, value []byte) error {
+	return db.bdb.Update(func(txn *badger.Txn) error {
+		return txn.Set(key, value)
+	})
+}
+
+func (db *db) Delete(key []byte) error {
+	return db.bdb.Update(func(txn *badger.Txn) error {
+		return txn.Delete(key)
+	})
+}
+
+// NewBatch creates a write-only key-value store that buffers changes to its host
+// database until a final write is called.
+func (db *db) NewBatch() incdb.Batch {
+	return &batch{
+		db: db.bdb,
+	}
+}
+
+// NewIterator creates a binary-alphabetical iterator over the entire keyspace
+// contained within the badger database.
+func (db *db) NewIterator() incdb.Iterator {
+	return newIterator(db.bdb, nil, nil)
+}
+
+// NewIteratorWithStart creates a binary-alphabetical iterator over a subset of
+// database content starting at a particular initial key (or after, if it does
+// not exist).
+func (db *db) NewIteratorWithStart(start []byte) incdb.Iterator {
+	return newIterator(db.bdb, nil, start)
+}
+
+// NewIteratorWithPrefix creates a binary-alphabetical iterator over a subset
+// of database content with a particular key prefix.
+func (db *db) NewIteratorWithPrefix(prefix []byte) incdb.Iterator {
+	return newIterator(db.bdb, prefix, prefix)
+}
+
+// NewIteratorWithPrefixStart creates a binary-alphabetical iterator over a subset
+// of database content with a particular key prefix, starting at a particular
+// initial key (or after, if it does not exist).
+func (db *db) NewIteratorWithPrefixStart(prefix []byte, start []byte) incdb.Iterator {
+	return newIterator(db.bdb, prefix, append(append([]byte{}, prefix...), start...))
+}
+
+// Stat returns a particular internal stat of the database. Badger does not have
+// named properties like leveldb, so the property is ignored and the sizes of the
+// LSM tree and the value log are reported instead.
+func (db *db) Stat(property string) (string, error) {
+	lsm, vlog := db.bdb.Size()
+	return fmt.Sprintf("lsm: %d, vlog: %d", lsm, vlog), nil
+}
+
+// Compact flattens the LSM tree and garbage collects the value log. Badger can
+// only compact the whole store, so start and limit are ignored.
+func (db *db) Compact(start []byte, limit []byte) error {
+	if err := db.bdb.Flatten(1); err != nil {
+		return err
+	}
+	for {
+		err := db.bdb.RunValueLogGC(valueLogGCDiscardRatio)
+		if err == badger.ErrNoRewrite || err == badger.ErrRejected {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// Path returns the path to the database directory.
+func (db *db) Path() string {
+	return db.fn
+}
+
+func (db *db) PreloadBackup(backupFile string) error {
+	return incdb.RestoreBackup(backupFile, db.dbPath)
+}
+
+// CommitPreload deletes the database replaced by PreloadBackup
+func (db *db) CommitPreload() error {
+	return incdb.CommitRestore(db.dbPath)
+}
+
+// RevertPreload puts back the database replaced by PreloadBackup, the database must be closed
+func (db *db) RevertPreload() error {
+	return incdb.RevertRestore(db.dbPath)
+}
+
+func (db *db) LatestBackup(path string) (int, string) {
+	return incdb.LatestBackup(filepath.Join(db.dbPath, path))
+}
+
+func (db *db) RemoveBackup(backupFile string) {
+	backupFile = filepath.Join(db.dbPath, backupFile)
+	os.Remove(backupFile)
+}
+
+func (db *db) Backup(backupFile string) error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	backupFile = filepath.Join(db.dbPath, backupFile)
+
+	if err := db.Close(); err != nil {
+		return err
+	}
+
+	err := incdb.CompressBackup(db.dbPath, backupFile)
+
+	if err := db.ReOpen(); err != nil {
+		panic(err)
+	}
+
+	return err
+}
+
+func (db *db) Clear() error {
+	return incdb.ClearDirectory(db.dbPath)
+}
+
+// batch is a write-only badger batch that commits changes to its host database
+// when Write is called. A batch cannot be used concurrently.
+//
+// Operations are buffered in memory rather than in a badger.WriteBatch so that
+// the batch can be reset and replayed like the leveldb one.
+type batch struct {
+	db   *badger.DB
+	ops  []batchOp
+	size int
+}
+
+type batchOp struct {
+	key    []byte
+	value  []byte
+	delete bool
+}
+
+// Put inserts the given value into the batch for later committing.
+func (b *batch) Put(key, value []byte) error {
+	b.ops = append(b.ops, batchOp{
+		key:   append([]byte{}, key...),
+		value: append([]byte{}, value...),
+	})
+	b.size += len(value)
+	return nil
+}
+
+// Delete inserts the a key removal into the batch for later committing.
+func (b *batch) Delete(key []byte) error {
+	b.ops = append(b.ops, batchOp{
+		key:    append([]byte{}, key...),
+		delete: true,
+	})
+	b.size++
+	return nil
+}
+
+// ValueSize retrieves the amount of data queued up for writing.
+func (b *batch) ValueSize() int {
+	return b.size
+}
+
+// Write flushes any accumulated data to disk.
+func (b *batch) Write() error {
+	wb := b.db.NewWriteBatch()
+	defer wb.Cancel()
+	for _, op := range b.ops {
+		var err error
+		if op.delete {
+			err = wb.Delete(op.key)
+		} else {
+			err = wb.Set(op.key, op.value)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return wb.Flush()
+}
+
+// Reset resets the batch for reuse.
+func (b *batch) Reset() {
+	b.ops = b.ops[:0]
+	b.size = 0
+}
+
+// Replay replays the batch contents.
+func (b *batch) Replay(w incdb.KeyValueWriter) error {
+	for _, op := range b.ops {
+		var err error
+		if op.delete {
+			err = w.Delete(op.key)
+		} else {
+			err = w.Put(op.key, op.value)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,12 @@
+package badgerdb_test
+
+import (
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/incdb/badgerdb"
+	"github.com/incognitochain/incognito-chain/incdb/dbtest"
+)
+
+func TestDb_Conformance(t *testing.T) {
+	dbtest.TestDatabaseSuite(t, badgerdb.DbType)
+}
@@ -0,0 +1,141 @@
+package badgerdb
+
+import (
+	"bytes"
+
+	"github.com/dgraph-io/badger"
+)
+
+// iterator adapts a badger iterator, which lives inside a read-only transaction,
+// to the leveldb-like incdb.Iterator: it is positioned before the first pair
+// until Next is called and it only yields keys in [start, end of prefix).
+type iterator struct {
+	txn    *badger.Txn
+	it     *badger.Iterator
+	prefix []byte
+	start  []byte
+
+	started   bool
+	exhausted bool
+	key       []byte
+	value     []byte
+	err       error
+}
+
+func newIterator(bdb *badger.DB, prefix []byte, start []byte) *iterator {
+	txn := bdb.NewTransaction(false)
+	opts := badger.DefaultIteratorOptions
+	opts.Prefix = prefix
+	return &iterator{
+		txn:    txn,
+		it:     txn.NewIterator(opts),
+		prefix: prefix,
+		start:  start,
+	}
+}
+
+// Next moves the iterator to the next key/value pair. It returns whether the
+// iterator is exhausted.
+func (it *iterator) Next() bool {
+	if it.exhausted || it.err != nil {
+		return false
+	}
+	if !it.started {
+		it.started = true
+		it.it.Seek(it.start)
+	} else {
+		it.it.Next()
+	}
+	return it.load(it.it)
+}
+
+// Last moves the iterator to the last key/value pair. It returns whether such
+// pair exist.
+func (it *iterator) Last() bool {
+	if it.it == nil || it.err != nil {
+		return false
+	}
+	opts := badger.DefaultIteratorOptions
+	opts.Prefix = it.prefix
+	opts.Reverse = true
+	rit := it.txn.NewIterator(opts)
+	defer rit.Close()
+
+	// Seeking backward finds the largest key not greater than the seek key; an
+	// empty seek key rewinds to the largest key of the store.
+	seekKey := prefixUpperBound(it.prefix)
+	rit.Seek(seekKey)
+	if seekKey != nil && rit.Valid() && bytes.Equal(rit.Item().Key(), seekKey) {
+		rit.Next()
+	}
+	it.started = true
+	if !it.load(rit) || bytes.Compare(it.key, it.start) < 0 {
+		it.exhausted = true
+		it.key, it.value = nil, nil
+		return false
+	}
+	// Any later Next moves past the last pair, as with leveldb.
+	it.exhausted = true
+	return true
+}
+
+// load copies the current pair of bit into the iterator.
+func (it *iterator) load(bit *badger.Iterator) bool {
+	if !bit.ValidForPrefix(it.prefix) {
+		it.exhausted = true
+		it.key, it.value = nil, nil
+		return false
+	}
+	item := bit.Item()
+	value, err := item.ValueCopy(nil)
+	if err != nil {
+		it.err = err
+		it.key, it.value = nil, nil
+		return false
+	}
+	it.key = item.KeyCopy(nil)
+	it.value = value
+	return true
+}
+
+// Error returns any accumulated error. Exhausting all the key/value pairs
+// is not considered to be an error.
+func (it *iterator) Error() error {
+	return it.err
+}
+
+// Key returns the key of the current key/value pair, or nil if done.
+func (it *iterator) Key() []byte {
+	return it.key
+}
+
+// Value returns the value of the current key/value pair, or nil if done.
+func (it *iterator) Value() []byte {
+	return it.value
+}
+
+// Release releases associated resources. Release should always succeed and can
+// be called multiple times without causing error.
+func (it *iterator) Release() {
+	if it.it != nil {
+		it.it.Close()
+		it.it = nil
+		it.txn.Discard()
+	}
+	it.exhausted = true
+	it.key, it.value = nil, nil
+}
+
+// prefixUpperBound returns the smallest key that sorts after every key starting
+// with prefix, or nil if there is no such key.
+func prefixUpperBound(prefix []byte) []byte {
+	for i := len(prefix) - 1; i >= 0; i-- {
+		if prefix[i] < 0xff {
+			limit := make([]byte, i+1)
+			copy(limit, prefix)
+			limit[i]++
+			return limit
+		}
+	}
+	return nil
+}
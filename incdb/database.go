@@ -59,6 +59,8 @@ type Database interface {
 	Backup(backupFolder string) error
 	LatestBackup(backupFolder string) (int, string)
 	PreloadBackup(backupFile string) error
+	CommitPreload() error
+	RevertPreload() error
 	ReOpen() error
 	Clear() error
 }
@@ -0,0 +1,390 @@
+// Package dbtest contains the conformance suite every incdb driver must pass.
+//
+// A driver runs it from its own tests:
+//
+//	func TestConformance(t *testing.T) {
+//		dbtest.TestDatabaseSuite(t, "leveldb")
+//	}
+package dbtest
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/incdb"
+)
+
+// TestDatabaseSuite runs every conformance test against the driver registered as
+// dbType. Each test gets a fresh database in its own temporary directory.
+func TestDatabaseSuite(t *testing.T, dbType string) {
+	tests := []struct {
+		name string
+		fn   func(t *testing.T, db incdb.Database, dbPath string)
+	}{
+		{"KeyValue", testKeyValue},
+		{"Batch", testBatch},
+		{"BatchReplay", testBatchReplay},
+		{"Iterator", testIterator},
+		{"IteratorLast", testIteratorLast},
+		{"StatAndCompact", testStatAndCompact},
+		{"ReOpen", testReOpen},
+		{"Clear", testClear},
+		{"BackupAndPreload", testBackupAndPreload},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir, err := ioutil.TempDir(os.TempDir(), "incdb_"+dbType+"_")
+			if err != nil {
+				t.Fatalf("failed to create temp dir: %+v", err)
+			}
+			defer os.RemoveAll(dir)
+			dbPath := filepath.Join(dir, "db")
+			db, err := incdb.Open(dbType, dbPath)
+			if err != nil {
+				t.Fatalf("could not open %s at %s: %+v", dbType, dbPath, err)
+			}
+			defer db.Close()
+			tt.fn(t, db, dbPath)
+		})
+	}
+}
+
+func testKeyValue(t *testing.T, db incdb.Database, dbPath string) {
+	key, value := []byte("key"), []byte("value")
+
+	if has, err := db.Has(key); err != nil || has {
+		t.Fatalf("Has on empty db = %v, %v; want false, nil", has, err)
+	}
+	if _, err := db.Get(key); err == nil {
+		t.Fatalf("Get of a missing key must return an error")
+	}
+	if err := db.Put(key, value); err != nil {
+		t.Fatalf("Put: %+v", err)
+	}
+	if has, err := db.Has(key); err != nil || !has {
+		t.Fatalf("Has after Put = %v, %v; want true, nil", has, err)
+	}
+	got, err := db.Get(key)
+	if err != nil || !bytes.Equal(got, value) {
+		t.Fatalf("Get after Put = %x, %v; want %x, nil", got, err, value)
+	}
+
+	// Overwrite and empty values
+	if err := db.Put(key, []byte{}); err != nil {
+		t.Fatalf("Put empty value: %+v", err)
+	}
+	got, err = db.Get(key)
+	if err != nil || len(got) != 0 {
+		t.Fatalf("Get empty value = %x, %v; want empty, nil", got, err)
+	}
+
+	if err := db.Delete(key); err != nil {
+		t.Fatalf("Delete: %+v", err)
+	}
+	if has, err := db.Has(key); err != nil || has {
+		t.Fatalf("Has after Delete = %v, %v; want false, nil", has, err)
+	}
+	if err := db.Delete([]byte("missing")); err != nil {
+		t.Fatalf("Delete of a missing key must succeed: %+v", err)
+	}
+}
+
+func testBatch(t *testing.T, db incdb.Database, dbPath string) {
+	if err := db.Put([]byte("deleted"), []byte("x")); err != nil {
+		t.Fatalf("Put: %+v", err)
+	}
+	b := db.NewBatch()
+	for i := 0; i < 10; i++ {
+		if err := b.Put([]byte(fmt.Sprintf("key%d", i)), []byte{byte(i), byte(i)}); err != nil {
+			t.Fatalf("batch Put: %+v", err)
+		}
+	}
+	if err := b.Delete([]byte("deleted")); err != nil {
+		t.Fatalf("batch Delete: %+v", err)
+	}
+	if b.ValueSize() == 0 {
+		t.Fatalf("ValueSize must grow with queued writes")
+	}
+	if has, _ := db.Has([]byte("key0")); has {
+		t.Fatalf("batch writes must not be visible before Write")
+	}
+	if err := b.Write(); err != nil {
+		t.Fatalf("batch Write: %+v", err)
+	}
+	for i := 0; i < 10; i++ {
+		got, err := db.Get([]byte(fmt.Sprintf("key%d", i)))
+		if err != nil || !bytes.Equal(got, []byte{byte(i), byte(i)}) {
+			t.Fatalf("Get key%d = %x, %v", i, got, err)
+		}
+	}
+	if has, _ := db.Has([]byte("deleted")); has {
+		t.Fatalf("batch Delete was not applied")
+	}
+
+	b.Reset()
+	if b.ValueSize() != 0 {
+		t.Fatalf("ValueSize after Reset = %d; want 0", b.ValueSize())
+	}
+	if err := b.Put([]byte("afterReset"), []byte("y")); err != nil {
+		t.Fatalf("batch Put: %+v", err)
+	}
+	if err := b.Write(); err != nil {
+		t.Fatalf("batch Write: %+v", err)
+	}
+	if has, _ := db.Has([]byte("afterReset")); !has {
+		t.Fatalf("batch is not reusable after Reset")
+	}
+}
+
+func testBatchReplay(t *testing.T, db incdb.Database, dbPath string) {
+	b := db.NewBatch()
+	b.Put([]byte("a"), []byte("1"))
+	b.Put([]byte("b"), []byte("2"))
+	b.Delete([]byte("a"))
+
+	w := &recorder{}
+	if err := b.Replay(w); err != nil {
+		t.Fatalf("Replay: %+v", err)
+	}
+	want := []string{"put a 1", "put b 2", "delete a"}
+	if len(w.ops) != len(want) {
+		t.Fatalf("Replay ops = %v; want %v", w.ops, want)
+	}
+	for i := range want {
+		if w.ops[i] != want[i] {
+			t.Fatalf("Replay ops = %v; want %v", w.ops, want)
+		}
+	}
+}
+
+func testIterator(t *testing.T, db incdb.Database, dbPath string) {
+	keys := []string{"1", "2", "3", "5", "10", "11", "12", "22", "222"}
+	for _, k := range keys {
+		if err := db.Put([]byte(k), []byte("v"+k)); err != nil {
+			t.Fatalf("Put: %+v", err)
+		}
+	}
+	sorted := append([]string{}, keys...)
+	sort.Strings(sorted)
+
+	tests := []struct {
+		name   string
+		it     incdb.Iterator
+		expect []string
+	}{
+		{"All", db.NewIterator(), sorted},
+		{"Start", db.NewIteratorWithStart([]byte("2")), []string{"2", "22", "222", "3", "5"}},
+		{"StartMissing", db.NewIteratorWithStart([]byte("4")), []string{"5"}},
+		{"Prefix", db.NewIteratorWithPrefix([]byte("1")), []string{"1", "10", "11", "12"}},
+		{"PrefixMissing", db.NewIteratorWithPrefix([]byte("4")), nil},
+		{"PrefixStart", db.NewIteratorWithPrefixStart([]byte("1"), []byte("1")), []string{"11", "12"}},
+		{"PrefixStartMissing", db.NewIteratorWithPrefixStart([]byte("2"), []byte("1")), []string{"22", "222"}},
+	}
+	for _, tt := range tests {
+		var got []string
+		for tt.it.Next() {
+			got = append(got, string(tt.it.Key()))
+			if string(tt.it.Value()) != "v"+string(tt.it.Key()) {
+				t.Errorf("%s: value of %s = %s", tt.name, tt.it.Key(), tt.it.Value())
+			}
+		}
+		if err := tt.it.Error(); err != nil {
+			t.Errorf("%s: iterator error %+v", tt.name, err)
+		}
+		tt.it.Release()
+		tt.it.Release()
+		if len(got) != len(tt.expect) {
+			t.Errorf("%s: keys = %v; want %v", tt.name, got, tt.expect)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.expect[i] {
+				t.Errorf("%s: keys = %v; want %v", tt.name, got, tt.expect)
+				break
+			}
+		}
+	}
+}
+
+func testIteratorLast(t *testing.T, db incdb.Database, dbPath string) {
+	for _, k := range []string{"a1", "a2", "b1", "b2", "c1"} {
+		db.Put([]byte(k), []byte(k))
+	}
+	tests := []struct {
+		name   string
+		it     incdb.Iterator
+		expect string
+	}{
+		{"All", db.NewIterator(), "c1"},
+		{"Start", db.NewIteratorWithStart([]byte("b")), "c1"},
+		{"Prefix", db.NewIteratorWithPrefix([]byte("b")), "b2"},
+		{"PrefixMissing", db.NewIteratorWithPrefix([]byte("d")), ""},
+	}
+	for _, tt := range tests {
+		ok := tt.it.Last()
+		if ok != (tt.expect != "") || string(tt.it.Key()) != tt.expect {
+			t.Errorf("%s: Last = %v, key %s; want %s", tt.name, ok, tt.it.Key(), tt.expect)
+		}
+		tt.it.Release()
+	}
+}
+
+func testStatAndCompact(t *testing.T, db incdb.Database, dbPath string) {
+	for i := 0; i < 100; i++ {
+		db.Put([]byte(fmt.Sprintf("key%03d", i)), bytes.Repeat([]byte{byte(i)}, 100))
+	}
+	for i := 0; i < 50; i++ {
+		db.Delete([]byte(fmt.Sprintf("key%03d", i)))
+	}
+	if _, err := db.Stat("leveldb.stats"); err != nil {
+		t.Errorf("Stat: %+v", err)
+	}
+	if err := db.Compact(nil, nil); err != nil {
+		t.Fatalf("Compact: %+v", err)
+	}
+	got, err := db.Get([]byte("key099"))
+	if err != nil || !bytes.Equal(got, bytes.Repeat([]byte{99}, 100)) {
+		t.Fatalf("Get after Compact = %x, %v", got, err)
+	}
+	if has, _ := db.Has([]byte("key000")); has {
+		t.Fatalf("Compact resurrected a deleted key")
+	}
+}
+
+func testReOpen(t *testing.T, db incdb.Database, dbPath string) {
+	db.Put([]byte("persist"), []byte("me"))
+	if err := db.Close(); err != nil {
+		t.Fatalf("Close: %+v", err)
+	}
+	if err := db.ReOpen(); err != nil {
+		t.Fatalf("ReOpen: %+v", err)
+	}
+	got, err := db.Get([]byte("persist"))
+	if err != nil || string(got) != "me" {
+		t.Fatalf("Get after ReOpen = %s, %v", got, err)
+	}
+}
+
+func testClear(t *testing.T, db incdb.Database, dbPath string) {
+	db.Put([]byte("gone"), []byte("soon"))
+	if err := db.Close(); err != nil {
+		t.Fatalf("Close: %+v", err)
+	}
+	if err := db.Clear(); err != nil {
+		t.Fatalf("Clear: %+v", err)
+	}
+	if err := db.ReOpen(); err != nil {
+		t.Fatalf("ReOpen: %+v", err)
+	}
+	if has, _ := db.Has([]byte("gone")); has {
+		t.Fatalf("Clear did not remove the data")
+	}
+}
+
+func testBackupAndPreload(t *testing.T, db incdb.Database, dbPath string) {
+	const backupFolder = "../backup"
+
+	if epoch, _ := db.LatestBackup(backupFolder); epoch != 0 {
+		t.Fatalf("LatestBackup without backups = %d; want 0", epoch)
+	}
+	for epoch := 1; epoch <= 3; epoch++ {
+		db.Put([]byte("epoch"), []byte{byte(epoch)})
+		if err := db.Backup(fmt.Sprintf("%s/%d", backupFolder, epoch)); err != nil {
+			t.Fatalf("Backup epoch %d: %+v", epoch, err)
+		}
+	}
+	// The database stays usable after a backup
+	db.Put([]byte("epoch"), []byte{4})
+	got, err := db.Get([]byte("epoch"))
+	if err != nil || !bytes.Equal(got, []byte{4}) {
+		t.Fatalf("Get after Backup = %x, %v", got, err)
+	}
+
+	// Only the latest two snapshots are kept
+	files, err := ioutil.ReadDir(filepath.Join(dbPath, backupFolder))
+	if err != nil {
+		t.Fatalf("read backup folder: %+v", err)
+	}
+	if len(files) != 2 {
+		t.Fatalf("backup folder has %d snapshots; want 2", len(files))
+	}
+	epoch, backupFile := db.LatestBackup(backupFolder)
+	if epoch != 3 || backupFile == "" {
+		t.Fatalf("LatestBackup = %d, %s; want 3", epoch, backupFile)
+	}
+
+	if err := db.Close(); err != nil {
+		t.Fatalf("Close: %+v", err)
+	}
+	if err := db.PreloadBackup(backupFile); err != nil {
+		t.Fatalf("PreloadBackup: %+v", err)
+	}
+	if err := db.ReOpen(); err != nil {
+		t.Fatalf("ReOpen: %+v", err)
+	}
+	got, err = db.Get([]byte("epoch"))
+	if err != nil || !bytes.Equal(got, []byte{3}) {
+		t.Fatalf("Get after PreloadBackup = %x, %v; want 03", got, err)
+	}
+
+	// A preload can be reverted to the database it replaced
+	if err := db.Close(); err != nil {
+		t.Fatalf("Close: %+v", err)
+	}
+	if err := db.RevertPreload(); err != nil {
+		t.Fatalf("RevertPreload: %+v", err)
+	}
+	if err := db.ReOpen(); err != nil {
+		t.Fatalf("ReOpen: %+v", err)
+	}
+	got, err = db.Get([]byte("epoch"))
+	if err != nil || !bytes.Equal(got, []byte{4}) {
+		t.Fatalf("Get after RevertPreload = %x, %v; want 04", got, err)
+	}
+
+	// Once committed, it cannot be reverted anymore
+	if err := db.Close(); err != nil {
+		t.Fatalf("Close: %+v", err)
+	}
+	if err := db.PreloadBackup(backupFile); err != nil {
+		t.Fatalf("PreloadBackup: %+v", err)
+	}
+	if err := db.ReOpen(); err != nil {
+		t.Fatalf("ReOpen: %+v", err)
+	}
+	if err := db.CommitPreload(); err != nil {
+		t.Fatalf("CommitPreload: %+v", err)
+	}
+	if err := db.RevertPreload(); err == nil {
+		t.Fatalf("RevertPreload after CommitPreload: expected error")
+	}
+	got, err = db.Get([]byte("epoch"))
+	if err != nil || !bytes.Equal(got, []byte{3}) {
+		t.Fatalf("Get after CommitPreload = %x, %v; want 03", got, err)
+	}
+
+	db.RemoveBackup(fmt.Sprintf("%s/%d", backupFolder, 3))
+	if epoch, _ := db.LatestBackup(backupFolder); epoch != 2 {
+		t.Fatalf("LatestBackup after RemoveBackup = %d; want 2", epoch)
+	}
+}
+
+// recorder is a KeyValueWriter that records the operations replayed into it.
+type recorder struct {
+	ops []string
+}
+
+func (r *recorder) Put(key []byte, value []byte) error {
+	r.ops = append(r.ops, fmt.Sprintf("put %s %s", key, value))
+	return nil
+}
+
+func (r *recorder) Delete(key []byte) error {
+	r.ops = append(r.ops, fmt.Sprintf("delete %s", key))
+	return nil
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/incdb"
+	"github.com/incognitochain/incognito-chain/incdb/dbtest"
 	_ "github.com/incognitochain/incognito-chain/incdb/lvdb"
 	"github.com/stretchr/testify/assert"
 )
@@ -66,16 +67,12 @@ func TestDb_Base(t *testing.T) {
 		assert.Equal(t, err, nil)
 		assert.Equal(t, has, false)
 
-		batchData := []incdb.BatchData{}
-		batchData = append(batchData, incdb.BatchData{
-			Key:   []byte("abc1"),
-			Value: []byte("abc1"),
-		})
-		batchData = append(batchData, incdb.BatchData{
-			Key:   []byte("abc2"),
-			Value: []byte("abc2"),
-		})
-		err = db.PutBatch(batchData)
+		batch := db.NewBatch()
+		err = batch.Put([]byte("abc1"), []byte("abc1"))
+		assert.Equal(t, err, nil)
+		err = batch.Put([]byte("abc2"), []byte("abc2"))
+		assert.Equal(t, err, nil)
+		err = batch.Write()
 		assert.Equal(t, err, nil)
 		v, err := db.Get([]byte("abc2"))
 		assert.Equal(t, err, nil)
@@ -84,3 +81,7 @@ func TestDb_Base(t *testing.T) {
 		t.Error("DB is not open")
 	}
 }
+
+func TestDb_Conformance(t *testing.T) {
+	dbtest.TestDatabaseSuite(t, "leveldb")
+}
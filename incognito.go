@@ -16,9 +16,12 @@ import (
 	"github.com/incognitochain/incognito-chain/pruner"
 
 	"github.com/incognitochain/incognito-chain/config"
+	"github.com/incognitochain/incognito-chain/metrics"
 	"github.com/incognitochain/incognito-chain/metrics/monitor"
+	"github.com/incognitochain/incognito-chain/metrics/prometheus"
 	"github.com/incognitochain/incognito-chain/portal"
 	bnbrelaying "github.com/incognitochain/incognito-chain/relaying/bnb"
+	"github.com/incognitochain/incognito-chain/tracing"
 	"github.com/incognitochain/incognito-chain/utils"
 
 	"github.com/btcsuite/btcd/chaincfg"
@@ -26,9 +29,11 @@ import (
 	"github.com/incognitochain/incognito-chain/databasemp"
 	_ "github.com/incognitochain/incognito-chain/databasemp/lvdb"
 	"github.com/incognitochain/incognito-chain/incdb"
+	_ "github.com/incognitochain/incognito-chain/incdb/badgerdb"
 	_ "github.com/incognitochain/incognito-chain/incdb/lvdb"
 	"github.com/incognitochain/incognito-chain/limits"
 	btcrelaying "github.com/incognitochain/incognito-chain/relaying/btc"
+	ltcrelaying "github.com/incognitochain/incognito-chain/relaying/ltc"
 	"github.com/incognitochain/incognito-chain/wallet"
 )
 
@@ -56,6 +61,28 @@ func getBTCRelayingChain(btcRelayingChainID, btcDataFolderName string) (*btcrela
 	)
 }
 
+// getLTCRelayingChain returns a nil chain for networks without ltc relaying chain (no pLTC token)
+func getLTCRelayingChain(ltcRelayingChainID, ltcDataFolderName string) (*btcrelaying.BlockChain, error) {
+	if ltcRelayingChainID == "" {
+		return nil, nil
+	}
+	relayingChainParams := map[string]*chaincfg.Params{
+		portal.TestnetLTCChainID: ltcrelaying.GetTestNet4Params(),
+	}
+	relayingChainGenesisBlkHeight := map[string]int32{
+		portal.TestnetLTCChainID: int32(0),
+	}
+	params, ok := relayingChainParams[ltcRelayingChainID]
+	if !ok {
+		return nil, fmt.Errorf("ltc relaying chain id %v is not supported", ltcRelayingChainID)
+	}
+	return ltcrelaying.GetChainV2(
+		filepath.Join(config.Config().DataDir, ltcDataFolderName),
+		params,
+		relayingChainGenesisBlkHeight[ltcRelayingChainID],
+	)
+}
+
 func getBNBRelayingChainState(bnbRelayingChainID string) (*bnbrelaying.BNBChainState, error) {
 	bnbChainState := new(bnbrelaying.BNBChainState)
 	err := bnbChainState.LoadBNBChainState(
@@ -111,10 +138,10 @@ func mainMaster(serverChan chan<- *Server) error {
 	if interruptRequested(interrupt) {
 		return nil
 	}
-	db, err := incdb.OpenMultipleDB("leveldb", filepath.Join(cfg.DataDir, cfg.DatabaseDir))
+	db, err := incdb.OpenMultipleDB(cfg.DatabaseType, filepath.Join(cfg.DataDir, cfg.DatabaseDir))
 	// Create db and use it.
 	if err != nil {
-		Logger.log.Error("could not open connection to leveldb")
+		Logger.log.Errorf("could not open connection to %v", cfg.DatabaseType)
 		Logger.log.Error(err)
 		panic(err)
 	}
@@ -123,6 +150,13 @@ func mainMaster(serverChan chan<- *Server) error {
 	if p == nil { //cannot init pruner
 		return nil
 	}
+	//archive node must keep the state of every block
+	if config.Config().ArchiveMode {
+		if err := p.VerifyArchive(); err != nil {
+			Logger.log.Error(err)
+			return err
+		}
+	}
 	//check if offline prune flag is available
 	if config.Config().OfflinePrune {
 		p.OfflinePrune()
@@ -130,9 +164,9 @@ func mainMaster(serverChan chan<- *Server) error {
 	}
 
 	// Create db for mempool and use it
-	consensusDB, err := incdb.Open("leveldb", filepath.Join(cfg.DataDir, "consensus"))
+	consensusDB, err := incdb.Open(cfg.DatabaseType, filepath.Join(cfg.DataDir, "consensus"))
 	if err != nil {
-		Logger.log.Error("could not open connection to leveldb")
+		Logger.log.Errorf("could not open connection to %v", cfg.DatabaseType)
 		Logger.log.Error(err)
 		panic(err)
 	}
@@ -189,6 +223,24 @@ func mainMaster(serverChan chan<- *Server) error {
 		db.Close()
 	}()
 
+	// Create ltcrelaying chain
+	ltcChain, err := getLTCRelayingChain(
+		portal.GetPortalParams().RelayingParam.LTCRelayingHeaderChainID,
+		portal.GetPortalParams().RelayingParam.LTCDataFolderName,
+	)
+	if err != nil {
+		Logger.log.Error("could not get or create ltc relaying chain")
+		Logger.log.Error(err)
+		panic(err)
+	}
+	if ltcChain != nil {
+		defer func() {
+			Logger.log.Warn("Gracefully shutting down the ltc database...")
+			db := ltcChain.GetDB()
+			db.Close()
+		}()
+	}
+
 	// Create bnbrelaying chain state
 	bnbChainState, err := getBNBRelayingChainState(portal.GetPortalParams().RelayingParam.BNBRelayingHeaderChainID)
 	if err != nil {
@@ -200,9 +252,9 @@ func mainMaster(serverChan chan<- *Server) error {
 	useOutcoinDb := len(cfg.UseOutcoinDatabase) >= 1
 	var outcoinDb *incdb.Database = nil
 	if useOutcoinDb {
-		temp, err := incdb.Open("leveldb", filepath.Join(cfg.DataDir, cfg.OutcoinDatabaseDir))
+		temp, err := incdb.Open(cfg.DatabaseType, filepath.Join(cfg.DataDir, cfg.OutcoinDatabaseDir))
 		if err != nil {
-			Logger.log.Error("could not open leveldb instance for coin storing")
+			Logger.log.Errorf("could not open %v instance for coin storing", cfg.DatabaseType)
 		}
 		outcoinDb = &temp
 	}
@@ -210,7 +262,7 @@ func mainMaster(serverChan chan<- *Server) error {
 	// Create server and start it.
 	server := Server{}
 	server.wallet = walletObj
-	err = server.NewServer(cfg.Listener, db, dbmp, outcoinDb, cfg.NumIndexerWorkers, cfg.IndexerAccessTokens, version, btcChain, bnbChainState, p, interrupt)
+	err = server.NewServer(cfg.Listener, db, dbmp, outcoinDb, cfg.NumIndexerWorkers, cfg.IndexerAccessTokens, version, btcChain, ltcChain, bnbChainState, p, interrupt)
 	if err != nil {
 		Logger.log.Errorf("Unable to start server on %+v", cfg.Listener)
 		Logger.log.Error(err)
@@ -220,6 +272,24 @@ func mainMaster(serverChan chan<- *Server) error {
 	// Init EVM caller cacher
 	evmcaller.InitCacher()
 
+	// Init block lifecycle tracing
+	exporters := tracing.MultiExporter{}
+	if cfg.TraceFile != "" {
+		fileExporter, err := tracing.NewFileExporter(cfg.TraceFile)
+		if err != nil {
+			Logger.log.Error(err)
+			return err
+		}
+		exporters = append(exporters, fileExporter)
+	}
+	if cfg.TraceOTLPEndpoint != "" {
+		exporters = append(exporters, tracing.NewOTLPExporter(cfg.TraceOTLPEndpoint, "incognito-node"))
+	}
+	if len(exporters) > 0 {
+		tracing.Start(exporters)
+		defer tracing.Stop()
+	}
+
 	defer func() {
 		Logger.log.Warn("Gracefully shutting down the server...")
 		server.Stop()
@@ -236,6 +306,16 @@ func mainMaster(serverChan chan<- *Server) error {
 	if env != "" {
 		Logger.log.Criticalf("Metric Server: %+v", os.Getenv("GrafanaURL"))
 	}
+	if cfg.MetricsListener != "" {
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", prometheus.Handler(metrics.DefaultRegistry))
+		go func() {
+			Logger.log.Infof("Prometheus metrics listen on %v", cfg.MetricsListener)
+			if err := http.ListenAndServe(cfg.MetricsListener, mux); err != nil {
+				Logger.log.Error(err)
+			}
+		}()
+	}
 	// Wait until the interrupt signal is received from an OS signal or
 	// shutdown is requested through one of the subsystems such as the RPC
 	// server.
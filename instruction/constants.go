@@ -22,6 +22,7 @@ const (
 	OUTDATED_DEQUEUE_REASON        = "outdated"
 	ACCEPT_BLOCK_REWARD_V3_ACTION  = "acceptblockrewardv3"
 	SHARD_RECEIVE_REWARD_V3_ACTION = "shardreceiverewardv3"
+	SLASH_EVIDENCE_ACTION          = "slashevidence"
 
 	SHARD_RECEIVE_REWARD_V1_ACTION = 43
 	ACCEPT_BLOCK_REWARD_V1_ACTION  = 37
@@ -0,0 +1,127 @@
+package instruction
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"github.com/incognitochain/incognito-chain/incognitokey"
+)
+
+var (
+	ErrSlashEvidenceInstruction = errors.New("slash evidence instruction error")
+)
+
+// SlashEvidenceVote is one of the conflicting votes of a slash evidence
+type SlashEvidenceVote struct {
+	BlockHeader []byte // JSON encoded header of the shard block the vote is for
+	Signature   []byte // BLS signature of the validator on the propose hash of the block
+}
+
+// SlashEvidenceInstruction : evidence that a shard validator voted for two different blocks of the same height proposed in the same timeslot
+// format: "slashevidence", "0", "key", "{vote1}", "{vote2}"
+type SlashEvidenceInstruction struct {
+	ChainID         int
+	PublicKey       string
+	PublicKeyStruct incognitokey.CommitteePublicKey
+	Votes           [2]SlashEvidenceVote
+}
+
+func NewSlashEvidenceInstructionWithValue(chainID int, publicKey string, votes [2]SlashEvidenceVote) *SlashEvidenceInstruction {
+	slashEvidenceInstruction := &SlashEvidenceInstruction{}
+	slashEvidenceInstruction.SetChainID(chainID)
+	slashEvidenceInstruction.SetPublicKey(publicKey)
+	slashEvidenceInstruction.SetVotes(votes)
+	return slashEvidenceInstruction
+}
+
+func NewSlashEvidenceInstruction() *SlashEvidenceInstruction {
+	return &SlashEvidenceInstruction{}
+}
+
+func (s *SlashEvidenceInstruction) GetType() string {
+	return SLASH_EVIDENCE_ACTION
+}
+
+func (s *SlashEvidenceInstruction) IsEmpty() bool {
+	return reflect.DeepEqual(s, NewSlashEvidenceInstruction()) || s.PublicKey == ""
+}
+
+func (s *SlashEvidenceInstruction) ToString() []string {
+	slashEvidenceInstructionStr := []string{SLASH_EVIDENCE_ACTION}
+	slashEvidenceInstructionStr = append(slashEvidenceInstructionStr, fmt.Sprintf("%v", s.ChainID))
+	slashEvidenceInstructionStr = append(slashEvidenceInstructionStr, s.PublicKey)
+	for _, vote := range s.Votes {
+		voteBytes, _ := json.Marshal(vote)
+		slashEvidenceInstructionStr = append(slashEvidenceInstructionStr, string(voteBytes))
+	}
+	return slashEvidenceInstructionStr
+}
+
+func (s *SlashEvidenceInstruction) SetChainID(chainID int) *SlashEvidenceInstruction {
+	s.ChainID = chainID
+	return s
+}
+
+func (s *SlashEvidenceInstruction) SetPublicKey(publicKey string) *SlashEvidenceInstruction {
+	s.PublicKey = publicKey
+	publicKeyStructs, _ := incognitokey.CommitteeBase58KeyListToStruct([]string{publicKey})
+	if len(publicKeyStructs) == 1 {
+		s.PublicKeyStruct = publicKeyStructs[0]
+	}
+	return s
+}
+
+func (s *SlashEvidenceInstruction) SetVotes(votes [2]SlashEvidenceVote) *SlashEvidenceInstruction {
+	s.Votes = votes
+	return s
+}
+
+func ValidateAndImportSlashEvidenceInstructionFromString(instruction []string) (*SlashEvidenceInstruction, error) {
+	if err := ValidateSlashEvidenceInstructionSanity(instruction); err != nil {
+		return nil, err
+	}
+	return ImportSlashEvidenceInstructionFromString(instruction), nil
+}
+
+// ImportSlashEvidenceInstructionFromString is unsafe method
+func ImportSlashEvidenceInstructionFromString(instruction []string) *SlashEvidenceInstruction {
+	slashEvidenceInstruction := NewSlashEvidenceInstruction()
+	chainID, _ := strconv.Atoi(instruction[1])
+	slashEvidenceInstruction.SetChainID(chainID)
+	slashEvidenceInstruction.SetPublicKey(instruction[2])
+	votes := [2]SlashEvidenceVote{}
+	for i := range votes {
+		_ = json.Unmarshal([]byte(instruction[3+i]), &votes[i])
+	}
+	slashEvidenceInstruction.SetVotes(votes)
+	return slashEvidenceInstruction
+}
+
+// ValidateSlashEvidenceInstructionSanity ...
+func ValidateSlashEvidenceInstructionSanity(instruction []string) error {
+	if len(instruction) != 5 {
+		return fmt.Errorf("%+v: invalid length, %+v", ErrSlashEvidenceInstruction, instruction)
+	}
+	if instruction[0] != SLASH_EVIDENCE_ACTION {
+		return fmt.Errorf("%+v: invalid slash evidence action, %+v", ErrSlashEvidenceInstruction, instruction)
+	}
+	if _, err := strconv.Atoi(instruction[1]); err != nil {
+		return fmt.Errorf("%+v: invalid slash evidence shard ID, err %+v, %+v", ErrSlashEvidenceInstruction, err, instruction)
+	}
+	if _, err := incognitokey.CommitteeBase58KeyListToStruct([]string{instruction[2]}); err != nil {
+		return fmt.Errorf("%+v: invalid slash evidence public key, err %+v, %+v", ErrSlashEvidenceInstruction, err, instruction)
+	}
+	for _, voteStr := range instruction[3:] {
+		vote := SlashEvidenceVote{}
+		if err := json.Unmarshal([]byte(voteStr), &vote); err != nil {
+			return fmt.Errorf("%+v: invalid slash evidence vote, err %+v, %+v", ErrSlashEvidenceInstruction, err, instruction)
+		}
+		if len(vote.BlockHeader) == 0 || len(vote.Signature) == 0 {
+			return fmt.Errorf("%+v: empty slash evidence vote, %+v", ErrSlashEvidenceInstruction, instruction)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package instruction
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSlashEvidenceInstruction_ToString(t *testing.T) {
+
+	initPublicKey()
+
+	votes := [2]SlashEvidenceVote{
+		{BlockHeader: []byte(`{"Height":10}`), Signature: []byte{1, 2}},
+		{BlockHeader: []byte(`{"Height":11}`), Signature: []byte{3, 4}},
+	}
+	inst := NewSlashEvidenceInstructionWithValue(1, key1, votes)
+	want := []string{
+		SLASH_EVIDENCE_ACTION,
+		"1",
+		key1,
+		`{"BlockHeader":"eyJIZWlnaHQiOjEwfQ==","Signature":"AQI="}`,
+		`{"BlockHeader":"eyJIZWlnaHQiOjExfQ==","Signature":"AwQ="}`,
+	}
+	if got := inst.ToString(); !reflect.DeepEqual(got, want) {
+		t.Errorf("SlashEvidenceInstruction.ToString() = %v, want %v", got, want)
+	}
+	if !reflect.DeepEqual(inst.PublicKeyStruct, *incKey1) {
+		t.Errorf("SlashEvidenceInstruction.PublicKeyStruct = %v, want %v", inst.PublicKeyStruct, *incKey1)
+	}
+}
+
+func TestValidateAndImportSlashEvidenceInstructionFromString(t *testing.T) {
+
+	initPublicKey()
+
+	vote1 := `{"BlockHeader":"eyJIZWlnaHQiOjEwfQ==","Signature":"AQI="}`
+	vote2 := `{"BlockHeader":"eyJIZWlnaHQiOjExfQ==","Signature":"AwQ="}`
+	type args struct {
+		instruction []string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *SlashEvidenceInstruction
+		wantErr bool
+	}{
+		{
+			name: "Length of instruction != 5",
+			args: args{
+				instruction: []string{SLASH_EVIDENCE_ACTION, "1", key1, vote1},
+			},
+			wantErr: true,
+		},
+		{
+			name: "instruction[0] != SLASH_EVIDENCE_ACTION",
+			args: args{
+				instruction: []string{FINISH_SYNC_ACTION, "1", key1, vote1, vote2},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Invalid shard ID",
+			args: args{
+				instruction: []string{SLASH_EVIDENCE_ACTION, "a", key1, vote1, vote2},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Invalid public key",
+			args: args{
+				instruction: []string{SLASH_EVIDENCE_ACTION, "1", "key", vote1, vote2},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Invalid vote",
+			args: args{
+				instruction: []string{SLASH_EVIDENCE_ACTION, "1", key1, vote1, "vote"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Empty vote",
+			args: args{
+				instruction: []string{SLASH_EVIDENCE_ACTION, "1", key1, vote1, `{"BlockHeader":"eyJIZWlnaHQiOjExfQ=="}`},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Valid Input",
+			args: args{
+				instruction: []string{SLASH_EVIDENCE_ACTION, "1", key1, vote1, vote2},
+			},
+			want: &SlashEvidenceInstruction{
+				ChainID:         1,
+				PublicKey:       key1,
+				PublicKeyStruct: *incKey1,
+				Votes: [2]SlashEvidenceVote{
+					{BlockHeader: []byte(`{"Height":10}`), Signature: []byte{1, 2}},
+					{BlockHeader: []byte(`{"Height":11}`), Signature: []byte{3, 4}},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ValidateAndImportSlashEvidenceInstructionFromString(tt.args.instruction)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateAndImportSlashEvidenceInstructionFromString() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ValidateAndImportSlashEvidenceInstructionFromString() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
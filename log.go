@@ -9,6 +9,7 @@ import (
 	"github.com/incognitochain/incognito-chain/metadata/evmcaller"
 	"github.com/incognitochain/incognito-chain/pruner"
 	"github.com/incognitochain/incognito-chain/syncker/finishsync"
+	"github.com/incognitochain/incognito-chain/tracing"
 
 	"github.com/incognitochain/incognito-chain/addrmanager"
 	"github.com/incognitochain/incognito-chain/blockchain"
@@ -92,6 +93,7 @@ var (
 	bridgeAggLogger        = backendLog.Logger("BridgeAgg log ", false)
 	finishSyncLogger       = backendLog.Logger("Finish Sync log ", false)
 	prunerLogger           = backendLog.Logger("Pruner log ", false)
+	tracingLogger          = backendLog.Logger("Tracing log ", false)
 
 	portalLogger          = backendLog.Logger("Portal log ", false)
 	portalRelayingLogger  = backendLog.Logger("Portal relaying log ", false)
@@ -154,6 +156,7 @@ func init() {
 	pdex.Logger.Init(pdexLogger)
 	bridgeagg.Logger.Init(bridgeAggLogger)
 	pruner.Logger.Init(prunerLogger)
+	tracing.Logger.Init(tracingLogger)
 
 	portal.Logger.Init(portalLogger)
 	portalrelaying.Logger.Init(portalRelayingLogger)
@@ -206,6 +209,7 @@ var subsystemLoggers = map[string]common.Logger{
 	"PORTALV4PROCESS":   portalV4ProcessLogger,
 	"PORTALV4TOKENS":    portalV4TokenLogger,
 	"EVMCALLER":         evmCallerLogger,
+	"TRACING":           tracingLogger,
 }
 
 // initLogRotator initializes the logging rotater to write logs to logFile and
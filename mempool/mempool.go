@@ -287,7 +287,8 @@ func hasCommitteeRelatedTx(txs ...metadata.Transaction) bool {
 	for _, tx := range txs {
 		if tx.GetMetadata() != nil {
 			switch tx.GetMetadata().GetType() {
-			case metadata.BeaconStakingMeta, metadata.ShardStakingMeta, metadata.StopAutoStakingMeta, metadata.UnStakingMeta:
+			case metadata.BeaconStakingMeta, metadata.ShardStakingMeta, metadata.StopAutoStakingMeta, metadata.UnStakingMeta,
+				metadata.CreateDelegationPoolMeta:
 				return true
 			}
 		}
@@ -90,7 +90,7 @@ func (sbsRes BeaconBlockSalaryRes) ValidateMetadataByItself() bool {
 
 func (sbsRes BeaconBlockSalaryRes) Hash() *common.Hash {
 	record := sbsRes.ProducerAddress.String()
-	record += string(sbsRes.BeaconBlockHeight)
+	record += runeString(sbsRes.BeaconBlockHeight)
 	record += sbsRes.InfoHash.String()
 
 	// final hash
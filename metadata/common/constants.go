@@ -110,6 +110,7 @@ const (
 	// relaying
 	RelayingBNBHeaderMeta = 200
 	RelayingBTCHeaderMeta = 201
+	RelayingLTCHeaderMeta = 211
 
 	PortalTopUpWaitingPortingRequestMeta  = 202
 	PortalTopUpWaitingPortingResponseMeta = 203
@@ -218,6 +219,12 @@ const (
 	BurnForCallRequestMeta      = 348
 	BurnForCallResponseMeta     = 349
 	IssuingReshieldResponseMeta = 350
+
+	// delegation
+	CreateDelegationPoolMeta = 360
+	DelegateStakingMeta      = 361
+	UnbondDelegationMeta     = 362
+	DelegationResponseMeta   = 363
 )
 
 var minerCreatedMetaTypes = []int{
@@ -270,6 +277,7 @@ var minerCreatedMetaTypes = []int{
 	BurningUnifiedTokenResponseMeta,
 	IssuingReshieldResponseMeta,
 	BurnForCallResponseMeta,
+	DelegationResponseMeta,
 }
 
 // Special rules for shardID: stored as 2nd param of instruction of BeaconBlock
@@ -351,6 +359,7 @@ var portalMetaTypesV3 = []int{
 var portalRelayingMetaTypes = []int{
 	RelayingBNBHeaderMeta,
 	RelayingBTCHeaderMeta,
+	RelayingLTCHeaderMeta,
 }
 
 var bridgeMetas = []string{
@@ -107,6 +107,12 @@ const (
 	BridgeAggConvertRequestValidateSanityDataError
 	BridgeAggShieldValidateSanityDataError
 	BridgeAggUnshieldValidateSanityDataError
+
+	// delegation
+	DelegationRequestValidateSanityDataError
+	DelegationRequestValidateTxWithBlockChainError
+	DelegationRequestBuildReqActionsError
+	DelegationRequestDecodeInstructionError
 )
 
 var ErrCodeMessage = map[int]struct {
@@ -207,6 +213,12 @@ var ErrCodeMessage = map[int]struct {
 	BridgeAggConvertRequestValidateSanityDataError: {-12001, "Convert request sanity error"},
 	BridgeAggShieldValidateSanityDataError:         {-12002, "Shield request sanity error"},
 	BridgeAggUnshieldValidateSanityDataError:       {-12003, "Unshield request sanity error"},
+
+	// delegation
+	DelegationRequestValidateSanityDataError:       {-13000, "Delegation request validate sanity data error"},
+	DelegationRequestValidateTxWithBlockChainError: {-13001, "Delegation request validate tx with blockchain error"},
+	DelegationRequestBuildReqActionsError:          {-13002, "Delegation request build request action error"},
+	DelegationRequestDecodeInstructionError:        {-13003, "Delegation request decode instruction error"},
 }
 
 type MetadataTxError struct {
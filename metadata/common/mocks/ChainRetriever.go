@@ -193,6 +193,22 @@ func (_m *ChainRetriever) GetFinalBeaconHeight() uint64 {
 	return r0
 }
 
+// GetLTCHeaderChain provides a mock function with given fields:
+func (_m *ChainRetriever) GetLTCHeaderChain() *btcrelaying.BlockChain {
+	ret := _m.Called()
+
+	var r0 *btcrelaying.BlockChain
+	if rf, ok := ret.Get(0).(func() *btcrelaying.BlockChain); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*btcrelaying.BlockChain)
+		}
+	}
+
+	return r0
+}
+
 // GetLatestBNBBlkHeight provides a mock function with given fields:
 func (_m *ChainRetriever) GetLatestBNBBlkHeight() (int64, error) {
 	ret := _m.Called()
@@ -28,6 +28,20 @@ func (_m *ShardViewRetriever) GetBeaconHeight() uint64 {
 	return r0
 }
 
+// GetBlockVersion provides a mock function with given fields:
+func (_m *ShardViewRetriever) GetBlockVersion() int {
+	ret := _m.Called()
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func() int); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	return r0
+}
+
 // GetCopiedFeatureStateDB provides a mock function with given fields:
 func (_m *ShardViewRetriever) GetCopiedFeatureStateDB() *statedb.StateDB {
 	ret := _m.Called()
@@ -195,6 +195,7 @@ func buildMetaInfo() {
 		Pdexv3DistributeStakingRewardMeta,
 		Pdexv3WithdrawStakingRewardResponseMeta,
 		IssuingPRVERC20ResponseMeta,
+		DelegationResponseMeta,
 	}
 	metaListNInfo = append(metaListNInfo, ListAndInfo{
 		list: listNNoInput,
@@ -216,6 +217,8 @@ func buildMetaInfo() {
 		PDECrossPoolTradeRequestMeta,
 		PDEWithdrawalRequestMeta,
 		PDEFeeWithdrawalRequestMeta,
+		CreateDelegationPoolMeta,
+		DelegateStakingMeta,
 		PortalCustodianDepositMeta,
 		PortalRequestPortingMeta,
 		PortalUserRequestPTokenMeta,
@@ -235,6 +238,7 @@ func buildMetaInfo() {
 		PortalUnlockOverRateCollateralsMeta,
 		RelayingBNBHeaderMeta,
 		RelayingBTCHeaderMeta,
+		RelayingLTCHeaderMeta,
 		PortalTopUpWaitingPortingRequestMeta,
 
 		IssuingRequestMeta,
@@ -285,6 +289,7 @@ func buildMetaInfo() {
 		WithDrawRewardRequestMeta,
 		StopAutoStakingMeta,
 		UnStakingMeta,
+		UnbondDelegationMeta,
 	}
 
 	metaListNInfo = append(metaListNInfo, ListAndInfo{
@@ -348,6 +353,7 @@ func buildMetaInfo() {
 		Pdexv3MintBlockRewardMeta,
 		Pdexv3DistributeStakingRewardMeta,
 		Pdexv3WithdrawStakingRewardResponseMeta,
+		DelegationResponseMeta,
 	}
 
 	metaListNInfo = append(metaListNInfo, ListAndInfo{
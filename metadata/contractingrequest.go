@@ -94,7 +94,7 @@ func (cReq ContractingRequest) Hash() *common.Hash {
 	record += cReq.BurnerAddress.String()
 	record += cReq.TokenID.String()
 	// TODO: @hung change to record += fmt.Sprint(cReq.BurnedAmount)
-	record += string(cReq.BurnedAmount)
+	record += runeString(cReq.BurnedAmount)
 
 	// final hash
 	hash := common.HashH([]byte(record))
@@ -0,0 +1,108 @@
+package metadata
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/config"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+)
+
+// CreateDelegationPoolMetadata : request of an operator to open a delegation pool for its validator key,
+// the operator makes the first delegation of the pool
+type CreateDelegationPoolMetadata struct {
+	MetadataBase
+	OperatorPaymentAddress string
+	CommitteePublicKey     string
+	// Commission is the part of the pool's reward kept by the operator, in basis points
+	Commission uint64
+	Amount     uint64
+}
+
+func NewCreateDelegationPoolMetadata(
+	operatorPaymentAddress string,
+	committeePublicKey string,
+	commission uint64,
+	amount uint64,
+) *CreateDelegationPoolMetadata {
+	metadataBase := NewMetadataBase(CreateDelegationPoolMeta)
+	return &CreateDelegationPoolMetadata{
+		MetadataBase:           *metadataBase,
+		OperatorPaymentAddress: operatorPaymentAddress,
+		CommitteePublicKey:     committeePublicKey,
+		Commission:             commission,
+		Amount:                 amount,
+	}
+}
+
+func (meta CreateDelegationPoolMetadata) ValidateTxWithBlockChain(tx Transaction, chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, shardID byte, transactionStateDB *statedb.StateDB) (bool, error) {
+	_, has, err := beaconViewRetriever.GetStakerInfo(meta.CommitteePublicKey)
+	if err != nil {
+		return false, NewMetadataTxError(DelegationRequestValidateTxWithBlockChainError, err)
+	}
+	if has {
+		return false, NewMetadataTxError(DelegationRequestValidateTxWithBlockChainError, fmt.Errorf("committee public key %v has staked already", meta.CommitteePublicKey))
+	}
+	_, has, err = statedb.GetDelegationPool(beaconViewRetriever.GetBeaconConsensusStateDB(), meta.CommitteePublicKey)
+	if err != nil {
+		return false, NewMetadataTxError(DelegationRequestValidateTxWithBlockChainError, err)
+	}
+	if has {
+		return false, NewMetadataTxError(DelegationRequestValidateTxWithBlockChainError, fmt.Errorf("delegation pool of %v exists already", meta.CommitteePublicKey))
+	}
+	return true, nil
+}
+
+func (meta CreateDelegationPoolMetadata) ValidateSanityData(chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, beaconHeight uint64, tx Transaction) (bool, bool, error) {
+	if beaconHeight < config.Param().DelegationParam.DelegationHeight {
+		return false, false, NewMetadataTxError(DelegationRequestValidateSanityDataError, fmt.Errorf("delegation is not enabled before beacon height %v", config.Param().DelegationParam.DelegationHeight))
+	}
+	if err := validateDelegationBurnTx(tx, meta.Amount); err != nil {
+		return false, false, NewMetadataTxError(DelegationRequestValidateSanityDataError, err)
+	}
+	if meta.Commission > config.Param().DelegationParam.MaxCommission {
+		return false, false, NewMetadataTxError(DelegationRequestValidateSanityDataError, fmt.Errorf("commission %v is greater than max commission %v", meta.Commission, config.Param().DelegationParam.MaxCommission))
+	}
+	if _, err := AssertPaymentAddressAndTxVersion(meta.OperatorPaymentAddress, tx.GetVersion()); err != nil {
+		return false, false, NewMetadataTxError(DelegationRequestValidateSanityDataError, fmt.Errorf("invalid operator address: %v", err))
+	}
+	if err := validateDelegationCommitteePublicKey(meta.CommitteePublicKey); err != nil {
+		return false, false, NewMetadataTxError(DelegationRequestValidateSanityDataError, err)
+	}
+	return true, true, nil
+}
+
+func (meta CreateDelegationPoolMetadata) ValidateMetadataByItself() bool {
+	return meta.Type == CreateDelegationPoolMeta
+}
+
+func (meta CreateDelegationPoolMetadata) Hash() *common.Hash {
+	record := meta.MetadataBase.Hash().String()
+	record += meta.OperatorPaymentAddress
+	record += meta.CommitteePublicKey
+	record += strconv.FormatUint(meta.Commission, 10)
+	record += strconv.FormatUint(meta.Amount, 10)
+	// final hash
+	hash := common.HashH([]byte(record))
+	return &hash
+}
+
+func (meta *CreateDelegationPoolMetadata) BuildReqActions(tx Transaction, chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, shardID byte, shardHeight uint64) ([][]string, error) {
+	operatorShardID, err := shardIDFromPaymentAddress(meta.OperatorPaymentAddress)
+	if err != nil {
+		return [][]string{}, NewMetadataTxError(DelegationRequestBuildReqActionsError, err)
+	}
+	return buildDelegationReqAction(meta.Type, DelegationContent{
+		CommitteePublicKey: meta.CommitteePublicKey,
+		Address:            meta.OperatorPaymentAddress,
+		Commission:         meta.Commission,
+		Amount:             meta.Amount,
+		TxReqID:            *tx.Hash(),
+		ShardID:            operatorShardID,
+	})
+}
+
+func (meta *CreateDelegationPoolMetadata) CalculateSize() uint64 {
+	return calculateSize(meta)
+}
@@ -0,0 +1,91 @@
+package metadata
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/config"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+)
+
+// DelegateStakingMetadata : request of a delegator to contribute PRV to the stake of a delegation pool
+type DelegateStakingMetadata struct {
+	MetadataBase
+	DelegatorPaymentAddress string
+	CommitteePublicKey      string
+	Amount                  uint64
+}
+
+func NewDelegateStakingMetadata(
+	delegatorPaymentAddress string,
+	committeePublicKey string,
+	amount uint64,
+) *DelegateStakingMetadata {
+	metadataBase := NewMetadataBase(DelegateStakingMeta)
+	return &DelegateStakingMetadata{
+		MetadataBase:            *metadataBase,
+		DelegatorPaymentAddress: delegatorPaymentAddress,
+		CommitteePublicKey:      committeePublicKey,
+		Amount:                  amount,
+	}
+}
+
+func (meta DelegateStakingMetadata) ValidateTxWithBlockChain(tx Transaction, chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, shardID byte, transactionStateDB *statedb.StateDB) (bool, error) {
+	_, has, err := statedb.GetDelegationPool(beaconViewRetriever.GetBeaconConsensusStateDB(), meta.CommitteePublicKey)
+	if err != nil {
+		return false, NewMetadataTxError(DelegationRequestValidateTxWithBlockChainError, err)
+	}
+	if !has {
+		return false, NewMetadataTxError(DelegationRequestValidateTxWithBlockChainError, fmt.Errorf("delegation pool of %v not found", meta.CommitteePublicKey))
+	}
+	return true, nil
+}
+
+func (meta DelegateStakingMetadata) ValidateSanityData(chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, beaconHeight uint64, tx Transaction) (bool, bool, error) {
+	if beaconHeight < config.Param().DelegationParam.DelegationHeight {
+		return false, false, NewMetadataTxError(DelegationRequestValidateSanityDataError, fmt.Errorf("delegation is not enabled before beacon height %v", config.Param().DelegationParam.DelegationHeight))
+	}
+	if err := validateDelegationBurnTx(tx, meta.Amount); err != nil {
+		return false, false, NewMetadataTxError(DelegationRequestValidateSanityDataError, err)
+	}
+	if _, err := AssertPaymentAddressAndTxVersion(meta.DelegatorPaymentAddress, tx.GetVersion()); err != nil {
+		return false, false, NewMetadataTxError(DelegationRequestValidateSanityDataError, fmt.Errorf("invalid delegator address: %v", err))
+	}
+	if err := validateDelegationCommitteePublicKey(meta.CommitteePublicKey); err != nil {
+		return false, false, NewMetadataTxError(DelegationRequestValidateSanityDataError, err)
+	}
+	return true, true, nil
+}
+
+func (meta DelegateStakingMetadata) ValidateMetadataByItself() bool {
+	return meta.Type == DelegateStakingMeta
+}
+
+func (meta DelegateStakingMetadata) Hash() *common.Hash {
+	record := meta.MetadataBase.Hash().String()
+	record += meta.DelegatorPaymentAddress
+	record += meta.CommitteePublicKey
+	record += strconv.FormatUint(meta.Amount, 10)
+	// final hash
+	hash := common.HashH([]byte(record))
+	return &hash
+}
+
+func (meta *DelegateStakingMetadata) BuildReqActions(tx Transaction, chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, shardID byte, shardHeight uint64) ([][]string, error) {
+	delegatorShardID, err := shardIDFromPaymentAddress(meta.DelegatorPaymentAddress)
+	if err != nil {
+		return [][]string{}, NewMetadataTxError(DelegationRequestBuildReqActionsError, err)
+	}
+	return buildDelegationReqAction(meta.Type, DelegationContent{
+		CommitteePublicKey: meta.CommitteePublicKey,
+		Address:            meta.DelegatorPaymentAddress,
+		Amount:             meta.Amount,
+		TxReqID:            *tx.Hash(),
+		ShardID:            delegatorShardID,
+	})
+}
+
+func (meta *DelegateStakingMetadata) CalculateSize() uint64 {
+	return calculateSize(meta)
+}
@@ -0,0 +1,108 @@
+package metadata
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/config"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/wallet"
+)
+
+// delegation instruction statuses
+const (
+	DelegationAcceptedStatus = "accepted"
+	DelegationRejectedStatus = "rejected"
+	DelegationReleasedStatus = "released"
+)
+
+// DelegationContent is the content of the delegation actions sent from shards to beacon
+// and of the delegation instructions in beacon blocks
+type DelegationContent struct {
+	CommitteePublicKey string
+	Address            string
+	Commission         uint64 `json:"Commission,omitempty"`
+	Amount             uint64
+	TxReqID            common.Hash
+	ShardID            byte
+}
+
+// ParseDelegationContent decodes the base64 content of a delegation action or instruction
+func ParseDelegationContent(contentStr string) (*DelegationContent, error) {
+	contentBytes, err := base64.StdEncoding.DecodeString(contentStr)
+	if err != nil {
+		return nil, NewMetadataTxError(DelegationRequestDecodeInstructionError, err)
+	}
+	content := &DelegationContent{}
+	if err := json.Unmarshal(contentBytes, content); err != nil {
+		return nil, NewMetadataTxError(DelegationRequestDecodeInstructionError, err)
+	}
+	return content, nil
+}
+
+// GenDelegationPoolTxStakingID returns the staking tx ID recorded for the validator of a delegation pool,
+// pools stake from beacon so there is no staking tx in any shard
+func GenDelegationPoolTxStakingID(committeePublicKey string) common.Hash {
+	return common.HashH([]byte("delegation-pool-staking-" + committeePublicKey))
+}
+
+// buildDelegationReqAction builds the action of a delegation request to send to beacon
+func buildDelegationReqAction(metaType int, content DelegationContent) ([][]string, error) {
+	contentBytes, err := json.Marshal(content)
+	if err != nil {
+		return [][]string{}, NewMetadataTxError(DelegationRequestBuildReqActionsError, err)
+	}
+	action := []string{strconv.Itoa(metaType), base64.StdEncoding.EncodeToString(contentBytes)}
+	return [][]string{action}, nil
+}
+
+// validateDelegationBurnTx checks that tx is a non privacy tx burning amount PRV
+func validateDelegationBurnTx(tx Transaction, amount uint64) error {
+	if tx.IsPrivacy() {
+		return errors.New("delegation transaction should not be a privacy transaction")
+	}
+	isBurned, burnCoin, tokenID, err := tx.GetTxBurnData()
+	if err != nil || !isBurned {
+		return errors.New("delegation transaction should be a burn transaction")
+	}
+	if !bytes.Equal(tokenID[:], common.PRVCoinID[:]) {
+		return errors.New("delegation transaction should burn PRV only")
+	}
+	if burnCoin.GetValue() != amount {
+		return fmt.Errorf("burned amount %v is not equal to delegation amount %v", burnCoin.GetValue(), amount)
+	}
+	if amount < config.Param().DelegationParam.MinDelegationAmount || amount > config.Param().StakingAmountShard {
+		return fmt.Errorf("delegation amount %v should be in range [%v, %v]",
+			amount, config.Param().DelegationParam.MinDelegationAmount, config.Param().StakingAmountShard)
+	}
+	return nil
+}
+
+func validateDelegationCommitteePublicKey(committeePublicKey string) error {
+	key := new(incognitokey.CommitteePublicKey)
+	if err := key.FromString(committeePublicKey); err != nil {
+		return err
+	}
+	if !key.CheckSanityData() {
+		return errors.New("invalid committee public key")
+	}
+	return nil
+}
+
+// shardIDFromPaymentAddress returns the shard of a base58 payment address
+func shardIDFromPaymentAddress(paymentAddress string) (byte, error) {
+	keyWallet, err := wallet.Base58CheckDeserialize(paymentAddress)
+	if err != nil {
+		return 0, err
+	}
+	pk := keyWallet.KeySet.PaymentAddress.Pk
+	if len(pk) == 0 {
+		return 0, errors.New("invalid payment address")
+	}
+	return common.GetShardIDFromLastByte(pk[len(pk)-1]), nil
+}
@@ -0,0 +1,257 @@
+package metadata_test
+
+import (
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/config"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/metadata"
+	metadataCommonMocks "github.com/incognitochain/incognito-chain/metadata/common/mocks"
+	coinMocks "github.com/incognitochain/incognito-chain/privacy/coin/mocks"
+	"github.com/incognitochain/incognito-chain/wallet"
+)
+
+func setDelegationTestParam() {
+	config.AbortParam()
+	config.Param().StakingAmountShard = 1000
+	config.Param().DelegationParam.DelegationHeight = 10
+	config.Param().DelegationParam.MinDelegationAmount = 100
+	config.Param().DelegationParam.MaxCommission = 5000
+}
+
+func newDelegationTestTx(isPrivacy bool, burnAmount uint64) *metadataCommonMocks.Transaction {
+	burnCoin := &coinMocks.Coin{}
+	burnCoin.On("GetValue").Return(burnAmount)
+	tx := &metadataCommonMocks.Transaction{}
+	tx.On("IsPrivacy").Return(isPrivacy)
+	tx.On("GetTxBurnData").Return(true, burnCoin, &common.PRVCoinID, nil)
+	tx.On("GetVersion").Return(int8(1))
+	return tx
+}
+
+// newDelegationTestBeaconView returns a beacon view retriever with the pool of validCommitteePublicKeys[0],
+// delegated by validPaymentAddresses[0], and the staker validCommitteePublicKeys[1]
+func newDelegationTestBeaconView(t *testing.T, unbonding bool) *metadataCommonMocks.BeaconViewRetriever {
+	stateDB, err := statedb.NewWithPrefixTrie(common.EmptyRoot, wrarperDB)
+	if err != nil {
+		t.Fatal(err)
+	}
+	unbondTxID := common.Hash{}
+	if unbonding {
+		unbondTxID = common.Hash{1}
+	}
+	pool := statedb.NewDelegationPoolStateWithValue(validCommitteePublicKeys[0], validPaymentAddresses[0], 1000,
+		[]*statedb.DelegationState{statedb.NewDelegationStateWithValue(validPaymentAddresses[0], 500, unbondTxID)}, false, false)
+	if err := statedb.StoreDelegationPool(stateDB, pool); err != nil {
+		t.Fatal(err)
+	}
+	beaconView := &metadataCommonMocks.BeaconViewRetriever{}
+	beaconView.On("GetBeaconConsensusStateDB").Return(stateDB)
+	beaconView.On("GetStakerInfo", validCommitteePublicKeys[0]).Return(nil, false, nil)
+	beaconView.On("GetStakerInfo", validCommitteePublicKeys[1]).Return(statedb.NewStakerInfo(), true, nil)
+	beaconView.On("GetStakerInfo", validCommitteePublicKeys[2]).Return(nil, false, nil)
+	return beaconView
+}
+
+func TestCreateDelegationPoolMetadata_ValidateSanityData(t *testing.T) {
+	setDelegationTestParam()
+	tests := []struct {
+		name         string
+		meta         *metadata.CreateDelegationPoolMetadata
+		beaconHeight uint64
+		tx           metadata.Transaction
+		wantErr      bool
+	}{
+		{"valid", metadata.NewCreateDelegationPoolMetadata(validPaymentAddresses[0], validCommitteePublicKeys[0], 1000, 500), 10, newDelegationTestTx(false, 500), false},
+		{"before delegation height", metadata.NewCreateDelegationPoolMetadata(validPaymentAddresses[0], validCommitteePublicKeys[0], 1000, 500), 9, newDelegationTestTx(false, 500), true},
+		{"privacy tx", metadata.NewCreateDelegationPoolMetadata(validPaymentAddresses[0], validCommitteePublicKeys[0], 1000, 500), 10, newDelegationTestTx(true, 500), true},
+		{"burned amount mismatch", metadata.NewCreateDelegationPoolMetadata(validPaymentAddresses[0], validCommitteePublicKeys[0], 1000, 500), 10, newDelegationTestTx(false, 400), true},
+		{"amount over staking amount", metadata.NewCreateDelegationPoolMetadata(validPaymentAddresses[0], validCommitteePublicKeys[0], 1000, 1001), 10, newDelegationTestTx(false, 1001), true},
+		{"commission over max", metadata.NewCreateDelegationPoolMetadata(validPaymentAddresses[0], validCommitteePublicKeys[0], 5001, 500), 10, newDelegationTestTx(false, 500), true},
+		{"invalid operator address", metadata.NewCreateDelegationPoolMetadata(invalidPaymentAddresses[0], validCommitteePublicKeys[0], 1000, 500), 10, newDelegationTestTx(false, 500), true},
+		{"invalid committee public key", metadata.NewCreateDelegationPoolMetadata(validPaymentAddresses[0], invalidCommitteePublicKeys[0], 1000, 500), 10, newDelegationTestTx(false, 500), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, got1, err := tt.meta.ValidateSanityData(nil, nil, nil, tt.beaconHeight, tt.tx)
+			if (err != nil) != tt.wantErr || got != !tt.wantErr || got1 != !tt.wantErr {
+				t.Errorf("ValidateSanityData() = %v, %v, %v, wantErr %v", got, got1, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCreateDelegationPoolMetadata_ValidateTxWithBlockChain(t *testing.T) {
+	beaconView := newDelegationTestBeaconView(t, false)
+	tests := []struct {
+		name    string
+		key     string
+		wantErr bool
+	}{
+		{"valid", validCommitteePublicKeys[2], false},
+		{"pool exists", validCommitteePublicKeys[0], true},
+		{"key staked", validCommitteePublicKeys[1], true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			meta := metadata.NewCreateDelegationPoolMetadata(validPaymentAddresses[0], tt.key, 1000, 500)
+			got, err := meta.ValidateTxWithBlockChain(nil, nil, nil, beaconView, 0, nil)
+			if (err != nil) != tt.wantErr || got != !tt.wantErr {
+				t.Errorf("ValidateTxWithBlockChain() = %v, %v, wantErr %v", got, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDelegateStakingMetadata_ValidateSanityData(t *testing.T) {
+	setDelegationTestParam()
+	tests := []struct {
+		name         string
+		meta         *metadata.DelegateStakingMetadata
+		beaconHeight uint64
+		tx           metadata.Transaction
+		wantErr      bool
+	}{
+		{"valid", metadata.NewDelegateStakingMetadata(validPaymentAddresses[1], validCommitteePublicKeys[0], 500), 10, newDelegationTestTx(false, 500), false},
+		{"before delegation height", metadata.NewDelegateStakingMetadata(validPaymentAddresses[1], validCommitteePublicKeys[0], 500), 9, newDelegationTestTx(false, 500), true},
+		{"amount under min", metadata.NewDelegateStakingMetadata(validPaymentAddresses[1], validCommitteePublicKeys[0], 99), 10, newDelegationTestTx(false, 99), true},
+		{"privacy tx", metadata.NewDelegateStakingMetadata(validPaymentAddresses[1], validCommitteePublicKeys[0], 500), 10, newDelegationTestTx(true, 500), true},
+		{"invalid delegator address", metadata.NewDelegateStakingMetadata(invalidPaymentAddresses[0], validCommitteePublicKeys[0], 500), 10, newDelegationTestTx(false, 500), true},
+		{"invalid committee public key", metadata.NewDelegateStakingMetadata(validPaymentAddresses[1], invalidCommitteePublicKeys[0], 500), 10, newDelegationTestTx(false, 500), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, got1, err := tt.meta.ValidateSanityData(nil, nil, nil, tt.beaconHeight, tt.tx)
+			if (err != nil) != tt.wantErr || got != !tt.wantErr || got1 != !tt.wantErr {
+				t.Errorf("ValidateSanityData() = %v, %v, %v, wantErr %v", got, got1, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDelegateStakingMetadata_ValidateTxWithBlockChain(t *testing.T) {
+	beaconView := newDelegationTestBeaconView(t, false)
+	tests := []struct {
+		name    string
+		key     string
+		wantErr bool
+	}{
+		{"valid", validCommitteePublicKeys[0], false},
+		{"pool not found", validCommitteePublicKeys[2], true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			meta := metadata.NewDelegateStakingMetadata(validPaymentAddresses[1], tt.key, 500)
+			got, err := meta.ValidateTxWithBlockChain(nil, nil, nil, beaconView, 0, nil)
+			if (err != nil) != tt.wantErr || got != !tt.wantErr {
+				t.Errorf("ValidateTxWithBlockChain() = %v, %v, wantErr %v", got, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUnbondDelegationMetadata_ValidateSanityData(t *testing.T) {
+	setDelegationTestParam()
+	tests := []struct {
+		name         string
+		meta         *metadata.UnbondDelegationMetadata
+		beaconHeight uint64
+		tx           metadata.Transaction
+		wantErr      bool
+	}{
+		{"valid", metadata.NewUnbondDelegationMetadata(validPaymentAddresses[0], validCommitteePublicKeys[0]), 10, newDelegationTestTx(false, 0), false},
+		{"before delegation height", metadata.NewUnbondDelegationMetadata(validPaymentAddresses[0], validCommitteePublicKeys[0]), 9, newDelegationTestTx(false, 0), true},
+		{"privacy tx", metadata.NewUnbondDelegationMetadata(validPaymentAddresses[0], validCommitteePublicKeys[0]), 10, newDelegationTestTx(true, 0), true},
+		{"invalid delegator address", metadata.NewUnbondDelegationMetadata(invalidPaymentAddresses[0], validCommitteePublicKeys[0]), 10, newDelegationTestTx(false, 0), true},
+		{"invalid committee public key", metadata.NewUnbondDelegationMetadata(validPaymentAddresses[0], invalidCommitteePublicKeys[0]), 10, newDelegationTestTx(false, 0), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, got1, err := tt.meta.ValidateSanityData(nil, nil, nil, tt.beaconHeight, tt.tx)
+			if (err != nil) != tt.wantErr || got != !tt.wantErr || got1 != !tt.wantErr {
+				t.Errorf("ValidateSanityData() = %v, %v, %v, wantErr %v", got, got1, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUnbondDelegationMetadata_ValidateTxWithBlockChain(t *testing.T) {
+	// newSignedTx returns a tx signed by the owner of paymentAddress
+	newSignedTx := func(paymentAddress string) *metadataCommonMocks.Transaction {
+		keyWallet, err := wallet.Base58CheckDeserialize(paymentAddress)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tx := &metadataCommonMocks.Transaction{}
+		tx.On("HashWithoutMetadataSig").Return(nil)
+		tx.On("GetSigPubKey").Return([]byte(keyWallet.KeySet.PaymentAddress.Pk))
+		return tx
+	}
+	signedTx := newSignedTx(validPaymentAddresses[0])
+	otherTx := newSignedTx(validPaymentAddresses[1])
+
+	tests := []struct {
+		name       string
+		delegator  string
+		key        string
+		tx         metadata.Transaction
+		beaconView *metadataCommonMocks.BeaconViewRetriever
+		wantErr    bool
+	}{
+		{"valid", validPaymentAddresses[0], validCommitteePublicKeys[0], signedTx, newDelegationTestBeaconView(t, false), false},
+		{"pool not found", validPaymentAddresses[0], validCommitteePublicKeys[2], signedTx, newDelegationTestBeaconView(t, false), true},
+		{"delegation not found", validPaymentAddresses[1], validCommitteePublicKeys[0], otherTx, newDelegationTestBeaconView(t, false), true},
+		{"signed by another delegator", validPaymentAddresses[0], validCommitteePublicKeys[0], otherTx, newDelegationTestBeaconView(t, false), true},
+		{"unbonding already", validPaymentAddresses[0], validCommitteePublicKeys[0], signedTx, newDelegationTestBeaconView(t, true), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			meta := metadata.NewUnbondDelegationMetadata(tt.delegator, tt.key)
+			got, err := meta.ValidateTxWithBlockChain(tt.tx, nil, nil, tt.beaconView, 0, nil)
+			if (err != nil) != tt.wantErr || got != !tt.wantErr {
+				t.Errorf("ValidateTxWithBlockChain() = %v, %v, wantErr %v", got, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestStakingMetadata_ValidateTxWithBlockChainDelegationPool(t *testing.T) {
+	setDelegationTestParam()
+	newBeaconView := func(height uint64) *metadataCommonMocks.BeaconViewRetriever {
+		beaconView := newDelegationTestBeaconView(t, false)
+		beaconView.On("GetAllCommitteeValidatorCandidate").
+			Return(map[byte][]incognitokey.CommitteePublicKey{}, map[byte][]incognitokey.CommitteePublicKey{}, map[byte][]incognitokey.CommitteePublicKey{}, []incognitokey.CommitteePublicKey{}, []incognitokey.CommitteePublicKey{}, []incognitokey.CommitteePublicKey{}, []incognitokey.CommitteePublicKey{}, []incognitokey.CommitteePublicKey{}, []incognitokey.CommitteePublicKey{},
+				nil)
+		beaconView.On("GetHeight").Return(height)
+		return beaconView
+	}
+
+	tests := []struct {
+		name         string
+		key          string
+		beaconHeight uint64
+		wantErr      bool
+	}{
+		{"pool key before delegation height", validCommitteePublicKeys[0], 9, false},
+		{"pool key", validCommitteePublicKeys[0], 10, true},
+		{"other key", validCommitteePublicKeys[2], 10, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			meta := metadata.StakingMetadata{
+				MetadataBase:       metadata.MetadataBase{Type: metadata.ShardStakingMeta},
+				CommitteePublicKey: tt.key,
+			}
+			beaconView := newBeaconView(tt.beaconHeight)
+			got, err := meta.ValidateTxWithBlockChain(&metadataCommonMocks.Transaction{}, nil, nil, beaconView, 0, nil)
+			if (err != nil) != tt.wantErr || got != !tt.wantErr {
+				t.Errorf("ValidateTxWithBlockChain() = %v, %v, wantErr %v", got, err, tt.wantErr)
+			}
+			if tt.beaconHeight < config.Param().DelegationParam.DelegationHeight {
+				beaconView.AssertNotCalled(t, "GetBeaconConsensusStateDB")
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package metadata
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/wallet"
+)
+
+// DelegationResponse : tx minted by shards to return PRV of a rejected delegation request or of a released delegation
+type DelegationResponse struct {
+	MetadataBase
+	Status        string
+	RequestedTxID common.Hash
+	SharedRandom  []byte `json:"SharedRandom,omitempty"`
+}
+
+func NewDelegationResponse(status string, requestedTxID common.Hash) *DelegationResponse {
+	metadataBase := NewMetadataBase(DelegationResponseMeta)
+	return &DelegationResponse{
+		MetadataBase:  *metadataBase,
+		Status:        status,
+		RequestedTxID: requestedTxID,
+	}
+}
+
+func (res DelegationResponse) CheckTransactionFee(tr Transaction, minFee uint64, beaconHeight int64, db *statedb.StateDB) bool {
+	// no need to have fee for this tx
+	return true
+}
+
+func (res DelegationResponse) ValidateTxWithBlockChain(tx Transaction, chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, shardID byte, transactionStateDB *statedb.StateDB) (bool, error) {
+	// no need to validate tx with blockchain, just need to validate with requested tx (via RequestedTxID)
+	return false, nil
+}
+
+func (res DelegationResponse) ValidateSanityData(chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, beaconHeight uint64, tx Transaction) (bool, bool, error) {
+	return false, true, nil
+}
+
+func (res DelegationResponse) ValidateMetadataByItself() bool {
+	return res.Type == DelegationResponseMeta
+}
+
+func (res DelegationResponse) Hash() *common.Hash {
+	record := res.RequestedTxID.String()
+	record += res.Status
+	record += res.MetadataBase.Hash().String()
+	if res.SharedRandom != nil && len(res.SharedRandom) > 0 {
+		record += string(res.SharedRandom)
+	}
+	// final hash
+	hash := common.HashH([]byte(record))
+	return &hash
+}
+
+func (res *DelegationResponse) CalculateSize() uint64 {
+	return calculateSize(res)
+}
+
+func (res DelegationResponse) VerifyMinerCreatedTxBeforeGettingInBlock(mintData *MintData, shardID byte, tx Transaction, chainRetriever ChainRetriever, ac *AccumulatedValues, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever) (bool, error) {
+	idx := -1
+	for i, inst := range mintData.Insts {
+		if len(inst) != 4 || mintData.InstsUsed[i] > 0 {
+			continue
+		}
+		instMetaType := inst[0]
+		instStatus := inst[2]
+		if instStatus != res.Status {
+			continue
+		}
+		switch instMetaType {
+		case strconv.Itoa(CreateDelegationPoolMeta), strconv.Itoa(DelegateStakingMeta):
+			if instStatus != DelegationRejectedStatus {
+				continue
+			}
+		case strconv.Itoa(UnbondDelegationMeta):
+			if instStatus != DelegationReleasedStatus {
+				continue
+			}
+		default:
+			continue
+		}
+		content, err := ParseDelegationContent(inst[3])
+		if err != nil {
+			Logger.log.Error("WARNING - VALIDATION: an error occured while parsing delegation content: ", err)
+			continue
+		}
+		if !bytes.Equal(res.RequestedTxID[:], content.TxReqID[:]) || shardID != content.ShardID {
+			continue
+		}
+		key, err := wallet.Base58CheckDeserialize(content.Address)
+		if err != nil {
+			Logger.log.Info("WARNING - VALIDATION: an error occured while deserializing receiver address string: ", err)
+			continue
+		}
+		isMinted, mintCoin, coinID, err := tx.GetTxMintData()
+		if err != nil || !isMinted || coinID.String() != common.PRVIDStr {
+			continue
+		}
+		if ok := mintCoin.CheckCoinValid(key.KeySet.PaymentAddress, res.SharedRandom, content.Amount); !ok {
+			continue
+		}
+		idx = i
+		break
+	}
+	if idx == -1 {
+		return false, fmt.Errorf("no delegation instruction found for DelegationResponse tx %s", tx.Hash().String())
+	}
+	mintData.InstsUsed[idx] = 1
+	return true, nil
+}
+
+func (res *DelegationResponse) SetSharedRandom(r []byte) {
+	res.SharedRandom = r
+}
@@ -161,6 +161,7 @@ const (
 	// relaying
 	RelayingBNBHeaderMeta                 = metadataCommon.RelayingBNBHeaderMeta
 	RelayingBTCHeaderMeta                 = metadataCommon.RelayingBTCHeaderMeta
+	RelayingLTCHeaderMeta                 = metadataCommon.RelayingLTCHeaderMeta
 	PortalTopUpWaitingPortingRequestMeta  = metadataCommon.PortalTopUpWaitingPortingRequestMeta
 	PortalTopUpWaitingPortingResponseMeta = metadataCommon.PortalTopUpWaitingPortingResponseMeta
 	// incognito mode for smart contract
@@ -216,6 +217,12 @@ const (
 	BurnForCallResponseMeta = metadataCommon.BurnForCallResponseMeta
 	BurnForCallConfirmMeta = metadataCommon.BurnForCallConfirmMeta
 	IssuingReshieldResponseMeta = metadataCommon.IssuingReshieldResponseMeta
+
+	// delegation
+	CreateDelegationPoolMeta = metadataCommon.CreateDelegationPoolMeta
+	DelegateStakingMeta      = metadataCommon.DelegateStakingMeta
+	UnbondDelegationMeta     = metadataCommon.UnbondDelegationMeta
+	DelegationResponseMeta   = metadataCommon.DelegationResponseMeta
 )
 
 // export error codes
@@ -280,4 +287,9 @@ const (
 	PortalCustodianDepositV3ValidateSanityDataError = metadataCommon.PortalCustodianDepositV3ValidateSanityDataError
 	NewPortalCustodianDepositV3MetaFromMapError     = metadataCommon.NewPortalCustodianDepositV3MetaFromMapError
 	PortalUnlockOverRateCollateralsError            = metadataCommon.PortalUnlockOverRateCollateralsError
+	// delegation
+	DelegationRequestValidateSanityDataError       = metadataCommon.DelegationRequestValidateSanityDataError
+	DelegationRequestValidateTxWithBlockChainError = metadataCommon.DelegationRequestValidateTxWithBlockChainError
+	DelegationRequestBuildReqActionsError          = metadataCommon.DelegationRequestBuildReqActionsError
+	DelegationRequestDecodeInstructionError        = metadataCommon.DelegationRequestDecodeInstructionError
 )
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
+	"unicode"
 
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
@@ -222,7 +223,7 @@ func (iReq IssuingRequest) ValidateMetadataByItself() bool {
 func (iReq IssuingRequest) Hash() *common.Hash {
 	record := iReq.ReceiverAddress.String()
 	record += iReq.TokenID.String()
-	record += string(iReq.DepositedAmount)
+	record += runeString(iReq.DepositedAmount)
 	record += iReq.TokenName
 	record += iReq.MetadataBaseWithSignature.Hash().String()
 	if iReq.Sig != nil && len(iReq.Sig) != 0 {
@@ -236,7 +237,7 @@ func (iReq IssuingRequest) Hash() *common.Hash {
 func (iReq IssuingRequest) HashWithoutSig() *common.Hash {
 	record := iReq.ReceiverAddress.String()
 	record += iReq.TokenID.String()
-	record += string(iReq.DepositedAmount)
+	record += runeString(iReq.DepositedAmount)
 	record += iReq.TokenName
 	record += iReq.MetadataBaseWithSignature.Hash().String()
 
@@ -268,3 +269,12 @@ func (iReq *IssuingRequest) BuildReqActions(tx Transaction, chainRetriever Chain
 func (iReq *IssuingRequest) CalculateSize() uint64 {
 	return calculateSize(iReq)
 }
+
+// runeString converts v the way string(v) does, which the hashes of existing
+// metadata were built with.
+func runeString(v uint64) string {
+	if v > unicode.MaxRune {
+		return string(unicode.ReplacementChar)
+	}
+	return string(rune(v))
+}
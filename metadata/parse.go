@@ -100,6 +100,14 @@ func ParseMetadata(meta interface{}) (Metadata, error) {
 		md = &UnStakingMetadata{}
 	case StopAutoStakingMeta:
 		md = &StopAutoStakingMetadata{}
+	case CreateDelegationPoolMeta:
+		md = &CreateDelegationPoolMetadata{}
+	case DelegateStakingMeta:
+		md = &DelegateStakingMetadata{}
+	case UnbondDelegationMeta:
+		md = &UnbondDelegationMetadata{}
+	case DelegationResponseMeta:
+		md = &DelegationResponse{}
 	case PDEContributionMeta:
 		md = &PDEContribution{}
 	case PDEPRVRequiredContributionRequestMeta:
@@ -146,6 +154,8 @@ func ParseMetadata(meta interface{}) (Metadata, error) {
 		md = &RelayingHeader{}
 	case RelayingBTCHeaderMeta:
 		md = &RelayingHeader{}
+	case RelayingLTCHeaderMeta:
+		md = &RelayingHeader{}
 	case PortalCustodianWithdrawRequestMeta:
 		md = &PortalCustodianWithdrawRequest{}
 	case PortalCustodianWithdrawResponseMeta:
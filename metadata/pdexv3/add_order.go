@@ -18,6 +18,10 @@ type AddOrderRequest struct {
 	MinAcceptableAmount uint64                              `json:"MinAcceptableAmount"`
 	Receiver            map[common.Hash]privacy.OTAReceiver `json:"Receiver"`
 	NftID               common.Hash                         `json:"NftID"`
+	// ExpiryHeight is the beacon height from which the order is withdrawn automatically, 0 keeps it until withdrawn
+	ExpiryHeight uint64 `json:"ExpiryHeight,omitempty"`
+	// TimeInForce is one of OrderGoodTillCancelled, OrderImmediateOrCancel, OrderFillOrKill
+	TimeInForce byte `json:"TimeInForce,omitempty"`
 	metadataCommon.MetadataBase
 }
 
@@ -112,6 +116,9 @@ func (req AddOrderRequest) ValidateSanityData(chainRetriever metadataCommon.Chai
 		return false, false, metadataCommon.NewMetadataTxError(metadataCommon.PDEInvalidMetadataValueError,
 			fmt.Errorf("SellAmount cannot be 0"))
 	}
+	if err := req.validateConditions(beaconHeight); err != nil {
+		return false, false, metadataCommon.NewMetadataTxError(metadataCommon.PDEInvalidMetadataValueError, err)
+	}
 
 	// Type vs burned token id + amount check
 	switch tx.GetType() {
@@ -153,6 +160,28 @@ func (req AddOrderRequest) ValidateSanityData(chainRetriever metadataCommon.Chai
 	return true, true, nil
 }
 
+// validateConditions() checks the expiry & time in force of the order, both default to a good-till-cancelled order
+func (req AddOrderRequest) validateConditions(beaconHeight uint64) error {
+	if req.ExpiryHeight == 0 && req.TimeInForce == OrderGoodTillCancelled {
+		return nil
+	}
+	if !IsAfterConditionalOrderBreakPoint(beaconHeight) {
+		return fmt.Errorf("Order expiry & time in force have not been activated yet")
+	}
+	if req.TimeInForce > OrderFillOrKill {
+		return fmt.Errorf("Invalid TimeInForce %d", req.TimeInForce)
+	}
+	if req.ExpiryHeight != 0 {
+		if req.TimeInForce != OrderGoodTillCancelled {
+			return fmt.Errorf("ExpiryHeight is only allowed for good-till-cancelled orders")
+		}
+		if req.ExpiryHeight <= beaconHeight {
+			return fmt.Errorf("ExpiryHeight %d must be greater than beacon height %d", req.ExpiryHeight, beaconHeight)
+		}
+	}
+	return nil
+}
+
 func (req AddOrderRequest) ValidateMetadataByItself() bool {
 	return req.Type == metadataCommon.Pdexv3AddOrderRequestMeta
 }
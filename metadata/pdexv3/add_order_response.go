@@ -36,6 +36,7 @@ type AcceptedAddOrder struct {
 	Token1Balance  uint64      `json:"Token1Balance"`
 	TradeDirection byte        `json:"TradeDirection"`
 	Receiver       [2]string   `json:"Receiver"`
+	ExpiryHeight   uint64      `json:"ExpiryHeight,omitempty"`
 }
 
 func (md AcceptedAddOrder) GetType() int {
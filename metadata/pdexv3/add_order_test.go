@@ -0,0 +1,69 @@
+package pdexv3
+
+import (
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddOrderRequest_validateConditions(t *testing.T) {
+	config.AbortParam()
+	config.Param().PDexParams.ConditionalOrderBreakPointHeight = 100
+
+	tests := []struct {
+		name         string
+		req          AddOrderRequest
+		beaconHeight uint64
+		wantErr      bool
+	}{
+		{
+			name:         "Good-till-cancelled order before break point",
+			req:          AddOrderRequest{},
+			beaconHeight: 50,
+			wantErr:      false,
+		},
+		{
+			name:         "Expiry before break point",
+			req:          AddOrderRequest{ExpiryHeight: 200},
+			beaconHeight: 50,
+			wantErr:      true,
+		},
+		{
+			name:         "Valid expiry",
+			req:          AddOrderRequest{ExpiryHeight: 101},
+			beaconHeight: 100,
+			wantErr:      false,
+		},
+		{
+			name:         "Expiry not above beacon height",
+			req:          AddOrderRequest{ExpiryHeight: 100},
+			beaconHeight: 100,
+			wantErr:      true,
+		},
+		{
+			name:         "Valid fill-or-kill order",
+			req:          AddOrderRequest{TimeInForce: OrderFillOrKill},
+			beaconHeight: 100,
+			wantErr:      false,
+		},
+		{
+			name:         "Invalid time in force",
+			req:          AddOrderRequest{TimeInForce: OrderFillOrKill + 1},
+			beaconHeight: 100,
+			wantErr:      true,
+		},
+		{
+			name:         "Immediate order with expiry",
+			req:          AddOrderRequest{ExpiryHeight: 200, TimeInForce: OrderImmediateOrCancel},
+			beaconHeight: 100,
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.req.validateConditions(tt.beaconHeight)
+			assert.Equal(t, tt.wantErr, err != nil)
+		})
+	}
+}
@@ -34,3 +34,10 @@ const (
 
 	MaxTradePathLength = 5
 )
+
+// time in force of an order
+const (
+	OrderGoodTillCancelled = iota // rests in the orderbook until withdrawn or expired
+	OrderImmediateOrCancel        // matched right away as far as possible, the remainder is refunded
+	OrderFillOrKill               // matched right away in full or refunded
+)
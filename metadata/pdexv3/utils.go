@@ -4,6 +4,7 @@ import (
 	"errors"
 
 	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/config"
 	"github.com/incognitochain/incognito-chain/privacy"
 )
 
@@ -26,3 +27,9 @@ func isValidOTAReceiver(receiverAddress privacy.OTAReceiver, expectedShardID byt
 
 	return receiverAddress, nil
 }
+
+// IsAfterConditionalOrderBreakPoint() reports whether orders may set an expiry or a time in force at beaconHeight
+func IsAfterConditionalOrderBreakPoint(beaconHeight uint64) bool {
+	breakPoint := config.Param().PDexParams.ConditionalOrderBreakPointHeight
+	return breakPoint != 0 && beaconHeight >= breakPoint
+}
@@ -103,7 +103,7 @@ func (rh RelayingHeader) ValidateSanityData(chainRetriever ChainRetriever, shard
 }
 
 func (rh RelayingHeader) ValidateMetadataByItself() bool {
-	return rh.Type == RelayingBNBHeaderMeta || rh.Type == RelayingBTCHeaderMeta
+	return rh.Type == RelayingBNBHeaderMeta || rh.Type == RelayingBTCHeaderMeta || rh.Type == RelayingLTCHeaderMeta
 }
 
 func (rh RelayingHeader) Hash() *common.Hash {
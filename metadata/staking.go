@@ -98,6 +98,17 @@ func (stakingMetadata StakingMetadata) ValidateTxWithBlockChain(tx Transaction,
 	if len(tempStaker) == 0 {
 		return false, errors.New("invalid Staker, This pubkey may staked already")
 	}
+	if beaconViewRetriever.GetHeight() < config.Param().DelegationParam.DelegationHeight {
+		return true, nil
+	}
+	// keys of delegation pools are staked by their delegators
+	_, hasPool, err := statedb.GetDelegationPool(beaconViewRetriever.GetBeaconConsensusStateDB(), stakingMetadata.CommitteePublicKey)
+	if err != nil {
+		return false, err
+	}
+	if hasPool {
+		return false, errors.New("invalid Staker, This pubkey belongs to a delegation pool")
+	}
 	return true, nil
 }
 
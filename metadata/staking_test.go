@@ -453,15 +453,17 @@ func TestStakingMetadata_ValidateTxWithBlockChain(t *testing.T) {
 	SPV := make(map[byte][]incognitokey.CommitteePublicKey)
 	happyCaseBeaconRetriever := &metadataCommonMocks.BeaconViewRetriever{}
 	happyCaseBeaconRetriever.On("GetAllCommitteeValidatorCandidate").
-		Return(SC, SPV, []incognitokey.CommitteePublicKey{}, []incognitokey.CommitteePublicKey{}, []incognitokey.CommitteePublicKey{}, []incognitokey.CommitteePublicKey{}, []incognitokey.CommitteePublicKey{}, []incognitokey.CommitteePublicKey{},
+		Return(SC, SPV, map[byte][]incognitokey.CommitteePublicKey{}, []incognitokey.CommitteePublicKey{}, []incognitokey.CommitteePublicKey{}, []incognitokey.CommitteePublicKey{}, []incognitokey.CommitteePublicKey{}, []incognitokey.CommitteePublicKey{}, []incognitokey.CommitteePublicKey{},
 			nil)
+	happyCaseBeaconRetriever.On("GetHeight").Return(uint64(1))
+	happyCaseBeaconRetriever.On("GetBeaconConsensusStateDB").Return(emptyStateDB)
 	stakeAlreadyBeaconRetriever := &metadataCommonMocks.BeaconViewRetriever{}
 	stakeAlreadyBeaconRetriever.On("GetAllCommitteeValidatorCandidate").
-		Return(SC, SPV, []incognitokey.CommitteePublicKey{validCommitteePublicKeyStructs[0]}, []incognitokey.CommitteePublicKey{}, []incognitokey.CommitteePublicKey{}, []incognitokey.CommitteePublicKey{}, []incognitokey.CommitteePublicKey{}, []incognitokey.CommitteePublicKey{},
+		Return(SC, SPV, map[byte][]incognitokey.CommitteePublicKey{}, []incognitokey.CommitteePublicKey{validCommitteePublicKeyStructs[0]}, []incognitokey.CommitteePublicKey{}, []incognitokey.CommitteePublicKey{}, []incognitokey.CommitteePublicKey{}, []incognitokey.CommitteePublicKey{}, []incognitokey.CommitteePublicKey{},
 			nil)
 	getCommitteeErrorBeaconRetriever := &metadataCommonMocks.BeaconViewRetriever{}
 	getCommitteeErrorBeaconRetriever.On("GetAllCommitteeValidatorCandidate").
-		Return(SC, SPV, []incognitokey.CommitteePublicKey{}, []incognitokey.CommitteePublicKey{}, []incognitokey.CommitteePublicKey{}, []incognitokey.CommitteePublicKey{}, []incognitokey.CommitteePublicKey{}, []incognitokey.CommitteePublicKey{},
+		Return(SC, SPV, map[byte][]incognitokey.CommitteePublicKey{}, []incognitokey.CommitteePublicKey{}, []incognitokey.CommitteePublicKey{}, []incognitokey.CommitteePublicKey{}, []incognitokey.CommitteePublicKey{}, []incognitokey.CommitteePublicKey{}, []incognitokey.CommitteePublicKey{},
 			errors.New("get committee error"))
 
 	type fields struct {
@@ -10,6 +10,7 @@ import (
 	"github.com/incognitochain/incognito-chain/metadata"
 	metadataCommonMocks "github.com/incognitochain/incognito-chain/metadata/common/mocks"
 	coinMocks "github.com/incognitochain/incognito-chain/privacy/coin/mocks"
+	"github.com/incognitochain/incognito-chain/wallet"
 )
 
 func TestNewStopAutoStakingMetadata(t *testing.T) {
@@ -39,6 +40,7 @@ func TestNewStopAutoStakingMetadata(t *testing.T) {
 			want: &metadata.StopAutoStakingMetadata{
 				MetadataBaseWithSignature: metadata.MetadataBaseWithSignature{
 					MetadataBase: metadata.MetadataBase{metadata.StopAutoStakingMeta},
+					Sig:          []byte{},
 				},
 				CommitteePublicKey: "121VhftSAygpEJZ6i9jGkCFHRkD4yhxxccAqVjQTWR9gy7skM1KcNf3uGLpX1NvojmHqs9bWwsPfvyBmer39YNBPwBHpgXg1Qku4EDhtUBZnGw2PZGMF7DMCrYa27GNS97uA9WC5z55YuCDA4WsnKfoEEuCFDNUN3iSCeUyrQ4SF5smx9CwBYX6AWAMAvNDPKf4tCuc7Wiafv9xkLKuHSFr7jaxBfg4rdaxtwXzR5eMpFDDpiXz6hQmdcee8xSXQRKceiafg9RMiuqLxDzx9tmLKvBD5TJq4G76LB3rrVmsYwMo1fY4RZLpiYn6AstAfca5EVnMeexueSAE5sam3Lsq8mq5poJfsW6KXzAbsmFPSsSjhmQ4wGhSXoKSap331gBMuuy7KtmVwQAPpwuFPo9hi7RBgrrn1ssdCdjYSwE226Ekc",
 			},
@@ -367,6 +369,9 @@ func TestStopAutoStakingMetadata_ValidateTxWithBlockChain(t *testing.T) {
 	stopStakeTx1.On("GetMetadata").Return(stopStakeTx1Meta)
 	stopStakeTx1.On("GetMetadataType").Return(metadata.StopAutoStakingMeta)
 	stopStakeTx1.On("GetSender").Return([]byte("12buoC8Nmh8WbPhSAiF1SSNB8AuxTu3QbX3sSUydqod4y9ws3e3"))
+	funderWallet, _ := wallet.Base58CheckDeserialize(validPaymentAddresses[0])
+	stopStakeTx1.On("HashWithoutMetadataSig").Return(nil)
+	stopStakeTx1.On("GetSigPubKey").Return([]byte(funderWallet.KeySet.PaymentAddress.Pk))
 
 	chain1 := &metadataCommonMocks.ChainRetriever{}
 	beacon1 := &metadataCommonMocks.BeaconViewRetriever{}
@@ -464,7 +469,7 @@ func TestStopAutoStakingMetadata_ValidateTxWithBlockChain(t *testing.T) {
 		MetadataBase: metadata.MetadataBase{
 			metadata.ShardStakingMeta,
 		},
-		FunderPaymentAddress: validCommitteePublicKeys[2],
+		FunderPaymentAddress: validPaymentAddresses[0],
 	}
 
 	stakeTx := &metadataCommonMocks.Transaction{}
@@ -0,0 +1,116 @@
+package metadata
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/config"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/wallet"
+)
+
+// UnbondDelegationMetadata : request of a delegator to get back its delegation, the delegation is released
+// to the delegator at the next epoch boundary if the other delegations of the pool still cover the staking amount,
+// after the pool stops validating otherwise
+type UnbondDelegationMetadata struct {
+	MetadataBaseWithSignature
+	DelegatorPaymentAddress string
+	CommitteePublicKey      string
+}
+
+func NewUnbondDelegationMetadata(delegatorPaymentAddress string, committeePublicKey string) *UnbondDelegationMetadata {
+	metadataBase := NewMetadataBaseWithSignature(UnbondDelegationMeta)
+	return &UnbondDelegationMetadata{
+		MetadataBaseWithSignature: *metadataBase,
+		DelegatorPaymentAddress:   delegatorPaymentAddress,
+		CommitteePublicKey:        committeePublicKey,
+	}
+}
+
+func (meta *UnbondDelegationMetadata) Hash() *common.Hash {
+	record := strconv.Itoa(meta.Type)
+	record += meta.DelegatorPaymentAddress
+	record += meta.CommitteePublicKey
+	if meta.Sig != nil && len(meta.Sig) != 0 {
+		record += string(meta.Sig)
+	}
+	// final hash
+	hash := common.HashH([]byte(record))
+	return &hash
+}
+
+func (meta *UnbondDelegationMetadata) HashWithoutSig() *common.Hash {
+	record := strconv.Itoa(meta.Type)
+	record += meta.DelegatorPaymentAddress
+	record += meta.CommitteePublicKey
+	// final hash
+	hash := common.HashH([]byte(record))
+	return &hash
+}
+
+func (*UnbondDelegationMetadata) ShouldSignMetaData() bool { return true }
+
+// ValidateTxWithBlockChain checks that the tx is signed by the delegator and the delegation is bonded
+func (meta UnbondDelegationMetadata) ValidateTxWithBlockChain(tx Transaction, chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, shardID byte, transactionStateDB *statedb.StateDB) (bool, error) {
+	delegatorWallet, err := wallet.Base58CheckDeserialize(meta.DelegatorPaymentAddress)
+	if err != nil || delegatorWallet == nil {
+		return false, NewMetadataTxError(DelegationRequestValidateTxWithBlockChainError, errors.New("invalid delegator payment address"))
+	}
+	if ok, err := meta.MetadataBaseWithSignature.VerifyMetadataSignature(delegatorWallet.KeySet.PaymentAddress.Pk, tx); !ok || err != nil {
+		return false, NewMetadataTxError(DelegationRequestValidateTxWithBlockChainError, fmt.Errorf("invalid signature of delegator %v", meta.DelegatorPaymentAddress))
+	}
+	pool, has, err := statedb.GetDelegationPool(beaconViewRetriever.GetBeaconConsensusStateDB(), meta.CommitteePublicKey)
+	if err != nil {
+		return false, NewMetadataTxError(DelegationRequestValidateTxWithBlockChainError, err)
+	}
+	if !has {
+		return false, NewMetadataTxError(DelegationRequestValidateTxWithBlockChainError, fmt.Errorf("delegation pool of %v not found", meta.CommitteePublicKey))
+	}
+	delegation := pool.Delegation(meta.DelegatorPaymentAddress)
+	if delegation == nil {
+		return false, NewMetadataTxError(DelegationRequestValidateTxWithBlockChainError, fmt.Errorf("delegation of %v to %v not found", meta.DelegatorPaymentAddress, meta.CommitteePublicKey))
+	}
+	if delegation.IsUnbonding() {
+		return false, NewMetadataTxError(DelegationRequestValidateTxWithBlockChainError, fmt.Errorf("delegation of %v to %v is unbonding already", meta.DelegatorPaymentAddress, meta.CommitteePublicKey))
+	}
+	return true, nil
+}
+
+func (meta UnbondDelegationMetadata) ValidateSanityData(chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, beaconHeight uint64, tx Transaction) (bool, bool, error) {
+	if beaconHeight < config.Param().DelegationParam.DelegationHeight {
+		return false, false, NewMetadataTxError(DelegationRequestValidateSanityDataError, fmt.Errorf("delegation is not enabled before beacon height %v", config.Param().DelegationParam.DelegationHeight))
+	}
+	if tx.IsPrivacy() {
+		return false, false, NewMetadataTxError(DelegationRequestValidateSanityDataError, errors.New("unbond delegation transaction should not be a privacy transaction"))
+	}
+	if _, err := AssertPaymentAddressAndTxVersion(meta.DelegatorPaymentAddress, tx.GetVersion()); err != nil {
+		return false, false, NewMetadataTxError(DelegationRequestValidateSanityDataError, fmt.Errorf("invalid delegator address: %v", err))
+	}
+	if err := validateDelegationCommitteePublicKey(meta.CommitteePublicKey); err != nil {
+		return false, false, NewMetadataTxError(DelegationRequestValidateSanityDataError, err)
+	}
+	return true, true, nil
+}
+
+func (meta UnbondDelegationMetadata) ValidateMetadataByItself() bool {
+	return meta.Type == UnbondDelegationMeta
+}
+
+func (meta *UnbondDelegationMetadata) BuildReqActions(tx Transaction, chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, shardID byte, shardHeight uint64) ([][]string, error) {
+	delegatorShardID, err := shardIDFromPaymentAddress(meta.DelegatorPaymentAddress)
+	if err != nil {
+		return [][]string{}, NewMetadataTxError(DelegationRequestBuildReqActionsError, err)
+	}
+	return buildDelegationReqAction(meta.Type, DelegationContent{
+		CommitteePublicKey: meta.CommitteePublicKey,
+		Address:            meta.DelegatorPaymentAddress,
+		TxReqID:            *tx.Hash(),
+		ShardID:            delegatorShardID,
+	})
+}
+
+func (meta *UnbondDelegationMetadata) CalculateSize() uint64 {
+	return calculateSize(meta)
+}
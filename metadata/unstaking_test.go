@@ -11,6 +11,7 @@ import (
 	"github.com/incognitochain/incognito-chain/incognitokey"
 	"github.com/incognitochain/incognito-chain/metadata"
 	metadataCommonMocks "github.com/incognitochain/incognito-chain/metadata/common/mocks"
+	"github.com/incognitochain/incognito-chain/wallet"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -189,20 +190,28 @@ func TestUnStakingMetadata_ValidateTxWithBlockChain(t *testing.T) {
 		MetadataBase: metadata.MetadataBase{
 			metadata.ShardStakingMeta,
 		},
-		FunderPaymentAddress: validCommitteePublicKeys[2],
+		FunderPaymentAddress: validPaymentAddresses[0],
 	}
+	funderWallet, _ := wallet.Base58CheckDeserialize(validPaymentAddresses[0])
 
 	stakingTxError := &metadataCommonMocks.Transaction{}
 	stakingTxError.
 		On("GetSender").
 		Return([]byte{1})
-	stakingTxError.On("GetMetadata").Return(stakeTxMeta)
+	stakingTxError.On("GetMetadata").Return(&metadata.StakingMetadata{
+		MetadataBase: metadata.MetadataBase{
+			metadata.ShardStakingMeta,
+		},
+		FunderPaymentAddress: validPaymentAddresses[1],
+	})
 
 	stakingTx := &metadataCommonMocks.Transaction{}
 	stakingTx.
 		On("GetSender").
 		Return([]byte(key1))
 	stakingTx.On("GetMetadata").Return(stakeTxMeta)
+	stakingTx.On("HashWithoutMetadataSig").Return(nil)
+	stakingTx.On("GetSigPubKey").Return([]byte(funderWallet.KeySet.PaymentAddress.Pk))
 
 	chainViewGetTxByHashError := &metadataCommonMocks.ChainRetriever{}
 	chainViewGetTxByHashError.
@@ -543,6 +552,7 @@ func TestNewUnStakingMetadata(t *testing.T) {
 					MetadataBase: metadata.MetadataBase{
 						Type: metadata.UnStakingMeta,
 					},
+					Sig: []byte{},
 				},
 				CommitteePublicKey: "keys",
 			},
@@ -0,0 +1,88 @@
+// Package prometheus exposes a metrics.Registry in the Prometheus text format
+// <https://prometheus.io/docs/instrumenting/exposition_formats/>
+package prometheus
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/metrics"
+)
+
+// Quantiles are the quantiles exported for histograms and timers
+var Quantiles = []float64{0.5, 0.75, 0.95, 0.99, 0.999}
+
+// Handler returns an http.Handler serving every metric of r, to be mounted on /metrics.
+// Counters are exported as counters, gauges as gauges, histograms & timers as summaries
+// (timers in seconds) and meters as counters of their events
+func Handler(r metrics.Registry) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		buf := &bytes.Buffer{}
+		Write(buf, r)
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		w.Header().Set("Content-Length", strconv.Itoa(buf.Len()))
+		w.Write(buf.Bytes())
+	})
+}
+
+// Write writes every metric of r to buf in the Prometheus text format, sorted by name
+func Write(buf *bytes.Buffer, r metrics.Registry) {
+	names := []string{}
+	r.Each(func(name string, i interface{}) {
+		names = append(names, name)
+	})
+	sort.Strings(names)
+
+	for _, name := range names {
+		key := Name(name)
+		switch m := r.Get(name).(type) {
+		case metrics.Counter:
+			writeValue(buf, key, "counter", m.Count())
+		case metrics.Gauge:
+			writeValue(buf, key, "gauge", m.Value())
+		case metrics.GaugeFloat64:
+			writeValue(buf, key, "gauge", m.Value())
+		case metrics.Meter:
+			writeValue(buf, key+"_total", "counter", m.Snapshot().Count())
+		case metrics.Histogram:
+			h := m.Snapshot()
+			writeSummary(buf, key, h.Percentiles(Quantiles), float64(h.Sum()), h.Count())
+		case metrics.Timer:
+			t := m.Snapshot()
+			ps := t.Percentiles(Quantiles)
+			for i := range ps {
+				ps[i] /= float64(time.Second)
+			}
+			writeSummary(buf, key+"_seconds", ps, float64(t.Sum())/float64(time.Second), t.Count())
+		}
+	}
+}
+
+// Name converts a registry name like "shard/0/insert" into a valid Prometheus name like "shard_0_insert"
+func Name(name string) string {
+	b := []byte(name)
+	for i, c := range b {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '_' || c == ':' || c >= '0' && c <= '9' && i > 0) {
+			b[i] = '_'
+		}
+	}
+	return string(b)
+}
+
+func writeValue(buf *bytes.Buffer, key string, typ string, value interface{}) {
+	fmt.Fprintf(buf, "# TYPE %s %s\n", key, typ)
+	fmt.Fprintf(buf, "%s %v\n", key, value)
+}
+
+func writeSummary(buf *bytes.Buffer, key string, ps []float64, sum float64, count int64) {
+	fmt.Fprintf(buf, "# TYPE %s summary\n", key)
+	for i, q := range Quantiles {
+		fmt.Fprintf(buf, "%s{quantile=\"%s\"} %s\n", key, strconv.FormatFloat(q, 'f', -1, 64), strconv.FormatFloat(ps[i], 'g', -1, 64))
+	}
+	fmt.Fprintf(buf, "%s_sum %s\n", key, strconv.FormatFloat(sum, 'g', -1, 64))
+	fmt.Fprintf(buf, "%s_count %d\n", key, count)
+}
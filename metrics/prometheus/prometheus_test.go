@@ -0,0 +1,71 @@
+package prometheus
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/metrics"
+)
+
+func TestName(t *testing.T) {
+	tests := map[string]string{
+		"beacon/insert":         "beacon_insert",
+		"shard/0/insert":        "shard_0_insert",
+		"message/finish-sync":   "message_finish_sync",
+		"0start":                "_start",
+		"txpool:size":           "txpool:size",
+		"consensus/vote.timing": "consensus_vote_timing",
+	}
+	for name, want := range tests {
+		if got := Name(name); got != want {
+			t.Errorf("Name(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestHandler(t *testing.T) {
+	r := metrics.NewRegistry()
+	metrics.NewRegisteredCounter("message/finish-sync", r).Inc(3)
+	metrics.NewRegisteredGauge("txpool/size", r).Update(12)
+	metrics.NewRegisteredGaugeFloat64("highway/rtt", r).Update(0.25)
+	h := metrics.NewRegisteredHistogram("indexer/queue", r, metrics.NewUniformSample(100))
+	h.Update(1)
+	h.Update(3)
+	timer := metrics.NewRegisteredTimer("shard/0/insert", r)
+	timer.Update(time.Second)
+	timer.Update(3 * time.Second)
+
+	w := httptest.NewRecorder()
+	Handler(r).ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+	if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain") {
+		t.Fatalf("Content-Type = %q", ct)
+	}
+	body := w.Body.String()
+	for _, line := range []string{
+		"# TYPE highway_rtt gauge\nhighway_rtt 0.25\n",
+		"# TYPE indexer_queue summary\n",
+		"indexer_queue_sum 4\nindexer_queue_count 2\n",
+		"# TYPE message_finish_sync counter\nmessage_finish_sync 3\n",
+		"# TYPE shard_0_insert_seconds summary\n",
+		"shard_0_insert_seconds{quantile=\"0.5\"} 2\n",
+		"shard_0_insert_seconds_sum 4\nshard_0_insert_seconds_count 2\n",
+		"# TYPE txpool_size gauge\ntxpool_size 12\n",
+	} {
+		if !strings.Contains(body, line) {
+			t.Errorf("missing %q in\n%s", line, body)
+		}
+	}
+	// metrics are sorted by name
+	if strings.Index(body, "highway_rtt") > strings.Index(body, "txpool_size") {
+		t.Errorf("metrics are not sorted\n%s", body)
+	}
+
+	buf := &bytes.Buffer{}
+	Write(buf, r)
+	if buf.String() != body {
+		t.Errorf("Write and Handler differ")
+	}
+}
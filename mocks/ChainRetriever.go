@@ -8,6 +8,8 @@ import (
 
 	common "github.com/incognitochain/incognito-chain/metadata/common"
 
+	incdb "github.com/incognitochain/incognito-chain/incdb"
+
 	incognito_chaincommon "github.com/incognitochain/incognito-chain/common"
 
 	mock "github.com/stretchr/testify/mock"
@@ -131,6 +133,22 @@ func (_m *ChainRetriever) GetBTCHeaderChain() *btcrelaying.BlockChain {
 	return r0
 }
 
+// GetBeaconChainDatabase provides a mock function with given fields:
+func (_m *ChainRetriever) GetBeaconChainDatabase() incdb.Database {
+	ret := _m.Called()
+
+	var r0 incdb.Database
+	if rf, ok := ret.Get(0).(func() incdb.Database); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(incdb.Database)
+		}
+	}
+
+	return r0
+}
+
 // GetBurningAddress provides a mock function with given fields: blockHeight
 func (_m *ChainRetriever) GetBurningAddress(blockHeight uint64) string {
 	ret := _m.Called(blockHeight)
@@ -173,6 +191,22 @@ func (_m *ChainRetriever) GetFinalBeaconHeight() uint64 {
 	return r0
 }
 
+// GetLTCHeaderChain provides a mock function with given fields:
+func (_m *ChainRetriever) GetLTCHeaderChain() *btcrelaying.BlockChain {
+	ret := _m.Called()
+
+	var r0 *btcrelaying.BlockChain
+	if rf, ok := ret.Get(0).(func() *btcrelaying.BlockChain); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*btcrelaying.BlockChain)
+		}
+	}
+
+	return r0
+}
+
 // GetLatestBNBBlkHeight provides a mock function with given fields:
 func (_m *ChainRetriever) GetLatestBNBBlkHeight() (int64, error) {
 	ret := _m.Called()
@@ -215,6 +249,22 @@ func (_m *ChainRetriever) GetMinAmountPortalToken(tokenIDStr string, beaconHeigh
 	return r0, r1
 }
 
+// GetPdexv3Cached provides a mock function with given fields: _a0
+func (_m *ChainRetriever) GetPdexv3Cached(_a0 incognito_chaincommon.Hash) interface{} {
+	ret := _m.Called(_a0)
+
+	var r0 interface{}
+	if rf, ok := ret.Get(0).(func(incognito_chaincommon.Hash) interface{}); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(interface{})
+		}
+	}
+
+	return r0
+}
+
 // GetPortalETHContractAddrStr provides a mock function with given fields: beaconHeight
 func (_m *ChainRetriever) GetPortalETHContractAddrStr(beaconHeight uint64) string {
 	ret := _m.Called(beaconHeight)
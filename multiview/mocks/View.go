@@ -10,6 +10,8 @@ import (
 
 	mock "github.com/stretchr/testify/mock"
 
+	multiview "github.com/incognitochain/incognito-chain/multiview"
+
 	types "github.com/incognitochain/incognito-chain/blockchain/types"
 )
 
@@ -18,6 +20,20 @@ type View struct {
 	mock.Mock
 }
 
+// CalculateTimeSlot provides a mock function with given fields: _a0
+func (_m *View) CalculateTimeSlot(_a0 int64) int64 {
+	ret := _m.Called(_a0)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(int64) int64); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	return r0
+}
+
 // CommitteeStateVersion provides a mock function with given fields:
 func (_m *View) CommitteeStateVersion() int {
 	ret := _m.Called()
@@ -32,6 +48,20 @@ func (_m *View) CommitteeStateVersion() int {
 	return r0
 }
 
+// CompareCommitteeFromBlock provides a mock function with given fields: _a0
+func (_m *View) CompareCommitteeFromBlock(_a0 multiview.View) int {
+	ret := _m.Called(_a0)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(multiview.View) int); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	return r0
+}
+
 // GetBeaconHeight provides a mock function with given fields:
 func (_m *View) GetBeaconHeight() uint64 {
 	ret := _m.Called()
@@ -78,6 +108,20 @@ func (_m *View) GetCommittee() []incognitokey.CommitteePublicKey {
 	return r0
 }
 
+// GetCurrentTimeSlot provides a mock function with given fields:
+func (_m *View) GetCurrentTimeSlot() int64 {
+	ret := _m.Called()
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func() int64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	return r0
+}
+
 // GetHash provides a mock function with given fields:
 func (_m *View) GetHash() *common.Hash {
 	ret := _m.Called()
@@ -181,3 +225,8 @@ func (_m *View) GetProposerLength() int {
 
 	return r0
 }
+
+// ReplaceBlock provides a mock function with given fields: blk
+func (_m *View) ReplaceBlock(blk types.BlockInterface) {
+	_m.Called(blk)
+}
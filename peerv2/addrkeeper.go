@@ -10,6 +10,7 @@ import (
 
 	cache "github.com/patrickmn/go-cache"
 
+	"github.com/incognitochain/incognito-chain/metrics"
 	"github.com/incognitochain/incognito-chain/peerv2/rpcclient"
 	"github.com/libp2p/go-libp2p-core/peer"
 	"github.com/libp2p/go-libp2p/p2p/protocol/ping"
@@ -21,6 +22,9 @@ import (
 type addresses []rpcclient.HighwayAddr // short alias
 const MAX_RTT_STORE = 5
 
+// highwayRTTTimer records the RTT to the highway the node is connected to
+var highwayRTTTimer = metrics.NewRegisteredTimer("highway/rtt", nil)
+
 type RTTInfo struct {
 	lastNcall [MAX_RTT_STORE]time.Duration
 	avgRTT    time.Duration
@@ -376,6 +380,9 @@ func (keeper *AddrKeeper) updateRTT(
 		info.lastNcall[info.lastIdx] = lastCallRTT
 		keeper.lastRTT[hwAddr] = info
 	}
+	if (keeper.currentHW != nil) && (keeper.currentHW.Libp2pAddr == hwAddr.Libp2pAddr) {
+		highwayRTTTimer.Update(lastCallRTT)
+	}
 }
 
 func (keeper *AddrKeeper) UpdateRTTData(
@@ -68,7 +68,7 @@ func NewConnManager(
 
 func (cm *ConnManager) PublishMessage(msg wire.Message) error {
 	var topic string
-	publishable := []string{wire.CmdBlockShard, wire.CmdBFT, wire.CmdBlockBeacon, wire.CmdTx, wire.CmdPrivacyCustomToken, wire.CmdPeerState, wire.CmdCrossShard, wire.CmdMsgFeatureStat}
+	publishable := []string{wire.CmdBlockShard, wire.CmdBFT, wire.CmdBlockBeacon, wire.CmdTx, wire.CmdPrivacyCustomToken, wire.CmdPeerState, wire.CmdCrossShard, wire.CmdMsgFeatureStat, wire.CmdMsgSlashEvidence}
 
 	// msgCrossShard := msg.(wire.MessageCrossShard)
 	msgType := msg.MessageType()
@@ -109,6 +109,7 @@ func (cm *ConnManager) PublishMessageToShard(msg wire.Message, shardID byte) err
 		wire.CmdTx,
 		wire.CmdPrivacyCustomToken,
 		wire.CmdMsgFinishSync,
+		wire.CmdMsgSlashEvidence,
 	}
 	msgType := msg.MessageType()
 	subs := cm.Subscriber.GetMsgToTopics()
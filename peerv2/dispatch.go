@@ -202,6 +202,10 @@ func (d *Dispatcher) processMessageForEachType(messageType reflect.Type, message
 		if d.MessageListeners.OnFeatureMsg != nil {
 			d.MessageListeners.OnFeatureMsg(peerConn, message.(*wire.MessageFeature))
 		}
+	case reflect.TypeOf(&wire.MessageSlashEvidence{}):
+		if d.MessageListeners.OnSlashEvidence != nil {
+			d.MessageListeners.OnSlashEvidence(peerConn, message.(*wire.MessageSlashEvidence))
+		}
 	// case reflect.TypeOf(&wire.MessageMsgCheck{}):
 	// 	err1 := peerConn.handleMsgCheck(message.(*wire.MessageMsgCheck))
 	// 	if err1 != nil {
@@ -233,8 +237,9 @@ type MessageListeners struct {
 	OnAddr           func(p *peer.PeerConn, msg *wire.MessageAddr)
 
 	//PBFT
-	OnBFTMsg     func(p *peer.PeerConn, msg wire.Message)
-	OnPeerState  func(p *peer.PeerConn, msg *wire.MessagePeerState)
-	OnFinishSync func(p *peer.PeerConn, msg *wire.MessageFinishSync)
-	OnFeatureMsg func(p *peer.PeerConn, msg *wire.MessageFeature)
+	OnBFTMsg        func(p *peer.PeerConn, msg wire.Message)
+	OnPeerState     func(p *peer.PeerConn, msg *wire.MessagePeerState)
+	OnFinishSync    func(p *peer.PeerConn, msg *wire.MessageFinishSync)
+	OnFeatureMsg    func(p *peer.PeerConn, msg *wire.MessageFeature)
+	OnSlashEvidence func(p *peer.PeerConn, msg *wire.MessageSlashEvidence)
 }
@@ -0,0 +1,49 @@
+package proto
+
+import "bytes"
+
+// Block types of the snap sync requests, sent through StreamBlockByHash. They are not declared in the
+// highway's highway.proto: proto3 keeps unknown enum values on the wire, so the requests reach
+// block providers as long as the highway forwards them by From like the other block types
+const (
+	// BlkType_StateNode requests state trie nodes by hash from the database of chain From
+	// (HighwayBeaconID for the beacon chain), each returned as raw bytes
+	BlkType_StateNode BlkType = 4
+	// BlkType_FinalView requests the final view of chain From (HighwayBeaconID for the beacon chain),
+	// with its best block, as JSON. The hash of the request is ignored
+	BlkType_FinalView BlkType = 5
+)
+
+// StateSyncVersion is the version of the snap sync requests. The first hash of a snap sync request is
+// StateSyncVersionHash(): block providers serve the request only if they run the same version,
+// providers without snap sync reject the unknown block type. Bump it whenever the requests or responses change
+const StateSyncVersion = 1
+
+func init() {
+	BlkType_name[int32(BlkType_StateNode)] = "StateNode"
+	BlkType_value["StateNode"] = int32(BlkType_StateNode)
+	BlkType_name[int32(BlkType_FinalView)] = "FinalView"
+	BlkType_value["FinalView"] = int32(BlkType_FinalView)
+}
+
+// IsStateSyncType returns true for the block types of the snap sync requests
+func IsStateSyncType(blkType BlkType) bool {
+	return blkType == BlkType_StateNode || blkType == BlkType_FinalView
+}
+
+// StateSyncVersionHash returns the 32 bytes marker of StateSyncVersion sent as the first hash of a snap sync request
+func StateSyncVersionHash() []byte {
+	marker := make([]byte, 32)
+	copy(marker, "statesync")
+	marker[31] = StateSyncVersion
+	return marker
+}
+
+// StateSyncHashes returns the hashes of a snap sync request, without its version marker.
+// ok is false if the request is not of StateSyncVersion
+func StateSyncHashes(req *BlockByHashRequest) (hashes [][]byte, ok bool) {
+	if len(req.Hashes) == 0 || !bytes.Equal(req.Hashes[0], StateSyncVersionHash()) {
+		return nil, false
+	}
+	return req.Hashes[1:], true
+}
@@ -0,0 +1,32 @@
+package proto
+
+import (
+	"testing"
+)
+
+func TestStateSyncHashes(t *testing.T) {
+	hash := make([]byte, 32)
+	hash[0] = 1
+	otherVersion := StateSyncVersionHash()
+	otherVersion[31]++
+
+	tests := []struct {
+		name   string
+		hashes [][]byte
+		want   int
+		ok     bool
+	}{
+		{"same version", [][]byte{StateSyncVersionHash(), hash}, 1, true},
+		{"other version", [][]byte{otherVersion, hash}, 0, false},
+		{"no version", [][]byte{hash}, 0, false},
+		{"empty", nil, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hashes, ok := StateSyncHashes(&BlockByHashRequest{Type: BlkType_StateNode, Hashes: tt.hashes})
+			if ok != tt.ok || len(hashes) != tt.want {
+				t.Fatalf("StateSyncHashes() = %v, %v, want %v hashes, %v", hashes, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
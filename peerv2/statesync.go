@@ -0,0 +1,84 @@
+package peerv2
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/peerv2/proto"
+	"github.com/incognitochain/incognito-chain/peerv2/wrapper"
+)
+
+// RequestStateNodesViaStream requests state trie nodes by hash from the database of chain fromSID
+// (-1 for the beacon chain). Nodes are streamed in the order of hashes, the stream ends at the first node
+// the provider does not have
+func (conn *ConnManager) RequestStateNodesViaStream(ctx context.Context, peerID string, fromSID int, hashes [][]byte) (nodeCh chan []byte, err error) {
+	cID := int32(fromSID)
+	if fromSID == common.BeaconChainID {
+		cID = int32(HighwayBeaconID)
+	}
+	req := &proto.BlockByHashRequest{
+		Type:         proto.BlkType_StateNode,
+		Hashes:       append([][]byte{proto.StateSyncVersionHash()}, hashes...),
+		From:         cID,
+		To:           cID,
+		SyncFromPeer: peerID,
+	}
+	return conn.requestDataByHashViaStream(ctx, peerID, req)
+}
+
+// RequestFinalViewViaStream requests the final view of chain fromSID (-1 for the beacon chain), streamed as JSON
+func (conn *ConnManager) RequestFinalViewViaStream(ctx context.Context, peerID string, fromSID int) (viewCh chan []byte, err error) {
+	cID := int32(fromSID)
+	if fromSID == common.BeaconChainID {
+		cID = int32(HighwayBeaconID)
+	}
+	req := &proto.BlockByHashRequest{
+		Type:         proto.BlkType_FinalView,
+		Hashes:       [][]byte{proto.StateSyncVersionHash(), common.Hash{}.Bytes()},
+		From:         cID,
+		To:           cID,
+		SyncFromPeer: peerID,
+	}
+	return conn.requestDataByHashViaStream(ctx, peerID, req)
+}
+
+func (conn *ConnManager) requestDataByHashViaStream(ctx context.Context, peerID string, req *proto.BlockByHashRequest) (dataCh chan []byte, err error) {
+	Logger.Infof("[stream] Request data type %v by hash from peerID %v, from CID %v, total %v hashes", req.Type, peerID, req.From, len(req.Hashes))
+	dataCh = make(chan []byte, len(req.Hashes))
+	stream, err := conn.Requester.StreamBlockByHash(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	go func(stream proto.HighwayService_StreamBlockByHashClient, ctx context.Context) {
+		defer close(dataCh)
+		for {
+			blkData, err := stream.Recv()
+			if err != nil {
+				return
+			}
+			if len(blkData.Data) < 2 {
+				return
+			}
+
+			var data []byte
+			if req.Type == proto.BlkType_StateNode {
+				err = wrapper.DeCom(blkData.Data[1:], &data)
+			} else {
+				err = wrapper.DeCom(blkData.Data[1:], (*json.RawMessage)(&data))
+			}
+			if err != nil {
+				Logger.Errorf("[stream] %v", err)
+				return
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case dataCh <- data:
+			}
+		}
+	}(stream, ctx)
+
+	return dataCh, nil
+}
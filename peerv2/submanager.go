@@ -397,6 +397,7 @@ func getMessagesForLayer(layer, role string, shardID []byte) []string {
 				wire.CmdBlockShard,
 				wire.CmdBlockBeacon,
 				wire.CmdMsgFinishSync,
+				wire.CmdMsgSlashEvidence,
 				wire.CmdBFT,
 				wire.CmdPeerState,
 				wire.CmdCrossShard,
@@ -434,6 +435,7 @@ func getMessagesForLayer(layer, role string, shardID []byte) []string {
 			wire.CmdBlockShard,
 			wire.CmdMsgFinishSync,
 			wire.CmdMsgFeatureStat,
+			wire.CmdMsgSlashEvidence,
 		}
 	default:
 		containShard := false
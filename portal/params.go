@@ -12,6 +12,7 @@ import (
 	portaltokensv3 "github.com/incognitochain/incognito-chain/portal/portalv3/portaltokens"
 	"github.com/incognitochain/incognito-chain/portal/portalv4"
 	portaltokensv4 "github.com/incognitochain/incognito-chain/portal/portalv4/portaltokens"
+	ltcrelaying "github.com/incognitochain/incognito-chain/relaying/ltc"
 )
 
 type PortalParams struct {
@@ -106,6 +107,25 @@ func SetupParam() {
 	}
 }
 
+// master public keys of beacon validators, they are shared by all portal v4 tokens of a network
+var localPortalV4MasterPubKeys = [][]byte{
+	[]byte{0x3, 0xb2, 0xd3, 0x16, 0x7d, 0x94, 0x9c, 0x25, 0x3, 0xe6, 0x9c, 0x9f, 0x29, 0x78, 0x7d, 0x9c, 0x8, 0x8d, 0x39, 0x17, 0x8d, 0xb4, 0x75, 0x40, 0x35, 0xf5, 0xae, 0x6a, 0xf0, 0x17, 0x12, 0x11, 0x0},
+	[]byte{0x3, 0x98, 0x7a, 0x87, 0xd1, 0x99, 0x13, 0xbd, 0xe3, 0xef, 0xf0, 0x55, 0x79, 0x2, 0xb4, 0x90, 0x57, 0xed, 0x1c, 0x9c, 0x8b, 0x32, 0xf9, 0x2, 0xbb, 0xbb, 0x85, 0x71, 0x3a, 0x99, 0x1f, 0xdc, 0x41},
+	[]byte{0x3, 0x73, 0x23, 0x5e, 0xb1, 0xc8, 0xf1, 0x84, 0xe7, 0x59, 0x17, 0x6c, 0xe3, 0x87, 0x37, 0xb7, 0x91, 0x19, 0x47, 0x1b, 0xba, 0x63, 0x56, 0xbc, 0xab, 0x8d, 0xcc, 0x14, 0x4b, 0x42, 0x99, 0x86, 0x1},
+	[]byte{0x3, 0x29, 0xe7, 0x59, 0x31, 0x89, 0xca, 0x7a, 0xf6, 0x1, 0xb6, 0x35, 0x67, 0x3d, 0xb1, 0x53, 0xd4, 0x19, 0xd7, 0x6, 0x19, 0x3, 0x2a, 0x32, 0x94, 0x57, 0x76, 0xb2, 0xb3, 0x80, 0x65, 0xe1, 0x5d},
+}
+
+var testnetPortalV4MasterPubKeys = [][]byte{
+	[]byte{0x2, 0x30, 0x34, 0xcb, 0x1a, 0x50, 0xf6, 0x7f, 0x5e, 0xb2, 0x53, 0x9e, 0x68, 0x3b, 0xd4,
+		0x80, 0x73, 0x71, 0x2a, 0xdf, 0xf3, 0x25, 0x94, 0x34, 0x72, 0x6d, 0x62, 0x80, 0x83, 0xd2, 0x6f, 0x4c, 0xdd},
+	[]byte{0x2, 0x74, 0x61, 0x32, 0x93, 0xe7, 0x93, 0x85, 0x94, 0xd2, 0x58, 0xfb, 0xcf, 0xc5, 0x33,
+		0x78, 0xdc, 0x82, 0xcd, 0x64, 0xd1, 0xc0, 0x33, 0x1, 0x71, 0x2f, 0x90, 0x85, 0x72, 0xb9, 0x17, 0xab, 0xc7},
+	[]byte{0x3, 0x67, 0x7a, 0x81, 0xfc, 0x9c, 0x4c, 0x9c, 0x6, 0x28, 0xd2, 0xf6, 0xd0, 0x1e, 0x27,
+		0x15, 0xbb, 0x54, 0x11, 0x75, 0xe9, 0x62, 0xae, 0x78, 0x8f, 0xff, 0x26, 0x75, 0x1e, 0xb5, 0x24, 0xe0, 0xeb},
+	[]byte{0x3, 0x2, 0xdb, 0xd4, 0xd4, 0x6b, 0x4e, 0xef, 0xe9, 0xa6, 0xe8, 0x64, 0xce, 0xeb, 0xb5,
+		0x11, 0x25, 0x71, 0x28, 0x8a, 0xc4, 0xce, 0xca, 0xf4, 0x10, 0xd4, 0x16, 0x5f, 0x4c, 0x4c, 0xeb, 0x27, 0xe3},
+}
+
 var localPortalParam = PortalParams{
 	PortalParamsV3: map[uint64]portalv3.PortalParams{
 		0: {
@@ -133,6 +153,8 @@ var localPortalParam = PortalParams{
 		BNBRelayingHeaderChainID: TestnetBNBChainID,
 		BTCRelayingHeaderChainID: TestnetBTCChainID,
 		BTCDataFolderName:        TestnetBTCDataFolderName,
+		LTCRelayingHeaderChainID: TestnetLTCChainID,
+		LTCDataFolderName:        TestnetLTCDataFolderName,
 		BNBFullNodeProtocol:      TestnetBNBFullNodeProtocol,
 		BNBFullNodeHost:          TestnetBNBFullNodeHost,
 		BNBFullNodePort:          TestnetBNBFullNodePort,
@@ -140,32 +162,34 @@ var localPortalParam = PortalParams{
 	PortalParamsV4: map[uint64]portalv4.PortalParams{
 		0: {
 			MasterPubKeys: map[string][][]byte{
-				LocalPortalV4BTCID: [][]byte{
-					[]byte{0x3, 0xb2, 0xd3, 0x16, 0x7d, 0x94, 0x9c, 0x25, 0x3, 0xe6, 0x9c, 0x9f, 0x29, 0x78, 0x7d, 0x9c, 0x8, 0x8d, 0x39, 0x17, 0x8d, 0xb4, 0x75, 0x40, 0x35, 0xf5, 0xae, 0x6a, 0xf0, 0x17, 0x12, 0x11, 0x0},
-					[]byte{0x3, 0x98, 0x7a, 0x87, 0xd1, 0x99, 0x13, 0xbd, 0xe3, 0xef, 0xf0, 0x55, 0x79, 0x2, 0xb4, 0x90, 0x57, 0xed, 0x1c, 0x9c, 0x8b, 0x32, 0xf9, 0x2, 0xbb, 0xbb, 0x85, 0x71, 0x3a, 0x99, 0x1f, 0xdc, 0x41},
-					[]byte{0x3, 0x73, 0x23, 0x5e, 0xb1, 0xc8, 0xf1, 0x84, 0xe7, 0x59, 0x17, 0x6c, 0xe3, 0x87, 0x37, 0xb7, 0x91, 0x19, 0x47, 0x1b, 0xba, 0x63, 0x56, 0xbc, 0xab, 0x8d, 0xcc, 0x14, 0x4b, 0x42, 0x99, 0x86, 0x1},
-					[]byte{0x3, 0x29, 0xe7, 0x59, 0x31, 0x89, 0xca, 0x7a, 0xf6, 0x1, 0xb6, 0x35, 0x67, 0x3d, 0xb1, 0x53, 0xd4, 0x19, 0xd7, 0x6, 0x19, 0x3, 0x2a, 0x32, 0x94, 0x57, 0x76, 0xb2, 0xb3, 0x80, 0x65, 0xe1, 0x5d},
-				},
+				LocalPortalV4BTCID: localPortalV4MasterPubKeys,
+				LocalPortalV4LTCID: localPortalV4MasterPubKeys,
 			},
 			NumRequiredSigs: 3,
 			GeneralMultiSigAddresses: map[string]string{
 				LocalPortalV4BTCID: "tb1qfgzhddwenekk573slpmqdutrd568ej89k37lmjr43tm9nhhulu0scjyajz",
+				LocalPortalV4LTCID: "tltc1qfgzhddwenekk573slpmqdutrd568ej89k37lmjr43tm9nhhulu0s83cuda",
 			},
 			PortalTokens: initPortalTokensV4ForLocal(),
 			DefaultFeeUnshields: map[string]uint64{
 				LocalPortalV4BTCID: 50000, // 50000 nano pbtc = 5000 satoshi
+				LocalPortalV4LTCID: 50000, // 50000 nano pltc = 5000 litoshi
 			},
 			MinShieldAmts: map[string]uint64{
 				LocalPortalV4BTCID: 5000, // 5000 nano pbtc = 500 satoshi
+				LocalPortalV4LTCID: 5000, // 5000 nano pltc = 500 litoshi
 			},
 			MinUnshieldAmts: map[string]uint64{
 				LocalPortalV4BTCID: 500000, // 500000 nano pbtc = 50000 satoshi
+				LocalPortalV4LTCID: 500000, // 500000 nano pltc = 50000 litoshi
 			},
 			DustValueThreshold: map[string]uint64{
 				LocalPortalV4BTCID: 10000000, // 1000000 nano pbtc = 0.01 BTC
+				LocalPortalV4LTCID: 10000000, // 1000000 nano pltc = 0.01 LTC
 			},
 			MinUTXOsInVault: map[string]uint64{
 				LocalPortalV4BTCID: 50,
+				LocalPortalV4LTCID: 50,
 			},
 			BatchNumBlks:                15, // ~ 2.5 mins
 			PortalReplacementAddress:    "12svfkP6w5UDJDSCwqH978PvqiqBxKmUnA9em9yAYWYJVRv7wuXY1qhhYpPAm4BDz2mLbFrRmdK3yRhnTqJCZXKHUmoi7NV83HCH2YFpctHNaDdkSiQshsjw2UFUuwdEvcidgaKmF3VJpY5f8RdN",
@@ -173,9 +197,11 @@ var localPortalParam = PortalParams{
 			TimeSpaceForFeeReplacement:  5 * time.Minute,
 			MaxUnshieldFees: map[string]uint64{
 				LocalPortalV4BTCID: 1000000, // 1000000 nano pbtc = 100000 satoshi
+				LocalPortalV4LTCID: 1000000, // 1000000 nano pltc = 100000 litoshi
 			},
 			PortalV4TokenIDs: []string{
 				LocalPortalV4BTCID,
+				LocalPortalV4LTCID,
 			},
 		},
 	},
@@ -208,6 +234,8 @@ var testnet1PortalParams = PortalParams{
 		BNBRelayingHeaderChainID: TestnetBNBChainID,
 		BTCRelayingHeaderChainID: TestnetBTCChainID,
 		BTCDataFolderName:        TestnetBTCDataFolderName,
+		LTCRelayingHeaderChainID: TestnetLTCChainID,
+		LTCDataFolderName:        TestnetLTCDataFolderName,
 		BNBFullNodeProtocol:      TestnetBNBFullNodeProtocol,
 		BNBFullNodeHost:          TestnetBNBFullNodeHost,
 		BNBFullNodePort:          TestnetBNBFullNodePort,
@@ -215,22 +243,13 @@ var testnet1PortalParams = PortalParams{
 	PortalParamsV4: map[uint64]portalv4.PortalParams{
 		0: {
 			MasterPubKeys: map[string][][]byte{
-				TestnetPortalV4BTCID: [][]byte{
-					[]byte{0x2, 0x30, 0x34, 0xcb, 0x1a, 0x50, 0xf6, 0x7f, 0x5e, 0xb2, 0x53, 0x9e, 0x68, 0x3b, 0xd4,
-						0x80, 0x73, 0x71, 0x2a, 0xdf, 0xf3, 0x25, 0x94, 0x34, 0x72, 0x6d, 0x62, 0x80, 0x83, 0xd2, 0x6f, 0x4c, 0xdd},
-					[]byte{0x2, 0x74, 0x61, 0x32, 0x93, 0xe7, 0x93, 0x85, 0x94, 0xd2, 0x58, 0xfb, 0xcf, 0xc5, 0x33,
-						0x78, 0xdc, 0x82, 0xcd, 0x64, 0xd1, 0xc0, 0x33, 0x1, 0x71, 0x2f, 0x90, 0x85, 0x72, 0xb9, 0x17, 0xab, 0xc7},
-					[]byte{0x3, 0x67, 0x7a, 0x81, 0xfc, 0x9c, 0x4c, 0x9c, 0x6, 0x28, 0xd2, 0xf6, 0xd0, 0x1e, 0x27,
-						0x15, 0xbb, 0x54, 0x11, 0x75, 0xe9, 0x62, 0xae, 0x78, 0x8f, 0xff, 0x26, 0x75, 0x1e, 0xb5, 0x24, 0xe0, 0xeb},
-					[]byte{0x3, 0x2, 0xdb, 0xd4, 0xd4, 0x6b, 0x4e, 0xef, 0xe9, 0xa6, 0xe8, 0x64, 0xce, 0xeb, 0xb5,
-						0x11, 0x25, 0x71, 0x28, 0x8a, 0xc4, 0xce, 0xca, 0xf4, 0x10, 0xd4, 0x16, 0x5f, 0x4c, 0x4c, 0xeb, 0x27, 0xe3},
-				},
+				TestnetPortalV4BTCID: testnetPortalV4MasterPubKeys,
 			},
 			NumRequiredSigs: 3,
 			GeneralMultiSigAddresses: map[string]string{
 				TestnetPortalV4BTCID: "tb1qjjy5aqpf86979y6jdkvy8nwh2z3r3qtt7tr9ux0wj4lk8vydffnq7azu84",
 			},
-			PortalTokens: initPortalTokensV4ForTestNet(),
+			PortalTokens: initPortalTokensV4ForTestNet(false),
 			DefaultFeeUnshields: map[string]uint64{
 				TestnetPortalV4BTCID: 50000, // nano pbtc
 			},
@@ -257,6 +276,51 @@ var testnet1PortalParams = PortalParams{
 				TestnetPortalV4BTCID,
 			},
 		},
+		// pLTC is supported from this beacon height
+		TestnetPortalV4LTCBeaconHeight: {
+			MasterPubKeys: map[string][][]byte{
+				TestnetPortalV4BTCID: testnetPortalV4MasterPubKeys,
+				TestnetPortalV4LTCID: testnetPortalV4MasterPubKeys,
+			},
+			NumRequiredSigs: 3,
+			GeneralMultiSigAddresses: map[string]string{
+				TestnetPortalV4BTCID: "tb1qjjy5aqpf86979y6jdkvy8nwh2z3r3qtt7tr9ux0wj4lk8vydffnq7azu84",
+				TestnetPortalV4LTCID: "tltc1qjjy5aqpf86979y6jdkvy8nwh2z3r3qtt7tr9ux0wj4lk8vydffnqp77ac2",
+			},
+			PortalTokens: initPortalTokensV4ForTestNet(true),
+			DefaultFeeUnshields: map[string]uint64{
+				TestnetPortalV4BTCID: 50000, // nano pbtc
+				TestnetPortalV4LTCID: 50000, // nano pltc
+			},
+			MinShieldAmts: map[string]uint64{
+				TestnetPortalV4BTCID: 100000, // nano pbtc
+				TestnetPortalV4LTCID: 100000, // nano pltc
+			},
+			MinUnshieldAmts: map[string]uint64{
+				TestnetPortalV4BTCID: 100000, // nano pbtc
+				TestnetPortalV4LTCID: 100000, // nano pltc
+			},
+			DustValueThreshold: map[string]uint64{
+				TestnetPortalV4BTCID: 10000000, // nano pbtc
+				TestnetPortalV4LTCID: 10000000, // nano pltc
+			},
+			MinUTXOsInVault: map[string]uint64{
+				TestnetPortalV4BTCID: 50,
+				TestnetPortalV4LTCID: 50,
+			},
+			BatchNumBlks:                20,
+			PortalReplacementAddress:    "12sv8WUvkvFfD5SW3aaXDSPs8yx2SxPdbv6a2LAU6FJb2kBKqmLcCuQ6ZQst4fg7THBTBtERaqMpJ7KBgsnRYobmysFEM2pbMwLE2kGzwyxgSijnZT7VQGeuUxBryC1Z6ebd8EWqDUkxwpW7Gqt8",
+			MaxFeePercentageForEachStep: 10, // ~ 10% from previous fee
+			TimeSpaceForFeeReplacement:  5 * time.Minute,
+			MaxUnshieldFees: map[string]uint64{
+				TestnetPortalV4BTCID: 100000, // pbtc
+				TestnetPortalV4LTCID: 100000, // pltc
+			},
+			PortalV4TokenIDs: []string{
+				TestnetPortalV4BTCID,
+				TestnetPortalV4LTCID,
+			},
+		},
 	},
 }
 
@@ -514,11 +578,25 @@ func initPortalTokensV4ForLocal() map[string]portaltokensv4.PortalTokenProcessor
 			ChainParam:    &chaincfg.TestNet3Params,
 			PortalTokenID: LocalPortalV4BTCID,
 		},
+		LocalPortalV4LTCID: portaltokensv4.PortalLTCTokenProcessor{
+			PortalBTCTokenProcessor: portaltokensv4.PortalBTCTokenProcessor{
+				PortalToken: &portaltokensv4.PortalToken{
+					ChainID:             TestnetLTCChainID,
+					MinTokenAmount:      10,
+					MultipleTokenAmount: 10,
+					ExternalInputSize:   130,
+					ExternalOutputSize:  43,
+					ExternalTxMaxSize:   5120,
+				},
+				ChainParam:    &ltcrelaying.TestNet4Params,
+				PortalTokenID: LocalPortalV4LTCID,
+			},
+		},
 	}
 }
 
-func initPortalTokensV4ForTestNet() map[string]portaltokensv4.PortalTokenProcessor {
-	return map[string]portaltokensv4.PortalTokenProcessor{
+func initPortalTokensV4ForTestNet(withLTC bool) map[string]portaltokensv4.PortalTokenProcessor {
+	portalTokens := map[string]portaltokensv4.PortalTokenProcessor{
 		TestnetPortalV4BTCID: portaltokensv4.PortalBTCTokenProcessor{
 			PortalToken: &portaltokensv4.PortalToken{
 				ChainID:             TestnetBTCChainID,
@@ -532,6 +610,23 @@ func initPortalTokensV4ForTestNet() map[string]portaltokensv4.PortalTokenProcess
 			PortalTokenID: TestnetPortalV4BTCID,
 		},
 	}
+	if withLTC {
+		portalTokens[TestnetPortalV4LTCID] = portaltokensv4.PortalLTCTokenProcessor{
+			PortalBTCTokenProcessor: portaltokensv4.PortalBTCTokenProcessor{
+				PortalToken: &portaltokensv4.PortalToken{
+					ChainID:             TestnetLTCChainID,
+					MinTokenAmount:      10,
+					MultipleTokenAmount: 10,
+					ExternalInputSize:   130,
+					ExternalOutputSize:  43,
+					ExternalTxMaxSize:   5120,
+				},
+				ChainParam:    &ltcrelaying.TestNet4Params,
+				PortalTokenID: TestnetPortalV4LTCID,
+			},
+		}
+	}
+	return portalTokens
 }
 
 func initPortalTokensV4ForTestNet2() map[string]portaltokensv4.PortalTokenProcessor {
@@ -573,6 +668,8 @@ const (
 	TestnetBNBChainID        = "Binance-Chain-Ganges"
 	TestnetBTCChainID        = "Bitcoin-Testnet"
 	TestnetBTCDataFolderName = "btcrelayingv15"
+	TestnetLTCChainID        = "Litecoin-Testnet"
+	TestnetLTCDataFolderName = "ltcrelayingv1"
 
 	// BNB fullnode
 	TestnetBNBFullNodeHost     = "data-seed-pre-0-s3.binance.org"
@@ -607,4 +704,9 @@ const (
 	TestnetPortalV4BTCID  = "4584d5e9b2fc0337dfb17f4b5bb025e5b82c38cfa4f54e8a3d4fcdd03954ff82"
 	Testnet2PortalV4BTCID = "4584d5e9b2fc0337dfb17f4b5bb025e5b82c38cfa4f54e8a3d4fcdd03954ff82"
 	MainnetPortalV4BTCID  = "b832e5d3b1f01a4f0623f7fe91d6673461e1f5d37d91fe78c5c2e6183ff39696"
+	LocalPortalV4LTCID    = "98775b952962cb2da787cea0e862e5e95079534dfadbf88764b01b7a276daf17"
+	TestnetPortalV4LTCID  = "415313ae8764bbbc43c47a73dc0f2f6986fa6e9a39986f77a82f7360f36b5357"
+
+	// feature heights of portal token v4
+	TestnetPortalV4LTCBeaconHeight = 3200000
 )
@@ -4,6 +4,8 @@ type RelayingParams struct {
 	BNBRelayingHeaderChainID string
 	BTCRelayingHeaderChainID string
 	BTCDataFolderName        string
+	LTCRelayingHeaderChainID string
+	LTCDataFolderName        string
 	BNBFullNodeProtocol      string
 	BNBFullNodeHost          string
 	BNBFullNodePort          string
@@ -37,6 +37,9 @@ type RelayingBNBChain struct {
 type RelayingBTCChain struct {
 	*RelayingChain
 }
+type RelayingLTCChain struct {
+	*RelayingChain
+}
 
 func (rChain *RelayingChain) GetActions() [][]string {
 	return rChain.Actions
@@ -150,4 +153,27 @@ func (rbtcChain *RelayingBTCChain) BuildRelayingInst(
 		RelayingHeaderConsideringChainStatus,
 	)
 	return [][]string{inst}
-}
\ No newline at end of file
+}
+func (rltcChain *RelayingLTCChain) BuildRelayingInst(
+	bc metadata.ChainRetriever,
+	relayingHeaderAction metadata.RelayingHeaderAction,
+	relayingState *RelayingHeaderChainState,
+) [][]string {
+	Logger.log.Info("[LTC Relaying] - Processing buildRelayingInst...")
+	status := RelayingHeaderConsideringChainStatus
+	if relayingState == nil || relayingState.LTCHeaderChain == nil {
+		// the network has no ltc relaying chain
+		Logger.log.Errorf("Error - [buildInstructionsForLTCHeaderRelaying]: LTC relaying chain is not enabled.")
+		status = RelayingHeaderRejectedChainStatus
+	}
+	inst := rltcChain.BuildHeaderRelayingInst(
+		relayingHeaderAction.Meta.IncogAddressStr,
+		relayingHeaderAction.Meta.Header,
+		relayingHeaderAction.Meta.BlockHeight,
+		relayingHeaderAction.Meta.Type,
+		relayingHeaderAction.ShardID,
+		relayingHeaderAction.TxReqID,
+		status,
+	)
+	return [][]string{inst}
+}
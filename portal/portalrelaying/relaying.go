@@ -17,10 +17,11 @@ import (
 )
 
 // RelayingHeaderChainState is state of relaying header chains
-// include btc and bnb header chain
+// include btc, ltc and bnb header chain
 type RelayingHeaderChainState struct {
 	BNBHeaderChain *bnbrelaying.BNBChainState
 	BTCHeaderChain *btcrelaying.BlockChain
+	LTCHeaderChain *btcrelaying.BlockChain
 }
 
 /*
@@ -124,6 +125,8 @@ func ProcessRelayingInstructions(instructions [][]string, relayingState *Relayin
 		//	err = blockchain.processRelayingBNBHeaderInst(inst, relayingState)
 		case strconv.Itoa(metadata.RelayingBTCHeaderMeta):
 			err = ProcessRelayingBTCHeaderInst(inst, relayingState)
+		case strconv.Itoa(metadata.RelayingLTCHeaderMeta):
+			err = ProcessRelayingLTCHeaderInst(inst, relayingState)
 		}
 		if err != nil {
 			Logger.log.Error(err)
@@ -147,7 +150,26 @@ func ProcessRelayingBTCHeaderInst(
 	if btcHeaderChain == nil {
 		return errors.New("[processRelayingBTCHeaderInst] BTC Header chain instance should not be nil")
 	}
+	return processRelayingBlockHeaderInst(instruction, btcHeaderChain)
+}
 
+func ProcessRelayingLTCHeaderInst(
+	instruction []string,
+	relayingState *RelayingHeaderChainState,
+) error {
+	Logger.log.Info("[LTC Relaying] - Processing processRelayingLTCHeaderInst...")
+	ltcHeaderChain := relayingState.LTCHeaderChain
+	if ltcHeaderChain == nil {
+		return errors.New("[processRelayingLTCHeaderInst] LTC Header chain instance should not be nil")
+	}
+	return processRelayingBlockHeaderInst(instruction, ltcHeaderChain)
+}
+
+// processRelayingBlockHeaderInst pushes the header in the instruction to a relaying chain of bitcoin or its forks
+func processRelayingBlockHeaderInst(
+	instruction []string,
+	headerChain *btcrelaying.BlockChain,
+) error {
 	if len(instruction) != 4 {
 		return nil // skip the instruction
 	}
@@ -168,7 +190,7 @@ func ProcessRelayingBTCHeaderInst(
 		return err
 	}
 	block := btcutil.NewBlock(msgBlk)
-	isMainChain, isOrphan, err := btcHeaderChain.ProcessBlockV2(block, btcrelaying.BFNone)
+	isMainChain, isOrphan, err := headerChain.ProcessBlockV2(block, btcrelaying.BFNone)
 	if err != nil {
 		Logger.log.Errorf("ProcessBlock fail with error: %v", err)
 		return err
@@ -130,6 +130,17 @@ func (p PortalBTCTokenProcessor) ParseAndVerifyUnshieldProof(
 	utxos []*statedb.UTXO,
 ) (bool, []*statedb.UTXO, string, uint64, error) {
 	btcChain := bc.GetBTCHeaderChain()
+	return p.parseAndVerifyUnshieldProofBTCChain(proof, btcChain, expectedReceivedMultisigAddress, chainCodeSeed, expectPaymentInfo, utxos)
+}
+
+func (p PortalBTCTokenProcessor) parseAndVerifyUnshieldProofBTCChain(
+	proof string,
+	btcChain *btcrelaying.BlockChain,
+	expectedReceivedMultisigAddress string,
+	chainCodeSeed string,
+	expectPaymentInfo []*OutputTx,
+	utxos []*statedb.UTXO,
+) (bool, []*statedb.UTXO, string, uint64, error) {
 	if btcChain == nil {
 		Logger.log.Error("BTC relaying chain should not be null")
 		return false, nil, "", 0, errors.New("BTC relaying chain should not be null")
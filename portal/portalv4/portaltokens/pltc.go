@@ -0,0 +1,50 @@
+package portaltokens
+
+import (
+	"errors"
+
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/metadata"
+)
+
+// PortalLTCTokenProcessor processes pLTC, litecoin shares the transaction format,
+// the script system and the 8 decimals of bitcoin so only the relaying header chain
+// and the chain params (addresses, HD keys) differ from pBTC
+type PortalLTCTokenProcessor struct {
+	PortalBTCTokenProcessor
+}
+
+func (p PortalLTCTokenProcessor) ParseAndVerifyShieldProof(
+	proof string, bc metadata.ChainRetriever, expectedReceivedMultisigAddress string, chainCodeSeed string, minShieldAmt uint64,
+) (bool, []*statedb.UTXO, error) {
+	ltcChain := bc.GetLTCHeaderChain()
+	if ltcChain == nil {
+		Logger.log.Error("LTC relaying chain should not be null")
+		return false, nil, errors.New("LTC relaying chain should not be null")
+	}
+	return p.parseAndVerifyProofBTCChain(proof, ltcChain, expectedReceivedMultisigAddress, chainCodeSeed, minShieldAmt)
+}
+
+func (p PortalLTCTokenProcessor) ParseAndVerifyUnshieldProof(
+	proof string,
+	bc metadata.ChainRetriever,
+	expectedReceivedMultisigAddress string,
+	chainCodeSeed string,
+	expectPaymentInfo []*OutputTx,
+	utxos []*statedb.UTXO,
+) (bool, []*statedb.UTXO, string, uint64, error) {
+	ltcChain := bc.GetLTCHeaderChain()
+	if ltcChain == nil {
+		Logger.log.Error("LTC relaying chain should not be null")
+		return false, nil, "", 0, errors.New("LTC relaying chain should not be null")
+	}
+	return p.parseAndVerifyUnshieldProofBTCChain(proof, ltcChain, expectedReceivedMultisigAddress, chainCodeSeed, expectPaymentInfo, utxos)
+}
+
+func (p PortalLTCTokenProcessor) IsValidRemoteAddress(address string, bcr metadata.ChainRetriever) (bool, error) {
+	ltcHeaderChain := bcr.GetLTCHeaderChain()
+	if ltcHeaderChain == nil {
+		return false, nil
+	}
+	return ltcHeaderChain.IsBTCAddressValid(address), nil
+}
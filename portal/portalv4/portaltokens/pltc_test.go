@@ -0,0 +1,54 @@
+package portaltokens
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	ltcrelaying "github.com/incognitochain/incognito-chain/relaying/ltc"
+)
+
+func TestGenerateOTMultisigAddressLTC(t *testing.T) {
+	pBTC := PortalBTCTokenProcessor{
+		ChainParam: &chaincfg.TestNet3Params,
+	}
+	pLTC := PortalLTCTokenProcessor{
+		PortalBTCTokenProcessor: PortalBTCTokenProcessor{
+			ChainParam: &ltcrelaying.TestNet4Params,
+		},
+	}
+
+	seeds := [][]byte{
+		[]byte{0xf1, 0x29, 0xb7, 0xa, 0x46, 0xac, 0x35, 0xc4, 0x17, 0x94, 0x10, 0xf3, 0x52, 0xd7, 0xf5, 0x5c, 0xc5, 0x47, 0xe1, 0xa9, 0x26, 0x1f, 0xe8, 0xed, 0xe7, 0x72, 0x34, 0x4, 0x71, 0xeb, 0xc6, 0x9},
+		[]byte{0xca, 0xa8, 0xaa, 0xdf, 0x1e, 0xdb, 0xc5, 0x72, 0x80, 0x8f, 0x8, 0x65, 0x1d, 0x41, 0x85, 0xde, 0xd1, 0x21, 0x5a, 0xd4, 0x7, 0xe6, 0x3c, 0xb4, 0x6f, 0x11, 0xc5, 0x5, 0xc6, 0x16, 0x7e, 0xfe},
+		[]byte{0x64, 0x3b, 0x2d, 0xb2, 0x89, 0x5c, 0x53, 0x11, 0x5a, 0xb1, 0x53, 0xd, 0xfd, 0xb3, 0x32, 0xee, 0x1b, 0xe0, 0x7d, 0xcc, 0xd4, 0x3a, 0xd9, 0xf5, 0x62, 0x9b, 0x4c, 0x50, 0x88, 0xa8, 0xad, 0x1a},
+		[]byte{0x0, 0xa, 0x43, 0x51, 0xdf, 0x7b, 0x2b, 0x86, 0xc3, 0x40, 0x58, 0xe6, 0x42, 0xa6, 0xc2, 0x5d, 0xb6, 0x6c, 0x30, 0x88, 0x8d, 0xb5, 0x8e, 0xe1, 0x44, 0xce, 0xc0, 0x45, 0xc, 0xf5, 0xa0, 0xeb},
+	}
+	masterPubKeys := [][]byte{}
+	for _, seed := range seeds {
+		masterPubKeys = append(masterPubKeys, pLTC.generatePublicKeyFromSeed(seed))
+	}
+	incAddress := "12S5Lrs1XeQLbqN4ySyKtjAjd2d7sBP2tjFijzmp6avrrkQCNFMpkXm3FPzj2Wcu2ZNqJEmh9JriVuRErVwhuQnLmWSaggobEWsBEci"
+
+	btcScript, btcAddress, err := pBTC.GenerateOTMultisigAddress(masterPubKeys, 3, incAddress)
+	if err != nil {
+		t.Fatalf("Error: %v\n", err)
+	}
+	ltcScript, ltcAddress, err := pLTC.GenerateOTMultisigAddress(masterPubKeys, 3, incAddress)
+	if err != nil {
+		t.Fatalf("Error: %v\n", err)
+	}
+
+	// litecoin shares the script system of bitcoin, only the address encoding differs
+	if !bytes.Equal(btcScript, ltcScript) {
+		t.Errorf("Multisig scripts are different")
+	}
+	if !strings.HasPrefix(ltcAddress, "tltc1") {
+		t.Errorf("Invalid litecoin testnet address %v", ltcAddress)
+	}
+	if btcAddress == ltcAddress {
+		t.Errorf("Litecoin address must differ from bitcoin address %v", btcAddress)
+	}
+	t.Logf("P2WSH Bech32 address: %v\n", ltcAddress)
+}
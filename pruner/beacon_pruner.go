@@ -0,0 +1,127 @@
+package pruner
+
+import (
+	"encoding/json"
+
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
+	"github.com/incognitochain/incognito-chain/incdb"
+)
+
+// beaconStates reads the state roots of the beacon chain.
+type beaconStates struct {
+	db       incdb.Database
+	shardDBs map[byte]incdb.Database
+}
+
+func NewBeaconPruner(db incdb.Database, shardDBs map[byte]incdb.Database) *ChainPruner {
+	return newChainPruner(common.BeaconChainID, db, &beaconStates{
+		db:       db,
+		shardDBs: shardDBs,
+	})
+}
+
+func beaconViewRoots(v *blockchain.BeaconBestState) stateRoots {
+	return beaconRoots(&blockchain.BeaconRootHash{
+		ConsensusStateDBRootHash: v.ConsensusStateDBRootHash,
+		FeatureStateDBRootHash:   v.FeatureStateDBRootHash,
+		RewardStateDBRootHash:    v.RewardStateDBRootHash,
+		SlashStateDBRootHash:     v.SlashStateDBRootHash,
+	})
+}
+
+func beaconRoots(bRH *blockchain.BeaconRootHash) stateRoots {
+	return stateRoots{
+		ConsensusStateDB: bRH.ConsensusStateDBRootHash,
+		FeatureStateDB:   bRH.FeatureStateDBRootHash,
+		RewardStateDB:    bRH.RewardStateDBRootHash,
+		SlashStateDB:     bRH.SlashStateDBRootHash,
+	}
+}
+
+func (s *beaconStates) loadViews() ([]chainView, error) {
+	allViews := []*blockchain.BeaconBestState{}
+	views, err := rawdbv2.GetBeaconViews(s.db)
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(views, &allViews)
+	if err != nil {
+		return nil, err
+	}
+	res := []chainView{}
+	for _, v := range allViews {
+		res = append(res, chainView{view: v, roots: beaconViewRoots(v)})
+	}
+	return res, nil
+}
+
+// keepFromHeight keeps the beacon blocks the synced shards have not processed
+// yet, since shards read the beacon feature and consensus state of the beacon
+// blocks they include. It also keeps the consensus state of the blocks the shard
+// committees are taken from.
+func (s *beaconStates) keepFromHeight(finalHeight uint64) (uint64, []stateRoots, error) {
+	keepHeight := finalHeight
+	keepRoots := []stateRoots{}
+	keepHashes := make(map[common.Hash]struct{})
+	for sid, db := range s.shardDBs {
+		data, err := rawdbv2.GetShardBestState(db, sid)
+		if err != nil {
+			// this shard is not synced by the node
+			continue
+		}
+		views := []*blockchain.ShardBestState{}
+		if err := json.Unmarshal(data, &views); err != nil {
+			return 0, nil, err
+		}
+		for _, v := range views {
+			if v.BeaconHeight < keepHeight {
+				keepHeight = v.BeaconHeight
+			}
+			if v.BestBlock != nil && !v.BestBlock.Header.CommitteeFromBlock.IsEqual(&common.Hash{}) {
+				keepHashes[v.BestBlock.Header.CommitteeFromBlock] = struct{}{}
+			}
+		}
+	}
+	for hash := range keepHashes {
+		data, err := rawdbv2.GetBeaconRootsHash(s.db, hash)
+		if err != nil {
+			// the beacon has not inserted this block yet
+			continue
+		}
+		roots, err := s.decodeRoots(data)
+		if err != nil {
+			return 0, nil, err
+		}
+		keepRoots = append(keepRoots, roots)
+	}
+	if keepHeight == 0 {
+		keepHeight = 1
+	}
+	return keepHeight, keepRoots, nil
+}
+
+func (s *beaconStates) rootsAtHeight(height uint64) (stateRoots, error) {
+	h, err := rawdbv2.GetFinalizedBeaconBlockHashByIndex(s.db, height)
+	if err != nil {
+		return nil, err
+	}
+	data, err := rawdbv2.GetBeaconRootsHash(s.db, *h)
+	if err != nil {
+		return nil, err
+	}
+	return s.decodeRoots(data)
+}
+
+func (s *beaconStates) rootsPrefix() []byte {
+	return rawdbv2.GetBeaconRootsHashPrefix()
+}
+
+func (s *beaconStates) decodeRoots(data []byte) (stateRoots, error) {
+	bRH := &blockchain.BeaconRootHash{}
+	if err := json.Unmarshal(data, bRH); err != nil {
+		return nil, err
+	}
+	return beaconRoots(bRH), nil
+}
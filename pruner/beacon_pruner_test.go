@@ -0,0 +1,135 @@
+package pruner
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/blockchain/types"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
+	"github.com/incognitochain/incognito-chain/incdb"
+	"github.com/stretchr/testify/assert"
+)
+
+var beaconStateDBTypes = []string{ConsensusStateDB, FeatureStateDB, RewardStateDB, SlashStateDB}
+
+func testBeaconView(height uint64, roots stateRoots) *blockchain.BeaconBestState {
+	return &blockchain.BeaconBestState{
+		BestBlock:                types.BeaconBlock{Header: types.BeaconHeader{Height: height}},
+		ConsensusStateDBRootHash: roots[ConsensusStateDB],
+		FeatureStateDBRootHash:   roots[FeatureStateDB],
+		RewardStateDBRootHash:    roots[RewardStateDB],
+		SlashStateDBRootHash:     roots[SlashStateDB],
+	}
+}
+
+// newTestBeaconChain stores a beacon chain finalized at testHeights-1 whose
+// best view is at testHeights.
+func newTestBeaconChain(t *testing.T) (incdb.Database, []stateRoots) {
+	db := newTestDB(t)
+	roots := storeTestRoots(t, db, beaconStateDBTypes)
+	for height := uint64(1); height <= testHeights; height++ {
+		hash := testBlockHash(common.BeaconChainID, height)
+		assert.Nil(t, rawdbv2.StoreBeaconRootsHash(db, hash, &blockchain.BeaconRootHash{
+			ConsensusStateDBRootHash: roots[height][ConsensusStateDB],
+			FeatureStateDBRootHash:   roots[height][FeatureStateDB],
+			RewardStateDBRootHash:    roots[height][RewardStateDB],
+			SlashStateDBRootHash:     roots[height][SlashStateDB],
+		}))
+		assert.Nil(t, rawdbv2.StoreFinalizedBeaconBlockHashByIndex(db, height, hash))
+	}
+	data, err := json.Marshal([]*blockchain.BeaconBestState{
+		testBeaconView(testHeights-1, roots[testHeights-1]),
+		testBeaconView(testHeights, roots[testHeights]),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Nil(t, rawdbv2.StoreBeaconViews(db, data))
+	return db, roots
+}
+
+// newTestShardDB stores a shard view which has included the beacon blocks up
+// to beaconHeight and takes its committee from the beacon block at committeeHeight.
+func newTestShardDB(t *testing.T, beaconHeight uint64, committeeHeight uint64) incdb.Database {
+	db := newTestDB(t)
+	view := testShardView(1, stateRoots{})
+	view.BeaconHeight = beaconHeight
+	view.BestBlock.Header.PreviousBlockHash = common.Hash{}
+	view.BestBlock.Header.CommitteeRoot = common.Hash{}
+	view.BestBlock.ValidationData = ""
+	if committeeHeight > 0 {
+		view.BestBlock.Header.CommitteeFromBlock = testBlockHash(common.BeaconChainID, committeeHeight)
+	}
+	assert.Nil(t, rawdbv2.StoreShardBestState(db, 0, []*blockchain.ShardBestState{view}))
+	return db
+}
+
+func TestBeaconStatesKeepFromHeight(t *testing.T) {
+	db, roots := newTestBeaconChain(t)
+	tests := []struct {
+		name       string
+		shardDBs   map[byte]incdb.Database
+		wantHeight uint64
+		wantRoots  []stateRoots
+	}{
+		{
+			name:       "no synced shard",
+			shardDBs:   map[byte]incdb.Database{},
+			wantHeight: testHeights - 1,
+			wantRoots:  []stateRoots{},
+		},
+		{
+			name:       "shard behind the final view",
+			shardDBs:   map[byte]incdb.Database{0: newTestShardDB(t, 3, 0)},
+			wantHeight: 3,
+			wantRoots:  []stateRoots{},
+		},
+		{
+			name:       "shard ahead of the final view",
+			shardDBs:   map[byte]incdb.Database{0: newTestShardDB(t, testHeights, 0)},
+			wantHeight: testHeights - 1,
+			wantRoots:  []stateRoots{},
+		},
+		{
+			name:       "shard committee from an old block",
+			shardDBs:   map[byte]incdb.Database{0: newTestShardDB(t, 4, 2)},
+			wantHeight: 4,
+			wantRoots:  []stateRoots{roots[2]},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &beaconStates{db: db, shardDBs: tt.shardDBs}
+			height, keepRoots, err := s.keepFromHeight(testHeights - 1)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.wantHeight, height)
+			assert.Equal(t, tt.wantRoots, keepRoots)
+		})
+	}
+}
+
+func TestBeaconPrunerPrune(t *testing.T) {
+	for _, byHash := range []bool{false, true} {
+		db, roots := newTestBeaconChain(t)
+		p := NewBeaconPruner(db, map[byte]incdb.Database{0: newTestShardDB(t, 4, 2)})
+		p.SetBloomSize(1)
+		assert.Nil(t, p.Prune(byHash))
+
+		// the committee block of the shard, the blocks it has not included and the views are kept
+		for _, height := range []uint64{2, 4, 5, 6} {
+			assert.Nil(t, recheckRoots(db, roots[height]), "byHash %v height %v", byHash, height)
+		}
+		for _, height := range []uint64{1, 3} {
+			assert.NotNil(t, recheckRoots(db, roots[height]), "byHash %v height %v", byHash, height)
+		}
+
+		report := p.Report()
+		assert.Empty(t, report.Error)
+		for _, dbType := range beaconStateDBTypes {
+			assert.True(t, report.StateDBs[dbType].TotalNodePrune > 0, dbType)
+		}
+		assert.Equal(t, uint64(0), report.StateDBs[TransactionStateDB].TotalNodePrune)
+	}
+}
@@ -0,0 +1,396 @@
+package pruner
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/config"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/incdb"
+	"github.com/incognitochain/incognito-chain/multiview"
+	"github.com/incognitochain/incognito-chain/trie"
+	"github.com/pkg/errors"
+)
+
+// stateRoots maps a state db type to the root of its trie in one block.
+type stateRoots map[string]common.Hash
+
+// chainView is a view restored from database together with its state roots.
+type chainView struct {
+	view  multiview.View
+	roots stateRoots
+}
+
+// chainStates gives a ChainPruner access to the state roots of one chain.
+type chainStates interface {
+	// loadViews restores all views stored in the chain database, the final view first.
+	loadViews() ([]chainView, error)
+	// keepFromHeight returns the height from which finalized state is still read by
+	// other chains and must be kept, and the roots of older blocks that are still read.
+	keepFromHeight(finalHeight uint64) (uint64, []stateRoots, error)
+	// rootsAtHeight returns the state roots of the finalized block at height.
+	rootsAtHeight(height uint64) (stateRoots, error)
+	// rootsPrefix is the database prefix of the state roots stored for every block.
+	rootsPrefix() []byte
+	// decodeRoots parses a value stored under rootsPrefix.
+	decodeRoots(data []byte) (stateRoots, error)
+}
+
+// ChainPruner removes the state trie nodes of one chain that are not reachable
+// from any view kept by the node. All state dbs of a chain share the same
+// database, so a single bloom filter collects the reachable nodes of every trie.
+type ChainPruner struct {
+	//state
+	chainID     int
+	db          incdb.Database
+	states      chainStates
+	stateBloom  *trie.StateBloom
+	bloomSize   uint64
+	role        string
+	finalHeight uint64
+	keepHeight  uint64
+	bestView    *chainView
+
+	//lock
+	lock       sync.Mutex
+	wg         sync.WaitGroup
+	insertLock *sync.Mutex
+
+	//report
+	lastTriggerTime      time.Time
+	lastProcessingMode   string
+	status               int
+	lastError            string
+	lastProcessingHeight uint64
+	storage              uint64
+	nodes                uint64
+	stateDBs             map[string]*StateDBReport
+}
+
+func newChainPruner(chainID int, db incdb.Database, states chainStates) *ChainPruner {
+	//init object
+	cp := &ChainPruner{
+		chainID:  chainID,
+		db:       db,
+		states:   states,
+		stateDBs: make(map[string]*StateDBReport),
+	}
+	for _, dbType := range stateDBTypes {
+		cp.stateDBs[dbType] = &StateDBReport{}
+	}
+	cp.restoreStatus()
+	if cp.lastProcessingHeight == 0 {
+		cp.lastProcessingHeight = 1
+	}
+	return cp
+}
+
+func (s *ChainPruner) SetBloomSize(size uint64) {
+	s.bloomSize = size
+}
+
+func (s *ChainPruner) Stop() {
+	s.status = IDLE
+}
+
+func (s *ChainPruner) InitBloomState() error {
+	//restore best views and final view from database
+	allViews, err := s.states.loadViews()
+	if err != nil {
+		Logger.log.Errorf("[state-prune %v] Cannot restore views %v", s.chainID, err)
+		return err
+	}
+	//collect tree nodes want to keep, add them to state bloom
+	if len(allViews) > 0 {
+		s.finalHeight = allViews[0].view.GetHeight()
+		s.bestView = &allViews[len(allViews)-1]
+	} else {
+		return errors.New("Cannot retrieve all views")
+	}
+	s.stateBloom = nil
+	for _, v := range allViews {
+		Logger.log.Infof("[state-prune %v] Start retrieve view %s at height %v",
+			s.chainID, v.view.GetHash().String(), v.view.GetHeight())
+		err = s.addRootsToBloom(v.roots)
+		if err != nil {
+			return err
+		}
+	}
+
+	//finalized blocks which are still read by other chains are kept as well
+	keepHeight, keepRoots, err := s.states.keepFromHeight(s.finalHeight)
+	if err != nil {
+		return err
+	}
+	for height := keepHeight; height < s.finalHeight; height++ {
+		roots, err := s.states.rootsAtHeight(height)
+		if err != nil {
+			return err
+		}
+		keepRoots = append(keepRoots, roots)
+	}
+	for _, roots := range keepRoots {
+		err = s.addRootsToBloom(roots)
+		if err != nil {
+			return err
+		}
+	}
+	s.keepHeight = keepHeight
+	return nil
+}
+
+func (s *ChainPruner) Prune(byHash bool) error {
+	if config.Config().ArchiveMode {
+		return fmt.Errorf("Chain %v is in archive mode, state pruning is disabled", s.chainID)
+	}
+	s.lock.Lock()
+	if s.status != IDLE {
+		s.lock.Unlock()
+		return fmt.Errorf("Chain %v is not ready! State: %v", s.chainID, s.status)
+	}
+	s.status = INIT
+
+	s.lastTriggerTime = time.Now()
+
+	err := s.InitBloomState()
+	if err != nil {
+		s.lastError = errors.Wrap(err, "init bloom state fail").Error()
+		s.stateBloom = nil
+		s.status = IDLE
+		s.lock.Unlock()
+		return err
+	}
+	s.status = PRUNING
+	s.lock.Unlock()
+
+	if byHash {
+		s.lastProcessingMode = "hash"
+		Logger.log.Infof("[state-prune %v] Start prune by hash", s.chainID)
+		s.pruneByHash()
+	} else {
+		s.lastProcessingMode = "height"
+		Logger.log.Infof("[state-prune %v] Start prune by height", s.chainID)
+		s.PruneByHeight()
+	}
+	s.saveStatus()
+	s.status = CHECKING
+	s.stateBloom = nil
+	s.CheckDataIntegrity()
+	s.status = IDLE
+	return nil
+}
+
+func (s *ChainPruner) PruneByHeight() error {
+	//prune height
+	if s.keepHeight <= 1 {
+		s.lastError = ""
+		return nil
+	}
+	var lastRoots stateRoots
+	for height := s.lastProcessingHeight; height < s.keepHeight; height++ {
+		if s.status != PRUNING {
+			return nil
+		}
+		err := func() error {
+			s.LockInsertBlock() //lock insert block
+			defer s.UnlockInsertBlock()
+			s.wg.Wait() //wait for all insert bloom task (in case we have new view)
+
+			//recheck if there is error when handle new view
+			if s.status != PRUNING {
+				return nil
+			}
+
+			roots, err := s.states.rootsAtHeight(height)
+			if err != nil {
+				return err
+			}
+			err = s.pruneRoots(roots, lastRoots)
+			if err != nil {
+				return err
+			}
+			lastRoots = roots
+
+			if height%1000 == 0 {
+				Logger.log.Infof("[state-prune %v] Finish prune for height %v delete totalNodes %v with storage %v", s.chainID, height, s.nodes, s.storage)
+				s.saveStatus()
+			}
+			s.lastProcessingHeight = height
+			return nil
+		}()
+
+		if err != nil {
+			s.lastError = errors.Wrap(err, "prune by height fail").Error()
+		} else {
+			s.lastError = ""
+		}
+	}
+	return nil
+}
+
+func (s *ChainPruner) pruneByHash() error {
+	iter := s.db.NewIteratorWithPrefixStart(s.states.rootsPrefix(), nil)
+	defer func() {
+		iter.Release()
+	}()
+	count := 0
+
+	// retrieve all state tree by root hash prefix
+	// delete all nodes which are not in state bloom
+	for iter.Next() {
+		if s.status != PRUNING {
+			return nil
+		}
+
+		err := func() error {
+			s.LockInsertBlock() //lock insert block
+			defer s.UnlockInsertBlock()
+			s.wg.Wait() //wait for all handle new view task (in case we have new view)
+
+			//recheck if there is error when handle new view
+			if s.status != PRUNING {
+				return nil
+			}
+
+			key := iter.Key()
+			roots, err := s.states.decodeRoots(iter.Value())
+			if err != nil {
+				return err
+			}
+			err = s.pruneRoots(roots, nil)
+			if err != nil {
+				return err
+			}
+
+			if count%1000 == 0 {
+				Logger.log.Infof("[state-prune %v] Finish prune for key %v totalKeys %v delete totalNodes %v with storage %v", s.chainID, key, count, s.nodes, s.storage)
+				s.saveStatus()
+			}
+			count++
+			return nil
+		}()
+
+		if err != nil {
+			s.lastError = err.Error()
+			return err
+		} else {
+			s.lastError = ""
+		}
+
+	}
+	return nil
+}
+
+// pruneRoots removes the nodes of every state trie in roots which are not in the
+// state bloom. Tries whose root did not change since prevRoots were already pruned.
+func (s *ChainPruner) pruneRoots(roots stateRoots, prevRoots stateRoots) error {
+	for _, dbType := range stateDBTypes {
+		root, ok := roots[dbType]
+		if !ok {
+			continue
+		}
+		if prevRoot, ok := prevRoots[dbType]; ok && prevRoot == root {
+			continue
+		}
+		storage, node, err := pruneStateDB(s.db, s.stateBloom, root)
+		s.storage += storage
+		s.nodes += node
+		s.stateDBs[dbType].TotalStoragePrune += storage
+		s.stateDBs[dbType].TotalNodePrune += node
+		s.stateDBs[dbType].LastRootHash = root.String()
+		if err != nil {
+			return errors.Wrapf(err, "prune %v state db", dbType)
+		}
+	}
+	return nil
+}
+
+func (s *ChainPruner) addRootsToBloom(roots stateRoots) error {
+	var dbAccessWarper = statedb.NewDatabaseAccessWarper(s.db)
+	for _, dbType := range stateDBTypes {
+		root, ok := roots[dbType]
+		if !ok {
+			continue
+		}
+		stateDB, err := statedb.NewWithPrefixTrie(root, dbAccessWarper)
+		if err != nil {
+			return err
+		}
+		//Retrieve all state tree for this state
+		if s.stateBloom == nil {
+			s.stateBloom, _ = trie.NewStateBloomWithSize(s.bloomSize)
+			_, err = stateDB.Retrieve(true, false, s.stateBloom, true)
+		} else {
+			_, err = stateDB.Retrieve(true, false, s.stateBloom, false)
+		}
+		if err != nil {
+			return errors.Wrapf(err, "retrieve %v state db %v", dbType, root.String())
+		}
+	}
+	return nil
+}
+
+// recheck walks every state trie of the best view and reports the first missing node.
+func (s *ChainPruner) recheck() error {
+	if s.bestView == nil {
+		return nil
+	}
+	for _, dbType := range stateDBTypes {
+		root, ok := s.bestView.roots[dbType]
+		if !ok {
+			continue
+		}
+		stateDB, err := statedb.NewWithPrefixTrie(root, statedb.NewDatabaseAccessWarper(s.db))
+		if err != nil {
+			return errors.Wrapf(err, "init %v state db", dbType)
+		}
+		if err := stateDB.Recheck(); err != nil {
+			return errors.Wrapf(err, "recheck %v state db %v at height %v", dbType, root.String(), s.bestView.view.GetHeight())
+		}
+	}
+	return nil
+}
+
+func (s *ChainPruner) CheckDataIntegrity() {
+	if err := s.recheck(); err != nil {
+		Logger.log.Infof("[state-prune %v] Chain %v Prune data error! %v", s.chainID, s.chainID, err)
+		panic(fmt.Sprintf("Prune data error! Chain %v Database corrupt!", s.chainID))
+	}
+}
+
+// isPruned reports whether state trie nodes of this chain were ever removed.
+func (s *ChainPruner) isPruned() bool {
+	return s.nodes > 0 || s.lastProcessingHeight > 1
+}
+
+func (s *ChainPruner) LockInsertBlock() {
+	if s.insertLock != nil {
+		s.insertLock.Lock()
+	}
+}
+
+func (s *ChainPruner) UnlockInsertBlock() {
+	if s.insertLock != nil {
+		s.insertLock.Unlock()
+	}
+}
+
+func (s *ChainPruner) handleNewView(view multiview.View, roots stateRoots) {
+	s.wg.Add(1)
+	s.bestView = &chainView{view: view, roots: roots}
+	go func() {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+		defer s.wg.Done()
+		if s.status == PRUNING {
+			err := s.addRootsToBloom(roots)
+			if err != nil {
+				s.lastError = errors.Wrap(err, "handle new view fail").Error()
+				s.status = IDLE
+				return
+			}
+		}
+	}()
+}
@@ -0,0 +1,213 @@
+package pruner
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/blockchain/types"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/config"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/incdb"
+	"github.com/incognitochain/incognito-chain/incdb/lvdb"
+	"github.com/incognitochain/incognito-chain/trie"
+	"github.com/stretchr/testify/assert"
+)
+
+const testHeights = 6
+
+var _ = func() (_ struct{}) {
+	Logger.Init(common.NewBackend(nil).Logger("test", true))
+	trie.Logger.Init(common.NewBackend(nil).Logger("test", true))
+	config.AbortConfig()
+	return
+}()
+
+func newTestDB(t *testing.T) incdb.Database {
+	db, err := lvdb.OpenMemory()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+// storeTestRoots grows one trie per state db type by a token every block and
+// returns the roots of each block, indexed by height.
+func storeTestRoots(t *testing.T, db incdb.Database, dbTypes []string) []stateRoots {
+	stateDBs := make(map[string]*statedb.StateDB)
+	for _, dbType := range dbTypes {
+		sDB, err := statedb.NewWithPrefixTrie(common.EmptyRoot, statedb.NewDatabaseAccessWarper(db))
+		if err != nil {
+			t.Fatal(err)
+		}
+		stateDBs[dbType] = sDB
+	}
+	res := make([]stateRoots, testHeights+1)
+	for height := uint64(1); height <= testHeights; height++ {
+		res[height] = stateRoots{}
+		for _, dbType := range dbTypes {
+			sDB := stateDBs[dbType]
+			tokenID := common.HashH([]byte(fmt.Sprintf("%v-%v", dbType, height)))
+			err := statedb.StorePrivacyToken(sDB, tokenID, dbType, dbType, statedb.InitToken, false, height, []byte{}, common.Hash{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			root, err := sDB.Commit(true)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := sDB.Database().TrieDB().Commit(root, false); err != nil {
+				t.Fatal(err)
+			}
+			res[height][dbType] = root
+		}
+	}
+	return res
+}
+
+func testBlockHash(chainID int, height uint64) common.Hash {
+	return common.HashH([]byte(fmt.Sprintf("%v-%v", chainID, height)))
+}
+
+func recheckRoots(db incdb.Database, roots stateRoots) error {
+	for dbType, root := range roots {
+		sDB, err := statedb.NewWithPrefixTrie(root, statedb.NewDatabaseAccessWarper(db))
+		if err != nil {
+			return err
+		}
+		if err := sDB.Recheck(); err != nil {
+			return fmt.Errorf("%v: %v", dbType, err)
+		}
+	}
+	return nil
+}
+
+func testShardView(height uint64, roots stateRoots) *blockchain.ShardBestState {
+	return &blockchain.ShardBestState{
+		BestBlock: &types.ShardBlock{
+			// passes the sanity check of a decoded block above height 1
+			ValidationData: "{}",
+			Header: types.ShardHeader{
+				Height:            height,
+				PreviousBlockHash: testBlockHash(0, height-1),
+				CommitteeRoot:     testBlockHash(0, height-1),
+			},
+		},
+		ShardHeight:                height,
+		ConsensusStateDBRootHash:   roots[ConsensusStateDB],
+		TransactionStateDBRootHash: roots[TransactionStateDB],
+		FeatureStateDBRootHash:     roots[FeatureStateDB],
+		RewardStateDBRootHash:      roots[RewardStateDB],
+		SlashStateDBRootHash:       roots[SlashStateDB],
+	}
+}
+
+// newTestShardChain stores a shard chain finalized at testHeights-1 whose best
+// view is at testHeights, and a beacon view which has confirmed it up to
+// beaconShardHeight.
+func newTestShardChain(t *testing.T, beaconShardHeight uint64) (incdb.Database, incdb.Database, []stateRoots) {
+	db, beaconDB := newTestDB(t), newTestDB(t)
+	roots := storeTestRoots(t, db, stateDBTypes)
+	for height := uint64(1); height <= testHeights; height++ {
+		hash := testBlockHash(0, height)
+		assert.Nil(t, rawdbv2.StoreShardRootsHash(db, 0, hash, &blockchain.ShardRootHash{
+			ConsensusStateDBRootHash:   roots[height][ConsensusStateDB],
+			TransactionStateDBRootHash: roots[height][TransactionStateDB],
+			FeatureStateDBRootHash:     roots[height][FeatureStateDB],
+			RewardStateDBRootHash:      roots[height][RewardStateDB],
+			SlashStateDBRootHash:       roots[height][SlashStateDB],
+		}))
+		assert.Nil(t, rawdbv2.StoreFinalizedShardBlockHashByIndex(db, 0, height, hash))
+	}
+	views := []*blockchain.ShardBestState{
+		testShardView(testHeights-1, roots[testHeights-1]),
+		testShardView(testHeights, roots[testHeights]),
+	}
+	assert.Nil(t, rawdbv2.StoreShardBestState(db, 0, views))
+	beaconViews := []*blockchain.BeaconBestState{{
+		BestBlock:       types.BeaconBlock{Header: types.BeaconHeader{Height: 1}},
+		BestShardHeight: map[byte]uint64{0: beaconShardHeight},
+	}}
+	data, err := json.Marshal(beaconViews)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Nil(t, rawdbv2.StoreBeaconViews(beaconDB, data))
+	return db, beaconDB, roots
+}
+
+func TestChainPrunerPrune(t *testing.T) {
+	for _, byHash := range []bool{false, true} {
+		t.Run(fmt.Sprintf("byHash=%v", byHash), func(t *testing.T) {
+			db, beaconDB, roots := newTestShardChain(t, 3)
+			p := NewShardPruner(0, db, beaconDB)
+			p.SetBloomSize(1)
+			assert.Nil(t, p.Prune(byHash))
+
+			// blocks the beacon has not confirmed and the views are kept
+			for height := uint64(3); height <= testHeights; height++ {
+				assert.Nil(t, recheckRoots(db, roots[height]), "height %v", height)
+			}
+			for height := uint64(1); height < 3; height++ {
+				assert.NotNil(t, recheckRoots(db, roots[height]), "height %v", height)
+			}
+
+			report := p.Report()
+			assert.Equal(t, "IDLE", report.Status)
+			assert.Empty(t, report.Error)
+			assert.True(t, report.TotalNodePrune > 0)
+			for _, dbType := range stateDBTypes {
+				assert.True(t, report.StateDBs[dbType].TotalNodePrune > 0, dbType)
+			}
+			if !byHash {
+				assert.Equal(t, uint64(2), report.LastProcessingHeight)
+			}
+		})
+	}
+}
+
+func TestChainPrunerArchiveMode(t *testing.T) {
+	db, beaconDB, roots := newTestShardChain(t, 3)
+	config.Config().ArchiveMode = true
+	defer func() { config.Config().ArchiveMode = false }()
+
+	p := NewShardPruner(0, db, beaconDB)
+	p.SetBloomSize(1)
+	assert.NotNil(t, p.Prune(false))
+	for height := uint64(1); height <= testHeights; height++ {
+		assert.Nil(t, recheckRoots(db, roots[height]), "height %v", height)
+	}
+}
+
+func TestChainPrunerResume(t *testing.T) {
+	db, beaconDB, roots := newTestShardChain(t, 3)
+	p := NewShardPruner(0, db, beaconDB)
+	p.SetBloomSize(1)
+	assert.Nil(t, p.Prune(false))
+
+	// a new pruner restores the progress and the beacon has confirmed more blocks
+	beaconViews := []*blockchain.BeaconBestState{{
+		BestBlock:       types.BeaconBlock{Header: types.BeaconHeader{Height: 1}},
+		BestShardHeight: map[byte]uint64{0: testHeights - 1},
+	}}
+	data, err := json.Marshal(beaconViews)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Nil(t, rawdbv2.StoreBeaconViews(beaconDB, data))
+	p = NewShardPruner(0, db, beaconDB)
+	assert.Equal(t, uint64(2), p.Report().LastProcessingHeight)
+	p.SetBloomSize(1)
+	assert.Nil(t, p.Prune(false))
+
+	for height := uint64(testHeights - 1); height <= testHeights; height++ {
+		assert.Nil(t, recheckRoots(db, roots[height]), "height %v", height)
+	}
+	for height := uint64(1); height < testHeights-1; height++ {
+		assert.NotNil(t, recheckRoots(db, roots[height]), "height %v", height)
+	}
+	assert.Equal(t, uint64(testHeights-2), p.Report().LastProcessingHeight)
+}
@@ -23,3 +23,15 @@ const (
 	PRUNING  = 2
 	CHECKING = 3
 )
+
+// State db types pruned by a ChainPruner. Shard chains have all of them, the
+// beacon chain has every type but the transaction state db.
+const (
+	ConsensusStateDB   = "consensus"
+	TransactionStateDB = "transaction"
+	FeatureStateDB     = "feature"
+	RewardStateDB      = "reward"
+	SlashStateDB       = "slash"
+)
+
+var stateDBTypes = []string{ConsensusStateDB, TransactionStateDB, FeatureStateDB, RewardStateDB, SlashStateDB}
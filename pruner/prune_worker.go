@@ -1,31 +1,14 @@
 package pruner
 
 import (
-	"encoding/json"
-	"github.com/incognitochain/incognito-chain/blockchain"
 	"github.com/incognitochain/incognito-chain/common"
-	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
 	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
 	"github.com/incognitochain/incognito-chain/incdb"
 	"github.com/incognitochain/incognito-chain/trie"
 )
 
-func pruneByHeight(db incdb.Database, shardID int, stateBloom *trie.StateBloom, height uint64) (uint64, uint64, error) {
-	h, err := rawdbv2.GetFinalizedShardBlockHashByIndex(db, byte(shardID), height)
-	if err != nil {
-		return 0, 0, err
-	}
-	data, err := rawdbv2.GetShardRootsHash(db, byte(shardID), *h)
-	sRH := &blockchain.ShardRootHash{}
-	if err = json.Unmarshal(data, sRH); err != nil {
-		return 0, 0, err
-	}
-	return pruneTxStateDB(db, stateBloom, sRH)
-
-}
-
-func pruneTxStateDB(db incdb.Database, stateBloom *trie.StateBloom, sRH *blockchain.ShardRootHash) (uint64, uint64, error) {
-	sDB, err := statedb.NewWithPrefixTrie(sRH.TransactionStateDBRootHash, statedb.NewDatabaseAccessWarper(db))
+func pruneStateDB(db incdb.Database, stateBloom *trie.StateBloom, root common.Hash) (uint64, uint64, error) {
+	sDB, err := statedb.NewWithPrefixTrie(root, statedb.NewDatabaseAccessWarper(db))
 	if err != nil {
 		return 0, 0, nil
 	}
@@ -20,44 +20,52 @@ type Config struct {
 }
 
 type PrunerManager struct {
-	ShardPruner map[int]*ShardPruner
+	ChainPruner map[int]*ChainPruner // key is the chain ID, common.BeaconChainID for the beacon
 	JobRquest   map[int]*Config
 }
 
 func NewPrunerManager(db map[int]incdb.Database) *PrunerManager {
 	prunerManager := &PrunerManager{
-		ShardPruner: make(map[int]*ShardPruner),
+		ChainPruner: make(map[int]*ChainPruner),
 		JobRquest:   make(map[int]*Config),
 	}
+	shardDBs := make(map[byte]incdb.Database)
 	for sid := 0; sid < common.MaxShardNumber; sid++ {
-		prunerManager.ShardPruner[sid] = NewShardPruner(sid, db[sid])
+		prunerManager.ChainPruner[sid] = NewShardPruner(sid, db[sid], db[common.BeaconChainID])
+		shardDBs[byte(sid)] = db[sid]
 	}
+	prunerManager.ChainPruner[common.BeaconChainID] = NewBeaconPruner(db[common.BeaconChainID], shardDBs)
 
 	return prunerManager
 }
 
 func (s *PrunerManager) Start() error {
 	for {
-		for sid, shardPruner := range s.ShardPruner {
-			//if shard pruner not run -> then check condition to trigger prune
-			if shardPruner.status == IDLE {
+		for cid, chainPruner := range s.ChainPruner {
+			//if chain pruner not run -> then check condition to trigger prune
+			if chainPruner.status == IDLE {
 				latest := false
-				if shardPruner.bestView != nil && shardPruner.bestView.CalculateTimeSlot(shardPruner.bestView.BestBlock.GetProposeTime()) == shardPruner.bestView.CalculateTimeSlot(time.Now().Unix()) {
-					latest = true
+				var bestHeight uint64
+				if chainPruner.bestView != nil {
+					bestView := chainPruner.bestView.view
+					bestHeight = bestView.GetHeight()
+					if bestView.CalculateTimeSlot(bestView.GetBlock().GetProposeTime()) == bestView.CalculateTimeSlot(time.Now().Unix()) {
+						latest = true
+					}
 				}
 				//if auto prune, sync latest block, and bestview block > last processing block
-				if config.Config().EnableAutoPrune && latest && shardPruner.bestView.ShardHeight > shardPruner.lastProcessingHeight+config.Config().NumBlockTriggerPrune {
-					shardPruner.SetBloomSize(config.Config().StateBloomSize)
-					shardPruner.Prune(false)
-				} else if req, ok := s.JobRquest[sid]; ok { //request for this shard from RPC
-					shardPruner.SetBloomSize(config.Config().StateBloomSize)
+				if config.Config().EnableAutoPrune && latest && bestHeight > chainPruner.lastProcessingHeight+config.Config().NumBlockTriggerPrune {
+					chainPruner.SetBloomSize(config.Config().StateBloomSize)
+					chainPruner.Prune(false)
+				} else if req, ok := s.JobRquest[cid]; ok { //request for this chain from RPC
+					chainPruner.SetBloomSize(config.Config().StateBloomSize)
 					if req.ShouldPruneByHash {
-						shardPruner.Prune(true)
+						chainPruner.Prune(true)
 					} else {
-						shardPruner.Prune(false)
+						chainPruner.Prune(false)
 					}
-					delete(s.JobRquest, sid)
-					//s.JobRquest[sid] = nil //unmark request for this shard
+					delete(s.JobRquest, cid)
+					//s.JobRquest[cid] = nil //unmark request for this chain
 				}
 			}
 		}
@@ -83,23 +91,23 @@ func (s *PrunerManager) OfflinePrune() {
 	go func() {
 		for {
 			select {
-			case shardID := <-ch:
+			case chainID := <-ch:
 				wg.Add(1)
 				go func() {
-					if _, ok := s.ShardPruner[shardID]; !ok {
-						fmt.Println("ShardPrunter is not ready")
+					if _, ok := s.ChainPruner[chainID]; !ok {
+						fmt.Println("ChainPruner is not ready")
 					}
-					s.ShardPruner[shardID].SetBloomSize(stateBloomSize)
-					s.ShardPruner[shardID].Prune(false)
+					s.ChainPruner[chainID].SetBloomSize(stateBloomSize)
+					s.ChainPruner[chainID].Prune(false)
 					wg.Done()
 					sem.Release(1)
-					Logger.log.Infof("Shard %v finish prune", shardID)
-					b, _ := json.MarshalIndent(s.ShardPruner[shardID].Report(), "", "\t")
+					Logger.log.Infof("Chain %v finish prune", chainID)
+					b, _ := json.MarshalIndent(s.ChainPruner[chainID].Report(), "", "\t")
 					fmt.Println(string(b))
 				}()
 			case <-stopCh:
 				count++
-				if count == common.MaxShardNumber {
+				if count == common.MaxShardNumber+1 {
 					return
 				}
 			}
@@ -109,22 +117,43 @@ func (s *PrunerManager) OfflinePrune() {
 		sem.Acquire(context.Background(), 1)
 		ch <- i
 	}
+	sem.Acquire(context.Background(), 1)
+	ch <- common.BeaconChainID
 	wg.Wait()
 }
 
+// VerifyArchive returns an error if a chain database was pruned before, an
+// archive node can only be run on a database that still holds every state root.
+func (s *PrunerManager) VerifyArchive() error {
+	for cid, chainPruner := range s.ChainPruner {
+		if chainPruner.isPruned() {
+			return fmt.Errorf("Chain %v database was pruned, it can not be used in archive mode", cid)
+		}
+	}
+	return nil
+}
+
 func (p *PrunerManager) SetShardInsertLock(sid int, mutex *sync.Mutex) {
-	p.ShardPruner[sid].shardInsertLock = mutex
+	p.ChainPruner[sid].insertLock = mutex
+}
+
+func (p *PrunerManager) SetBeaconInsertLock(mutex *sync.Mutex) {
+	p.ChainPruner[common.BeaconChainID].insertLock = mutex
 }
 
 func (p *PrunerManager) InsertNewView(shardBestState *blockchain.ShardBestState) {
 	sid := shardBestState.ShardID
-	p.ShardPruner[int(sid)].handleNewView(shardBestState)
+	p.ChainPruner[int(sid)].handleNewView(shardBestState, shardViewRoots(shardBestState))
 }
 
-func (s *PrunerManager) Report() map[int]ShardPrunerReport {
-	res := map[int]ShardPrunerReport{}
-	for sid := 0; sid < common.MaxShardNumber; sid++ {
-		res[sid] = s.ShardPruner[sid].Report()
+func (p *PrunerManager) InsertNewBeaconView(beaconBestState *blockchain.BeaconBestState) {
+	p.ChainPruner[common.BeaconChainID].handleNewView(beaconBestState, beaconViewRoots(beaconBestState))
+}
+
+func (s *PrunerManager) Report() map[int]ChainPrunerReport {
+	res := map[int]ChainPrunerReport{}
+	for cid, chainPruner := range s.ChainPruner {
+		res[cid] = chainPruner.Report()
 	}
 	return res
 }
@@ -6,7 +6,7 @@ import (
 	"time"
 )
 
-type ShardPrunerReport struct {
+type ChainPrunerReport struct {
 	ChainID              int
 	LastTriggerTime      time.Time
 	BloomSize            uint64
@@ -16,10 +16,18 @@ type ShardPrunerReport struct {
 	LastProcessingMode   string
 	TotalNodePrune       uint64
 	TotalStoragePrune    uint64
+	StateDBs             map[string]StateDBReport
 }
 
-func (s *ShardPruner) Report() ShardPrunerReport {
-	res := ShardPrunerReport{}
+// StateDBReport is the pruning progress of one state db type of a chain.
+type StateDBReport struct {
+	LastRootHash      string
+	TotalNodePrune    uint64
+	TotalStoragePrune uint64
+}
+
+func (s *ChainPruner) Report() ChainPrunerReport {
+	res := ChainPrunerReport{}
 	res.LastTriggerTime = s.lastTriggerTime
 	switch s.status {
 	case IDLE:
@@ -31,27 +39,31 @@ func (s *ShardPruner) Report() ShardPrunerReport {
 	case CHECKING:
 		res.Status = "CHECKING"
 	}
-	res.ChainID = s.shardID
+	res.ChainID = s.chainID
 	res.Error = s.lastError
 	res.LastProcessingHeight = s.lastProcessingHeight
 	res.LastProcessingMode = s.lastProcessingMode
 	res.TotalNodePrune = s.nodes
 	res.TotalStoragePrune = s.storage
 	res.BloomSize = s.bloomSize
+	res.StateDBs = make(map[string]StateDBReport)
+	for dbType, r := range s.stateDBs {
+		res.StateDBs[dbType] = *r
+	}
 	return res
 }
 
-func (s *ShardPruner) saveStatus() {
+func (s *ChainPruner) saveStatus() {
 	b, _ := json.Marshal(s.Report())
 	rawdbv2.StorePruneStatus(s.db, b)
 }
 
-func (s *ShardPruner) restoreStatus() {
+func (s *ChainPruner) restoreStatus() {
 	b, err := rawdbv2.GetPruneStatus(s.db)
 	if err != nil {
 		return
 	}
-	report := &ShardPrunerReport{}
+	report := &ChainPrunerReport{}
 	err = json.Unmarshal(b, report)
 	if err != nil {
 		return
@@ -62,4 +74,9 @@ func (s *ShardPruner) restoreStatus() {
 	s.lastProcessingHeight = report.LastProcessingHeight
 	s.storage = report.TotalStoragePrune
 	s.nodes = report.TotalNodePrune
+	for dbType, r := range report.StateDBs {
+		if _, ok := s.stateDBs[dbType]; ok {
+			*s.stateDBs[dbType] = r
+		}
+	}
 }
@@ -2,292 +2,112 @@ package pruner
 
 import (
 	"encoding/json"
-	"fmt"
-	"sync"
-	"time"
 
 	"github.com/incognitochain/incognito-chain/blockchain"
 	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
-	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
 	"github.com/incognitochain/incognito-chain/incdb"
-	"github.com/incognitochain/incognito-chain/trie"
-	"github.com/pkg/errors"
 )
 
-type ShardPruner struct {
-	//state
-	shardID     int
-	db          incdb.Database
-	stateBloom  *trie.StateBloom
-	bloomSize   uint64
-	role        string
-	finalHeight uint64
-	bestView    *blockchain.ShardBestState
-
-	//lock
-	lock            sync.Mutex
-	wg              sync.WaitGroup
-	shardInsertLock *sync.Mutex
-
-	//report
-	lastTriggerTime      time.Time
-	lastProcessingMode   string
-	status               int
-	lastError            string
-	lastProcessingHeight uint64
-	storage              uint64
-	nodes                uint64
+// shardStates reads the state roots of one shard chain.
+type shardStates struct {
+	shardID  byte
+	db       incdb.Database
+	beaconDB incdb.Database
 }
 
-func NewShardPruner(sid int, db incdb.Database) *ShardPruner {
-	//init object
-	sp := &ShardPruner{
-		shardID: sid,
-		db:      db,
-	}
-	sp.restoreStatus()
-	if sp.lastProcessingHeight == 0 {
-		sp.lastProcessingHeight = 1
-	}
-	return sp
+func NewShardPruner(sid int, db incdb.Database, beaconDB incdb.Database) *ChainPruner {
+	return newChainPruner(sid, db, &shardStates{
+		shardID:  byte(sid),
+		db:       db,
+		beaconDB: beaconDB,
+	})
 }
 
-func (s *ShardPruner) SetBloomSize(size uint64) {
-	s.bloomSize = size
+func shardViewRoots(v *blockchain.ShardBestState) stateRoots {
+	return shardRoots(&blockchain.ShardRootHash{
+		ConsensusStateDBRootHash:   v.ConsensusStateDBRootHash,
+		TransactionStateDBRootHash: v.TransactionStateDBRootHash,
+		FeatureStateDBRootHash:     v.FeatureStateDBRootHash,
+		RewardStateDBRootHash:      v.RewardStateDBRootHash,
+		SlashStateDBRootHash:       v.SlashStateDBRootHash,
+	})
 }
 
-func (s *ShardPruner) Stop() {
-	s.status = IDLE
+func shardRoots(sRH *blockchain.ShardRootHash) stateRoots {
+	return stateRoots{
+		ConsensusStateDB:   sRH.ConsensusStateDBRootHash,
+		TransactionStateDB: sRH.TransactionStateDBRootHash,
+		FeatureStateDB:     sRH.FeatureStateDBRootHash,
+		RewardStateDB:      sRH.RewardStateDBRootHash,
+		SlashStateDB:       sRH.SlashStateDBRootHash,
+	}
 }
 
-func (s *ShardPruner) InitBloomState() error {
-	//restore best views and final view from database
+func (s *shardStates) loadViews() ([]chainView, error) {
 	allViews := []*blockchain.ShardBestState{}
-	views, err := rawdbv2.GetShardBestState(s.db, byte(s.shardID))
+	views, err := rawdbv2.GetShardBestState(s.db, s.shardID)
 	if err != nil {
-		Logger.log.Errorf("debug Cannot see shard best state %v", err)
-		return err
+		return nil, err
 	}
 	err = json.Unmarshal(views, &allViews)
 	if err != nil {
-		Logger.log.Errorf("debug Cannot unmarshall shard best state", string(views))
-		return err
-	}
-	//collect tree nodes want to keep, add them to state bloom
-	if len(allViews) > 0 {
-		s.finalHeight = allViews[0].ShardHeight
-		s.bestView = allViews[len(allViews)-1]
-	} else {
-		return errors.New("Cannot retrieve all shard views")
+		return nil, err
 	}
+	res := []chainView{}
 	for _, v := range allViews {
-		err = s.addViewToBloom(v)
-		if err != nil {
-			return err
-		}
+		res = append(res, chainView{view: v, roots: shardViewRoots(v)})
 	}
-
-	return nil
+	return res, nil
 }
 
-func (s *ShardPruner) Prune(byHash bool) error {
-	s.lock.Lock()
-	if s.status != IDLE {
-		s.lock.Unlock()
-		return fmt.Errorf("Shard %v is not ready! State: %v", s.shardID, s.status)
+// keepFromHeight keeps the shard blocks the beacon has not confirmed yet: the
+// beacon reads the transaction state of its best shard blocks.
+func (s *shardStates) keepFromHeight(finalHeight uint64) (uint64, []stateRoots, error) {
+	if s.beaconDB == nil {
+		return finalHeight, nil, nil
 	}
-	s.status = INIT
-
-	s.lastTriggerTime = time.Now()
-
-	err := s.InitBloomState()
+	data, err := rawdbv2.GetBeaconViews(s.beaconDB)
 	if err != nil {
-		s.lastError = errors.Wrap(err, "init bloom state fail").Error()
-		return err
+		// no beacon view to protect yet
+		return finalHeight, nil, nil
 	}
-	s.status = PRUNING
-	s.lock.Unlock()
-
-	if byHash {
-		s.lastProcessingMode = "hash"
-		Logger.log.Infof("[state-prune %v] Start prune by hash", s.shardID)
-		s.pruneByHash()
-	} else {
-		s.lastProcessingMode = "height"
-		Logger.log.Infof("[state-prune %v] Start prune by height", s.shardID)
-		s.PruneByHeight()
-	}
-	s.saveStatus()
-	s.status = CHECKING
-	s.stateBloom = nil
-	s.CheckDataIntegrity()
-	s.status = IDLE
-	return nil
-}
-
-func (s *ShardPruner) PruneByHeight() error {
-	//prune height
-	if s.finalHeight <= 1 {
-		s.lastError = ""
-		return nil
+	beaconViews := []*blockchain.BeaconBestState{}
+	err = json.Unmarshal(data, &beaconViews)
+	if err != nil {
+		return 0, nil, err
 	}
-	for height := s.lastProcessingHeight; height < s.finalHeight; height++ {
-		if s.status != PRUNING {
-			return nil
-		}
-		err := func() error {
-			s.LockInsertShardBlock() //lock insert shard block
-			defer s.UnlockInsertShardBlock()
-			s.wg.Wait() //wait for all insert bloom task (in case we have new view)
-
-			//recheck if there is error when handle new view
-			if s.status != PRUNING {
-				return nil
-			}
-
-			storage, node, err := pruneByHeight(s.db, s.shardID, s.stateBloom, height)
-			s.storage += storage
-			s.nodes += node
-			if err != nil {
-				return err
-			}
-
-			if height%1000 == 0 {
-				Logger.log.Infof("[state-prune %v] Finish prune for height %v delete totalNodes %v with storage %v", s.shardID, height, s.nodes, s.storage)
-				s.saveStatus()
-			}
-			s.lastProcessingHeight = height
-			return nil
-		}()
-
-		if err != nil {
-			s.lastError = errors.Wrap(err, "prune by height fail").Error()
-		} else {
-			s.lastError = ""
+	keepHeight := finalHeight
+	for _, v := range beaconViews {
+		if h, ok := v.BestShardHeight[s.shardID]; ok && h < keepHeight {
+			keepHeight = h
 		}
 	}
-	return nil
-}
-
-func (s *ShardPruner) pruneByHash() error {
-	iter := s.db.NewIteratorWithPrefixStart(rawdbv2.GetShardRootsHashPrefix(byte(s.shardID)), nil)
-	defer func() {
-		iter.Release()
-	}()
-	count := 0
-
-	// retrieve all state tree by shard rooth hash prefix
-	// delete all nodes which are not in state bloom
-	for iter.Next() {
-		if s.status != PRUNING {
-			return nil
-		}
-
-		err := func() error {
-			s.LockInsertShardBlock() //lock insert shard block
-			defer s.UnlockInsertShardBlock()
-			s.wg.Wait() //wait for all handle new view task (in case we have new view)
-
-			//recheck if there is error when handle new view
-			if s.status != PRUNING {
-				return nil
-			}
-
-			key := iter.Key()
-			rootHash := &blockchain.ShardRootHash{}
-			err := json.Unmarshal(iter.Value(), &rootHash)
-			if err != nil {
-				return err
-			}
-			storage, node, err := pruneTxStateDB(s.db, s.stateBloom, rootHash)
-			s.storage += storage
-			s.nodes += node
-			if err != nil {
-				return err
-			}
-
-			if count%1000 == 0 {
-				Logger.log.Infof("[state-prune %v] Finish prune for key %v totalKeys %v delete totalNodes %v with storage %v", s.shardID, key, count, s.nodes, s.storage)
-				s.saveStatus()
-			}
-			count++
-			return nil
-		}()
-
-		if err != nil {
-			s.lastError = err.Error()
-			return err
-		} else {
-			s.lastError = ""
-		}
-
+	if keepHeight == 0 {
+		keepHeight = 1
 	}
-	return nil
+	return keepHeight, nil, nil
 }
 
-func (s *ShardPruner) addViewToBloom(v *blockchain.ShardBestState) error {
-	Logger.log.Infof("[state-prune %v] Start retrieve view %s at height %v hash %v ",
-		v.ShardID, v.BestBlockHash.String(), v.ShardHeight, v.TransactionStateDBRootHash.String())
-	var dbAccessWarper = statedb.NewDatabaseAccessWarper(s.db)
-	stateDB, err := statedb.NewWithPrefixTrie(v.TransactionStateDBRootHash, dbAccessWarper)
+func (s *shardStates) rootsAtHeight(height uint64) (stateRoots, error) {
+	h, err := rawdbv2.GetFinalizedShardBlockHashByIndex(s.db, s.shardID, height)
 	if err != nil {
-		return err
-	}
-	//Retrieve all state tree for this state
-	if s.stateBloom == nil {
-		s.stateBloom, _ = trie.NewStateBloomWithSize(s.bloomSize)
-		_, err = stateDB.Retrieve(true, false, s.stateBloom, true)
-	} else {
-		_, err = stateDB.Retrieve(true, false, s.stateBloom, false)
+		return nil, err
 	}
-
-	if err != nil {
-		panic(err)
-		return err
-	}
-	Logger.log.Infof("[state-prune %v] Finish retrieve view %s at height %v",
-		v.ShardID, v.BestBlockHash.String(), v.ShardHeight)
-	return nil
-}
-
-func (s *ShardPruner) CheckDataIntegrity() {
-	txDB, err := statedb.NewWithPrefixTrie(s.bestView.TransactionStateDBRootHash, statedb.NewDatabaseAccessWarper(s.db))
+	data, err := rawdbv2.GetShardRootsHash(s.db, s.shardID, *h)
 	if err != nil {
-		panic(fmt.Sprintf("Something wrong when init txDB %v", s.shardID))
-	}
-	if err := txDB.Recheck(); err != nil {
-		fmt.Println("Recheck TransactionStateDBRootHash", s.bestView.ShardHeight, s.bestView.TransactionStateDBRootHash.String())
-		Logger.log.Infof("[state-prune %v] Shard %v Prune data error! %v", s.shardID, s.shardID, err)
-		panic(fmt.Sprintf("Prune data error! Shard %v Database corrupt!", s.shardID))
+		return nil, err
 	}
+	return s.decodeRoots(data)
 }
 
-func (s *ShardPruner) LockInsertShardBlock() {
-	if s.shardInsertLock != nil {
-		s.shardInsertLock.Lock()
-	}
+func (s *shardStates) rootsPrefix() []byte {
+	return rawdbv2.GetShardRootsHashPrefix(s.shardID)
 }
 
-func (s *ShardPruner) UnlockInsertShardBlock() {
-	if s.shardInsertLock != nil {
-		s.shardInsertLock.Unlock()
+func (s *shardStates) decodeRoots(data []byte) (stateRoots, error) {
+	sRH := &blockchain.ShardRootHash{}
+	if err := json.Unmarshal(data, sRH); err != nil {
+		return nil, err
 	}
-}
-
-func (s *ShardPruner) handleNewView(shardBestState *blockchain.ShardBestState) {
-	s.wg.Add(1)
-	s.bestView = shardBestState
-	go func() {
-		s.lock.Lock()
-		defer s.lock.Unlock()
-		defer s.wg.Done()
-		if s.status == PRUNING {
-			err := s.addViewToBloom(shardBestState)
-			if err != nil {
-				s.lastError = errors.Wrap(err, "handle new view fail").Error()
-				s.status = IDLE
-				return
-			}
-		}
-	}()
+	return shardRoots(sRH), nil
 }
@@ -13,6 +13,7 @@ const (
 	MempoolInfoTopic                = "mempoolinfotopic"
 	BeaconBeststateTopic            = "beaconbeststatetopic"
 	ShardBeststateTopic             = "shardbeststatetopic"
+	IncomingCoinTopic               = "incomingcointopic"
 	RequestShardBlockByHashTopic    = "requestshardblockbyhashtopic"
 	RequestShardBlockByHeightTopic  = "requestshardblockbyheighttopic"
 	RequestBeaconBlockByHeightTopic = "requestbeaconblockbyheighttopic"
@@ -35,6 +36,7 @@ var Topics = []string{
 	RequestShardBlockByHeightTopic,
 	RequestShardBlockByHashTopic,
 	ShardBeststateTopic,
+	IncomingCoinTopic,
 }
 
 type NodeRole struct {
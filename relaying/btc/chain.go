@@ -26,6 +26,11 @@ const (
 	maxOrphanBlocks = 0
 )
 
+// PowHashFunc computes the hash of a block header that is checked against the
+// target difficulty.  Bitcoin uses the block hash itself while some forks,
+// such as litecoin, use a different hash function for proof of work.
+type PowHashFunc func(header *wire.BlockHeader) chainhash.Hash
+
 // BlockLocator is used to help locate a specific block.  The algorithm for
 // building the block locator is to add the hashes in reverse order until
 // the genesis block is reached.  In order to keep the list of locator hashes
@@ -104,6 +109,8 @@ type BlockChain struct {
 	sigCache            *txscript.SigCache
 	indexManager        IndexManager
 	hashCache           *txscript.HashCache
+	powHash             PowHashFunc
+	fullRetargetWindow  bool
 
 	// The following fields are calculated based upon the provided chain
 	// parameters.  They are also set when the instance is created and
@@ -1845,6 +1852,18 @@ type Config struct {
 	// This field can be nil if the caller is not interested in using a
 	// signature cache.
 	HashCache *txscript.HashCache
+
+	// PowHash defines the hash function used to check the proof of work of
+	// block headers.
+	//
+	// This field can be nil to use the block hash as bitcoin does.
+	PowHash PowHashFunc
+
+	// FullRetargetWindow specifies whether the difficulty retarget measures
+	// the timespan over the full retarget interval instead of skipping its
+	// first block as bitcoin does.  Litecoin enables this to fix the time
+	// warp bug.
+	FullRetargetWindow bool
 }
 
 // New returns a BlockChain instance using the provided configuration details.
@@ -1898,6 +1917,8 @@ func New(config *Config, genesisBlkHeight int32) (*BlockChain, error) {
 		blocksPerRetarget:   int32(targetTimespan / targetTimePerBlock),
 		index:               newBlockIndex(config.DB, params),
 		hashCache:           config.HashCache,
+		powHash:             config.PowHash,
+		fullRetargetWindow:  config.FullRetargetWindow,
 		bestChain:           newChainView(nil),
 		orphans:             make(map[chainhash.Hash]*orphanBlock),
 		prevOrphans:         make(map[chainhash.Hash][]*orphanBlock),
@@ -2017,25 +2038,100 @@ func removeUnusedBackupDatabase(filePath string) error {
 	return nil
 }
 
+// RestoreDBFromBackup replaces the database with a backup made by BackupDB and reloads the chain state from it.
+// The backup is checked first with verifyMainChain, the database is kept unchanged if the backup is invalid.
 func (b *BlockChain) RestoreDBFromBackup(src string) error {
+	restorePath := b.dbPath + "_restore"
+	if err := os.RemoveAll(restorePath); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(restorePath, 0700); err != nil {
+		return err
+	}
+	defer os.RemoveAll(restorePath)
+	err := common.DecompressDatabaseBackup(src, restorePath)
+	if err != nil {
+		return err
+	}
+	if err := b.verifyBackupDB(restorePath); err != nil {
+		return err
+	}
+
+	b.chainLock.Lock()
+	defer b.chainLock.Unlock()
 	b.db.Close()
 	if err := os.RemoveAll(b.dbPath); err != nil {
-		panic(err)
+		return err
 	}
-	//Create new data
-	if err := os.MkdirAll(b.dbPath, 0700); err != nil {
-		panic(err)
+	if err := os.Rename(restorePath, b.dbPath); err != nil {
+		return err
+	}
+	db, err := database.Open(testDbType, b.dbPath, blockDataNet)
+	if err != nil {
+		return err
 	}
+	b.db = db
 
-	err := common.DecompressDatabaseBackup(src, b.dbPath)
+	// reload the chain state of the restored database
+	b.index = newBlockIndex(db, b.chainParams)
+	b.bestChain = newChainView(nil)
+	b.nextCheckpoint = nil
+	b.checkpointNode = nil
+	b.orphanLock.Lock()
+	b.orphans = make(map[chainhash.Hash]*orphanBlock)
+	b.prevOrphans = make(map[chainhash.Hash][]*orphanBlock)
+	b.oldestOrphan = nil
+	b.orphanLock.Unlock()
+	b.warningCaches = newThresholdCaches(vbNumBits)
+	b.deploymentCaches = newThresholdCaches(chaincfg.DefinedDeployments)
+	if err := b.initChainState(); err != nil {
+		return err
+	}
+	return b.initThresholdCaches()
+}
+
+// verifyBackupDB loads the chain stored in dbPath with the same parameters as b and checks it with verifyMainChain
+func (b *BlockChain) verifyBackupDB(dbPath string) error {
+	db, err := database.Open(testDbType, dbPath, blockDataNet)
 	if err != nil {
 		return err
 	}
-	db, err := database.Open(testDbType, b.dbPath, blockDataNet)
+	defer db.Close()
+	restored, err := New(&Config{
+		DB:                 db,
+		dbPath:             dbPath,
+		ChainParams:        b.chainParams,
+		Checkpoints:        b.checkpoints,
+		TimeSource:         b.timeSource,
+		PowHash:            b.powHash,
+		FullRetargetWindow: b.fullRetargetWindow,
+	}, b.genesisBlkHeight)
 	if err != nil {
 		return err
 	}
-	b.db = db
+	return restored.verifyMainChain()
+}
 
+// verifyMainChain checks the block headers of a chain loaded from a database this node did not build:
+// the main chain must start at the genesis block of the chain params,
+// and every following header must meet the proof of work, difficulty & timestamp rules given its parent
+func (b *BlockChain) verifyMainChain() error {
+	b.chainLock.Lock()
+	defer b.chainLock.Unlock()
+
+	node := b.bestChain.NodeByHeight(b.genesisBlkHeight)
+	if node == nil || node.hash != *b.chainParams.GenesisHash {
+		return AssertError("main chain does not start at the genesis block")
+	}
+	for node = b.bestChain.Next(node); node != nil; node = b.bestChain.Next(node) {
+		header := node.Header()
+		err := checkBlockHeaderSanity(&header, b.chainParams.PowLimit, b.powHash, b.timeSource, BFNone)
+		if err == nil {
+			err = b.checkBlockHeaderContext(&header, node.parent, BFNone)
+		}
+		if err != nil {
+			return fmt.Errorf("invalid block %v at height %v: %v", node.hash, node.height, err)
+		}
+	}
 	return nil
 }
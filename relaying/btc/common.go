@@ -186,6 +186,16 @@ func chainSetup(dbName string, params *chaincfg.Params) (*BlockChain, func(), er
 
 // GetChainV2 returns btcrelaying chain
 func GetChainV2(dbPath string, params *chaincfg.Params, genesisBlkHeight int32) (*BlockChain, error) {
+	return getChain(dbPath, params, genesisBlkHeight, nil, false)
+}
+
+// GetForkChain returns relaying chain of a bitcoin fork which checks proof of work
+// with powHash and optionally retargets difficulty over the full retarget window
+func GetForkChain(dbPath string, params *chaincfg.Params, genesisBlkHeight int32, powHash PowHashFunc, fullRetargetWindow bool) (*BlockChain, error) {
+	return getChain(dbPath, params, genesisBlkHeight, powHash, fullRetargetWindow)
+}
+
+func getChain(dbPath string, params *chaincfg.Params, genesisBlkHeight int32, powHash PowHashFunc, fullRetargetWindow bool) (*BlockChain, error) {
 	if !isSupportedDbType(testDbType) {
 		return nil, fmt.Errorf("unsupported db type %v", testDbType)
 	}
@@ -215,12 +225,14 @@ func GetChainV2(dbPath string, params *chaincfg.Params, genesisBlkHeight int32)
 
 	// Create the main chain instance.
 	chain, err := New(&Config{
-		DB:          db,
-		dbPath:      dbPath,
-		ChainParams: &paramsCopy,
-		Checkpoints: nil,
-		TimeSource:  NewMedianTime(),
-		SigCache:    txscript.NewSigCache(1000),
+		DB:                 db,
+		dbPath:             dbPath,
+		ChainParams:        &paramsCopy,
+		Checkpoints:        nil,
+		TimeSource:         NewMedianTime(),
+		SigCache:           txscript.NewSigCache(1000),
+		PowHash:            powHash,
+		FullRetargetWindow: fullRetargetWindow,
 	}, genesisBlkHeight)
 	if err != nil {
 		err := fmt.Errorf("failed to create chain instance: %v", err)
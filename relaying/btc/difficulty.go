@@ -257,9 +257,13 @@ func (b *BlockChain) calcNextRequiredDifficulty(lastNode *blockNode, header *wir
 
 	// Get the block node at the previous retarget (targetTimespan days
 	// worth of blocks).
-	firstNode := lastNode.RelativeAncestor(b.blocksPerRetarget - 1)
+	blocksToGoBack := b.blocksPerRetarget - 1
+	if b.fullRetargetWindow && lastNode.height+1 != b.blocksPerRetarget {
+		blocksToGoBack = b.blocksPerRetarget
+	}
+	firstNode := lastNode.RelativeAncestor(blocksToGoBack)
 	if firstNode == nil {
-		if b.genesisBlkHeight > lastNode.height+1-b.blocksPerRetarget {
+		if b.genesisBlkHeight > lastNode.height-blocksToGoBack {
 			return header.Bits, nil
 		}
 		return 0, AssertError("unable to obtain previous retarget block")
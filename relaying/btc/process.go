@@ -151,7 +151,7 @@ func (b *BlockChain) ProcessBlockV2(block *btcutil.Block, flags BehaviorFlags) (
 	// }
 
 	// Perform preliminary sanity checks on the block and its transactions.
-	err = checkBlockSanityV2(block, b.chainParams.PowLimit, b.timeSource, flags)
+	err = checkBlockSanityV2(block, b.chainParams.PowLimit, b.powHash, b.timeSource, flags)
 	if err != nil {
 		return false, false, err
 	}
@@ -0,0 +1,104 @@
+package btcrelaying
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+	"github.com/incognitochain/incognito-chain/common"
+)
+
+// mineTestBlocks adds numBlocks empty blocks to the tip of chain, with a valid proof of work unless invalidPoW is set
+func mineTestBlocks(t *testing.T, chain *BlockChain, numBlocks int, invalidPoW bool) {
+	params := chain.GetChainParams()
+	for i := 0; i < numBlocks; i++ {
+		tip := chain.BestSnapshot()
+		header := wire.BlockHeader{
+			Version:   4,
+			PrevBlock: tip.Hash,
+			Timestamp: time.Unix(tip.MedianTime.Unix(), 0).Add(time.Duration(i+1) * time.Minute),
+			Bits:      params.PowLimitBits,
+		}
+		for {
+			hash := header.BlockHash()
+			if (HashToBig(&hash).Cmp(CompactToBig(header.Bits)) > 0) == invalidPoW {
+				break
+			}
+			header.Nonce++
+		}
+		flags := BFNone
+		if invalidPoW {
+			flags = BFNoPoWCheck
+		}
+		if _, _, err := chain.ProcessBlockV2(btcutil.NewBlock(&wire.MsgBlock{Header: header}), flags); err != nil {
+			t.Fatalf("Cannot process block %v: %v", i, err)
+		}
+	}
+}
+
+func newTestRestoreChain(t *testing.T, dir, name string) *BlockChain {
+	chain, err := GetChainV2(filepath.Join(dir, name), &chaincfg.RegressionNetParams, 0)
+	if err != nil {
+		t.Fatalf("Cannot create chain %v: %v", name, err)
+	}
+	return chain
+}
+
+func backupTestChain(t *testing.T, chain *BlockChain, dir, name string) string {
+	backupFile := filepath.Join(dir, name)
+	chain.GetDB().Close()
+	if err := common.CompressDatabase(chain.dbPath, backupFile); err != nil {
+		t.Fatalf("Cannot back up chain: %v", err)
+	}
+	return backupFile
+}
+
+func TestRestoreDBFromBackup(t *testing.T) {
+	dir, err := ioutil.TempDir("", "btc_restore")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	source := newTestRestoreChain(t, dir, "source")
+	mineTestBlocks(t, source, 5, false)
+	validBackup := backupTestChain(t, source, dir, "valid")
+
+	tampered := newTestRestoreChain(t, dir, "tampered")
+	mineTestBlocks(t, tampered, 2, false)
+	mineTestBlocks(t, tampered, 6, true)
+	tamperedBackup := backupTestChain(t, tampered, dir, "tampered_backup")
+
+	chain := newTestRestoreChain(t, dir, "chain")
+	defer chain.GetDB().Close()
+	mineTestBlocks(t, chain, 1, false)
+	tip := chain.BestSnapshot().Hash
+
+	// a backup with a header failing its proof of work is rejected and the database is kept
+	if err := chain.RestoreDBFromBackup(tamperedBackup); err == nil {
+		t.Fatal("Expect the tampered backup to be rejected")
+	}
+	if chain.BestSnapshot().Hash != tip || chain.BestSnapshot().Height != 1 {
+		t.Fatalf("Expect the chain to be unchanged, got tip %v", chain.BestSnapshot().Height)
+	}
+	if _, err := os.Stat(chain.dbPath + "_restore"); !os.IsNotExist(err) {
+		t.Fatalf("Expect the restored files to be removed, got %v", err)
+	}
+
+	// a valid backup replaces the database and the chain state
+	if err := chain.RestoreDBFromBackup(validBackup); err != nil {
+		t.Fatalf("Cannot restore valid backup: %v", err)
+	}
+	if chain.BestSnapshot().Height != 5 {
+		t.Fatalf("Expect the restored tip at height 5, got %v", chain.BestSnapshot().Height)
+	}
+	mineTestBlocks(t, chain, 1, false)
+	if chain.BestSnapshot().Height != 6 {
+		t.Fatalf("Expect the restored chain to grow, got tip %v", chain.BestSnapshot().Height)
+	}
+}
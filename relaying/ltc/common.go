@@ -0,0 +1,39 @@
+package ltcrelaying
+
+import (
+	"bytes"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	btcrelaying "github.com/incognitochain/incognito-chain/relaying/btc"
+	"golang.org/x/crypto/scrypt"
+)
+
+// PowHash returns the scrypt(1024, 1, 1) hash of the serialized block header,
+// it is the hash litecoin compares against the target difficulty
+func PowHash(header *wire.BlockHeader) chainhash.Hash {
+	var buf bytes.Buffer
+	buf.Grow(wire.MaxBlockHeaderPayload)
+	_ = header.Serialize(&buf)
+	headerBytes := buf.Bytes()
+
+	var hash chainhash.Hash
+	powBytes, err := scrypt.Key(headerBytes, headerBytes, 1024, 1, 1, chainhash.HashSize)
+	if err != nil {
+		// parameters are constant and valid, so this never happens,
+		// return the highest hash in order to fail the proof of work check
+		for i := range hash {
+			hash[i] = 0xff
+		}
+		return hash
+	}
+	copy(hash[:], powBytes)
+	return hash
+}
+
+// GetChainV2 returns ltc relaying chain, it is a bitcoin relaying chain
+// checking proof of work with scrypt and retargeting over the full window
+func GetChainV2(dbPath string, params *chaincfg.Params, genesisBlkHeight int32) (*btcrelaying.BlockChain, error) {
+	return btcrelaying.GetForkChain(dbPath, params, genesisBlkHeight, PowHash, true)
+}
@@ -0,0 +1,128 @@
+package ltcrelaying
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/hex"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+	btcrelaying "github.com/incognitochain/incognito-chain/relaying/btc"
+	"github.com/stretchr/testify/assert"
+)
+
+// getRegTestParams returns params of litecoin regtest, headers of this network are cheap to mine
+// so the fixture headers in testdata are mined on it
+func getRegTestParams() *chaincfg.Params {
+	params := TestNet4Params
+	params.Name = "litecoin-regtest"
+	params.Net = wire.BitcoinNet(0xdab5bffa)
+	params.PowLimit = new(big.Int).Sub(new(big.Int).Lsh(bigOne, 255), bigOne)
+	params.PowLimitBits = 0x207fffff
+	params.ReduceMinDifficulty = false
+	genesisHash, _ := chainhash.NewHashFromStr("530827f38f93b43ed12af0b3ad25a288dc02ed74d6d7857862df51fc56c416f9")
+	genesisBlock := &wire.MsgBlock{
+		Header: wire.BlockHeader{
+			Version:    int32(1),
+			MerkleRoot: *genesisMerkleRoot,
+			Timestamp:  time.Unix(1296688602, 0),
+			Bits:       uint32(0x207fffff),
+			Nonce:      uint32(0),
+		},
+		Transactions: []*wire.MsgTx{},
+	}
+	return putGenesisBlockIntoChainParams(genesisHash, genesisBlock, params)
+}
+
+func loadFixtureHeaders(t *testing.T, fileName string) []*wire.BlockHeader {
+	f, err := os.Open(filepath.Join("testdata", fileName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	headers := []*wire.BlockHeader{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		headerBytes, err := hex.DecodeString(scanner.Text())
+		if err != nil {
+			t.Fatal(err)
+		}
+		header := &wire.BlockHeader{}
+		if err := header.Deserialize(bytes.NewReader(headerBytes)); err != nil {
+			t.Fatal(err)
+		}
+		headers = append(headers, header)
+	}
+	return headers
+}
+
+func meetsTarget(header *wire.BlockHeader) bool {
+	powHash := PowHash(header)
+	return btcrelaying.HashToBig(&powHash).Cmp(btcrelaying.CompactToBig(header.Bits)) <= 0
+}
+
+func TestPowHashOfGenesisBlocks(t *testing.T) {
+	for _, params := range []*chaincfg.Params{GetTestNet4Params(), getRegTestParams()} {
+		header := &params.GenesisBlock.Header
+		assert.Equal(t, params.GenesisHash.String(), header.BlockHash().String(), params.Name)
+		assert.True(t, meetsTarget(header), params.Name)
+	}
+}
+
+func TestProcessFixtureHeaders(t *testing.T) {
+	dbPath, err := ioutil.TempDir("", "ltcrelaying")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dbPath)
+
+	params := getRegTestParams()
+	ltcChain, err := GetChainV2(filepath.Join(dbPath, "regtest"), params, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ltcChain.GetDB().Close()
+
+	headers := loadFixtureHeaders(t, "regtest_headers.txt")
+	for i, header := range headers {
+		block := btcutil.NewBlock(&wire.MsgBlock{Header: *header})
+		isMainChain, isOrphan, err := ltcChain.ProcessBlockV2(block, btcrelaying.BFNone)
+		assert.Nil(t, err, "header %v", i+1)
+		assert.True(t, isMainChain, "header %v", i+1)
+		assert.False(t, isOrphan, "header %v", i+1)
+	}
+	bestState := ltcChain.BestSnapshot()
+	assert.Equal(t, int32(len(headers)), bestState.Height)
+	assert.Equal(t, headers[len(headers)-1].BlockHash(), bestState.Hash)
+
+	// a header whose block hash is valid but whose scrypt hash misses the target must be rejected
+	tip := headers[len(headers)-1]
+	invalidHeader := wire.BlockHeader{
+		Version:    tip.Version,
+		PrevBlock:  tip.BlockHash(),
+		MerkleRoot: chainhash.DoubleHashH([]byte("invalid pow")),
+		Timestamp:  tip.Timestamp.Add(150 * time.Second),
+		Bits:       tip.Bits,
+	}
+	for {
+		blockHash := invalidHeader.BlockHash()
+		if !meetsTarget(&invalidHeader) &&
+			btcrelaying.HashToBig(&blockHash).Cmp(btcrelaying.CompactToBig(invalidHeader.Bits)) <= 0 {
+			break
+		}
+		invalidHeader.Nonce++
+	}
+	_, _, err = ltcChain.ProcessBlockV2(btcutil.NewBlock(&wire.MsgBlock{Header: invalidHeader}), btcrelaying.BFNone)
+	ruleErr, ok := err.(btcrelaying.RuleError)
+	assert.True(t, ok)
+	assert.Equal(t, btcrelaying.ErrHighHash, ruleErr.ErrorCode)
+}
@@ -0,0 +1,103 @@
+package ltcrelaying
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/wire"
+)
+
+const (
+	// MainNet represents the litecoin main network
+	MainNet wire.BitcoinNet = 0xdbb6c0fb
+	// TestNet4 represents the litecoin test network (version 4)
+	TestNet4 wire.BitcoinNet = 0xf1c8d2fd
+)
+
+var (
+	bigOne = big.NewInt(1)
+
+	// powLimit is the highest proof of work value a litecoin block can have
+	// for both the main and the test network, it is 2^236 - 1
+	powLimit = new(big.Int).Sub(new(big.Int).Lsh(bigOne, 236), bigOne)
+)
+
+// MainNetParams defines the network parameters for the litecoin main network.
+// Fields which are not related to header relaying or addresses are kept from bitcoin
+var MainNetParams = func() chaincfg.Params {
+	params := chaincfg.MainNetParams
+	params.Name = "litecoin-mainnet"
+	params.Net = MainNet
+	params.DefaultPort = "9333"
+	params.DNSSeeds = nil
+	params.Checkpoints = nil
+
+	params.PowLimit = powLimit
+	params.PowLimitBits = 0x1e0fffff
+	params.BIP0034Height = 710000
+	params.BIP0065Height = 918684
+	params.BIP0066Height = 811879
+	params.CoinbaseMaturity = 100
+	params.SubsidyReductionInterval = 840000
+	params.TargetTimespan = time.Hour * 84 // 3.5 days
+	params.TargetTimePerBlock = time.Second * 150
+	params.RetargetAdjustmentFactor = 4
+	params.ReduceMinDifficulty = false
+	params.MinDiffReductionTime = 0
+
+	params.Bech32HRPSegwit = "ltc"
+	params.PubKeyHashAddrID = 0x30
+	params.ScriptHashAddrID = 0x32
+	params.PrivateKeyID = 0xb0
+	params.WitnessPubKeyHashAddrID = 0x06
+	params.WitnessScriptHashAddrID = 0x0a
+	params.HDPrivateKeyID = [4]byte{0x04, 0x88, 0xad, 0xe4}
+	params.HDPublicKeyID = [4]byte{0x04, 0x88, 0xb2, 0x1e}
+	params.HDCoinType = 2
+	return params
+}()
+
+// TestNet4Params defines the network parameters for the litecoin test network (version 4).
+// Fields which are not related to header relaying or addresses are kept from bitcoin
+var TestNet4Params = func() chaincfg.Params {
+	params := chaincfg.TestNet3Params
+	params.Name = "litecoin-testnet4"
+	params.Net = TestNet4
+	params.DefaultPort = "19335"
+	params.DNSSeeds = nil
+	params.Checkpoints = nil
+
+	params.PowLimit = powLimit
+	params.PowLimitBits = 0x1e0fffff
+	params.BIP0034Height = 76
+	params.BIP0065Height = 76
+	params.BIP0066Height = 76
+	params.CoinbaseMaturity = 100
+	params.SubsidyReductionInterval = 840000
+	params.TargetTimespan = time.Hour * 84 // 3.5 days
+	params.TargetTimePerBlock = time.Second * 150
+	params.RetargetAdjustmentFactor = 4
+	params.ReduceMinDifficulty = true
+	params.MinDiffReductionTime = time.Minute * 5 // TargetTimePerBlock * 2
+
+	params.Bech32HRPSegwit = "tltc"
+	params.PubKeyHashAddrID = 0x6f
+	params.ScriptHashAddrID = 0x3a
+	params.PrivateKeyID = 0xef
+	params.WitnessPubKeyHashAddrID = 0x52
+	params.WitnessScriptHashAddrID = 0x31
+	params.HDPrivateKeyID = [4]byte{0x04, 0x35, 0x83, 0x94}
+	params.HDPublicKeyID = [4]byte{0x04, 0x35, 0x87, 0xcf}
+	params.HDCoinType = 1
+	return params
+}()
+
+func init() {
+	// register litecoin networks so that their base58 addresses can be decoded by btcutil
+	for _, params := range []*chaincfg.Params{&MainNetParams, &TestNet4Params} {
+		if err := chaincfg.Register(params); err != nil {
+			panic("failed to register litecoin network: " + err.Error())
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package ltcrelaying
+
+import (
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+)
+
+// genesisMerkleRoot is the merkle root of the litecoin genesis block, shared by mainnet and testnet4.
+// There is no hardcoded mainnet block: the ltc relaying chain is disabled on mainnet until a recent
+// mainnet checkpoint is chosen, relaying from block 0 would take months of header relaying.
+var genesisMerkleRoot, _ = chainhash.NewHashFromStr("97ddfbbae6be97fd6cdf3e7ca13232a3afff2353e29badfab7f73011edd4ced9")
+
+func getHardcodedTestNet4GenesisBlock() (*wire.MsgBlock, *chainhash.Hash) {
+	// Block 0 from litecoin testnet4, testnet relaying chains are synced from the genesis block
+	genesisHash, _ := chainhash.NewHashFromStr("4966625a4b2851d9fdee139e56211a0d88575f59ed816ff5e6a63deb4e3e29a0")
+	var genesisBlock = wire.MsgBlock{
+		Header: wire.BlockHeader{
+			Version:    int32(1),
+			PrevBlock:  chainhash.Hash{},
+			MerkleRoot: *genesisMerkleRoot,
+			Timestamp:  time.Unix(1486949366, 0),
+			Bits:       uint32(0x1e0ffff0),
+			Nonce:      uint32(293345),
+		},
+		Transactions: []*wire.MsgTx{},
+	}
+	return &genesisBlock, genesisHash
+}
+
+func putGenesisBlockIntoChainParams(
+	genesisHash *chainhash.Hash,
+	msgBlk *wire.MsgBlock,
+	chainParams chaincfg.Params,
+) *chaincfg.Params {
+	chainParams.GenesisBlock = msgBlk
+	chainParams.GenesisHash = genesisHash
+	return &chainParams
+}
+
+func GetTestNet4Params() *chaincfg.Params {
+	genesisBlock, genesisHash := getHardcodedTestNet4GenesisBlock()
+	return putGenesisBlockIntoChainParams(genesisHash, genesisBlock, TestNet4Params)
+}
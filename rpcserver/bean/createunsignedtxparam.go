@@ -0,0 +1,156 @@
+package bean
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/privacy"
+	"github.com/incognitochain/incognito-chain/wallet"
+)
+
+// CreateUnsignedTxParam holds the params to build a transaction from the read-only keys of the sender,
+// the transaction is signed offline with the private key.
+type CreateUnsignedTxParam struct {
+	SenderKeySet         *incognitokey.KeySet
+	ShardIDSender        byte
+	PaymentInfos         []*privacy.PaymentInfo
+	EstimateFeeCoinPerKb int64
+	TokenParamsRaw       map[string]interface{}
+	Info                 []byte
+	// ExcludedCoins are the base58 public keys of the coins not to spend : without the private key,
+	// the node cannot tell which coins are spent by the pending transactions of the sender
+	ExcludedCoins map[string]bool
+}
+
+// GetKeySetFromReadOnlyKeysParams builds a key set without private key from
+// {"PaymentAddress": ..., "ReadonlyKey": ..., "OTASecretKey": ...}
+func GetKeySetFromReadOnlyKeysParams(param interface{}) (*incognitokey.KeySet, byte, error) {
+	keys, ok := param.(map[string]interface{})
+	if !ok {
+		return nil, byte(0), errors.New("sender keys are invalid")
+	}
+	keyStrs := make(map[string]*wallet.KeyWallet)
+	for _, keyName := range []string{"PaymentAddress", "ReadonlyKey", "OTASecretKey"} {
+		keyStr, ok := keys[keyName].(string)
+		if !ok || keyStr == "" {
+			return nil, byte(0), fmt.Errorf("%s of sender is missing", keyName)
+		}
+		keyWallet, err := wallet.Base58CheckDeserialize(keyStr)
+		if err != nil {
+			return nil, byte(0), fmt.Errorf("%s of sender is invalid: %v", keyName, err)
+		}
+		keyStrs[keyName] = keyWallet
+	}
+
+	keySet := &incognitokey.KeySet{
+		PaymentAddress: keyStrs["PaymentAddress"].KeySet.PaymentAddress,
+		ReadonlyKey:    keyStrs["ReadonlyKey"].KeySet.ReadonlyKey,
+		OTAKey:         keyStrs["OTASecretKey"].KeySet.OTAKey,
+	}
+	if len(keySet.PaymentAddress.Pk) == 0 || len(keySet.ReadonlyKey.Rk) == 0 || keySet.OTAKey.GetOTASecretKey() == nil {
+		return nil, byte(0), errors.New("sender keys are invalid")
+	}
+	pubSpend := keySet.OTAKey.GetPublicSpend()
+	if pubSpend == nil || !bytes.Equal(pubSpend.ToBytesS(), keySet.PaymentAddress.Pk) || !bytes.Equal(keySet.ReadonlyKey.Pk, keySet.PaymentAddress.Pk) {
+		return nil, byte(0), errors.New("sender keys do not belong to the payment address")
+	}
+
+	// calculate shard ID
+	lastByte := keySet.PaymentAddress.Pk[len(keySet.PaymentAddress.Pk)-1]
+	shardID := common.GetShardIDFromLastByte(lastByte)
+
+	return keySet, shardID, nil
+}
+
+func getExcludedCoins(param interface{}) (map[string]bool, error) {
+	excludedCoins := make(map[string]bool)
+	if param == nil {
+		return excludedCoins, nil
+	}
+	for _, coinParam := range common.InterfaceSlice(param) {
+		publicKeyStr, ok := coinParam.(string)
+		if !ok {
+			return nil, errors.New("excluded coin is invalid")
+		}
+		excludedCoins[publicKeyStr] = true
+	}
+	return excludedCoins, nil
+}
+
+func NewCreateUnsignedTxParam(params interface{}) (*CreateUnsignedTxParam, error) {
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) < 3 {
+		return nil, errors.New("not enough param")
+	}
+
+	// param #1: read-only keys of sender
+	senderKeySet, shardIDSender, err := GetKeySetFromReadOnlyKeysParams(arrayParams[0])
+	if err != nil {
+		return nil, err
+	}
+
+	// param #2: list receivers
+	paymentInfos, err := GetListReceivers(arrayParams[1])
+	if err != nil {
+		return nil, err
+	}
+
+	// param #3: estimation fee nano P per kb
+	estimateFeeCoinPerKb, ok := arrayParams[2].(float64)
+	if !ok {
+		return nil, errors.New("estimate fee coin per kb is invalid")
+	}
+
+	// param #4: info (optional)
+	info := []byte{}
+	if len(arrayParams) > 3 && arrayParams[3] != nil {
+		infoStr, ok := arrayParams[3].(string)
+		if !ok {
+			return nil, errors.New("info is invalid")
+		}
+		info = []byte(infoStr)
+	}
+
+	// param #5: public keys of the coins not to spend (optional)
+	var excludedCoinsParam interface{}
+	if len(arrayParams) > 4 {
+		excludedCoinsParam = arrayParams[4]
+	}
+	excludedCoins, err := getExcludedCoins(excludedCoinsParam)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CreateUnsignedTxParam{
+		SenderKeySet:         senderKeySet,
+		ShardIDSender:        shardIDSender,
+		PaymentInfos:         paymentInfos,
+		EstimateFeeCoinPerKb: int64(estimateFeeCoinPerKb),
+		Info:                 info,
+		ExcludedCoins:        excludedCoins,
+	}, nil
+}
+
+func NewCreateUnsignedPrivacyTokenTxParam(params interface{}) (*CreateUnsignedTxParam, error) {
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) < 4 {
+		return nil, errors.New("not enough param")
+	}
+
+	// param #4: token component, the next params are the same as for a PRV transfer
+	tokenParamsRaw, ok := arrayParams[3].(map[string]interface{})
+	if !ok {
+		return nil, errors.New("token param is invalid")
+	}
+	txParamsArray := append([]interface{}{}, arrayParams[:3]...)
+	txParamsArray = append(txParamsArray, arrayParams[4:]...)
+	txParam, err := NewCreateUnsignedTxParam(txParamsArray)
+	if err != nil {
+		return nil, err
+	}
+	txParam.TokenParamsRaw = tokenParamsRaw
+	return txParam, nil
+}
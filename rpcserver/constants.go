@@ -10,6 +10,9 @@ const (
 	stopProfiling  = "stopprofiling"
 	exportMetrics  = "exportmetrics"
 
+	// OpenRPC document
+	rpcDiscover = "rpc.discover"
+
 	getNetworkInfo       = "getnetworkinfo"
 	getConnectionCount   = "getconnectioncount"
 	getAllConnectedPeers = "getallconnectedpeers"
@@ -61,6 +64,7 @@ const (
 	listOutputCoinsFromCache                   = "listoutputcoinsfromcache"
 	listOutputTokens                           = "listoutputtokens"
 	createRawTransaction                       = "createtransaction"
+	createUnsignedTransaction                  = "createunsignedtransaction"
 	sendRawTransaction                         = "sendtransaction"
 	createAndSendTransaction                   = "createandsendtransaction"
 	createConvertCoinVer1ToVer2Transaction     = "createconvertcoinver1tover2transaction"
@@ -69,6 +73,7 @@ const (
 	createRawCustomTokenTransaction            = "createrawcustomtokentransaction"
 	createConvertCoinVer1ToVer2TxToken         = "createconvertcoinver1tover2txtoken"
 	createRawPrivacyCustomTokenTransaction     = "createrawprivacycustomtokentransaction"
+	createUnsignedPrivacyTokenTransaction      = "createunsignedprivacycustomtokentransaction"
 	sendRawPrivacyCustomTokenTransaction       = "sendrawprivacycustomtokentransaction"
 	createAndSendPrivacyCustomTokenTransaction = "createandsendprivacycustomtokentransaction"
 	getMempoolInfo                             = "getmempoolinfo"
@@ -302,6 +307,8 @@ const (
 	getPdexv3EstimatedStakingPoolReward            = "pdexv3_getEstimatedStakingPoolReward"
 	createAndSendTxWithPdexv3WithdrawStakingReward = "pdexv3_txWithdrawStakingReward"
 	getPdexv3WithdrawalStakingRewardStatus         = "pdexv3_getWithdrawalStakingRewardStatus"
+	getPdexv3BestTradePath                         = "pdexv3_getBestTradePath"
+	simulatePdexv3Trade                            = "pdexv3_simulateTrade"
 
 	// bridgeagg method
 	bridgeaggState                       = "bridgeaggGetState"
@@ -322,6 +329,9 @@ const (
 	// get burning address
 	getBurningAddress = "getburningaddress"
 
+	// delegation
+	getDelegationPools = "getdelegationpools"
+
 	// portal
 	createAndSendTxWithCustodianDeposit           = "createandsendtxwithcustodiandeposit"
 	createAndSendTxWithReqPToken                  = "createandsendtxwithreqptoken"
@@ -374,10 +384,13 @@ const (
 	// relaying
 	createAndSendTxWithRelayingBNBHeader = "createandsendtxwithrelayingbnbheader"
 	createAndSendTxWithRelayingBTCHeader = "createandsendtxwithrelayingbtcheader"
+	createAndSendTxWithRelayingLTCHeader = "createandsendtxwithrelayingltcheader"
 	getRelayingBNBHeaderState            = "getrelayingbnbheaderstate"
 	getRelayingBNBHeaderByBlockHeight    = "getrelayingbnbheaderbyblockheight"
 	getBTCRelayingBestState              = "getbtcrelayingbeststate"
 	getBTCBlockByHash                    = "getbtcblockbyhash"
+	getLTCRelayingBestState              = "getltcrelayingbeststate"
+	getLTCBlockByHash                    = "getltcblockbyhash"
 	getLatestBNBHeaderBlockHeight        = "getlatestbnbheaderblockheight"
 
 	// incognito mode for sc
@@ -425,6 +438,8 @@ const (
 	createAndSendTxPortalConvertVaultRequest   = "createandsendtxportalconvertvault"
 	getPortalConvertVaultTxStatus              = "getportalconvertvaultstatus"
 	generatePortalShieldMultisigAddress        = "generateportalshieldmultisigaddress"
+	getPortalV4Tokens                          = "getportalv4tokens"
+	validatePortalRemoteAddress                = "validateportalremoteaddress"
 
 	// stake
 	unstake = "createunstaketransaction"
@@ -435,6 +450,13 @@ const (
 	prune          = "pruneState"
 	getPruneState  = "getPruneState"
 	checkPruneData = "checkprunedata"
+
+	// state proof
+	getTokenStateProof     = "gettokenstateproof"
+	getRewardAmountProof   = "getrewardamountproof"
+	getBridgeTokenProof    = "getbridgetokenproof"
+	getPdexv3PoolPairProof = "pdexv3_getPoolPairProof"
+	getCommitteeStateProof = "getcommitteestateproof"
 )
 
 const (
@@ -457,6 +479,7 @@ const (
 	subcribeBeaconBestStateFromMem              = "subcribebeaconbeststatefrommem"
 	subcribeBeaconPoolBeststate                 = "subcribebeaconpoolbeststate"
 	subcribeShardPoolBeststate                  = "subcribeshardpoolbeststate"
+	subcribeIncomingCoinByOTAKey                = "subcribeincomingcoinbyotakey"
 )
 
 // add method names when add new feature flags
@@ -464,10 +487,13 @@ var FeatureFlagWithMethodNames = map[string][]string{
 	common.PortalRelayingFlag: {
 		createAndSendTxWithRelayingBNBHeader,
 		createAndSendTxWithRelayingBTCHeader,
+		createAndSendTxWithRelayingLTCHeader,
 		getRelayingBNBHeaderState,
 		getRelayingBNBHeaderByBlockHeight,
 		getBTCRelayingBestState,
 		getBTCBlockByHash,
+		getLTCRelayingBestState,
+		getLTCBlockByHash,
 		getLatestBNBHeaderBlockHeight,
 	},
 	common.PortalV3Flag: {
@@ -536,5 +562,7 @@ var FeatureFlagWithMethodNames = map[string][]string{
 		getPortalConvertVaultTxStatus,
 		getPortalV4Params,
 		generatePortalShieldMultisigAddress,
+		getPortalV4Tokens,
+		validatePortalRemoteAddress,
 	},
 }
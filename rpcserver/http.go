@@ -1,8 +1,11 @@
 package rpcserver
 
 import (
+	"bufio"
+	"bytes"
 	"crypto/subtle"
 	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -16,6 +19,7 @@ import (
 	"time"
 
 	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/config"
 	"github.com/incognitochain/incognito-chain/incdb"
 	"github.com/incognitochain/incognito-chain/pruner"
 
@@ -23,6 +27,40 @@ import (
 	"github.com/incognitochain/incognito-chain/rpcserver/rpcservice"
 )
 
+// rpcAPIKey is an API key of the config, ready to check the requests authenticated with it
+type rpcAPIKey struct {
+	name                     string
+	authSHA                  []byte
+	methods                  map[string]bool
+	limitRequestPerDay       int
+	limitRequestErrorPerHour int
+}
+
+func newRPCAPIKey(apiKey config.RPCAPIKey) *rpcAPIKey {
+	login := apiKey.Name + ":" + apiKey.Key
+	auth := "Basic " + base64.StdEncoding.EncodeToString([]byte(login))
+	methods := make(map[string]bool)
+	for _, method := range apiKey.Methods {
+		methods[method] = true
+	}
+	return &rpcAPIKey{
+		name:                     apiKey.Name,
+		authSHA:                  common.HashB([]byte(auth)),
+		methods:                  methods,
+		limitRequestPerDay:       apiKey.LimitRequestPerDay,
+		limitRequestErrorPerHour: apiKey.LimitRequestErrorPerHour,
+	}
+}
+
+// isAllowed checks the method against the allowlist of the key, "*" allows every method
+// except the limited ones, which must be listed by name.
+func (apiKey *rpcAPIKey) isAllowed(method string) bool {
+	if _, ok := LimitedHttpHandler[method]; ok {
+		return apiKey.methods[method]
+	}
+	return apiKey.methods["*"] || apiKey.methods[method]
+}
+
 type HttpServer struct {
 	started          int32
 	shutdown         int32
@@ -34,6 +72,7 @@ type HttpServer struct {
 	statusLines      map[int]string
 	authSHA          []byte
 	limitAuthSHA     []byte
+	apiKeys          []*rpcAPIKey
 	// channel
 	cRequestProcessShutdown chan struct{}
 
@@ -64,6 +103,10 @@ func (httpServer *HttpServer) Init(config *RpcServerConfig) {
 		auth := "Basic " + base64.StdEncoding.EncodeToString([]byte(login))
 		httpServer.limitAuthSHA = common.HashB([]byte(auth))
 	}
+	httpServer.apiKeys = nil
+	for _, apiKey := range config.APIKeys {
+		httpServer.apiKeys = append(httpServer.apiKeys, newRPCAPIKey(apiKey))
+	}
 
 	// init service
 	httpServer.blockService = &rpcservice.BlockService{
@@ -176,16 +219,21 @@ func (httpServer *HttpServer) handleRequest(w http.ResponseWriter, r *http.Reque
 		httpServer.DecrementClients()
 		//fmt.Println("RPCCON:", before, httpServer.numClients)
 	}()
-	// Check authentication for rpc user
-	ok, isLimitUser, err := httpServer.checkAuth(r, true)
-	if err != nil || !ok {
-		Logger.log.Error(err)
-		AuthFail(w)
-		return
+	// Check authentication for api key first, then for rpc user
+	apiKey := httpServer.checkAPIKeyAuth(r)
+	isLimitUser := false
+	if apiKey == nil {
+		ok, isLimit, err := httpServer.checkAuth(r, true)
+		if err != nil || !ok {
+			Logger.log.Error(err)
+			AuthFail(w)
+			return
+		}
+		isLimitUser = isLimit
 	}
 
 	go func() {
-		httpServer.ProcessRpcRequest(w, r, isLimitUser)
+		httpServer.processRpcRequest(w, r, isLimitUser, apiKey)
 		done <- 1
 	}()
 
@@ -201,19 +249,23 @@ handles reading and responding to RPC messages.
 */
 
 func (httpServer *HttpServer) ProcessRpcRequest(w http.ResponseWriter, r *http.Request, isLimitedUser bool) {
+	httpServer.processRpcRequest(w, r, isLimitedUser, nil)
+}
+
+// processRpcRequest handles the RPC messages of a client authenticated with apiKey,
+// or with the rpc users when apiKey is nil.
+func (httpServer *HttpServer) processRpcRequest(w http.ResponseWriter, r *http.Request, isLimitedUser bool, apiKey *rpcAPIKey) {
 	if atomic.LoadInt32(&httpServer.shutdown) != 0 {
 		return
 	}
 
-	if httpServer.config.RPCLimitRequestPerDay > 0 {
-		// check limit request per day
-		if httpServer.checkLimitRequestPerDay(r) {
-			errMsg := "Reach limit request per day"
-			Logger.log.Error(errMsg)
-			errCode := http.StatusTooManyRequests
-			http.Error(w, strconv.Itoa(errCode)+" "+errMsg, errCode)
-			return
-		}
+	// check limit request per day
+	if httpServer.checkLimitRequestPerDay(r, apiKey) {
+		errMsg := "Reach limit request per day"
+		Logger.log.Error(errMsg)
+		errCode := http.StatusTooManyRequests
+		http.Error(w, strconv.Itoa(errCode)+" "+errMsg, errCode)
+		return
 	}
 
 	// Read and close the JSON-RPC request body from the caller.
@@ -250,6 +302,11 @@ func (httpServer *HttpServer) ProcessRpcRequest(w http.ResponseWriter, r *http.R
 	defer buf.Flush()
 	conn.SetReadDeadline(timeZeroVal)
 
+	if isBatchRequest(body) {
+		httpServer.processBatchRequest(w, r, conn, buf, body, isLimitedUser, apiKey)
+		return
+	}
+
 	var jsonErr error
 	var result interface{}
 	var request *JsonRequest
@@ -271,60 +328,24 @@ func (httpServer *HttpServer) ProcessRpcRequest(w http.ResponseWriter, r *http.R
 			return
 		}
 
-		if httpServer.config.RPCLimitRequestErrorPerHour > 0 {
-			if httpServer.checkBlackListClientRequestErrorPerHour(r, request.Method) {
-				errMsg := "Reach limit request error for method " + request.Method
-				Logger.log.Error(errMsg)
-				errCode := http.StatusTooManyRequests
-				http.Error(w, strconv.Itoa(errCode)+" "+errMsg, errCode)
-				return
-			}
+		if httpServer.checkBlackListClientRequestErrorPerHour(r, apiKey, request.Method) {
+			errMsg := "Reach limit request error for method " + request.Method
+			Logger.log.Error(errMsg)
+			errCode := http.StatusTooManyRequests
+			http.Error(w, strconv.Itoa(errCode)+" "+errMsg, errCode)
+			return
 		}
 
-		// Setup a close notifier.  Since the connection is hijacked,
-		// the CloseNotifer on the ResponseWriter is not available.
-		closeChan := make(chan struct{}, 1)
-		go func() {
-			_, err := conn.Read(make([]byte, 1))
-			if err != nil {
-				close(closeChan)
-			}
-		}()
+		closeChan := httpServer.closeNotifier(conn)
 
-		// Check if the user is limited and set error if method unauthorized
-		if !isLimitedUser {
-			if function, ok := LimitedHttpHandler[request.Method]; ok {
-				_ = function
-				jsonErr = rpcservice.NewRPCError(rpcservice.RPCInvalidMethodPermissionError, errors.New(""))
-			}
-		}
-		if jsonErr == nil {
+		if permissionErr := httpServer.checkMethodPermission(request.Method, isLimitedUser, apiKey); permissionErr != nil {
+			jsonErr = permissionErr
+		} else {
 			if request.Method == "downloadbackup" {
 				httpServer.handleDownloadBackup(conn, request.Params)
 				return
 			}
-
-			// Attempt to parse the JSON-RPC request into a known concrete
-			// command.
-			command := HttpHandler[request.Method]
-			if command == nil {
-				if isLimitedUser {
-					command = LimitedHttpHandler[request.Method]
-				} else {
-					result = nil
-					jsonErr = rpcservice.NewRPCError(rpcservice.RPCMethodNotFoundError, errors.New("Method not found: "+request.Method))
-				}
-			}
-			if command != nil {
-				// check feature flags
-				isFeatureFlag, isEnable := httpServer.checkEnableFeatureFlagRPC(request.Method)
-				if isFeatureFlag && !isEnable {
-					result = nil
-					jsonErr = rpcservice.NewRPCError(rpcservice.UnexpectedError, errors.New("Method is disabled on this version: "+request.Method))
-				} else {
-					result, jsonErr = command(httpServer, request.Params, closeChan)
-				}
-			}
+			result, jsonErr = httpServer.executeRequest(request, canCallLimitedMethod(request.Method, isLimitedUser, apiKey), closeChan)
 		}
 	}
 
@@ -332,7 +353,7 @@ func (httpServer *HttpServer) ProcessRpcRequest(w http.ResponseWriter, r *http.R
 		if jsonErr.(*rpcservice.RPCError).Code == rpcservice.ErrCodeMessage[rpcservice.RPCParseError].Code {
 			Logger.log.Errorf("RPC function process with err \n %+v", jsonErr)
 			httpServer.writeHTTPResponseHeaders(r, w.Header(), http.StatusBadRequest, buf)
-			httpServer.addBlackListClientRequestErrorPerHour(r, request.Method)
+			httpServer.addBlackListClientRequestErrorPerHour(r, apiKey, request.Method)
 			return
 		}
 
@@ -344,7 +365,7 @@ func (httpServer *HttpServer) ProcessRpcRequest(w http.ResponseWriter, r *http.R
 	}
 
 	if jsonErr != nil && jsonErr.(*rpcservice.RPCError) != nil {
-		httpServer.addBlackListClientRequestErrorPerHour(r, request.Method)
+		httpServer.addBlackListClientRequestErrorPerHour(r, apiKey, request.Method)
 	}
 
 	// Marshal the response.
@@ -354,11 +375,177 @@ func (httpServer *HttpServer) ProcessRpcRequest(w http.ResponseWriter, r *http.R
 		Logger.log.Error(err)
 		return
 	}
+	httpServer.writeResponse(w, r, buf, msg)
+}
+
+// processBatchRequest handles a JSON-RPC 2.0 batch: every call of the array is checked
+// and executed like a single request, and the responses are written back in one array.
+// The notifications of the batch are not answered.
+func (httpServer *HttpServer) processBatchRequest(w http.ResponseWriter, r *http.Request, conn net.Conn, buf *bufio.ReadWriter, body []byte, isLimitedUser bool, apiKey *rpcAPIKey) {
+	var rawRequests []json.RawMessage
+	if err := json.Unmarshal(body, &rawRequests); err != nil {
+		Logger.log.Errorf("RPC batch request can not be parsed \n %+v", err)
+		httpServer.writeHTTPResponseHeaders(r, w.Header(), http.StatusBadRequest, buf)
+		return
+	}
+	if len(rawRequests) == 0 || len(rawRequests) > rpcMaxBatchRequests {
+		jsonErr := rpcservice.NewRPCError(rpcservice.RPCInvalidRequestError, fmt.Errorf("batch request must contain from 1 to %d requests", rpcMaxBatchRequests))
+		msg, err := createMarshalledResponse(&JsonRequest{}, nil, jsonErr)
+		if err != nil {
+			Logger.log.Errorf("Failed to marshal reply: %s", err.Error())
+			return
+		}
+		httpServer.writeResponse(w, r, buf, msg)
+		return
+	}
+
+	closeChan := httpServer.closeNotifier(conn)
+	responses := []json.RawMessage{}
+	for i, rawRequest := range rawRequests {
+		// the first request of the batch is already counted in the limit request per day
+		msg := httpServer.processBatchCall(r, rawRequest, i > 0, isLimitedUser, apiKey, closeChan)
+		if msg != nil {
+			responses = append(responses, msg)
+		}
+	}
+	if len(responses) == 0 {
+		err := httpServer.writeHTTPResponseHeaders(r, w.Header(), http.StatusOK, buf)
+		if err != nil {
+			Logger.log.Error(err)
+		}
+		return
+	}
+	msg, err := json.MarshalIndent(responses, "", "\t")
+	if err != nil {
+		Logger.log.Errorf("Failed to marshal reply: %s", err.Error())
+		return
+	}
+	httpServer.writeResponse(w, r, buf, msg)
+}
+
+// processBatchCall executes one call of a batch request and returns its marshalled response,
+// or nil if the call is a notification.
+func (httpServer *HttpServer) processBatchCall(r *http.Request, rawRequest []byte, countRequest bool, isLimitedUser bool, apiKey *rpcAPIKey, closeChan <-chan struct{}) (msg []byte) {
+	var jsonErr error
+	var result interface{}
+	request, err := parseJsonRequest(rawRequest, r.Method)
+	if err != nil {
+		// the call can not be identified, it is answered with a null id
+		request = &JsonRequest{}
+		jsonErr = rpcservice.NewRPCError(rpcservice.RPCInvalidRequestError, err)
+	} else {
+		if request.Id == nil && !(httpServer.config.RPCQuirks && request.Jsonrpc == "") {
+			return nil
+		}
+		defer func() {
+			if err := recover(); err != nil {
+				Logger.log.Errorf("Recovery error message: %v", err)
+				msg, _ = createMarshalledResponse(request, nil, rpcservice.NewRPCError(rpcservice.RPCInternalError, fmt.Errorf("%v", err)))
+			}
+		}()
+
+		if countRequest && httpServer.checkLimitRequestPerDay(r, apiKey) {
+			jsonErr = rpcservice.NewRPCError(rpcservice.RPCInvalidRequestError, errors.New("Reach limit request per day"))
+		} else if httpServer.checkBlackListClientRequestErrorPerHour(r, apiKey, request.Method) {
+			jsonErr = rpcservice.NewRPCError(rpcservice.RPCInvalidRequestError, errors.New("Reach limit request error for method "+request.Method))
+		} else if permissionErr := httpServer.checkMethodPermission(request.Method, isLimitedUser, apiKey); permissionErr != nil {
+			jsonErr = permissionErr
+		} else if request.Method == "downloadbackup" {
+			jsonErr = rpcservice.NewRPCError(rpcservice.RPCInvalidRequestError, errors.New("downloadbackup can not be called in a batch request"))
+		} else {
+			var rpcErr *rpcservice.RPCError
+			result, rpcErr = httpServer.executeRequest(request, canCallLimitedMethod(request.Method, isLimitedUser, apiKey), closeChan)
+			if rpcErr != nil {
+				jsonErr = rpcErr
+			}
+		}
+	}
+
+	if jsonErr != nil {
+		if request.Method != getTransactionByHash {
+			Logger.log.Errorf("RPC function process with err \n %+v", jsonErr)
+		}
+		httpServer.addBlackListClientRequestErrorPerHour(r, apiKey, request.Method)
+	}
+	msg, err = createMarshalledResponse(request, result, jsonErr)
+	if err != nil {
+		Logger.log.Errorf("Failed to marshal reply: %s", err.Error())
+		msg, _ = createMarshalledResponse(&JsonRequest{}, nil, rpcservice.NewRPCError(rpcservice.RPCInternalError, err))
+	}
+	return msg
+}
+
+func isBatchRequest(body []byte) bool {
+	return bytes.HasPrefix(bytes.TrimLeft(body, " \t\r\n"), []byte("["))
+}
+
+// closeNotifier returns a channel closed when the client closes the connection.
+// Since the connection is hijacked, the CloseNotifer on the ResponseWriter is not available.
+func (httpServer *HttpServer) closeNotifier(conn net.Conn) <-chan struct{} {
+	closeChan := make(chan struct{}, 1)
+	go func() {
+		_, err := conn.Read(make([]byte, 1))
+		if err != nil {
+			close(closeChan)
+		}
+	}()
+	return closeChan
+}
+
+// checkMethodPermission returns an error if the client can not call method: a client with
+// an API key only calls the methods of its key, else the limited methods are reserved
+// to the limited user.
+func (httpServer *HttpServer) checkMethodPermission(method string, isLimitedUser bool, apiKey *rpcAPIKey) *rpcservice.RPCError {
+	if apiKey != nil {
+		if !apiKey.isAllowed(method) {
+			return rpcservice.NewRPCError(rpcservice.RPCInvalidMethodPermissionError, errors.New("Method is not allowed for API key "+apiKey.name+": "+method))
+		}
+		return nil
+	}
+	// Check if the user is limited and set error if method unauthorized
+	if !isLimitedUser {
+		if _, ok := LimitedHttpHandler[method]; ok {
+			return rpcservice.NewRPCError(rpcservice.RPCInvalidMethodPermissionError, errors.New(""))
+		}
+	}
+	return nil
+}
+
+// canCallLimitedMethod checks if the LimitedHttpHandler can serve method: an API key only
+// calls the limited methods of its allowlist, the rpc user authenticated as limited calls all of them.
+func canCallLimitedMethod(method string, isLimitedUser bool, apiKey *rpcAPIKey) bool {
+	if apiKey != nil {
+		return apiKey.isAllowed(method)
+	}
+	return isLimitedUser
+}
+
+// executeRequest runs the command of request, the limited commands are only looked up
+// if withLimitedHandler is set.
+func (httpServer *HttpServer) executeRequest(request *JsonRequest, withLimitedHandler bool, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	// Attempt to parse the JSON-RPC request into a known concrete
+	// command.
+	command := HttpHandler[request.Method]
+	if command == nil && withLimitedHandler {
+		command = LimitedHttpHandler[request.Method]
+	}
+	if command == nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCMethodNotFoundError, errors.New("Method not found: "+request.Method))
+	}
+	// check feature flags
+	isFeatureFlag, isEnable := httpServer.checkEnableFeatureFlagRPC(request.Method)
+	if isFeatureFlag && !isEnable {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, errors.New("Method is disabled on this version: "+request.Method))
+	}
+	return command(httpServer, request.Params, closeChan)
+}
 
+// writeResponse writes the marshalled response msg on the hijacked connection.
+func (httpServer *HttpServer) writeResponse(w http.ResponseWriter, r *http.Request, buf *bufio.ReadWriter, msg []byte) {
 	// Write the response.
 	// for testing only
 	// w.WriteHeader(http.StatusOK)
-	err = httpServer.writeHTTPResponseHeaders(r, w.Header(), http.StatusOK, buf)
+	err := httpServer.writeHTTPResponseHeaders(r, w.Header(), http.StatusOK, buf)
 	if err != nil {
 		Logger.log.Error(err)
 		return
@@ -408,12 +595,21 @@ func lookupAddress(ip string) string {
 	}
 }
 
-func (httpServer *HttpServer) checkBlackListClientRequestErrorPerHour(r *http.Request, method string) bool {
-	if httpServer.config.RPCLimitRequestErrorPerHour == 0 {
+// getRequestLimits returns the client the limits are counted for: the API key, else the remote address,
+// with its limit request per day and limit request error per hour.
+func (httpServer *HttpServer) getRequestLimits(r *http.Request, apiKey *rpcAPIKey) (string, int, int) {
+	if apiKey != nil {
+		return "rpc-apikey-" + apiKey.name, apiKey.limitRequestPerDay, apiKey.limitRequestErrorPerHour
+	}
+	return getIP(r), httpServer.config.RPCLimitRequestPerDay, httpServer.config.RPCLimitRequestErrorPerHour
+}
+
+func (httpServer *HttpServer) checkBlackListClientRequestErrorPerHour(r *http.Request, apiKey *rpcAPIKey, method string) bool {
+	remoteAddress, _, limitRequestErrorPerHour := httpServer.getRequestLimits(r, apiKey)
+	if limitRequestErrorPerHour == 0 {
 		return false
 	}
 	inBlackList := false
-	remoteAddress := getIP(r)
 	remoteAddressKey := append([]byte("rpc-blacklist-"), []byte(remoteAddress)...)
 	remoteAddressKey = append(remoteAddressKey, []byte(method)...)
 
@@ -423,8 +619,8 @@ func (httpServer *HttpServer) checkBlackListClientRequestErrorPerHour(r *http.Re
 	//}
 	if requestCountInByte != nil {
 		requestCount := common.BytesToInt(requestCountInByte)
-		if requestCount > httpServer.config.RPCLimitRequestErrorPerHour {
-			// only accept RPCLimitRequestErrorPerHour error request in 1 hour
+		if requestCount > limitRequestErrorPerHour {
+			// only accept limitRequestErrorPerHour error request in 1 hour
 			inBlackList = true
 		}
 	}
@@ -432,8 +628,9 @@ func (httpServer *HttpServer) checkBlackListClientRequestErrorPerHour(r *http.Re
 	return inBlackList
 }
 
-func (httpServer *HttpServer) addBlackListClientRequestErrorPerHour(r *http.Request, method string) {
-	if httpServer.config.RPCLimitRequestErrorPerHour == 0 {
+func (httpServer *HttpServer) addBlackListClientRequestErrorPerHour(r *http.Request, apiKey *rpcAPIKey, method string) {
+	remoteAddress, _, limitRequestErrorPerHour := httpServer.getRequestLimits(r, apiKey)
+	if limitRequestErrorPerHour == 0 {
 		return
 	}
 	// pink list method
@@ -442,7 +639,6 @@ func (httpServer *HttpServer) addBlackListClientRequestErrorPerHour(r *http.Requ
 		return
 	}
 
-	remoteAddress := getIP(r)
 	remoteAddressKey := append([]byte("rpc-blacklist-"), []byte(remoteAddress)...)
 	remoteAddressKey = append(remoteAddressKey, []byte(method)...)
 
@@ -467,11 +663,11 @@ func (httpServer *HttpServer) addBlackListClientRequestErrorPerHour(r *http.Requ
 	}
 }
 
-func (httpServer *HttpServer) checkLimitRequestPerDay(r *http.Request) bool {
-	if httpServer.config.RPCLimitRequestPerDay == 0 {
+func (httpServer *HttpServer) checkLimitRequestPerDay(r *http.Request, apiKey *rpcAPIKey) bool {
+	remoteAddress, limitRequestPerDay, _ := httpServer.getRequestLimits(r, apiKey)
+	if limitRequestPerDay == 0 {
 		return false
 	}
-	remoteAddress := getIP(r)
 	remoteAddressKey := []byte(remoteAddress)
 	requestCountInByte, _ := httpServer.config.MemCache.Get(remoteAddressKey)
 	//if err != nil {
@@ -481,7 +677,7 @@ func (httpServer *HttpServer) checkLimitRequestPerDay(r *http.Request) bool {
 	if requestCountInByte != nil {
 		requestCount := common.BytesToInt(requestCountInByte)
 		requestCount += 1
-		if requestCount > httpServer.config.RPCLimitRequestPerDay {
+		if requestCount > limitRequestPerDay {
 			reachLimit = true
 		}
 		requestCountInByte = common.IntToBytes(requestCount)
@@ -543,6 +739,37 @@ func (httpServer *HttpServer) checkAuth(r *http.Request, require bool) (bool, bo
 	return false, false, rpcservice.NewRPCError(rpcservice.AuthFailError, nil)
 }
 
+// checkAPIKeyAuth returns the API key matching the HTTP Basic authentication
+// of the request r, or nil if there is none.
+//
+// This check is time-constant.
+func (httpServer *HttpServer) checkAPIKeyAuth(r *http.Request) *rpcAPIKey {
+	if httpServer.config.DisableAuth {
+		return nil
+	}
+	return findAPIKey(httpServer.apiKeys, r)
+}
+
+// findAPIKey returns the key of apiKeys matching the HTTP Basic authentication
+// of the request r, or nil if there is none.
+func findAPIKey(apiKeys []*rpcAPIKey, r *http.Request) *rpcAPIKey {
+	if len(apiKeys) == 0 {
+		return nil
+	}
+	authhdr := r.Header["Authorization"]
+	if len(authhdr) <= 0 {
+		return nil
+	}
+	authsha := common.HashB([]byte(authhdr[0]))
+	var found *rpcAPIKey
+	for _, apiKey := range apiKeys {
+		if subtle.ConstantTimeCompare(authsha[:], apiKey.authSHA[:]) == 1 {
+			found = apiKey
+		}
+	}
+	return found
+}
+
 // AuthFail sends a Message back to the client if the http auth is rejected.
 func AuthFail(w http.ResponseWriter) {
 	w.Header().Add("WWW-Authenticate", `Basic realm="RPC"`)
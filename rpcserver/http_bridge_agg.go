@@ -28,11 +28,11 @@ func (httpServer *HttpServer) handleGetBridgeAggState(params interface{}, closeC
 	if !ok {
 		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Payload data is invalid"))
 	}
-	beaconHeight, ok := data["BeaconHeight"].(float64)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Beacon height is invalid"))
+	beaconHeight, err := getAtHeightParam(data)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
 	}
-	result, err := httpServer.blockService.GetBridgeAggState(uint64(beaconHeight))
+	result, err := httpServer.blockService.GetBridgeAggState(beaconHeight)
 	if err != nil {
 		return nil, rpcservice.NewRPCError(rpcservice.GetBridgeAggStateError, err)
 	}
@@ -760,6 +760,7 @@ func (httpServer *HttpServer) handleEstimateFeeByBurntAmount(params interface{},
 		UnifiedTokenID common.Hash `json:"UnifiedTokenID"`
 		TokenID        common.Hash `json:"TokenID"`
 		BurntAmount    uint64      `json:"BurntAmount"`
+		AtHeight       uint64      `json:"AtHeight"`
 	}{}
 	rawMd, err := json.Marshal(arrayParams[0])
 	if err != nil {
@@ -769,7 +770,7 @@ func (httpServer *HttpServer) handleEstimateFeeByBurntAmount(params interface{},
 	if err != nil {
 		return nil, rpcservice.NewRPCError(rpcservice.BridgeAggEstimateFeeByBurntAmountError, err)
 	}
-	result, err := httpServer.blockService.BridgeAggEstimateFeeByBurntAmount(mdReader.UnifiedTokenID, mdReader.TokenID, mdReader.BurntAmount)
+	result, err := httpServer.blockService.BridgeAggEstimateFeeByBurntAmount(mdReader.UnifiedTokenID, mdReader.TokenID, mdReader.BurntAmount, mdReader.AtHeight)
 	if err != nil {
 		return nil, rpcservice.NewRPCError(rpcservice.BridgeAggEstimateFeeByBurntAmountError, err)
 	}
@@ -787,6 +788,7 @@ func (httpServer *HttpServer) handleEstimateFeeByExpectedAmount(params interface
 		UnifiedTokenID common.Hash `json:"UnifiedTokenID"`
 		TokenID        common.Hash `json:"TokenID"`
 		ExpectedAmount uint64      `json:"ExpectedAmount"`
+		AtHeight       uint64      `json:"AtHeight"`
 	}{}
 	rawMd, err := json.Marshal(arrayParams[0])
 	if err != nil {
@@ -796,7 +798,7 @@ func (httpServer *HttpServer) handleEstimateFeeByExpectedAmount(params interface
 	if err != nil {
 		return nil, rpcservice.NewRPCError(rpcservice.BridgeAggEstimateFeeByExpectedAmountError, err)
 	}
-	result, err := httpServer.blockService.BridgeAggEstimateFeeByExpectedAmount(mdReader.UnifiedTokenID, mdReader.TokenID, mdReader.ExpectedAmount)
+	result, err := httpServer.blockService.BridgeAggEstimateFeeByExpectedAmount(mdReader.UnifiedTokenID, mdReader.TokenID, mdReader.ExpectedAmount, mdReader.AtHeight)
 	if err != nil {
 		return nil, rpcservice.NewRPCError(rpcservice.BridgeAggEstimateFeeByExpectedAmountError, err)
 	}
@@ -814,6 +816,7 @@ func (httpServer *HttpServer) handleBridgeAggEstimateReward(params interface{},
 		UnifiedTokenID common.Hash `json:"UnifiedTokenID"`
 		TokenID        common.Hash `json:"TokenID"`
 		Amount         uint64      `json:"Amount"`
+		AtHeight       uint64      `json:"AtHeight"`
 	}{}
 	rawMd, err := json.Marshal(arrayParams[0])
 	if err != nil {
@@ -823,7 +826,7 @@ func (httpServer *HttpServer) handleBridgeAggEstimateReward(params interface{},
 	if err != nil {
 		return nil, rpcservice.NewRPCError(rpcservice.BridgeAggEstimateRewardError, err)
 	}
-	result, err := httpServer.blockService.BridgeAggEstimateReward(mdReader.UnifiedTokenID, mdReader.TokenID, mdReader.Amount)
+	result, err := httpServer.blockService.BridgeAggEstimateReward(mdReader.UnifiedTokenID, mdReader.TokenID, mdReader.Amount, mdReader.AtHeight)
 	if err != nil {
 		return nil, rpcservice.NewRPCError(rpcservice.BridgeAggEstimateRewardError, err)
 	}
@@ -0,0 +1,35 @@
+package rpcserver
+
+import (
+	"errors"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/rpcserver/rpcservice"
+)
+
+// handleGetDelegationPools returns the delegation pools of the beacon best state,
+// params: optional committee public key to get only the pool of this key
+func (httpServer *HttpServer) handleGetDelegationPools(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	stateDB := httpServer.config.BlockChain.GetBeaconBestState().GetBeaconConsensusStateDB()
+	if len(arrayParams) > 0 {
+		committeePublicKey, ok := arrayParams[0].(string)
+		if !ok {
+			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("committee public key is invalid"))
+		}
+		pool, has, err := statedb.GetDelegationPool(stateDB, committeePublicKey)
+		if err != nil {
+			return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
+		}
+		if !has {
+			return []*statedb.DelegationPoolState{}, nil
+		}
+		return []*statedb.DelegationPoolState{pool}, nil
+	}
+	pools, err := statedb.GetAllDelegationPools(stateDB)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
+	}
+	return pools, nil
+}
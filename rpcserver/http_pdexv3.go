@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"math/big"
 
+	lru "github.com/hashicorp/golang-lru"
+
 	"github.com/incognitochain/incognito-chain/blockchain/pdex"
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/common/base58"
@@ -29,15 +31,15 @@ func (httpServer *HttpServer) handleGetPdexv3State(params interface{}, closeChan
 	if !ok {
 		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Payload data is invalid"))
 	}
-	beaconHeight, ok := data["BeaconHeight"].(float64)
-	if !ok {
-		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Beacon height is invalid"))
+	beaconHeight, err := getAtHeightParam(data)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
 	}
 	filter, ok := data["Filter"].(map[string]interface{})
 	if !ok {
 		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Filter is invalid"))
 	}
-	result, err := httpServer.blockService.GetPdexv3State(filter, uint64(beaconHeight))
+	result, err := httpServer.blockService.GetPdexv3State(filter, beaconHeight)
 	if err != nil {
 		return nil, rpcservice.NewRPCError(rpcservice.GetPdexv3StateError, err)
 	}
@@ -310,8 +312,12 @@ func (httpServer *HttpServer) handleGetPdexv3EstimatedLPValue(params interface{}
 		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
 	}
 	beaconBestView := httpServer.config.BlockChain.GetBeaconBestState()
-	beaconHeight, ok := data["BeaconHeight"].(float64)
-	if !ok || beaconHeight == 0 {
+	atHeight, err := getAtHeightParam(data)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	}
+	beaconHeight := float64(atHeight)
+	if beaconHeight == 0 {
 		beaconHeight = float64(beaconBestView.BeaconHeight)
 	}
 
@@ -429,8 +435,12 @@ func (httpServer *HttpServer) handleGetPdexv3EstimatedLPPoolReward(params interf
 		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("PairID is invalid"))
 	}
 	beaconBestView := httpServer.config.BlockChain.GetBeaconBestState()
-	beaconHeight, ok := data["BeaconHeight"].(float64)
-	if !ok || beaconHeight == 0 {
+	atHeight, err := getAtHeightParam(data)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	}
+	beaconHeight := float64(atHeight)
+	if beaconHeight == 0 {
 		beaconHeight = float64(beaconBestView.BeaconHeight)
 	}
 
@@ -1328,6 +1338,55 @@ func (httpServer *HttpServer) handlePdexv3GetWithdrawOrderStatus(params interfac
 	return res, nil
 }
 
+func (httpServer *HttpServer) handleGetPdexv3BestTradePath(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	reader := &struct {
+		TokenToSell   common.Hash
+		TokenToBuy    common.Hash
+		SellAmount    Uint64Reader
+		MaxPathLength int
+		FeeInPRV      bool
+	}{}
+	if err := readParamsObject(params, reader); err != nil {
+		return nil, err
+	}
+	pairs, pdexParams, beaconHeight, err := httpServer.getPdexv3BestPoolPairs()
+	if err != nil {
+		return nil, err
+	}
+	result, e := pdex.FindBestTradePath(
+		reader.TokenToSell, reader.TokenToBuy, uint64(reader.SellAmount),
+		reader.MaxPathLength, reader.FeeInPRV, pairs, pdexParams, beaconHeight+1,
+	)
+	if e != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.SimulatePdexv3TradeError, e)
+	}
+	return result, nil
+}
+
+func (httpServer *HttpServer) handleSimulatePdexv3Trade(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	reader := &struct {
+		TokenToSell common.Hash
+		TradePath   []string
+		SellAmount  Uint64Reader
+		FeeInPRV    bool
+	}{}
+	if err := readParamsObject(params, reader); err != nil {
+		return nil, err
+	}
+	pairs, pdexParams, beaconHeight, err := httpServer.getPdexv3BestPoolPairs()
+	if err != nil {
+		return nil, err
+	}
+	result, e := pdex.SimulateTrade(
+		reader.TokenToSell, reader.TradePath, uint64(reader.SellAmount),
+		reader.FeeInPRV, pairs, pdexParams, beaconHeight+1,
+	)
+	if e != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.SimulatePdexv3TradeError, e)
+	}
+	return result, nil
+}
+
 // --- Helpers ---
 
 func createPdexv3TradeRequestTransaction(
@@ -1444,6 +1503,8 @@ func createPdexv3AddOrderRequestTransaction(
 		SellAmount          Uint64Reader
 		MinAcceptableAmount Uint64Reader
 		NftID               common.Hash
+		ExpiryHeight        Uint64Reader
+		TimeInForce         byte
 	}{}
 
 	// parse params & metadata
@@ -1461,6 +1522,8 @@ func createPdexv3AddOrderRequestTransaction(
 		uint64(mdReader.MinAcceptableAmount), nil,
 		mdReader.NftID, metadataCommon.Pdexv3AddOrderRequestMeta,
 	)
+	md.ExpiryHeight = uint64(mdReader.ExpiryHeight)
+	md.TimeInForce = mdReader.TimeInForce
 
 	// set token ID & metadata to paramSelect struct. Generate new OTAReceivers from private key
 	paramSelect.SetTokenID(md.TokenToSell)
@@ -1950,8 +2013,12 @@ func (httpServer *HttpServer) handleGetPdexv3EstimatedStakingReward(params inter
 		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
 	}
 	beaconBestView := httpServer.config.BlockChain.GetBeaconBestState()
-	beaconHeight, ok := data["BeaconHeight"].(float64)
-	if !ok || beaconHeight == 0 {
+	atHeight, err := getAtHeightParam(data)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	}
+	beaconHeight := float64(atHeight)
+	if beaconHeight == 0 {
 		beaconHeight = float64(beaconBestView.BeaconHeight)
 	}
 
@@ -2012,8 +2079,12 @@ func (httpServer *HttpServer) handleGetPdexv3EstimatedStakingPoolReward(params i
 	}
 
 	beaconBestView := httpServer.config.BlockChain.GetBeaconBestState()
-	beaconHeight, ok := data["BeaconHeight"].(float64)
-	if !ok || beaconHeight == 0 {
+	atHeight, err := getAtHeightParam(data)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	}
+	beaconHeight := float64(atHeight)
+	if beaconHeight == 0 {
 		beaconHeight = float64(beaconBestView.BeaconHeight)
 	}
 
@@ -2198,3 +2269,37 @@ func (httpServer *HttpServer) handleGetPdexv3WithdrawalStakingRewardStatus(param
 	}
 	return status, nil
 }
+
+// pdexv3PoolPairsCache keeps the pool pairs decoded from the pDEX v3 state of the latest beacon best views,
+// by beacon block hash, so that trade simulations do not unmarshal every pool for each request.
+// Cached pairs are shared between requests and must not be modified
+var pdexv3PoolPairsCache, _ = lru.New(4)
+
+type pdexv3PoolPairs struct {
+	poolPairs map[string]*pdex.PoolPairState
+	params    *pdex.Params
+}
+
+// getPdexv3BestPoolPairs returns the pool pairs & params of the pDEX v3 state of the beacon best view, with its height
+func (httpServer *HttpServer) getPdexv3BestPoolPairs() (map[string]*pdex.PoolPairState, *pdex.Params, uint64, *rpcservice.RPCError) {
+	beaconBestView := httpServer.config.BlockChain.GetBeaconBestState()
+	if beaconBestView.BeaconHeight < config.Param().PDexParams.Pdexv3BreakPointHeight {
+		return nil, nil, 0, rpcservice.NewRPCError(rpcservice.SimulatePdexv3TradeError, errors.New("pDEX v3 is not available"))
+	}
+	if value, exists := pdexv3PoolPairsCache.Get(beaconBestView.BestBlockHash); exists {
+		cached := value.(*pdexv3PoolPairs)
+		return cached.poolPairs, cached.params, beaconBestView.BeaconHeight, nil
+	}
+	pdexState := beaconBestView.PdeState(pdex.AmplifierVersion)
+	if pdexState == nil {
+		return nil, nil, 0, rpcservice.NewRPCError(rpcservice.GetPdexv3StateError, errors.New("pDEX v3 state is not found"))
+	}
+	poolPairs := make(map[string]*pdex.PoolPairState)
+	err := json.Unmarshal(pdexState.Reader().PoolPairs(), &poolPairs)
+	if err != nil {
+		return nil, nil, 0, rpcservice.NewRPCError(rpcservice.GetPdexv3StateError, err)
+	}
+	params := pdexState.Reader().Params().Clone()
+	pdexv3PoolPairsCache.Add(beaconBestView.BestBlockHash, &pdexv3PoolPairs{poolPairs: poolPairs, params: params})
+	return poolPairs, params, beaconBestView.BeaconHeight, nil
+}
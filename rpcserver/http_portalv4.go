@@ -822,3 +822,75 @@ func (httpServer *HttpServer) handleGenerateShieldingMultisigAddress(
 
 	return shieldingAddress, nil
 }
+
+/*
+===== Portal tokens
+*/
+func (httpServer *HttpServer) handleGetPortalV4Tokens(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	// parse params
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) < 1 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Param array must be at least one element"))
+	}
+	data, ok := arrayParams[0].(map[string]interface{})
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Payload data is invalid"))
+	}
+	beaconHeight, err := common.AssertAndConvertStrToNumber(data["BeaconHeight"])
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	}
+
+	// portal tokens are left out of getportalv4params, return the supported tokens with their remote chains
+	portalParamV4 := httpServer.config.BlockChain.GetPortalParamsV4(beaconHeight)
+	result := []jsonresult.PortalV4TokenResult{}
+	for _, tokenID := range portalParamV4.PortalV4TokenIDs {
+		portalToken, ok := portalParamV4.PortalTokens[tokenID]
+		if !ok {
+			return nil, rpcservice.NewRPCError(rpcservice.GetPortalV4ParamsError, fmt.Errorf("Portal token %v has no processor", tokenID))
+		}
+		result = append(result, jsonresult.PortalV4TokenResult{
+			TokenID:                tokenID,
+			ChainID:                portalToken.GetChainID(),
+			MinTokenAmount:         portalToken.GetMinTokenAmount(),
+			MultipleTokenAmount:    portalToken.GetMultipleTokenAmount(),
+			GeneralMultiSigAddress: portalParamV4.GeneralMultiSigAddresses[tokenID],
+		})
+	}
+	return result, nil
+}
+
+func (httpServer *HttpServer) handleValidatePortalRemoteAddress(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	// parse params
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) < 1 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Param array must be at least one element"))
+	}
+	data, ok := arrayParams[0].(map[string]interface{})
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Payload data is invalid"))
+	}
+	tokenID, ok := data["TokenID"].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("TokenID is invalid"))
+	}
+	remoteAddress, ok := data["RemoteAddress"].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("RemoteAddress is invalid"))
+	}
+
+	// get portal params with the latest beacon height
+	latestBeaconHeight := httpServer.config.BlockChain.GetBeaconBestState().BeaconHeight
+	portalParamV4 := httpServer.config.BlockChain.GetPortalParamsV4(latestBeaconHeight)
+	if !portalParamV4.IsPortalToken(tokenID) {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError,
+			errors.New("TokenID is not a portal token"))
+	}
+
+	// the address is checked against the params of the relaying chain of the token
+	isValid, err := portalParamV4.PortalTokens[tokenID].IsValidRemoteAddress(remoteAddress, httpServer.config.BlockChain)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.ValidatePortalV4RemoteAddressError, err)
+	}
+	return isValid, nil
+}
@@ -0,0 +1,157 @@
+package rpcserver
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/config"
+	"github.com/incognitochain/incognito-chain/multiview"
+	"github.com/incognitochain/incognito-chain/portal"
+	btcrelaying "github.com/incognitochain/incognito-chain/relaying/btc"
+	ltcrelaying "github.com/incognitochain/incognito-chain/relaying/ltc"
+	"github.com/incognitochain/incognito-chain/rpcserver/jsonresult"
+	"github.com/stretchr/testify/assert"
+)
+
+// newPortalV4TestServer uses the testnet portal params, the latest beacon height is beaconHeight
+func newPortalV4TestServer(t *testing.T, beaconHeight uint64, ltcChain *btcrelaying.BlockChain) *HttpServer {
+	config.AbortConfig()
+	config.Config().IsTestNet = true
+	config.Config().TestNetVersion = config.TestNetVersion1Number
+	portal.SetupParam()
+	btcrelaying.Logger.Init(common.NewBackend(nil).Logger("test", true))
+
+	bc := blockchain.NewBlockChain(&blockchain.Config{}, false)
+	bc.GetConfig().LTCChain = ltcChain
+	beaconView := blockchain.NewBeaconBestState()
+	beaconView.BeaconHeight = beaconHeight
+	beaconMultiView := multiview.NewBeaconMultiView()
+	beaconMultiView.AddView(beaconView)
+	bc.BeaconChain = blockchain.NewBeaconChain(beaconMultiView, nil, bc, common.BeaconChainKey)
+
+	return &HttpServer{config: RpcServerConfig{BlockChain: bc}}
+}
+
+func TestHandleGetPortalV4Tokens(t *testing.T) {
+	server := newPortalV4TestServer(t, 1, nil)
+
+	tests := []struct {
+		name         string
+		beaconHeight uint64
+		want         []jsonresult.PortalV4TokenResult
+	}{
+		{
+			name:         "before pLTC feature height",
+			beaconHeight: portal.TestnetPortalV4LTCBeaconHeight - 1,
+			want: []jsonresult.PortalV4TokenResult{
+				{
+					TokenID:                portal.TestnetPortalV4BTCID,
+					ChainID:                portal.TestnetBTCChainID,
+					MinTokenAmount:         10,
+					MultipleTokenAmount:    10,
+					GeneralMultiSigAddress: "tb1qjjy5aqpf86979y6jdkvy8nwh2z3r3qtt7tr9ux0wj4lk8vydffnq7azu84",
+				},
+			},
+		},
+		{
+			name:         "from pLTC feature height",
+			beaconHeight: portal.TestnetPortalV4LTCBeaconHeight,
+			want: []jsonresult.PortalV4TokenResult{
+				{
+					TokenID:                portal.TestnetPortalV4BTCID,
+					ChainID:                portal.TestnetBTCChainID,
+					MinTokenAmount:         10,
+					MultipleTokenAmount:    10,
+					GeneralMultiSigAddress: "tb1qjjy5aqpf86979y6jdkvy8nwh2z3r3qtt7tr9ux0wj4lk8vydffnq7azu84",
+				},
+				{
+					TokenID:                portal.TestnetPortalV4LTCID,
+					ChainID:                portal.TestnetLTCChainID,
+					MinTokenAmount:         10,
+					MultipleTokenAmount:    10,
+					GeneralMultiSigAddress: "tltc1qjjy5aqpf86979y6jdkvy8nwh2z3r3qtt7tr9ux0wj4lk8vydffnqp77ac2",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := []interface{}{map[string]interface{}{"BeaconHeight": strconv.FormatUint(tt.beaconHeight, 10)}}
+			got, rpcErr := server.handleGetPortalV4Tokens(params, nil)
+			if rpcErr != nil {
+				t.Fatal(rpcErr)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestHandleValidatePortalRemoteAddress(t *testing.T) {
+	dir, err := ioutil.TempDir(os.TempDir(), "rpc_portalv4_")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	ltcChain, err := ltcrelaying.GetChainV2(filepath.Join(dir, "ltc"), ltcrelaying.GetTestNet4Params(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ltcChain.GetDB().Close()
+
+	tests := []struct {
+		name         string
+		beaconHeight uint64
+		ltcChain     *btcrelaying.BlockChain
+		address      string
+		want         bool
+		wantErr      bool
+	}{
+		{
+			name:         "ltc testnet address",
+			beaconHeight: portal.TestnetPortalV4LTCBeaconHeight,
+			ltcChain:     ltcChain,
+			address:      "tltc1qjjy5aqpf86979y6jdkvy8nwh2z3r3qtt7tr9ux0wj4lk8vydffnqp77ac2",
+			want:         true,
+		},
+		{
+			name:         "btc testnet address",
+			beaconHeight: portal.TestnetPortalV4LTCBeaconHeight,
+			ltcChain:     ltcChain,
+			address:      "tb1qjjy5aqpf86979y6jdkvy8nwh2z3r3qtt7tr9ux0wj4lk8vydffnq7azu84",
+			want:         false,
+		},
+		{
+			name:         "no ltc relaying chain",
+			beaconHeight: portal.TestnetPortalV4LTCBeaconHeight,
+			address:      "tltc1qjjy5aqpf86979y6jdkvy8nwh2z3r3qtt7tr9ux0wj4lk8vydffnqp77ac2",
+			want:         false,
+		},
+		{
+			name:         "before pLTC feature height",
+			beaconHeight: portal.TestnetPortalV4LTCBeaconHeight - 1,
+			ltcChain:     ltcChain,
+			address:      "tltc1qjjy5aqpf86979y6jdkvy8nwh2z3r3qtt7tr9ux0wj4lk8vydffnqp77ac2",
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newPortalV4TestServer(t, tt.beaconHeight, tt.ltcChain)
+			params := []interface{}{map[string]interface{}{"TokenID": portal.TestnetPortalV4LTCID, "RemoteAddress": tt.address}}
+			got, rpcErr := server.handleValidatePortalRemoteAddress(params, nil)
+			if tt.wantErr {
+				assert.NotNil(t, rpcErr)
+				return
+			}
+			if rpcErr != nil {
+				t.Fatal(rpcErr)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/config"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
 	"github.com/incognitochain/incognito-chain/pruner"
 	"github.com/incognitochain/incognito-chain/rpcserver/rpcservice"
 )
@@ -22,6 +23,7 @@ func (httpServer *HttpServer) handlePrune(params interface{}, closeChan <-chan s
 	}
 	type Temp struct {
 		Config map[byte]pruner.Config `json:"Config"`
+		Beacon *pruner.Config         `json:"Beacon"`
 	}
 	t := Temp{}
 	b, err := json.Marshal(arrayParams[0])
@@ -38,6 +40,9 @@ func (httpServer *HttpServer) handlePrune(params interface{}, closeChan <-chan s
 		}
 		httpServer.Pruner.JobRquest[int(shardID)] = &pruner.Config{ShouldPruneByHash: c.ShouldPruneByHash}
 	}
+	if t.Beacon != nil {
+		httpServer.Pruner.JobRquest[common.BeaconChainID] = &pruner.Config{ShouldPruneByHash: t.Beacon.ShouldPruneByHash}
+	}
 	type Result struct {
 		Message string `json:"Message"`
 	}
@@ -51,18 +56,40 @@ func (httpServer *HttpServer) getPruneState(params interface{}, closeChan <-chan
 
 func (httpServer *HttpServer) checkPruneData(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
 	res := map[int]bool{}
+	lock := sync.Mutex{}
 	wg := sync.WaitGroup{}
+	recheck := func(cid int, stateDBs ...*statedb.StateDB) {
+		ok := true
+		for _, stateDB := range stateDBs {
+			if err := stateDB.Recheck(); err != nil {
+				ok = false
+				break
+			}
+		}
+		lock.Lock()
+		res[cid] = ok
+		lock.Unlock()
+		wg.Done()
+	}
 	for i := 0; i < common.MaxShardNumber; i++ {
+		shardBestState := httpServer.GetBlockchain().GetBestStateShard(byte(i))
 		wg.Add(1)
-		go func(sid int) {
-			if err := httpServer.GetBlockchain().GetBestStateShard(byte(sid)).GetCopiedTransactionStateDB().Recheck(); err != nil {
-				res[sid] = false
-			} else {
-				res[sid] = true
-			}
-			wg.Done()
-		}(i)
+		go recheck(i,
+			shardBestState.GetCopiedConsensusStateDB(),
+			shardBestState.GetCopiedTransactionStateDB(),
+			shardBestState.GetCopiedFeatureStateDB(),
+			shardBestState.GetShardRewardStateDB(),
+			shardBestState.GetCopiedSlashStateDB(),
+		)
 	}
+	beaconBestState := httpServer.GetBlockchain().GetBeaconBestState()
+	wg.Add(1)
+	go recheck(common.BeaconChainID,
+		beaconBestState.GetBeaconConsensusStateDB(),
+		beaconBestState.GetBeaconFeatureStateDB(),
+		beaconBestState.GetBeaconRewardStateDB(),
+		beaconBestState.GetBeaconSlashStateDB(),
+	)
 	wg.Wait()
 	fmt.Println("checkPruneData", res)
 	return res, nil
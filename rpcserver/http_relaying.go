@@ -25,6 +25,14 @@ func (httpServer *HttpServer) handleCreateRawTxWithRelayingBTCHeader(params inte
 	)
 }
 
+func (httpServer *HttpServer) handleCreateRawTxWithRelayingLTCHeader(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	return httpServer.handleCreateRawTxWithRelayingHeader(
+		metadata.RelayingLTCHeaderMeta,
+		params,
+		closeChan,
+	)
+}
+
 func (httpServer *HttpServer) handleCreateRawTxWithRelayingBNBHeader(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
 	return httpServer.handleCreateRawTxWithRelayingHeader(
 		metadata.RelayingBNBHeaderMeta,
@@ -130,9 +138,26 @@ func (httpServer *HttpServer) handleCreateAndSendTxWithRelayingBTCHeader(params
 	return result, nil
 }
 
+func (httpServer *HttpServer) handleCreateAndSendTxWithRelayingLTCHeader(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	data, err := httpServer.handleCreateRawTxWithRelayingLTCHeader(params, closeChan)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
+	}
+	tx := data.(jsonresult.CreateTransactionResult)
+	base58CheckData := tx.Base58CheckData
+	newParam := make([]interface{}, 0)
+	newParam = append(newParam, base58CheckData)
+	sendResult, err := httpServer.handleSendRawTransaction(newParam, closeChan)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
+	}
+	result := jsonresult.NewCreateTransactionResult(nil, sendResult.(jsonresult.CreateTransactionResult).TxID, nil, sendResult.(jsonresult.CreateTransactionResult).ShardID)
+	return result, nil
+}
+
 func (httpServer *HttpServer) handleGetRelayingBNBHeaderState(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
 	bc := httpServer.config.BlockChain
-	relayingState, err := portalrelaying.InitRelayingHeaderChainStateFromDB(bc.GetBNBHeaderChain(), bc.GetBTCHeaderChain())
+	relayingState, err := portalrelaying.InitRelayingHeaderChainStateFromDB(bc.GetBNBHeaderChain(), bc.GetBTCHeaderChain(), bc.GetLTCHeaderChain())
 	if err != nil {
 		return nil, rpcservice.NewRPCError(rpcservice.GetRelayingBNBHeaderError, err)
 	}
@@ -219,3 +244,42 @@ func (httpServer *HttpServer) handleGetBTCBlockByHash(params interface{}, closeC
 	}
 	return btcBlock.MsgBlock(), nil
 }
+
+func (httpServer *HttpServer) handleGetLTCRelayingBestState(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	bc := httpServer.config.BlockChain
+	ltcChain := bc.GetConfig().LTCChain
+	if ltcChain == nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetLTCRelayingBestState, errors.New("LTC relaying chain should not be null"))
+	}
+	bestState := ltcChain.BestSnapshot()
+	return bestState, nil
+}
+
+func (httpServer *HttpServer) handleGetLTCBlockByHash(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	bc := httpServer.config.BlockChain
+	ltcChain := bc.GetConfig().LTCChain
+	if ltcChain == nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetLTCBlockByHash, errors.New("LTC relaying chain should not be null"))
+	}
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) < 1 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Param array must be at least 1"))
+	}
+
+	// get meta data from params
+	ltcBlockHashStr, ok := arrayParams[0].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("LTC block hash param is invalid"))
+	}
+
+	blkHash, err := chainhash.NewHashFromStr(ltcBlockHashStr)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetLTCBlockByHash, err)
+	}
+
+	ltcBlock, err := ltcChain.BlockByHash(blkHash)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetLTCBlockByHash, err)
+	}
+	return ltcBlock.MsgBlock(), nil
+}
@@ -0,0 +1,124 @@
+package rpcserver
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/common/base58"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/rpcserver/rpcservice"
+)
+
+// handleGetTokenStateProof - Get the token info of a shard with its Merkle proof
+func (httpServer *HttpServer) handleGetTokenStateProof(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	reader := &struct {
+		ShardID   byte
+		TokenID   common.Hash
+		BlockHash common.Hash
+	}{}
+	if err := readParamsObject(params, reader); err != nil {
+		return nil, err
+	}
+	result, err := httpServer.blockService.GetShardStateProof(
+		reader.ShardID, reader.BlockHash, rpcservice.TransactionStateDB, statedb.GenerateTokenObjectKey(reader.TokenID),
+	)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetStateProofError, err)
+	}
+	return result, nil
+}
+
+// handleGetRewardAmountProof - Get the committee reward balances of a payment address with their Merkle proof
+func (httpServer *HttpServer) handleGetRewardAmountProof(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	reader := &struct {
+		PaymentAddress string
+		BlockHash      common.Hash
+	}{}
+	if err := readParamsObject(params, reader); err != nil {
+		return nil, err
+	}
+	keySet, _, err := rpcservice.GetKeySetFromPaymentAddressParam(reader.PaymentAddress)
+	if err != nil || len(keySet.PaymentAddress.Pk) == 0 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("payment address is invalid"))
+	}
+	publicKey := keySet.PaymentAddress.Pk
+	key, err := statedb.GenerateCommitteeRewardObjectKey(base58.Base58Check{}.Encode(publicKey, common.Base58Version))
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	}
+	shardID := common.GetShardIDFromLastByte(publicKey[len(publicKey)-1])
+	result, err := httpServer.blockService.GetShardStateProof(shardID, reader.BlockHash, rpcservice.RewardStateDB, key)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetStateProofError, err)
+	}
+	return result, nil
+}
+
+// handleGetBridgeTokenProof - Get the info of a bridge token with its Merkle proof
+func (httpServer *HttpServer) handleGetBridgeTokenProof(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	reader := &struct {
+		TokenID       common.Hash
+		IsCentralized bool
+		BlockHash     common.Hash
+	}{}
+	if err := readParamsObject(params, reader); err != nil {
+		return nil, err
+	}
+	result, err := httpServer.blockService.GetBeaconStateProof(
+		reader.BlockHash, rpcservice.FeatureStateDB,
+		statedb.GenerateBridgeTokenInfoObjectKey(reader.IsCentralized, reader.TokenID),
+	)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetStateProofError, err)
+	}
+	return result, nil
+}
+
+// handleGetPdexv3PoolPairProof - Get the state of a pDEX v3 pool pair (without its shares & orders) with its Merkle proof
+func (httpServer *HttpServer) handleGetPdexv3PoolPairProof(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	reader := &struct {
+		PoolPairID string
+		BlockHash  common.Hash
+	}{}
+	if err := readParamsObject(params, reader); err != nil {
+		return nil, err
+	}
+	result, err := httpServer.blockService.GetBeaconStateProof(
+		reader.BlockHash, rpcservice.FeatureStateDB, statedb.GeneratePdexv3PoolPairObjectKey(reader.PoolPairID),
+	)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetStateProofError, err)
+	}
+	return result, nil
+}
+
+// handleGetCommitteeStateProof - Get the committee entry of a validator key in a role with its Merkle proof
+func (httpServer *HttpServer) handleGetCommitteeStateProof(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	reader := &struct {
+		Role               int
+		ShardID            int
+		CommitteePublicKey string
+		BlockHash          common.Hash
+	}{}
+	if err := readParamsObject(params, reader); err != nil {
+		return nil, err
+	}
+	if reader.Role < statedb.NextEpochShardCandidate || reader.Role > statedb.CurrentValidator {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, fmt.Errorf("Invalid committee role %d", reader.Role))
+	}
+	committees, err := incognitokey.CommitteeBase58KeyListToStruct([]string{reader.CommitteePublicKey})
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	}
+	key, err := statedb.GenerateCommitteeObjectKeyWithRole(reader.Role, reader.ShardID, committees[0])
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	}
+	result, err := httpServer.blockService.GetBeaconStateProof(reader.BlockHash, rpcservice.ConsensusStateDB, key)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetStateProofError, err)
+	}
+	return result, nil
+}
@@ -0,0 +1,363 @@
+package rpcserver
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/blockchain/types"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/common/base58"
+	"github.com/incognitochain/incognito-chain/config"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/incdb"
+	_ "github.com/incognitochain/incognito-chain/incdb/lvdb"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/multiview"
+	"github.com/incognitochain/incognito-chain/rpcserver/jsonresult"
+	"github.com/incognitochain/incognito-chain/rpcserver/rpcservice"
+	"github.com/incognitochain/incognito-chain/trie"
+	"github.com/incognitochain/incognito-chain/wallet"
+)
+
+type stateProofTestData struct {
+	tokenID        common.Hash
+	poolPairID     string
+	paymentAddress string
+	committee      string
+	blockHash      map[int]common.Hash // hash of the proven block by chain ID
+	finalBlockHash map[int]common.Hash
+}
+
+// commitTestState writes the state db & returns its root
+func commitTestState(t *testing.T, db incdb.Database, store func(stateDB *statedb.StateDB) error) common.Hash {
+	stateDB, err := statedb.NewWithPrefixTrie(common.EmptyRoot, statedb.NewDatabaseAccessWarper(db))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store(stateDB); err != nil {
+		t.Fatal(err)
+	}
+	root, err := stateDB.Commit(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := stateDB.Database().TrieDB().Commit(root, false); err != nil {
+		t.Fatal(err)
+	}
+	return root
+}
+
+// newStateProofTestServer stores the blocks at height 2 & 3 of shard 0 & of the beacon, with the state of block 2.
+// Block 3 is finalized and commits the state roots of block 2 unless committed is false
+func newStateProofTestServer(t *testing.T, dir string, committed bool) (*HttpServer, *stateProofTestData) {
+	config.AbortParam()
+	config.Param().ActiveShards = 1
+	common.MaxShardNumber = 1
+
+	data := &stateProofTestData{
+		tokenID:        common.HashH([]byte("token")),
+		poolPairID:     "pool-pair",
+		blockHash:      make(map[int]common.Hash),
+		finalBlockHash: make(map[int]common.Hash),
+	}
+	key, err := wallet.NewMasterKey([]byte("state proof test seed"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	data.paymentAddress = key.Base58CheckSerialize(wallet.PaymentAddressType)
+	publicKey := key.KeySet.PaymentAddress.Pk
+	committeeKey, err := incognitokey.NewCommitteeKeyFromSeed([]byte("state proof test seed"), publicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data.committee, err = committeeKey.ToBase58()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dbs := make(map[int]incdb.Database)
+	for _, chainID := range []int{common.BeaconChainID, 0} {
+		db, err := incdb.Open("leveldb", filepath.Join(dir, strconv.Itoa(chainID)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		dbs[chainID] = db
+	}
+
+	shardDB := dbs[0]
+	shardRoots := blockchain.ShardRootHash{
+		ConsensusStateDBRootHash: common.EmptyRoot,
+		TransactionStateDBRootHash: commitTestState(t, shardDB, func(stateDB *statedb.StateDB) error {
+			return statedb.StorePrivacyToken(stateDB, data.tokenID, "token", "TKN", statedb.InitToken, false, 1000, []byte{}, common.Hash{})
+		}),
+		FeatureStateDBRootHash: common.EmptyRoot,
+		RewardStateDBRootHash: commitTestState(t, shardDB, func(stateDB *statedb.StateDB) error {
+			return statedb.AddCommitteeReward(stateDB, base58.Base58Check{}.Encode(publicKey, common.Base58Version), 100, common.PRVCoinID)
+		}),
+		SlashStateDBRootHash: common.EmptyRoot,
+	}
+	shardBlock := types.NewShardBlock()
+	shardBlock.Header.Height = 2
+	shardBlock.Header.PreviousBlockHash = common.HashH([]byte("shard parent"))
+	shardBlock.ValidationData = "shard validation data"
+	shardBlock.Header.CommitteeRoot = common.HashH([]byte("shard committee"))
+	shardCommitBlock := types.NewShardBlock()
+	shardCommitBlock.Header.Height = 3
+	shardCommitBlock.Header.PreviousBlockHash = *shardBlock.Hash()
+	shardCommitBlock.ValidationData = "shard validation data"
+	shardCommitBlock.Header.CommitteeRoot = shardBlock.Header.CommitteeRoot
+	if committed {
+		shardCommitBlock.Header.ParentStateRootsHash = shardRoots.Hash()
+	}
+	if err := rawdbv2.StoreShardRootsHash(shardDB, 0, *shardBlock.Hash(), shardRoots); err != nil {
+		t.Fatal(err)
+	}
+	for _, blk := range []*types.ShardBlock{shardBlock, shardCommitBlock} {
+		if err := rawdbv2.StoreShardBlock(shardDB, *blk.Hash(), blk); err != nil {
+			t.Fatal(err)
+		}
+		if err := rawdbv2.StoreFinalizedShardBlockHashByIndex(shardDB, 0, blk.GetHeight(), *blk.Hash()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	data.blockHash[0] = *shardBlock.Hash()
+	data.finalBlockHash[0] = *shardCommitBlock.Hash()
+
+	beaconDB := dbs[common.BeaconChainID]
+	beaconRoots := blockchain.BeaconRootHash{
+		ConsensusStateDBRootHash: commitTestState(t, beaconDB, func(stateDB *statedb.StateDB) error {
+			return statedb.StoreBeaconCommittee(stateDB, []incognitokey.CommitteePublicKey{committeeKey})
+		}),
+		FeatureStateDBRootHash: commitTestState(t, beaconDB, func(stateDB *statedb.StateDB) error {
+			if err := statedb.UpdateBridgeTokenInfo(stateDB, data.tokenID, []byte("external"), false, 1000, "+"); err != nil {
+				return err
+			}
+			return statedb.StorePdexv3PoolPair(stateDB, data.poolPairID, *rawdbv2.NewPdexv3PoolPair())
+		}),
+		RewardStateDBRootHash: common.EmptyRoot,
+		SlashStateDBRootHash:  common.EmptyRoot,
+	}
+	beaconBlock := types.NewBeaconBlock()
+	beaconBlock.Header.Height = 2
+	beaconBlock.Header.PreviousBlockHash = common.HashH([]byte("beacon parent"))
+	beaconBlock.ValidationData = "beacon validation data"
+	beaconCommitBlock := types.NewBeaconBlock()
+	beaconCommitBlock.Header.Height = 3
+	beaconCommitBlock.Header.PreviousBlockHash = *beaconBlock.Hash()
+	beaconCommitBlock.ValidationData = "beacon validation data"
+	if committed {
+		beaconCommitBlock.Header.ParentStateRootsHash = beaconRoots.Hash()
+	}
+	if err := rawdbv2.StoreBeaconRootsHash(beaconDB, *beaconBlock.Hash(), beaconRoots); err != nil {
+		t.Fatal(err)
+	}
+	for _, blk := range []*types.BeaconBlock{beaconBlock, beaconCommitBlock} {
+		if err := rawdbv2.StoreBeaconBlockByHash(beaconDB, *blk.Hash(), blk); err != nil {
+			t.Fatal(err)
+		}
+		if err := rawdbv2.StoreFinalizedBeaconBlockHashByIndex(beaconDB, blk.GetHeight(), *blk.Hash()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	data.blockHash[common.BeaconChainID] = *beaconBlock.Hash()
+	data.finalBlockHash[common.BeaconChainID] = *beaconCommitBlock.Hash()
+
+	bc := blockchain.NewBlockChain(&blockchain.Config{}, false)
+	bc.GetConfig().DataBase = dbs
+	shardView := blockchain.NewShardBestState()
+	shardView.BestBlock = shardCommitBlock
+	shardView.BestBlockHash = *shardCommitBlock.Hash()
+	shardView.ShardHeight = 3
+	shardMultiView := multiview.NewShardMultiView()
+	shardMultiView.AddView(shardView)
+	bc.ShardChain = []*blockchain.ShardChain{blockchain.NewShardChain(0, shardMultiView, nil, bc, common.GetShardChainKey(0), nil, nil)}
+	beaconView := blockchain.NewBeaconBestState()
+	beaconView.BestBlock = *beaconCommitBlock
+	beaconView.BestBlockHash = *beaconCommitBlock.Hash()
+	beaconView.BeaconHeight = 3
+	beaconMultiView := multiview.NewBeaconMultiView()
+	beaconMultiView.AddView(beaconView)
+	bc.BeaconChain = blockchain.NewBeaconChain(beaconMultiView, nil, bc, common.BeaconChainKey)
+
+	return &HttpServer{blockService: &rpcservice.BlockService{BlockChain: bc}}, data
+}
+
+func closeStateProofTestServer(server *HttpServer) {
+	for _, db := range server.blockService.BlockChain.GetConfig().DataBase {
+		db.Close()
+	}
+}
+
+func TestHandleStateProof(t *testing.T) {
+	dir, err := ioutil.TempDir(os.TempDir(), "rpc_stateproof_")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	server, data := newStateProofTestServer(t, filepath.Join(dir, "committed"), true)
+	defer closeStateProofTestServer(server)
+	uncommittedServer, _ := newStateProofTestServer(t, filepath.Join(dir, "uncommitted"), false)
+	defer closeStateProofTestServer(uncommittedServer)
+
+	type handler func(server *HttpServer, params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError)
+	tests := []struct {
+		name        string
+		handler     handler
+		params      map[string]interface{}
+		chainID     int
+		stateDB     string
+		wantErr     string
+		uncommitted bool
+	}{
+		{
+			name:    "token of a shard",
+			handler: (*HttpServer).handleGetTokenStateProof,
+			params:  map[string]interface{}{"ShardID": 0, "TokenID": data.tokenID.String()},
+			chainID: 0,
+			stateDB: rpcservice.TransactionStateDB,
+		},
+		{
+			name:    "reward of a payment address",
+			handler: (*HttpServer).handleGetRewardAmountProof,
+			params:  map[string]interface{}{"PaymentAddress": data.paymentAddress},
+			chainID: 0,
+			stateDB: rpcservice.RewardStateDB,
+		},
+		{
+			name:    "bridge token",
+			handler: (*HttpServer).handleGetBridgeTokenProof,
+			params:  map[string]interface{}{"TokenID": data.tokenID.String(), "IsCentralized": false},
+			chainID: common.BeaconChainID,
+			stateDB: rpcservice.FeatureStateDB,
+		},
+		{
+			name:    "pdexv3 pool pair",
+			handler: (*HttpServer).handleGetPdexv3PoolPairProof,
+			params:  map[string]interface{}{"PoolPairID": data.poolPairID},
+			chainID: common.BeaconChainID,
+			stateDB: rpcservice.FeatureStateDB,
+		},
+		{
+			name:    "beacon committee",
+			handler: (*HttpServer).handleGetCommitteeStateProof,
+			params:  map[string]interface{}{"Role": statedb.CurrentValidator, "ShardID": statedb.BeaconChainID, "CommitteePublicKey": data.committee},
+			chainID: common.BeaconChainID,
+			stateDB: rpcservice.ConsensusStateDB,
+		},
+		{
+			name:    "block hash of the proven block",
+			handler: (*HttpServer).handleGetTokenStateProof,
+			params:  map[string]interface{}{"ShardID": 0, "TokenID": data.tokenID.String(), "BlockHash": data.blockHash[0].String()},
+			chainID: 0,
+			stateDB: rpcservice.TransactionStateDB,
+		},
+		{
+			name:    "final block is not committed yet",
+			handler: (*HttpServer).handleGetBridgeTokenProof,
+			params:  map[string]interface{}{"TokenID": data.tokenID.String(), "BlockHash": data.finalBlockHash[common.BeaconChainID].String()},
+			wantErr: "not committed by a finalized block yet",
+		},
+		{
+			name:        "shard roots not in the header",
+			handler:     (*HttpServer).handleGetTokenStateProof,
+			params:      map[string]interface{}{"ShardID": 0, "TokenID": data.tokenID.String()},
+			wantErr:     "are not committed in the header",
+			uncommitted: true,
+		},
+		{
+			name:        "beacon roots not in the header",
+			handler:     (*HttpServer).handleGetPdexv3PoolPairProof,
+			params:      map[string]interface{}{"PoolPairID": data.poolPairID},
+			wantErr:     "are not committed in the header",
+			uncommitted: true,
+		},
+		{
+			name:    "invalid shard",
+			handler: (*HttpServer).handleGetTokenStateProof,
+			params:  map[string]interface{}{"ShardID": 1, "TokenID": data.tokenID.String()},
+			wantErr: "Invalid shard ID",
+		},
+		{
+			name:    "invalid committee role",
+			handler: (*HttpServer).handleGetCommitteeStateProof,
+			params:  map[string]interface{}{"Role": statedb.CurrentValidator + 1, "ShardID": statedb.BeaconChainID, "CommitteePublicKey": data.committee},
+			wantErr: "Invalid committee role",
+		},
+		{
+			name:    "invalid payment address",
+			handler: (*HttpServer).handleGetRewardAmountProof,
+			params:  map[string]interface{}{"PaymentAddress": "invalid"},
+			wantErr: "payment address is invalid",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := server
+			if tt.uncommitted {
+				s = uncommittedServer
+			}
+			res, rpcErr := tt.handler(s, []interface{}{tt.params}, nil)
+			if tt.wantErr != "" {
+				if rpcErr == nil || !strings.Contains(rpcErr.Error(), tt.wantErr) {
+					t.Fatalf("Expect error %v, got %v", tt.wantErr, rpcErr)
+				}
+				return
+			}
+			if rpcErr != nil {
+				t.Fatal(rpcErr)
+			}
+			proof := res.(*jsonresult.StateProof)
+			if proof.StateDB != tt.stateDB || proof.BlockHash != data.blockHash[tt.chainID].String() {
+				t.Fatalf("Expect a proof of %v at block %v, got %v at %v", tt.stateDB, data.blockHash[tt.chainID].String(), proof.StateDB, proof.BlockHash)
+			}
+			if len(proof.Value) == 0 {
+				t.Fatal("Expect the value to be found")
+			}
+
+			// the value is proven against RootHash
+			root, err := common.Hash{}.NewHashFromStr(proof.RootHash)
+			if err != nil {
+				t.Fatal(err)
+			}
+			key, err := common.Hash{}.NewHashFromStr(proof.Key)
+			if err != nil {
+				t.Fatal(err)
+			}
+			value, err := statedb.VerifyProof(*root, *key, trie.ProofList(proof.Proof))
+			if err != nil || string(value) != string(proof.Value) {
+				t.Fatalf("Expect the proof to verify, got %v", err)
+			}
+
+			// RootHash is one of StateRoots, committed in the header of CommitBlock linking to Block
+			var rootsHash, parentStateRootsHash, previousBlockHash common.Hash
+			var roots []common.Hash
+			switch stateRoots := proof.StateRoots.(type) {
+			case *blockchain.ShardRootHash:
+				rootsHash = stateRoots.Hash()
+				roots = []common.Hash{stateRoots.TransactionStateDBRootHash, stateRoots.RewardStateDBRootHash}
+				header := proof.CommitBlock.Header.(types.ShardHeader)
+				parentStateRootsHash, previousBlockHash = header.ParentStateRootsHash, header.PreviousBlockHash
+			case *blockchain.BeaconRootHash:
+				rootsHash = stateRoots.Hash()
+				roots = []common.Hash{stateRoots.ConsensusStateDBRootHash, stateRoots.FeatureStateDBRootHash}
+				header := proof.CommitBlock.Header.(types.BeaconHeader)
+				parentStateRootsHash, previousBlockHash = header.ParentStateRootsHash, header.PreviousBlockHash
+			}
+			if *root != roots[0] && *root != roots[1] {
+				t.Fatalf("Expect RootHash %v in the state roots %v", proof.RootHash, roots)
+			}
+			if parentStateRootsHash != rootsHash || previousBlockHash.String() != proof.Block.Hash {
+				t.Fatalf("Expect the commit block to commit the state roots of block %v", proof.Block.Hash)
+			}
+			if proof.CommitBlock.Hash != data.finalBlockHash[tt.chainID].String() || proof.CommitBlock.ValidationData == "" {
+				t.Fatalf("Expect the signed final block as commit block, got %+v", proof.CommitBlock)
+			}
+		})
+	}
+}
@@ -15,8 +15,10 @@ import (
 	"sync/atomic"
 	"testing"
 
+	"github.com/gorilla/websocket"
 	"github.com/incognitochain/incognito-chain/blockchain"
 	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/config"
 	"github.com/incognitochain/incognito-chain/pubsub"
 	"github.com/incognitochain/incognito-chain/rpcserver/rpcservice"
 )
@@ -296,3 +298,197 @@ func TestHttpServerProcessRpcRequest(t *testing.T) {
 		t.Fatalf("Expect code %+v but get %+v", http.StatusInternalServerError, w.Code)
 	}
 }
+func TestHttpServerCheckAPIKeyAuth(t *testing.T) {
+	r := &http.Request{
+		Header: make(map[string][]string),
+	}
+	ResetHttpServer()
+	apiKeyConfig := *rpcConfig
+	apiKeyConfig.APIKeys = []config.RPCAPIKey{
+		{Name: "public", Key: "public@123", Methods: []string{getBlockChainInfo}},
+		{Name: "signer", Key: "signer@123", Methods: []string{"*"}},
+	}
+	httpServer.Init(&apiKeyConfig)
+	defer httpServer.Init(rpcConfig)
+	httpServer.config.DisableAuth = false
+	r.Header["Authorization"] = []string{"Basic " + base64.StdEncoding.EncodeToString([]byte("signer:signer@123"))}
+	if apiKey := httpServer.checkAPIKeyAuth(r); apiKey == nil || apiKey.name != "signer" {
+		t.Fatalf("Expect API key signer but get %+v", apiKey)
+	}
+	r.Header["Authorization"] = []string{"Basic " + base64.StdEncoding.EncodeToString([]byte("public:signer@123"))}
+	if apiKey := httpServer.checkAPIKeyAuth(r); apiKey != nil {
+		t.Fatalf("Expect no API key but get %+v", apiKey.name)
+	}
+	r.Header["Authorization"] = []string{"Basic " + base64.StdEncoding.EncodeToString([]byte(user+":"+pass))}
+	if apiKey := httpServer.checkAPIKeyAuth(r); apiKey != nil {
+		t.Fatalf("Expect no API key for rpc user but get %+v", apiKey.name)
+	}
+	if ok, isLimitUser, err := httpServer.checkAuth(r, true); !(err == nil && ok && !isLimitUser) {
+		t.Fatal("Expect rpc user to still pass auth", err, ok, isLimitUser)
+	}
+	httpServer.config.DisableAuth = true
+	r.Header["Authorization"] = []string{"Basic " + base64.StdEncoding.EncodeToString([]byte("signer:signer@123"))}
+	if apiKey := httpServer.checkAPIKeyAuth(r); apiKey != nil {
+		t.Fatalf("Expect no API key because disable auth but get %+v", apiKey.name)
+	}
+}
+func TestHttpServerCheckMethodPermission(t *testing.T) {
+	ResetHttpServer()
+	publicKey := newRPCAPIKey(config.RPCAPIKey{Name: "public", Key: "public@123", Methods: []string{getBlockChainInfo, listAccounts}})
+	signerKey := newRPCAPIKey(config.RPCAPIKey{Name: "signer", Key: "signer@123", Methods: []string{"*"}})
+	walletKey := newRPCAPIKey(config.RPCAPIKey{Name: "wallet", Key: "wallet@123", Methods: []string{"*", listAccounts}})
+	testCases := []struct {
+		method        string
+		isLimitedUser bool
+		apiKey        *rpcAPIKey
+		allowed       bool
+	}{
+		{getBlockChainInfo, false, publicKey, true},
+		{listAccounts, false, publicKey, true},
+		{createAndSendTransaction, false, publicKey, false},
+		{createAndSendTransaction, false, signerKey, true},
+		{listAccounts, false, signerKey, false},
+		{listAccounts, false, walletKey, true},
+		{createAndSendTransaction, false, walletKey, true},
+		{getBlockChainInfo, false, nil, true},
+		{listAccounts, false, nil, false},
+		{listAccounts, true, nil, true},
+	}
+	for _, tc := range testCases {
+		err := httpServer.checkMethodPermission(tc.method, tc.isLimitedUser, tc.apiKey)
+		if tc.allowed && err != nil {
+			t.Fatalf("Expect method %+v to be allowed but get %+v", tc.method, err)
+		}
+		if !tc.allowed && (err == nil || err.Code != rpcservice.ErrCodeMessage[rpcservice.RPCInvalidMethodPermissionError].Code) {
+			t.Fatalf("Expect method %+v to be denied but get %+v", tc.method, err)
+		}
+	}
+}
+func TestHttpServerAPIKeyCannotCallLimitedMethod(t *testing.T) {
+	ResetHttpServer()
+	r := &http.Request{
+		Method: "POST",
+		Header: header,
+	}
+	closeChan := make(chan struct{})
+	rawRequest := []byte(`{"jsonrpc": "2.0","method": "` + listAccounts + `","params": [],"id": 1}`)
+	for _, methods := range [][]string{{testHttpServer}, {"*"}} {
+		apiKey := newRPCAPIKey(config.RPCAPIKey{Name: "public", Key: "public@123", Methods: methods})
+		if canCallLimitedMethod(listAccounts, false, apiKey) {
+			t.Fatalf("Expect API key with methods %+v to not reach the limited handler", methods)
+		}
+		msg := httpServer.processBatchCall(r, rawRequest, false, false, apiKey, closeChan)
+		response := &JsonResponse{}
+		if err := json.Unmarshal(msg, response); err != nil {
+			t.Fatalf("Expect a JSON response but get %s", msg)
+		}
+		if response.Error == nil || response.Error.Code != rpcservice.ErrCodeMessage[rpcservice.RPCInvalidMethodPermissionError].Code {
+			t.Fatalf("Expect API key with methods %+v to be denied %+v but get %+v", methods, listAccounts, response.Error)
+		}
+	}
+	if !canCallLimitedMethod(listAccounts, true, nil) || canCallLimitedMethod(listAccounts, false, nil) {
+		t.Fatal("Expect only the limited rpc user to reach the limited handler")
+	}
+}
+func TestHttpServerProcessBatchCall(t *testing.T) {
+	ResetHttpServer()
+	r := &http.Request{
+		Method: "POST",
+		Header: header,
+	}
+	publicKey := newRPCAPIKey(config.RPCAPIKey{Name: "public", Key: "public@123", Methods: []string{testHttpServer, "nosuchmethod"}})
+	closeChan := make(chan struct{})
+	if !isBatchRequest([]byte(" \n["+testRpcServerString+"]")) || isBatchRequest([]byte(testRpcServerString)) {
+		t.Fatal("Expect only the JSON array to be a batch request")
+	}
+	// notification
+	if msg := httpServer.processBatchCall(r, []byte(`{"jsonrpc": "2.0","method": "testrpcserver","params": ""}`), false, false, publicKey, closeChan); msg != nil {
+		t.Fatalf("Expect no response to notification but get %s", msg)
+	}
+	testCases := []struct {
+		rawRequest []byte
+		id         interface{}
+		errCode    int
+	}{
+		{[]byte(`{"jsonrpc": "2.0","method": "nosuchmethod","params": "","id": 1}`), float64(1), rpcservice.ErrCodeMessage[rpcservice.RPCMethodNotFoundError].Code},
+		{[]byte(`{"jsonrpc": "2.0","method": "getblockchaininfo","params": "","id": "2"}`), "2", rpcservice.ErrCodeMessage[rpcservice.RPCInvalidMethodPermissionError].Code},
+		{[]byte(`1`), nil, rpcservice.ErrCodeMessage[rpcservice.RPCInvalidRequestError].Code},
+	}
+	for _, tc := range testCases {
+		msg := httpServer.processBatchCall(r, tc.rawRequest, false, false, publicKey, closeChan)
+		response := &JsonResponse{}
+		if err := json.Unmarshal(msg, response); err != nil {
+			t.Fatalf("Expect a JSON response for %s but get %s", tc.rawRequest, msg)
+		}
+		var id interface{}
+		if response.Id != nil {
+			id = *response.Id
+		}
+		if id != tc.id {
+			t.Fatalf("Expect id %+v but get %+v", tc.id, id)
+		}
+		if response.Error == nil || response.Error.Code != tc.errCode {
+			t.Fatalf("Expect error code %+v for %s but get %+v", tc.errCode, tc.rawRequest, response.Error)
+		}
+	}
+}
+func TestWsServerAPIKeyACL(t *testing.T) {
+	wsConfig := *rpcConfig
+	wsConfig.RPCMaxWSClients = 1
+	wsConfig.APIKeys = []config.RPCAPIKey{
+		{Name: "public", Key: "public@123", Methods: []string{"nosuchmethod"}},
+	}
+	wsServer := &WsServer{}
+	wsServer.Init(&wsConfig)
+	wsServer.config.DisableAuth = false
+	server := httptest.NewServer(http.HandlerFunc(wsServer.handleWsRequest))
+	defer server.Close()
+	url := "ws" + strings.TrimPrefix(server.URL, "http")
+	authHeader := func(login string) http.Header {
+		return http.Header{"Authorization": []string{"Basic " + base64.StdEncoding.EncodeToString([]byte(login))}}
+	}
+
+	for _, header := range []http.Header{nil, authHeader("public:signer@123"), authHeader(user + ":" + pass)} {
+		_, resp, err := websocket.DefaultDialer.Dial(url, header)
+		if err == nil || resp == nil || resp.StatusCode != http.StatusUnauthorized {
+			t.Fatalf("Expect the upgrade with header %+v to be unauthorized but get %+v", header, err)
+		}
+	}
+	ws, _, err := websocket.DefaultDialer.Dial(url, authHeader("public:public@123"))
+	if err != nil {
+		t.Fatalf("Expect the upgrade with the API key but get %+v", err)
+	}
+	testCases := []struct {
+		method  string
+		errCode int
+	}{
+		{"nosuchmethod", rpcservice.ErrCodeMessage[rpcservice.RPCMethodNotFoundError].Code},
+		{subcribeNewShardBlock, rpcservice.ErrCodeMessage[rpcservice.RPCInvalidMethodPermissionError].Code},
+	}
+	for _, tc := range testCases {
+		subRequest := SubcriptionRequest{
+			JsonRequest: JsonRequest{Jsonrpc: "1.0", Method: tc.method, Params: []interface{}{float64(0)}, Id: 1},
+			Subcription: tc.method,
+		}
+		if err := ws.WriteJSON(subRequest); err != nil {
+			t.Fatal(err)
+		}
+		response := &JsonResponse{}
+		if err := ws.ReadJSON(response); err != nil {
+			t.Fatalf("Expect a JSON response but get %+v", err)
+		}
+		if response.Error == nil || response.Error.Code != tc.errCode {
+			t.Fatalf("Expect error code %+v for %+v but get %+v", tc.errCode, tc.method, response.Error)
+		}
+	}
+	ws.Close()
+
+	// without API keys the websocket stays open to every client
+	wsServer.Init(rpcConfig)
+	wsServer.config.RPCMaxWSClients = 2
+	ws, _, err = websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		t.Fatalf("Expect the upgrade without API keys but get %+v", err)
+	}
+	ws.Close()
+}
@@ -40,6 +40,23 @@ func (httpServer *HttpServer) handleCreateRawTransaction(params interface{}, clo
 	return result, nil
 }
 
+// handleCreateUnsignedTransaction handles createunsignedtransaction commands.
+// The transaction is built from the read-only keys of the sender and signed offline,
+// the signed transaction is sent with sendtransaction.
+func (httpServer *HttpServer) handleCreateUnsignedTransaction(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	createUnsignedTxParam, errNewParam := bean.NewCreateUnsignedTxParam(params)
+	if errNewParam != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errNewParam)
+	}
+
+	unsignedTx, inputCoins, err := httpServer.txService.BuildUnsignedTransaction(createUnsignedTxParam)
+	if err != nil {
+		return nil, err
+	}
+	result := jsonresult.NewCreateUnsignedTransactionResult(unsignedTx, inputCoins)
+	return result, nil
+}
+
 func (httpServer *HttpServer) handleCreateRawConvertVer1ToVer2Transaction(params interface{}, closeChan <-chan struct{}) (*jsonresult.CreateTransactionResult, *rpcservice.RPCError) {
 	Logger.log.Debugf("handleCreateRawConvertVer1ToVer2Transaction params: %+v", params)
 
@@ -487,6 +504,7 @@ func (httpServer *HttpServer) handleGetListPrivacyCustomTokenBalance(params inte
 
 // #1 param: privateKey string
 // #2 param: tokenID
+// #3 param (optional): beacon height the balance is computed at, the best view if omitted
 // handleGetListPrivacyCustomTokenBalance - return list privacy token + balance for one account payment address
 func (httpServer *HttpServer) handleGetBalancePrivacyCustomToken(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
 	arrayParams := common.InterfaceSlice(params)
@@ -504,7 +522,12 @@ func (httpServer *HttpServer) handleGetBalancePrivacyCustomToken(params interfac
 		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("tokenID is invalid"))
 	}
 
-	totalValue, err2 := httpServer.txService.GetBalancePrivacyCustomToken(privateKey, tokenID)
+	atHeight, err := getOptionalAtHeightParam(arrayParams, 2)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	}
+
+	totalValue, err2 := httpServer.txService.GetBalancePrivacyCustomToken(privateKey, tokenID, atHeight)
 	if err2 != nil {
 		return nil, err2
 	}
@@ -1119,6 +1142,22 @@ func (httpServer *HttpServer) handleCreateRawPrivacyCustomTokenTransaction(param
 	return result, nil
 }
 
+// handleCreateUnsignedPrivacyCustomTokenTransaction handles createunsignedprivacycustomtokentransaction commands.
+// The signed transaction is sent with sendrawprivacycustomtokentransaction.
+func (httpServer *HttpServer) handleCreateUnsignedPrivacyCustomTokenTransaction(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	createUnsignedTxParam, errNewParam := bean.NewCreateUnsignedPrivacyTokenTxParam(params)
+	if errNewParam != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errNewParam)
+	}
+
+	unsignedTx, inputCoins, err := httpServer.txService.BuildUnsignedPrivacyCustomTokenTransaction(createUnsignedTxParam)
+	if err != nil {
+		return nil, err
+	}
+	result := jsonresult.NewCreateUnsignedTransactionResult(unsignedTx, inputCoins)
+	return result, nil
+}
+
 // handleSendRawTransaction...
 func (httpServer *HttpServer) handleSendRawPrivacyCustomTokenTransaction(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
 	arrayParams := common.InterfaceSlice(params)
@@ -257,7 +257,7 @@ func (httpServer *HttpServer) handleRemoveAccount(params interface{}, closeChan
 func (httpServer *HttpServer) handleGetBalanceByPrivatekey(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
 	// all component
 	arrayParams := common.InterfaceSlice(params)
-	if arrayParams == nil || len(arrayParams) != 1 {
+	if arrayParams == nil || len(arrayParams) < 1 || len(arrayParams) > 2 {
 		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 1 element"))
 	}
 	// param #1: private key of sender
@@ -265,8 +265,13 @@ func (httpServer *HttpServer) handleGetBalanceByPrivatekey(params interface{}, c
 	if !ok {
 		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("invalid private key"))
 	}
+	// param #2 (optional): beacon height the balance is computed at, the best view if omitted
+	atHeight, err := getOptionalAtHeightParam(arrayParams, 1)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	}
 
-	return httpServer.walletService.GetBalanceByPrivateKey(senderKeyParam)
+	return httpServer.walletService.GetBalanceByPrivateKey(senderKeyParam, atHeight)
 }
 
 // handleGetBalanceByPaymentAddress -  return balance of paymentaddress
@@ -274,7 +279,7 @@ func (httpServer *HttpServer) handleGetBalanceByPaymentAddress(params interface{
 
 	// all component
 	arrayParams := common.InterfaceSlice(params)
-	if arrayParams == nil || len(arrayParams) != 1 {
+	if arrayParams == nil || len(arrayParams) < 1 || len(arrayParams) > 2 {
 		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 1 element"))
 	}
 	// param #1: private key of sender
@@ -282,8 +287,13 @@ func (httpServer *HttpServer) handleGetBalanceByPaymentAddress(params interface{
 	if !ok {
 		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("payment address is invalid"))
 	}
+	// param #2 (optional): beacon height the balance is computed at, the best view if omitted
+	atHeight, err := getOptionalAtHeightParam(arrayParams, 1)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	}
 
-	return httpServer.walletService.GetBalanceByPaymentAddress(paymentAddressParam)
+	return httpServer.walletService.GetBalanceByPaymentAddress(paymentAddressParam, atHeight)
 }
 
 /*
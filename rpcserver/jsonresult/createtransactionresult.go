@@ -3,6 +3,7 @@ package jsonresult
 import (
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/common/base58"
+	"github.com/incognitochain/incognito-chain/transaction"
 )
 
 type CreateTransactionResult struct {
@@ -41,3 +42,18 @@ type CreateTransactionTokenResult struct {
 
 	}
 }*/
+
+// CreateUnsignedTransactionResult is a transaction to be signed offline, InputCoins are the base58 public keys of the coins it spends
+type CreateUnsignedTransactionResult struct {
+	UnsignedTx *transaction.UnsignedTx
+	InputCoins []string
+	ShardID    byte
+}
+
+func NewCreateUnsignedTransactionResult(unsignedTx *transaction.UnsignedTx, inputCoins []string) CreateUnsignedTransactionResult {
+	return CreateUnsignedTransactionResult{
+		UnsignedTx: unsignedTx,
+		InputCoins: inputCoins,
+		ShardID:    unsignedTx.PubKeyLastByteSender,
+	}
+}
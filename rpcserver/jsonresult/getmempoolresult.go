@@ -128,3 +128,33 @@ func NewGetPendingTxsInBlockgenResult(txs []metadata.Transaction) GetPendingTxsI
 	}
 	return GetPendingTxsInBlockgenResult{TxHashes: txHashes}
 }
+
+type MempoolEvent struct {
+	Type         string `json:"Type"`
+	Reason       string `json:"Reason,omitempty"`
+	ShardID      byte   `json:"ShardID"`
+	Seq          uint64 `json:"Seq"`
+	TxID         string `json:"TxID"`
+	TxType       string `json:"TxType"`
+	MetadataType int    `json:"MetadataType"`
+	Fee          uint64 `json:"Fee"`
+	Size         uint64 `json:"Size"`
+	LockTime     int64  `json:"LockTime"`
+	PoolSize     int    `json:"PoolSize"`
+}
+
+func NewMempoolEvent(event *txpool.MempoolEvent) *MempoolEvent {
+	return &MempoolEvent{
+		Type:         event.Type,
+		Reason:       event.Reason,
+		ShardID:      event.ShardID,
+		Seq:          event.Seq,
+		TxID:         event.TxHash,
+		TxType:       event.TxType,
+		MetadataType: event.MetadataType,
+		Fee:          event.Fee,
+		Size:         event.Size,
+		LockTime:     event.LockTime,
+		PoolSize:     event.PoolSize,
+	}
+}
@@ -0,0 +1,27 @@
+package jsonresult
+
+import coinIndexer "github.com/incognitochain/incognito-chain/transaction/coin_indexer"
+
+type IncomingCoinResult struct {
+	PublicKey     string `json:"PublicKey"`
+	CoinPublicKey string `json:"CoinPublicKey"`
+	TxHash        string `json:"TxHash"`
+	TokenID       string `json:"TokenID"`
+	ShardID       byte   `json:"ShardID"`
+	BlockHeight   uint64 `json:"BlockHeight"`
+	BlockHash     string `json:"BlockHash"`
+	IsCrossShard  bool   `json:"IsCrossShard"`
+}
+
+func NewIncomingCoinResult(incomingCoin *coinIndexer.IncomingCoin) IncomingCoinResult {
+	return IncomingCoinResult{
+		PublicKey:     incomingCoin.PublicKey,
+		CoinPublicKey: incomingCoin.CoinPublicKey,
+		TxHash:        incomingCoin.TxHash,
+		TokenID:       incomingCoin.TokenID,
+		ShardID:       incomingCoin.ShardID,
+		BlockHeight:   incomingCoin.BlockHeight,
+		BlockHash:     incomingCoin.BlockHash,
+		IsCrossShard:  incomingCoin.IsCrossShard,
+	}
+}
@@ -0,0 +1,60 @@
+package jsonresult
+
+// OpenRPCDocument describes the RPC methods of a node following the OpenRPC specification
+// (https://spec.open-rpc.org), it is the result of rpc.discover
+type OpenRPCDocument struct {
+	OpenRPC    string            `json:"openrpc"`
+	Info       OpenRPCInfo       `json:"info"`
+	Methods    []OpenRPCMethod   `json:"methods"`
+	Components OpenRPCComponents `json:"components"`
+}
+
+type OpenRPCInfo struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+type OpenRPCMethod struct {
+	Name           string                     `json:"name"`
+	Summary        string                     `json:"summary,omitempty"`
+	Tags           []OpenRPCTag               `json:"tags,omitempty"`
+	ParamStructure string                     `json:"paramStructure"`
+	Params         []OpenRPCContentDescriptor `json:"params"`
+	Result         *OpenRPCContentDescriptor  `json:"result"`
+	// GoName is the name of the method in the generated Go clients
+	GoName string `json:"x-go-name,omitempty"`
+	// Inferred is set when the params and the result of the method are inferred from its handler:
+	// the types the handler does not assert are left unknown
+	Inferred bool `json:"x-inferred,omitempty"`
+	// Untyped is set when the params and the result of the method are not declared:
+	// the params are parsed positionally by the handler
+	Untyped bool `json:"x-untyped,omitempty"`
+}
+
+type OpenRPCTag struct {
+	Name string `json:"name"`
+}
+
+type OpenRPCContentDescriptor struct {
+	Name     string         `json:"name"`
+	Required bool           `json:"required,omitempty"`
+	Schema   *OpenRPCSchema `json:"schema"`
+	// GoType is the Go type of the value, its packages are listed in GoImports
+	GoType    string   `json:"x-go-type,omitempty"`
+	GoImports []string `json:"x-go-imports,omitempty"`
+}
+
+// OpenRPCSchema is the subset of JSON schema needed to describe the RPC values
+type OpenRPCSchema struct {
+	Ref                  string                    `json:"$ref,omitempty"`
+	Type                 string                    `json:"type,omitempty"`
+	Format               string                    `json:"format,omitempty"`
+	Items                *OpenRPCSchema            `json:"items,omitempty"`
+	Properties           map[string]*OpenRPCSchema `json:"properties,omitempty"`
+	AdditionalProperties *OpenRPCSchema            `json:"additionalProperties,omitempty"`
+}
+
+type OpenRPCComponents struct {
+	Schemas map[string]*OpenRPCSchema `json:"schemas"`
+}
@@ -0,0 +1,9 @@
+package jsonresult
+
+type PortalV4TokenResult struct {
+	TokenID                string `json:"TokenID"`
+	ChainID                string `json:"ChainID"`
+	MinTokenAmount         uint64 `json:"MinTokenAmount"`
+	MultipleTokenAmount    uint64 `json:"MultipleTokenAmount"`
+	GeneralMultiSigAddress string `json:"GeneralMultiSigAddress"`
+}
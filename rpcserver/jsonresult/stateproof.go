@@ -0,0 +1,24 @@
+package jsonresult
+
+// StateProof is a state value with its Merkle proof against a state root of a block,
+// the root is the field StateDB + "StateDBRootHash" of the StateRoots (ShardRootHash / BeaconRootHash) of the block.
+// The hash of StateRoots is the ParentStateRootsHash of the header of CommitBlock, the finalized child of the block,
+// so the proof is checked against the signatures of the committee in the ValidationData of CommitBlock
+type StateProof struct {
+	BlockHash   string       `json:"BlockHash"`
+	StateDB     string       `json:"StateDB"`
+	RootHash    string       `json:"RootHash"`
+	Key         string       `json:"Key"`
+	Value       []byte       `json:"Value"` // JSON encoding of the state object, empty if the key is not in the state
+	Proof       [][]byte     `json:"Proof"` // encoded trie nodes from the root to the value
+	StateRoots  interface{}  `json:"StateRoots"`
+	Block       SignedHeader `json:"Block"`
+	CommitBlock SignedHeader `json:"CommitBlock"`
+}
+
+// SignedHeader is the header of a shard / beacon block with the validation data holding the committee signatures
+type SignedHeader struct {
+	Hash           string      `json:"Hash"`
+	Header         interface{} `json:"Header"`
+	ValidationData string      `json:"ValidationData"`
+}
@@ -0,0 +1,580 @@
+package rpcserver
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/rpcserver/jsonresult"
+	"github.com/incognitochain/incognito-chain/rpcserver/rpcservice"
+)
+
+//go:generate go run ../utility/genrpcspecs -output openrpc_generated.go
+
+const openRPCVersion = "1.2.6"
+
+// rpcMethodSpec declares the params and the result of an RPC method, the params are
+// sent by position. The Go types of the values are given by zero values: the handler
+// parses the params from JSON, so the numbers of the params are float64.
+type rpcMethodSpec struct {
+	Summary string
+	// GoName is the name of the method in the generated Go clients, empty to skip the method
+	GoName string
+	Params []rpcParamSpec
+	Result interface{}
+}
+
+type rpcParamSpec struct {
+	Name     string
+	Value    interface{}
+	Optional bool
+}
+
+var (
+	openRPCDocument     *jsonresult.OpenRPCDocument
+	openRPCDocumentOnce sync.Once
+)
+
+func init() {
+	// registered here since the handler lists the other handlers
+	HttpHandler[rpcDiscover] = (*HttpServer).handleDiscover
+}
+
+// handleDiscover returns the OpenRPC document of the RPC methods
+func (httpServer *HttpServer) handleDiscover(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	return NewOpenRPCDocument(), nil
+}
+
+// NewOpenRPCDocument returns the OpenRPC document of the methods of HttpHandler, LimitedHttpHandler
+// and WsHandler. The methods without spec in rpcMethodSpecs are declared by generatedRPCMethodSpecs
+// and listed as inferred, the ones in neither are listed as untyped.
+func NewOpenRPCDocument() *jsonresult.OpenRPCDocument {
+	openRPCDocumentOnce.Do(func() {
+		openRPCDocument = buildOpenRPCDocument()
+	})
+	return openRPCDocument
+}
+
+func buildOpenRPCDocument() *jsonresult.OpenRPCDocument {
+	tags := make(map[string][]jsonresult.OpenRPCTag)
+	for name := range HttpHandler {
+		tags[name] = append(tags[name], jsonresult.OpenRPCTag{Name: "http"})
+	}
+	for name := range LimitedHttpHandler {
+		tags[name] = append(tags[name], jsonresult.OpenRPCTag{Name: "limited"})
+	}
+	for name := range WsHandler {
+		tags[name] = append(tags[name], jsonresult.OpenRPCTag{Name: "websocket"})
+	}
+	names := make([]string, 0, len(tags))
+	for name := range tags {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	builder := &openRPCSchemaBuilder{
+		schemas: make(map[string]*jsonresult.OpenRPCSchema),
+	}
+	methods := make([]jsonresult.OpenRPCMethod, 0, len(names))
+	for _, name := range names {
+		method := jsonresult.OpenRPCMethod{
+			Name:           name,
+			Tags:           tags[name],
+			ParamStructure: "by-position",
+			Params:         []jsonresult.OpenRPCContentDescriptor{},
+		}
+		spec, ok := rpcMethodSpecs[name]
+		if !ok {
+			spec, ok = generatedRPCMethodSpecs[name]
+			method.Inferred = ok
+		}
+		if !ok {
+			method.Untyped = true
+			method.Result = &jsonresult.OpenRPCContentDescriptor{Name: "result", Schema: &jsonresult.OpenRPCSchema{}}
+			methods = append(methods, method)
+			continue
+		}
+		method.Summary = spec.Summary
+		method.GoName = spec.GoName
+		for _, param := range spec.Params {
+			descriptor := builder.contentDescriptor(param.Name, param.Value)
+			descriptor.Required = !param.Optional
+			method.Params = append(method.Params, *descriptor)
+		}
+		method.Result = builder.contentDescriptor("result", spec.Result)
+		methods = append(methods, method)
+	}
+
+	return &jsonresult.OpenRPCDocument{
+		OpenRPC: openRPCVersion,
+		Info: jsonresult.OpenRPCInfo{
+			Title:       "Incognito chain RPC",
+			Description: "RPC methods of an Incognito node, the params are sent by position. The specs of the methods not called by the Go clients are inferred from their handlers",
+			Version:     RpcServerVersion,
+		},
+		Methods: methods,
+		Components: jsonresult.OpenRPCComponents{
+			Schemas: builder.schemas,
+		},
+	}
+}
+
+var (
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// openRPCSchemaBuilder builds the JSON schemas of Go types, the named structs are
+// put in the components of the document and referenced
+type openRPCSchemaBuilder struct {
+	schemas map[string]*jsonresult.OpenRPCSchema
+}
+
+func (builder *openRPCSchemaBuilder) contentDescriptor(name string, value interface{}) *jsonresult.OpenRPCContentDescriptor {
+	t := reflect.TypeOf(value)
+	descriptor := &jsonresult.OpenRPCContentDescriptor{
+		Name:   name,
+		Schema: builder.schemaOf(t),
+		GoType: "interface{}",
+	}
+	if t != nil {
+		descriptor.GoType = t.String()
+		descriptor.GoImports = goImportsOf(t)
+	}
+	return descriptor
+}
+
+func (builder *openRPCSchemaBuilder) schemaOf(t reflect.Type) *jsonresult.OpenRPCSchema {
+	if t == nil {
+		return &jsonresult.OpenRPCSchema{}
+	}
+	if t.Implements(textMarshalerType) && !t.Implements(jsonMarshalerType) {
+		return &jsonresult.OpenRPCSchema{Type: "string"}
+	}
+	if t.Implements(jsonMarshalerType) || reflect.PtrTo(t).Implements(jsonMarshalerType) {
+		// the JSON encoding of the type is custom
+		return &jsonresult.OpenRPCSchema{}
+	}
+	switch t.Kind() {
+	case reflect.Ptr:
+		return builder.schemaOf(t.Elem())
+	case reflect.Bool:
+		return &jsonresult.OpenRPCSchema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &jsonresult.OpenRPCSchema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &jsonresult.OpenRPCSchema{Type: "number"}
+	case reflect.String:
+		return &jsonresult.OpenRPCSchema{Type: "string"}
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &jsonresult.OpenRPCSchema{Type: "string", Format: "byte"}
+		}
+		return &jsonresult.OpenRPCSchema{Type: "array", Items: builder.schemaOf(t.Elem())}
+	case reflect.Array:
+		return &jsonresult.OpenRPCSchema{Type: "array", Items: builder.schemaOf(t.Elem())}
+	case reflect.Map:
+		return &jsonresult.OpenRPCSchema{Type: "object", AdditionalProperties: builder.schemaOf(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			schema := &jsonresult.OpenRPCSchema{Type: "object", Properties: make(map[string]*jsonresult.OpenRPCSchema)}
+			builder.addProperties(schema, t)
+			return schema
+		}
+		schemaName := t.String()
+		if _, ok := builder.schemas[schemaName]; !ok {
+			schema := &jsonresult.OpenRPCSchema{Type: "object", Properties: make(map[string]*jsonresult.OpenRPCSchema)}
+			// put before the properties so that recursive types refer to it
+			builder.schemas[schemaName] = schema
+			builder.addProperties(schema, t)
+		}
+		return &jsonresult.OpenRPCSchema{Ref: "#/components/schemas/" + schemaName}
+	default:
+		return &jsonresult.OpenRPCSchema{}
+	}
+}
+
+// addProperties adds the fields of the struct t encoded by encoding/json to schema
+func (builder *openRPCSchemaBuilder) addProperties(schema *jsonresult.OpenRPCSchema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+		fieldType := field.Type
+		if field.Anonymous && name == "" {
+			if fieldType.Kind() == reflect.Ptr {
+				fieldType = fieldType.Elem()
+			}
+			if fieldType.Kind() == reflect.Struct {
+				builder.addProperties(schema, fieldType)
+				continue
+			}
+		}
+		if field.PkgPath != "" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		schema.Properties[name] = builder.schemaOf(field.Type)
+	}
+}
+
+// goImportsOf returns the packages of the named types t is made of
+func goImportsOf(t reflect.Type) []string {
+	if t.Name() != "" {
+		if t.PkgPath() == "" {
+			return nil
+		}
+		return []string{t.PkgPath()}
+	}
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Array:
+		return goImportsOf(t.Elem())
+	case reflect.Map:
+		return append(goImportsOf(t.Key()), goImportsOf(t.Elem())...)
+	}
+	return nil
+}
+
+// rpcMethodSpecs declares the params and the results of the RPC methods called by the Go clients of
+// the testsuite (rpcclient.ClientInterface). The other methods are declared by generatedRPCMethodSpecs,
+// generated from their handlers by go generate: their spec is added here when a client starts calling them.
+var rpcMethodSpecs = map[string]rpcMethodSpec{
+	rpcDiscover: {
+		Summary: "Returns the OpenRPC document of the RPC methods",
+		Result:  (*jsonresult.OpenRPCDocument)(nil),
+	},
+
+	// node
+	isInstantFinality: {
+		Summary: "Returns whether the chain finalizes its blocks instantly",
+		GoName:  "IsInstantFinality",
+		Params:  []rpcParamSpec{{Name: "chainID", Value: int(0)}},
+		Result:  false,
+	},
+	getAllViewDetail: {
+		Summary: "Returns the views of the chain",
+		GoName:  "GetAllViewDetail",
+		Params:  []rpcParamSpec{{Name: "chainID", Value: int(0)}},
+		Result:  []jsonresult.GetViewResult{},
+	},
+	getMiningInfo: {
+		Summary: "Returns the mining info of the node",
+		GoName:  "GetMiningInfo",
+		Result:  (*jsonresult.GetMiningInfoResult)(nil),
+	},
+	getPublicKeyRole: {
+		Summary: "Returns the role of a mining public key",
+		GoName:  "GetPublicKeyRole",
+		Params:  []rpcParamSpec{{Name: "publicKey", Value: ""}, {Name: "detail", Value: false}},
+		Result:  nil,
+	},
+
+	// block
+	getBlockChainInfo: {
+		Summary: "Returns the best blocks of the beacon and the shards",
+		GoName:  "GetBlockChainInfo",
+		Result:  (*jsonresult.GetBlockChainInfoResult)(nil),
+	},
+	getBeaconBestState: {
+		Summary: "Returns the beacon best state",
+		GoName:  "GetBeaconBestState",
+		Result:  jsonresult.GetBeaconBestState{},
+	},
+	getShardBestState: {
+		Summary: "Returns the best state of a shard",
+		GoName:  "GetShardBestState",
+		Params:  []rpcParamSpec{{Name: "sid", Value: int(0)}},
+		Result:  jsonresult.GetShardBestState{},
+	},
+	getBlockHash: {
+		Summary: "Returns the hashes of the blocks of a chain at a height",
+		GoName:  "GetBlockHash",
+		Params:  []rpcParamSpec{{Name: "chainID", Value: float64(0)}, {Name: "height", Value: float64(0)}},
+		Result:  []common.Hash{},
+	},
+	retrieveBlock: {
+		Summary: "Returns a shard block by hash",
+		GoName:  "RetrieveBlock",
+		Params:  []rpcParamSpec{{Name: "hash", Value: ""}, {Name: "verbosity", Value: ""}},
+		Result:  (*jsonresult.GetShardBlockResult)(nil),
+	},
+	retrieveBlockByHeight: {
+		Summary: "Returns the shard blocks at a height",
+		GoName:  "RetrieveBlockByHeight",
+		Params:  []rpcParamSpec{{Name: "shardID", Value: float64(0)}, {Name: "height", Value: float64(0)}, {Name: "verbosity", Value: ""}},
+		Result:  []*jsonresult.GetShardBlockResult{},
+	},
+	retrieveBeaconBlock: {
+		Summary: "Returns a beacon block by hash",
+		GoName:  "RetrieveBeaconBlock",
+		Params:  []rpcParamSpec{{Name: "hash", Value: ""}},
+		Result:  (*jsonresult.GetBeaconBlockResult)(nil),
+	},
+	retrieveBeaconBlockByHeight: {
+		Summary: "Returns the beacon blocks at a height",
+		GoName:  "RetrieveBeaconBlockByHeight",
+		Params:  []rpcParamSpec{{Name: "height", Value: float64(0)}},
+		Result:  []*jsonresult.GetBeaconBlockResult{},
+	},
+	getCandidateList: {
+		Summary: "Returns the candidates of the beacon and the shards",
+		GoName:  "GetCandidateList",
+		Result:  (*jsonresult.CandidateListsResult)(nil),
+	},
+	getCommitteeList: {
+		Summary: "Returns the committees of the beacon and the shards",
+		GoName:  "GetCommitteeList",
+		Result:  (*jsonresult.CommitteeListsResult)(nil),
+	},
+	getBurningAddress: {
+		Summary: "Returns the burning address at a beacon height",
+		GoName:  "GetBurningAddress",
+		Params:  []rpcParamSpec{{Name: "beaconHeight", Value: float64(0)}},
+		Result:  "",
+	},
+
+	// transaction
+	getTransactionByHash: {
+		Summary: "Returns a transaction by hash",
+		GoName:  "GetTransactionByHash",
+		Params:  []rpcParamSpec{{Name: "transactionHash", Value: ""}},
+		Result:  (*jsonresult.TransactionDetail)(nil),
+	},
+	createAndSendTransaction: {
+		Summary: "Creates and sends a PRV transaction",
+		GoName:  "CreateAndSendTransaction",
+		Params:  []rpcParamSpec{{Name: "privateKey", Value: ""}, {Name: "receivers", Value: map[string]interface{}{}}, {Name: "fee", Value: float64(0)}, {Name: "privacy", Value: float64(0)}},
+		Result:  jsonresult.CreateTransactionResult{},
+	},
+	createAndSendPrivacyCustomTokenTransaction: {
+		Summary: "Creates and sends a token transaction",
+		GoName:  "CreateAndSendPrivacyCustomTokenTransaction",
+		Params:  tokenTxParamSpecs("tokenInfo"),
+		Result:  jsonresult.CreateTransactionTokenResult{},
+	},
+	defragmentAccount: {
+		Summary: "Merges the PRV coins of an account lower than a value",
+		GoName:  "DefragmentAccount",
+		Params:  []rpcParamSpec{{Name: "privateKey", Value: ""}, {Name: "maxValue", Value: float64(0)}, {Name: "fee", Value: float64(0)}, {Name: "privacy", Value: float64(0)}},
+		Result:  jsonresult.CreateTransactionResult{},
+	},
+	defragmentAccountToken: {
+		Summary: "Merges the token coins of an account",
+		GoName:  "DefragmentAccountToken",
+		Params:  tokenTxParamSpecs("reqInfo"),
+		Result:  jsonresult.CreateTransactionTokenResult{},
+	},
+	getPrivacyCustomToken: {
+		Summary: "Returns a token by ID",
+		GoName:  "GetPrivacyCustomToken",
+		Params:  []rpcParamSpec{{Name: "tokenStr", Value: ""}},
+		Result:  (*jsonresult.GetCustomToken)(nil),
+	},
+
+	// staking and reward
+	createAndSendStakingTransaction: {
+		Summary: "Creates and sends a staking transaction",
+		GoName:  "CreateAndSendStakingTransaction",
+		Params:  txWithMetadataParamSpecs("stakeInfo"),
+		Result:  jsonresult.CreateTransactionResult{},
+	},
+	createAndSendStopAutoStakingTransaction: {
+		Summary: "Creates and sends a stop auto staking transaction",
+		GoName:  "CreateAndSendStopAutoStakingTransaction",
+		Params:  txWithMetadataParamSpecs("stopStakeInfo"),
+		Result:  jsonresult.CreateTransactionResult{},
+	},
+	CreateRawWithDrawTransaction: {
+		Summary: "Creates and sends a reward withdrawal transaction",
+		GoName:  "WithdrawReward",
+		Params:  []rpcParamSpec{{Name: "privateKey", Value: ""}, {Name: "receivers", Value: map[string]interface{}{}}, {Name: "amount", Value: float64(0)}, {Name: "privacy", Value: float64(0)}, {Name: "info", Value: map[string]interface{}{}}},
+		Result:  jsonresult.CreateTransactionResult{},
+	},
+	getRewardAmount: {
+		Summary: "Returns the rewards of a payment address by token",
+		GoName:  "GetRewardAmount",
+		Params:  []rpcParamSpec{{Name: "paymentAddress", Value: ""}},
+		Result:  map[string]uint64{},
+	},
+
+	// pdex v2
+	createAndSendTxWithWithdrawalReqV2: {
+		Summary: "Creates and sends a pDEX withdrawal transaction",
+		GoName:  "CreateAndSendTxWithWithdrawalReqV2",
+		Params:  txWithMetadataParamSpecs("reqInfo"),
+		Result:  jsonresult.CreateTransactionResult{},
+	},
+	createAndSendTxWithPDEFeeWithdrawalReq: {
+		Summary: "Creates and sends a pDEX fee withdrawal transaction",
+		GoName:  "CreateAndSendTxWithPDEFeeWithdrawalReq",
+		Params:  txWithMetadataParamSpecs("reqInfo"),
+		Result:  jsonresult.CreateTransactionResult{},
+	},
+	createAndSendTxWithPTokenTradeReq: {
+		Summary: "Creates and sends a pDEX trade selling a token",
+		GoName:  "CreateAndSendTxWithPTokenTradeReq",
+		Params:  tokenTxParamSpecs("reqInfo"),
+		Result:  jsonresult.CreateTransactionTokenResult{},
+	},
+	createAndSendTxWithPTokenCrossPoolTradeReq: {
+		Summary: "Creates and sends a pDEX cross pool trade selling a token",
+		GoName:  "CreateAndSendTxWithPTokenCrossPoolTradeReq",
+		Params:  tokenTxParamSpecs("reqInfo"),
+		Result:  jsonresult.CreateTransactionTokenResult{},
+	},
+	createAndSendTxWithPRVTradeReq: {
+		Summary: "Creates and sends a pDEX trade selling PRV",
+		GoName:  "CreateAndSendTxWithPRVTradeReq",
+		Params:  txWithMetadataParamSpecs("reqInfo"),
+		Result:  jsonresult.CreateTransactionResult{},
+	},
+	createAndSendTxWithPRVCrossPoolTradeReq: {
+		Summary: "Creates and sends a pDEX cross pool trade selling PRV",
+		GoName:  "CreateAndSendTxWithPRVCrossPoolTradeReq",
+		Params:  txWithMetadataParamSpecs("reqInfo"),
+		Result:  jsonresult.CreateTransactionResult{},
+	},
+	createAndSendTxWithPTokenContributionV2: {
+		Summary: "Creates and sends a pDEX contribution of a token",
+		GoName:  "CreateAndSendTxWithPTokenContributionV2",
+		Params:  tokenTxParamSpecs("reqInfo"),
+		Result:  jsonresult.CreateTransactionTokenResult{},
+	},
+	createAndSendTxWithPRVContributionV2: {
+		Summary: "Creates and sends a pDEX contribution of PRV",
+		GoName:  "CreateAndSendTxWithPRVContributionV2",
+		Params:  txWithMetadataParamSpecs("reqInfo"),
+		Result:  jsonresult.CreateTransactionResult{},
+	},
+	getPDEState: {
+		Summary: "Returns the pDEX state at a beacon height",
+		GoName:  "GetPDEState",
+		Params:  []rpcParamSpec{{Name: "data", Value: map[string]interface{}{}}},
+		Result:  jsonresult.CurrentPDEState{},
+	},
+
+	// wallet of the node
+	getBalanceByPrivatekey: {
+		Summary: "Returns the PRV balance of a private key",
+		GoName:  "GetBalanceByPrivateKey",
+		Params:  []rpcParamSpec{{Name: "privateKey", Value: ""}},
+		Result:  uint64(0),
+	},
+	getListPrivacyCustomTokenBalance: {
+		Summary: "Returns the token balances of a private key",
+		GoName:  "GetListPrivacyCustomTokenBalance",
+		Params:  []rpcParamSpec{{Name: "privateKey", Value: ""}},
+		Result:  jsonresult.ListCustomTokenBalance{},
+	},
+	submitKey: {
+		Summary: "Submits an OTA key to the coin indexer of the node",
+		GoName:  "SubmitKey",
+		Params:  []rpcParamSpec{{Name: "privateKey", Value: ""}},
+		Result:  false,
+	},
+	getMempoolInfo: {
+		Summary: "Returns the transactions in the mempool of the node",
+		Result:  (*jsonresult.GetMempoolInfo)(nil),
+	},
+
+	// the Go clients of the methods below are hand-written since they take the params as structs
+
+	// pdex v3
+	createAndSendTokenInitTransaction: {
+		Summary: "Creates and sends a transaction minting a new token",
+		Params:  []rpcParamSpec{{Name: "param", Value: map[string]interface{}{}}},
+		Result:  jsonresult.CreateTransactionTokenResult{},
+	},
+	pdexv3MintNft: {
+		Summary: "Creates and sends a pDEX v3 nft minting transaction",
+		Params:  txWithMetadataParamSpecs("reqInfo"),
+		Result:  jsonresult.CreateTransactionResult{},
+	},
+	createAndSendTxWithPdexv3ModifyParams: {
+		Summary: "Creates and sends a pDEX v3 params modification transaction",
+		Params:  txWithMetadataParamSpecs("reqInfo"),
+		Result:  jsonresult.CreateTransactionResult{},
+	},
+	pdexv3AddLiquidityV3: {
+		Summary: "Creates and sends a pDEX v3 contribution, the result is a token result when the contributed token is not PRV",
+		Params:  txWithMetadataParamSpecs("reqInfo"),
+		Result:  nil,
+	},
+	pdexv3WithdrawLiquidityV3: {
+		Summary: "Creates and sends a pDEX v3 liquidity withdrawal transaction",
+		Params:  txWithMetadataParamSpecs("reqInfo"),
+		Result:  jsonresult.CreateTransactionTokenResult{},
+	},
+	pdexv3TxTrade: {
+		Summary: "Creates and sends a pDEX v3 trade, the result is a token result when the sold token is not PRV",
+		Params:  txWithMetadataParamSpecs("reqInfo"),
+		Result:  nil,
+	},
+	pdexv3TxAddOrder: {
+		Summary: "Creates and sends a pDEX v3 order, the result is a token result when the sold token is not PRV",
+		Params:  txWithMetadataParamSpecs("reqInfo"),
+		Result:  nil,
+	},
+	pdexv3Staking: {
+		Summary: "Creates and sends a pDEX v3 staking transaction, the result is a token result when the staked token is not PRV",
+		Params:  txWithMetadataParamSpecs("reqInfo"),
+		Result:  nil,
+	},
+	pdexv3Unstaking: {
+		Summary: "Creates and sends a pDEX v3 unstaking transaction",
+		Params:  txWithMetadataParamSpecs("reqInfo"),
+		Result:  jsonresult.CreateTransactionTokenResult{},
+	},
+
+	// portal v4
+	createAndSendTxWithShieldingRequest: {
+		Summary: "Creates and sends a portal v4 shielding request",
+		Params:  txWithMetadataParamSpecs("reqInfo"),
+		Result:  jsonresult.CreateTransactionResult{},
+	},
+	getPortalShieldingRequestStatus: {
+		Summary: "Returns the status of a portal v4 shielding request by its ReqTxID",
+		Params:  []rpcParamSpec{{Name: "data", Value: map[string]interface{}{}}},
+		Result:  (*metadata.PortalShieldingRequestStatus)(nil),
+	},
+	createAndSendTxWithPortalV4UnshieldRequest: {
+		Summary: "Creates and sends a portal v4 unshielding request",
+		Params:  txWithMetadataParamSpecs("reqInfo"),
+		Result:  jsonresult.CreateTransactionTokenResult{},
+	},
+	getPortalUnshieldingRequestStatus: {
+		Summary: "Returns the status of a portal v4 unshielding request by its UnshieldID",
+		Params:  []rpcParamSpec{{Name: "data", Value: map[string]interface{}{}}},
+		Result:  (*metadata.PortalUnshieldRequestStatus)(nil),
+	},
+}
+
+// txWithMetadataParamSpecs are the params of the PRV transactions with metadata
+func txWithMetadataParamSpecs(metadataName string) []rpcParamSpec {
+	return []rpcParamSpec{
+		{Name: "privateKey", Value: ""},
+		{Name: "receivers", Value: map[string]interface{}{}},
+		{Name: "fee", Value: float64(0)},
+		{Name: "privacy", Value: float64(0)},
+		{Name: metadataName, Value: map[string]interface{}{}},
+	}
+}
+
+// tokenTxParamSpecs are the params of the token transactions, the PRV fee params follow the token params
+func tokenTxParamSpecs(tokenParamName string) []rpcParamSpec {
+	return append(txWithMetadataParamSpecs(tokenParamName),
+		rpcParamSpec{Name: "info", Value: ""},
+		rpcParamSpec{Name: "hasPrivacyToken", Value: float64(0)},
+	)
+}
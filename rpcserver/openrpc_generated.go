@@ -0,0 +1,2205 @@
+// Code generated by utility/genrpcspecs from the handlers of rpcserver. DO NOT EDIT.
+
+package rpcserver
+
+import (
+	"github.com/btcsuite/btcd/wire"
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/blockchain/bridgeagg"
+	"github.com/incognitochain/incognito-chain/blockchain/pdex"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/common/consensus"
+	"github.com/incognitochain/incognito-chain/config"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/stats"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/metadata/pdexv3"
+	"github.com/incognitochain/incognito-chain/portal/portalv4"
+	"github.com/incognitochain/incognito-chain/pruner"
+	"github.com/incognitochain/incognito-chain/relaying/btc"
+	"github.com/incognitochain/incognito-chain/rpcserver/jsonresult"
+	"github.com/incognitochain/incognito-chain/wallet"
+	"github.com/tendermint/tendermint/types"
+)
+
+// generatedRPCMethodSpecs declares the methods of the handlers without spec in rpcMethodSpecs,
+// from the params their handler reads and the result it returns
+var generatedRPCMethodSpecs = map[string]rpcMethodSpec{
+	authorizedSubmitKey: {
+		Summary: "is the advanced version of the handleSubmitKey",
+		Params: []rpcParamSpec{
+			{Name: "key", Value: ""},
+			{Name: "accessToken", Value: ""},
+			{Name: "tmpSyncFrom", Value: float64(0), Optional: true},
+			{Name: "isReset", Value: false, Optional: true},
+		},
+		Result: false,
+	},
+	bridgeaggBurnForCall: {
+		Params: []rpcParamSpec{
+			{Name: "privateKey", Value: ""},
+			{Name: "param1", Value: nil},
+			{Name: "param2", Value: nil},
+			{Name: "privacyDetect", Value: float64(0)},
+		},
+		Result: nil,
+	},
+	bridgeaggConvert: {
+		Params: []rpcParamSpec{
+			{Name: "privateKey", Value: ""},
+			{Name: "param1", Value: nil},
+			{Name: "param2", Value: nil},
+			{Name: "privacyDetect", Value: float64(0)},
+		},
+		Result: nil,
+	},
+	bridgeaggEstimateFeeByBurntAmount: {
+		Params: []rpcParamSpec{
+			{Name: "param0", Value: nil},
+		},
+		Result: nil,
+	},
+	bridgeaggEstimateFeeByExpectedAmount: {
+		Params: []rpcParamSpec{
+			{Name: "param0", Value: nil},
+		},
+		Result: nil,
+	},
+	bridgeaggEstimateReward: {
+		Params: []rpcParamSpec{
+			{Name: "param0", Value: nil},
+		},
+		Result: nil,
+	},
+	bridgeaggGetBurnProof: {
+		Params: []rpcParamSpec{
+			{Name: "param0", Value: nil},
+		},
+		Result: jsonresult.GetInstructionProof{},
+	},
+	bridgeaggState: {
+		Params: []rpcParamSpec{
+			{Name: "data", Value: (map[string]interface{})(nil)},
+		},
+		Result: nil,
+	},
+	bridgeaggStatusConvert: {
+		Params: []rpcParamSpec{
+			{Name: "s", Value: ""},
+		},
+		Result: bridgeagg.ConvertStatus{},
+	},
+	bridgeaggStatusShield: {
+		Params: []rpcParamSpec{
+			{Name: "s", Value: ""},
+		},
+		Result: bridgeagg.ShieldStatus{},
+	},
+	bridgeaggStatusUnshield: {
+		Params: []rpcParamSpec{
+			{Name: "s", Value: ""},
+		},
+		Result: bridgeagg.UnshieldStatus{},
+	},
+	bridgeaggModifyParam: {
+		Params: []rpcParamSpec{
+			{Name: "privateKey", Value: ""},
+			{Name: "param1", Value: nil},
+			{Name: "param2", Value: nil},
+			{Name: "privacyDetect", Value: float64(0)},
+		},
+		Result: nil,
+	},
+	bridgeaggShield: {
+		Params: []rpcParamSpec{
+			{Name: "privateKey", Value: ""},
+			{Name: "param1", Value: nil},
+			{Name: "param2", Value: nil},
+			{Name: "privacyDetect", Value: float64(0)},
+		},
+		Result: nil,
+	},
+	bridgeaggStatusModifyParam: {
+		Params: []rpcParamSpec{
+			{Name: "s", Value: ""},
+		},
+		Result: bridgeagg.ModifyParamStatus{},
+	},
+	bridgeaggUnshield: {
+		Params: []rpcParamSpec{
+			{Name: "privateKey", Value: ""},
+			{Name: "param1", Value: nil},
+			{Name: "param2", Value: nil},
+			{Name: "privacyDetect", Value: float64(0)},
+		},
+		Result: nil,
+	},
+	canPubkeyStake: {
+		Summary: "Tell a public key can stake or not",
+		Params: []rpcParamSpec{
+			{Name: "publicKey", Value: ""},
+		},
+		Result: (*jsonresult.StakeResult)(nil),
+	},
+	checkBSCHashIssued: {
+		Params: []rpcParamSpec{
+			{Name: "data", Value: (map[string]interface{})(nil)},
+		},
+		Result: false,
+	},
+	checkETHHashIssued: {
+		Params: []rpcParamSpec{
+			{Name: "data", Value: (map[string]interface{})(nil)},
+		},
+		Result: false,
+	},
+	checkHashValue: {
+		Params: []rpcParamSpec{
+			{Name: "hashParams", Value: ""},
+		},
+		Result: jsonresult.HashValueDetail{},
+	},
+	checkPRVPeggingHashIssued: {
+		Params: []rpcParamSpec{
+			{Name: "data", Value: (map[string]interface{})(nil)},
+		},
+		Result: false,
+	},
+	checkPortalExternalHashSubmitted: {
+		Summary: "Check external txHash submitted or not",
+		Params: []rpcParamSpec{
+			{Name: "data", Value: (map[string]interface{})(nil)},
+		},
+		Result: false,
+	},
+	checkPruneData: {
+		Result: (map[int]bool)(nil),
+	},
+	convertExchangeRates: {
+		Params: []rpcParamSpec{
+			{Name: "data", Value: (map[string]interface{})(nil)},
+		},
+		Result: uint64(0),
+	},
+	convertNativeTokenToPrivacyToken: {
+		Params: []rpcParamSpec{
+			{Name: "data", Value: (map[string]interface{})(nil)},
+		},
+		Result: float64(0),
+	},
+	convertPaymentAddress: {
+		Params: []rpcParamSpec{
+			{Name: "address", Value: ""},
+		},
+		Result: "",
+	},
+	convertPDEPrices: {
+		Params: []rpcParamSpec{
+			{Name: "data", Value: (map[string]interface{})(nil)},
+		},
+		Result: ([]*ConvertedPrice)(nil),
+	},
+	convertPrivacyTokenToNativeToken: {
+		Params: []rpcParamSpec{
+			{Name: "data", Value: (map[string]interface{})(nil)},
+		},
+		Result: float64(0),
+	},
+	createAndSendBurningBSCRequest: {
+		Params: []rpcParamSpec{
+			{Name: "senderPrivateKeyParam", Value: ""},
+			{Name: "param1", Value: nil},
+			{Name: "param2", Value: nil},
+			{Name: "param3", Value: nil},
+			{Name: "tokenParamsRaw", Value: (map[string]interface{})(nil)},
+			{Name: "param5", Value: nil, Optional: true},
+			{Name: "privacyTemp", Value: float64(0), Optional: true},
+		},
+		Result: nil,
+	},
+	createAndSendBurningForDepositToSCRequest: {
+		Params: []rpcParamSpec{
+			{Name: "senderPrivateKeyParam", Value: ""},
+			{Name: "param1", Value: nil},
+			{Name: "param2", Value: nil},
+			{Name: "param3", Value: nil},
+			{Name: "tokenParamsRaw", Value: (map[string]interface{})(nil)},
+			{Name: "param5", Value: nil, Optional: true},
+			{Name: "privacyTemp", Value: float64(0), Optional: true},
+		},
+		Result: nil,
+	},
+	createAndSendBurningForDepositToSCRequestV2: {
+		Params: []rpcParamSpec{
+			{Name: "senderPrivateKeyParam", Value: ""},
+			{Name: "param1", Value: nil},
+			{Name: "param2", Value: nil},
+			{Name: "param3", Value: nil},
+			{Name: "tokenParamsRaw", Value: (map[string]interface{})(nil)},
+			{Name: "param5", Value: nil, Optional: true},
+			{Name: "privacyTemp", Value: float64(0), Optional: true},
+		},
+		Result: nil,
+	},
+	createAndSendBurningFTMForDepositToSCRequest: {
+		Params: []rpcParamSpec{
+			{Name: "senderPrivateKeyParam", Value: ""},
+			{Name: "param1", Value: nil},
+			{Name: "param2", Value: nil},
+			{Name: "param3", Value: nil},
+			{Name: "tokenParamsRaw", Value: (map[string]interface{})(nil)},
+			{Name: "param5", Value: nil, Optional: true},
+			{Name: "privacyTemp", Value: float64(0), Optional: true},
+		},
+		Result: nil,
+	},
+	createAndSendBurningFTMRequest: {
+		Params: []rpcParamSpec{
+			{Name: "senderPrivateKeyParam", Value: ""},
+			{Name: "param1", Value: nil},
+			{Name: "param2", Value: nil},
+			{Name: "param3", Value: nil},
+			{Name: "tokenParamsRaw", Value: (map[string]interface{})(nil)},
+			{Name: "param5", Value: nil, Optional: true},
+			{Name: "privacyTemp", Value: float64(0), Optional: true},
+		},
+		Result: nil,
+	},
+	createAndSendBurningPBSCForDepositToSCRequest: {
+		Params: []rpcParamSpec{
+			{Name: "senderPrivateKeyParam", Value: ""},
+			{Name: "param1", Value: nil},
+			{Name: "param2", Value: nil},
+			{Name: "param3", Value: nil},
+			{Name: "tokenParamsRaw", Value: (map[string]interface{})(nil)},
+			{Name: "param5", Value: nil, Optional: true},
+			{Name: "privacyTemp", Value: float64(0), Optional: true},
+		},
+		Result: nil,
+	},
+	createAndSendBurningPLGForDepositToSCRequest: {
+		Params: []rpcParamSpec{
+			{Name: "senderPrivateKeyParam", Value: ""},
+			{Name: "param1", Value: nil},
+			{Name: "param2", Value: nil},
+			{Name: "param3", Value: nil},
+			{Name: "tokenParamsRaw", Value: (map[string]interface{})(nil)},
+			{Name: "param5", Value: nil, Optional: true},
+			{Name: "privacyTemp", Value: float64(0), Optional: true},
+		},
+		Result: nil,
+	},
+	createAndSendBurningPLGRequest: {
+		Params: []rpcParamSpec{
+			{Name: "senderPrivateKeyParam", Value: ""},
+			{Name: "param1", Value: nil},
+			{Name: "param2", Value: nil},
+			{Name: "param3", Value: nil},
+			{Name: "tokenParamsRaw", Value: (map[string]interface{})(nil)},
+			{Name: "param5", Value: nil, Optional: true},
+			{Name: "privacyTemp", Value: float64(0), Optional: true},
+		},
+		Result: nil,
+	},
+	createAndSendBurningPRVBEP20Request: {
+		Params: []rpcParamSpec{
+			{Name: "senderPrivateKeyParam", Value: ""},
+			{Name: "param1", Value: nil},
+			{Name: "param2", Value: nil},
+			{Name: "param3", Value: nil},
+			{Name: "tokenParamsRaw", Value: (map[string]interface{})(nil)},
+			{Name: "param5", Value: nil, Optional: true},
+			{Name: "privacyTemp", Value: float64(0), Optional: true},
+		},
+		Result: nil,
+	},
+	createAndSendBurningPRVERC20Request: {
+		Params: []rpcParamSpec{
+			{Name: "senderPrivateKeyParam", Value: ""},
+			{Name: "param1", Value: nil},
+			{Name: "param2", Value: nil},
+			{Name: "param3", Value: nil},
+			{Name: "tokenParamsRaw", Value: (map[string]interface{})(nil)},
+			{Name: "param5", Value: nil, Optional: true},
+			{Name: "privacyTemp", Value: float64(0), Optional: true},
+		},
+		Result: nil,
+	},
+	createAndSendBurningRequest: {
+		Params: []rpcParamSpec{
+			{Name: "senderPrivateKeyParam", Value: ""},
+			{Name: "param1", Value: nil},
+			{Name: "param2", Value: nil},
+			{Name: "param3", Value: nil},
+			{Name: "tokenParamsRaw", Value: (map[string]interface{})(nil)},
+			{Name: "param5", Value: nil, Optional: true},
+			{Name: "privacyTemp", Value: float64(0), Optional: true},
+		},
+		Result: nil,
+	},
+	createAndSendBurningRequestV2: {
+		Result: nil,
+	},
+	createAndSendContractingRequest: {
+		Params: []rpcParamSpec{
+			{Name: "senderPrivateKeyParam", Value: ""},
+			{Name: "param1", Value: nil},
+			{Name: "param2", Value: nil},
+			{Name: "param3", Value: nil},
+			{Name: "tokenParamsRaw", Value: (map[string]interface{})(nil)},
+			{Name: "param5", Value: nil, Optional: true},
+			{Name: "privacyTemp", Value: float64(0), Optional: true},
+		},
+		Result: nil,
+	},
+	createAndSendContractingRequestV2: {
+		Params: []rpcParamSpec{
+			{Name: "senderPrivateKeyParam", Value: ""},
+			{Name: "param1", Value: nil},
+			{Name: "param2", Value: nil},
+			{Name: "param3", Value: nil},
+			{Name: "tokenParamsRaw", Value: (map[string]interface{})(nil)},
+			{Name: "param5", Value: nil, Optional: true},
+			{Name: "privacyTemp", Value: float64(0), Optional: true},
+		},
+		Result: nil,
+	},
+	createAndSendCustodianTopup: {
+		Params: []rpcParamSpec{
+			{Name: "param0", Value: nil},
+			{Name: "param1", Value: nil},
+			{Name: "param2", Value: nil},
+			{Name: "param3", Value: nil},
+			{Name: "data", Value: (map[string]interface{})(nil)},
+		},
+		Result: nil,
+	},
+	createAndSendCustodianTopupV3: {
+		Params: []rpcParamSpec{
+			{Name: "param0", Value: nil},
+			{Name: "param1", Value: nil},
+			{Name: "param2", Value: nil},
+			{Name: "param3", Value: nil},
+			{Name: "data", Value: (map[string]interface{})(nil)},
+		},
+		Result: nil,
+	},
+	createAndSendCustodianWithdrawRequest: {
+		Params: []rpcParamSpec{
+			{Name: "param0", Value: nil},
+			{Name: "param1", Value: nil},
+			{Name: "param2", Value: nil},
+			{Name: "param3", Value: nil},
+			{Name: "data", Value: (map[string]interface{})(nil)},
+		},
+		Result: jsonresult.CreateTransactionResult{},
+	},
+	createAndSendIssuingRequest: {
+		Summary: "for user to buy Constant (using USD) or BANK token (using USD/ETH) from DCB",
+		Result:  nil,
+	},
+	createAndSendIssuingRequestV2: {
+		Summary: "for user to buy Constant (using USD) or BANK token (using USD/ETH) from DCB",
+		Result:  nil,
+	},
+	createAndSendPortalExchangeRates: {
+		Params: []rpcParamSpec{
+			{Name: "param0", Value: nil},
+			{Name: "param1", Value: nil},
+			{Name: "param2", Value: nil},
+			{Name: "param3", Value: nil},
+			{Name: "data", Value: (map[string]interface{})(nil)},
+		},
+		Result: jsonresult.CreateTransactionResult{},
+	},
+	createAndSendRegisterPortingPublicTokens: {
+		Params: []rpcParamSpec{
+			{Name: "param0", Value: nil},
+			{Name: "param1", Value: nil},
+			{Name: "param2", Value: nil},
+			{Name: "param3", Value: nil},
+			{Name: "data", Value: (map[string]interface{})(nil)},
+		},
+		Result: jsonresult.CreateTransactionResult{},
+	},
+	createAndSendStakingTransactionV2: {
+		Summary: "RPC creates staking transaction and send to network",
+		Params: []rpcParamSpec{
+			{Name: "param0", Value: nil},
+			{Name: "param1", Value: nil},
+			{Name: "param2", Value: nil},
+			{Name: "param3", Value: nil},
+			{Name: "data", Value: (map[string]interface{})(nil)},
+		},
+		Result: jsonresult.CreateTransactionResult{},
+	},
+	createAndSendStopAutoStakingTransactionV2: {
+		Params: []rpcParamSpec{
+			{Name: "param0", Value: nil},
+			{Name: "param1", Value: nil},
+			{Name: "param2", Value: nil},
+			{Name: "param3", Value: nil},
+			{Name: "data", Value: (map[string]interface{})(nil)},
+		},
+		Result: jsonresult.CreateTransactionResult{},
+	},
+	createAndSendTopUpWaitingPorting: {
+		Params: []rpcParamSpec{
+			{Name: "param0", Value: nil},
+			{Name: "param1", Value: nil},
+			{Name: "param2", Value: nil},
+			{Name: "param3", Value: nil},
+			{Name: "data", Value: (map[string]interface{})(nil)},
+		},
+		Result: nil,
+	},
+	createAndSendTopUpWaitingPortingV3: {
+		Params: []rpcParamSpec{
+			{Name: "param0", Value: nil},
+			{Name: "param1", Value: nil},
+			{Name: "param2", Value: nil},
+			{Name: "param3", Value: nil},
+			{Name: "data", Value: (map[string]interface{})(nil)},
+		},
+		Result: nil,
+	},
+	createAndSendTransactionV2: {
+		Result: jsonresult.CreateTransactionResult{},
+	},
+	createAndSendTxPortalConvertVaultRequest: {
+		Params: []rpcParamSpec{
+			{Name: "param0", Value: nil},
+			{Name: "param1", Value: nil},
+			{Name: "param2", Value: nil},
+			{Name: "param3", Value: nil},
+			{Name: "data", Value: (map[string]interface{})(nil)},
+		},
+		Result: jsonresult.CreateTransactionResult{},
+	},
+	createAndSendTxRedeemFromLiquidationPoolV3: {
+		Params: []rpcParamSpec{
+			{Name: "param0", Value: nil},
+			{Name: "param1", Value: nil},
+			{Name: "param2", Value: nil},
+			{Name: "param3", Value: nil},
+			{Name: "tokenParamsRaw", Value: (map[string]interface{})(nil)},
+			{Name: "param5", Value: nil, Optional: true},
+			{Name: "hasPrivacyTokenParam", Value: float64(0), Optional: true},
+		},
+		Result: nil,
+	},
+	createAndSendTxWithCustodianDeposit: {
+		Params: []rpcParamSpec{
+			{Name: "param0", Value: nil},
+			{Name: "param1", Value: nil},
+			{Name: "param2", Value: nil},
+			{Name: "param3", Value: nil},
+			{Name: "data", Value: (map[string]interface{})(nil)},
+		},
+		Result: jsonresult.CreateTransactionResult{},
+	},
+	createAndSendTxWithCustodianDepositV3: {
+		Params: []rpcParamSpec{
+			{Name: "param0", Value: nil},
+			{Name: "param1", Value: nil},
+			{Name: "param2", Value: nil},
+			{Name: "param3", Value: nil},
+			{Name: "data", Value: (map[string]interface{})(nil)},
+		},
+		Result: jsonresult.CreateTransactionResult{},
+	},
+	createAndSendTxWithCustodianWithdrawRequestV3: {
+		Params: []rpcParamSpec{
+			{Name: "param0", Value: nil},
+			{Name: "param1", Value: nil},
+			{Name: "param2", Value: nil},
+			{Name: "param3", Value: nil},
+			{Name: "data", Value: (map[string]interface{})(nil)},
+		},
+		Result: jsonresult.CreateTransactionResult{},
+	},
+	createAndSendTxWithIssuingBSCReq: {
+		Params: []rpcParamSpec{
+			{Name: "param0", Value: nil},
+			{Name: "param1", Value: nil},
+			{Name: "param2", Value: nil},
+			{Name: "param3", Value: nil},
+			{Name: "data", Value: (map[string]interface{})(nil)},
+		},
+		Result: jsonresult.CreateTransactionResult{},
+	},
+	createAndSendTxWithIssuingETHReq: {
+		Params: []rpcParamSpec{
+			{Name: "param0", Value: nil},
+			{Name: "param1", Value: nil},
+			{Name: "param2", Value: nil},
+			{Name: "param3", Value: nil},
+			{Name: "data", Value: (map[string]interface{})(nil)},
+		},
+		Result: jsonresult.CreateTransactionResult{},
+	},
+	createAndSendTxWithIssuingETHReqV2: {
+		Params: []rpcParamSpec{
+			{Name: "param0", Value: nil},
+			{Name: "param1", Value: nil},
+			{Name: "param2", Value: nil},
+			{Name: "param3", Value: nil},
+			{Name: "data", Value: (map[string]interface{})(nil)},
+		},
+		Result: jsonresult.CreateTransactionResult{},
+	},
+	createAndSendTxWithIssuingFTMReq: {
+		Params: []rpcParamSpec{
+			{Name: "param0", Value: nil},
+			{Name: "param1", Value: nil},
+			{Name: "param2", Value: nil},
+			{Name: "param3", Value: nil},
+			{Name: "data", Value: (map[string]interface{})(nil)},
+		},
+		Result: jsonresult.CreateTransactionResult{},
+	},
+	createAndSendTxWithIssuingPLGReq: {
+		Params: []rpcParamSpec{
+			{Name: "param0", Value: nil},
+			{Name: "param1", Value: nil},
+			{Name: "param2", Value: nil},
+			{Name: "param3", Value: nil},
+			{Name: "data", Value: (map[string]interface{})(nil)},
+		},
+		Result: jsonresult.CreateTransactionResult{},
+	},
+	createAndSendTxWithIssuingPRVBEP20Req: {
+		Params: []rpcParamSpec{
+			{Name: "param0", Value: nil},
+			{Name: "param1", Value: nil},
+			{Name: "param2", Value: nil},
+			{Name: "param3", Value: nil},
+			{Name: "data", Value: (map[string]interface{})(nil)},
+		},
+		Result: jsonresult.CreateTransactionResult{},
+	},
+	createAndSendTxWithIssuingPRVERC20Req: {
+		Params: []rpcParamSpec{
+			{Name: "param0", Value: nil},
+			{Name: "param1", Value: nil},
+			{Name: "param2", Value: nil},
+			{Name: "param3", Value: nil},
+			{Name: "data", Value: (map[string]interface{})(nil)},
+		},
+		Result: jsonresult.CreateTransactionResult{},
+	},
+	createAndSendTxWithPortalReplacementFee: {
+		Params: []rpcParamSpec{
+			{Name: "param0", Value: nil},
+			{Name: "param1", Value: nil},
+			{Name: "param2", Value: nil},
+			{Name: "param3", Value: nil},
+			{Name: "tokenParamsRaw", Value: (map[string]interface{})(nil)},
+		},
+		Result: nil,
+	},
+	createAndSendTxWithPortalSubmitConfirmedTx: {
+		Params: []rpcParamSpec{
+			{Name: "param0", Value: nil},
+			{Name: "param1", Value: nil},
+			{Name: "param2", Value: nil},
+			{Name: "param3", Value: nil},
+			{Name: "tokenParamsRaw", Value: (map[string]interface{})(nil)},
+		},
+		Result: nil,
+	},
+	createAndSendTxWithPRVContribution: {
+		Params: []rpcParamSpec{
+			{Name: "param0", Value: nil},
+			{Name: "param1", Value: nil},
+			{Name: "param2", Value: nil},
+			{Name: "param3", Value: nil},
+			{Name: "data", Value: (map[string]interface{})(nil)},
+		},
+		Result: jsonresult.CreateTransactionResult{},
+	},
+	createAndSendTxWithPTokenContribution: {
+		Params: []rpcParamSpec{
+			{Name: "param0", Value: nil},
+			{Name: "param1", Value: nil},
+			{Name: "param2", Value: nil},
+			{Name: "param3", Value: nil},
+			{Name: "tokenParamsRaw", Value: (map[string]interface{})(nil)},
+			{Name: "param5", Value: nil, Optional: true},
+			{Name: "param6", Value: float64(0), Optional: true},
+		},
+		Result: nil,
+	},
+	createAndSendTxWithRedeemReq: {
+		Params: []rpcParamSpec{
+			{Name: "param0", Value: nil},
+			{Name: "param1", Value: nil},
+			{Name: "param2", Value: nil},
+			{Name: "param3", Value: nil},
+			{Name: "tokenParamsRaw", Value: (map[string]interface{})(nil)},
+			{Name: "param5", Value: nil, Optional: true},
+			{Name: "hasPrivacyTokenParam", Value: float64(0), Optional: true},
+		},
+		Result: nil,
+	},
+	createAndSendTxWithRelayingBNBHeader: {
+		Params: []rpcParamSpec{
+			{Name: "param0", Value: nil},
+			{Name: "param1", Value: nil},
+			{Name: "param2", Value: nil},
+			{Name: "param3", Value: nil},
+			{Name: "data", Value: (map[string]interface{})(nil)},
+		},
+		Result: jsonresult.CreateTransactionResult{},
+	},
+	createAndSendTxWithRelayingBTCHeader: {
+		Params: []rpcParamSpec{
+			{Name: "param0", Value: nil},
+			{Name: "param1", Value: nil},
+			{Name: "param2", Value: nil},
+			{Name: "param3", Value: nil},
+			{Name: "data", Value: (map[string]interface{})(nil)},
+		},
+		Result: jsonresult.CreateTransactionResult{},
+	},
+	createAndSendTxWithRelayingLTCHeader: {
+		Params: []rpcParamSpec{
+			{Name: "param0", Value: nil},
+			{Name: "param1", Value: nil},
+			{Name: "param2", Value: nil},
+			{Name: "param3", Value: nil},
+			{Name: "data", Value: (map[string]interface{})(nil)},
+		},
+		Result: jsonresult.CreateTransactionResult{},
+	},
+	createAndSendTxWithReqMatchingRedeem: {
+		Params: []rpcParamSpec{
+			{Name: "param0", Value: nil},
+			{Name: "param1", Value: nil},
+			{Name: "param2", Value: nil},
+			{Name: "param3", Value: nil},
+			{Name: "data", Value: (map[string]interface{})(nil)},
+		},
+		Result: jsonresult.CreateTransactionResult{},
+	},
+	createAndSendTxWithReqPToken: {
+		Params: []rpcParamSpec{
+			{Name: "param0", Value: nil},
+			{Name: "param1", Value: nil},
+			{Name: "param2", Value: nil},
+			{Name: "param3", Value: nil},
+			{Name: "data", Value: (map[string]interface{})(nil)},
+		},
+		Result: jsonresult.CreateTransactionResult{},
+	},
+	createAndSendTxWithReqUnlockCollateral: {
+		Params: []rpcParamSpec{
+			{Name: "param0", Value: nil},
+			{Name: "param1", Value: nil},
+			{Name: "param2", Value: nil},
+			{Name: "param3", Value: nil},
+			{Name: "data", Value: (map[string]interface{})(nil)},
+		},
+		Result: jsonresult.CreateTransactionResult{},
+	},
+	createAndSendTxWithReqWithdrawRewardPortal: {
+		Params: []rpcParamSpec{
+			{Name: "param0", Value: nil},
+			{Name: "param1", Value: nil},
+			{Name: "param2", Value: nil},
+			{Name: "param3", Value: nil},
+			{Name: "data", Value: (map[string]interface{})(nil)},
+		},
+		Result: jsonresult.CreateTransactionResult{},
+	},
+	createAndSendUnlockOverRateCollaterals: {
+		Params: []rpcParamSpec{
+			{Name: "param0", Value: nil},
+			{Name: "param1", Value: nil},
+			{Name: "param2", Value: nil},
+			{Name: "param3", Value: nil},
+			{Name: "data", Value: (map[string]interface{})(nil)},
+		},
+		Result: jsonresult.CreateTransactionResult{},
+	},
+	createAndSendTxWithWithdrawalReq: {
+		Params: []rpcParamSpec{
+			{Name: "param0", Value: nil},
+			{Name: "param1", Value: nil},
+			{Name: "param2", Value: nil},
+			{Name: "param3", Value: nil},
+			{Name: "data", Value: (map[string]interface{})(nil)},
+		},
+		Result: jsonresult.CreateTransactionResult{},
+	},
+	createConvertCoinVer1ToVer2Transaction: {
+		Result: jsonresult.CreateTransactionResult{},
+	},
+	createConvertCoinVer1ToVer2TxToken: {
+		Result: (*jsonresult.CreateTransactionResult)(nil),
+	},
+	createIssuingRequest: {
+		Params: []rpcParamSpec{
+			{Name: "privateKeyParam", Value: ""},
+			{Name: "param1", Value: nil},
+			{Name: "param2", Value: nil},
+			{Name: "param3", Value: nil},
+			{Name: "metaRaw", Value: (map[string]interface{})(nil)},
+		},
+		Result: jsonresult.CreateTransactionResult{},
+	},
+	createRawPrivacyCustomTokenTransaction: {
+		Summary: "handle create a custom token command and return in hex string format",
+		Result:  jsonresult.CreateTransactionTokenResult{},
+	},
+	createRawTransaction: {
+		Summary: "handles createtransaction commands",
+		Result:  jsonresult.CreateTransactionResult{},
+	},
+	createUnsignedPrivacyTokenTransaction: {
+		Summary: "handles createunsignedprivacycustomtokentransaction commands",
+		Result:  jsonresult.CreateUnsignedTransactionResult{},
+	},
+	createUnsignedTransaction: {
+		Summary: "handles createunsignedtransaction commands",
+		Result:  jsonresult.CreateUnsignedTransactionResult{},
+	},
+	unstake: {
+		Summary: "RPC create and send unstake tx to network",
+		Params: []rpcParamSpec{
+			{Name: "param0", Value: nil},
+			{Name: "param1", Value: nil},
+			{Name: "param2", Value: nil},
+			{Name: "param3", Value: nil},
+			{Name: "data", Value: (map[string]interface{})(nil)},
+		},
+		Result: jsonresult.CreateTransactionResult{},
+	},
+	decryptoutputcoinbykeyoftransaction: {
+		Params: []rpcParamSpec{
+			{Name: "txIdParam", Value: ""},
+			{Name: "keys", Value: (map[string]interface{})(nil)},
+		},
+		Result: (map[string]interface{})(nil),
+	},
+	defragmentAccountTokenV2: {
+		Summary: "defragment for token",
+		Result:  jsonresult.CreateTransactionResult{},
+	},
+	defragmentAccountV2: {
+		Result: jsonresult.CreateTransactionResult{},
+	},
+	dumpPrivkey: {
+		Summary: "dumpprivkey RPC returns the wallet-import-format (WIP) private key corresponding to an address",
+		Result:  wallet.KeySerializedData{},
+	},
+	enableMining: {
+		Params: []rpcParamSpec{
+			{Name: "enableParam", Value: false},
+			{Name: "validatorKeyParam", Value: ""},
+		},
+		Result: nil,
+	},
+	estimateFee: {
+		Summary: "RPC estimates the transaction fee per kilobyte that needs to be paid for a transaction to be included within a certain number of blocks",
+		Params: []rpcParamSpec{
+			{Name: "senderKeyParam", Value: ""},
+			{Name: "receiversPaymentAddressStrParam", Value: (map[string]interface{})(nil)},
+			{Name: "defaultFeeCoinPerKbtemp", Value: float64(0)},
+			{Name: "hashPrivacyTemp", Value: float64(0)},
+			{Name: "tokenParamsRaw", Value: (map[string]interface{})(nil), Optional: true},
+		},
+		Result: (*jsonresult.EstimateFeeResult)(nil),
+	},
+	estimateFeeWithEstimator: {
+		Summary: "get unit fee (fee per kb) from estimator",
+		Params: []rpcParamSpec{
+			{Name: "defaultFeeCoinPerKbTemp", Value: float64(0)},
+			{Name: "paymentAddressParam", Value: ""},
+			{Name: "numBlockParam", Value: float64(0), Optional: true},
+			{Name: "tokenIdParam", Value: "", Optional: true},
+		},
+		Result: (*jsonresult.EstimateFeeResult)(nil),
+	},
+	exportMetrics: {
+		Result: "",
+	},
+	extractPDEInstsFromBeaconBlock: {
+		Params: []rpcParamSpec{
+			{Name: "data", Value: (map[string]interface{})(nil)},
+		},
+		Result: PDEInfoFromBeaconBlock{},
+	},
+	generatePortalShieldMultisigAddress: {
+		Params: []rpcParamSpec{
+			{Name: "data", Value: (map[string]interface{})(nil)},
+		},
+		Result: "",
+	},
+	generateTokenID: {
+		Params: []rpcParamSpec{
+			{Name: "network", Value: ""},
+			{Name: "tokenName", Value: ""},
+		},
+		Result: "",
+	},
+	getPruneState: {
+		Result: (map[int]pruner.ChainPrunerReport)(nil),
+	},
+	getAccount: {
+		Summary: "getaccount RPC returns the name of the account associated with the given address",
+		Result:  "",
+	},
+	getAccountAddress: {
+		Summary: "getaccountaddress RPC returns the current coin address for receiving payments to this account",
+		Result:  wallet.KeySerializedData{},
+	},
+	getActiveShards: {
+		Summary: "return active shard num",
+		Result:  int(0),
+	},
+	getAddressesByAccount: {
+		Summary: "getaddressesbyaccount RPC returns a list of every address assigned to a particular account",
+		Result:  jsonresult.GetAddressesByAccount{},
+	},
+	getAllBridgeTokens: {
+		Result: nil,
+	},
+	getAllBridgeTokensByHeight: {
+		Params: []rpcParamSpec{
+			{Name: "height", Value: float64(0)},
+		},
+		Result: nil,
+	},
+	getAllConnectedPeers: {
+		Summary: "return all connnected peers which this node connected",
+		Result:  (*jsonresult.GetAllConnectedPeersResult)(nil),
+	},
+	getAllPeers: {
+		Summary: "return all peers which this node connected",
+		Result:  (*jsonresult.GetAllPeersResult)(nil),
+	},
+	getAllView: {
+		Params: []rpcParamSpec{
+			{Name: "shardID", Value: float64(0)},
+			{Name: "numOfBlks", Value: float64(0)},
+		},
+		Result: ([]jsonresult.GetViewResult)(nil),
+	},
+	getAmountTopUpWaitingPorting: {
+		Params: []rpcParamSpec{
+			{Name: "data", Value: (map[string]interface{})(nil)},
+		},
+		Result: (map[string]uint64)(nil),
+	},
+	getAndSendTxsFromFile: {
+		Params: []rpcParamSpec{
+			{Name: "shardIDParam", Value: float64(0)},
+			{Name: "txType", Value: ""},
+			{Name: "isSent", Value: false},
+			{Name: "interval", Value: float64(0)},
+		},
+		Result: CountResult{},
+	},
+	getAndSendTxsFromFileV2: {
+		Params: []rpcParamSpec{
+			{Name: "shardIDParam", Value: float64(0)},
+			{Name: "txType", Value: ""},
+			{Name: "isSent", Value: false},
+			{Name: "interval", Value: float64(0)},
+		},
+		Result: CountResult{},
+	},
+	getAutoEnableFeatureConfig: {
+		Result: (map[string]config.AutoEnableFeature)(nil),
+	},
+	getAutoStakingByHeight: {
+		Params: []rpcParamSpec{
+			{Name: "height", Value: float64(0)},
+		},
+		Result: ([]interface{})(nil),
+	},
+	getBalance: {
+		Summary: "RPC gets the balances in decimal",
+		Params: []rpcParamSpec{
+			{Name: "accountName", Value: ""},
+			{Name: "minTemp", Value: float64(0)},
+			{Name: "passPhrase", Value: ""},
+		},
+		Result: nil,
+	},
+	getBalanceByPaymentAddress: {
+		Summary: "return balance of paymentaddress",
+		Params: []rpcParamSpec{
+			{Name: "paymentAddressParam", Value: ""},
+		},
+		Result: nil,
+	},
+	getBalancePrivacyCustomToken: {
+		Params: []rpcParamSpec{
+			{Name: "privateKey", Value: ""},
+			{Name: "tokenID", Value: ""},
+		},
+		Result: uint64(0),
+	},
+	getBeaconBestStateDetail: {
+		Result: (*jsonresult.GetBeaconBestStateDetail)(nil),
+	},
+	getBeaconPoolInfo: {
+		Result: (*jsonresult.PoolInfo)(nil),
+	},
+	getBeaconSwapProof: {
+		Summary: "returns a proof of a new beacon committee (for a given bridge block height)",
+		Result:  jsonresult.GetInstructionProof{},
+	},
+	getBeaconViewByHash: {
+		Params: []rpcParamSpec{
+			{Name: "blockHashStr", Value: ""},
+		},
+		Result: (*jsonresult.GetBeaconBestState)(nil),
+	},
+	getBestBlock: {
+		Summary: "implements the getbestblock command",
+		Result:  jsonresult.GetBestBlockResult{},
+	},
+	getBestBlockHash: {
+		Summary: "implements the getbestblock command",
+		Result:  jsonresult.GetBestBlockHashResult{},
+	},
+	getBlockCount: {
+		Summary: "getblockcount RPC return information fo blockchain node",
+		Params: []rpcParamSpec{
+			{Name: "paramNumberFloat", Value: float64(0)},
+		},
+		Result: uint64(0),
+	},
+	getBlocks: {
+		Summary: "get n top blocks from chain ID",
+		Params: []rpcParamSpec{
+			{Name: "numBlockTemp", Value: float64(0)},
+			{Name: "shardIDParam", Value: float64(0)},
+		},
+		Result: nil,
+	},
+	getBlocksFromHeight: {
+		Summary: "get n blocks from specific height",
+		Params: []rpcParamSpec{
+			{Name: "chainIDTemp", Value: float64(0)},
+			{Name: "fromHeightTemp", Value: float64(0)},
+			{Name: "numBlockTemp", Value: float64(0)},
+		},
+		Result: nil,
+	},
+	getBridgeReqWithStatus: {
+		Params: []rpcParamSpec{
+			{Name: "data", Value: (map[string]interface{})(nil)},
+		},
+		Result: nil,
+	},
+	getBridgeSwapProof: {
+		Summary: "returns a proof of a new bridge committee (for a given beacon block height)",
+		Result:  jsonresult.GetInstructionProof{},
+	},
+	getBridgeTokenProof: {
+		Summary: "Get the info of a bridge token with its Merkle proof",
+		Params: []rpcParamSpec{
+			{Name: "param0", Value: nil},
+		},
+		Result: (*jsonresult.StateProof)(nil),
+	},
+	getBSCBurnProof: {
+		Summary: "returns a proof of a tx burning pBSC",
+		Result:  jsonresult.GetInstructionProof{},
+	},
+	getBTCBlockByHash: {
+		Params: []rpcParamSpec{
+			{Name: "btcBlockHashStr", Value: ""},
+		},
+		Result: (*wire.MsgBlock)(nil),
+	},
+	getBTCRelayingBestState: {
+		Result: (*btcrelaying.BestState)(nil),
+	},
+	getBurnFTMProofForDepositToSC: {
+		Summary: "returns a proof of a tx burning pFTM to deposit to SC",
+		Result:  jsonresult.GetInstructionProof{},
+	},
+	getBurnPBSCProofForDepositToSC: {
+		Summary: "returns a proof of a tx burning pBSC to deposit to SC",
+		Result:  jsonresult.GetInstructionProof{},
+	},
+	getBurnPLGProofForDepositToSC: {
+		Summary: "returns a proof of a tx burning pPLG to deposit to SC",
+		Result:  jsonresult.GetInstructionProof{},
+	},
+	getBurnProof: {
+		Summary: "returns a proof of a tx burning pETH",
+		Result:  jsonresult.GetInstructionProof{},
+	},
+	getBurnProofForDepositToSC: {
+		Summary: "returns a proof of a tx burning pETH to deposit to SC",
+		Result:  jsonresult.GetInstructionProof{},
+	},
+	getByzantineBlackList: {
+		Result: nil,
+	},
+	getByzantineDetectorInfo: {
+		Result: (map[string]interface{})(nil),
+	},
+	getChainMiningStatus: {
+		Params: []rpcParamSpec{
+			{Name: "chainIDParam", Value: float64(0)},
+		},
+		Result: "",
+	},
+	getCommitteeState: {
+		Params: []rpcParamSpec{
+			{Name: "height", Value: float64(0)},
+			{Name: "tempHash", Value: ""},
+		},
+		Result: (map[string]interface{})(nil),
+	},
+	getCommitteeStateByShard: {
+		Params: []rpcParamSpec{
+			{Name: "shardID", Value: float64(0)},
+			{Name: "tempHash", Value: ""},
+		},
+		Result: (map[string]interface{})(nil),
+	},
+	getCommitteeStateProof: {
+		Summary: "Get the committee entry of a validator key in a role with its Merkle proof",
+		Params: []rpcParamSpec{
+			{Name: "param0", Value: nil},
+		},
+		Result: (*jsonresult.StateProof)(nil),
+	},
+	getConnectionCount: {
+		Summary: "RPC returns the number of connections to other nodes",
+		Result:  int(0),
+	},
+	connectionStatus: {
+		Result: nil,
+	},
+	getConsensusData: {
+		Params: []rpcParamSpec{
+			{Name: "tempChainID", Value: float64(0)},
+		},
+		Result: (map[string]interface{})(nil),
+	},
+	handleGetConsensusInfoV3: {
+		Result: ([]interface{})(nil),
+	},
+	getConsensusRule: {
+		Result: nil,
+	},
+	getCrossShardBlock: {
+		Summary: "This function return the result of cross shard block of a specific block in shard",
+		Params: []rpcParamSpec{
+			{Name: "shardIDParam", Value: float64(0)},
+			{Name: "blockHeightParam", Value: float64(0)},
+		},
+		Result: (map[common.Hash]jsonresult.CrossShardDataResult)(nil),
+	},
+	getCrossShardPoolInfo: {
+		Params: []rpcParamSpec{
+			{Name: "shardID", Value: float64(0)},
+		},
+		Result: (*jsonresult.PoolInfo)(nil),
+	},
+	getCustodianLiquidationStatus: {
+		Params: []rpcParamSpec{
+			{Name: "data", Value: (map[string]interface{})(nil)},
+		},
+		Result: (*metadata.PortalLiquidateCustodianStatus)(nil),
+	},
+	getPortalCustodianTopupStatus: {
+		Params: []rpcParamSpec{
+			{Name: "data", Value: (map[string]interface{})(nil)},
+		},
+		Result: (*metadata.LiquidationCustodianDepositStatusV2)(nil),
+	},
+	getPortalCustodianTopupStatusV3: {
+		Params: []rpcParamSpec{
+			{Name: "data", Value: (map[string]interface{})(nil)},
+		},
+		Result: (*metadata.LiquidationCustodianDepositStatusV3)(nil),
+	},
+	getPortalCustodianTopupWaitingPortingStatus: {
+		Params: []rpcParamSpec{
+			{Name: "data", Value: (map[string]interface{})(nil)},
+		},
+		Result: (*metadata.PortalTopUpWaitingPortingRequestStatus)(nil),
+	},
+	getPortalCustodianTopupWaitingPortingStatusV3: {
+		Params: []rpcParamSpec{
+			{Name: "data", Value: (map[string]interface{})(nil)},
+		},
+		Result: (*metadata.PortalTopUpWaitingPortingRequestStatusV3)(nil),
+	},
+	getCustodianWithdrawByTxId: {
+		Params: []rpcParamSpec{
+			{Name: "data", Value: (map[string]interface{})(nil)},
+		},
+		Result: jsonresult.PortalCustodianWithdrawRequest{},
+	},
+	getCustodianWithdrawRequestStatusV3ByTxId: {
+		Params: []rpcParamSpec{
+			{Name: "data", Value: (map[string]interface{})(nil)},
+		},
+		Result: metadata.CustodianWithdrawRequestStatusV3{},
+	},
+	getDelegationPools: {
+		Summary: "returns the delegation pools of the beacon best state,",
+		Params: []rpcParamSpec{
+			{Name: "committeePublicKey", Value: "", Optional: true},
+		},
+		Result: ([]*statedb.DelegationPoolState)(nil),
+	},
+	getDetailBlocksOfEpoch: {
+		Params: []rpcParamSpec{
+			{Name: "shardID", Value: float64(0)},
+			{Name: "epoch", Value: float64(0)},
+		},
+		Result: (map[uint64]*stats.DetailBlockInOneEpochStats)(nil),
+	},
+	getEncodedTransactionsByHashes: {
+		Summary: "handles the request getEncodedTransactionByHashes",
+		Params: []rpcParamSpec{
+			{Name: "paramList", Value: (map[string]interface{})(nil)},
+		},
+		Result: nil,
+	},
+	getETHHeaderByHash: {
+		Params: []rpcParamSpec{
+			{Name: "ethBlockHash", Value: ""},
+		},
+		Result: nil,
+	},
+	getFeatureStats: {
+		Result: blockchain.FeatureReportInfo{},
+	},
+	getFinalityProof: {
+		Params: []rpcParamSpec{
+			{Name: "tempShardID", Value: float64(0)},
+			{Name: "tempHash", Value: ""},
+		},
+		Result: (map[string]interface{})(nil),
+	},
+	getFTMBurnProof: {
+		Summary: "returns a proof of a tx burning pFTM ( Fantom )",
+		Result:  jsonresult.GetInstructionProof{},
+	},
+	getBlockHeader: {
+		Summary: "return block header data",
+		Params: []rpcParamSpec{
+			{Name: "getBy", Value: ""},
+			{Name: "block", Value: ""},
+			{Name: "shardID", Value: float64(0)},
+		},
+		Result: ([]jsonresult.GetHeaderResult)(nil),
+	},
+	getIncognitoPublicKeyRole: {
+		Params: []rpcParamSpec{
+			{Name: "keyParam", Value: ""},
+		},
+		Result: (*struct {
+			Role     int
+			IsBeacon bool
+			ShardID  int
+		})(nil),
+	},
+	getInOutMessageCount: {
+		Summary: "return all inbound/outbound message count by peer which this node connected",
+		Result:  (*jsonresult.GetInOutMessageCountResult)(nil),
+	},
+	getInOutMessages: {
+		Summary: "return all inbound/outbound messages peer which this node connected",
+		Result:  (*jsonresult.GetInOutMessageResult)(nil),
+	},
+	getKeySubmissionInfo: {
+		Summary: "is the advanced version of the handleSubmitKey",
+		Params: []rpcParamSpec{
+			{Name: "keyStr", Value: ""},
+		},
+		Result: int(0),
+	},
+	getLatestBackup: {
+		Result: nil,
+	},
+	getLatestBeaconSwapProof: {
+		Summary: "returns the latest proof of a change in bridge's committee",
+		Result:  nil,
+	},
+	getLatestBNBHeaderBlockHeight: {
+		Result: int64(0),
+	},
+	getLatestBridgeSwapProof: {
+		Summary: "returns the latest proof of a change in bridge's committee",
+		Result:  nil,
+	},
+	getLiquidationExchangeRatesPool: {
+		Summary: "Portal liquidation pool",
+		Params: []rpcParamSpec{
+			{Name: "data", Value: (map[string]interface{})(nil)},
+		},
+		Result: jsonresult.GetLiquidateExchangeRates{},
+	},
+	getLTCBlockByHash: {
+		Params: []rpcParamSpec{
+			{Name: "ltcBlockHashStr", Value: ""},
+		},
+		Result: (*wire.MsgBlock)(nil),
+	},
+	getLTCRelayingBestState: {
+		Result: (*btcrelaying.BestState)(nil),
+	},
+	getMaxShardsNumber: {
+		Result: int(0),
+	},
+	getMempoolEntry: {
+		Summary: "RPC fetch a specific transaction from the mempool",
+		Result:  (*jsonresult.TransactionDetail)(nil),
+	},
+	getMinerRewardFromMiningKey: {
+		Params: []rpcParamSpec{
+			{Name: "keyParam", Value: ""},
+		},
+		Result: (map[string]uint64)(nil),
+	},
+	getNetworkInfo: {
+		Result: (*jsonresult.GetNetworkInfoResult)(nil),
+	},
+	getNodeRole: {
+		Result: "",
+	},
+	getNumberOfTxsInMempool: {
+		Result: int(0),
+	},
+	getOTACoinLength: {
+		Result: nil,
+	},
+	getOTACoinsByIndices: {
+		Params: []rpcParamSpec{
+			{Name: "paramList", Value: (map[string]interface{})(nil)},
+		},
+		Result: nil,
+	},
+	getPDEContributionStatus: {
+		Params: []rpcParamSpec{
+			{Name: "data", Value: (map[string]interface{})(nil)},
+		},
+		Result: byte(0),
+	},
+	getPDEContributionStatusV2: {
+		Params: []rpcParamSpec{
+			{Name: "data", Value: (map[string]interface{})(nil)},
+		},
+		Result: (*metadata.PDEContributionStatus)(nil),
+	},
+	getPDEFeeWithdrawalStatus: {
+		Params: []rpcParamSpec{
+			{Name: "data", Value: (map[string]interface{})(nil)},
+		},
+		Result: byte(0),
+	},
+	getPDETradeStatus: {
+		Params: []rpcParamSpec{
+			{Name: "data", Value: (map[string]interface{})(nil)},
+		},
+		Result: byte(0),
+	},
+	getPDEWithdrawalStatus: {
+		Params: []rpcParamSpec{
+			{Name: "data", Value: (map[string]interface{})(nil)},
+		},
+		Result: byte(0),
+	},
+	getPendingTxsInBlockgen: {
+		Summary: "RPC returns all transaction ids in blockgen",
+		Result:  jsonresult.GetPendingTxsInBlockgenResult{},
+	},
+	getPLGBurnProof: {
+		Summary: "returns a proof of a tx burning pPLG ( polygon )",
+		Result:  jsonresult.GetInstructionProof{},
+	},
+	getPortalBatchUnshieldingRequestStatus: {
+		Params: []rpcParamSpec{
+			{Name: "data", Value: (map[string]interface{})(nil)},
+		},
+		Result: (*metadata.PortalUnshieldRequestBatchStatus)(nil),
+	},
+	getPortalConvertVaultTxStatus: {
+		Params: []rpcParamSpec{
+			{Name: "data", Value: (map[string]interface{})(nil)},
+		},
+		Result: (*metadata.PortalConvertVaultRequestStatus)(nil),
+	},
+	getPortalCustodianDepositStatus: {
+		Params: []rpcParamSpec{
+			{Name: "data", Value: (map[string]interface{})(nil)},
+		},
+		Result: (*metadata.PortalCustodianDepositStatus)(nil),
+	},
+	getPortalCustodianDepositStatusV3: {
+		Params: []rpcParamSpec{
+			{Name: "data", Value: (map[string]interface{})(nil)},
+		},
+		Result: (*metadata.PortalCustodianDepositStatusV3)(nil),
+	},
+	getPortalFinalExchangeRates: {
+		Params: []rpcParamSpec{
+			{Name: "data", Value: (map[string]interface{})(nil)},
+		},
+		Result: jsonresult.FinalExchangeRatesResult{},
+	},
+	getPortalPortingRequestByKey: {
+		Params: []rpcParamSpec{
+			{Name: "data", Value: (map[string]interface{})(nil)},
+		},
+		Result: jsonresult.PortalPortingRequest{},
+	},
+	getPortalPortingRequestByPortingId: {
+		Params: []rpcParamSpec{
+			{Name: "data", Value: (map[string]interface{})(nil)},
+		},
+		Result: jsonresult.PortalPortingRequest{},
+	},
+	getPortalReplacementFeeStatus: {
+		Params: []rpcParamSpec{
+			{Name: "data", Value: (map[string]interface{})(nil)},
+		},
+		Result: (*metadata.PortalReplacementFeeRequestStatus)(nil),
+	},
+	getPortalReqPTokenStatus: {
+		Params: []rpcParamSpec{
+			{Name: "data", Value: (map[string]interface{})(nil)},
+		},
+		Result: (*metadata.PortalRequestPTokensStatus)(nil),
+	},
+	getPortalReqRedeemStatus: {
+		Params: []rpcParamSpec{
+			{Name: "data", Value: (map[string]interface{})(nil)},
+		},
+		Result: (*metadata.PortalRedeemRequestStatus)(nil),
+	},
+	getPortalReqUnlockCollateralStatus: {
+		Params: []rpcParamSpec{
+			{Name: "data", Value: (map[string]interface{})(nil)},
+		},
+		Result: (*metadata.PortalRequestUnlockCollateralStatus)(nil),
+	},
+	getPortalReward: {
+		Params: []rpcParamSpec{
+			{Name: "data", Value: (map[string]interface{})(nil)},
+		},
+		Result: (map[string]uint64)(nil),
+	},
+	getSignedRawReplaceFeeTransaction: {
+		Result: getSignedTxResult{},
+	},
+	getSignedRawTransactionByBatchID: {
+		Summary: "Get raw signed tx",
+		Result:  getSignedTxResult{},
+	},
+	getPortalState: {
+		Summary: "Portal state",
+		Params: []rpcParamSpec{
+			{Name: "data", Value: (map[string]interface{})(nil)},
+		},
+		Result: nil,
+	},
+	getPortalSubmitConfirmedTx: {
+		Params: []rpcParamSpec{
+			{Name: "data", Value: (map[string]interface{})(nil)},
+		},
+		Result: (*metadata.PortalSubmitConfirmedTxStatus)(nil),
+	},
+	getPortalUnlockOverRateCollateralsStatus: {
+		Params: []rpcParamSpec{
+			{Name: "data", Value: (map[string]interface{})(nil)},
+		},
+		Result: (*metadata.UnlockOverRateCollateralsRequestStatus)(nil),
+	},
+	getPortalV4Params: {
+		Params: []rpcParamSpec{
+			{Name: "data", Value: (map[string]interface{})(nil)},
+		},
+		Result: portalv4.PortalParams{},
+	},
+	getPortalV4State: {
+		Summary: "Get Portal State",
+		Params: []rpcParamSpec{
+			{Name: "data", Value: (map[string]interface{})(nil)},
+		},
+		Result: nil,
+	},
+	getPortalV4Tokens: {
+		Summary: "Portal tokens",
+		Params: []rpcParamSpec{
+			{Name: "data", Value: (map[string]interface{})(nil)},
+		},
+		Result: ([]jsonresult.PortalV4TokenResult)(nil),
+	},
+	getPortalWithdrawCollateralProof: {
+		Summary: "returns a proof of a tx withdraw external collaterals",
+		Result:  jsonresult.GetInstructionProof{},
+	},
+	getPortingRequestFees: {
+		Summary: "Porting request",
+		Params: []rpcParamSpec{
+			{Name: "data", Value: (map[string]interface{})(nil)},
+		},
+		Result: uint64(0),
+	},
+	getProposerIndex: {
+		Params: []rpcParamSpec{
+			{Name: "tempShardID", Value: float64(0)},
+		},
+		Result: (map[string]interface{})(nil),
+	},
+	getPRVBEP20BurnProof: {
+		Summary: "returns a proof of a tx burning prv bep20",
+		Result:  jsonresult.GetInstructionProof{},
+	},
+	getPRVERC20BurnProof: {
+		Summary: "returns a proof of a tx burning prv erc20",
+		Result:  jsonresult.GetInstructionProof{},
+	},
+	getPublicKeyFromPaymentAddress: {
+		Summary: "return base58check encode of public key which is got from payment address",
+		Params: []rpcParamSpec{
+			{Name: "paymentAddress", Value: ""},
+		},
+		Result: (*jsonresult.GetPublicKeyFromPaymentAddressResult)(nil),
+	},
+	getPublickeyMining: {
+		Summary: "return publickey mining which be used to verify block",
+		Result:  ([]string)(nil),
+	},
+	getRawMempool: {
+		Summary: "RPC returns all transaction ids in memory pool as a json array of string transaction ids",
+		Result:  (*jsonresult.GetRawMempoolResult)(nil),
+	},
+	getReceivedByAccount: {
+		Summary: "RPC returns the total amount received by addresses in a",
+		Params: []rpcParamSpec{
+			{Name: "accountName", Value: ""},
+			{Name: "minTemp", Value: float64(0)},
+			{Name: "passPhrase", Value: ""},
+		},
+		Result: nil,
+	},
+	getRelayingBNBHeaderByBlockHeight: {
+		Params: []rpcParamSpec{
+			{Name: "data", Value: (map[string]interface{})(nil)},
+		},
+		Result: (*types.Block)(nil),
+	},
+	getRelayingBNBHeaderState: {
+		Result: nil,
+	},
+	getReqMatchingRedeemStatus: {
+		Params: []rpcParamSpec{
+			{Name: "data", Value: (map[string]interface{})(nil)},
+		},
+		Result: (*metadata.PortalReqMatchingRedeemStatus)(nil),
+	},
+	getReqRedeemFromLiquidationPoolByTxIDStatus: {
+		Params: []rpcParamSpec{
+			{Name: "data", Value: (map[string]interface{})(nil)},
+		},
+		Result: (*metadata.RedeemLiquidateExchangeRatesStatus)(nil),
+	},
+	getReqRedeemFromLiquidationPoolByTxIDStatusV3: {
+		Params: []rpcParamSpec{
+			{Name: "data", Value: (map[string]interface{})(nil)},
+		},
+		Result: (*metadata.PortalRedeemFromLiquidationPoolStatusV3)(nil),
+	},
+	getPortalReqRedeemByTxIDStatus: {
+		Params: []rpcParamSpec{
+			{Name: "data", Value: (map[string]interface{})(nil)},
+		},
+		Result: (*metadata.PortalRedeemRequestStatus)(nil),
+	},
+	getRequestWithdrawPortalRewardStatus: {
+		Params: []rpcParamSpec{
+			{Name: "data", Value: (map[string]interface{})(nil)},
+		},
+		Result: (*metadata.PortalRequestWithdrawRewardStatus)(nil),
+	},
+	getRewardAmountByPublicKey: {
+		Summary: "Get the reward amount of a payment address with all existed token",
+		Params: []rpcParamSpec{
+			{Name: "paymentAddress", Value: ""},
+		},
+		Result: (map[string]uint64)(nil),
+	},
+	getRewardAmountProof: {
+		Summary: "Get the committee reward balances of a payment address with their Merkle proof",
+		Params: []rpcParamSpec{
+			{Name: "param0", Value: nil},
+		},
+		Result: (*jsonresult.StateProof)(nil),
+	},
+	getRewardFeature: {
+		Params: []rpcParamSpec{
+			{Name: "data", Value: (map[string]interface{})(nil)},
+		},
+		Result: (map[string]uint64)(nil),
+	},
+	getRoleByValidatorKey: {
+		Summary: "get validator key, convert to bls consensus public key and get role in network",
+		Params: []rpcParamSpec{
+			{Name: "keyParam", Value: ""},
+		},
+		Result: (*struct {
+			Role    int
+			ShardID int
+		})(nil),
+	},
+	getShardBestStateDetail: {
+		Summary: "RPC get shard best state",
+		Params: []rpcParamSpec{
+			{Name: "shardIdParam", Value: float64(0)},
+		},
+		Result: (*jsonresult.GetShardBestStateDetail)(nil),
+	},
+	getShardPoolInfo: {
+		Params: []rpcParamSpec{
+			{Name: "shardID", Value: float64(0)},
+		},
+		Result: (*jsonresult.PoolInfo)(nil),
+	},
+	getSlashingCommittee: {
+		Params: []rpcParamSpec{
+			{Name: "epoch", Value: float64(0)},
+		},
+		Result: (map[byte][]string)(nil),
+	},
+	getSlashingCommitteeDetail: {
+		Params: []rpcParamSpec{
+			{Name: "epoch", Value: float64(0)},
+		},
+		Result: (map[byte][]incognitokey.CommitteeKeyString)(nil),
+	},
+	getStackingAmount: {
+		Params: []rpcParamSpec{
+			{Name: "stakingTypeParam", Value: float64(0)},
+		},
+		Result: uint64(0),
+	},
+	getSyncPoolValidator: {
+		Summary: "check list serial numbers existed in mempool or not",
+		Result:  (map[byte][]string)(nil),
+	},
+	getSyncPoolValidatorDetail: {
+		Summary: "check list serial numbers existed in mempool or not",
+		Result:  (map[byte][]incognitokey.CommitteeKeyString)(nil),
+	},
+	getSyncStats: {
+		Result: (*jsonresult.SyncStats)(nil),
+	},
+	getTokenStateProof: {
+		Summary: "Get the token info of a shard with its Merkle proof",
+		Params: []rpcParamSpec{
+			{Name: "param0", Value: nil},
+		},
+		Result: (*jsonresult.StateProof)(nil),
+	},
+	getTopupAmountForCustodian: {
+		Params: []rpcParamSpec{
+			{Name: "data", Value: (map[string]interface{})(nil)},
+		},
+		Result: uint64(0),
+	},
+	getTotalBlockInEpoch: {
+		Params: []rpcParamSpec{
+			{Name: "epoch", Value: float64(0)},
+		},
+		Result: (map[byte]*stats.NumberOfBlockInOneEpochStats)(nil),
+	},
+	getTotalStaker: {
+		Result: (*jsonresult.GetTotalStaker)(nil),
+	},
+	getTotalTransaction: {
+		Params: []rpcParamSpec{
+			{Name: "shardIdParam", Value: float64(0)},
+		},
+		Result: (*jsonresult.TotalTransactionInShard)(nil),
+	},
+	gettransactionbypublickey: {
+		Params: []rpcParamSpec{
+			{Name: "paramList", Value: (map[string]interface{})(nil)},
+		},
+		Result: (map[string]map[byte][]common.Hash)(nil),
+	},
+	gettransactionbyreceiver: {
+		Params: []rpcParamSpec{
+			{Name: "keys", Value: (map[string]interface{})(nil)},
+		},
+		Result: (*jsonresult.ListReceivedTransaction)(nil),
+	},
+	gettransactionbyreceiverv2: {
+		Params: []rpcParamSpec{
+			{Name: "keys", Value: (map[string]interface{})(nil)},
+		},
+		Result: struct {
+			Total                uint
+			Skip                 uint
+			Limit                uint
+			ReceivedTransactions []jsonresult.ReceivedTransactionV2
+		}{},
+	},
+	gettransactionbyserialnumber: {
+		Summary: "Get transaction by serial numbers",
+		Params: []rpcParamSpec{
+			{Name: "paramList", Value: (map[string]interface{})(nil)},
+		},
+		Result: nil,
+	},
+	gettransactionhashbyreceiver: {
+		Params: []rpcParamSpec{
+			{Name: "paymentAddress", Value: ""},
+		},
+		Result: (map[byte][]common.Hash)(nil),
+	},
+	gettransactionhashbyreceiverv2: {
+		Summary: "Get tx hash by receiver in paging fashion",
+		Params: []rpcParamSpec{
+			{Name: "paymentAddress", Value: ""},
+			{Name: "skip", Value: float64(0)},
+			{Name: "limit", Value: float64(0)},
+		},
+		Result: struct {
+			Skip    uint
+			Limit   uint
+			TxHashs []common.Hash
+		}{},
+	},
+	getValKeyState: {
+		Result: (map[string]consensus.MiningState)(nil),
+	},
+	hashToIdenticon: {
+		Result: ([]string)(nil),
+	},
+	hasSerialNumbers: {
+		Summary: "check list serial numbers existed in db of node",
+		Params: []rpcParamSpec{
+			{Name: "shardID", Value: float64(0)},
+			{Name: "serialNumbersStr", Value: ([]interface{})(nil)},
+			{Name: "tokenIDTemp", Value: "", Optional: true},
+		},
+		Result: ([]bool)(nil),
+	},
+	hasSerialNumbersInMempool: {
+		Summary: "check list serial numbers existed in mempool or not",
+		Params: []rpcParamSpec{
+			{Name: "serialNumbersStr", Value: ([]interface{})(nil)},
+		},
+		Result: nil,
+	},
+	hasSnDerivators: {
+		Summary: "check list serial numbers existed in db of node",
+		Params: []rpcParamSpec{
+			{Name: "paymentAddressStr", Value: ""},
+			{Name: "snDerivatorStr", Value: ([]interface{})(nil)},
+		},
+		Result: ([]bool)(nil),
+	},
+	importAccount: {
+		Summary: "import a new account by private-key",
+		Params: []rpcParamSpec{
+			{Name: "privateKey", Value: ""},
+			{Name: "accountName", Value: ""},
+			{Name: "passPhrase", Value: ""},
+		},
+		Result: wallet.KeySerializedData{},
+	},
+	listAccounts: {
+		Summary: "listaccount RPC lists accounts and their balances",
+		Result:  jsonresult.ListAccounts{},
+	},
+	listCommitmentIndices: {
+		Summary: "return list all commitment indices in shard for token ID",
+		Params: []rpcParamSpec{
+			{Name: "tokenIDTemp", Value: "", Optional: true},
+			{Name: "shardIDParam", Value: float64(0), Optional: true},
+		},
+		Result: (map[uint64]string)(nil),
+	},
+	listCommitments: {
+		Summary: "return list all commitments in shard for token ID",
+		Params: []rpcParamSpec{
+			{Name: "tokenIDTemp", Value: "", Optional: true},
+			{Name: "shardIDParam", Value: float64(0), Optional: true},
+		},
+		Result: (map[string]uint64)(nil),
+	},
+	listOutputCoins: {
+		Summary: "use readonly key to get all tx which contains output coin of account",
+		Params: []rpcParamSpec{
+			{Name: "minTemp", Value: float64(0)},
+			{Name: "maxTemp", Value: float64(0)},
+			{Name: "param2", Value: nil},
+			{Name: "tokenIdParam", Value: "", Optional: true},
+		},
+		Result: (*jsonresult.ListOutputCoins)(nil),
+	},
+	listOutputCoinsFromCache: {
+		Params: []rpcParamSpec{
+			{Name: "minTemp", Value: float64(0)},
+			{Name: "maxTemp", Value: float64(0)},
+			{Name: "param2", Value: nil},
+			{Name: "tokenIdParam", Value: "", Optional: true},
+		},
+		Result: (*jsonresult.ListOutputCoins)(nil),
+	},
+	listOutputTokens: {
+		Summary: "use readonly key to get all tx which contains output coin of account",
+		Params: []rpcParamSpec{
+			{Name: "minTemp", Value: float64(0)},
+			{Name: "maxTemp", Value: float64(0)},
+			{Name: "param2", Value: nil},
+			{Name: "tokenIdParam", Value: "", Optional: true},
+		},
+		Result: (*jsonresult.ListOutputCoins)(nil),
+	},
+	listPrivacyCustomToken: {
+		Result: nil,
+	},
+	listPrivacyCustomTokenByShard: {
+		Params: []rpcParamSpec{
+			{Name: "shardID", Value: float64(0)},
+		},
+		Result: nil,
+	},
+	listPrivacyCustomTokenIDs: {
+		Result: nil,
+	},
+	listRewardAmount: {
+		Summary: "Get the reward amount of all committee with all existed token",
+		Result:  (map[string]map[common.Hash]uint64)(nil),
+	},
+	listSerialNumbers: {
+		Summary: "return list all serialnumber in shard for token ID",
+		Params: []rpcParamSpec{
+			{Name: "tokenIDTemp", Value: "", Optional: true},
+			{Name: "shardIDParam", Value: float64(0), Optional: true},
+		},
+		Result: (map[string]struct{})(nil),
+	},
+	listUnspentOutputCoins: {
+		Summary: "use private key to get all tx which contains output coin of account",
+		Params: []rpcParamSpec{
+			{Name: "minParam", Value: float64(0)},
+			{Name: "maxParam", Value: float64(0)},
+			{Name: "param2", Value: nil},
+			{Name: "tokenIDStr", Value: "", Optional: true},
+		},
+		Result: (*jsonresult.ListOutputCoins)(nil),
+	},
+	listUnspentOutputCoinsFromCache: {
+		Summary: "use private key to get all tx which contains cached output coin of account",
+		Params: []rpcParamSpec{
+			{Name: "minParam", Value: float64(0)},
+			{Name: "maxParam", Value: float64(0)},
+			{Name: "param2", Value: nil},
+			{Name: "tokenIDStr", Value: "", Optional: true},
+		},
+		Result: (*jsonresult.ListOutputCoins)(nil),
+	},
+	listUnspentOutputTokens: {
+		Params: []rpcParamSpec{
+			{Name: "minParam", Value: float64(0)},
+			{Name: "maxParam", Value: float64(0)},
+			{Name: "param2", Value: nil},
+		},
+		Result: (*jsonresult.ListOutputCoins)(nil),
+	},
+	pdexv3GetAddOrderStatus: {
+		Params: []rpcParamSpec{
+			{Name: "s", Value: ""},
+		},
+		Result: nil,
+	},
+	getPdexv3BestTradePath: {
+		Params: []rpcParamSpec{
+			{Name: "param0", Value: nil},
+		},
+		Result: (*pdex.TradeSimulation)(nil),
+	},
+	getPdexv3ContributionStatus: {
+		Params: []rpcParamSpec{
+			{Name: "s", Value: ""},
+		},
+		Result: nil,
+	},
+	getPdexv3EstimatedLPPoolReward: {
+		Params: []rpcParamSpec{
+			{Name: "data", Value: (map[string]interface{})(nil)},
+		},
+		Result: (map[string]uint64)(nil),
+	},
+	getPdexv3EstimatedLPValue: {
+		Summary: "Fee Management",
+		Params: []rpcParamSpec{
+			{Name: "data", Value: (map[string]interface{})(nil)},
+		},
+		Result: jsonresult.Pdexv3LPValue{},
+	},
+	getPdexv3EstimatedStakingPoolReward: {
+		Params: []rpcParamSpec{
+			{Name: "data", Value: (map[string]interface{})(nil)},
+		},
+		Result: (map[string]uint64)(nil),
+	},
+	getPdexv3EstimatedStakingReward: {
+		Params: []rpcParamSpec{
+			{Name: "data", Value: (map[string]interface{})(nil)},
+		},
+		Result: (map[string]uint64)(nil),
+	},
+	getPdexv3MintNftStatus: {
+		Params: []rpcParamSpec{
+			{Name: "s", Value: ""},
+		},
+		Result: nil,
+	},
+	getPdexv3ParamsModifyingStatus: {
+		Params: []rpcParamSpec{
+			{Name: "data", Value: (map[string]interface{})(nil)},
+		},
+		Result: (*pdexv3.ParamsModifyingRequestStatus)(nil),
+	},
+	getPdexv3PoolPairProof: {
+		Summary: "Get the state of a pDEX v3 pool pair (without its shares & orders) with its Merkle proof",
+		Params: []rpcParamSpec{
+			{Name: "param0", Value: nil},
+		},
+		Result: (*jsonresult.StateProof)(nil),
+	},
+	pdexv3GetStakingStatus: {
+		Params: []rpcParamSpec{
+			{Name: "s", Value: ""},
+		},
+		Result: nil,
+	},
+	getPdexv3State: {
+		Params: []rpcParamSpec{
+			{Name: "data", Value: (map[string]interface{})(nil)},
+		},
+		Result: nil,
+	},
+	pdexv3GetTradeStatus: {
+		Params: []rpcParamSpec{
+			{Name: "s", Value: ""},
+		},
+		Result: nil,
+	},
+	pdexv3GetUnstakingStatus: {
+		Params: []rpcParamSpec{
+			{Name: "s", Value: ""},
+		},
+		Result: nil,
+	},
+	getPdexv3WithdrawLiquidityStatus: {
+		Params: []rpcParamSpec{
+			{Name: "s", Value: ""},
+		},
+		Result: nil,
+	},
+	pdexv3GetWithdrawOrderStatus: {
+		Result: nil,
+	},
+	getPdexv3WithdrawalLPFeeStatus: {
+		Params: []rpcParamSpec{
+			{Name: "data", Value: (map[string]interface{})(nil)},
+		},
+		Result: (*pdexv3.WithdrawalLPFeeStatus)(nil),
+	},
+	getPdexv3WithdrawalProtocolFeeStatus: {
+		Params: []rpcParamSpec{
+			{Name: "data", Value: (map[string]interface{})(nil)},
+		},
+		Result: (*pdexv3.WithdrawalProtocolFeeStatus)(nil),
+	},
+	getPdexv3WithdrawalStakingRewardStatus: {
+		Params: []rpcParamSpec{
+			{Name: "data", Value: (map[string]interface{})(nil)},
+		},
+		Result: (*pdexv3.WithdrawalStakingRewardStatus)(nil),
+	},
+	simulatePdexv3Trade: {
+		Params: []rpcParamSpec{
+			{Name: "param0", Value: nil},
+		},
+		Result: (*pdex.TradeSimulation)(nil),
+	},
+	createAndSendTxWithPdexv3WithdrawLPFee: {
+		Params: []rpcParamSpec{
+			{Name: "param0", Value: nil},
+			{Name: "param1", Value: nil},
+			{Name: "param2", Value: nil},
+			{Name: "param3", Value: nil},
+			{Name: "tokenParamsRaw", Value: (map[string]interface{})(nil)},
+			{Name: "param5", Value: nil, Optional: true},
+			{Name: "hasPrivacyTokenParam", Value: float64(0), Optional: true},
+		},
+		Result: nil,
+	},
+	pdexv3TxWithdrawOrder: {
+		Result: nil,
+	},
+	createAndSendTxWithPdexv3WithdrawProtocolFee: {
+		Params: []rpcParamSpec{
+			{Name: "param0", Value: nil},
+			{Name: "param1", Value: nil},
+			{Name: "param2", Value: nil},
+			{Name: "param3", Value: nil},
+			{Name: "tokenParamsRaw", Value: (map[string]interface{})(nil)},
+		},
+		Result: nil,
+	},
+	createAndSendTxWithPdexv3WithdrawStakingReward: {
+		Params: []rpcParamSpec{
+			{Name: "param0", Value: nil},
+			{Name: "param1", Value: nil},
+			{Name: "param2", Value: nil},
+			{Name: "param3", Value: nil},
+			{Name: "tokenParamsRaw", Value: (map[string]interface{})(nil)},
+			{Name: "param5", Value: nil, Optional: true},
+			{Name: "hasPrivacyTokenParam", Value: float64(0), Optional: true},
+		},
+		Result: nil,
+	},
+	privacyCustomTokenTxs: {
+		Summary: "return list tx which relate to privacy custom token by token id",
+		Params: []rpcParamSpec{
+			{Name: "tokenIDTemp", Value: ""},
+		},
+		Result: jsonresult.CustomToken{},
+	},
+	prune: {
+		Params: []rpcParamSpec{
+			{Name: "param0", Value: nil},
+		},
+		Result: nil,
+	},
+	randomCommitments: {
+		Summary: "from input of outputcoin, random to create data for create new tx",
+		Params: []rpcParamSpec{
+			{Name: "shardID", Value: float64(0)},
+			{Name: "outputs", Value: ([]interface{})(nil)},
+			{Name: "tokenIDTemp", Value: "", Optional: true},
+		},
+		Result: (*jsonresult.RandomCommitmentResult)(nil),
+	},
+	randomCommitmentsAndPublicKeys: {
+		Summary: "returns a list of random commitments, public keys and indices for creating txver2",
+		Params: []rpcParamSpec{
+			{Name: "shardID", Value: float64(0)},
+			{Name: "numOutputs", Value: float64(0)},
+			{Name: "tokenIDTemp", Value: "", Optional: true},
+		},
+		Result: (*jsonresult.RandomCommitmentAndPublicKeyResult)(nil),
+	},
+	removeAccount: {
+		Params: []rpcParamSpec{
+			{Name: "privateKey", Value: ""},
+			{Name: "passPhrase", Value: ""},
+		},
+		Result: nil,
+	},
+	removeByzantineDetector: {
+		Params: []rpcParamSpec{
+			{Name: "validator", Value: ""},
+		},
+		Result: "",
+	},
+	removeTxInMempool: {
+		Summary: "try to remove tx from tx mempool",
+		Result:  ([]bool)(nil),
+	},
+	resetCache: {
+		Params: []rpcParamSpec{
+			{Name: "n", Value: float64(0)},
+		},
+		Result: "",
+	},
+	sendFinishSync: {
+		Params: []rpcParamSpec{
+			{Name: "miningKeyStr", Value: ""},
+			{Name: "cpk", Value: ""},
+			{Name: "sid", Value: float64(0)},
+		},
+		Result: nil,
+	},
+	sendIssuingRequest: {
+		Params: []rpcParamSpec{
+			{Name: "base58CheckData", Value: ""},
+		},
+		Result: jsonresult.CreateTransactionResult{},
+	},
+	sendRawPrivacyCustomTokenTransaction: {
+		Params: []rpcParamSpec{
+			{Name: "base58CheckData", Value: ""},
+		},
+		Result: jsonresult.CreateTransactionTokenResult{},
+	},
+	sendRawTransaction: {
+		Summary: "implements the sendtransaction command",
+		Params: []rpcParamSpec{
+			{Name: "base58CheckData", Value: ""},
+		},
+		Result: jsonresult.CreateTransactionResult{},
+	},
+	setAutoEnableFeatureConfig: {
+		Params: []rpcParamSpec{
+			{Name: "jsonStr", Value: ""},
+		},
+		Result: nil,
+	},
+	setBackup: {
+		Result: false,
+	},
+	setConsensusRule: {
+		Params: []rpcParamSpec{
+			{Name: "param", Value: (map[string]interface{})(nil)},
+		},
+		Result: (map[string]interface{})(nil),
+	},
+	setTxFee: {
+		Summary: "RPC sets the transaction fee per kilobyte paid more by transactions created by this wallet",
+		Result:  false,
+	},
+	startProfiling: {
+		Result: nil,
+	},
+	stopProfiling: {
+		Result: nil,
+	},
+	subcribeBeaconBestState: {
+		Result: (*jsonresult.GetBeaconBestState)(nil),
+	},
+	subcribeBeaconBestStateFromMem: {
+		Result: (*jsonresult.GetBeaconBestState)(nil),
+	},
+	subcribeBeaconCandidateByPublickey: {
+		Params: []rpcParamSpec{
+			{Name: "candidate", Value: ""},
+		},
+		Result: false,
+	},
+	subcribeBeaconCommitteeByPublickey: {
+		Params: []rpcParamSpec{
+			{Name: "committee", Value: ""},
+		},
+		Result: false,
+	},
+	subcribeBeaconPendingValidatorByPublickey: {
+		Params: []rpcParamSpec{
+			{Name: "validator", Value: ""},
+		},
+		Result: false,
+	},
+	subcribeBeaconPoolBeststate: {
+		Result: nil,
+	},
+	subcribeCrossCustomTokenPrivacyByPrivateKey: {
+		Params: []rpcParamSpec{
+			{Name: "privateKey", Value: ""},
+		},
+		Result: jsonresult.CrossCustomTokenPrivacyResult{},
+	},
+	subcribeCrossOutputCoinByPrivateKey: {
+		Params: []rpcParamSpec{
+			{Name: "privateKey", Value: ""},
+		},
+		Result: jsonresult.CrossOutputCoinResult{},
+	},
+	subcribeIncomingCoinByOTAKey: {
+		Summary: "pushes the output coins of newly finalized shard blocks that belong to an OTA key",
+		Params: []rpcParamSpec{
+			{Name: "keyStr", Value: ""},
+		},
+		Result: jsonresult.IncomingCoinResult{},
+	},
+	subcribeMempoolInfo: {
+		Summary: "streams the txs added to and removed from the tx pools of the node",
+		Params: []rpcParamSpec{
+			{Name: "shardIDParam", Value: float64(0), Optional: true},
+		},
+		Result: (*jsonresult.MempoolEvent)(nil),
+	},
+	subcribeNewBeaconBlock: {
+		Result: (*jsonresult.GetBeaconBlockResult)(nil),
+	},
+	subcribeNewShardBlock: {
+		Params: []rpcParamSpec{
+			{Name: "shardID", Value: float64(0)},
+		},
+		Result: (*jsonresult.GetShardBlockResult)(nil),
+	},
+	subcribePendingTransaction: {
+		Params: []rpcParamSpec{
+			{Name: "txHashTemp", Value: ""},
+		},
+		Result: (*jsonresult.TransactionDetail)(nil),
+	},
+	subcribeShardBestState: {
+		Params: []rpcParamSpec{
+			{Name: "shardID", Value: float64(0)},
+		},
+		Result: (*jsonresult.GetShardBestState)(nil),
+	},
+	subcribeShardCandidateByPublickey: {
+		Params: []rpcParamSpec{
+			{Name: "candidate", Value: ""},
+		},
+		Result: false,
+	},
+	subcribeShardCommitteeByPublickey: {
+		Params: []rpcParamSpec{
+			{Name: "committee", Value: ""},
+		},
+		Result: false,
+	},
+	subcribeShardPendingValidatorByPublickey: {
+		Params: []rpcParamSpec{
+			{Name: "validator", Value: ""},
+		},
+		Result: false,
+	},
+	subcribeShardPoolBeststate: {
+		Result: nil,
+	},
+	testHttpServer: {
+		Result: nil,
+	},
+	testSubcrice: {
+		Result: int(0),
+	},
+	handleTestValidate: {
+		Params: []rpcParamSpec{
+			{Name: "aggSigHash", Value: ""},
+			{Name: "blkHash", Value: ""},
+			{Name: "param2", Value: ([]interface{})(nil)},
+			{Name: "committeeFromBlockHash", Value: ""},
+			{Name: "chainID", Value: float64(0)},
+			{Name: "proposerIndex", Value: float64(0)},
+			{Name: "blockVersion", Value: float64(0)},
+		},
+		Result: "",
+	},
+	unlockMempool: {
+		Result: nil,
+	},
+	validatePortalRemoteAddress: {
+		Params: []rpcParamSpec{
+			{Name: "data", Value: (map[string]interface{})(nil)},
+		},
+		Result: false,
+	},
+}
@@ -0,0 +1,132 @@
+package rpcserver
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/rpcserver/jsonresult"
+)
+
+func TestRPCMethodSpecsAreRegistered(t *testing.T) {
+	for name := range rpcMethodSpecs {
+		_, isHttp := HttpHandler[name]
+		_, isLimited := LimitedHttpHandler[name]
+		_, isWs := WsHandler[name]
+		if !isHttp && !isLimited && !isWs {
+			t.Fatalf("Expect method %+v of the specs to have a handler", name)
+		}
+	}
+	if _, ok := HttpHandler[rpcDiscover]; !ok {
+		t.Fatalf("Expect %+v to be registered", rpcDiscover)
+	}
+}
+
+func TestRPCHandlersAreDeclared(t *testing.T) {
+	names := []string{}
+	for name := range HttpHandler {
+		names = append(names, name)
+	}
+	for name := range LimitedHttpHandler {
+		names = append(names, name)
+	}
+	for name := range WsHandler {
+		names = append(names, name)
+	}
+	for _, name := range names {
+		_, isDeclared := rpcMethodSpecs[name]
+		_, isGenerated := generatedRPCMethodSpecs[name]
+		if !isDeclared && !isGenerated {
+			t.Fatalf("Expect method %+v to be declared, run go generate ./rpcserver", name)
+		}
+	}
+	for name := range generatedRPCMethodSpecs {
+		_, isHttp := HttpHandler[name]
+		_, isLimited := LimitedHttpHandler[name]
+		_, isWs := WsHandler[name]
+		if _, isDeclared := rpcMethodSpecs[name]; isDeclared || (!isHttp && !isLimited && !isWs) {
+			t.Fatalf("Expect generated method %+v to have a handler and no spec, run go generate ./rpcserver", name)
+		}
+	}
+}
+
+func TestNewOpenRPCDocument(t *testing.T) {
+	doc := NewOpenRPCDocument()
+	if len(doc.Methods) < len(HttpHandler) {
+		t.Fatalf("Expect at least %+v methods but get %+v", len(HttpHandler), len(doc.Methods))
+	}
+	data, err := json.Marshal(doc)
+	if err != nil {
+		t.Fatalf("Expect to marshal the document but get %+v", err)
+	}
+	decodedDoc := &jsonresult.OpenRPCDocument{}
+	if err := json.Unmarshal(data, decodedDoc); err != nil {
+		t.Fatalf("Expect to unmarshal the document but get %+v", err)
+	}
+
+	var checkRefs func(schema *jsonresult.OpenRPCSchema)
+	checkRefs = func(schema *jsonresult.OpenRPCSchema) {
+		if schema == nil {
+			return
+		}
+		if schema.Ref != "" {
+			if _, ok := decodedDoc.Components.Schemas[strings.TrimPrefix(schema.Ref, "#/components/schemas/")]; !ok {
+				t.Fatalf("Expect schema %+v in the components", schema.Ref)
+			}
+		}
+		checkRefs(schema.Items)
+		checkRefs(schema.AdditionalProperties)
+		for _, property := range schema.Properties {
+			checkRefs(property)
+		}
+	}
+	for _, schema := range decodedDoc.Components.Schemas {
+		checkRefs(schema)
+	}
+
+	for _, method := range decodedDoc.Methods {
+		spec, ok := rpcMethodSpecs[method.Name]
+		if method.Inferred == ok {
+			t.Fatalf("Expect method %+v to be inferred only without spec", method.Name)
+		}
+		if !ok {
+			spec = generatedRPCMethodSpecs[method.Name]
+		}
+		if method.Untyped {
+			t.Fatalf("Expect method %+v to be declared", method.Name)
+		}
+		if method.Result == nil {
+			t.Fatalf("Expect method %+v to have a result", method.Name)
+		}
+		checkRefs(method.Result.Schema)
+		if len(method.Params) != len(spec.Params) {
+			t.Fatalf("Expect %+v params for method %+v but get %+v", len(spec.Params), method.Name, len(method.Params))
+		}
+		for _, param := range method.Params {
+			checkRefs(param.Schema)
+		}
+	}
+}
+
+func TestOpenRPCSchemaBuilder(t *testing.T) {
+	builder := &openRPCSchemaBuilder{
+		schemas: make(map[string]*jsonresult.OpenRPCSchema),
+	}
+	descriptor := builder.contentDescriptor("result", []*jsonresult.CreateTransactionResult{})
+	if descriptor.GoType != "[]*jsonresult.CreateTransactionResult" {
+		t.Fatalf("Expect Go type []*jsonresult.CreateTransactionResult but get %+v", descriptor.GoType)
+	}
+	if len(descriptor.GoImports) != 1 || descriptor.GoImports[0] != "github.com/incognitochain/incognito-chain/rpcserver/jsonresult" {
+		t.Fatalf("Expect jsonresult import but get %+v", descriptor.GoImports)
+	}
+	if descriptor.Schema.Type != "array" || descriptor.Schema.Items.Ref != "#/components/schemas/jsonresult.CreateTransactionResult" {
+		t.Fatalf("Expect an array of references but get %+v", descriptor.Schema)
+	}
+	schema := builder.schemas["jsonresult.CreateTransactionResult"]
+	if schema == nil || schema.Properties["TxID"] == nil || schema.Properties["TxID"].Type != "string" {
+		t.Fatalf("Expect the TxID string property but get %+v", schema)
+	}
+	if descriptor := builder.contentDescriptor("fee", float64(0)); descriptor.Schema.Type != "number" || descriptor.GoImports != nil {
+		t.Fatalf("Expect a number without import but get %+v", descriptor)
+	}
+}
@@ -70,6 +70,7 @@ var HttpHandler = map[string]httpHandler{
 	listOutputCoinsFromCache:                (*HttpServer).handleListOutputCoinsFromCache,
 	listOutputTokens:                        (*HttpServer).handleListOutputCoins,
 	createRawTransaction:                    (*HttpServer).handleCreateRawTransaction,
+	createUnsignedTransaction:               (*HttpServer).handleCreateUnsignedTransaction,
 	sendRawTransaction:                      (*HttpServer).handleSendRawTransaction,
 	createConvertCoinVer1ToVer2Transaction:  (*HttpServer).handleCreateConvertCoinVer1ToVer2Transaction,
 	createAndSendTransaction:                (*HttpServer).handleCreateAndSendTx,
@@ -143,6 +144,7 @@ var HttpHandler = map[string]httpHandler{
 	// custom token which support privacy
 	createConvertCoinVer1ToVer2TxToken:         (*HttpServer).handleCreateConvertCoinVer1ToVer2TxToken,
 	createRawPrivacyCustomTokenTransaction:     (*HttpServer).handleCreateRawPrivacyCustomTokenTransaction,
+	createUnsignedPrivacyTokenTransaction:      (*HttpServer).handleCreateUnsignedPrivacyCustomTokenTransaction,
 	sendRawPrivacyCustomTokenTransaction:       (*HttpServer).handleSendRawPrivacyCustomTokenTransaction,
 	createAndSendPrivacyCustomTokenTransaction: (*HttpServer).handleCreateAndSendPrivacyCustomTokenTransaction,
 	listPrivacyCustomToken:                     (*HttpServer).handleListPrivacyCustomToken,
@@ -275,6 +277,8 @@ var HttpHandler = map[string]httpHandler{
 	getPdexv3EstimatedStakingPoolReward:            (*HttpServer).handleGetPdexv3EstimatedStakingPoolReward,
 	createAndSendTxWithPdexv3WithdrawStakingReward: (*HttpServer).handleCreateAndSendTxWithPdexv3WithdrawStakingReward,
 	getPdexv3WithdrawalStakingRewardStatus:         (*HttpServer).handleGetPdexv3WithdrawalStakingRewardStatus,
+	getPdexv3BestTradePath:                         (*HttpServer).handleGetPdexv3BestTradePath,
+	simulatePdexv3Trade:                            (*HttpServer).handleSimulatePdexv3Trade,
 	// bridgeagg method
 	bridgeaggState:                       (*HttpServer).handleGetBridgeAggState,
 	bridgeaggModifyParam:                 (*HttpServer).handleCreateAndSendTxBridgeAggModifyParamTx,
@@ -293,6 +297,9 @@ var HttpHandler = map[string]httpHandler{
 
 	getBurningAddress: (*HttpServer).handleGetBurningAddress,
 
+	// delegation
+	getDelegationPools: (*HttpServer).handleGetDelegationPools,
+
 	// portal
 	getPortalState:                                (*HttpServer).handleGetPortalState,
 	createAndSendTxWithCustodianDeposit:           (*HttpServer).handleCreateAndSendTxWithCustodianDeposit,
@@ -345,10 +352,13 @@ var HttpHandler = map[string]httpHandler{
 	// relaying
 	createAndSendTxWithRelayingBNBHeader: (*HttpServer).handleCreateAndSendTxWithRelayingBNBHeader,
 	createAndSendTxWithRelayingBTCHeader: (*HttpServer).handleCreateAndSendTxWithRelayingBTCHeader,
+	createAndSendTxWithRelayingLTCHeader: (*HttpServer).handleCreateAndSendTxWithRelayingLTCHeader,
 	getRelayingBNBHeaderState:            (*HttpServer).handleGetRelayingBNBHeaderState,
 	getRelayingBNBHeaderByBlockHeight:    (*HttpServer).handleGetRelayingBNBHeaderByBlockHeight,
 	getBTCRelayingBestState:              (*HttpServer).handleGetBTCRelayingBestState,
 	getBTCBlockByHash:                    (*HttpServer).handleGetBTCBlockByHash,
+	getLTCRelayingBestState:              (*HttpServer).handleGetLTCRelayingBestState,
+	getLTCBlockByHash:                    (*HttpServer).handleGetLTCBlockByHash,
 	getLatestBNBHeaderBlockHeight:        (*HttpServer).handleGetLatestBNBHeaderBlockHeight,
 
 	// incognnito mode for sc
@@ -400,6 +410,8 @@ var HttpHandler = map[string]httpHandler{
 	getPortalConvertVaultTxStatus:              (*HttpServer).handleGetPortalConvertVaultTxStatus,
 	getPortalV4Params:                          (*HttpServer).handleGetPortalV4Params,
 	generatePortalShieldMultisigAddress:        (*HttpServer).handleGenerateShieldingMultisigAddress,
+	getPortalV4Tokens:                          (*HttpServer).handleGetPortalV4Tokens,
+	validatePortalRemoteAddress:                (*HttpServer).handleValidatePortalRemoteAddress,
 
 	// unstake
 	unstake: (*HttpServer).handleCreateUnstakeTransaction,
@@ -410,6 +422,13 @@ var HttpHandler = map[string]httpHandler{
 	prune:          (*HttpServer).handlePrune,
 	getPruneState:  (*HttpServer).getPruneState,
 	checkPruneData: (*HttpServer).checkPruneData,
+
+	// state proof
+	getTokenStateProof:     (*HttpServer).handleGetTokenStateProof,
+	getRewardAmountProof:   (*HttpServer).handleGetRewardAmountProof,
+	getBridgeTokenProof:    (*HttpServer).handleGetBridgeTokenProof,
+	getPdexv3PoolPairProof: (*HttpServer).handleGetPdexv3PoolPairProof,
+	getCommitteeStateProof: (*HttpServer).handleGetCommitteeStateProof,
 }
 
 // Commands that are available to a limited user
@@ -455,4 +474,5 @@ var WsHandler = map[string]wsHandler{
 	subcribeBeaconBestStateFromMem:              (*WsServer).handleSubscribeBeaconBestStateFromMem,
 	subcribeBeaconPoolBeststate:                 (*WsServer).handleSubscribeBeaconPoolBestState,
 	subcribeShardPoolBeststate:                  (*WsServer).handleSubscribeShardPoolBeststate,
+	subcribeIncomingCoinByOTAKey:                (*WsServer).handleSubcribeIncomingCoinByOTAKey,
 }
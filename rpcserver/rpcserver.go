@@ -8,6 +8,7 @@ import (
 
 	"github.com/incognitochain/incognito-chain/addrmanager"
 	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/config"
 	"github.com/incognitochain/incognito-chain/connmanager"
 	"github.com/incognitochain/incognito-chain/incdb"
 	"github.com/incognitochain/incognito-chain/memcache"
@@ -26,6 +27,7 @@ import (
 const (
 	rpcAuthTimeoutSeconds    = 60
 	rpcProcessTimeoutSeconds = 90
+	rpcMaxBatchRequests      = 100
 	RpcServerVersion         = "1.0"
 )
 
@@ -104,6 +106,8 @@ type RpcServerConfig struct {
 	RPCLimitUser string
 	RPCLimitPass string
 	DisableAuth  bool
+	// APIKeys restrict the methods and the request limits of the clients authenticated with them
+	APIKeys []config.RPCAPIKey
 	// The fee estimator keeps track of how long transactions are left in
 	// the mempool before they are mined into blocks.
 	FeeEstimator map[byte]*mempool.FeeEstimator
@@ -42,6 +42,19 @@ func (blockService BlockService) GetBridgeAggState(
 	return res, nil
 }
 
+// getBridgeAggStateByHeight returns the bridge agg state at the best view, or as of
+// the beacon height beaconHeight when it is not 0.
+func (blockService BlockService) getBridgeAggStateByHeight(beaconHeight uint64) (*bridgeagg.State, error) {
+	if beaconHeight == 0 {
+		return blockService.BlockChain.GetBeaconBestState().BridgeAggManager().State(), nil
+	}
+	stateDB, err := blockService.BlockChain.GetBestStateBeaconFeatureStateDBByHeight(beaconHeight, blockService.BlockChain.GetBeaconChainDatabase())
+	if err != nil {
+		return nil, err
+	}
+	return bridgeagg.InitStateFromDB(stateDB)
+}
+
 func getBridgeAggState(
 	beaconHeight uint64, beaconTimeStamp int64, stateDB *statedb.StateDB,
 ) (interface{}, error) {
@@ -61,9 +74,11 @@ func getBridgeAggState(
 	return res, nil
 }
 
-func (blockService BlockService) BridgeAggEstimateFeeByBurntAmount(unifiedTokenID, tokenID common.Hash, burntAmount uint64) (interface{}, error) {
-	beaconBestView := blockService.BlockChain.GetBeaconBestState()
-	state := beaconBestView.BridgeAggManager().State()
+func (blockService BlockService) BridgeAggEstimateFeeByBurntAmount(unifiedTokenID, tokenID common.Hash, burntAmount uint64, atHeight uint64) (interface{}, error) {
+	state, err := blockService.getBridgeAggStateByHeight(atHeight)
+	if err != nil {
+		return nil, err
+	}
 
 	vaults, ok := state.UnifiedTokenVaults()[unifiedTokenID]
 	if !ok {
@@ -101,9 +116,11 @@ func (blockService BlockService) BridgeAggEstimateFeeByBurntAmount(unifiedTokenI
 	}, nil
 }
 
-func (blockService BlockService) BridgeAggEstimateFeeByExpectedAmount(unifiedTokenID, tokenID common.Hash, amount uint64) (interface{}, error) {
-	beaconBestView := blockService.BlockChain.GetBeaconBestState()
-	state := beaconBestView.BridgeAggManager().State()
+func (blockService BlockService) BridgeAggEstimateFeeByExpectedAmount(unifiedTokenID, tokenID common.Hash, amount uint64, atHeight uint64) (interface{}, error) {
+	state, err := blockService.getBridgeAggStateByHeight(atHeight)
+	if err != nil {
+		return nil, err
+	}
 
 	vaults, ok := state.UnifiedTokenVaults()[unifiedTokenID]
 	if !ok {
@@ -144,9 +161,11 @@ func calMaxUnshieldFee(receivedAmt uint64, percentFeeWithDec uint64, percentFeeD
 	return tmp.Uint64()
 }
 
-func (blockService BlockService) BridgeAggEstimateReward(unifiedTokenID, tokenID common.Hash, amount uint64) (interface{}, error) {
-	beaconBestView := blockService.BlockChain.GetBeaconBestState()
-	state := beaconBestView.BridgeAggManager().State()
+func (blockService BlockService) BridgeAggEstimateReward(unifiedTokenID, tokenID common.Hash, amount uint64, atHeight uint64) (interface{}, error) {
+	state, err := blockService.getBridgeAggStateByHeight(atHeight)
+	if err != nil {
+		return nil, err
+	}
 
 	vaults, ok := state.UnifiedTokenVaults()[unifiedTokenID]
 	if !ok {
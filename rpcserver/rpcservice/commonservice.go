@@ -6,6 +6,7 @@ import (
 
 	rCommon "github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/incognitochain/incognito-chain/blockchain"
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/config"
 	"github.com/incognitochain/incognito-chain/incognitokey"
@@ -344,3 +345,12 @@ func GetInputSerialnumber(inputCoins []privacy.PlainCoin) ([]string, error) {
 	}
 	return tmp, nil
 }
+
+// tryGetAllOutputCoinsByKeyset returns the decrypted output coins of a key set at the best view,
+// or as of the beacon height atHeight when it is not 0.
+func tryGetAllOutputCoinsByKeyset(bc *blockchain.BlockChain, keySet *incognitokey.KeySet, shardID byte, tokenID *common.Hash, atHeight uint64) ([]privacy.PlainCoin, error) {
+	if atHeight == 0 {
+		return bc.TryGetAllOutputCoinsByKeyset(keySet, shardID, tokenID, true)
+	}
+	return bc.TryGetAllOutputCoinsByKeysetAtHeight(keySet, shardID, tokenID, true, atHeight)
+}
@@ -110,6 +110,8 @@ const (
 	GetRelayingBNBHeaderByBlockHeightError
 	GetBTCRelayingBestState
 	GetBTCBlockByHash
+	GetLTCRelayingBestState
+	GetLTCBlockByHash
 	GetRelayingBNBHeaderError
 	GetLatestBNBHeaderBlockHeightError
 
@@ -142,6 +144,7 @@ const (
 	GetPortalV4FeeReplacementReqStatusError
 	GetPortalV4SubmitConfirmedTxStatusError
 	GetPortalV4ConvertVaultTxStatusError
+	ValidatePortalV4RemoteAddressError
 
 	CacheQueueError
 
@@ -154,6 +157,7 @@ const (
 	GetPdexv3WithdrawalLPFeeStatusError
 	GetPdexv3WithdrawalProtocolFeeStatusError
 	GetPdexv3WithdrawalStakingRewardStatusError
+	SimulatePdexv3TradeError
 
 	// bridgeagg
 	GetBridgeAggStateError
@@ -163,6 +167,9 @@ const (
 
 	// prune
 	PruneError
+
+	// state proof
+	GetStateProofError
 )
 
 // Standard JSON-RPC 2.0 errors.
@@ -276,6 +283,8 @@ var ErrCodeMessage = map[int]struct {
 	GetBTCRelayingBestState:                {-10003, "Get BTC relaying best state error"},
 	GetLatestBNBHeaderBlockHeightError:     {-10004, "Get latest bnb header block height error"},
 	GetBTCBlockByHash:                      {-10005, "Get BTC block by hash error"},
+	GetLTCRelayingBestState:                {-10006, "Get LTC relaying best state error"},
+	GetLTCBlockByHash:                      {-10007, "Get LTC block by hash error"},
 
 	// feature reward
 	GetRewardFeatureByFeatureNameError: {-11001, "Get feature reward by feature name error"},
@@ -301,6 +310,7 @@ var ErrCodeMessage = map[int]struct {
 	GetPdexv3StateError:                {-14001, "Get pDex V3 state error"},
 	GenerateOTAFailError:               {-14002, "Generate ota fail"},
 	GetPdexv3ParamsModyfingStatusError: {-14003, "Get pDex v3 params modyfing status error"},
+	SimulatePdexv3TradeError:           {-14004, "Simulate pDex v3 trade error"},
 	// Portal v4
 	GetPortalV4ShieldReqStatusError:         {-12501, "Get portal v4 shielding request status error"},
 	GetPortalV4UnshieldReqStatusError:       {-12502, "Get portal v4 unshielding request status error"},
@@ -308,6 +318,7 @@ var ErrCodeMessage = map[int]struct {
 	GetPortalV4FeeReplacementReqStatusError: {-12504, "Get portal v4 fee replacement request status error"},
 	GetPortalV4SubmitConfirmedTxStatusError: {-12505, "Get portal v4 submit external confirmed tx request status error"},
 	GetPortalV4ConvertVaultTxStatusError:    {-12506, "Get portal v4 convert vault tx request status error"},
+	ValidatePortalV4RemoteAddressError:      {-12507, "Validate portal v4 remote address error"},
 	// bridgeagg
 	GetBridgeAggStateError:                    {-13000, "Get bridge agg state error"},
 	BridgeAggEstimateFeeByBurntAmountError:    {-13001, "Bridge agg estimate fee by burnt amount error"},
@@ -316,6 +327,9 @@ var ErrCodeMessage = map[int]struct {
 
 	// prune
 	PruneError: {-14000, "Prune error"},
+
+	// state proof
+	GetStateProofError: {-15000, "Get state proof error"},
 }
 
 // RPCError represents an error that is used as a part of a JSON-RPC JsonResponse
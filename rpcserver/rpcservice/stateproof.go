@@ -0,0 +1,164 @@
+package rpcservice
+
+import (
+	"fmt"
+
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/config"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/incdb"
+	"github.com/incognitochain/incognito-chain/rpcserver/jsonresult"
+)
+
+// State dbs of ShardRootHash & BeaconRootHash
+const (
+	ConsensusStateDB   = "Consensus"
+	TransactionStateDB = "Transaction"
+	FeatureStateDB     = "Feature"
+	RewardStateDB      = "Reward"
+)
+
+// GetShardStateProof returns the value of key in a state db of a shard block with its Merkle proof against the root
+// of that state db, and the signed headers of the block and of its finalized child committing the state roots.
+// If blockHash is empty, the block is the parent of the final block
+func (blockService BlockService) GetShardStateProof(
+	shardID byte, blockHash common.Hash, stateDBName string, key common.Hash,
+) (*jsonresult.StateProof, error) {
+	if int(shardID) >= config.Param().ActiveShards {
+		return nil, fmt.Errorf("Invalid shard ID %d", shardID)
+	}
+	finalView, ok := blockService.BlockChain.ShardChain[shardID].GetFinalView().(*blockchain.ShardBestState)
+	if !ok {
+		return nil, fmt.Errorf("Final view of shard %d not found", shardID)
+	}
+	if blockHash.IsZeroValue() {
+		blockHash = finalView.BestBlock.Header.PreviousBlockHash
+	}
+	block, _, err := blockService.BlockChain.GetShardBlockByHashWithShardID(blockHash, shardID)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot get shard block %s: %v", blockHash.String(), err)
+	}
+	if block.Header.Height >= finalView.ShardHeight {
+		return nil, fmt.Errorf("Shard block %s is not committed by a finalized block yet", blockHash.String())
+	}
+	db := blockService.BlockChain.GetShardChainDatabase(shardID)
+	commitBlockHash, err := rawdbv2.GetFinalizedShardBlockHashByIndex(db, shardID, block.Header.Height+1)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot get finalized shard block at height %d: %v", block.Header.Height+1, err)
+	}
+	commitBlock, _, err := blockService.BlockChain.GetShardBlockByHashWithShardID(*commitBlockHash, shardID)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot get shard block %s: %v", commitBlockHash.String(), err)
+	}
+	if commitBlock.Header.PreviousBlockHash != blockHash {
+		return nil, fmt.Errorf("Shard block %s is not finalized", blockHash.String())
+	}
+	rootHash, err := blockchain.GetShardRootsHashByBlockHash(db, shardID, blockHash)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot get state roots of shard block %s: %v", blockHash.String(), err)
+	}
+	if commitBlock.Header.ParentStateRootsHash != rootHash.Hash() {
+		return nil, fmt.Errorf("State roots of shard block %s are not committed in the header of block %s", blockHash.String(), commitBlockHash.String())
+	}
+	var root common.Hash
+	switch stateDBName {
+	case ConsensusStateDB:
+		root = rootHash.ConsensusStateDBRootHash
+	case TransactionStateDB:
+		root = rootHash.TransactionStateDBRootHash
+	case FeatureStateDB:
+		root = rootHash.FeatureStateDBRootHash
+	case RewardStateDB:
+		root = rootHash.RewardStateDBRootHash
+	default:
+		return nil, fmt.Errorf("Invalid shard state db %s", stateDBName)
+	}
+	result, err := getStateProof(db, blockHash, stateDBName, root, key)
+	if err != nil {
+		return nil, err
+	}
+	result.StateRoots = rootHash
+	result.Block = jsonresult.SignedHeader{Hash: blockHash.String(), Header: block.Header, ValidationData: block.ValidationData}
+	result.CommitBlock = jsonresult.SignedHeader{Hash: commitBlockHash.String(), Header: commitBlock.Header, ValidationData: commitBlock.ValidationData}
+	return result, nil
+}
+
+// GetBeaconStateProof returns the value of key in a state db of a beacon block with its Merkle proof against the root
+// of that state db, and the signed headers of the block and of its finalized child committing the state roots.
+// If blockHash is empty, the block is the parent of the final block
+func (blockService BlockService) GetBeaconStateProof(
+	blockHash common.Hash, stateDBName string, key common.Hash,
+) (*jsonresult.StateProof, error) {
+	finalView := blockService.BlockChain.BeaconChain.GetFinalViewState()
+	if blockHash.IsZeroValue() {
+		blockHash = finalView.BestBlock.Header.PreviousBlockHash
+	}
+	block, _, err := blockService.BlockChain.GetBeaconBlockByHash(blockHash)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot get beacon block %s: %v", blockHash.String(), err)
+	}
+	if block.Header.Height >= finalView.BeaconHeight {
+		return nil, fmt.Errorf("Beacon block %s is not committed by a finalized block yet", blockHash.String())
+	}
+	db := blockService.BlockChain.GetBeaconChainDatabase()
+	commitBlockHash, err := rawdbv2.GetFinalizedBeaconBlockHashByIndex(db, block.Header.Height+1)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot get finalized beacon block at height %d: %v", block.Header.Height+1, err)
+	}
+	commitBlock, _, err := blockService.BlockChain.GetBeaconBlockByHash(*commitBlockHash)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot get beacon block %s: %v", commitBlockHash.String(), err)
+	}
+	if commitBlock.Header.PreviousBlockHash != blockHash {
+		return nil, fmt.Errorf("Beacon block %s is not finalized", blockHash.String())
+	}
+	rootHash, err := blockchain.GetBeaconRootsHashByBlockHash(db, blockHash)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot get state roots of beacon block %s: %v", blockHash.String(), err)
+	}
+	if commitBlock.Header.ParentStateRootsHash != rootHash.Hash() {
+		return nil, fmt.Errorf("State roots of beacon block %s are not committed in the header of block %s", blockHash.String(), commitBlockHash.String())
+	}
+	var root common.Hash
+	switch stateDBName {
+	case ConsensusStateDB:
+		root = rootHash.ConsensusStateDBRootHash
+	case FeatureStateDB:
+		root = rootHash.FeatureStateDBRootHash
+	case RewardStateDB:
+		root = rootHash.RewardStateDBRootHash
+	default:
+		return nil, fmt.Errorf("Invalid beacon state db %s", stateDBName)
+	}
+	result, err := getStateProof(db, blockHash, stateDBName, root, key)
+	if err != nil {
+		return nil, err
+	}
+	result.StateRoots = rootHash
+	result.Block = jsonresult.SignedHeader{Hash: blockHash.String(), Header: block.Header, ValidationData: block.ValidationData}
+	result.CommitBlock = jsonresult.SignedHeader{Hash: commitBlockHash.String(), Header: commitBlock.Header, ValidationData: commitBlock.ValidationData}
+	return result, nil
+}
+
+func getStateProof(
+	db incdb.Database, blockHash common.Hash, stateDBName string, root, key common.Hash,
+) (*jsonresult.StateProof, error) {
+	stateDB, err := statedb.NewWithPrefixTrie(root, statedb.NewDatabaseAccessWarper(db))
+	if err != nil {
+		return nil, fmt.Errorf("Cannot open %s state db at root %s: %v", stateDBName, root.String(), err)
+	}
+	value, proof, err := stateDB.GetProof(key)
+	if err != nil {
+		return nil, err
+	}
+	return &jsonresult.StateProof{
+		BlockHash: blockHash.String(),
+		StateDB:   stateDBName,
+		RootHash:  root.String(),
+		Key:       key.String(),
+		Value:     value,
+		Proof:     proof,
+	}, nil
+}
@@ -403,15 +403,6 @@ func (txService TxService) chooseOutsCoinByKeyset(
 	metadataParam metadata.Metadata,
 	privacyCustomTokenParams *transaction.TokenParam,
 ) ([]coin.PlainCoin, uint64, *RPCError) {
-	// estimate fee according to 8 recent block
-	if numBlock == 0 {
-		numBlock = 1000
-	}
-	// calculate total amount to send
-	totalAmmount := uint64(0)
-	for _, receiver := range paymentInfos {
-		totalAmmount += receiver.Amount
-	}
 	// get list outputcoins tx
 	prvCoinID := &common.Hash{}
 	prvCoinID.SetBytes(common.PRVCoinID[:])
@@ -424,6 +415,29 @@ func (txService TxService) chooseOutsCoinByKeyset(
 	if err != nil {
 		return nil, 0, NewRPCError(GetOutputCoinError, err)
 	}
+	return txService.chooseOutsCoinToSpent(plainCoins, paymentInfos, unitFeeNativeToken, numBlock, keySet.PaymentAddress,
+		shardIDSender, hasPrivacy, metadataParam, privacyCustomTokenParams)
+}
+
+// chooseOutsCoinToSpent returns the coins native token to spent among plainCoins, with enough value
+// for the receivers and the estimated fee
+func (txService TxService) chooseOutsCoinToSpent(
+	plainCoins []coin.PlainCoin,
+	paymentInfos []*privacy.PaymentInfo,
+	unitFeeNativeToken int64, numBlock uint64, senderAddress privacy.PaymentAddress, shardIDSender byte,
+	hasPrivacy bool,
+	metadataParam metadata.Metadata,
+	privacyCustomTokenParams *transaction.TokenParam,
+) ([]coin.PlainCoin, uint64, *RPCError) {
+	// estimate fee according to 8 recent block
+	if numBlock == 0 {
+		numBlock = 1000
+	}
+	// calculate total amount to send
+	totalAmmount := uint64(0)
+	for _, receiver := range paymentInfos {
+		totalAmmount += receiver.Amount
+	}
 	if len(plainCoins) == 0 && totalAmmount > 0 {
 		return nil, 0, NewRPCError(GetOutputCoinError, errors.New("not enough output coin"))
 	}
@@ -438,7 +452,7 @@ func (txService TxService) chooseOutsCoinByKeyset(
 	if overBalanceAmount > 0 {
 		// add more into output for estimate fee
 		paymentInfos = append(paymentInfos, &privacy.PaymentInfo{
-			PaymentAddress: keySet.PaymentAddress,
+			PaymentAddress: senderAddress,
 			Amount:         overBalanceAmount,
 		})
 	}
@@ -707,7 +721,7 @@ func (txService TxService) SendRawTransaction(txB58Check string) (wire.Message,
 			isDoubleSpend, canReplaceOldTx, oldTx, _ := sChain.TxPool.CheckDoubleSpendWithCurMem(tx)
 			if isDoubleSpend {
 				if !canReplaceOldTx {
-					return nil, nil, byte(0), NewRPCError(RejectDoubleSpendTxError, fmt.Errorf("Tx %v is double spend with tx %v in mempool", tx.Hash().String(), oldTx))
+					return nil, nil, byte(0), NewRPCError(RejectDoubleSpendTxError, fmt.Errorf("Tx %v is double spend with tx %v in mempool, a replacement must spend the same coins and pay a higher fee rate", tx.Hash().String(), oldTx))
 				}
 			}
 			bcView, err := txService.BlockChain.GetBeaconViewStateDataFromBlockHash(sView.BestBeaconHash, isTxRelateCommittee(tx), false, false)
@@ -951,36 +965,37 @@ func (txService TxService) BuildTokenParamV2(tokenParamsRaw map[string]interface
 	return privacyTokenParam, nil
 }
 
-func (txService TxService) BuildPrivacyCustomTokenParamV2(tokenParamsRaw map[string]interface{}, senderKeySet *incognitokey.KeySet, shardIDSender byte) (*transaction.TokenParam, map[common.Hash]transaction.TransactionToken, map[common.Hash]types.CrossShardTokenPrivacyMetaData, *RPCError) {
+// parsePrivacyCustomTokenParamV2 reads the token params of a request, it returns the amount of token the inputs must cover
+func parsePrivacyCustomTokenParamV2(tokenParamsRaw map[string]interface{}) (*transaction.TokenParam, int64, *RPCError) {
 	property, ok := tokenParamsRaw["TokenID"].(string)
 	if !ok {
-		return nil, nil, nil, NewRPCError(RPCInvalidParamsError, fmt.Errorf("Invalid Token ID, Params %+v ", tokenParamsRaw))
+		return nil, 0, NewRPCError(RPCInvalidParamsError, fmt.Errorf("Invalid Token ID, Params %+v ", tokenParamsRaw))
 	}
 	_, ok = tokenParamsRaw["TokenReceivers"]
 	if !ok {
-		return nil, nil, nil, NewRPCError(RPCInvalidParamsError, errors.New("Token Receiver is invalid"))
+		return nil, 0, NewRPCError(RPCInvalidParamsError, errors.New("Token Receiver is invalid"))
 	}
 	tokenName, ok := tokenParamsRaw["TokenName"].(string)
 	if !ok {
-		return nil, nil, nil, NewRPCError(RPCInvalidParamsError, fmt.Errorf("Invalid Token Name, Params %+v ", tokenParamsRaw))
+		return nil, 0, NewRPCError(RPCInvalidParamsError, fmt.Errorf("Invalid Token Name, Params %+v ", tokenParamsRaw))
 	}
 	tokenSymbol, ok := tokenParamsRaw["TokenSymbol"].(string)
 	if !ok {
-		return nil, nil, nil, NewRPCError(RPCInvalidParamsError, fmt.Errorf("Invalid Token Symbol, Params %+v ", tokenParamsRaw))
+		return nil, 0, NewRPCError(RPCInvalidParamsError, fmt.Errorf("Invalid Token Symbol, Params %+v ", tokenParamsRaw))
 	}
 	tokenTxType, ok := tokenParamsRaw["TokenTxType"].(float64)
 	if !ok {
-		return nil, nil, nil, NewRPCError(RPCInvalidParamsError, fmt.Errorf("Invalid Token Tx Type, Params %+v ", tokenParamsRaw))
+		return nil, 0, NewRPCError(RPCInvalidParamsError, fmt.Errorf("Invalid Token Tx Type, Params %+v ", tokenParamsRaw))
 	}
 
 	tokenAmount, err := common.AssertAndConvertStrToNumber(tokenParamsRaw["TokenAmount"])
 	if err != nil {
-		return nil, nil, nil, NewRPCError(RPCInvalidParamsError, fmt.Errorf("Invalid Token Amout - error: %+v ", err))
+		return nil, 0, NewRPCError(RPCInvalidParamsError, fmt.Errorf("Invalid Token Amout - error: %+v ", err))
 	}
 
 	tokenFee, err := common.AssertAndConvertStrToNumber(tokenParamsRaw["TokenFee"])
 	if err != nil {
-		return nil, nil, nil, NewRPCError(RPCInvalidParamsError, fmt.Errorf("Invalid Token Fee - error: %+v ", err))
+		return nil, 0, NewRPCError(RPCInvalidParamsError, fmt.Errorf("Invalid Token Fee - error: %+v ", err))
 	}
 
 	if tokenTxType == transaction.CustomTokenInit {
@@ -995,14 +1010,21 @@ func (txService TxService) BuildPrivacyCustomTokenParamV2(tokenParamsRaw map[str
 		TokenInput:     nil,
 		Fee:            uint64(tokenFee),
 	}
-	voutsAmount := int64(0)
+	var voutsAmount int64
 	var err1 error
-
 	tokenParams.Receiver, voutsAmount, err1 = CreateCustomTokenPrivacyReceiverArrayV2(tokenParamsRaw["TokenReceivers"])
 	if err1 != nil {
-		return nil, nil, nil, NewRPCError(RPCInvalidParamsError, err1)
+		return nil, 0, NewRPCError(RPCInvalidParamsError, err1)
 	}
 	voutsAmount += int64(tokenFee)
+	return tokenParams, voutsAmount, nil
+}
+
+func (txService TxService) BuildPrivacyCustomTokenParamV2(tokenParamsRaw map[string]interface{}, senderKeySet *incognitokey.KeySet, shardIDSender byte) (*transaction.TokenParam, map[common.Hash]transaction.TransactionToken, map[common.Hash]types.CrossShardTokenPrivacyMetaData, *RPCError) {
+	tokenParams, voutsAmount, rpcErr := parsePrivacyCustomTokenParamV2(tokenParamsRaw)
+	if rpcErr != nil {
+		return nil, nil, nil, rpcErr
+	}
 	// get list custom token
 	switch tokenParams.TokenTxType {
 	case transaction.CustomTokenTransfer:
@@ -1261,6 +1283,134 @@ func (txService TxService) BuildRawPrivacyCustomTokenTransactionV2(params interf
 	return tx, nil
 }
 
+// getUnsignedTxOutCoins returns the coins v2 of tokenID which a key set without private key can spend.
+// The node cannot compute the key images of the coins, so it does not know which of them are spent :
+// the caller excludes them by their public keys.
+func (txService TxService) getUnsignedTxOutCoins(keySet *incognitokey.KeySet, shardIDSender byte, tokenID *common.Hash, excludedCoins map[string]bool) ([]coin.PlainCoin, *RPCError) {
+	plainCoins, err := txService.BlockChain.TryGetAllOutputCoinsByKeyset(keySet, shardIDSender, tokenID, false)
+	if err != nil {
+		return nil, NewRPCError(GetOutputCoinError, err)
+	}
+	result := make([]coin.PlainCoin, 0, len(plainCoins))
+	for _, plainCoin := range plainCoins {
+		if plainCoin.GetVersion() != 2 {
+			continue
+		}
+		if excludedCoins[base58.Base58Check{}.Encode(plainCoin.GetPublicKey().ToBytesS(), common.ZeroByte)] {
+			continue
+		}
+		result = append(result, plainCoin)
+	}
+	return result, nil
+}
+
+func getCoinPublicKeys(coins []coin.PlainCoin) []string {
+	result := make([]string, 0, len(coins))
+	for _, c := range coins {
+		result = append(result, base58.Base58Check{}.Encode(c.GetPublicKey().ToBytesS(), common.ZeroByte))
+	}
+	return result
+}
+
+// BuildUnsignedTransaction builds a PRV transfer from the read-only keys of the sender, which is signed offline.
+// It also returns the public keys of the coins spent by the transaction.
+func (txService TxService) BuildUnsignedTransaction(params *bean.CreateUnsignedTxParam) (*transaction.UnsignedTx, []string, *RPCError) {
+	Logger.log.Infof("Build Unsigned Transaction Params: \n %+v", params)
+	plainCoins, err1 := txService.getUnsignedTxOutCoins(params.SenderKeySet, params.ShardIDSender, &common.PRVCoinID, params.ExcludedCoins)
+	if err1 != nil {
+		return nil, nil, err1
+	}
+	// get output coins to spend and real fee
+	inputCoins, realFee, err1 := txService.chooseOutsCoinToSpent(plainCoins,
+		params.PaymentInfos, params.EstimateFeeCoinPerKb, 0,
+		params.SenderKeySet.PaymentAddress, params.ShardIDSender, true,
+		nil, nil)
+	if err1 != nil {
+		return nil, nil, err1
+	}
+
+	txPrivacyParams := transaction.NewTxPrivacyInitParams(
+		nil,
+		params.PaymentInfos,
+		inputCoins,
+		realFee,
+		true,
+		txService.BlockChain.GetBestStateShard(params.ShardIDSender).GetCopiedTransactionStateDB(),
+		nil, // use for prv coin -> nil is valid
+		nil,
+		params.Info,
+	)
+	unsignedTx, err := transaction.NewUnsignedTx(txPrivacyParams, params.SenderKeySet.PaymentAddress)
+	if err != nil {
+		return nil, nil, NewRPCError(CreateTxDataError, err)
+	}
+	return unsignedTx, getCoinPublicKeys(inputCoins), nil
+}
+
+// BuildUnsignedPrivacyCustomTokenTransaction builds a pToken transfer from the read-only keys of the sender,
+// which is signed offline. The fee is paid in PRV.
+// It also returns the public keys of the PRV and token coins spent by the transaction.
+func (txService TxService) BuildUnsignedPrivacyCustomTokenTransaction(params *bean.CreateUnsignedTxParam) (*transaction.UnsignedTx, []string, *RPCError) {
+	Logger.log.Infof("Build Unsigned Token Transaction Params: \n %+v", params)
+	tokenParams, voutsAmount, err1 := parsePrivacyCustomTokenParamV2(params.TokenParamsRaw)
+	if err1 != nil {
+		return nil, nil, err1
+	}
+	if tokenParams.TokenTxType != transaction.CustomTokenTransfer {
+		return nil, nil, NewRPCError(RPCInvalidParamsError, errors.New("an unsigned transaction can only transfer a token"))
+	}
+	if tokenParams.Fee > 0 {
+		return nil, nil, NewRPCError(RPCInvalidParamsError, errors.New("the fee of an unsigned transaction is paid in PRV"))
+	}
+	tokenID, err := common.Hash{}.NewHashFromStr(tokenParams.PropertyID)
+	if err != nil {
+		return nil, nil, NewRPCError(RPCInvalidParamsError, errors.New("Invalid Token ID"))
+	}
+
+	/******* START choose output token coins, which is used to create tx *****/
+	tokenCoins, err1 := txService.getUnsignedTxOutCoins(params.SenderKeySet, params.ShardIDSender, tokenID, params.ExcludedCoins)
+	if err1 != nil {
+		return nil, nil, err1
+	}
+	tokenParams.TokenInput, _, _, err = txService.chooseBestOutCoinsToSpent(tokenCoins, uint64(voutsAmount))
+	if err != nil {
+		return nil, nil, NewRPCError(GetOutputCoinError, err)
+	}
+	/******* END choose output token coins *****/
+
+	/******* START choose output native coins(PRV), which is used to pay the fee *****/
+	plainCoins, err1 := txService.getUnsignedTxOutCoins(params.SenderKeySet, params.ShardIDSender, &common.PRVCoinID, params.ExcludedCoins)
+	if err1 != nil {
+		return nil, nil, err1
+	}
+	inputCoins, realFeePRV, err1 := txService.chooseOutsCoinToSpent(plainCoins,
+		params.PaymentInfos, params.EstimateFeeCoinPerKb, 0,
+		params.SenderKeySet.PaymentAddress, params.ShardIDSender, true,
+		nil, tokenParams)
+	if err1 != nil {
+		return nil, nil, err1
+	}
+	/******* END choose output native coins(PRV) *****/
+	beaconView := txService.BlockChain.BeaconChain.GetFinalViewState()
+
+	txTokenParams := transaction.NewTxTokenParams(nil,
+		params.PaymentInfos,
+		inputCoins,
+		realFeePRV,
+		tokenParams,
+		txService.BlockChain.GetBestStateShard(params.ShardIDSender).GetCopiedTransactionStateDB(),
+		nil,
+		true,
+		true,
+		params.ShardIDSender, params.Info,
+		beaconView.GetBeaconFeatureStateDB())
+	unsignedTx, err := transaction.NewUnsignedTxToken(txTokenParams, params.SenderKeySet.PaymentAddress)
+	if err != nil {
+		return nil, nil, NewRPCError(CreateTxDataError, err)
+	}
+	return unsignedTx, append(getCoinPublicKeys(inputCoins), getCoinPublicKeys(tokenParams.TokenInput)...), nil
+}
+
 func (txService TxService) GetTransactionHashByReceiver(paymentAddressParam string) (map[byte][]common.Hash, error) {
 	var keySet *incognitokey.KeySet
 
@@ -1842,7 +1992,7 @@ func (txService TxService) GetListPrivacyCustomTokenBalanceNew(privateKey string
 	return res, nil
 }
 
-func (txService TxService) GetBalancePrivacyCustomToken(privateKey string, tokenIDStr string) (uint64, *RPCError) {
+func (txService TxService) GetBalancePrivacyCustomToken(privateKey string, tokenIDStr string, atHeight uint64) (uint64, *RPCError) {
 	var totalValue uint64 = 0
 	account, err := wallet.Base58CheckDeserialize(privateKey)
 	if err != nil {
@@ -1871,7 +2021,7 @@ func (txService TxService) GetBalancePrivacyCustomToken(privateKey string, token
 		shardIDSender := common.GetShardIDFromLastByte(lastByte)
 		// Get balance privacy custom token should use 0 as start height, because we do not use this in production anyway
 		// Get from 0 to get all the coins starting from begin to end
-		outcoins, err := txService.BlockChain.TryGetAllOutputCoinsByKeyset(&account.KeySet, shardIDSender, tokenID, true)
+		outcoins, err := tryGetAllOutputCoinsByKeyset(txService.BlockChain, &account.KeySet, shardIDSender, tokenID, atHeight)
 		if err != nil {
 			Logger.log.Debugf("handleGetBalancePrivacyCustomToken result: %+v, err: %+v", nil, err)
 			return uint64(0), NewRPCError(UnexpectedError, err)
@@ -1898,7 +2048,7 @@ func (txService TxService) GetBalancePrivacyCustomToken(privateKey string, token
 					if tokenID.IsEqual(tempTokenID) {
 						lastByte := account.KeySet.PaymentAddress.Pk[len(account.KeySet.PaymentAddress.Pk)-1]
 						shardIDSender := common.GetShardIDFromLastByte(lastByte)
-						outcoints, err := txService.BlockChain.TryGetAllOutputCoinsByKeyset(&account.KeySet, shardIDSender, tempTokenID, true)
+						outcoints, err := tryGetAllOutputCoinsByKeyset(txService.BlockChain, &account.KeySet, shardIDSender, tempTokenID, atHeight)
 						if err != nil {
 							Logger.log.Debugf("handleGetBalancePrivacyCustomToken result: %+v, err: %+v", nil, err)
 							return uint64(0), NewRPCError(UnexpectedError, err)
@@ -2032,7 +2182,7 @@ func (txService TxService) SendRawPrivacyCustomTokenTransaction(base58CheckData
 			isDoubleSpend, canReplaceOldTx, oldTx, _ := sChain.TxPool.CheckDoubleSpendWithCurMem(tx)
 			if isDoubleSpend {
 				if !canReplaceOldTx {
-					return nil, nil, NewRPCError(RejectDoubleSpendTxError, fmt.Errorf("Tx %v is double spend with tx %v in mempool", tx.Hash().String(), oldTx))
+					return nil, nil, NewRPCError(RejectDoubleSpendTxError, fmt.Errorf("Tx %v is double spend with tx %v in mempool, a replacement must spend the same coins and pay a higher fee rate", tx.Hash().String(), oldTx))
 				}
 			}
 
@@ -2600,7 +2750,8 @@ func (txService TxService) BuildRawDefragmentPrivacyCustomTokenTransaction(param
 func isTxRelateCommittee(tx metadata.Transaction) bool {
 	if tx.GetMetadata() != nil {
 		switch tx.GetMetadata().GetType() {
-		case metadata.BeaconStakingMeta, metadata.ShardStakingMeta, metadata.StopAutoStakingMeta, metadata.UnStakingMeta:
+		case metadata.BeaconStakingMeta, metadata.ShardStakingMeta, metadata.StopAutoStakingMeta, metadata.UnStakingMeta,
+			metadata.CreateDelegationPoolMeta:
 			return true
 		}
 	}
@@ -133,7 +133,7 @@ func (walletService *WalletService) RemoveAccount(privateKey string, passPhrase
 	return true, nil
 }
 
-func (walletService WalletService) GetBalanceByPrivateKey(privateKey string) (uint64, *RPCError) {
+func (walletService WalletService) GetBalanceByPrivateKey(privateKey string, atHeight uint64) (uint64, *RPCError) {
 	keySet, shardIDSender, err := GetKeySetFromPrivateKeyParams(privateKey)
 	if err != nil {
 		return uint64(0), NewRPCError(RPCInvalidParamsError, err)
@@ -148,7 +148,7 @@ func (walletService WalletService) GetBalanceByPrivateKey(privateKey string) (ui
 	}
 
 	// Get balance by private key should return all tokens belong to this private key, so start at 0
-	outcoints, err := walletService.BlockChain.TryGetAllOutputCoinsByKeyset(keySet, shardIDSender, prvCoinID, true)
+	outcoints, err := tryGetAllOutputCoinsByKeyset(walletService.BlockChain, keySet, shardIDSender, prvCoinID, atHeight)
 	if err != nil {
 		return uint64(0), NewRPCError(UnexpectedError, err)
 	}
@@ -161,7 +161,7 @@ func (walletService WalletService) GetBalanceByPrivateKey(privateKey string) (ui
 	return balance, nil
 }
 
-func (walletService WalletService) GetBalanceByPaymentAddress(paymentAddress string) (uint64, *RPCError) {
+func (walletService WalletService) GetBalanceByPaymentAddress(paymentAddress string, atHeight uint64) (uint64, *RPCError) {
 	keySet, shardIDSender, err := GetKeySetFromPaymentAddressParam(paymentAddress)
 	if err != nil {
 		return uint64(0), NewRPCError(RPCInvalidParamsError, errors.New("payment address is invalid"))
@@ -174,7 +174,7 @@ func (walletService WalletService) GetBalanceByPaymentAddress(paymentAddress str
 	}
 
 	// Get balance should get all, so start from zero
-	outcoints, err := walletService.BlockChain.TryGetAllOutputCoinsByKeyset(keySet, shardIDSender, prvCoinID, true)
+	outcoints, err := tryGetAllOutputCoinsByKeyset(walletService.BlockChain, keySet, shardIDSender, prvCoinID, atHeight)
 	Logger.log.Debugf("OutCoins: %+v", outcoints)
 	Logger.log.Debugf("shardIDSender: %+v", shardIDSender)
 	Logger.log.Debugf("accountWithPaymentAddress.KeySet: %+v", keySet)
@@ -17,6 +17,9 @@ import (
 	"os"
 	"strconv"
 	"time"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/rpcserver/rpcservice"
 )
 
 // genCertPair generates a key/cert pair to the paths provided.
@@ -194,3 +197,49 @@ func (u *Uint64Reader) UnmarshalJSON(raw []byte) error {
 	*u = Uint64Reader(theNum)
 	return err
 }
+
+// getAtHeightParam reads the beacon height a state query is answered at from the "AtHeight"
+// field of a params object, the "BeaconHeight" field is still read for backward compatibility.
+// It returns 0, which means the best view, when neither is set.
+func getAtHeightParam(data map[string]interface{}) (uint64, error) {
+	for _, key := range []string{"AtHeight", "BeaconHeight"} {
+		if value, ok := data[key]; ok && value != nil {
+			height, err := common.AssertAndConvertNumber(value)
+			if err != nil {
+				return 0, fmt.Errorf("%v is invalid: %v", key, err)
+			}
+			return height, nil
+		}
+	}
+	return 0, nil
+}
+
+// getOptionalAtHeightParam reads the beacon height a state query is answered at from the
+// optional positional param at index. It returns 0, which means the best view, when it is not set.
+func getOptionalAtHeightParam(arrayParams []interface{}, index int) (uint64, error) {
+	if len(arrayParams) <= index || arrayParams[index] == nil {
+		return 0, nil
+	}
+	height, err := common.AssertAndConvertNumber(arrayParams[index])
+	if err != nil {
+		return 0, fmt.Errorf("AtHeight is invalid: %v", err)
+	}
+	return height, nil
+}
+
+// readParamsObject decodes the params object (the first element of params) into reader
+func readParamsObject(params interface{}, reader interface{}) *rpcservice.RPCError {
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) == 0 {
+		return rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Payload data is invalid"))
+	}
+	rawData, err := json.Marshal(arrayParams[0])
+	if err != nil {
+		return rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	}
+	err = json.Unmarshal(rawData, reader)
+	if err != nil {
+		return rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, fmt.Errorf("cannot deserialize parameters %v", err))
+	}
+	return nil
+}
@@ -23,6 +23,7 @@ type WsServer struct {
 	statusLock   sync.RWMutex
 	authSHA      []byte
 	limitAuthSHA []byte
+	apiKeys      []*rpcAPIKey
 	// channel
 	cRequestProcessShutdown chan struct{}
 
@@ -39,6 +40,8 @@ type SubcriptionManager struct {
 	subMtx         sync.RWMutex
 	subRequestList map[string]map[common.Hash]chan struct{} // String: Subcription Method, Hash: hash from Subcription Params
 	ws             *websocket.Conn
+	// apiKey authenticated the connection, its allowlist applies to the subscriptions
+	apiKey *rpcAPIKey
 }
 
 var upgrader = websocket.Upgrader{
@@ -48,6 +51,10 @@ var upgrader = websocket.Upgrader{
 
 func (wsServer *WsServer) Init(config *RpcServerConfig) {
 	wsServer.config = *config
+	wsServer.apiKeys = nil
+	for _, apiKey := range config.APIKeys {
+		wsServer.apiKeys = append(wsServer.apiKeys, newRPCAPIKey(apiKey))
+	}
 
 	// init service
 	wsServer.blockService = &rpcservice.BlockService{
@@ -110,11 +117,17 @@ func (wsServer *WsServer) Stop() {
 /*
 Handle all ws request to rpcserver
 */
-// @NOTICE: no auth for this version yet
+// @NOTICE: the websocket has no rpc user, only the API keys authenticate its clients
 func (wsServer *WsServer) handleWsRequest(w http.ResponseWriter, r *http.Request) {
 	if wsServer.limitWsConnections(w, r.RemoteAddr) {
 		return
 	}
+	apiKey, err := wsServer.checkAPIKeyAuth(r)
+	if err != nil {
+		Logger.log.Error(err)
+		AuthFail(w)
+		return
+	}
 	// Keep track of the number of connected clients.
 	wsServer.IncrementWsClients()
 	defer wsServer.DecrementWsClients()
@@ -126,7 +139,23 @@ func (wsServer *WsServer) handleWsRequest(w http.ResponseWriter, r *http.Request
 	if err != nil {
 		return
 	}
-	wsServer.ProcessRpcWsRequest(ws)
+	wsServer.processRpcWsRequest(ws, apiKey)
+}
+
+// checkAPIKeyAuth returns the API key of the upgrade request r. When API keys are configured,
+// r must be authenticated with one of them, else the websocket is open to every client.
+//
+// This check is time-constant.
+func (wsServer *WsServer) checkAPIKeyAuth(r *http.Request) (*rpcAPIKey, error) {
+	if wsServer.config.DisableAuth || len(wsServer.apiKeys) == 0 {
+		return nil, nil
+	}
+	apiKey := findAPIKey(wsServer.apiKeys, r)
+	if apiKey == nil {
+		Logger.log.Warnf("RPC Websocket authentication failure from %s", r.RemoteAddr)
+		return nil, rpcservice.NewRPCError(rpcservice.AuthFailError, nil)
+	}
+	return apiKey, nil
 }
 
 func (wsServer *WsServer) limitWsConnections(w http.ResponseWriter, remoteAddr string) bool {
@@ -150,12 +179,19 @@ func (wsServer *WsServer) DecrementWsClients() {
 }
 
 func (wsServer *WsServer) ProcessRpcWsRequest(ws *websocket.Conn) {
+	wsServer.processRpcWsRequest(ws, nil)
+}
+
+// processRpcWsRequest handles the subscriptions of a client authenticated with apiKey,
+// or of any client when apiKey is nil.
+func (wsServer *WsServer) processRpcWsRequest(ws *websocket.Conn, apiKey *rpcAPIKey) {
 	if atomic.LoadInt32(&wsServer.shutdown) != 0 {
 		return
 	}
 	defer ws.Close()
 	// one sub manager will manage connection and subcription with one client (one websocket connection)
 	subManager := NewSubscriptionManager(ws)
+	subManager.apiKey = apiKey
 	for {
 		msgType, msg, err := ws.ReadMessage()
 		if err != nil {
@@ -200,8 +236,12 @@ func (wsServer *WsServer) subscribe(subManager *SubcriptionManager, subRequest *
 	command := WsHandler[request.Method]
 	if command == nil {
 		jsonErr = rpcservice.NewRPCError(rpcservice.RPCMethodNotFoundError, errors.New("Method"+request.Method+"Not found"))
+	} else if apiKey := subManager.apiKey; apiKey != nil && !apiKey.isAllowed(request.Method) {
+		jsonErr = rpcservice.NewRPCError(rpcservice.RPCInvalidMethodPermissionError, errors.New("Method is not allowed for API key "+apiKey.name+": "+request.Method))
+	}
+	if jsonErr != nil {
 		Logger.log.Errorf("RPC from client %+v error %+v", subManager.ws.RemoteAddr(), jsonErr)
-		//Notify user, method not found
+		//Notify user, method not found or not allowed
 		res, err := createMarshalledSubResponse(subRequest, nil, jsonErr)
 		if err != nil {
 			Logger.log.Errorf("Failed to marshal reply: %s", err.Error())
@@ -2,14 +2,17 @@ package rpcserver
 
 import (
 	"errors"
+	"fmt"
 	"reflect"
 
 	"github.com/incognitochain/incognito-chain/blockchain"
 	"github.com/incognitochain/incognito-chain/blockchain/types"
 	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/common/base58"
 	"github.com/incognitochain/incognito-chain/pubsub"
 	"github.com/incognitochain/incognito-chain/rpcserver/jsonresult"
 	"github.com/incognitochain/incognito-chain/rpcserver/rpcservice"
+	coinIndexer "github.com/incognitochain/incognito-chain/transaction/coin_indexer"
 	"github.com/incognitochain/incognito-chain/wallet"
 )
 
@@ -195,3 +198,72 @@ func (wsServer *WsServer) handleSubcribeCrossCustomTokenPrivacyByPrivateKey(para
 		}
 	}
 }
+
+// handleSubcribeIncomingCoinByOTAKey pushes the output coins of newly finalized shard blocks that belong to an OTA key.
+// The OTA key (or private key) must have been submitted to the coin indexer beforehand.
+func (wsServer *WsServer) handleSubcribeIncomingCoinByOTAKey(params interface{}, subcription string, cResult chan RpcSubResult, closeChan <-chan struct{}) {
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) != 1 {
+		err := rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Methods should only contain ONE params"))
+		cResult <- RpcSubResult{Error: err}
+		return
+	}
+	keyStr, ok := arrayParams[0].(string)
+	if !ok {
+		err := rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Params is invalid"))
+		cResult <- RpcSubResult{Error: err}
+		return
+	}
+	keySet, _, err := rpcservice.GetKeySetFromPrivateKeyParams(keyStr)
+	if err != nil || keySet.OTAKey.GetOTASecretKey() == nil {
+		err := rpcservice.NewRPCError(rpcservice.InvalidSenderViewingKeyError, fmt.Errorf("OTA key not found, error: %v", err))
+		cResult <- RpcSubResult{Error: err}
+		return
+	}
+	indexer := blockchain.GetCoinIndexer()
+	if !blockchain.EnableIndexingCoinByOTAKey || indexer == nil {
+		err := rpcservice.NewRPCError(rpcservice.SubcribeError, errors.New("OTA key submission not supported by this node configuration"))
+		cResult <- RpcSubResult{Error: err}
+		return
+	}
+	if submitted, _ := indexer.HasOTAKey(coinIndexer.OTAKeyToRaw(keySet.OTAKey)); !submitted {
+		err := rpcservice.NewRPCError(rpcservice.SubcribeError, errors.New("OTA key has not been submitted"))
+		cResult <- RpcSubResult{Error: err}
+		return
+	}
+	publicKey := base58.Base58Check{}.Encode(keySet.OTAKey.GetPublicSpend().ToBytesS(), common.ZeroByte)
+
+	subId, subChan, err := wsServer.config.PubSubManager.RegisterNewSubscriber(pubsub.IncomingCoinTopic)
+	if err != nil {
+		err := rpcservice.NewRPCError(rpcservice.SubcribeError, err)
+		cResult <- RpcSubResult{Error: err}
+		return
+	}
+	defer func() {
+		Logger.log.Info("Finish Subscribe Incoming Coin")
+		wsServer.config.PubSubManager.Unsubscribe(pubsub.IncomingCoinTopic, subId)
+		close(cResult)
+	}()
+	for {
+		select {
+		case msg := <-subChan:
+			{
+				incomingCoins, ok := msg.Value.([]*coinIndexer.IncomingCoin)
+				if !ok {
+					Logger.log.Errorf("Wrong Message Type from Pubsub Manager, wanted []*coinIndexer.IncomingCoin, have %+v", reflect.TypeOf(msg.Value))
+					continue
+				}
+				for _, incomingCoin := range incomingCoins {
+					if incomingCoin.PublicKey == publicKey {
+						cResult <- RpcSubResult{Result: jsonresult.NewIncomingCoinResult(incomingCoin), Error: nil}
+					}
+				}
+			}
+		case <-closeChan:
+			{
+				cResult <- RpcSubResult{Result: jsonresult.UnsubcribeResult{Message: "Unsubscribe Incoming Coin"}}
+				return
+			}
+		}
+	}
+}
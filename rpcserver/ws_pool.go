@@ -1,42 +1,68 @@
 package rpcserver
 
+import (
+	"errors"
+	"reflect"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/pubsub"
+	"github.com/incognitochain/incognito-chain/rpcserver/jsonresult"
+	"github.com/incognitochain/incognito-chain/rpcserver/rpcservice"
+	"github.com/incognitochain/incognito-chain/txpool"
+)
+
+// handleSubcribeMempoolInfo streams the txs added to and removed from the tx pools of the node.
+// Param #1 (optional): shard ID, -1 or no param for all shards
 func (wsServer *WsServer) handleSubcribeMempoolInfo(params interface{}, subcription string, cResult chan RpcSubResult, closeChan <-chan struct{}) {
-	// Logger.log.Info("Handle Subcribe Mempool Informantion", params, subcription)
-	// arrayParams := common.InterfaceSlice(params)
-	// if len(arrayParams) != 0 {
-	// 	err := rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Methods should only contain NO params"))
-	// 	cResult <- RpcSubResult{Error: err}
-	// 	return
-	// }
-	// subId, subChan, err := wsServer.config.PubSubManager.RegisterNewSubscriber(pubsub.MempoolInfoTopic)
-	// if err != nil {
-	// 	err := rpcservice.NewRPCError(rpcservice.SubcribeError, err)
-	// 	cResult <- RpcSubResult{Error: err}
-	// 	return
-	// }
-	// defer func() {
-	// 	Logger.log.Info("Finish Subcribe Mempool Informantion")
-	// 	wsServer.config.PubSubManager.Unsubscribe(pubsub.MempoolInfoTopic, subId)
-	// 	close(cResult)
-	// }()
-	// for {
-	// 	select {
-	// 	case msg := <-subChan:
-	// 		{
-	// 			listTxs, ok := msg.Value.([]string)
-	// 			if !ok {
-	// 				Logger.log.Errorf("Wrong Message Type from Pubsub Manager, wanted []string, have %+v", reflect.TypeOf(msg.Value))
-	// 				continue
-	// 			}
-	// 			cResult <- RpcSubResult{Result: listTxs, Error: nil}
-	// 		}
-	// 	case <-closeChan:
-	// 		{
-	// 			cResult <- RpcSubResult{Result: jsonresult.UnsubcribeResult{Message: "Unsubscribe Mempool Info"}}
-	// 			return
-	// 		}
-	// 	}
-	// }
+	Logger.log.Info("Handle Subcribe Mempool Informantion", params, subcription)
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) > 1 {
+		err := rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Methods should only contain NO params or 1 param"))
+		cResult <- RpcSubResult{Error: err}
+		return
+	}
+	shardID := -1
+	if len(arrayParams) == 1 {
+		shardIDParam, ok := arrayParams[0].(float64)
+		if !ok || shardIDParam < -1 || int(shardIDParam) >= common.MaxShardNumber {
+			err := rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Shard ID is invalid"))
+			cResult <- RpcSubResult{Error: err}
+			return
+		}
+		shardID = int(shardIDParam)
+	}
+	subId, subChan, err := wsServer.config.PubSubManager.RegisterNewSubscriber(pubsub.MempoolInfoTopic)
+	if err != nil {
+		err := rpcservice.NewRPCError(rpcservice.SubcribeError, err)
+		cResult <- RpcSubResult{Error: err}
+		return
+	}
+	defer func() {
+		Logger.log.Info("Finish Subcribe Mempool Informantion")
+		wsServer.config.PubSubManager.Unsubscribe(pubsub.MempoolInfoTopic, subId)
+		close(cResult)
+	}()
+	for {
+		select {
+		case msg := <-subChan:
+			{
+				event, ok := msg.Value.(*txpool.MempoolEvent)
+				if !ok {
+					Logger.log.Errorf("Wrong Message Type from Pubsub Manager, wanted *txpool.MempoolEvent, have %+v", reflect.TypeOf(msg.Value))
+					continue
+				}
+				if shardID != -1 && int(event.ShardID) != shardID {
+					continue
+				}
+				cResult <- RpcSubResult{Result: jsonresult.NewMempoolEvent(event), Error: nil}
+			}
+		case <-closeChan:
+			{
+				cResult <- RpcSubResult{Result: jsonresult.UnsubcribeResult{Message: "Unsubscribe Mempool Info"}}
+				return
+			}
+		}
+	}
 }
 
 func (wsServer *WsServer) handleSubscribeBeaconPoolBestState(params interface{}, subcription string, cResult chan RpcSubResult, closeChan <-chan struct{}) {
@@ -201,6 +201,7 @@ func (serverObj *Server) NewServer(
 	indexerToken string,
 	protocolVer string,
 	btcChain *btcrelaying.BlockChain,
+	ltcChain *btcrelaying.BlockChain,
 	bnbChainState *bnbrelaying.BNBChainState,
 	p *pruner.PrunerManager,
 	interrupt <-chan struct{},
@@ -287,8 +288,9 @@ func (serverObj *Server) NewServer(
 			//mubft
 			OnBFTMsg:     serverObj.OnBFTMsg,
 			OnPeerState:  serverObj.OnPeerState,
-			OnFinishSync: serverObj.OnFinishSync,
-			OnFeatureMsg: serverObj.OnFeatureMsg,
+			OnFinishSync:    serverObj.OnFinishSync,
+			OnFeatureMsg:    serverObj.OnFeatureMsg,
+			OnSlashEvidence: serverObj.OnSlashEvidence,
 		},
 		BC: serverObj.blockChain,
 	}
@@ -311,9 +313,11 @@ func (serverObj *Server) NewServer(
 		common.MaxShardNumber,
 		serverObj.pusubManager,
 		time.Duration(cfg.TxPoolTTL)*time.Second,
+		cfg.TxPoolMaxTx,
 	)
 	err = serverObj.blockChain.Init(&blockchain.Config{
 		BTCChain:      btcChain,
+		LTCChain:      ltcChain,
 		BNBChainState: bnbChainState,
 		DataBase:      serverObj.dataBase,
 		MemCache:      serverObj.memCache,
@@ -332,6 +336,8 @@ func (serverObj *Server) NewServer(
 		OutCoinByOTAKeyDb: dboc,
 		IndexerWorkers:    indexerWorkers,
 		IndexerToken:      indexerToken,
+		IndexerWebhook:    cfg.IndexerWebhook,
+		WebhookRetries:    cfg.NumWebhookRetries,
 		PoolManager:       poolManager,
 	})
 	if err != nil {
@@ -340,6 +346,19 @@ func (serverObj *Server) NewServer(
 	serverObj.blockChain.InitChannelBlockchain(cRemovedTxs)
 	fixedNodes := serverObj.blockChain.GetShardFixedNodes()
 	blsbft.ByzantineDetectorObject.SetFixedNodes(fixedNodes)
+	if serverObj.blockChain.UsingNewPool() && cfg.IsPersistMempool {
+		// the mempool database keeps the snapshot of the txpool instead of the legacy mempool
+		if cfg.IsLoadFromMempool {
+			err = poolManager.LoadSnapshot(dbmp, serverObj.blockChain.GetShardViewsForTxPool)
+		} else {
+			err = poolManager.ResetSnapshot(dbmp)
+		}
+		if err != nil {
+			Logger.log.Errorf("Init txpool snapshot return error %v", err)
+		} else {
+			go poolManager.RunSnapshot(time.Duration(cfg.TxPoolSnapshotInterval)*time.Second, serverObj.cQuit)
+		}
+	}
 	go poolManager.Start(relayShards)
 
 	//set bc obj for monitor
@@ -350,6 +369,7 @@ func (serverObj *Server) NewServer(
 	for sid := 0; sid < serverObj.GetActiveShardNumber(); sid++ {
 		serverObj.Pruner.SetShardInsertLock(sid, serverObj.blockChain.ShardChain[sid].GetInsertLock())
 	}
+	serverObj.Pruner.SetBeaconInsertLock(serverObj.blockChain.BeaconChain.GetInsertLock())
 	go serverObj.Pruner.Start()
 
 	// or if it cannot be loaded, create a new one.
@@ -538,6 +558,7 @@ func (serverObj *Server) NewServer(
 			RPCLimitUser:                cfg.RPCLimitUser,
 			RPCLimitPass:                cfg.RPCLimitPass,
 			DisableAuth:                 cfg.RPCDisableAuth,
+			APIKeys:                     cfg.RPCAPIKeys,
 			// NodeMode:                    cfg.NodeMode,
 			FeeEstimator:    serverObj.feeEstimator,
 			ProtocolVersion: serverObj.protocolVersion,
@@ -644,6 +665,13 @@ func (serverObj *Server) Stop() error {
 		blockchain.GetCoinIndexer().Stop()
 	}
 
+	// Save the txs in pool to load them after restart
+	if pm := serverObj.blockChain.GetPoolManager(); pm != nil {
+		if err := pm.SaveSnapshot(); err != nil {
+			Logger.log.Errorf("Save txpool snapshot return error %v", err)
+		}
+	}
+
 	// Signal the remaining goroutines to cQuit.
 	close(serverObj.cQuit)
 	return nil
@@ -747,9 +775,11 @@ func (serverObj Server) Start() {
 	go serverObj.blockgen.Start(serverObj.cQuit)
 
 	if serverObj.memPool != nil {
-		err := serverObj.memPool.LoadOrResetDatabaseMempool()
-		if err != nil {
-			Logger.log.Error(err)
+		if !serverObj.blockChain.UsingNewPool() {
+			err := serverObj.memPool.LoadOrResetDatabaseMempool()
+			if err != nil {
+				Logger.log.Error(err)
+			}
 		}
 		// go serverObj.TransactionPoolBroadcastLoop()
 		go serverObj.memPool.Start(serverObj.cQuit)
@@ -990,6 +1020,19 @@ func (serverObj *Server) OnFinishSync(p *peer.PeerConn, msg *wire.MessageFinishS
 	}
 }
 
+var slashEvidenceMessageHistory = metrics.NewRegisteredCounter("message/slash-evidence", nil)
+
+//OnSlashEvidence handle slash evidence message, only beacon committee keeps evidences to include them in beacon blocks
+func (serverObj *Server) OnSlashEvidence(p *peer.PeerConn, msg *wire.MessageSlashEvidence) {
+	slashEvidenceMessageHistory.Inc(1)
+	if role, chainID := serverObj.GetUserMiningState(); role != common.CommitteeRole || chainID != common.BeaconChainID {
+		return
+	}
+	if err := serverObj.blockChain.AddSlashEvidence(msg.Instruction); err != nil {
+		Logger.log.Infof("Receive an invalid MsgSlashEvidence, error %v", err)
+	}
+}
+
 //OnFeatureMsg handle feature message
 func (serverObj *Server) OnFeatureMsg(p *peer.PeerConn, msg *wire.MessageFeature) {
 	blockchain.DefaultFeatureStat.ReceiveMsg(msg)
@@ -2160,3 +2203,7 @@ func (serverObj *Server) GetSelfPeerID() libp2p.ID {
 func (serverObj *Server) InsertNewShardView(newView *blockchain.ShardBestState) {
 	serverObj.Pruner.InsertNewView(newView)
 }
+
+func (serverObj *Server) InsertNewBeaconView(newView *blockchain.BeaconBestState) {
+	serverObj.Pruner.InsertNewBeaconView(newView)
+}
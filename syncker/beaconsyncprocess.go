@@ -36,6 +36,7 @@ type BeaconSyncProcess struct {
 	actionCh            chan func()
 	lastCrossShardState map[byte]map[byte]uint64
 	lastInsert          string
+	snapSyncState       int32
 }
 
 func NewBeaconSyncProcess(network Network, bc *blockchain.BlockChain, chain BeaconChainInterface) *BeaconSyncProcess {
@@ -262,9 +263,14 @@ func (s *BeaconSyncProcess) syncBeacon() {
 			continue
 		}
 
+		peerHeight := uint64(0)
 		for peerID, pState := range s.getBeaconPeerStates() {
 			requestCnt += s.streamFromPeer(peerID, pState)
+			if pState.BestViewHeight > peerHeight {
+				peerHeight = pState.BestViewHeight
+			}
 		}
+		updateSyncLag("syncker/beacon/lag", s.chain.GetBestViewHeight(), peerHeight)
 
 		//last check, if we still need to sync more
 		if requestCnt > 0 {
@@ -21,6 +21,8 @@ type Network interface {
 	RequestCrossShardBlocksByHashViaStream(ctx context.Context, peerID string, fromSID int, toSID int, hashes [][]byte) (blockCh chan types.BlockInterface, err error)
 	RequestBeaconBlocksByHashViaStream(ctx context.Context, peerID string, hashes [][]byte) (blockCh chan types.BlockInterface, err error)
 	RequestShardBlocksByHashViaStream(ctx context.Context, peerID string, fromSID int, hashes [][]byte) (blockCh chan types.BlockInterface, err error)
+	RequestFinalViewViaStream(ctx context.Context, peerID string, fromSID int) (viewCh chan []byte, err error)
+	RequestStateNodesViaStream(ctx context.Context, peerID string, fromSID int, hashes [][]byte) (nodeCh chan []byte, err error)
 	PublishMessageToShard(msg wire.Message, shardID byte) error
 	SetSyncMode(string)
 }
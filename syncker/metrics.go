@@ -0,0 +1,12 @@
+package syncker
+
+import "github.com/incognitochain/incognito-chain/metrics"
+
+// updateSyncLag exports the number of blocks a chain is behind the highest view of its peers
+func updateSyncLag(name string, bestHeight uint64, peerHeight uint64) {
+	lag := int64(0)
+	if peerHeight > bestHeight {
+		lag = int64(peerHeight - bestHeight)
+	}
+	metrics.GetOrRegisterGauge(name, nil).Update(lag)
+}
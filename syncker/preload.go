@@ -9,6 +9,9 @@ import (
 	"net/http"
 	"os"
 
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/common"
+	configpkg "github.com/incognitochain/incognito-chain/config"
 	"github.com/incognitochain/incognito-chain/incdb"
 	btcrelaying "github.com/incognitochain/incognito-chain/relaying/btc"
 )
@@ -93,15 +96,17 @@ func makeRPCRequest(address string, method string, params ...interface{}) (*Json
 	return &response, nil
 }
 
-//preloadDatabase call to backuped database node ...
-func preloadDatabase(chainID int, currentEpoch int, url string, db incdb.Database, btcChain *btcrelaying.BlockChain) error {
+//preloadDatabase call to backuped database node ..., return true if the database is replaced by the backup.
+//The backups of the relaying chains are only downloaded with the beacon one, see restoreRelayingChain
+func preloadDatabase(chainID int, currentEpoch int, url string, db incdb.Database, withLTC bool) (bool, error) {
+	preloaded := false
 	chainName := "beacon"
 	if chainID > -1 {
 		chainName = fmt.Sprintf("shard%v", chainID)
 	}
 	response, err := makeRPCRequest(url, "getlatestbackup", chainName)
 	if err != nil {
-		return err
+		return false, err
 	}
 	type LatestEpochResult struct {
 		LatestEpoch int
@@ -109,7 +114,7 @@ func preloadDatabase(chainID int, currentEpoch int, url string, db incdb.Databas
 	result := LatestEpochResult{}
 	err = json.Unmarshal(response.Result, &result)
 	if err != nil {
-		return err
+		return false, err
 	}
 
 	if currentEpoch < result.LatestEpoch-2 {
@@ -117,26 +122,39 @@ func preloadDatabase(chainID int, currentEpoch int, url string, db incdb.Databas
 
 		fd, err := os.OpenFile(backupFile, os.O_CREATE|os.O_WRONLY, 0666)
 		if err != nil {
-			return err
+			return false, err
 		}
 		fd.Truncate(0)
 		err = makeRPCDownloadRequest(url, "downloadbackup", fd, chainName)
 		if err != nil {
-			return err
+			return false, err
 		}
 		fd.Close()
 
 		if chainName == "beacon" {
 			fd, err = os.OpenFile("./data/preload/btc", os.O_CREATE|os.O_WRONLY, 0666)
 			if err != nil {
-				return err
+				return false, err
 			}
 			fd.Truncate(0)
 			err = makeRPCDownloadRequest(url, "downloadbackup", fd, chainName, "btc")
 			if err != nil {
-				return err
+				return false, err
 			}
 			fd.Close()
+
+			if withLTC {
+				fd, err = os.OpenFile("./data/preload/ltc", os.O_CREATE|os.O_WRONLY, 0666)
+				if err != nil {
+					return false, err
+				}
+				fd.Truncate(0)
+				err = makeRPCDownloadRequest(url, "downloadbackup", fd, chainName, "ltc")
+				if err != nil {
+					return false, err
+				}
+				fd.Close()
+			}
 		}
 
 		fmt.Println("Download finish", chainName)
@@ -147,16 +165,68 @@ func preloadDatabase(chainID int, currentEpoch int, url string, db incdb.Databas
 		//restore beacon|shard
 		err = db.PreloadBackup(backupFile)
 		if err != nil {
-			return err
+			return false, err
 		}
+		preloaded = true
+	}
+	return preloaded, nil
+}
 
-		//restore btc if we restore beacon
-		if chainName == "beacon" {
-			err = btcChain.RestoreDBFromBackup("./data/preload/btc")
-			if err != nil {
-				panic(err)
-			}
+//restoreRelayingChain replace the database of a relaying chain by the backup downloaded with the beacon one,
+//the backup is verified by the relaying chain, which keeps its database if the backup is invalid
+func restoreRelayingChain(chain *btcrelaying.BlockChain, backupFile string) error {
+	if chain == nil {
+		return nil
+	}
+	return chain.RestoreDBFromBackup(backupFile)
+}
+
+//preloadCheckpoint return the hash of the trusted beacon block a preloaded database is verified from:
+//the configured checkpoint if any, otherwise the final beacon block of the node before preloading
+func preloadCheckpoint(bc *blockchain.BlockChain) (common.Hash, error) {
+	if checkpoint := configpkg.Config().PreloadCheckpoint; checkpoint != "" {
+		hash, err := common.Hash{}.NewHashFromStr(checkpoint)
+		if err != nil {
+			return common.Hash{}, err
 		}
+		return *hash, nil
 	}
-	return nil
+	return *bc.BeaconChain.GetFinalView().GetHash(), nil
+}
+
+//verifyPreload check the views restored from a preloaded database with verify, keep the preloaded database if they are valid,
+//otherwise revert the database to its content before preloading and restore the views again
+func verifyPreload(db incdb.Database, restore func() error, verify func() error) error {
+	err := restoreViews(restore)
+	if err == nil {
+		err = verify()
+	}
+	if err == nil {
+		if err := db.CommitPreload(); err != nil {
+			Logger.Errorf("Cannot remove database replaced by preload: %v", err)
+		}
+		return nil
+	}
+
+	db.Close()
+	if revertErr := db.RevertPreload(); revertErr != nil {
+		Logger.Errorf("Cannot revert preloaded database: %v", revertErr)
+	}
+	if reopenErr := db.ReOpen(); reopenErr != nil {
+		return fmt.Errorf("%v, cannot reopen reverted database: %v", err, reopenErr)
+	}
+	if restoreErr := restoreViews(restore); restoreErr != nil {
+		return fmt.Errorf("%v, cannot restore views of reverted database: %v", err, restoreErr)
+	}
+	return err
+}
+
+//restoreViews call restore, which panics on some invalid data
+func restoreViews(restore func() error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+	return restore()
 }
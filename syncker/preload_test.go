@@ -1,9 +1,114 @@
 package syncker
 
 import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
+
+	"github.com/incognitochain/incognito-chain/incdb"
+	_ "github.com/incognitochain/incognito-chain/incdb/lvdb"
 )
 
 func Test_preloadDatabase(t *testing.T) {
-	preloadDatabase(0, 0, "http://127.0.0.1:20004", nil)
+	preloadDatabase(0, 0, "http://127.0.0.1:20004", nil, false)
+}
+
+func Test_verifyPreload(t *testing.T) {
+	dir, err := ioutil.TempDir(os.TempDir(), "syncker_preload_")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	db, err := incdb.Open("leveldb", filepath.Join(dir, "db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	key := []byte("block")
+	db.Put(key, []byte("preloaded"))
+	if err := db.Backup("../backup/1"); err != nil {
+		t.Fatal(err)
+	}
+	_, backupFile := db.LatestBackup("../backup")
+	db.Put(key, []byte("local"))
+
+	preload := func() {
+		if err := db.Close(); err != nil {
+			t.Fatal(err)
+		}
+		if err := db.PreloadBackup(backupFile); err != nil {
+			t.Fatal(err)
+		}
+		if err := db.ReOpen(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	checkValue := func(want string) {
+		got, err := db.Get(key)
+		if err != nil || !bytes.Equal(got, []byte(want)) {
+			t.Fatalf("Get = %s, %v; want %s", got, err, want)
+		}
+	}
+
+	tests := []struct {
+		name        string
+		restore     func(call int) error
+		verify      func() error
+		wantErr     bool
+		wantValue   string
+		wantRestore int
+	}{
+		{
+			name:        "tampered database",
+			restore:     func(call int) error { return nil },
+			verify:      func() error { return errors.New("invalid signature") },
+			wantErr:     true,
+			wantValue:   "local",
+			wantRestore: 2,
+		},
+		{
+			name: "views cannot be restored",
+			restore: func(call int) error {
+				if call == 1 {
+					panic("invalid view")
+				}
+				return nil
+			},
+			verify:      func() error { return nil },
+			wantErr:     true,
+			wantValue:   "local",
+			wantRestore: 2,
+		},
+		{
+			name:        "valid database",
+			restore:     func(call int) error { return nil },
+			verify:      func() error { return nil },
+			wantErr:     false,
+			wantValue:   "preloaded",
+			wantRestore: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			preload()
+			checkValue("preloaded")
+			restored := 0
+			restore := func() error {
+				restored++
+				return tt.restore(restored)
+			}
+			err := verifyPreload(db, restore, tt.verify)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("verifyPreload() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if restored != tt.wantRestore {
+				t.Fatalf("restored %v times, want %v", restored, tt.wantRestore)
+			}
+			checkValue(tt.wantValue)
+		})
+	}
 }
@@ -14,6 +14,7 @@ import (
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/consensus_v2/consensustypes"
 	"github.com/incognitochain/incognito-chain/peerv2"
+	"github.com/incognitochain/incognito-chain/tracing"
 	"github.com/incognitochain/incognito-chain/utils"
 	"github.com/incognitochain/incognito-chain/wire"
 )
@@ -42,6 +43,7 @@ type ShardSyncProcess struct {
 	consensus             peerv2.ConsensusData
 	lock                  *sync.RWMutex
 	lastInsert            string
+	snapSyncState         int32
 }
 
 func NewShardSyncProcess(
@@ -66,7 +68,7 @@ func NewShardSyncProcess(
 		Network:          network,
 		Chain:            chain,
 		beaconChain:      beaconChain,
-		shardPool:        NewBlkPool("ShardPool-"+string(shardID), isOutdatedBlock),
+		shardPool:        NewBlkPool("ShardPool-"+string(rune(shardID)), isOutdatedBlock),
 		shardPeerState:   make(map[string]ShardPeerState),
 		shardPeerStateCh: make(chan *wire.MessagePeerState, 100),
 		consensus:        consensus,
@@ -181,7 +183,10 @@ func (s *ShardSyncProcess) insertShardBlockFromPool() {
 			insertShardTimeCache.Add(viewHash.String(), time.Now())
 			insertCnt++
 			//must validate this block when insert
-			if err := s.Chain.InsertBlock(block.(types.BlockInterface), true); err != nil {
+			span := tracing.StartBlockSpan(*block.Hash(), "syncker.insertFromPool", tracing.Attr("shard", block.GetShardID()), tracing.Attr("height", block.GetHeight()))
+			err := s.Chain.InsertBlock(block.(types.BlockInterface), true)
+			span.End(err)
+			if err != nil {
 				Logger.Error("Insert shard block from pool fail", block.GetHeight(), block.Hash(), err)
 				continue
 			} else {
@@ -214,9 +219,14 @@ func (s *ShardSyncProcess) syncShardProcess() {
 			continue
 		}
 
+		peerHeight := uint64(0)
 		for peerID, pState := range s.getShardPeerStates() {
 			requestCnt += s.streamFromPeer(peerID, pState)
+			if pState.BestViewHeight > peerHeight {
+				peerHeight = pState.BestViewHeight
+			}
 		}
+		updateSyncLag(fmt.Sprintf("syncker/shard/%v/lag", s.shardID), s.Chain.GetBestViewHeight(), peerHeight)
 
 		if requestCnt > 0 {
 			s.isCatchUp = false
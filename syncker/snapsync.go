@@ -0,0 +1,243 @@
+package syncker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/blockchain/types"
+	"github.com/incognitochain/incognito-chain/common"
+	configpkg "github.com/incognitochain/incognito-chain/config"
+	"github.com/incognitochain/incognito-chain/incdb"
+	"github.com/incognitochain/incognito-chain/trie"
+)
+
+// number of state trie nodes requested at once
+const snapSyncBatchSize = 512
+
+// the child block of a snapshot may not be produced yet, it is requested again after snapSyncRetryDelay
+const snapSyncChildRetries = 10
+
+var snapSyncRetryDelay = 5 * time.Second
+
+// state of the snap sync of a chain, run once in its own goroutine before the normal sync starts
+const (
+	snapSyncIdle int32 = iota
+	snapSyncRunning
+	snapSyncDone
+)
+
+// runSnapSync starts snapSync in its own goroutine the first time it is called, and returns true once it is over
+// so that the normal sync can start. A failed snap sync falls back to the normal sync
+func runSnapSync(state *int32, name string, snapSync func() error) bool {
+	if atomic.CompareAndSwapInt32(state, snapSyncIdle, snapSyncRunning) {
+		go func() {
+			if err := snapSync(); err != nil {
+				Logger.Errorf("Snap sync %v fail, fall back to normal sync! %v", name, err)
+			}
+			atomic.StoreInt32(state, snapSyncDone)
+		}()
+	}
+	return atomic.LoadInt32(state) == snapSyncDone
+}
+
+// requestFinalView gets the final view of chain chainID (-1 for the beacon chain) from a block provider into view
+func requestFinalView(network Network, chainID int, view interface{}) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	viewCh, err := network.RequestFinalViewViaStream(ctx, "", chainID)
+	if err != nil {
+		return err
+	}
+	data, ok := <-viewCh
+	if !ok {
+		return fmt.Errorf("Cannot get final view of chain %v", chainID)
+	}
+	return json.Unmarshal(data, view)
+}
+
+// requestChildBlock gets the block at height following the block prevHash from a block provider with request,
+// waiting for it to be produced
+func requestChildBlock(request func(ctx context.Context, height uint64) (chan types.BlockInterface, error), prevHash common.Hash, height uint64) (types.BlockInterface, error) {
+	for i := 0; i < snapSyncChildRetries; i++ {
+		if i > 0 {
+			time.Sleep(snapSyncRetryDelay)
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		blockCh, err := request(ctx, height)
+		if err != nil {
+			cancel()
+			Logger.Errorf("Snap sync cannot request child block of %v: %v", prevHash.String(), err)
+			continue
+		}
+		var child types.BlockInterface
+		for blk := range blockCh {
+			if blk.GetHeight() == height && blk.GetPrevHash() == prevHash {
+				child = blk
+			}
+		}
+		cancel()
+		if child != nil {
+			return child, nil
+		}
+	}
+	return nil, fmt.Errorf("Cannot get child block of %v", prevHash.String())
+}
+
+// syncStateTries downloads the state tries of roots from the database of chain chainID into db,
+// every node being verified by its hash
+func syncStateTries(network Network, chainID int, db incdb.Database, roots []common.Hash) error {
+	fetch := func(hashes []common.Hash) ([][]byte, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		req := make([][]byte, len(hashes))
+		for i, hash := range hashes {
+			req[i] = hash.Bytes()
+		}
+		nodeCh, err := network.RequestStateNodesViaStream(ctx, "", chainID, req)
+		if err != nil {
+			return nil, err
+		}
+		nodes := [][]byte{}
+		for node := range nodeCh {
+			nodes = append(nodes, node)
+		}
+		return nodes, nil
+	}
+	for _, root := range roots {
+		if root == (common.Hash{}) {
+			continue
+		}
+		synced, err := trie.SyncTrie(root, db, fetch, snapSyncBatchSize)
+		if err != nil {
+			return err
+		}
+		Logger.Infof("Snap sync chain %v: %v trie nodes of root %v", chainID, synced, root.String())
+	}
+	return nil
+}
+
+// snapSync starts an empty shard from the final view of a block provider instead of replaying every block:
+// the state tries of the view roots are downloaded & verified by hash, then the view is imported
+// once its best block is confirmed by the final beacon view, its committee state matches the block
+// and its state roots match the ones committed by the next block
+func (s *ShardSyncProcess) snapSync() error {
+	if s.Chain.GetBestViewHeight() > 1 {
+		return nil
+	}
+	Logger.Infof("Snap sync shard %v", s.shardID)
+
+	view := &blockchain.ShardBestState{}
+	if err := requestFinalView(s.Network, s.shardID, view); err != nil {
+		return err
+	}
+	if int(view.ShardID) != s.shardID || view.BestBlock == nil || *view.BestBlock.Hash() != view.BestBlockHash {
+		return fmt.Errorf("Invalid view %v of shard %v", view.BestBlockHash.String(), s.shardID)
+	}
+	child, err := requestChildBlock(func(ctx context.Context, height uint64) (chan types.BlockInterface, error) {
+		return s.Network.RequestShardBlocksViaStream(ctx, "", s.shardID, height, height)
+	}, view.BestBlockHash, view.ShardHeight+1)
+	if err != nil {
+		return err
+	}
+	shardChild, ok := child.(*types.ShardBlock)
+	if !ok {
+		return fmt.Errorf("Invalid child block of shard %v", s.shardID)
+	}
+	roots := []common.Hash{
+		view.ConsensusStateDBRootHash,
+		view.TransactionStateDBRootHash,
+		view.FeatureStateDBRootHash,
+		view.RewardStateDBRootHash,
+		view.SlashStateDBRootHash,
+	}
+	if err := syncStateTries(s.Network, s.shardID, s.Chain.GetDatabase(), roots); err != nil {
+		return err
+	}
+	return s.blockchain.ImportShardSnapshot(view, shardChild)
+}
+
+// snapSync starts an empty beacon chain from the final view of a block provider, like the shards.
+// The view is anchored on the trusted block SnapSyncCheckpoint: the blocks from the checkpoint to the next block
+// of the view are downloaded, and each one must be signed by the committee of its previous block
+func (s *BeaconSyncProcess) snapSync() error {
+	checkpointStr := configpkg.Config().SnapSyncCheckpoint
+	if checkpointStr == "" || s.chain.GetBestViewHeight() > 1 {
+		return nil
+	}
+	checkpoint, err := common.Hash{}.NewHashFromStr(checkpointStr)
+	if err != nil {
+		return err
+	}
+	Logger.Infof("Snap sync beacon from checkpoint %v", checkpoint.String())
+
+	view := &blockchain.BeaconBestState{}
+	if err := requestFinalView(s.network, common.BeaconChainID, view); err != nil {
+		return err
+	}
+	if *view.BestBlock.Hash() != view.BestBlockHash {
+		return fmt.Errorf("Invalid beacon view %v", view.BestBlockHash.String())
+	}
+	blocks, err := s.requestCheckpointBlocks(*checkpoint, view.BeaconHeight)
+	if err != nil {
+		return err
+	}
+	child, err := requestChildBlock(func(ctx context.Context, height uint64) (chan types.BlockInterface, error) {
+		return s.network.RequestBeaconBlocksViaStream(ctx, "", height, height)
+	}, view.BestBlockHash, view.BeaconHeight+1)
+	if err != nil {
+		return err
+	}
+	beaconChild, ok := child.(*types.BeaconBlock)
+	if !ok {
+		return errors.New("Invalid child block of beacon")
+	}
+	roots := []common.Hash{
+		view.ConsensusStateDBRootHash,
+		view.FeatureStateDBRootHash,
+		view.RewardStateDBRootHash,
+		view.SlashStateDBRootHash,
+	}
+	if err := syncStateTries(s.network, common.BeaconChainID, s.chain.GetDatabase(), roots); err != nil {
+		return err
+	}
+	return s.blockchain.ImportBeaconSnapshot(view, *checkpoint, blocks, beaconChild)
+}
+
+// requestCheckpointBlocks gets the beacon blocks from the checkpoint up to height to
+func (s *BeaconSyncProcess) requestCheckpointBlocks(checkpoint common.Hash, to uint64) ([]*types.BeaconBlock, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	blockCh, err := s.network.RequestBeaconBlocksByHashViaStream(ctx, "", [][]byte{checkpoint.Bytes()})
+	if err != nil {
+		return nil, err
+	}
+	blk, ok := <-blockCh
+	if !ok {
+		return nil, fmt.Errorf("Cannot get checkpoint block %v", checkpoint.String())
+	}
+	first, ok := blk.(*types.BeaconBlock)
+	if !ok || *first.Hash() != checkpoint || first.GetHeight() > to {
+		return nil, fmt.Errorf("Invalid checkpoint block %v", checkpoint.String())
+	}
+	blocks := []*types.BeaconBlock{first}
+	if first.GetHeight() == to {
+		return blocks, nil
+	}
+	blockCh, err = s.network.RequestBeaconBlocksViaStream(ctx, "", first.GetHeight()+1, to)
+	if err != nil {
+		return nil, err
+	}
+	for blk := range blockCh {
+		beaconBlock, ok := blk.(*types.BeaconBlock)
+		if !ok || beaconBlock.GetHeight() != first.GetHeight()+uint64(len(blocks)) {
+			return nil, errors.New("Unexpected beacon block from checkpoint")
+		}
+		blocks = append(blocks, beaconBlock)
+	}
+	return blocks, nil
+}
@@ -0,0 +1,85 @@
+package syncker
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/blockchain/types"
+	"github.com/incognitochain/incognito-chain/common"
+)
+
+func Test_runSnapSync(t *testing.T) {
+	Logger.Init(common.NewBackend(nil).Logger("test", true))
+	for _, snapSyncErr := range []error{nil, errors.New("snap sync fail")} {
+		var state int32
+		calls := int32(0)
+		release := make(chan struct{})
+		snapSync := func() error {
+			atomic.AddInt32(&calls, 1)
+			<-release
+			return snapSyncErr
+		}
+		// the normal sync waits while snap sync runs in background
+		if runSnapSync(&state, "test", snapSync) || runSnapSync(&state, "test", snapSync) {
+			t.Fatal("Expect the sync to wait for snap sync")
+		}
+		close(release)
+		for i := 0; !runSnapSync(&state, "test", snapSync); i++ {
+			if i > 100 {
+				t.Fatal("Expect snap sync to be done")
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		if atomic.LoadInt32(&calls) != 1 {
+			t.Fatalf("Expect snap sync to run once, got %v", calls)
+		}
+	}
+}
+
+func Test_requestChildBlock(t *testing.T) {
+	Logger.Init(common.NewBackend(nil).Logger("test", true))
+	snapSyncRetryDelay = time.Millisecond
+	defer func() { snapSyncRetryDelay = 5 * time.Second }()
+
+	parent := types.NewBeaconBlock()
+	parent.Header.Height = 5
+	child := types.NewBeaconBlock()
+	child.Header.Height = 6
+	child.Header.PreviousBlockHash = *parent.Hash()
+	other := types.NewBeaconBlock()
+	other.Header.Height = 6
+	other.Header.PreviousBlockHash = common.Hash{1}
+
+	// the child is only produced at the third request, a block of another branch is ignored
+	requests := 0
+	request := func(ctx context.Context, height uint64) (chan types.BlockInterface, error) {
+		requests++
+		blockCh := make(chan types.BlockInterface, 1)
+		switch requests {
+		case 1:
+			return nil, errors.New("no provider")
+		case 2:
+			blockCh <- other
+		default:
+			blockCh <- child
+		}
+		close(blockCh)
+		return blockCh, nil
+	}
+	blk, err := requestChildBlock(request, *parent.Hash(), 6)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *blk.Hash() != *child.Hash() || requests != 3 {
+		t.Fatalf("Expect child %v at the third request, got %v at request %v", child.Hash().String(), blk.Hash().String(), requests)
+	}
+
+	if _, err := requestChildBlock(func(ctx context.Context, height uint64) (chan types.BlockInterface, error) {
+		return nil, errors.New("no provider")
+	}, *parent.Hash(), 6); err == nil {
+		t.Fatal("Expect no child block")
+	}
+}
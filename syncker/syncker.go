@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"os"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/incognitochain/incognito-chain/metrics/monitor"
@@ -63,11 +64,26 @@ func (synckerManager *SynckerManager) Init(config *SynckerManagerConfig) {
 	//check preload beacon
 	preloadAddr := configpkg.Config().PreloadAddress
 	if preloadAddr != "" {
-		if err := preloadDatabase(-1, int(config.Blockchain.BeaconChain.GetEpoch()), preloadAddr, config.Blockchain.GetBeaconChainDatabase(), config.Blockchain.GetBTCHeaderChain()); err != nil {
+		bc := config.Blockchain
+		if checkpoint, err := preloadCheckpoint(bc); err != nil {
+			Logger.Errorf("Invalid preload checkpoint, skip preload! %v", err)
+		} else if preloaded, err := preloadDatabase(-1, int(bc.BeaconChain.GetEpoch()), preloadAddr, bc.GetBeaconChainDatabase(), bc.GetLTCHeaderChain() != nil); err != nil {
 			fmt.Println(err)
 			Logger.Infof("Preload beacon fail!")
-		} else {
-			config.Blockchain.RestoreBeaconViews()
+		} else if preloaded {
+			if err := verifyPreload(bc.GetBeaconChainDatabase(), bc.RestoreBeaconViews, func() error {
+				return bc.VerifyBeaconViews(checkpoint)
+			}); err != nil {
+				Logger.Errorf("Preloaded beacon database is invalid, fall back to normal sync! %v", err)
+			} else {
+				//restore btc & ltc only with a valid beacon database
+				if err := restoreRelayingChain(bc.GetBTCHeaderChain(), "./data/preload/btc"); err != nil {
+					Logger.Errorf("Cannot restore preloaded BTC database, keep the current one! %v", err)
+				}
+				if err := restoreRelayingChain(bc.GetLTCHeaderChain(), "./data/preload/ltc"); err != nil {
+					Logger.Errorf("Cannot restore preloaded LTC database, keep the current one! %v", err)
+				}
+			}
 		}
 	}
 
@@ -133,7 +149,10 @@ func (synckerManager *SynckerManager) manageSyncProcess() {
 	}
 
 	preloadAddr := configpkg.Config().PreloadAddress
-	synckerManager.BeaconSyncProcess.start()
+	//snap sync empty beacon in background, then start sync
+	if !configpkg.Config().SnapSync || runSnapSync(&synckerManager.BeaconSyncProcess.snapSyncState, "beacon", synckerManager.BeaconSyncProcess.snapSync) {
+		synckerManager.BeaconSyncProcess.start()
+	}
 
 	if time.Now().Unix()-synckerManager.Blockchain.GetBeaconBestState().BestBlock.GetProduceTime() > 4*60*60 {
 		lastInsertTime := synckerManager.BeaconSyncProcess.lastInsert
@@ -169,16 +188,26 @@ func (synckerManager *SynckerManager) manageSyncProcess() {
 			if _, ok := wantedShard[byte(sid)]; ok {
 				//check preload shard
 				if preloadAddr != "" {
-					if syncProc.status != RUNNING_SYNC { //run only when start
-						if err := preloadDatabase(sid, int(syncProc.Chain.GetEpoch()), preloadAddr, synckerManager.config.Blockchain.GetShardChainDatabase(byte(sid)), nil); err != nil {
+					if syncProc.status != RUNNING_SYNC && atomic.LoadInt32(&syncProc.snapSyncState) == snapSyncIdle { //run only when start
+						bc := synckerManager.config.Blockchain
+						if preloaded, err := preloadDatabase(sid, int(syncProc.Chain.GetEpoch()), preloadAddr, bc.GetShardChainDatabase(byte(sid)), false); err != nil {
 							fmt.Println(err)
 							Logger.Infof("Preload shard %v fail!", sid)
-						} else {
-							synckerManager.config.Blockchain.RestoreShardViews(byte(sid))
+						} else if preloaded {
+							if err := verifyPreload(bc.GetShardChainDatabase(byte(sid)), func() error {
+								return bc.RestoreShardViews(byte(sid))
+							}, func() error {
+								return bc.VerifyShardViews(byte(sid))
+							}); err != nil {
+								Logger.Errorf("Preloaded shard %v database is invalid, fall back to normal sync! %v", sid, err)
+							}
 						}
 					}
 				}
-				syncProc.start()
+				//snap sync empty shard in background, then start sync
+				if !configpkg.Config().SnapSync || runSnapSync(&syncProc.snapSyncState, fmt.Sprintf("shard %v", sid), syncProc.snapSync) {
+					syncProc.start()
+				}
 			} else {
 				syncProc.stop()
 			}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+
+	"github.com/incognitochain/incognito-chain/testsuite/indexer"
+)
+
+func main() {
+	fullnode := flag.String("h", "http://127.0.0.1:9334", "Fullnode RPC Endpoint")
+	ws := flag.String("ws", "ws://127.0.0.1:19334", "Fullnode Websocket Endpoint")
+	dataDir := flag.String("d", "indexer_data", "Checkpoint Directory")
+	flag.Parse()
+
+	store, err := indexer.NewFileCheckpointStore(*dataDir)
+	if err != nil {
+		panic(err)
+	}
+	idx, err := indexer.NewIndexer(indexer.Config{
+		Source:      indexer.NewRemoteSource(*fullnode, *ws),
+		Checkpoints: store,
+		ChainIDs:    []int{indexer.BeaconChainID, 0},
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	idx.OnTrade(func(event indexer.Event) error {
+		fmt.Println("trade", event.Block.ChainID, event.Block.Height, event.MetadataType, event.TxHash.String())
+		return nil
+	})
+	idx.OnShield(func(event indexer.Event) error {
+		fmt.Println("shield", event.Block.ChainID, event.Block.Height, event.MetadataType, event.Instruction)
+		return nil
+	})
+	idx.OnUnshield(func(event indexer.Event) error {
+		fmt.Println("unshield", event.Block.ChainID, event.Block.Height, event.MetadataType, event.Instruction)
+		return nil
+	})
+	idx.OnStaking(func(event indexer.Event) error {
+		fmt.Println("staking", event.Block.ChainID, event.Block.Height, event.Instruction)
+		return nil
+	})
+	idx.OnRollback(func(block indexer.BlockRef) error {
+		fmt.Println("rollback", block.ChainID, block.Height, block.Hash.String())
+		return nil
+	})
+
+	if err := idx.Start(); err != nil {
+		panic(err)
+	}
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	<-interrupt
+	idx.Stop()
+}
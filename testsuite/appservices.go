@@ -12,6 +12,8 @@ func NewAppService(fullnode string, finalizedBlock bool) *AppService {
 	}
 }
 
+// AppService polls a fullnode for new blocks and does not handle forks,
+// use testsuite/indexer to follow finality, roll back reorgs and resume from checkpoints
 type AppService struct {
 	Fullnode       string
 	FinalizedBlock bool
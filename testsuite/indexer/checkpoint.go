@@ -0,0 +1,90 @@
+package indexer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// Checkpoint is the indexing progress of a chain.
+// Blocks holds the indexed blocks from the last finalized one to the tip, oldest first,
+// so a reorg found after a restart can still be rolled back
+type Checkpoint struct {
+	ChainID int        `json:"ChainID"`
+	Blocks  []BlockRef `json:"Blocks"`
+}
+
+// Tip returns the last indexed block, nil if nothing has been indexed yet
+func (cp *Checkpoint) Tip() *BlockRef {
+	if len(cp.Blocks) == 0 {
+		return nil
+	}
+	return &cp.Blocks[len(cp.Blocks)-1]
+}
+
+// trimFinalized drops the blocks below the finalized height, keeping the highest finalized one
+func (cp *Checkpoint) trimFinalized(finalHeight uint64) {
+	idx := 0
+	for i, blk := range cp.Blocks {
+		if blk.Height <= finalHeight {
+			idx = i
+		}
+	}
+	cp.Blocks = cp.Blocks[idx:]
+}
+
+// CheckpointStore persists checkpoints so that the indexer can resume after a restart
+type CheckpointStore interface {
+	// Load returns the checkpoint of a chain, or nil if the chain has never been indexed
+	Load(chainID int) (*Checkpoint, error)
+	Save(cp *Checkpoint) error
+}
+
+// FileCheckpointStore saves checkpoints as json files in a directory, one file per chain
+type FileCheckpointStore struct {
+	Dir string
+}
+
+func NewFileCheckpointStore(dir string) (*FileCheckpointStore, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	return &FileCheckpointStore{Dir: dir}, nil
+}
+
+func (s *FileCheckpointStore) path(chainID int) string {
+	if chainID == BeaconChainID {
+		return filepath.Join(s.Dir, "checkpoint_beacon.json")
+	}
+	return filepath.Join(s.Dir, fmt.Sprintf("checkpoint_shard_%d.json", chainID))
+}
+
+func (s *FileCheckpointStore) Load(chainID int) (*Checkpoint, error) {
+	data, err := ioutil.ReadFile(s.path(chainID))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	cp := &Checkpoint{}
+	if err := json.Unmarshal(data, cp); err != nil {
+		return nil, fmt.Errorf("Cannot parse checkpoint of chain %v: %v", chainID, err)
+	}
+	return cp, nil
+}
+
+// Save writes to a temporary file then renames it, so a crash never leaves a partial checkpoint
+func (s *FileCheckpointStore) Save(cp *Checkpoint) error {
+	data, err := json.Marshal(cp)
+	if err != nil {
+		return err
+	}
+	tmpPath := s.path(cp.ChainID) + ".tmp"
+	if err := ioutil.WriteFile(tmpPath, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, s.path(cp.ChainID))
+}
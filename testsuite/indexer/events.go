@@ -0,0 +1,159 @@
+package indexer
+
+import (
+	"strconv"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/instruction"
+	"github.com/incognitochain/incognito-chain/metadata"
+	metadataCommon "github.com/incognitochain/incognito-chain/metadata/common"
+)
+
+// EventKind groups metadata and instruction types which backend services usually index together
+type EventKind int
+
+const (
+	EventOther EventKind = iota
+	EventTrade
+	EventShield
+	EventUnshield
+	EventStaking
+)
+
+func (k EventKind) String() string {
+	switch k {
+	case EventTrade:
+		return "trade"
+	case EventShield:
+		return "shield"
+	case EventUnshield:
+		return "unshield"
+	case EventStaking:
+		return "staking"
+	default:
+		return "other"
+	}
+}
+
+// BlockRef identifies an indexed block
+type BlockRef struct {
+	ChainID int         `json:"ChainID"`
+	Height  uint64      `json:"Height"`
+	Hash    common.Hash `json:"Hash"`
+}
+
+// Event is emitted for every transaction carrying metadata in an indexed shard block
+// and for every instruction in an indexed beacon block
+type Event struct {
+	Kind  EventKind
+	Block BlockRef
+
+	// MetadataType is the type of the tx metadata, or the type the instruction was built from.
+	// It is -1 for instructions which are not built from a metadata (staking, swap, ...)
+	MetadataType int
+
+	// shard block events
+	TxHash   common.Hash
+	Tx       metadata.Transaction
+	Metadata metadata.Metadata
+
+	// beacon block events
+	Instruction []string
+}
+
+// NOTE: append new metadata types to the matching kind
+var metaTypeKinds = map[int]EventKind{
+	// trades
+	metadataCommon.PDETradeRequestMeta:           EventTrade,
+	metadataCommon.PDETradeResponseMeta:          EventTrade,
+	metadataCommon.PDECrossPoolTradeRequestMeta:  EventTrade,
+	metadataCommon.PDECrossPoolTradeResponseMeta: EventTrade,
+	metadataCommon.Pdexv3TradeRequestMeta:        EventTrade,
+	metadataCommon.Pdexv3TradeResponseMeta:       EventTrade,
+	metadataCommon.Pdexv3AddOrderRequestMeta:     EventTrade,
+	metadataCommon.Pdexv3AddOrderResponseMeta:    EventTrade,
+
+	// shields
+	metadataCommon.IssuingRequestMeta:              EventShield,
+	metadataCommon.IssuingResponseMeta:             EventShield,
+	metadataCommon.IssuingETHRequestMeta:           EventShield,
+	metadataCommon.IssuingETHResponseMeta:          EventShield,
+	metadataCommon.IssuingBSCRequestMeta:           EventShield,
+	metadataCommon.IssuingBSCResponseMeta:          EventShield,
+	metadataCommon.IssuingPRVERC20RequestMeta:      EventShield,
+	metadataCommon.IssuingPRVERC20ResponseMeta:     EventShield,
+	metadataCommon.IssuingPRVBEP20RequestMeta:      EventShield,
+	metadataCommon.IssuingPRVBEP20ResponseMeta:     EventShield,
+	metadataCommon.IssuingPLGRequestMeta:           EventShield,
+	metadataCommon.IssuingPLGResponseMeta:          EventShield,
+	metadataCommon.IssuingFantomRequestMeta:        EventShield,
+	metadataCommon.IssuingFantomResponseMeta:       EventShield,
+	metadataCommon.IssuingUnifiedTokenRequestMeta:  EventShield,
+	metadataCommon.IssuingUnifiedTokenResponseMeta: EventShield,
+	metadataCommon.IssuingReshieldResponseMeta:     EventShield,
+	metadataCommon.PortalV4ShieldingRequestMeta:    EventShield,
+	metadataCommon.PortalV4ShieldingResponseMeta:   EventShield,
+
+	// unshields
+	metadataCommon.BurningConfirmMeta:                     EventUnshield,
+	metadataCommon.BurningConfirmMetaV2:                   EventUnshield,
+	metadataCommon.BurningConfirmForDepositToSCMeta:       EventUnshield,
+	metadataCommon.BurningConfirmForDepositToSCMetaV2:     EventUnshield,
+	metadataCommon.BurningBSCConfirmMeta:                  EventUnshield,
+	metadataCommon.BurningPRVERC20ConfirmMeta:             EventUnshield,
+	metadataCommon.BurningPRVBEP20ConfirmMeta:             EventUnshield,
+	metadataCommon.BurningPBSCConfirmForDepositToSCMeta:   EventUnshield,
+	metadataCommon.BurningPLGConfirmMeta:                  EventUnshield,
+	metadataCommon.BurningPLGConfirmForDepositToSCMeta:    EventUnshield,
+	metadataCommon.BurningFantomConfirmMeta:               EventUnshield,
+	metadataCommon.BurningFantomConfirmForDepositToSCMeta: EventUnshield,
+	metadataCommon.BurningUnifiedTokenResponseMeta:        EventUnshield,
+	metadataCommon.BurnForCallConfirmMeta:                 EventUnshield,
+	metadataCommon.PortalV4UnshieldingRequestMeta:         EventUnshield,
+	metadataCommon.PortalV4UnshieldingResponseMeta:        EventUnshield,
+	metadataCommon.PortalV4UnshieldBatchingMeta:           EventUnshield,
+
+	// staking
+	metadataCommon.ShardStakingMeta:         EventStaking,
+	metadataCommon.BeaconStakingMeta:        EventStaking,
+	metadataCommon.StopAutoStakingMeta:      EventStaking,
+	metadataCommon.UnStakingMeta:            EventStaking,
+	metadataCommon.ReturnStakingMeta:        EventStaking,
+	metadataCommon.CreateDelegationPoolMeta: EventStaking,
+	metadataCommon.DelegateStakingMeta:      EventStaking,
+	metadataCommon.UnbondDelegationMeta:     EventStaking,
+	metadataCommon.DelegationResponseMeta:   EventStaking,
+}
+
+var instructionKinds = map[string]EventKind{
+	instruction.STAKE_ACTION:           EventStaking,
+	instruction.STOP_AUTO_STAKE_ACTION: EventStaking,
+	instruction.UNSTAKE_ACTION:         EventStaking,
+	instruction.RETURN_ACTION:          EventStaking,
+}
+
+// KindOfMetadataType returns the event kind of a metadata type
+func KindOfMetadataType(metaType int) EventKind {
+	if kind, ok := metaTypeKinds[metaType]; ok {
+		return kind
+	}
+	if metadataCommon.IsBridgeUnshieldMetaType(metaType) || metadataCommon.IsBridgeAggUnshieldMetaType(metaType) {
+		return EventUnshield
+	}
+	return EventOther
+}
+
+// parseInstruction returns the metadata type an instruction was built from (-1 if there is none)
+// and the event kind of the instruction
+func parseInstruction(inst []string) (int, EventKind) {
+	if len(inst) == 0 {
+		return -1, EventOther
+	}
+	if metaType, err := strconv.Atoi(inst[0]); err == nil {
+		return metaType, KindOfMetadataType(metaType)
+	}
+	if kind, ok := instructionKinds[inst[0]]; ok {
+		return -1, kind
+	}
+	return -1, EventOther
+}
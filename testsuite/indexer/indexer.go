@@ -0,0 +1,381 @@
+// Package indexer is a client side library following the blocks of a fullnode and emitting
+// typed events for the transactions and instructions they contain.
+//
+// Blocks are fetched by height over rpc, new block notifications come from the websocket
+// subscriptions of the node and a slow poll covers broken subscriptions. The indexer follows
+// the finalized view by default, when it follows the best view instead, blocks reorganized
+// away by the node are rolled back (newest first) through the rollback handlers.
+// Progress is saved in a checkpoint after each block, so handlers get every event at least once.
+package indexer
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/blockchain/types"
+	"github.com/incognitochain/incognito-chain/instruction"
+)
+
+const (
+	BeaconChainID = instruction.BEACON_CHAIN_ID
+
+	defaultBatchSize    = 50
+	defaultPollInterval = 10 * time.Second
+)
+
+type EventHandler func(event Event) error
+type RollbackHandler func(block BlockRef) error
+
+type Config struct {
+	Source      Source
+	Checkpoints CheckpointStore
+	// ChainIDs are the chains to index, BeaconChainID for the beacon chain
+	ChainIDs []int
+	// StartHeights are the first heights to index of chains without checkpoint, default 1
+	StartHeights map[int]uint64
+	// FollowBestView indexes blocks which are not finalized yet, they may be rolled back later
+	FollowBestView bool
+	BatchSize      int
+	PollInterval   time.Duration
+	// OnError is called with errors which make the indexer retry later, they are printed if it is nil
+	OnError func(chainID int, err error)
+}
+
+type Indexer struct {
+	config Config
+
+	lock             sync.RWMutex
+	kindHandlers     map[EventKind][]EventHandler
+	metaHandlers     map[int][]EventHandler
+	instHandlers     map[string][]EventHandler
+	blockHandlers    []func(block types.BlockInterface) error
+	rollbackHandlers []RollbackHandler
+
+	stopCh  chan struct{}
+	wg      sync.WaitGroup
+	started bool
+}
+
+func NewIndexer(config Config) (*Indexer, error) {
+	if config.Source == nil {
+		return nil, errors.New("Source is required")
+	}
+	if config.Checkpoints == nil {
+		return nil, errors.New("Checkpoint store is required")
+	}
+	if len(config.ChainIDs) == 0 {
+		return nil, errors.New("No chain to index")
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = defaultBatchSize
+	}
+	if config.PollInterval <= 0 {
+		config.PollInterval = defaultPollInterval
+	}
+	if config.OnError == nil {
+		config.OnError = func(chainID int, err error) {
+			fmt.Println("indexer chain", chainID, "error:", err)
+		}
+	}
+	return &Indexer{
+		config:       config,
+		kindHandlers: make(map[EventKind][]EventHandler),
+		metaHandlers: make(map[int][]EventHandler),
+		instHandlers: make(map[string][]EventHandler),
+	}, nil
+}
+
+// OnEvent registers a handler for all the events of a kind
+func (idx *Indexer) OnEvent(kind EventKind, f EventHandler) {
+	idx.lock.Lock()
+	defer idx.lock.Unlock()
+	idx.kindHandlers[kind] = append(idx.kindHandlers[kind], f)
+}
+
+func (idx *Indexer) OnTrade(f EventHandler)    { idx.OnEvent(EventTrade, f) }
+func (idx *Indexer) OnShield(f EventHandler)   { idx.OnEvent(EventShield, f) }
+func (idx *Indexer) OnUnshield(f EventHandler) { idx.OnEvent(EventUnshield, f) }
+func (idx *Indexer) OnStaking(f EventHandler)  { idx.OnEvent(EventStaking, f) }
+
+// OnMetadata registers a handler for the txs and the instructions of a metadata type
+func (idx *Indexer) OnMetadata(metaType int, f EventHandler) {
+	idx.lock.Lock()
+	defer idx.lock.Unlock()
+	idx.metaHandlers[metaType] = append(idx.metaHandlers[metaType], f)
+}
+
+// OnInstruction registers a handler for beacon instructions by their first element
+// (the action, or the metadata type they were built from)
+func (idx *Indexer) OnInstruction(action string, f EventHandler) {
+	idx.lock.Lock()
+	defer idx.lock.Unlock()
+	idx.instHandlers[action] = append(idx.instHandlers[action], f)
+}
+
+// OnBlock registers a handler called with every indexed block, before its events
+func (idx *Indexer) OnBlock(f func(block types.BlockInterface) error) {
+	idx.lock.Lock()
+	defer idx.lock.Unlock()
+	idx.blockHandlers = append(idx.blockHandlers, f)
+}
+
+// OnRollback registers a handler called with every indexed block which is removed by a reorg,
+// data indexed from this block must be reverted
+func (idx *Indexer) OnRollback(f RollbackHandler) {
+	idx.lock.Lock()
+	defer idx.lock.Unlock()
+	idx.rollbackHandlers = append(idx.rollbackHandlers, f)
+}
+
+// Start indexes every configured chain in its own goroutine until Stop is called
+func (idx *Indexer) Start() error {
+	if idx.started {
+		return errors.New("Indexer is already started")
+	}
+	checkpoints := make(map[int]*Checkpoint)
+	for _, chainID := range idx.config.ChainIDs {
+		cp, err := idx.loadCheckpoint(chainID)
+		if err != nil {
+			return err
+		}
+		checkpoints[chainID] = cp
+	}
+	idx.stopCh = make(chan struct{})
+	idx.started = true
+	for _, chainID := range idx.config.ChainIDs {
+		idx.wg.Add(1)
+		go idx.run(chainID, checkpoints[chainID])
+	}
+	return nil
+}
+
+// Stop stops the indexer and waits for the handlers being called to return
+func (idx *Indexer) Stop() {
+	if !idx.started {
+		return
+	}
+	close(idx.stopCh)
+	idx.wg.Wait()
+	idx.started = false
+}
+
+func (idx *Indexer) loadCheckpoint(chainID int) (*Checkpoint, error) {
+	cp, err := idx.config.Checkpoints.Load(chainID)
+	if err != nil {
+		return nil, err
+	}
+	if cp == nil {
+		cp = &Checkpoint{ChainID: chainID}
+	}
+	return cp, nil
+}
+
+func (idx *Indexer) run(chainID int, cp *Checkpoint) {
+	defer idx.wg.Done()
+	ticker := time.NewTicker(idx.config.PollInterval)
+	defer ticker.Stop()
+
+	var notifyCh <-chan struct{}
+	for {
+		if notifyCh == nil {
+			ch, err := idx.config.Source.SubscribeNewBlock(chainID, idx.stopCh)
+			if err != nil {
+				idx.config.OnError(chainID, fmt.Errorf("Cannot subscribe new blocks: %v", err))
+			} else {
+				notifyCh = ch
+			}
+		}
+
+		// index until the target height is reached, a step returns false when there is nothing left
+		for {
+			select {
+			case <-idx.stopCh:
+				return
+			default:
+			}
+			hasMore, err := idx.step(chainID, cp)
+			if err != nil {
+				idx.config.OnError(chainID, err)
+				break
+			}
+			if !hasMore {
+				break
+			}
+		}
+
+		select {
+		case <-idx.stopCh:
+			return
+		case _, ok := <-notifyCh:
+			if !ok {
+				// resubscribe on the next loop, the ticker keeps indexing meanwhile
+				notifyCh = nil
+			}
+		case <-ticker.C:
+		}
+	}
+}
+
+// step indexes the next batch of blocks of a chain, rolling back the tip first if it is not
+// the parent of the next block anymore. It returns whether more blocks are waiting
+func (idx *Indexer) step(chainID int, cp *Checkpoint) (bool, error) {
+	finalHeight, bestHeight, err := idx.config.Source.GetChainHeights(chainID)
+	if err != nil {
+		return false, err
+	}
+	targetHeight := finalHeight
+	if idx.config.FollowBestView {
+		targetHeight = bestHeight
+	}
+
+	nextHeight := idx.startHeight(chainID)
+	if tip := cp.Tip(); tip != nil {
+		nextHeight = tip.Height + 1
+	}
+	if nextHeight > targetHeight {
+		// the best view may be shorter than the tip after a reorg, check the tip is still there
+		if tip := cp.Tip(); tip != nil && tip.Height > finalHeight {
+			return idx.checkTip(chainID, cp, finalHeight)
+		}
+		return false, nil
+	}
+
+	num := int(targetHeight - nextHeight + 1)
+	if num > idx.config.BatchSize {
+		num = idx.config.BatchSize
+	}
+	blocks, err := idx.config.Source.GetBlocks(chainID, nextHeight, num)
+	if err != nil {
+		return false, err
+	}
+	if len(blocks) == 0 {
+		return false, nil
+	}
+	for _, blk := range blocks {
+		if blk.GetHeight() != nextHeight {
+			return false, fmt.Errorf("Expect block height %v, got %v", nextHeight, blk.GetHeight())
+		}
+		if tip := cp.Tip(); tip != nil && blk.GetPrevHash() != tip.Hash {
+			if err := idx.rollbackTip(chainID, cp, finalHeight); err != nil {
+				return false, err
+			}
+			return true, nil
+		}
+		if err := idx.emitBlock(chainID, blk); err != nil {
+			return false, err
+		}
+		cp.Blocks = append(cp.Blocks, BlockRef{ChainID: chainID, Height: blk.GetHeight(), Hash: *blk.Hash()})
+		cp.trimFinalized(finalHeight)
+		if err := idx.config.Checkpoints.Save(cp); err != nil {
+			return false, err
+		}
+		nextHeight++
+	}
+	return nextHeight <= targetHeight, nil
+}
+
+// checkTip rolls back the tip if the node has no block with its hash at its height anymore
+func (idx *Indexer) checkTip(chainID int, cp *Checkpoint, finalHeight uint64) (bool, error) {
+	tip := cp.Tip()
+	blocks, err := idx.config.Source.GetBlocks(chainID, tip.Height, 1)
+	if err != nil {
+		return false, err
+	}
+	if len(blocks) == 1 && *blocks[0].Hash() == tip.Hash {
+		return false, nil
+	}
+	if err := idx.rollbackTip(chainID, cp, finalHeight); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (idx *Indexer) rollbackTip(chainID int, cp *Checkpoint, finalHeight uint64) error {
+	tip := cp.Tip()
+	if tip.Height <= finalHeight || len(cp.Blocks) == 1 {
+		return fmt.Errorf("Cannot roll back block %v %v, the reorg is deeper than the indexed blocks", tip.Height, tip.Hash.String())
+	}
+	idx.lock.RLock()
+	handlers := idx.rollbackHandlers
+	idx.lock.RUnlock()
+	for _, f := range handlers {
+		if err := f(*tip); err != nil {
+			return err
+		}
+	}
+	cp.Blocks = cp.Blocks[:len(cp.Blocks)-1]
+	return idx.config.Checkpoints.Save(cp)
+}
+
+func (idx *Indexer) startHeight(chainID int) uint64 {
+	if h, ok := idx.config.StartHeights[chainID]; ok && h > 0 {
+		return h
+	}
+	return 1
+}
+
+func (idx *Indexer) emitBlock(chainID int, blk types.BlockInterface) error {
+	idx.lock.RLock()
+	defer idx.lock.RUnlock()
+	for _, f := range idx.blockHandlers {
+		if err := f(blk); err != nil {
+			return err
+		}
+	}
+	for _, event := range buildEvents(chainID, blk) {
+		if err := idx.emit(event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (idx *Indexer) emit(event Event) error {
+	handlers := append([]EventHandler{}, idx.kindHandlers[event.Kind]...)
+	if event.MetadataType != -1 {
+		handlers = append(handlers, idx.metaHandlers[event.MetadataType]...)
+	}
+	if len(event.Instruction) > 0 {
+		handlers = append(handlers, idx.instHandlers[event.Instruction[0]]...)
+	}
+	for _, f := range handlers {
+		if err := f(event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func buildEvents(chainID int, blk types.BlockInterface) []Event {
+	ref := BlockRef{ChainID: chainID, Height: blk.GetHeight(), Hash: *blk.Hash()}
+	events := []Event{}
+	if shardBlock, ok := blk.(*types.ShardBlock); ok {
+		for _, tx := range shardBlock.Body.Transactions {
+			meta := tx.GetMetadata()
+			if meta == nil {
+				continue
+			}
+			events = append(events, Event{
+				Kind:         KindOfMetadataType(meta.GetType()),
+				Block:        ref,
+				MetadataType: meta.GetType(),
+				TxHash:       *tx.Hash(),
+				Tx:           tx,
+				Metadata:     meta,
+			})
+		}
+		return events
+	}
+	for _, inst := range blk.GetInstructions() {
+		metaType, kind := parseInstruction(inst)
+		events = append(events, Event{
+			Kind:         kind,
+			Block:        ref,
+			MetadataType: metaType,
+			Instruction:  inst,
+		})
+	}
+	return events
+}
@@ -0,0 +1,208 @@
+package indexer
+
+import (
+	"io/ioutil"
+	"os"
+	"strconv"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/blockchain/types"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/instruction"
+	metadataCommon "github.com/incognitochain/incognito-chain/metadata/common"
+	"github.com/stretchr/testify/assert"
+)
+
+// mockSource serves a beacon chain kept in memory, tests replace its blocks to simulate reorgs
+type mockSource struct {
+	blocks      []*types.BeaconBlock
+	finalHeight uint64
+}
+
+func (s *mockSource) GetChainHeights(chainID int) (uint64, uint64, error) {
+	return s.finalHeight, uint64(len(s.blocks)), nil
+}
+
+func (s *mockSource) GetBlocks(chainID int, from uint64, num int) ([]types.BlockInterface, error) {
+	res := []types.BlockInterface{}
+	for h := from; h < from+uint64(num) && h <= uint64(len(s.blocks)); h++ {
+		res = append(res, s.blocks[h-1])
+	}
+	return res, nil
+}
+
+func (s *mockSource) SubscribeNewBlock(chainID int, closeCh <-chan struct{}) (<-chan struct{}, error) {
+	return make(chan struct{}), nil
+}
+
+// extend appends blocks to the chain from a height, dropping the blocks from this height on
+func (s *mockSource) extend(fromHeight uint64, fork int64, instsByBlock ...[][]string) {
+	s.blocks = s.blocks[:fromHeight-1]
+	for _, insts := range instsByBlock {
+		blk := types.NewBeaconBlock()
+		blk.Header.Height = uint64(len(s.blocks)) + 1
+		blk.Header.Timestamp = fork
+		if len(s.blocks) > 0 {
+			blk.Header.PreviousBlockHash = *s.blocks[len(s.blocks)-1].Hash()
+		}
+		blk.Body.Instructions = insts
+		s.blocks = append(s.blocks, blk)
+	}
+}
+
+func tradeInst() []string {
+	return []string{strconv.Itoa(metadataCommon.Pdexv3TradeRequestMeta), "accepted"}
+}
+
+func shieldInst() []string {
+	return []string{strconv.Itoa(metadataCommon.IssuingETHRequestMeta), "accepted"}
+}
+
+func stakeInst() []string {
+	return []string{instruction.STAKE_ACTION, "key"}
+}
+
+type recorder struct {
+	events    map[EventKind][]uint64
+	rollbacks []uint64
+}
+
+func newTestIndexer(t *testing.T, source Source, dir string, followBestView bool) (*Indexer, *Checkpoint, *recorder) {
+	store, err := NewFileCheckpointStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	idx, err := NewIndexer(Config{
+		Source:         source,
+		Checkpoints:    store,
+		ChainIDs:       []int{BeaconChainID},
+		FollowBestView: followBestView,
+		BatchSize:      2,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec := &recorder{events: make(map[EventKind][]uint64)}
+	for _, kind := range []EventKind{EventTrade, EventShield, EventStaking, EventOther} {
+		kind := kind
+		idx.OnEvent(kind, func(event Event) error {
+			rec.events[kind] = append(rec.events[kind], event.Block.Height)
+			return nil
+		})
+	}
+	idx.OnRollback(func(block BlockRef) error {
+		rec.rollbacks = append(rec.rollbacks, block.Height)
+		return nil
+	})
+	cp, err := idx.loadCheckpoint(BeaconChainID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return idx, cp, rec
+}
+
+func syncChain(t *testing.T, idx *Indexer, cp *Checkpoint) {
+	for i := 0; i < 100; i++ {
+		hasMore, err := idx.step(BeaconChainID, cp)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !hasMore {
+			return
+		}
+	}
+	t.Fatal("indexer does not converge")
+}
+
+func TestIndexFinalizedBlocksAndResume(t *testing.T) {
+	dir, err := ioutil.TempDir("", "indexer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	source := &mockSource{}
+	source.extend(1, 0, [][]string{stakeInst()}, [][]string{tradeInst(), shieldInst()}, [][]string{}, [][]string{tradeInst()}, [][]string{shieldInst()})
+	source.finalHeight = 4
+
+	idx, cp, rec := newTestIndexer(t, source, dir, false)
+	syncChain(t, idx, cp)
+	assert.Equal(t, []uint64{2, 4}, rec.events[EventTrade])
+	assert.Equal(t, []uint64{2}, rec.events[EventShield])
+	assert.Equal(t, []uint64{1}, rec.events[EventStaking])
+	assert.Equal(t, uint64(4), cp.Tip().Height)
+	assert.Equal(t, 1, len(cp.Blocks))
+
+	// a new indexer resumes from the saved checkpoint
+	source.finalHeight = 5
+	idx, cp, rec = newTestIndexer(t, source, dir, false)
+	assert.Equal(t, uint64(4), cp.Tip().Height)
+	syncChain(t, idx, cp)
+	assert.Equal(t, 0, len(rec.events[EventTrade]))
+	assert.Equal(t, []uint64{5}, rec.events[EventShield])
+	assert.Equal(t, *source.blocks[4].Hash(), cp.Tip().Hash)
+}
+
+func TestRollbackOnReorg(t *testing.T) {
+	dir, err := ioutil.TempDir("", "indexer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	source := &mockSource{}
+	source.extend(1, 0, [][]string{}, [][]string{}, [][]string{tradeInst()}, [][]string{tradeInst()}, [][]string{tradeInst()})
+	source.finalHeight = 2
+
+	idx, cp, rec := newTestIndexer(t, source, dir, true)
+	syncChain(t, idx, cp)
+	assert.Equal(t, []uint64{3, 4, 5}, rec.events[EventTrade])
+	assert.Equal(t, 4, len(cp.Blocks))
+
+	// the node switches to a longer fork from height 4
+	source.extend(4, 1, [][]string{shieldInst()}, [][]string{shieldInst()}, [][]string{shieldInst()})
+	syncChain(t, idx, cp)
+	assert.Equal(t, []uint64{5, 4}, rec.rollbacks)
+	assert.Equal(t, []uint64{4, 5, 6}, rec.events[EventShield])
+	assert.Equal(t, *source.blocks[5].Hash(), cp.Tip().Hash)
+
+	// the rollback is also found after a restart, when the node switches to a shorter fork
+	source.extend(5, 2, [][]string{stakeInst()})
+	source.finalHeight = 4
+	idx, cp, rec = newTestIndexer(t, source, dir, true)
+	syncChain(t, idx, cp)
+	assert.Equal(t, []uint64{6, 5}, rec.rollbacks)
+	assert.Equal(t, []uint64{5}, rec.events[EventStaking])
+	assert.Equal(t, *source.blocks[4].Hash(), cp.Tip().Hash)
+
+	// finalized blocks are never rolled back
+	source.extend(3, 3, [][]string{}, [][]string{}, [][]string{}, [][]string{})
+	_, err = idx.step(BeaconChainID, cp)
+	for err == nil {
+		_, err = idx.step(BeaconChainID, cp)
+	}
+	assert.Contains(t, err.Error(), "deeper than the indexed blocks")
+	assert.NotEqual(t, common.Hash{}, cp.Tip().Hash)
+}
+
+func TestStartStop(t *testing.T) {
+	dir, err := ioutil.TempDir("", "indexer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	source := &mockSource{}
+	source.extend(1, 0, [][]string{tradeInst()})
+	source.finalHeight = 1
+
+	idx, _, _ := newTestIndexer(t, source, dir, false)
+	traded := make(chan uint64, 1)
+	idx.OnTrade(func(event Event) error {
+		traded <- event.Block.Height
+		return nil
+	})
+	assert.Nil(t, idx.Start())
+	assert.Equal(t, uint64(1), <-traded)
+	idx.Stop()
+}
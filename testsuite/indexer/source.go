@@ -0,0 +1,174 @@
+package indexer
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/incognitochain/incognito-chain/blockchain/types"
+	"github.com/incognitochain/incognito-chain/rpcserver/jsonresult"
+)
+
+// Source provides the blocks of the chains being indexed
+type Source interface {
+	// GetChainHeights returns the finalized height and the best height of a chain
+	GetChainHeights(chainID int) (finalHeight uint64, bestHeight uint64, err error)
+	// GetBlocks returns at most num consecutive blocks from a height,
+	// they are *types.BeaconBlock for the beacon chain and *types.ShardBlock for shard chains
+	GetBlocks(chainID int, from uint64, num int) ([]types.BlockInterface, error)
+	// SubscribeNewBlock sends to the returned channel every time the node inserts a block into the chain.
+	// The channel is closed when the subscription breaks or closeCh is closed
+	SubscribeNewBlock(chainID int, closeCh <-chan struct{}) (<-chan struct{}, error)
+}
+
+// RemoteSource reads blocks from a fullnode with its http rpc and websocket endpoints
+type RemoteSource struct {
+	RPCEndpoint string
+	WSEndpoint  string
+	Client      *http.Client
+}
+
+func NewRemoteSource(rpcEndpoint, wsEndpoint string) *RemoteSource {
+	return &RemoteSource{
+		RPCEndpoint: rpcEndpoint,
+		WSEndpoint:  wsEndpoint,
+		Client:      &http.Client{Timeout: time.Minute},
+	}
+}
+
+type rpcError struct {
+	Code       int
+	Message    string
+	StackTrace string
+}
+
+func (s *RemoteSource) call(method string, params []interface{}, result interface{}) error {
+	requestBody, err := json.Marshal(map[string]interface{}{
+		"jsonrpc": "1.0",
+		"method":  method,
+		"params":  params,
+		"id":      1,
+	})
+	if err != nil {
+		return err
+	}
+	resp, err := s.Client.Post(s.RPCEndpoint, "application/json", bytes.NewBuffer(requestBody))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	res := struct {
+		Result json.RawMessage
+		Error  *rpcError
+	}{}
+	if err := json.Unmarshal(body, &res); err != nil {
+		return err
+	}
+	if res.Error != nil {
+		return fmt.Errorf("RPC %v error %v: %v", method, res.Error.Code, res.Error.Message)
+	}
+	return json.Unmarshal(res.Result, result)
+}
+
+// GetChainHeights reads the views of the chain, the first view returned by the node is the final one
+func (s *RemoteSource) GetChainHeights(chainID int) (uint64, uint64, error) {
+	views := []jsonresult.GetViewResult{}
+	if err := s.call("getallviewdetail", []interface{}{chainID}, &views); err != nil {
+		return 0, 0, err
+	}
+	if len(views) == 0 {
+		return 0, 0, fmt.Errorf("No view of chain %v", chainID)
+	}
+	finalHeight, bestHeight := views[0].Height, views[0].Height
+	for _, view := range views {
+		if view.Height > bestHeight {
+			bestHeight = view.Height
+		}
+	}
+	return finalHeight, bestHeight, nil
+}
+
+func (s *RemoteSource) GetBlocks(chainID int, from uint64, num int) ([]types.BlockInterface, error) {
+	res := []types.BlockInterface{}
+	if chainID == BeaconChainID {
+		blocks := []*types.BeaconBlock{}
+		if err := s.call("getblocksfromheight", []interface{}{chainID, from, num}, &blocks); err != nil {
+			return nil, err
+		}
+		for _, blk := range blocks {
+			res = append(res, blk)
+		}
+		return res, nil
+	}
+	blocks := []*types.ShardBlock{}
+	if err := s.call("getblocksfromheight", []interface{}{chainID, from, num}, &blocks); err != nil {
+		return nil, err
+	}
+	for _, blk := range blocks {
+		res = append(res, blk)
+	}
+	return res, nil
+}
+
+func (s *RemoteSource) SubscribeNewBlock(chainID int, closeCh <-chan struct{}) (<-chan struct{}, error) {
+	if s.WSEndpoint == "" {
+		return nil, errors.New("Websocket endpoint is not set")
+	}
+	method, params := "subcribenewbeaconblock", []interface{}{}
+	if chainID != BeaconChainID {
+		method, params = "subcribenewshardblock", []interface{}{chainID}
+	}
+	conn, _, err := websocket.DefaultDialer.Dial(s.WSEndpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	err = conn.WriteJSON(map[string]interface{}{
+		"Request": map[string]interface{}{
+			"Jsonrpc": "1.0",
+			"Method":  method,
+			"Params":  params,
+			"Id":      1,
+		},
+		"Subcription": fmt.Sprintf("indexer-%v", chainID),
+		"Type":        0,
+	})
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	notifyCh := make(chan struct{}, 1)
+	done := make(chan struct{})
+	go func() {
+		select {
+		case <-closeCh:
+		case <-done:
+		}
+		conn.Close()
+	}()
+	go func() {
+		defer close(notifyCh)
+		defer close(done)
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+			// the block itself is fetched by height, a pending notification is enough
+			select {
+			case notifyCh <- struct{}{}:
+			default:
+			}
+		}
+	}()
+	return notifyCh, nil
+}
@@ -1,7 +1,9 @@
 package devframework
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
 
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/metadata"
@@ -63,19 +65,71 @@ func (r *LocalRPCClient) Pdexv3_TxAddLiquidity(privatekey string, params rpcclie
 }
 
 func (r *LocalRPCClient) Pdexv3_TxWithdrawLiquidity(privatekey, poolairID, nftID, shareAmount string) error {
-	panic("implement me")
+	httpServer := r.rpcServer.HttpServer
+	c := rpcserver.HttpHandler["pdexv3_txWithdrawLiquidity"]
+
+	sendParam := map[string]interface{}{}
+	sendParam["PoolPairID"] = poolairID
+	sendParam["NftID"] = nftID
+	sendParam["ShareAmount"] = shareAmount
+	_, rpcERR := c(httpServer, []interface{}{privatekey, nil, float64(-1), float64(1), sendParam}, nil)
+	if rpcERR != nil {
+		return errors.New(rpcERR.Error())
+	}
+	return nil
 }
 
 func (r *LocalRPCClient) Pdexv3_TxModifyParams(privatekey string, newParams rpcclient.PdexV3Params) {
-	panic("implement me")
+	httpServer := r.rpcServer.HttpServer
+	c := rpcserver.HttpHandler["pdexv3_txModifyParams"]
+
+	// the handler reads the new params as decoded from JSON
+	data, err := json.Marshal(newParams)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	params := map[string]interface{}{}
+	if err := json.Unmarshal(data, &params); err != nil {
+		fmt.Println(err)
+		return
+	}
+	sendParam := map[string]interface{}{}
+	sendParam["NewParams"] = params
+	_, rpcERR := c(httpServer, []interface{}{privatekey, nil, float64(-1), float64(1), sendParam}, nil)
+	if rpcERR != nil {
+		fmt.Println(rpcERR)
+	}
 }
 
 func (r *LocalRPCClient) Pdexv3_TxStake(privatekey, stakingPoolID, nftID, amount string) error {
-	panic("implement me")
+	httpServer := r.rpcServer.HttpServer
+	c := rpcserver.HttpHandler["pdexv3_txStake"]
+
+	sendParam := map[string]interface{}{}
+	sendParam["StakingPoolID"] = stakingPoolID
+	sendParam["NftID"] = nftID
+	sendParam["Amount"] = amount
+	_, rpcERR := c(httpServer, []interface{}{privatekey, nil, float64(-1), float64(1), sendParam}, nil)
+	if rpcERR != nil {
+		return errors.New(rpcERR.Error())
+	}
+	return nil
 }
 
 func (r *LocalRPCClient) Pdexv3_TxUnstake(privatekey, stakingPoolID, nftID, amount string) error {
-	panic("implement me")
+	httpServer := r.rpcServer.HttpServer
+	c := rpcserver.HttpHandler["pdexv3_txUnstake"]
+
+	sendParam := map[string]interface{}{}
+	sendParam["StakingPoolID"] = stakingPoolID
+	sendParam["NftID"] = nftID
+	sendParam["Amount"] = amount
+	_, rpcERR := c(httpServer, []interface{}{privatekey, nil, float64(-1), float64(1), sendParam}, nil)
+	if rpcERR != nil {
+		return errors.New(rpcERR.Error())
+	}
+	return nil
 }
 
 func (r *LocalRPCClient) Pdexv3_TxAddTrade(privatekey string, params rpcclient.PdexV3TradeParam) error {
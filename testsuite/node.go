@@ -283,6 +283,7 @@ func (sim *NodeEngine) Init() {
 		common.MaxShardNumber,
 		ps,
 		time.Duration(15*60)*time.Second,
+		1000,
 	)
 	otadb, _ := incdb.Open("leveldb", "/tmp/database/ota")
 	err = bc.Init(&blockchain.Config{
@@ -605,7 +606,7 @@ func (s *NodeEngine) SignBlockWithCommittee(block types.BlockInterface, committe
 			//}
 		}
 		for _, committeeID := range committeeIndex {
-			vote, _ := blsbft.CreateVote(miningKeys[committeeID], block, committeePubKey, s.bc.GetChain(-1).(*blockchain.BeaconChain).GetPortalParamsV4(0))
+			vote, _ := blsbft.CreateVote(s.bc.GetChain(block.GetShardID()).(blsbft.Chain), miningKeys[committeeID], block, committeePubKey, s.bc.GetChain(-1).(*blockchain.BeaconChain).GetPortalParamsV4(0))
 			vote.IsValid = 1
 			votes[vote.Validator] = vote
 		}
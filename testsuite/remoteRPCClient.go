@@ -1,1409 +1,227 @@
-package devframework //This file is auto generated. Please do not change if you dont know what you are doing
+// Code generated by utility/genrpcclient from the OpenRPC document of rpcserver. DO NOT EDIT.
+
+package devframework
+
 import (
-	"bytes"
 	"encoding/json"
 	"errors"
-	"github.com/incognitochain/incognito-chain/blockchain/types"
+
 	"github.com/incognitochain/incognito-chain/common"
-	"github.com/incognitochain/incognito-chain/metadata"
 	"github.com/incognitochain/incognito-chain/rpcserver/jsonresult"
-	"github.com/incognitochain/incognito-chain/testsuite/rpcclient"
-	"io/ioutil"
-	"net/http"
 )
 
-type RemoteRPCClient struct {
-	Endpoint string
+func (r *RemoteRPCClient) CreateAndSendPrivacyCustomTokenTransaction(privateKey string, receivers map[string]interface{}, fee float64, privacy float64, tokenInfo map[string]interface{}, info string, hasPrivacyToken float64) (res jsonresult.CreateTransactionTokenResult, err error) {
+	err = r.call("createandsendprivacycustomtokentransaction", []interface{}{privateKey, receivers, fee, privacy, tokenInfo, info, hasPrivacyToken}, &res)
+	return res, err
 }
 
-func (r *RemoteRPCClient) IsInstantFinality(chainID int) (res bool, err error) {
-	requestBody, rpcERR := json.Marshal(map[string]interface{}{
-		"jsonrpc": "1.0",
-		"method":  "isinstantfinality",
-		"params":  []interface{}{chainID},
-		"id":      1,
-	})
-	if rpcERR != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	body, err := r.sendRequest(requestBody)
-	if err != nil {
-		return res, errors.New(err.Error())
-	}
-	resp := struct {
-		Result bool
-		Error  *ErrMsg
-	}{}
-	err = json.Unmarshal(body, &resp)
-
-	if resp.Error != nil && resp.Error.StackTrace != "" {
-		return res, errors.New(resp.Error.StackTrace)
-	}
-
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	return resp.Result, err
+func (r *RemoteRPCClient) CreateAndSendStakingTransaction(privateKey string, receivers map[string]interface{}, fee float64, privacy float64, stakeInfo map[string]interface{}) (res jsonresult.CreateTransactionResult, err error) {
+	err = r.call("createandsendstakingtransaction", []interface{}{privateKey, receivers, fee, privacy, stakeInfo}, &res)
+	return res, err
 }
 
-func (r *RemoteRPCClient) GetAllViewDetail(chainID int) (res []jsonresult.GetViewResult, err error) {
-	requestBody, rpcERR := json.Marshal(map[string]interface{}{
-		"jsonrpc": "1.0",
-		"method":  "getallviewdetail",
-		"params":  []interface{}{chainID},
-		"id":      1,
-	})
-	if rpcERR != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	body, err := r.sendRequest(requestBody)
-	if err != nil {
-		return res, errors.New(err.Error())
-	}
-	resp := struct {
-		Result []jsonresult.GetViewResult
-		Error  *ErrMsg
-	}{}
-	err = json.Unmarshal(body, &resp)
-
-	if resp.Error != nil && resp.Error.StackTrace != "" {
-		return res, errors.New(resp.Error.StackTrace)
-	}
-
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	return resp.Result, err
+func (r *RemoteRPCClient) CreateAndSendStopAutoStakingTransaction(privateKey string, receivers map[string]interface{}, fee float64, privacy float64, stopStakeInfo map[string]interface{}) (res jsonresult.CreateTransactionResult, err error) {
+	err = r.call("createandsendstopautostakingtransaction", []interface{}{privateKey, receivers, fee, privacy, stopStakeInfo}, &res)
+	return res, err
 }
 
-func (r *RemoteRPCClient) GetMiningInfo() (res *jsonresult.GetMiningInfoResult, err error) {
-	requestBody, rpcERR := json.Marshal(map[string]interface{}{
-		"jsonrpc": "1.0",
-		"method":  "getmininginfo",
-		"params":  []interface{}{},
-		"id":      1,
-	})
-	if rpcERR != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	body, err := r.sendRequest(requestBody)
-	if err != nil {
-		return res, errors.New(err.Error())
-	}
-	resp := struct {
-		Result *jsonresult.GetMiningInfoResult
-		Error  *ErrMsg
-	}{}
-	err = json.Unmarshal(body, &resp)
-
-	if resp.Error != nil && resp.Error.StackTrace != "" {
-		return res, errors.New(resp.Error.StackTrace)
-	}
-
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	return resp.Result, err
+func (r *RemoteRPCClient) CreateAndSendTransaction(privateKey string, receivers map[string]interface{}, fee float64, privacy float64) (res jsonresult.CreateTransactionResult, err error) {
+	err = r.call("createandsendtransaction", []interface{}{privateKey, receivers, fee, privacy}, &res)
+	return res, err
 }
 
-func (r *RemoteRPCClient) GetPortalShieldingRequestStatus(tx string) (res *metadata.PortalShieldingRequestStatus, err error) {
-	panic("implement me")
+func (r *RemoteRPCClient) CreateAndSendTxWithPDEFeeWithdrawalReq(privateKey string, receivers map[string]interface{}, fee float64, privacy float64, reqInfo map[string]interface{}) (res jsonresult.CreateTransactionResult, err error) {
+	err = r.call("createandsendtxwithpdefeewithdrawalreq", []interface{}{privateKey, receivers, fee, privacy, reqInfo}, &res)
+	return res, err
 }
 
-func (r *RemoteRPCClient) CreateAndSendTxWithPortalV4UnshieldRequest(privatekey string, tokenID string, amount string, paymentAddress string, remoteAddress string) (res jsonresult.CreateTransactionTokenResult, err error) {
-	panic("implement me")
+func (r *RemoteRPCClient) CreateAndSendTxWithPRVContributionV2(privateKey string, receivers map[string]interface{}, fee float64, privacy float64, reqInfo map[string]interface{}) (res jsonresult.CreateTransactionResult, err error) {
+	err = r.call("createandsendtxwithprvcontributionv2", []interface{}{privateKey, receivers, fee, privacy, reqInfo}, &res)
+	return res, err
 }
 
-func (r *RemoteRPCClient) GetPortalUnshieldRequestStatus(tx string) (res *metadata.PortalUnshieldRequestStatus, err error) {
-	panic("implement me")
+func (r *RemoteRPCClient) CreateAndSendTxWithPRVCrossPoolTradeReq(privateKey string, receivers map[string]interface{}, fee float64, privacy float64, reqInfo map[string]interface{}) (res jsonresult.CreateTransactionResult, err error) {
+	err = r.call("createandsendtxwithprvcrosspooltradereq", []interface{}{privateKey, receivers, fee, privacy, reqInfo}, &res)
+	return res, err
 }
 
-func (r *RemoteRPCClient) CreateAndSendTokenInitTransaction(param rpcclient.PdexV3InitTokenParam) (jsonresult.CreateTransactionTokenResult, error) {
-	panic("implement me")
+func (r *RemoteRPCClient) CreateAndSendTxWithPRVTradeReq(privateKey string, receivers map[string]interface{}, fee float64, privacy float64, reqInfo map[string]interface{}) (res jsonresult.CreateTransactionResult, err error) {
+	err = r.call("createandsendtxwithprvtradereq", []interface{}{privateKey, receivers, fee, privacy, reqInfo}, &res)
+	return res, err
 }
 
-func (r *RemoteRPCClient) Pdexv3_TxMintNft(privatekeys string) error {
-	panic("implement me")
+func (r *RemoteRPCClient) CreateAndSendTxWithPTokenContributionV2(privateKey string, receivers map[string]interface{}, fee float64, privacy float64, reqInfo map[string]interface{}, info string, hasPrivacyToken float64) (res jsonresult.CreateTransactionTokenResult, err error) {
+	err = r.call("createandsendtxwithptokencontributionv2", []interface{}{privateKey, receivers, fee, privacy, reqInfo, info, hasPrivacyToken}, &res)
+	return res, err
 }
 
-func (r *RemoteRPCClient) Pdexv3_TxAddLiquidity(privatekey string, param rpcclient.PdexV3AddLiquidityParam) error {
-	panic("implement me")
+func (r *RemoteRPCClient) CreateAndSendTxWithPTokenCrossPoolTradeReq(privateKey string, receivers map[string]interface{}, fee float64, privacy float64, reqInfo map[string]interface{}, info string, hasPrivacyToken float64) (res jsonresult.CreateTransactionTokenResult, err error) {
+	err = r.call("createandsendtxwithptokencrosspooltradereq", []interface{}{privateKey, receivers, fee, privacy, reqInfo, info, hasPrivacyToken}, &res)
+	return res, err
 }
 
-func (r *RemoteRPCClient) Pdexv3_TxWithdrawLiquidity(privatekey, poolairID, nftID, shareAmount string) error {
-	panic("implement me")
+func (r *RemoteRPCClient) CreateAndSendTxWithPTokenTradeReq(privateKey string, receivers map[string]interface{}, fee float64, privacy float64, reqInfo map[string]interface{}, info string, hasPrivacyToken float64) (res jsonresult.CreateTransactionTokenResult, err error) {
+	err = r.call("createandsendtxwithptokentradereq", []interface{}{privateKey, receivers, fee, privacy, reqInfo, info, hasPrivacyToken}, &res)
+	return res, err
 }
 
-func (r *RemoteRPCClient) Pdexv3_TxModifyParams(privatekey string, newParams rpcclient.PdexV3Params) {
-	panic("implement me")
+func (r *RemoteRPCClient) CreateAndSendTxWithWithdrawalReqV2(privateKey string, receivers map[string]interface{}, fee float64, privacy float64, reqInfo map[string]interface{}) (res jsonresult.CreateTransactionResult, err error) {
+	err = r.call("createandsendtxwithwithdrawalreqv2", []interface{}{privateKey, receivers, fee, privacy, reqInfo}, &res)
+	return res, err
 }
 
-func (r *RemoteRPCClient) Pdexv3_TxStake(privatekey, stakingPoolID, nftID, amount string) error {
-	panic("implement me")
+func (r *RemoteRPCClient) DefragmentAccount(privateKey string, maxValue float64, fee float64, privacy float64) (res jsonresult.CreateTransactionResult, err error) {
+	err = r.call("defragmentaccount", []interface{}{privateKey, maxValue, fee, privacy}, &res)
+	return res, err
 }
 
-func (r *RemoteRPCClient) Pdexv3_TxUnstake(privatekey, stakingPoolID, nftID, amount string) error {
-	panic("implement me")
+func (r *RemoteRPCClient) DefragmentAccountToken(privateKey string, receivers map[string]interface{}, fee float64, privacy float64, reqInfo map[string]interface{}, info string, hasPrivacyToken float64) (res jsonresult.CreateTransactionTokenResult, err error) {
+	err = r.call("defragmentaccounttoken", []interface{}{privateKey, receivers, fee, privacy, reqInfo, info, hasPrivacyToken}, &res)
+	return res, err
 }
 
-func (r *RemoteRPCClient) Pdexv3_TxAddTrade(privatekey string, param rpcclient.PdexV3TradeParam) error {
-	panic("implement me")
+func (r *RemoteRPCClient) GetAllViewDetail(chainID int) (res []jsonresult.GetViewResult, err error) {
+	err = r.call("getallviewdetail", []interface{}{chainID}, &res)
+	return res, err
 }
 
-func (r *RemoteRPCClient) Pdexv3_TxAddOrder(privatekey string, params rpcclient.PdexV3AddOrderParam) error {
-	panic("implement me")
+func (r *RemoteRPCClient) GetBalanceByPrivateKey(privateKey string) (res uint64, err error) {
+	err = r.call("getbalancebyprivatekey", []interface{}{privateKey}, &res)
+	return res, err
 }
 
-func (r *RemoteRPCClient) SendFinishSync(mining, cpk string, sid float64) error {
-	requestBody, rpcERR := json.Marshal(map[string]interface{}{
-		"jsonrpc": "1.0",
-		"method":  "sendfinishsync",
-		"params":  []interface{}{mining, cpk, sid},
-		"id":      1,
-	})
-	if rpcERR != nil {
-		return errors.New(rpcERR.Error())
-	}
-	body, err := r.sendRequest(requestBody)
-	if err != nil {
-		return errors.New(rpcERR.Error())
-	}
-	resp := struct {
-		Result bool
-		Error  *ErrMsg
-	}{}
-	//fmt.Println(string(body))
-	err = json.Unmarshal(body, &resp)
-
-	if resp.Error != nil && resp.Error.StackTrace != "" {
-		return errors.New(resp.Error.StackTrace)
-	}
-
-	if err != nil {
-		return errors.New(err.Error())
-	}
-	return err
-
+func (r *RemoteRPCClient) GetBeaconBestState() (res jsonresult.GetBeaconBestState, err error) {
+	err = r.call("getbeaconbeststate", []interface{}{}, &res)
+	return res, err
 }
-func (r *RemoteRPCClient) CreateConvertCoinVer1ToVer2Transaction(privateKey string) (err error) {
-	requestBody, rpcERR := json.Marshal(map[string]interface{}{
-		"jsonrpc": "1.0",
-		"method":  "createconvertcoinver1tover2transaction",
-		"params":  []interface{}{privateKey, -1},
-		"id":      1,
-	})
-	if err != nil {
-		return errors.New(rpcERR.Error())
-	}
-	body, err := r.sendRequest(requestBody)
-	if err != nil {
-		return errors.New(rpcERR.Error())
-	}
-	resp := struct {
-		Result bool
-		Error  *ErrMsg
-	}{}
-	//fmt.Println(string(body))
-	err = json.Unmarshal(body, &resp)
-
-	if resp.Error != nil && resp.Error.StackTrace != "" {
-		return errors.New(resp.Error.StackTrace)
-	}
 
-	if err != nil {
-		return errors.New(err.Error())
-	}
-	return err
+func (r *RemoteRPCClient) GetBlockChainInfo() (res *jsonresult.GetBlockChainInfoResult, err error) {
+	err = r.call("getblockchaininfo", []interface{}{}, &res)
+	return res, err
 }
 
-func (r *RemoteRPCClient) GetMempoolInfo() (res *jsonresult.GetMempoolInfo, err error) {
-	panic("implement me")
+func (r *RemoteRPCClient) GetBlockHash(chainID float64, height float64) (res []common.Hash, err error) {
+	err = r.call("getblockhash", []interface{}{chainID, height}, &res)
+	return res, err
 }
 
-func (r *RemoteRPCClient) CreateAndSendTXShieldingRequest(privateKey string, incAddr string, tokenID string, proof string) (res jsonresult.CreateTransactionResult, err error) {
-	panic("implement me")
+func (r *RemoteRPCClient) GetBurningAddress(beaconHeight float64) (res string, err error) {
+	err = r.call("getburningaddress", []interface{}{beaconHeight}, &res)
+	return res, err
 }
 
-type ErrMsg struct {
-	Code       int
-	Message    string
-	StackTrace string
+func (r *RemoteRPCClient) GetCandidateList() (res *jsonresult.CandidateListsResult, err error) {
+	err = r.call("getcandidatelist", []interface{}{}, &res)
+	return res, err
 }
 
-func (r *RemoteRPCClient) sendRequest(requestBody []byte) ([]byte, error) {
-	resp, err := http.Post(r.Endpoint, "application/json", bytes.NewBuffer(requestBody))
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	body, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
-	return body, nil
+func (r *RemoteRPCClient) GetCommitteeList() (res *jsonresult.CommitteeListsResult, err error) {
+	err = r.call("getcommitteelist", []interface{}{}, &res)
+	return res, err
 }
 
-func (r *RemoteRPCClient) GetBlocksFromHeight(shardID int, from uint64, num int) (res interface{}, err error) {
-	requestBody, err := json.Marshal(map[string]interface{}{
-		"jsonrpc": "1.0",
-		"method":  "getblocksfromheight",
-		"params":  []interface{}{shardID, from, num},
-		"id":      1,
-	})
-	if err != nil {
-		return res, err
-	}
-	body, err := r.sendRequest(requestBody)
-
-	if err != nil {
-		return res, err
-	}
-
-	if shardID == -1 {
-		resp := struct {
-			Result []types.BeaconBlock
-			Error  *ErrMsg
-		}{}
-		err = json.Unmarshal(body, &resp)
-		if resp.Error != nil && resp.Error.StackTrace != "" {
-			return res, errors.New(resp.Error.StackTrace)
-		}
-		if err != nil {
-			return res, err
-		}
-		return resp.Result, nil
-	} else {
-		resp := struct {
-			Result []types.ShardBlock
-			Error  *ErrMsg
-		}{}
-		err = json.Unmarshal(body, &resp)
-		if resp.Error != nil && resp.Error.StackTrace != "" {
-			return res, errors.New(resp.Error.StackTrace)
-		}
-		if err != nil {
-			return res, err
-		}
-		return resp.Result, nil
-	}
+func (r *RemoteRPCClient) GetListPrivacyCustomTokenBalance(privateKey string) (res jsonresult.ListCustomTokenBalance, err error) {
+	err = r.call("getlistprivacycustomtokenbalance", []interface{}{privateKey}, &res)
+	return res, err
 }
-func (r *RemoteRPCClient) SubmitKey(privateKey string) (res bool, err error) {
-	requestBody, rpcERR := json.Marshal(map[string]interface{}{
-		"jsonrpc": "1.0",
-		"method":  "submitkey",
-		"params":  []interface{}{privateKey},
-		"id":      1,
-	})
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	body, err := r.sendRequest(requestBody)
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	resp := struct {
-		Result bool
-		Error  *ErrMsg
-	}{}
-	err = json.Unmarshal(body, &resp)
-
-	if resp.Error != nil && resp.Error.StackTrace != "" {
-		return res, errors.New(resp.Error.StackTrace)
-	}
 
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	return resp.Result, err
+func (r *RemoteRPCClient) GetMiningInfo() (res *jsonresult.GetMiningInfoResult, err error) {
+	err = r.call("getmininginfo", []interface{}{}, &res)
+	return res, err
 }
 
-func (r *RemoteRPCClient) AuthorizedSubmitKey(privateKey string) (res bool, err error) {
-	requestBody, rpcERR := json.Marshal(map[string]interface{}{
-		"jsonrpc": "1.0",
-		"method":  "authorizedsubmitkey",
-		"params":  []interface{}{privateKey, "0c3d46946bbf99c8213dd7f6c640ed6433bdc056a5b68e7e80f5525311b0ca11", 0, true},
-		"id":      1,
-	})
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	body, err := r.sendRequest(requestBody)
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	resp := struct {
-		Result bool
-		Error  *ErrMsg
-	}{}
-	err = json.Unmarshal(body, &resp)
+func (r *RemoteRPCClient) GetPDEState(data map[string]interface{}) (res jsonresult.CurrentPDEState, err error) {
+	err = r.call("getpdestate", []interface{}{data}, &res)
+	return res, err
+}
 
-	if resp.Error != nil && resp.Error.StackTrace != "" {
-		return res, errors.New(resp.Error.StackTrace)
-	}
+func (r *RemoteRPCClient) GetPrivacyCustomToken(tokenStr string) (res *jsonresult.GetCustomToken, err error) {
+	err = r.call("getprivacycustomtoken", []interface{}{tokenStr}, &res)
+	return res, err
+}
 
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	return resp.Result, err
+func (r *RemoteRPCClient) GetPublicKeyRole(publicKey string, detail bool) (res interface{}, err error) {
+	err = r.call("getpublickeyrole", []interface{}{publicKey, detail}, &res)
+	return res, err
 }
 
-func (r *RemoteRPCClient) GetBalanceByPrivateKey(privateKey string) (res uint64, err error) {
-	requestBody, rpcERR := json.Marshal(map[string]interface{}{
-		"jsonrpc": "1.0",
-		"method":  "getbalancebyprivatekey",
-		"params":  []interface{}{privateKey},
-		"id":      1,
-	})
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	body, err := r.sendRequest(requestBody)
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	resp := struct {
-		Result uint64
-		Error  *ErrMsg
-	}{}
-	err = json.Unmarshal(body, &resp)
+func (r *RemoteRPCClient) GetRewardAmount(paymentAddress string) (res map[string]uint64, err error) {
+	err = r.call("getrewardamount", []interface{}{paymentAddress}, &res)
+	return res, err
+}
 
-	if resp.Error != nil && resp.Error.StackTrace != "" {
-		return res, errors.New(resp.Error.StackTrace)
-	}
+func (r *RemoteRPCClient) GetShardBestState(sid int) (res jsonresult.GetShardBestState, err error) {
+	err = r.call("getshardbeststate", []interface{}{sid}, &res)
+	return res, err
+}
 
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	return resp.Result, err
+func (r *RemoteRPCClient) GetTransactionByHash(transactionHash string) (res *jsonresult.TransactionDetail, err error) {
+	err = r.call("gettransactionbyhash", []interface{}{transactionHash}, &res)
+	return res, err
 }
 
-func (r *RemoteRPCClient) GetListPrivacyCustomTokenBalance(privateKey string) (res jsonresult.ListCustomTokenBalance, err error) {
-	requestBody, rpcERR := json.Marshal(map[string]interface{}{
-		"jsonrpc": "1.0",
-		"method":  "getlistprivacycustomtokenbalance",
-		"params":  []interface{}{privateKey},
-		"id":      1,
-	})
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	body, err := r.sendRequest(requestBody)
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	resp := struct {
-		Result jsonresult.ListCustomTokenBalance
-		Error  *ErrMsg
-	}{}
-	err = json.Unmarshal(body, &resp)
+func (r *RemoteRPCClient) IsInstantFinality(chainID int) (res bool, err error) {
+	err = r.call("isinstantfinality", []interface{}{chainID}, &res)
+	return res, err
+}
 
-	if resp.Error != nil && resp.Error.StackTrace != "" {
-		return res, errors.New(resp.Error.StackTrace)
-	}
+func (r *RemoteRPCClient) RetrieveBeaconBlock(hash string) (res *jsonresult.GetBeaconBlockResult, err error) {
+	err = r.call("retrievebeaconblock", []interface{}{hash}, &res)
+	return res, err
+}
 
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	return resp.Result, err
+func (r *RemoteRPCClient) RetrieveBeaconBlockByHeight(height float64) (res []*jsonresult.GetBeaconBlockResult, err error) {
+	err = r.call("retrievebeaconblockbyheight", []interface{}{height}, &res)
+	return res, err
 }
 
-func (r *RemoteRPCClient) GetRewardAmount(paymentAddress string) (res map[string]uint64, err error) {
-	requestBody, rpcERR := json.Marshal(map[string]interface{}{
-		"jsonrpc": "1.0",
-		"method":  "getrewardamount",
-		"params":  []interface{}{paymentAddress},
-		"id":      1,
-	})
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	body, err := r.sendRequest(requestBody)
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	resp := struct {
-		Result map[string]uint64
-		Error  *ErrMsg
-	}{}
-	err = json.Unmarshal(body, &resp)
+func (r *RemoteRPCClient) RetrieveBlock(hash string, verbosity string) (res *jsonresult.GetShardBlockResult, err error) {
+	err = r.call("retrieveblock", []interface{}{hash, verbosity}, &res)
+	return res, err
+}
 
-	if resp.Error != nil && resp.Error.StackTrace != "" {
-		return res, errors.New(resp.Error.StackTrace)
-	}
+func (r *RemoteRPCClient) RetrieveBlockByHeight(shardID float64, height float64, verbosity string) (res []*jsonresult.GetShardBlockResult, err error) {
+	err = r.call("retrieveblockbyheight", []interface{}{shardID, height, verbosity}, &res)
+	return res, err
+}
 
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	return resp.Result, err
+func (r *RemoteRPCClient) SubmitKey(privateKey string) (res bool, err error) {
+	err = r.call("submitkey", []interface{}{privateKey}, &res)
+	return res, err
 }
 
 func (r *RemoteRPCClient) WithdrawReward(privateKey string, receivers map[string]interface{}, amount float64, privacy float64, info map[string]interface{}) (res jsonresult.CreateTransactionResult, err error) {
-	requestBody, rpcERR := json.Marshal(map[string]interface{}{
+	err = r.call("withdrawreward", []interface{}{privateKey, receivers, amount, privacy, info}, &res)
+	return res, err
+}
+
+func (r *RemoteRPCClient) call(method string, params []interface{}, result interface{}) error {
+	requestBody, err := json.Marshal(map[string]interface{}{
 		"jsonrpc": "1.0",
-		"method":  "withdrawreward",
-		"params":  []interface{}{privateKey, receivers, amount, privacy, info},
+		"method":  method,
+		"params":  params,
 		"id":      1,
 	})
 	if err != nil {
-		return res, errors.New(rpcERR.Error())
+		return err
 	}
 	body, err := r.sendRequest(requestBody)
 	if err != nil {
-		return res, errors.New(rpcERR.Error())
+		return err
 	}
 	resp := struct {
-		Result jsonresult.CreateTransactionResult
+		Result json.RawMessage
 		Error  *ErrMsg
 	}{}
 	err = json.Unmarshal(body, &resp)
-
 	if resp.Error != nil && resp.Error.StackTrace != "" {
-		return res, errors.New(resp.Error.StackTrace)
-	}
-
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	return resp.Result, err
-}
-
-func (r *RemoteRPCClient) CreateAndSendStakingTransaction(privateKey string, receivers map[string]interface{}, fee float64, privacy float64, stakeInfo map[string]interface{}) (res jsonresult.CreateTransactionResult, err error) {
-	requestBody, rpcERR := json.Marshal(map[string]interface{}{
-		"jsonrpc": "1.0",
-		"method":  "createandsendstakingtransaction",
-		"params":  []interface{}{privateKey, receivers, fee, privacy, stakeInfo},
-		"id":      1,
-	})
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
+		return errors.New(resp.Error.StackTrace)
 	}
-	body, err := r.sendRequest(requestBody)
 	if err != nil {
-		return res, errors.New(rpcERR.Error())
+		return err
 	}
-	resp := struct {
-		Result jsonresult.CreateTransactionResult
-		Error  *ErrMsg
-	}{}
-	err = json.Unmarshal(body, &resp)
-
-	if resp.Error != nil && resp.Error.StackTrace != "" {
-		return res, errors.New(resp.Error.StackTrace)
-	}
-
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	return resp.Result, err
-}
-
-func (r *RemoteRPCClient) CreateAndSendStopAutoStakingTransaction(privateKey string, receivers map[string]interface{}, fee float64, privacy float64, stopStakeInfo map[string]interface{}) (res jsonresult.CreateTransactionResult, err error) {
-	requestBody, rpcERR := json.Marshal(map[string]interface{}{
-		"jsonrpc": "1.0",
-		"method":  "createandsendstopautostakingtransaction",
-		"params":  []interface{}{privateKey, receivers, fee, privacy, stopStakeInfo},
-		"id":      1,
-	})
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	body, err := r.sendRequest(requestBody)
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	resp := struct {
-		Result jsonresult.CreateTransactionResult
-		Error  *ErrMsg
-	}{}
-	err = json.Unmarshal(body, &resp)
-
-	if resp.Error != nil && resp.Error.StackTrace != "" {
-		return res, errors.New(resp.Error.StackTrace)
-	}
-
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	return resp.Result, err
-}
-
-func (r *RemoteRPCClient) CreateAndSendTransaction(privateKey string, receivers map[string]interface{}, fee float64, privacy float64) (res jsonresult.CreateTransactionResult, err error) {
-	requestBody, rpcERR := json.Marshal(map[string]interface{}{
-		"jsonrpc": "1.0",
-		"method":  "createandsendtransaction",
-		"params":  []interface{}{privateKey, receivers, fee, privacy},
-		"id":      1,
-	})
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	body, err := r.sendRequest(requestBody)
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	resp := struct {
-		Result jsonresult.CreateTransactionResult
-		Error  *ErrMsg
-	}{}
-	err = json.Unmarshal(body, &resp)
-
-	if resp.Error != nil && resp.Error.StackTrace != "" {
-		return res, errors.New(resp.Error.StackTrace)
-	}
-
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	return resp.Result, err
-}
-
-func (r *RemoteRPCClient) CreateAndSendPrivacyCustomTokenTransaction(privateKey string, receivers map[string]interface{}, fee float64, privacy float64, tokenInfo map[string]interface{}, p1 string, pPrivacy float64) (res jsonresult.CreateTransactionTokenResult, err error) {
-	requestBody, rpcERR := json.Marshal(map[string]interface{}{
-		"jsonrpc": "1.0",
-		"method":  "createandsendprivacycustomtokentransaction",
-		"params":  []interface{}{privateKey, receivers, fee, privacy, tokenInfo, p1, pPrivacy},
-		"id":      1,
-	})
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	body, err := r.sendRequest(requestBody)
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	resp := struct {
-		Result jsonresult.CreateTransactionTokenResult
-		Error  *ErrMsg
-	}{}
-	err = json.Unmarshal(body, &resp)
-
-	if resp.Error != nil && resp.Error.StackTrace != "" {
-		return res, errors.New(resp.Error.StackTrace)
-	}
-
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	return resp.Result, err
-}
-
-func (r *RemoteRPCClient) CreateAndSendTxWithWithdrawalReqV2(privateKey string, receivers map[string]interface{}, fee float64, privacy float64, reqInfo map[string]interface{}) (res jsonresult.CreateTransactionResult, err error) {
-	requestBody, rpcERR := json.Marshal(map[string]interface{}{
-		"jsonrpc": "1.0",
-		"method":  "createandsendtxwithwithdrawalreqv2",
-		"params":  []interface{}{privateKey, receivers, fee, privacy, reqInfo},
-		"id":      1,
-	})
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	body, err := r.sendRequest(requestBody)
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	resp := struct {
-		Result jsonresult.CreateTransactionResult
-		Error  *ErrMsg
-	}{}
-	err = json.Unmarshal(body, &resp)
-
-	if resp.Error != nil && resp.Error.StackTrace != "" {
-		return res, errors.New(resp.Error.StackTrace)
-	}
-
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	return resp.Result, err
-}
-
-func (r *RemoteRPCClient) CreateAndSendTxWithPDEFeeWithdrawalReq(privateKey string, receivers map[string]interface{}, fee float64, privacy float64, reqInfo map[string]interface{}) (res jsonresult.CreateTransactionResult, err error) {
-	requestBody, rpcERR := json.Marshal(map[string]interface{}{
-		"jsonrpc": "1.0",
-		"method":  "createandsendtxwithpdefeewithdrawalreq",
-		"params":  []interface{}{privateKey, receivers, fee, privacy, reqInfo},
-		"id":      1,
-	})
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	body, err := r.sendRequest(requestBody)
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	resp := struct {
-		Result jsonresult.CreateTransactionResult
-		Error  *ErrMsg
-	}{}
-	err = json.Unmarshal(body, &resp)
-
-	if resp.Error != nil && resp.Error.StackTrace != "" {
-		return res, errors.New(resp.Error.StackTrace)
-	}
-
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	return resp.Result, err
-}
-
-func (r *RemoteRPCClient) CreateAndSendTxWithPTokenTradeReq(privateKey string, receivers map[string]interface{}, fee float64, privacy float64, reqInfo map[string]interface{}, p1 string, pPrivacy float64) (res jsonresult.CreateTransactionTokenResult, err error) {
-	requestBody, rpcERR := json.Marshal(map[string]interface{}{
-		"jsonrpc": "1.0",
-		"method":  "createandsendtxwithptokentradereq",
-		"params":  []interface{}{privateKey, receivers, fee, privacy, reqInfo, p1, pPrivacy},
-		"id":      1,
-	})
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	body, err := r.sendRequest(requestBody)
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	resp := struct {
-		Result jsonresult.CreateTransactionTokenResult
-		Error  *ErrMsg
-	}{}
-	err = json.Unmarshal(body, &resp)
-
-	if resp.Error != nil && resp.Error.StackTrace != "" {
-		return res, errors.New(resp.Error.StackTrace)
-	}
-
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	return resp.Result, err
-}
-
-func (r *RemoteRPCClient) CreateAndSendTxWithPTokenCrossPoolTradeReq(privateKey string, receivers map[string]interface{}, fee float64, privacy float64, reqInfo map[string]interface{}, p1 string, pPrivacy float64) (res jsonresult.CreateTransactionTokenResult, err error) {
-	requestBody, rpcERR := json.Marshal(map[string]interface{}{
-		"jsonrpc": "1.0",
-		"method":  "createandsendtxwithptokencrosspooltradereq",
-		"params":  []interface{}{privateKey, receivers, fee, privacy, reqInfo, p1, pPrivacy},
-		"id":      1,
-	})
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	body, err := r.sendRequest(requestBody)
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	resp := struct {
-		Result jsonresult.CreateTransactionTokenResult
-		Error  *ErrMsg
-	}{}
-	err = json.Unmarshal(body, &resp)
-
-	if resp.Error != nil && resp.Error.StackTrace != "" {
-		return res, errors.New(resp.Error.StackTrace)
-	}
-
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	return resp.Result, err
-}
-
-func (r *RemoteRPCClient) CreateAndSendTxWithPRVTradeReq(privateKey string, receivers map[string]interface{}, fee float64, privacy float64, reqInfo map[string]interface{}) (res jsonresult.CreateTransactionResult, err error) {
-	requestBody, rpcERR := json.Marshal(map[string]interface{}{
-		"jsonrpc": "1.0",
-		"method":  "createandsendtxwithprvtradereq",
-		"params":  []interface{}{privateKey, receivers, fee, privacy, reqInfo},
-		"id":      1,
-	})
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	body, err := r.sendRequest(requestBody)
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	resp := struct {
-		Result jsonresult.CreateTransactionResult
-		Error  *ErrMsg
-	}{}
-	err = json.Unmarshal(body, &resp)
-
-	if resp.Error != nil && resp.Error.StackTrace != "" {
-		return res, errors.New(resp.Error.StackTrace)
-	}
-
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	return resp.Result, err
-}
-
-func (r *RemoteRPCClient) CreateAndSendTxWithPRVCrossPoolTradeReq(privateKey string, receivers map[string]interface{}, fee float64, privacy float64, reqInfo map[string]interface{}) (res jsonresult.CreateTransactionResult, err error) {
-	requestBody, rpcERR := json.Marshal(map[string]interface{}{
-		"jsonrpc": "1.0",
-		"method":  "createandsendtxwithprvcrosspooltradereq",
-		"params":  []interface{}{privateKey, receivers, fee, privacy, reqInfo},
-		"id":      1,
-	})
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	body, err := r.sendRequest(requestBody)
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	resp := struct {
-		Result jsonresult.CreateTransactionResult
-		Error  *ErrMsg
-	}{}
-	err = json.Unmarshal(body, &resp)
-
-	if resp.Error != nil && resp.Error.StackTrace != "" {
-		return res, errors.New(resp.Error.StackTrace)
-	}
-
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	return resp.Result, err
-}
-
-func (r *RemoteRPCClient) CreateAndSendTxWithPTokenContributionV2(privateKey string, receivers map[string]interface{}, fee float64, privacy float64, reqInfo map[string]interface{}, p1 string, pPrivacy float64) (res jsonresult.CreateTransactionTokenResult, err error) {
-	requestBody, rpcERR := json.Marshal(map[string]interface{}{
-		"jsonrpc": "1.0",
-		"method":  "createandsendtxwithptokencontributionv2",
-		"params":  []interface{}{privateKey, receivers, fee, privacy, reqInfo, p1, pPrivacy},
-		"id":      1,
-	})
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	body, err := r.sendRequest(requestBody)
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	resp := struct {
-		Result jsonresult.CreateTransactionTokenResult
-		Error  *ErrMsg
-	}{}
-	err = json.Unmarshal(body, &resp)
-
-	if resp.Error != nil && resp.Error.StackTrace != "" {
-		return res, errors.New(resp.Error.StackTrace)
-	}
-
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	return resp.Result, err
-}
-
-func (r *RemoteRPCClient) CreateAndSendTxWithPRVContributionV2(privateKey string, receivers map[string]interface{}, fee float64, privacy float64, reqInfo map[string]interface{}) (res jsonresult.CreateTransactionResult, err error) {
-	requestBody, rpcERR := json.Marshal(map[string]interface{}{
-		"jsonrpc": "1.0",
-		"method":  "createandsendtxwithprvcontributionv2",
-		"params":  []interface{}{privateKey, receivers, fee, privacy, reqInfo},
-		"id":      1,
-	})
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	body, err := r.sendRequest(requestBody)
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	resp := struct {
-		Result jsonresult.CreateTransactionResult
-		Error  *ErrMsg
-	}{}
-	err = json.Unmarshal(body, &resp)
-
-	if resp.Error != nil && resp.Error.StackTrace != "" {
-		return res, errors.New(resp.Error.StackTrace)
-	}
-
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	return resp.Result, err
-}
-
-func (r *RemoteRPCClient) GetPDEState(data map[string]interface{}) (res jsonresult.CurrentPDEState, err error) {
-	requestBody, rpcERR := json.Marshal(map[string]interface{}{
-		"jsonrpc": "1.0",
-		"method":  "getpdestate",
-		"params":  []interface{}{data},
-		"id":      1,
-	})
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	body, err := r.sendRequest(requestBody)
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	resp := struct {
-		Result jsonresult.CurrentPDEState
-		Error  *ErrMsg
-	}{}
-	err = json.Unmarshal(body, &resp)
-
-	if resp.Error != nil && resp.Error.StackTrace != "" {
-		return res, errors.New(resp.Error.StackTrace)
-	}
-
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	return resp.Result, err
-}
-
-func (r *RemoteRPCClient) GetBeaconBestState() (res jsonresult.GetBeaconBestState, err error) {
-	requestBody, rpcERR := json.Marshal(map[string]interface{}{
-		"jsonrpc": "1.0",
-		"method":  "getbeaconbeststate",
-		"params":  []interface{}{},
-		"id":      1,
-	})
-	if rpcERR != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	body, err := r.sendRequest(requestBody)
-	if err != nil {
-		return res, errors.New(err.Error())
-	}
-	resp := struct {
-		Result jsonresult.GetBeaconBestState
-		Error  *ErrMsg
-	}{}
-	err = json.Unmarshal(body, &resp)
-
-	if resp.Error != nil && resp.Error.StackTrace != "" {
-		return res, errors.New(resp.Error.StackTrace)
-	}
-
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	return resp.Result, err
-}
-
-func (r *RemoteRPCClient) GetShardBestState(sid int) (res jsonresult.GetShardBestState, err error) {
-	requestBody, rpcERR := json.Marshal(map[string]interface{}{
-		"jsonrpc": "1.0",
-		"method":  "getshardbeststate",
-		"params":  []interface{}{sid},
-		"id":      1,
-	})
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	body, err := r.sendRequest(requestBody)
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	resp := struct {
-		Result jsonresult.GetShardBestState
-		Error  *ErrMsg
-	}{}
-	err = json.Unmarshal(body, &resp)
-
-	if resp.Error != nil && resp.Error.StackTrace != "" {
-		return res, errors.New(resp.Error.StackTrace)
-	}
-
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	return resp.Result, err
-}
-
-func (r *RemoteRPCClient) GetTransactionByHash(transactionHash string) (res *jsonresult.TransactionDetail, err error) {
-	requestBody, rpcERR := json.Marshal(map[string]interface{}{
-		"jsonrpc": "1.0",
-		"method":  "gettransactionbyhash",
-		"params":  []interface{}{transactionHash},
-		"id":      1,
-	})
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	body, err := r.sendRequest(requestBody)
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	resp := struct {
-		Result *jsonresult.TransactionDetail
-		Error  *ErrMsg
-	}{}
-	err = json.Unmarshal(body, &resp)
-
-	if resp.Error != nil && resp.Error.StackTrace != "" {
-		return res, errors.New(resp.Error.StackTrace)
-	}
-
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	return resp.Result, err
-}
-
-func (r *RemoteRPCClient) GetPrivacyCustomToken(tokenStr string) (res *jsonresult.GetCustomToken, err error) {
-	requestBody, rpcERR := json.Marshal(map[string]interface{}{
-		"jsonrpc": "1.0",
-		"method":  "getprivacycustomtoken",
-		"params":  []interface{}{tokenStr},
-		"id":      1,
-	})
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	body, err := r.sendRequest(requestBody)
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	resp := struct {
-		Result *jsonresult.GetCustomToken
-		Error  *ErrMsg
-	}{}
-	err = json.Unmarshal(body, &resp)
-
-	if resp.Error != nil && resp.Error.StackTrace != "" {
-		return res, errors.New(resp.Error.StackTrace)
-	}
-
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	return resp.Result, err
-}
-
-func (r *RemoteRPCClient) GetBurningAddress(beaconHeight float64) (res string, err error) {
-	requestBody, rpcERR := json.Marshal(map[string]interface{}{
-		"jsonrpc": "1.0",
-		"method":  "getburningaddress",
-		"params":  []interface{}{beaconHeight},
-		"id":      1,
-	})
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	body, err := r.sendRequest(requestBody)
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	resp := struct {
-		Result string
-		Error  *ErrMsg
-	}{}
-	err = json.Unmarshal(body, &resp)
-
-	if resp.Error != nil && resp.Error.StackTrace != "" {
-		return res, errors.New(resp.Error.StackTrace)
-	}
-
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	return resp.Result, err
-}
-
-func (r *RemoteRPCClient) GetPublicKeyRole(publicKey string, detail bool) (res interface{}, err error) {
-	requestBody, rpcERR := json.Marshal(map[string]interface{}{
-		"jsonrpc": "1.0",
-		"method":  "getpublickeyrole",
-		"params":  []interface{}{publicKey, detail},
-		"id":      1,
-	})
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	body, err := r.sendRequest(requestBody)
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	resp := struct {
-		Result interface{}
-		Error  *ErrMsg
-	}{}
-	err = json.Unmarshal(body, &resp)
-
-	if resp.Error != nil && resp.Error.StackTrace != "" {
-		return res, errors.New(resp.Error.StackTrace)
-	}
-
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	return resp.Result, err
-}
-
-func (r *RemoteRPCClient) GetBlockChainInfo() (res *jsonresult.GetBlockChainInfoResult, err error) {
-	requestBody, rpcERR := json.Marshal(map[string]interface{}{
-		"jsonrpc": "1.0",
-		"method":  "getblockchaininfo",
-		"params":  []interface{}{},
-		"id":      1,
-	})
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	body, err := r.sendRequest(requestBody)
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	resp := struct {
-		Result *jsonresult.GetBlockChainInfoResult
-		Error  *ErrMsg
-	}{}
-	err = json.Unmarshal(body, &resp)
-
-	if resp.Error != nil && resp.Error.StackTrace != "" {
-		return res, errors.New(resp.Error.StackTrace)
-	}
-
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	return resp.Result, err
-}
-
-func (r *RemoteRPCClient) GetCandidateList() (res *jsonresult.CandidateListsResult, err error) {
-	requestBody, rpcERR := json.Marshal(map[string]interface{}{
-		"jsonrpc": "1.0",
-		"method":  "getcandidatelist",
-		"params":  []interface{}{},
-		"id":      1,
-	})
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	body, err := r.sendRequest(requestBody)
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	resp := struct {
-		Result *jsonresult.CandidateListsResult
-		Error  *ErrMsg
-	}{}
-	err = json.Unmarshal(body, &resp)
-
-	if resp.Error != nil && resp.Error.StackTrace != "" {
-		return res, errors.New(resp.Error.StackTrace)
-	}
-
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	return resp.Result, err
-}
-
-type ConsensusRule struct {
-	VoteRule          string `json:"vote_rule,omitempty"`
-	CreateRule        string `json:"create_rule,omitempty"`
-	HandleVoteRule    string `json:"handle_vote_rule,omitempty"`
-	HandleProposeRule string `json:"handle_propose_rule,omitempty"`
-	InsertRule        string `json:"insert_rule,omitempty"`
-	ValidatorRule     string `json:"validator_rule,omitempty"`
-}
-
-func (r *RemoteRPCClient) SetConsensusRule(rules ConsensusRule) (res map[string]interface{}, err error) {
-	requestBody, rpcERR := json.Marshal(map[string]interface{}{
-		"jsonrpc": "1.0",
-		"method":  "setconsensusrule",
-		"params":  []interface{}{rules},
-		"id":      1,
-	})
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-
-	body, err := r.sendRequest(requestBody)
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	resp := struct {
-		Result map[string]interface{}
-		Error  *ErrMsg
-	}{}
-	err = json.Unmarshal(body, &resp)
-
-	if resp.Error != nil && resp.Error.StackTrace != "" {
-		return res, errors.New(resp.Error.StackTrace)
-	}
-
-	if err != nil {
-		return res, errors.New(err.Error())
-	}
-	return resp.Result, err
-}
-
-func (r *RemoteRPCClient) GetCommitteeList() (res *jsonresult.CommitteeListsResult, err error) {
-	requestBody, rpcERR := json.Marshal(map[string]interface{}{
-		"jsonrpc": "1.0",
-		"method":  "getcommitteelist",
-		"params":  []interface{}{},
-		"id":      1,
-	})
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	body, err := r.sendRequest(requestBody)
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	resp := struct {
-		Result *jsonresult.CommitteeListsResult
-		Error  *ErrMsg
-	}{}
-	err = json.Unmarshal(body, &resp)
-
-	if resp.Error != nil && resp.Error.StackTrace != "" {
-		return res, errors.New(resp.Error.StackTrace)
-	}
-
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	return resp.Result, err
-}
-
-func (r *RemoteRPCClient) GetBlockHash(chainID float64, height float64) (res []common.Hash, err error) {
-	requestBody, rpcERR := json.Marshal(map[string]interface{}{
-		"jsonrpc": "1.0",
-		"method":  "getblockhash",
-		"params":  []interface{}{chainID, height},
-		"id":      1,
-	})
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	body, err := r.sendRequest(requestBody)
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	resp := struct {
-		Result []common.Hash
-		Error  *ErrMsg
-	}{}
-	err = json.Unmarshal(body, &resp)
-
-	if resp.Error != nil && resp.Error.StackTrace != "" {
-		return res, errors.New(resp.Error.StackTrace)
-	}
-
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	return resp.Result, err
-}
-
-func (r *RemoteRPCClient) RetrieveBlock(hash string, verbosity string) (res *jsonresult.GetShardBlockResult, err error) {
-	requestBody, rpcERR := json.Marshal(map[string]interface{}{
-		"jsonrpc": "1.0",
-		"method":  "retrieveblock",
-		"params":  []interface{}{hash, verbosity},
-		"id":      1,
-	})
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	body, err := r.sendRequest(requestBody)
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	resp := struct {
-		Result *jsonresult.GetShardBlockResult
-		Error  *ErrMsg
-	}{}
-	err = json.Unmarshal(body, &resp)
-
-	if resp.Error != nil && resp.Error.StackTrace != "" {
-		return res, errors.New(resp.Error.StackTrace)
-	}
-
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	return resp.Result, err
-}
-
-func (r *RemoteRPCClient) RetrieveBlockByHeight(shardID float64, height float64, verbosity string) (res []*jsonresult.GetShardBlockResult, err error) {
-	requestBody, rpcERR := json.Marshal(map[string]interface{}{
-		"jsonrpc": "1.0",
-		"method":  "retrieveblockbyheight",
-		"params":  []interface{}{shardID, height, verbosity},
-		"id":      1,
-	})
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	body, err := r.sendRequest(requestBody)
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	resp := struct {
-		Result []*jsonresult.GetShardBlockResult
-		Error  *ErrMsg
-	}{}
-	err = json.Unmarshal(body, &resp)
-
-	if resp.Error != nil && resp.Error.StackTrace != "" {
-		return res, errors.New(resp.Error.StackTrace)
-	}
-
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	return resp.Result, err
-}
-
-func (r *RemoteRPCClient) RetrieveBeaconBlock(hash string) (res *jsonresult.GetBeaconBlockResult, err error) {
-	requestBody, rpcERR := json.Marshal(map[string]interface{}{
-		"jsonrpc": "1.0",
-		"method":  "retrievebeaconblock",
-		"params":  []interface{}{hash},
-		"id":      1,
-	})
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	body, err := r.sendRequest(requestBody)
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	resp := struct {
-		Result *jsonresult.GetBeaconBlockResult
-		Error  *ErrMsg
-	}{}
-	err = json.Unmarshal(body, &resp)
-
-	if resp.Error != nil && resp.Error.StackTrace != "" {
-		return res, errors.New(resp.Error.StackTrace)
-	}
-
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	return resp.Result, err
-}
-
-func (r *RemoteRPCClient) RetrieveBeaconBlockByHeight(height float64) (res []*jsonresult.GetBeaconBlockResult, err error) {
-	requestBody, rpcERR := json.Marshal(map[string]interface{}{
-		"jsonrpc": "1.0",
-		"method":  "retrievebeaconblockbyheight",
-		"params":  []interface{}{height},
-		"id":      1,
-	})
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	body, err := r.sendRequest(requestBody)
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	resp := struct {
-		Result []*jsonresult.GetBeaconBlockResult
-		Error  *ErrMsg
-	}{}
-	err = json.Unmarshal(body, &resp)
-
-	if resp.Error != nil && resp.Error.StackTrace != "" {
-		return res, errors.New(resp.Error.StackTrace)
-	}
-
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	return resp.Result, err
-}
-
-func (r *RemoteRPCClient) GetRewardAmountByEpoch(shard float64, epoch float64) (res uint64, err error) {
-	requestBody, rpcERR := json.Marshal(map[string]interface{}{
-		"jsonrpc": "1.0",
-		"method":  "getrewardamountbyepoch",
-		"params":  []interface{}{shard, epoch},
-		"id":      1,
-	})
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	body, err := r.sendRequest(requestBody)
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	resp := struct {
-		Result uint64
-		Error  *ErrMsg
-	}{}
-	err = json.Unmarshal(body, &resp)
-
-	if resp.Error != nil && resp.Error.StackTrace != "" {
-		return res, errors.New(resp.Error.StackTrace)
-	}
-
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	return resp.Result, err
-}
-
-func (r *RemoteRPCClient) DefragmentAccount(privateKey string, maxValue float64, fee float64, privacy float64) (res jsonresult.CreateTransactionResult, err error) {
-	requestBody, rpcERR := json.Marshal(map[string]interface{}{
-		"jsonrpc": "1.0",
-		"method":  "defragmentaccount",
-		"params":  []interface{}{privateKey, maxValue, fee, privacy},
-		"id":      1,
-	})
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	body, err := r.sendRequest(requestBody)
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	resp := struct {
-		Result jsonresult.CreateTransactionResult
-		Error  *ErrMsg
-	}{}
-	err = json.Unmarshal(body, &resp)
-
-	if resp.Error != nil && resp.Error.StackTrace != "" {
-		return res, errors.New(resp.Error.StackTrace)
-	}
-
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	return resp.Result, err
-}
-
-func (r *RemoteRPCClient) DefragmentAccountToken(privateKey string, receiver map[string]interface{}, fee float64, privacy float64, reqInfo map[string]interface{}, p1 string, pPrivacy float64) (res jsonresult.CreateTransactionTokenResult, err error) {
-	requestBody, rpcERR := json.Marshal(map[string]interface{}{
-		"jsonrpc": "1.0",
-		"method":  "defragmentaccounttoken",
-		"params":  []interface{}{privateKey, receiver, fee, privacy, reqInfo, p1, pPrivacy},
-		"id":      1,
-	})
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	body, err := r.sendRequest(requestBody)
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
-	}
-	resp := struct {
-		Result jsonresult.CreateTransactionTokenResult
-		Error  *ErrMsg
-	}{}
-	err = json.Unmarshal(body, &resp)
-
-	if resp.Error != nil && resp.Error.StackTrace != "" {
-		return res, errors.New(resp.Error.StackTrace)
-	}
-
-	if err != nil {
-		return res, errors.New(rpcERR.Error())
+	if len(resp.Result) == 0 {
+		return nil
 	}
-	return resp.Result, err
+	return json.Unmarshal(resp.Result, result)
 }
@@ -0,0 +1,375 @@
+package devframework
+
+//go:generate go run ../utility/genrpcclient -package devframework -type RemoteRPCClient -output remoteRPCClient.go
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/incognitochain/incognito-chain/blockchain/types"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/rpcserver/jsonresult"
+	"github.com/incognitochain/incognito-chain/testsuite/rpcclient"
+)
+
+type RemoteRPCClient struct {
+	Endpoint string
+}
+
+func (r *RemoteRPCClient) GetPortalShieldingRequestStatus(tx string) (res *metadata.PortalShieldingRequestStatus, err error) {
+	err = r.call("getportalshieldingrequeststatus", []interface{}{map[string]interface{}{"ReqTxID": tx}}, &res)
+	return res, err
+}
+
+func (r *RemoteRPCClient) CreateAndSendTxWithPortalV4UnshieldRequest(privatekey string, tokenID string, amount string, paymentAddress string, remoteAddress string) (res jsonresult.CreateTransactionTokenResult, err error) {
+	err = r.call("createandsendtxwithportalv4unshieldrequest", []interface{}{privatekey, nil, float64(5000), float64(-1), map[string]interface{}{
+		"Privacy":     true,
+		"TokenID":     tokenID,
+		"TokenTxType": float64(1),
+		"TokenName":   "",
+		"TokenSymbol": "",
+		"TokenAmount": amount,
+		"TokenReceivers": map[string]interface{}{
+			"12RxahVABnAVCGP3LGwCn8jkQxgw7z1x14wztHzn455TTVpi1wBq9YGwkRMQg3J4e657AbAnCvYCJSdA9czBUNuCKwGSRQt55Xwz8WA": amount,
+		},
+		"TokenFee":       "0",
+		"PortalTokenID":  tokenID,
+		"UnshieldAmount": amount,
+		"IncAddressStr":  paymentAddress,
+		"RemoteAddress":  remoteAddress,
+	}}, &res)
+	return res, err
+}
+
+func (r *RemoteRPCClient) GetPortalUnshieldRequestStatus(tx string) (res *metadata.PortalUnshieldRequestStatus, err error) {
+	err = r.call("getportalunshieldrequeststatus", []interface{}{map[string]interface{}{"UnshieldID": tx}}, &res)
+	return res, err
+}
+
+func (r *RemoteRPCClient) CreateAndSendTokenInitTransaction(param rpcclient.PdexV3InitTokenParam) (res jsonresult.CreateTransactionTokenResult, err error) {
+	err = r.call("createandsendtokeninittransaction", []interface{}{param}, &res)
+	return res, err
+}
+
+// sendPdexv3Tx calls a pDEX v3 transaction method, the result depends on the token of the transaction
+func (r *RemoteRPCClient) sendPdexv3Tx(method string, privatekey string, reqInfo map[string]interface{}) error {
+	var res interface{}
+	return r.call(method, []interface{}{privatekey, nil, float64(-1), float64(1), reqInfo}, &res)
+}
+
+func (r *RemoteRPCClient) Pdexv3_TxMintNft(privatekey string) error {
+	return r.sendPdexv3Tx("pdexv3_txMintNft", privatekey, map[string]interface{}{})
+}
+
+func (r *RemoteRPCClient) Pdexv3_TxAddLiquidity(privatekey string, param rpcclient.PdexV3AddLiquidityParam) error {
+	return r.sendPdexv3Tx("pdexv3_txAddLiquidity", privatekey, map[string]interface{}{
+		"NftID":             param.NftID,
+		"TokenID":           param.TokenID,
+		"PoolPairID":        param.PoolPairID,
+		"PairHash":          param.PairHash,
+		"ContributedAmount": param.ContributedAmount,
+		"Amplifier":         param.Amplifier,
+	})
+}
+
+func (r *RemoteRPCClient) Pdexv3_TxWithdrawLiquidity(privatekey, poolairID, nftID, shareAmount string) error {
+	return r.sendPdexv3Tx("pdexv3_txWithdrawLiquidity", privatekey, map[string]interface{}{
+		"PoolPairID":  poolairID,
+		"NftID":       nftID,
+		"ShareAmount": shareAmount,
+	})
+}
+
+func (r *RemoteRPCClient) Pdexv3_TxModifyParams(privatekey string, newParams rpcclient.PdexV3Params) {
+	err := r.sendPdexv3Tx("pdexv3_txModifyParams", privatekey, map[string]interface{}{
+		"NewParams": newParams,
+	})
+	if err != nil {
+		fmt.Println(err)
+	}
+}
+
+func (r *RemoteRPCClient) Pdexv3_TxStake(privatekey, stakingPoolID, nftID, amount string) error {
+	return r.sendPdexv3Tx("pdexv3_txStake", privatekey, map[string]interface{}{
+		"StakingPoolID": stakingPoolID,
+		"NftID":         nftID,
+		"Amount":        amount,
+	})
+}
+
+func (r *RemoteRPCClient) Pdexv3_TxUnstake(privatekey, stakingPoolID, nftID, amount string) error {
+	return r.sendPdexv3Tx("pdexv3_txUnstake", privatekey, map[string]interface{}{
+		"StakingPoolID": stakingPoolID,
+		"NftID":         nftID,
+		"Amount":        amount,
+	})
+}
+
+func (r *RemoteRPCClient) Pdexv3_TxAddTrade(privatekey string, param rpcclient.PdexV3TradeParam) error {
+	return r.sendPdexv3Tx("pdexv3_txTrade", privatekey, map[string]interface{}{
+		"TradePath":           param.TradePath,
+		"TokenToSell":         param.TokenToSell,
+		"TokenToBuy":          param.TokenToBuy,
+		"SellAmount":          param.SellAmount,
+		"MinAcceptableAmount": param.MinAcceptableAmount,
+		"TradingFee":          param.TradingFee,
+		"FeeInPRV":            param.FeeInPRV,
+	})
+}
+
+func (r *RemoteRPCClient) Pdexv3_TxAddOrder(privatekey string, param rpcclient.PdexV3AddOrderParam) error {
+	return r.sendPdexv3Tx("pdexv3_txAddOrder", privatekey, map[string]interface{}{
+		"PoolPairID":          param.PoolPairID,
+		"TokenToSell":         param.TokenToSell,
+		"TokenToBuy":          param.TokenToBuy,
+		"NftID":               param.NftID,
+		"SellAmount":          param.SellAmount,
+		"MinAcceptableAmount": param.MinAcceptableAmount,
+	})
+}
+
+func (r *RemoteRPCClient) SendFinishSync(mining, cpk string, sid float64) error {
+	requestBody, rpcERR := json.Marshal(map[string]interface{}{
+		"jsonrpc": "1.0",
+		"method":  "sendfinishsync",
+		"params":  []interface{}{mining, cpk, sid},
+		"id":      1,
+	})
+	if rpcERR != nil {
+		return errors.New(rpcERR.Error())
+	}
+	body, err := r.sendRequest(requestBody)
+	if err != nil {
+		return errors.New(rpcERR.Error())
+	}
+	resp := struct {
+		Result bool
+		Error  *ErrMsg
+	}{}
+	//fmt.Println(string(body))
+	err = json.Unmarshal(body, &resp)
+
+	if resp.Error != nil && resp.Error.StackTrace != "" {
+		return errors.New(resp.Error.StackTrace)
+	}
+
+	if err != nil {
+		return errors.New(err.Error())
+	}
+	return err
+
+}
+
+func (r *RemoteRPCClient) CreateConvertCoinVer1ToVer2Transaction(privateKey string) (err error) {
+	requestBody, rpcERR := json.Marshal(map[string]interface{}{
+		"jsonrpc": "1.0",
+		"method":  "createconvertcoinver1tover2transaction",
+		"params":  []interface{}{privateKey, -1},
+		"id":      1,
+	})
+	if err != nil {
+		return errors.New(rpcERR.Error())
+	}
+	body, err := r.sendRequest(requestBody)
+	if err != nil {
+		return errors.New(rpcERR.Error())
+	}
+	resp := struct {
+		Result bool
+		Error  *ErrMsg
+	}{}
+	//fmt.Println(string(body))
+	err = json.Unmarshal(body, &resp)
+
+	if resp.Error != nil && resp.Error.StackTrace != "" {
+		return errors.New(resp.Error.StackTrace)
+	}
+
+	if err != nil {
+		return errors.New(err.Error())
+	}
+	return err
+}
+
+func (r *RemoteRPCClient) GetMempoolInfo() (res *jsonresult.GetMempoolInfo, err error) {
+	err = r.call("getmempoolinfo", []interface{}{}, &res)
+	return res, err
+}
+
+func (r *RemoteRPCClient) CreateAndSendTXShieldingRequest(privateKey string, incAddr string, tokenID string, proof string) (res jsonresult.CreateTransactionResult, err error) {
+	err = r.call("createandsendtxshieldingrequest", []interface{}{privateKey, nil, float64(9876), float64(0), map[string]interface{}{
+		"IncogAddressStr": incAddr,
+		"TokenID":         tokenID,
+		"ShieldingProof":  proof,
+	}}, &res)
+	return res, err
+}
+
+type ErrMsg struct {
+	Code       int
+	Message    string
+	StackTrace string
+}
+
+func (r *RemoteRPCClient) sendRequest(requestBody []byte) ([]byte, error) {
+	resp, err := http.Post(r.Endpoint, "application/json", bytes.NewBuffer(requestBody))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	return body, nil
+}
+
+func (r *RemoteRPCClient) GetBlocksFromHeight(shardID int, from uint64, num int) (res interface{}, err error) {
+	requestBody, err := json.Marshal(map[string]interface{}{
+		"jsonrpc": "1.0",
+		"method":  "getblocksfromheight",
+		"params":  []interface{}{shardID, from, num},
+		"id":      1,
+	})
+	if err != nil {
+		return res, err
+	}
+	body, err := r.sendRequest(requestBody)
+
+	if err != nil {
+		return res, err
+	}
+
+	if shardID == -1 {
+		resp := struct {
+			Result []types.BeaconBlock
+			Error  *ErrMsg
+		}{}
+		err = json.Unmarshal(body, &resp)
+		if resp.Error != nil && resp.Error.StackTrace != "" {
+			return res, errors.New(resp.Error.StackTrace)
+		}
+		if err != nil {
+			return res, err
+		}
+		return resp.Result, nil
+	} else {
+		resp := struct {
+			Result []types.ShardBlock
+			Error  *ErrMsg
+		}{}
+		err = json.Unmarshal(body, &resp)
+		if resp.Error != nil && resp.Error.StackTrace != "" {
+			return res, errors.New(resp.Error.StackTrace)
+		}
+		if err != nil {
+			return res, err
+		}
+		return resp.Result, nil
+	}
+}
+
+func (r *RemoteRPCClient) AuthorizedSubmitKey(privateKey string) (res bool, err error) {
+	requestBody, rpcERR := json.Marshal(map[string]interface{}{
+		"jsonrpc": "1.0",
+		"method":  "authorizedsubmitkey",
+		"params":  []interface{}{privateKey, "0c3d46946bbf99c8213dd7f6c640ed6433bdc056a5b68e7e80f5525311b0ca11", 0, true},
+		"id":      1,
+	})
+	if err != nil {
+		return res, errors.New(rpcERR.Error())
+	}
+	body, err := r.sendRequest(requestBody)
+	if err != nil {
+		return res, errors.New(rpcERR.Error())
+	}
+	resp := struct {
+		Result bool
+		Error  *ErrMsg
+	}{}
+	err = json.Unmarshal(body, &resp)
+
+	if resp.Error != nil && resp.Error.StackTrace != "" {
+		return res, errors.New(resp.Error.StackTrace)
+	}
+
+	if err != nil {
+		return res, errors.New(rpcERR.Error())
+	}
+	return resp.Result, err
+}
+
+type ConsensusRule struct {
+	VoteRule          string `json:"vote_rule,omitempty"`
+	CreateRule        string `json:"create_rule,omitempty"`
+	HandleVoteRule    string `json:"handle_vote_rule,omitempty"`
+	HandleProposeRule string `json:"handle_propose_rule,omitempty"`
+	InsertRule        string `json:"insert_rule,omitempty"`
+	ValidatorRule     string `json:"validator_rule,omitempty"`
+}
+
+func (r *RemoteRPCClient) SetConsensusRule(rules ConsensusRule) (res map[string]interface{}, err error) {
+	requestBody, rpcERR := json.Marshal(map[string]interface{}{
+		"jsonrpc": "1.0",
+		"method":  "setconsensusrule",
+		"params":  []interface{}{rules},
+		"id":      1,
+	})
+	if err != nil {
+		return res, errors.New(rpcERR.Error())
+	}
+
+	body, err := r.sendRequest(requestBody)
+	if err != nil {
+		return res, errors.New(rpcERR.Error())
+	}
+	resp := struct {
+		Result map[string]interface{}
+		Error  *ErrMsg
+	}{}
+	err = json.Unmarshal(body, &resp)
+
+	if resp.Error != nil && resp.Error.StackTrace != "" {
+		return res, errors.New(resp.Error.StackTrace)
+	}
+
+	if err != nil {
+		return res, errors.New(err.Error())
+	}
+	return resp.Result, err
+}
+
+func (r *RemoteRPCClient) GetRewardAmountByEpoch(shard float64, epoch float64) (res uint64, err error) {
+	requestBody, rpcERR := json.Marshal(map[string]interface{}{
+		"jsonrpc": "1.0",
+		"method":  "getrewardamountbyepoch",
+		"params":  []interface{}{shard, epoch},
+		"id":      1,
+	})
+	if err != nil {
+		return res, errors.New(rpcERR.Error())
+	}
+	body, err := r.sendRequest(requestBody)
+	if err != nil {
+		return res, errors.New(rpcERR.Error())
+	}
+	resp := struct {
+		Result uint64
+		Error  *ErrMsg
+	}{}
+	err = json.Unmarshal(body, &resp)
+
+	if resp.Error != nil && resp.Error.StackTrace != "" {
+		return res, errors.New(resp.Error.StackTrace)
+	}
+
+	if err != nil {
+		return res, errors.New(rpcERR.Error())
+	}
+	return resp.Result, err
+}
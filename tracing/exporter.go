@@ -0,0 +1,240 @@
+package tracing
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// jsonSpan is the JSON form of a span written by FileExporter
+type jsonSpan struct {
+	TraceID    string                 `json:"traceId"`
+	SpanID     string                 `json:"spanId"`
+	Name       string                 `json:"name"`
+	Start      time.Time              `json:"start"`
+	End        time.Time              `json:"end"`
+	DurationMs float64                `json:"durationMs"`
+	Attributes map[string]interface{} `json:"attributes,omitempty"`
+	Error      string                 `json:"error,omitempty"`
+}
+
+// FileExporter appends spans to a local file, one JSON object per line
+type FileExporter struct {
+	lock sync.Mutex
+	file *os.File
+	w    *bufio.Writer
+}
+
+// NewFileExporter opens path to append spans to it
+func NewFileExporter(path string) (*FileExporter, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+	return &FileExporter{file: file, w: bufio.NewWriter(file)}, nil
+}
+
+func (e *FileExporter) Export(spans []*Span) error {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	enc := json.NewEncoder(e.w)
+	for _, span := range spans {
+		s := jsonSpan{
+			TraceID:    span.TraceIDString(),
+			SpanID:     span.SpanIDString(),
+			Name:       span.Name,
+			Start:      span.StartTime,
+			End:        span.EndTime,
+			DurationMs: float64(span.EndTime.Sub(span.StartTime)) / float64(time.Millisecond),
+			Error:      span.Error,
+		}
+		if len(span.Attributes) > 0 {
+			s.Attributes = map[string]interface{}{}
+			for _, attr := range span.Attributes {
+				s.Attributes[attr.Key] = attr.Value
+			}
+		}
+		if err := enc.Encode(s); err != nil {
+			return err
+		}
+	}
+	return e.w.Flush()
+}
+
+func (e *FileExporter) Close() error {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	if err := e.w.Flush(); err != nil {
+		return err
+	}
+	return e.file.Close()
+}
+
+// OTLPExporter posts spans to an OpenTelemetry collector with OTLP over HTTP, JSON encoded
+// <https://opentelemetry.io/docs/specs/otlp/#otlphttp>
+type OTLPExporter struct {
+	endpoint    string
+	serviceName string
+	client      *http.Client
+}
+
+// NewOTLPExporter creates an exporter to endpoint, the URL of the traces receiver of a collector
+// (e.g. http://localhost:4318/v1/traces). serviceName is the service.name resource of the spans
+func NewOTLPExporter(endpoint string, serviceName string) *OTLPExporter {
+	return &OTLPExporter{
+		endpoint:    endpoint,
+		serviceName: serviceName,
+		client:      &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+type otlpValue struct {
+	StringValue *string  `json:"stringValue,omitempty"`
+	BoolValue   *bool    `json:"boolValue,omitempty"`
+	IntValue    *string  `json:"intValue,omitempty"`
+	DoubleValue *float64 `json:"doubleValue,omitempty"`
+}
+
+type otlpAttribute struct {
+	Key   string    `json:"key"`
+	Value otlpValue `json:"value"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+type otlpSpan struct {
+	TraceID           string          `json:"traceId"`
+	SpanID            string          `json:"spanId"`
+	Name              string          `json:"name"`
+	Kind              int             `json:"kind"`
+	StartTimeUnixNano string          `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string          `json:"endTimeUnixNano"`
+	Attributes        []otlpAttribute `json:"attributes,omitempty"`
+	Status            otlpStatus      `json:"status"`
+}
+
+type otlpScopeSpans struct {
+	Scope struct {
+		Name string `json:"name"`
+	} `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpResourceSpans struct {
+	Resource struct {
+		Attributes []otlpAttribute `json:"attributes"`
+	} `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpRequest struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+const (
+	otlpSpanKindInternal = 1
+	otlpStatusOk         = 1
+	otlpStatusError      = 2
+)
+
+func (e *OTLPExporter) Export(spans []*Span) error {
+	data, err := json.Marshal(e.request(spans))
+	if err != nil {
+		return err
+	}
+	resp, err := e.client.Post(e.endpoint, "application/json", bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("OTLP endpoint %v returns %v: %s", e.endpoint, resp.Status, body)
+	}
+	return nil
+}
+
+func (e *OTLPExporter) Close() error {
+	return nil
+}
+
+func (e *OTLPExporter) request(spans []*Span) *otlpRequest {
+	scopeSpans := otlpScopeSpans{Spans: make([]otlpSpan, 0, len(spans))}
+	scopeSpans.Scope.Name = "github.com/incognitochain/incognito-chain/tracing"
+	for _, span := range spans {
+		s := otlpSpan{
+			TraceID:           span.TraceIDString(),
+			SpanID:            span.SpanIDString(),
+			Name:              span.Name,
+			Kind:              otlpSpanKindInternal,
+			StartTimeUnixNano: strconv.FormatInt(span.StartTime.UnixNano(), 10),
+			EndTimeUnixNano:   strconv.FormatInt(span.EndTime.UnixNano(), 10),
+			Status:            otlpStatus{Code: otlpStatusOk},
+		}
+		for _, attr := range span.Attributes {
+			s.Attributes = append(s.Attributes, newOTLPAttribute(attr.Key, attr.Value))
+		}
+		if span.Error != "" {
+			s.Status = otlpStatus{Code: otlpStatusError, Message: span.Error}
+		}
+		scopeSpans.Spans = append(scopeSpans.Spans, s)
+	}
+	resourceSpans := otlpResourceSpans{ScopeSpans: []otlpScopeSpans{scopeSpans}}
+	resourceSpans.Resource.Attributes = []otlpAttribute{newOTLPAttribute("service.name", e.serviceName)}
+	return &otlpRequest{ResourceSpans: []otlpResourceSpans{resourceSpans}}
+}
+
+func newOTLPAttribute(key string, value interface{}) otlpAttribute {
+	attr := otlpAttribute{Key: key}
+	switch v := value.(type) {
+	case bool:
+		attr.Value.BoolValue = &v
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		s := fmt.Sprintf("%d", v)
+		attr.Value.IntValue = &s
+	case float32:
+		f := float64(v)
+		attr.Value.DoubleValue = &f
+	case float64:
+		attr.Value.DoubleValue = &v
+	case string:
+		attr.Value.StringValue = &v
+	default:
+		s := fmt.Sprintf("%v", v)
+		attr.Value.StringValue = &s
+	}
+	return attr
+}
+
+// MultiExporter exports spans with every exporter of it
+type MultiExporter []Exporter
+
+func (exporters MultiExporter) Export(spans []*Span) error {
+	var lastErr error
+	for _, e := range exporters {
+		if err := e.Export(spans); err != nil {
+			lastErr = err
+		}
+	}
+	return lastErr
+}
+
+func (exporters MultiExporter) Close() error {
+	var lastErr error
+	for _, e := range exporters {
+		if err := e.Close(); err != nil {
+			lastErr = err
+		}
+	}
+	return lastErr
+}
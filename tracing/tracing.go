@@ -0,0 +1,214 @@
+// Package tracing records spans of the lifecycle of blocks. Every span of a block belongs to the trace
+// whose ID is the first 32 hex digits of the block hash, so the spans of one block recorded by consensus, syncker
+// and blockchain, on one or many nodes, are shown together by any OpenTelemetry compatible backend.
+// Tracing is disabled, and costs nothing, until an exporter is set by Start.
+//
+// Spans of a shard block:
+//
+//	consensus.propose            creation of the block by its proposer (blsbft)
+//	consensus.validate           validation of the propose block before voting (blsbft)
+//	consensus.vote               from receiving the propose block to voting for it (blsbft)
+//	consensus.collectVotes       from receiving the propose block to committing it with enough votes (blsbft)
+//	shard.waitCrossShard         wait for the cross shard blocks the block needs (blockchain)
+//	syncker.insertFromPool       insertion of a synced block from the block pool (syncker)
+//	shard.insert                 InsertShardBlock, with its phases shard.verifyPreProcessing, shard.verifyBestState,
+//	                             shard.updateBestState, shard.verifyPostProcessing and shard.store (blockchain)
+//	beacon.confirm               from the propose time of the block to its inclusion in a beacon block (blockchain)
+package tracing
+
+import (
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/common"
+)
+
+const (
+	// spanBufferSize is the number of ended spans waiting to be exported, spans are dropped when it is full
+	spanBufferSize = 4096
+	// exportBatchSize is the maximum number of spans exported at once
+	exportBatchSize = 512
+	// exportInterval is the maximum time a span waits to be exported
+	exportInterval = time.Second
+)
+
+// Attribute is a key-value pair describing a span
+type Attribute struct {
+	Key   string
+	Value interface{}
+}
+
+// Attr creates an Attribute, value is exported as a string unless it is a bool, an integer or a float
+func Attr(key string, value interface{}) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// Span is one phase of the lifecycle of a block
+type Span struct {
+	TraceID    [16]byte
+	SpanID     [8]byte
+	Name       string
+	StartTime  time.Time
+	EndTime    time.Time
+	Attributes []Attribute
+	// Error is the error the phase ended with, empty if it succeeded
+	Error string
+}
+
+// TraceIDString returns the hex encoded trace ID
+func (span *Span) TraceIDString() string {
+	return hex.EncodeToString(span.TraceID[:])
+}
+
+// SpanIDString returns the hex encoded span ID
+func (span *Span) SpanIDString() string {
+	return hex.EncodeToString(span.SpanID[:])
+}
+
+// Exporter sends ended spans to a tracing backend
+type Exporter interface {
+	Export(spans []*Span) error
+	Close() error
+}
+
+type tracer struct {
+	exporter Exporter
+	spanCh   chan *Span
+	quit     chan struct{}
+	done     chan struct{}
+}
+
+var (
+	mtx     sync.RWMutex
+	current *tracer
+)
+
+// Start enables tracing, spans are exported by exporter in background until Stop
+func Start(exporter Exporter) {
+	Stop()
+	t := &tracer{
+		exporter: exporter,
+		spanCh:   make(chan *Span, spanBufferSize),
+		quit:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	go t.run()
+	mtx.Lock()
+	current = t
+	mtx.Unlock()
+}
+
+// Stop disables tracing, exports the pending spans and closes the exporter
+func Stop() {
+	mtx.Lock()
+	t := current
+	current = nil
+	mtx.Unlock()
+	if t != nil {
+		close(t.quit)
+		<-t.done
+	}
+}
+
+// Enabled returns true if spans are recorded
+func Enabled() bool {
+	mtx.RLock()
+	defer mtx.RUnlock()
+	return current != nil
+}
+
+// TraceID returns the ID of the trace of a block, the first 32 hex digits of its hash
+func TraceID(blockHash common.Hash) [16]byte {
+	id := [16]byte{}
+	b, _ := hex.DecodeString(blockHash.String()[:32])
+	copy(id[:], b)
+	return id
+}
+
+// StartBlockSpan starts a span of the block with hash blockHash, it returns nil, on which End is a no-op, if tracing is disabled
+func StartBlockSpan(blockHash common.Hash, name string, attributes ...Attribute) *Span {
+	if !Enabled() {
+		return nil
+	}
+	span := &Span{
+		Name:       name,
+		StartTime:  time.Now(),
+		Attributes: append([]Attribute{Attr("block.hash", blockHash.String())}, attributes...),
+	}
+	span.TraceID = TraceID(blockHash)
+	copy(span.SpanID[:], common.RandBytes(8))
+	return span
+}
+
+// End ends the span with the error of its phase, nil if it succeeded, and queues it to be exported
+func (span *Span) End(err error) {
+	if span == nil {
+		return
+	}
+	span.EndTime = time.Now()
+	if err != nil {
+		span.Error = err.Error()
+	}
+	mtx.RLock()
+	t := current
+	mtx.RUnlock()
+	if t == nil {
+		return
+	}
+	select {
+	case t.spanCh <- span:
+	default:
+		Logger.log.Debugf("Drop span %v of trace %v, export buffer is full", span.Name, span.TraceIDString())
+	}
+}
+
+// RecordBlockSpan records a span of the block with hash blockHash whose start is already known, ending now
+func RecordBlockSpan(blockHash common.Hash, name string, start time.Time, err error, attributes ...Attribute) {
+	span := StartBlockSpan(blockHash, name, attributes...)
+	if span == nil {
+		return
+	}
+	span.StartTime = start
+	span.End(err)
+}
+
+func (t *tracer) run() {
+	defer close(t.done)
+	ticker := time.NewTicker(exportInterval)
+	defer ticker.Stop()
+	batch := []*Span{}
+	export := func() {
+		if len(batch) == 0 {
+			return
+		}
+		if err := t.exporter.Export(batch); err != nil {
+			Logger.log.Errorf("Export %v spans error: %v", len(batch), err)
+		}
+		batch = []*Span{}
+	}
+	for {
+		select {
+		case span := <-t.spanCh:
+			batch = append(batch, span)
+			if len(batch) >= exportBatchSize {
+				export()
+			}
+		case <-ticker.C:
+			export()
+		case <-t.quit:
+			for {
+				select {
+				case span := <-t.spanCh:
+					batch = append(batch, span)
+				default:
+					export()
+					if err := t.exporter.Close(); err != nil {
+						Logger.log.Error(err)
+					}
+					return
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,192 @@
+package tracing
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/common"
+)
+
+func init() {
+	Logger.Init(common.NewBackend(nil).Logger("test", true))
+}
+
+type memExporter struct {
+	lock   sync.Mutex
+	spans  []*Span
+	closed bool
+}
+
+func (e *memExporter) Export(spans []*Span) error {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	e.spans = append(e.spans, spans...)
+	return nil
+}
+
+func (e *memExporter) Close() error {
+	e.closed = true
+	return nil
+}
+
+func TestDisabled(t *testing.T) {
+	Stop()
+	if Enabled() {
+		t.Fatal("tracing is enabled")
+	}
+	span := StartBlockSpan(common.HashH([]byte("block")), "shard.insert")
+	if span != nil {
+		t.Fatal("span is recorded while tracing is disabled")
+	}
+	// no-op on nil span
+	span.End(errors.New("error"))
+	RecordBlockSpan(common.HashH([]byte("block")), "shard.insert", time.Now(), nil)
+}
+
+func TestBlockSpans(t *testing.T) {
+	exporter := &memExporter{}
+	Start(exporter)
+	hash := common.HashH([]byte("block"))
+	otherHash := common.HashH([]byte("other block"))
+
+	span := StartBlockSpan(hash, "shard.insert", Attr("shard", 1))
+	span.End(nil)
+	start := time.Now().Add(-time.Second)
+	RecordBlockSpan(hash, "consensus.vote", start, errors.New("timeout"))
+	StartBlockSpan(otherHash, "shard.insert").End(nil)
+	Stop()
+
+	if !exporter.closed {
+		t.Fatal("exporter is not closed by Stop")
+	}
+	if len(exporter.spans) != 3 {
+		t.Fatalf("exported %v spans, want 3", len(exporter.spans))
+	}
+	insert, vote, other := exporter.spans[0], exporter.spans[1], exporter.spans[2]
+	if insert.TraceID != vote.TraceID || insert.TraceID == other.TraceID {
+		t.Error("spans of a block must share a trace, spans of different blocks must not")
+	}
+	if insert.TraceIDString() != hash.String()[:32] {
+		t.Errorf("trace ID %v is not the block hash %v", insert.TraceIDString(), hash.String())
+	}
+	if insert.SpanID == vote.SpanID {
+		t.Error("spans have the same ID")
+	}
+	if len(insert.Attributes) != 2 || insert.Attributes[0].Value != hash.String() || insert.Attributes[1].Key != "shard" {
+		t.Errorf("wrong attributes %+v", insert.Attributes)
+	}
+	if insert.Error != "" || vote.Error != "timeout" {
+		t.Errorf("wrong errors %q %q", insert.Error, vote.Error)
+	}
+	if !vote.StartTime.Equal(start) || vote.EndTime.Sub(vote.StartTime) < time.Second {
+		t.Errorf("wrong time of recorded span %v - %v", vote.StartTime, vote.EndTime)
+	}
+}
+
+func TestFileExporter(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tracing")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "spans.json")
+	exporter, err := NewFileExporter(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	Start(exporter)
+	hash := common.HashH([]byte("block"))
+	StartBlockSpan(hash, "shard.insert", Attr("height", 10)).End(nil)
+	StartBlockSpan(hash, "shard.store").End(errors.New("db error"))
+	Stop()
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	spans := []map[string]interface{}{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		span := map[string]interface{}{}
+		if err := json.Unmarshal(scanner.Bytes(), &span); err != nil {
+			t.Fatal(err)
+		}
+		spans = append(spans, span)
+	}
+	if len(spans) != 2 {
+		t.Fatalf("wrote %v spans, want 2", len(spans))
+	}
+	if spans[0]["name"] != "shard.insert" || spans[0]["traceId"] != hash.String()[:32] ||
+		spans[0]["attributes"].(map[string]interface{})["height"] != float64(10) {
+		t.Errorf("wrong span %v", spans[0])
+	}
+	if spans[1]["error"] != "db error" {
+		t.Errorf("wrong span %v", spans[1])
+	}
+}
+
+func TestOTLPExporter(t *testing.T) {
+	requests := make(chan otlpRequest, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := otlpRequest{}
+		if r.URL.Path != "/v1/traces" || r.Header.Get("Content-Type") != "application/json" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		requests <- req
+	}))
+	defer server.Close()
+
+	hash := common.HashH([]byte("block"))
+	start := time.Unix(100, 5)
+	span := &Span{
+		Name:       "beacon.confirm",
+		StartTime:  start,
+		EndTime:    start.Add(time.Second),
+		Attributes: []Attribute{Attr("shard", byte(2)), Attr("valid", true), Attr("hash", hash)},
+		Error:      "late",
+	}
+	span.TraceID = TraceID(hash)
+	exporter := NewOTLPExporter(server.URL+"/v1/traces", "incognito-test")
+	if err := exporter.Export([]*Span{span}); err != nil {
+		t.Fatal(err)
+	}
+	req := <-requests
+	if len(req.ResourceSpans) != 1 || len(req.ResourceSpans[0].ScopeSpans) != 1 || len(req.ResourceSpans[0].ScopeSpans[0].Spans) != 1 {
+		t.Fatalf("wrong request %+v", req)
+	}
+	if v := req.ResourceSpans[0].Resource.Attributes[0].Value.StringValue; v == nil || *v != "incognito-test" {
+		t.Errorf("wrong service name")
+	}
+	s := req.ResourceSpans[0].ScopeSpans[0].Spans[0]
+	if s.TraceID != hash.String()[:32] || s.SpanID != "0000000000000000" || s.Name != "beacon.confirm" {
+		t.Errorf("wrong span %+v", s)
+	}
+	if s.StartTimeUnixNano != "100000000005" || s.EndTimeUnixNano != "101000000005" {
+		t.Errorf("wrong time %v - %v", s.StartTimeUnixNano, s.EndTimeUnixNano)
+	}
+	if s.Status.Code != otlpStatusError || s.Status.Message != "late" {
+		t.Errorf("wrong status %+v", s.Status)
+	}
+	if *s.Attributes[0].Value.IntValue != "2" || !*s.Attributes[1].Value.BoolValue || *s.Attributes[2].Value.StringValue != hash.String() {
+		t.Errorf("wrong attributes %+v", s.Attributes)
+	}
+
+	if err := NewOTLPExporter(server.URL+"/wrong", "incognito-test").Export([]*Span{span}); err == nil {
+		t.Error("export to a wrong endpoint must fail")
+	}
+}
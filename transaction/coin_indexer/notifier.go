@@ -0,0 +1,323 @@
+package coinIndexer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/common/base58"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
+	"github.com/incognitochain/incognito-chain/incdb"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/privacy"
+	"github.com/incognitochain/incognito-chain/transaction/utils"
+	"github.com/incognitochain/incognito-chain/wallet"
+)
+
+const (
+	DefaultWebhookMaxRetries    = 5
+	DefaultWebhookRetryInterval = 2 * time.Second
+	DefaultWebhookPauseInterval = time.Minute
+	webhookRequestTimeout       = 30 * time.Second
+	webhookBatchSize            = 100
+)
+
+// IncomingCoin is the event emitted when a newly inserted shard block adds an output coin belonging to a managed OTAKey.
+type IncomingCoin struct {
+	PublicKey     string // base58-encoded public spending key of the receiving OTAKey
+	CoinPublicKey string // base58-encoded public key of the output coin
+	TxHash        string
+	TokenID       string
+	ShardID       byte
+	BlockHeight   uint64
+	BlockHash     string
+	IsCrossShard  bool
+}
+
+// BlockOutputCoin is an output coin produced by a shard block, together with the transaction creating it.
+type BlockOutputCoin struct {
+	Coin         privacy.Coin
+	TxHash       common.Hash
+	TokenID      *common.Hash // nil or common.ConfidentialAssetID if the token is hidden by the asset tag
+	IsCrossShard bool
+}
+
+// FindIncomingCoins matches the output coins of a shard block against the managed OTAKeys of the shard and returns
+// an IncomingCoin for each coin that belongs to one of them. Its cost grows with the number of managed OTAKeys times
+// the number of output coins, so it should not be called on the block-insertion path.
+func (ci *CoinIndexer) FindIncomingCoins(outputCoins []BlockOutputCoin, shardID byte, blockHeight uint64, blockHash common.Hash) []*IncomingCoin {
+	keySets := make([]*incognitokey.KeySet, 0)
+	ci.managedOTAKeys.Range(func(k, v interface{}) bool {
+		rawKey, ok := k.([64]byte)
+		if !ok {
+			return true
+		}
+		if status, ok := v.(int); !ok || status == StatusNotSubmitted {
+			return true
+		}
+		otaKey := OTAKeyFromRaw(rawKey)
+		pubKeyBytes := otaKey.GetPublicSpend().ToBytesS()
+		if common.GetShardIDFromLastByte(pubKeyBytes[len(pubKeyBytes)-1]) != shardID {
+			return true
+		}
+		keySets = append(keySets, &incognitokey.KeySet{OTAKey: otaKey})
+		return true
+	})
+	if len(keySets) == 0 {
+		return nil
+	}
+
+	burningPubKey := wallet.GetBurningPublicKey()
+	var rawAssetTags map[string]*common.Hash
+	res := make([]*IncomingCoin, 0)
+	for _, outputCoin := range outputCoins {
+		if outputCoin.Coin == nil || outputCoin.Coin.GetVersion() != 2 {
+			continue
+		}
+		cv2, ok := outputCoin.Coin.(*privacy.CoinV2)
+		if !ok {
+			continue
+		}
+		coinPubKey := cv2.GetPublicKey().ToBytesS()
+		if bytes.Equal(coinPubKey, burningPubKey) {
+			continue
+		}
+
+		for _, keySet := range keySets {
+			if belongs, _ := cv2.DoesCoinBelongToKeySet(keySet); !belongs {
+				continue
+			}
+
+			tokenID := outputCoin.TokenID
+			if cv2.GetAssetTag() == nil {
+				if tokenID == nil {
+					tokenID = &common.PRVCoinID
+				}
+			} else if tokenID == nil || *tokenID == common.ConfidentialAssetID {
+				if rawAssetTags == nil {
+					rawAssetTags = ci.getRawAssetTags()
+				}
+				var err error
+				tokenID, err = cv2.GetTokenId(keySet, rawAssetTags)
+				if err != nil {
+					utils.Logger.Log.Errorf("[CoinIndexer] cannot get tokenID of coin %v: %v", cv2.GetPublicKey().String(), err)
+				}
+			}
+
+			incomingCoin := &IncomingCoin{
+				PublicKey:     base58.Base58Check{}.Encode(keySet.OTAKey.GetPublicSpend().ToBytesS(), common.ZeroByte),
+				CoinPublicKey: base58.Base58Check{}.Encode(coinPubKey, common.ZeroByte),
+				ShardID:       shardID,
+				BlockHeight:   blockHeight,
+				BlockHash:     blockHash.String(),
+				IsCrossShard:  outputCoin.IsCrossShard,
+			}
+			if !outputCoin.TxHash.IsZeroValue() {
+				incomingCoin.TxHash = outputCoin.TxHash.String()
+			}
+			if tokenID != nil {
+				incomingCoin.TokenID = tokenID.String()
+			}
+			res = append(res, incomingCoin)
+			break
+		}
+	}
+
+	return res
+}
+
+// getRawAssetTags returns the map from raw asset tags to the tokenIDs currently known by the cache layer.
+func (ci *CoinIndexer) getRawAssetTags() map[string]*common.Hash {
+	res := make(map[string]*common.Hash)
+	prvID := common.PRVCoinID
+	res[privacy.HashToPoint(prvID[:]).String()] = &prvID
+	for tokenID := range ci.GetAllTokenIDs() {
+		tmpTokenID := tokenID
+		res[privacy.HashToPoint(tmpTokenID[:]).String()] = &tmpTokenID
+	}
+	return res
+}
+
+// HasManagedOTAKeys checks if an OTAKey of the given shard has been submitted to the cache.
+func (ci *CoinIndexer) HasManagedOTAKeys(shardID byte) bool {
+	found := false
+	ci.managedOTAKeys.Range(func(k, v interface{}) bool {
+		rawKey, ok := k.([64]byte)
+		if !ok {
+			return true
+		}
+		if status, ok := v.(int); !ok || status == StatusNotSubmitted {
+			return true
+		}
+		pubKeyBytes := OTAKeyFromRaw(rawKey).GetPublicSpend().ToBytesS()
+		found = common.GetShardIDFromLastByte(pubKeyBytes[len(pubKeyBytes)-1]) == shardID
+		return !found
+	})
+	return found
+}
+
+// SetWebhook sets the webhook to which IncomingCoin events are delivered.
+func (ci *CoinIndexer) SetWebhook(webhook *IncomingCoinWebhook) {
+	ci.mtx.Lock()
+	ci.webhook = webhook
+	ci.mtx.Unlock()
+}
+
+// GetIncomingCoinCursor returns the height of the last block of a shard scanned for incoming coins, and false if
+// the shard has not been scanned yet.
+func (ci *CoinIndexer) GetIncomingCoinCursor(shardID byte) (uint64, bool, error) {
+	return rawdbv2.GetIncomingCoinCursor(ci.db, shardID)
+}
+
+// SaveIncomingCoins persists the IncomingCoin events of a shard block until the webhook delivers them, then moves
+// the cursor of the shard to the block. Blocks after the cursor are scanned again after a restart.
+func (ci *CoinIndexer) SaveIncomingCoins(shardID byte, blockHeight uint64, incomingCoins []*IncomingCoin) error {
+	ci.mtx.RLock()
+	webhook := ci.webhook
+	ci.mtx.RUnlock()
+	if webhook != nil && len(incomingCoins) > 0 {
+		data, err := json.Marshal(incomingCoins)
+		if err != nil {
+			return err
+		}
+		err = rawdbv2.StoreIncomingCoins(ci.db, shardID, blockHeight, data)
+		if err != nil {
+			return err
+		}
+		webhook.wake()
+	}
+	return rawdbv2.StoreIncomingCoinCursor(ci.db, shardID, blockHeight)
+}
+
+// IncomingCoinWebhook delivers the IncomingCoin events persisted in the cache db to an HTTP endpoint, as a JSON array
+// per block in a POST request. Events are delivered in the order of the block heights of each shard, and are deleted
+// only once the endpoint accepts them. A failed delivery is retried up to maxRetries times, doubling the waiting
+// interval after each attempt; after that, the delivery is paused for pauseInterval and started over.
+type IncomingCoinWebhook struct {
+	db            incdb.Database
+	url           string
+	maxRetries    int
+	retryInterval time.Duration
+	pauseInterval time.Duration
+	client        *http.Client
+	wakeChan      chan struct{}
+	quitChan      chan bool
+}
+
+// NewIncomingCoinWebhook creates an IncomingCoinWebhook instance. A non-positive maxRetries falls back to DefaultWebhookMaxRetries.
+func NewIncomingCoinWebhook(db incdb.Database, url string, maxRetries int) *IncomingCoinWebhook {
+	if maxRetries <= 0 {
+		maxRetries = DefaultWebhookMaxRetries
+	}
+	return &IncomingCoinWebhook{
+		db:            db,
+		url:           url,
+		maxRetries:    maxRetries,
+		retryInterval: DefaultWebhookRetryInterval,
+		pauseInterval: DefaultWebhookPauseInterval,
+		client:        &http.Client{Timeout: webhookRequestTimeout},
+		wakeChan:      make(chan struct{}, 1),
+		quitChan:      make(chan bool),
+	}
+}
+
+// wake notifies the webhook that new events have been persisted.
+func (w *IncomingCoinWebhook) wake() {
+	select {
+	case w.wakeChan <- struct{}{}:
+	default:
+	}
+}
+
+// Start delivers the persisted events, including the ones left undelivered before a restart, until Stop is called.
+func (w *IncomingCoinWebhook) Start() {
+	utils.Logger.Log.Infof("[CoinIndexer] Start incoming coin webhook %v", w.url)
+	for {
+		wait := w.pauseInterval
+		numDelivered, err := w.deliverPending()
+		if err != nil {
+			utils.Logger.Log.Errorf("[CoinIndexer] incoming coin webhook paused for %v: %v", wait, err)
+		} else if numDelivered == webhookBatchSize {
+			// more events may be waiting
+			wait = 0
+		}
+		select {
+		case <-w.wakeChan:
+		case <-time.After(wait):
+		case <-w.quitChan:
+			utils.Logger.Log.Infof("[CoinIndexer] Stopped incoming coin webhook")
+			return
+		}
+	}
+}
+
+// Stop terminates the IncomingCoinWebhook.
+func (w *IncomingCoinWebhook) Stop() {
+	close(w.quitChan)
+}
+
+// deliverPending delivers a batch of persisted events and returns the number of delivered blocks. It stops at the
+// first block that cannot be delivered, so that the events of a shard are never delivered out of order.
+func (w *IncomingCoinWebhook) deliverPending() (int, error) {
+	records, err := rawdbv2.GetIncomingCoins(w.db, webhookBatchSize)
+	if err != nil {
+		return 0, err
+	}
+	for i, record := range records {
+		err = w.deliver(record.Data)
+		if err != nil {
+			return i, fmt.Errorf("cannot deliver the incoming coins of shard %v block %v: %v", record.ShardID, record.BlockHeight, err)
+		}
+		err = rawdbv2.DeleteIncomingCoins(w.db, record.ShardID, record.BlockHeight)
+		if err != nil {
+			return i, err
+		}
+	}
+	return len(records), nil
+}
+
+// deliver posts body to the webhook, retrying with an exponential back-off.
+func (w *IncomingCoinWebhook) deliver(body []byte) error {
+	var err error
+	interval := w.retryInterval
+	for attempt := 0; ; attempt++ {
+		err = w.post(body)
+		if err == nil {
+			return nil
+		}
+		if attempt >= w.maxRetries {
+			return fmt.Errorf("webhook failed after %v attempt(s): %v", attempt+1, err)
+		}
+		utils.Logger.Log.Warnf("[CoinIndexer] webhook attempt %v failed: %v, retry in %v", attempt+1, err, interval)
+		select {
+		case <-time.After(interval):
+		case <-w.quitChan:
+			return fmt.Errorf("webhook stopped: %v", err)
+		}
+		interval *= 2
+	}
+}
+
+func (w *IncomingCoinWebhook) post(body []byte) error {
+	ctx, cancel := context.WithTimeout(context.Background(), webhookRequestTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewBuffer(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("unexpected status code %v", resp.StatusCode)
+	}
+	return nil
+}
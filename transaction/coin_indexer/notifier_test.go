@@ -0,0 +1,255 @@
+package coinIndexer
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
+	"github.com/incognitochain/incognito-chain/incdb"
+	_ "github.com/incognitochain/incognito-chain/incdb/lvdb"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/privacy"
+	"github.com/incognitochain/incognito-chain/privacy/key"
+	"github.com/incognitochain/incognito-chain/transaction/utils"
+	"github.com/incognitochain/incognito-chain/wallet"
+)
+
+func init() {
+	utils.Logger.Init(common.NewBackend(os.Stderr).Logger("test", true))
+}
+
+func newTestKeySet(t *testing.T, shardID byte) *incognitokey.KeySet {
+	for {
+		keySet := &incognitokey.KeySet{}
+		if err := keySet.InitFromPrivateKeyByte(common.RandBytes(32)); err != nil {
+			t.Fatal(err)
+		}
+		pk := keySet.PaymentAddress.Pk
+		if common.GetShardIDFromLastByte(pk[len(pk)-1]) == shardID {
+			return keySet
+		}
+	}
+}
+
+func TestCoinIndexer_FindIncomingCoins(t *testing.T) {
+	common.MaxShardNumber = 8
+	wallet.InitPublicKeyBurningAddressByte()
+	shardID := byte(3)
+
+	receiver := newTestKeySet(t, shardID)
+	other := newTestKeySet(t, shardID)
+	unsubmitted := newTestKeySet(t, shardID)
+
+	ci := &CoinIndexer{
+		mtx:            new(sync.RWMutex),
+		managedOTAKeys: &sync.Map{},
+		allTokens:      make(map[common.Hash]interface{}),
+	}
+	ci.managedOTAKeys.Store(OTAKeyToRaw(receiver.OTAKey), StatusKeySubmittedUsual)
+	ci.managedOTAKeys.Store(OTAKeyToRaw(other.OTAKey), StatusIndexingFinished)
+	ci.managedOTAKeys.Store(OTAKeyToRaw(unsubmitted.OTAKey), StatusNotSubmitted)
+
+	tokenID := common.HashH([]byte("token"))
+	ci.AddTokenID(tokenID)
+
+	newCoin := func(keySet *incognitokey.KeySet, tokenID *common.Hash) privacy.Coin {
+		paymentInfo := key.InitPaymentInfo(keySet.PaymentAddress, 100, nil)
+		if tokenID == nil {
+			c, err := privacy.NewCoinFromPaymentInfo(privacy.NewCoinParams().FromPaymentInfo(paymentInfo))
+			if err != nil {
+				t.Fatal(err)
+			}
+			return c
+		}
+		c, _, err := privacy.NewCoinCA(privacy.NewCoinParams().FromPaymentInfo(paymentInfo), tokenID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return c
+	}
+
+	prvTxHash := common.HashH([]byte("prv tx"))
+	tokenTxHash := common.HashH([]byte("token tx"))
+	confidentialAssetID := common.ConfidentialAssetID
+	outputCoins := []BlockOutputCoin{
+		{Coin: newCoin(receiver, nil), TxHash: prvTxHash},
+		{Coin: newCoin(other, &tokenID), TxHash: tokenTxHash, TokenID: &confidentialAssetID},
+		{Coin: newCoin(unsubmitted, nil), TxHash: prvTxHash},
+		{Coin: newCoin(receiver, nil), IsCrossShard: true},
+	}
+
+	blockHash := common.HashH([]byte("block"))
+	res := ci.FindIncomingCoins(outputCoins, shardID, 10, blockHash)
+	if len(res) != 3 {
+		t.Fatalf("expected 3 incoming coins, got %v", len(res))
+	}
+
+	if res[0].TxHash != prvTxHash.String() || res[0].TokenID != common.PRVCoinID.String() || res[0].IsCrossShard {
+		t.Errorf("unexpected PRV incoming coin %+v", res[0])
+	}
+	if res[1].TxHash != tokenTxHash.String() || res[1].TokenID != tokenID.String() {
+		t.Errorf("unexpected token incoming coin %+v", res[1])
+	}
+	if res[2].TxHash != "" || !res[2].IsCrossShard {
+		t.Errorf("unexpected cross-shard incoming coin %+v", res[2])
+	}
+	for _, incomingCoin := range res {
+		if incomingCoin.ShardID != shardID || incomingCoin.BlockHeight != 10 || incomingCoin.BlockHash != blockHash.String() {
+			t.Errorf("unexpected block info %+v", incomingCoin)
+		}
+	}
+
+	if res := ci.FindIncomingCoins(outputCoins, shardID+1, 10, blockHash); len(res) != 0 {
+		t.Errorf("expected no incoming coin from another shard, got %v", len(res))
+	}
+}
+
+func newTestWebhookDB(t *testing.T) incdb.Database {
+	dbPath, err := ioutil.TempDir(os.TempDir(), "test_incoming_coin_webhook")
+	if err != nil {
+		t.Fatal(err)
+	}
+	db, err := incdb.Open("leveldb", dbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+func TestCoinIndexer_SaveIncomingCoins(t *testing.T) {
+	db := newTestWebhookDB(t)
+	ci := &CoinIndexer{mtx: new(sync.RWMutex), db: db}
+
+	if _, ok, err := ci.GetIncomingCoinCursor(1); err != nil || ok {
+		t.Fatalf("expected no cursor, got %v, %v", ok, err)
+	}
+	// without a webhook, only the cursor moves
+	if err := ci.SaveIncomingCoins(1, 10, []*IncomingCoin{{TxHash: "abc"}}); err != nil {
+		t.Fatal(err)
+	}
+	if height, ok, err := ci.GetIncomingCoinCursor(1); err != nil || !ok || height != 10 {
+		t.Fatalf("expected cursor 10, got %v, %v, %v", height, ok, err)
+	}
+	if records, _ := rawdbv2.GetIncomingCoins(db, webhookBatchSize); len(records) != 0 {
+		t.Fatalf("expected no persisted event, got %v", len(records))
+	}
+
+	ci.SetWebhook(NewIncomingCoinWebhook(db, "http://localhost", 1))
+	if err := ci.SaveIncomingCoins(1, 12, []*IncomingCoin{{TxHash: "def"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := ci.SaveIncomingCoins(0, 11, []*IncomingCoin{{TxHash: "abc"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := ci.SaveIncomingCoins(1, 13, nil); err != nil {
+		t.Fatal(err)
+	}
+	records, err := rawdbv2.GetIncomingCoins(db, webhookBatchSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 2 || records[0].ShardID != 0 || records[0].BlockHeight != 11 || records[1].ShardID != 1 || records[1].BlockHeight != 12 {
+		t.Fatalf("unexpected persisted events %+v", records)
+	}
+	if height, _, _ := ci.GetIncomingCoinCursor(1); height != 13 {
+		t.Fatalf("expected cursor 13, got %v", height)
+	}
+}
+
+func TestIncomingCoinWebhook_Retry(t *testing.T) {
+	db := newTestWebhookDB(t)
+	var numCalls int32
+	received := make(chan []*IncomingCoin, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&numCalls, 1) < 3 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		var incomingCoins []*IncomingCoin
+		if err := json.NewDecoder(r.Body).Decode(&incomingCoins); err != nil {
+			t.Error(err)
+		}
+		received <- incomingCoins
+	}))
+	defer server.Close()
+
+	webhook := NewIncomingCoinWebhook(db, server.URL, 3)
+	webhook.retryInterval = time.Millisecond
+	ci := &CoinIndexer{mtx: new(sync.RWMutex), db: db}
+	ci.SetWebhook(webhook)
+	go webhook.Start()
+	defer webhook.Stop()
+
+	if err := ci.SaveIncomingCoins(1, 5, []*IncomingCoin{{TxHash: "abc", TokenID: common.PRVIDStr, ShardID: 1, BlockHeight: 5}}); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case incomingCoins := <-received:
+		if len(incomingCoins) != 1 || incomingCoins[0].TxHash != "abc" || incomingCoins[0].BlockHeight != 5 {
+			t.Errorf("unexpected payload %+v", incomingCoins)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("webhook was not delivered")
+	}
+	if n := atomic.LoadInt32(&numCalls); n != 3 {
+		t.Errorf("expected 3 attempts, got %v", n)
+	}
+}
+
+func TestIncomingCoinWebhook_KeepUndelivered(t *testing.T) {
+	db := newTestWebhookDB(t)
+	failing := int32(1)
+	var delivered []string
+	var deliveredLock sync.Mutex
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.LoadInt32(&failing) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		var incomingCoins []*IncomingCoin
+		if err := json.NewDecoder(r.Body).Decode(&incomingCoins); err != nil {
+			t.Error(err)
+		}
+		deliveredLock.Lock()
+		delivered = append(delivered, incomingCoins[0].TxHash)
+		deliveredLock.Unlock()
+	}))
+	defer server.Close()
+
+	for i, txHash := range []string{"a", "b", "c"} {
+		data, _ := json.Marshal([]*IncomingCoin{{TxHash: txHash}})
+		if err := rawdbv2.StoreIncomingCoins(db, 2, uint64(i+1), data); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	webhook := NewIncomingCoinWebhook(db, server.URL, 2)
+	webhook.retryInterval = time.Millisecond
+	if n, err := webhook.deliverPending(); err == nil || n != 0 {
+		t.Fatalf("expected the delivery to fail, got %v, %v", n, err)
+	}
+	if records, _ := rawdbv2.GetIncomingCoins(db, webhookBatchSize); len(records) != 3 {
+		t.Fatalf("expected the events to be kept, got %v", len(records))
+	}
+
+	// a new webhook, e.g. after a restart, replays the undelivered events in order
+	atomic.StoreInt32(&failing, 0)
+	webhook = NewIncomingCoinWebhook(db, server.URL, 2)
+	if n, err := webhook.deliverPending(); err != nil || n != 3 {
+		t.Fatalf("expected 3 delivered blocks, got %v, %v", n, err)
+	}
+	if len(delivered) != 3 || delivered[0] != "a" || delivered[1] != "b" || delivered[2] != "c" {
+		t.Fatalf("unexpected delivery order %v", delivered)
+	}
+	if records, _ := rawdbv2.GetIncomingCoins(db, webhookBatchSize); len(records) != 0 {
+		t.Fatalf("expected the delivered events to be deleted, got %v", len(records))
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
 	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
 	"github.com/incognitochain/incognito-chain/incdb"
+	"github.com/incognitochain/incognito-chain/metrics"
 	"github.com/incognitochain/incognito-chain/privacy"
 )
 
@@ -40,6 +41,8 @@ type CoinIndexer struct {
 
 	managedOTAKeys *sync.Map
 	IdxChan        chan *IndexParam
+
+	webhook *IncomingCoinWebhook
 }
 
 // The following constants indicate the state of an OTAKey.
@@ -808,6 +811,13 @@ func (ci *CoinIndexer) Start(cfg *IndexerInitialConfig) {
 	ci.statusChan = make(chan JobStatus, 10*ci.numWorkers)
 	ci.quitChan = make(chan bool)
 
+	// export the number of OTA keys waiting to be indexed
+	metrics.GetOrRegister("coinindexer/queue", metrics.NewFunctionalGauge(func() int64 {
+		ci.mtx.RLock()
+		defer ci.mtx.RUnlock()
+		return int64(ci.queueSize)
+	}))
+
 	for shardID := 0; shardID < common.MaxShardNumber; shardID++ {
 		for _, idxParam := range ci.idxQueue[byte(shardID)] {
 			idxParam.ToHeight = cfg.BestBlocks[shardID]
@@ -21,18 +21,18 @@ func TestCoinIndexer_getIdxParamsForIndexing(t *testing.T) {
 		numWorkers := common.RandInt()%10 + 1
 		fmt.Printf("totalNumWorkers: %v\n", numWorkers)
 		ci := CoinIndexer{numWorkers: numWorkers}
-		ci.IdxChan = make(chan IndexParam, scaleFactor*ci.numWorkers)
+		ci.IdxChan = make(chan *IndexParam, scaleFactor*ci.numWorkers)
 		ci.statusChan = make(chan JobStatus, scaleFactor*ci.numWorkers)
 		ci.quitChan = make(chan bool)
-		ci.idxQueue = make(map[byte][]IndexParam)
+		ci.idxQueue = make(map[byte][]*IndexParam)
 		ci.queueSize = 0
 		common.MaxShardNumber = common.RandInt()%7 + 1
 		fmt.Printf("#shards: %v\n", common.MaxShardNumber)
 		for shardID := 0; shardID < common.MaxShardNumber; shardID++ {
-			tmpIdxParams := make([]IndexParam, 0)
+			tmpIdxParams := make([]*IndexParam, 0)
 			r := common.RandInt() % (scaleFactor * numWorkers)
 			for j := 0; j < r; j++ {
-				tmpIdxParams = append(tmpIdxParams, IndexParam{})
+				tmpIdxParams = append(tmpIdxParams, &IndexParam{})
 			}
 
 			ci.idxQueue[byte(shardID)] = tmpIdxParams
@@ -31,6 +31,10 @@ type EstimateTxSizeParam = tx_generic.EstimateTxSizeParam
 type TxConvertVer1ToVer2InitParams = tx_ver2.TxConvertVer1ToVer2InitParams
 type TxTokenConvertVer1ToVer2InitParams = tx_ver2.TxTokenConvertVer1ToVer2InitParams
 type TxPrivacyInitParams = tx_generic.TxPrivacyInitParams
+type UnsignedTx = tx_ver2.UnsignedTx
+type UnsignedTransfer = tx_ver2.UnsignedTransfer
+type UnsignedTokenTransfer = tx_ver2.UnsignedTokenTransfer
+type RingMember = tx_ver2.RingMember
 
 func NewRandomCommitmentsProcessParam(usableInputCoins []privacy.PlainCoin, randNum int, stateDB *statedb.StateDB, shardID byte, tokenID *common.Hash) *tx_generic.RandomCommitmentsProcessParam {
 	return tx_generic.NewRandomCommitmentsProcessParam(usableInputCoins, randNum, stateDB, shardID, tokenID)
@@ -107,3 +111,13 @@ func NewTxPrivacyInitParams(senderSK *privacy.PrivateKey,
 func GetTxVersionFromCoins(coins []privacy.PlainCoin) (int8, error) {
 	return tx_generic.GetTxVersionFromCoins(coins)
 }
+
+// NewUnsignedTx builds a PRV transfer from the read-only keys of the sender, see tx_ver2.NewUnsignedTx
+func NewUnsignedTx(params *TxPrivacyInitParams, senderAddress privacy.PaymentAddress) (*UnsignedTx, error) {
+	return tx_ver2.NewUnsignedTx(params, senderAddress)
+}
+
+// NewUnsignedTxToken builds a pToken transfer from the read-only keys of the sender, see tx_ver2.NewUnsignedTxToken
+func NewUnsignedTxToken(params *TxTokenParams, senderAddress privacy.PaymentAddress) (*UnsignedTx, error) {
+	return tx_ver2.NewUnsignedTxToken(params, senderAddress)
+}
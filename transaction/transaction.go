@@ -251,6 +251,17 @@ func NewTransactionTokenFromJsonBytes(data []byte) (tx_generic.TransactionToken,
 	return nil, fmt.Errorf("cannot parse TX as token transaction")
 }
 
+// SignUnsignedTxJson parses an unsigned transaction built by a node from the read-only keys of the sender
+// and signs it with the private key of the sender. It does not need any access to the chain,
+// the signed transaction is submitted with the same RPCs as the raw transactions.
+func SignUnsignedTxJson(data []byte, senderSK *privacy.PrivateKey) (metadata.Transaction, error) {
+	unsignedTx := new(UnsignedTx)
+	if err := json.Unmarshal(data, unsignedTx); err != nil {
+		return nil, err
+	}
+	return unsignedTx.Sign(senderSK)
+}
+
 // TxChoice is a helper struct for parsing transactions of all types from JSON.
 // After parsing succeeds, one of its fields will have the TX object; others will be nil.
 // This can be used to assert the transaction type.
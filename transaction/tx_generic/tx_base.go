@@ -92,7 +92,8 @@ func GetTxInfo(paramInfo []byte) ([]byte, error) {
 	return paramInfo, nil
 }
 
-func updateParamsWhenOverBalance(params *TxPrivacyInitParams, senderPaymentAddree privacy.PaymentAddress) error {
+// UpdateParamsWhenOverBalance pays the sender back the part of the inputs which is not spent on the outputs and the fee
+func UpdateParamsWhenOverBalance(params *TxPrivacyInitParams, senderPaymentAddree privacy.PaymentAddress) error {
 	// Calculate sum of all output coins' value
 	sumOutputValue := uint64(0)
 	for _, p := range params.PaymentInfo {
@@ -175,7 +176,7 @@ func (tx *TxBase) InitializeTxAndParams(params *TxPrivacyInitParams) error {
 	}
 
 	// Params: update balance if overbalance
-	return updateParamsWhenOverBalance(params, senderKeySet.PaymentAddress)
+	return UpdateParamsWhenOverBalance(params, senderKeySet.PaymentAddress)
 }
 
 // =================== PARSING JSON FUNCTIONS ===================
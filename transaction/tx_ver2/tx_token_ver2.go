@@ -354,7 +354,7 @@ func (tx *Tx) provePRV(params *tx_generic.TxPrivacyInitParams) ([]privacy.PlainC
 		return nil, nil, err
 	}
 
-	// inputCoins is plainCoin because it may have coinV1 with coinV2
+	// inputCoins are the decrypted coins v2 of the sender, transactions ver2 only spend coins v1 in conversions
 	inputCoins := params.InputCoins
 
 	tx.Proof, err = privacy.ProveV2(inputCoins, outputCoins, nil, false, params.PaymentInfo)
@@ -0,0 +1,407 @@
+package tx_ver2
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/privacy"
+	"github.com/incognitochain/incognito-chain/transaction/tx_generic"
+	"github.com/incognitochain/incognito-chain/transaction/utils"
+)
+
+// UnsignedTransfer holds the coins spent by a transfer and where they go.
+// Ring has privacy.RingSize rows of coins with their indexes : the row Pi holds the coins spent,
+// the other rows hold the decoys. PaymentInfo already includes the change of the sender.
+type UnsignedTransfer struct {
+	Ring        [][]*RingMember
+	Pi          int
+	PaymentInfo []*privacy.PaymentInfo
+}
+
+// UnsignedTokenTransfer is the token part of an unsigned pToken transaction
+type UnsignedTokenTransfer struct {
+	UnsignedTransfer
+	PropertyID     common.Hash
+	PropertyName   string
+	PropertySymbol string
+	Type           int
+	Mintable       bool
+}
+
+// UnsignedTx is a transaction ver2 built by a node which only knows the read-only and OTA keys of the sender.
+// It holds everything needed to create the proofs and the signatures, so an offline signer
+// with the private key produces the transaction without any access to the chain.
+//
+// PRV pays the fee and the PRV receivers; Token is only set for a pToken transfer.
+type UnsignedTx struct {
+	Version              int8
+	Type                 string
+	LockTime             int64
+	Fee                  uint64
+	Info                 []byte
+	PubKeyLastByteSender byte
+	Metadata             metadata.Metadata
+	PRV                  *UnsignedTransfer
+	Token                *UnsignedTokenTransfer `json:",omitempty"`
+}
+
+func (u *UnsignedTx) UnmarshalJSON(data []byte) error {
+	type Alias UnsignedTx
+	temp := &struct {
+		Metadata *json.RawMessage
+		*Alias
+	}{
+		Alias: (*Alias)(u),
+	}
+	if err := json.Unmarshal(data, temp); err != nil {
+		return utils.NewTransactionErr(utils.UnexpectedError, err)
+	}
+	u.Metadata = nil
+	if temp.Metadata != nil {
+		meta, err := metadata.ParseMetadata(temp.Metadata)
+		if err != nil {
+			return err
+		}
+		u.Metadata = meta
+	}
+	return nil
+}
+
+// newUnsignedTransfer adds the change of the sender to the outputs and reads the decoys
+// of the ring from the database, where the coins of the token are indexed under dbTokenID
+func newUnsignedTransfer(params *tx_generic.TxPrivacyInitParams, senderAddress privacy.PaymentAddress, dbTokenID common.Hash, shardID byte) (*UnsignedTransfer, error) {
+	if err := tx_generic.ValidateTxParams(params); err != nil {
+		return nil, err
+	}
+	if len(params.InputCoins) == 0 {
+		return nil, utils.NewTransactionErr(utils.WrongInputError, fmt.Errorf("an unsigned transaction must spend some coins"))
+	}
+	if version, err := tx_generic.GetTxVersionFromCoins(params.InputCoins); err != nil || version != utils.TxVersion2Number {
+		return nil, utils.NewTransactionErr(utils.WrongInputError, fmt.Errorf("an unsigned transaction can only spend coins v2"))
+	}
+	if err := tx_generic.UpdateParamsWhenOverBalance(params, senderAddress); err != nil {
+		return nil, err
+	}
+
+	piBig, err := common.RandBigIntMaxRange(big.NewInt(int64(privacy.RingSize)))
+	if err != nil {
+		return nil, err
+	}
+	pi := int(piBig.Int64())
+	members, err := pickMlsagRing(params.InputCoins, params.StateDB, dbTokenID, pi, shardID, privacy.RingSize)
+	if err != nil {
+		utils.Logger.Log.Errorf("Cannot pick the ring of an unsigned transaction: %v", err)
+		return nil, err
+	}
+	return &UnsignedTransfer{
+		Ring:        members,
+		Pi:          pi,
+		PaymentInfo: params.PaymentInfo,
+	}, nil
+}
+
+// NewUnsignedTx builds a PRV transfer to be signed offline. params.SenderSK is not used,
+// the change goes back to senderAddress.
+//
+// A node which does not hold the private key cannot compute the key images of the input coins,
+// so it is up to the caller to pick coins which are not spent yet.
+func NewUnsignedTx(params *tx_generic.TxPrivacyInitParams, senderAddress privacy.PaymentAddress) (*UnsignedTx, error) {
+	if len(senderAddress.Pk) == 0 {
+		return nil, utils.NewTransactionErr(utils.PrivateKeySenderInvalidError, fmt.Errorf("payment address of the sender is empty"))
+	}
+	if params.TokenID != nil && *params.TokenID != common.PRVCoinID {
+		return nil, utils.NewTransactionErr(utils.TokenIDInvalidError, fmt.Errorf("a transaction ver2 only transfers PRV"), params.TokenID.String())
+	}
+	info, err := tx_generic.GetTxInfo(params.Info)
+	if err != nil {
+		return nil, err
+	}
+	shardID := common.GetShardIDFromLastByte(senderAddress.Pk[len(senderAddress.Pk)-1])
+	transfer, err := newUnsignedTransfer(params, senderAddress, common.PRVCoinID, shardID)
+	if err != nil {
+		return nil, err
+	}
+	return &UnsignedTx{
+		Version:              utils.TxVersion2Number,
+		Type:                 common.TxNormalType,
+		LockTime:             time.Now().Unix(),
+		Fee:                  params.Fee,
+		Info:                 info,
+		PubKeyLastByteSender: shardID,
+		Metadata:             params.MetaData,
+		PRV:                  transfer,
+	}, nil
+}
+
+// NewUnsignedTxToken builds a pToken transfer to be signed offline, the fee is paid in PRV.
+// params.SenderKey is not used, the change of both PRV and the token goes back to senderAddress.
+func NewUnsignedTxToken(params *tx_generic.TxTokenParams, senderAddress privacy.PaymentAddress) (*UnsignedTx, error) {
+	if len(senderAddress.Pk) == 0 {
+		return nil, utils.NewTransactionErr(utils.PrivateKeySenderInvalidError, fmt.Errorf("payment address of the sender is empty"))
+	}
+	if params.TokenParams == nil {
+		return nil, utils.NewTransactionErr(utils.UnexpectedError, fmt.Errorf("token params are empty"))
+	}
+	if params.TokenParams.Fee > 0 || params.FeeNativeCoin == 0 {
+		utils.Logger.Log.Errorf("only accept tx fee in PRV")
+		return nil, utils.NewTransactionErr(utils.PrivacyTokenInitFeeParamsError, nil, strconv.Itoa(int(params.TokenParams.Fee)))
+	}
+	if params.TokenParams.TokenTxType != utils.CustomTokenTransfer {
+		return nil, utils.NewTransactionErr(utils.PrivacyTokenTxTypeNotHandleError, fmt.Errorf("an unsigned transaction can only transfer a token"))
+	}
+	propertyID, err := common.TokenStringToHash(params.TokenParams.PropertyID)
+	if err != nil {
+		return nil, utils.NewTransactionErr(utils.TokenIDInvalidError, err, params.TokenParams.PropertyID)
+	}
+	info, err := tx_generic.GetTxInfo(params.Info)
+	if err != nil {
+		return nil, err
+	}
+	shardID := common.GetShardIDFromLastByte(senderAddress.Pk[len(senderAddress.Pk)-1])
+
+	prvParams := tx_generic.NewTxPrivacyInitParams(
+		nil,
+		params.PaymentInfo,
+		params.InputCoin,
+		params.FeeNativeCoin,
+		params.HasPrivacyCoin,
+		params.TransactionStateDB,
+		nil,
+		params.MetaData,
+		info,
+	)
+	prvTransfer, err := newUnsignedTransfer(prvParams, senderAddress, common.PRVCoinID, shardID)
+	if err != nil {
+		return nil, utils.NewTransactionErr(utils.PrivacyTokenInitPRVError, err)
+	}
+	tokenParams := tx_generic.NewTxPrivacyInitParams(
+		nil,
+		params.TokenParams.Receiver,
+		params.TokenParams.TokenInput,
+		0,
+		params.HasPrivacyToken,
+		params.TransactionStateDB,
+		propertyID,
+		nil,
+		nil,
+	)
+	tokenTransfer, err := newUnsignedTransfer(tokenParams, senderAddress, common.ConfidentialAssetID, shardID)
+	if err != nil {
+		return nil, utils.NewTransactionErr(utils.PrivacyTokenInitTokenDataError, err)
+	}
+	return &UnsignedTx{
+		Version:              utils.TxVersion2Number,
+		Type:                 common.TxCustomTokenPrivacyType,
+		LockTime:             time.Now().Unix(),
+		Fee:                  params.FeeNativeCoin,
+		Info:                 info,
+		PubKeyLastByteSender: shardID,
+		Metadata:             params.MetaData,
+		PRV:                  prvTransfer,
+		Token: &UnsignedTokenTransfer{
+			UnsignedTransfer: *tokenTransfer,
+			PropertyID:       *propertyID,
+			PropertyName:     params.TokenParams.PropertyName,
+			PropertySymbol:   params.TokenParams.PropertySymbol,
+			Type:             params.TokenParams.TokenTxType,
+			Mintable:         params.TokenParams.Mintable,
+		},
+	}, nil
+}
+
+// inputCoins decrypts the coins spent by a transfer, which must belong to the sender,
+// and checks that they exactly pay for the outputs and the fee
+func (t *UnsignedTransfer) inputCoins(keySet *incognitokey.KeySet, fee uint64) ([]privacy.PlainCoin, error) {
+	if t.Pi < 0 || t.Pi >= len(t.Ring) {
+		return nil, fmt.Errorf("ring row %d of the input coins is out of range", t.Pi)
+	}
+	sumInputValue := uint64(0)
+	inputCoins := make([]privacy.PlainCoin, 0, len(t.Ring[t.Pi]))
+	for _, member := range t.Ring[t.Pi] {
+		if member == nil || member.Coin == nil {
+			return nil, fmt.Errorf("ring has an empty member")
+		}
+		if belongs, _ := member.Coin.DoesCoinBelongToKeySet(keySet); !belongs {
+			return nil, fmt.Errorf("input coin %s does not belong to the sender", member.Coin.GetPublicKey().MarshalText())
+		}
+		inputCoin, err := member.Coin.Decrypt(keySet)
+		if err != nil {
+			return nil, err
+		}
+		sumInputValue += inputCoin.GetValue()
+		inputCoins = append(inputCoins, inputCoin)
+	}
+	sumOutputValue := fee
+	for _, p := range t.PaymentInfo {
+		sumOutputValue += p.Amount
+	}
+	if sumInputValue != sumOutputValue {
+		return nil, fmt.Errorf("inputs must pay exactly for the outputs and the fee: sumInputValue=%d sumOutputValue=%d fee=%d", sumInputValue, sumOutputValue-fee, fee)
+	}
+	return inputCoins, nil
+}
+
+// Sign creates the proofs and the signatures of the transaction with the private key of the sender.
+// It needs no access to the chain, so the one-time addresses of the outputs are not checked
+// against the chain : a collision is negligible and makes the node reject the transaction.
+func (u *UnsignedTx) Sign(senderSK *privacy.PrivateKey) (metadata.Transaction, error) {
+	var senderKeySet incognitokey.KeySet
+	if err := senderKeySet.InitFromPrivateKey(senderSK); err != nil {
+		utils.Logger.Log.Errorf("Cannot parse Private Key. Err %v", err)
+		return nil, utils.NewTransactionErr(utils.PrivateKeySenderInvalidError, err)
+	}
+	pk := senderKeySet.PaymentAddress.Pk
+	if common.GetShardIDFromLastByte(pk[len(pk)-1]) != u.PubKeyLastByteSender {
+		return nil, utils.NewTransactionErr(utils.PrivateKeySenderInvalidError, fmt.Errorf("private key is not in the shard of the sender"))
+	}
+	if u.Version != utils.TxVersion2Number {
+		return nil, utils.NewTransactionErr(utils.UnexpectedError, fmt.Errorf("cannot sign an unsigned transaction of version %d", u.Version))
+	}
+	if u.PRV == nil {
+		return nil, utils.NewTransactionErr(utils.UnexpectedError, fmt.Errorf("unsigned transaction does not pay the fee"))
+	}
+
+	switch u.Type {
+	case common.TxNormalType:
+		if u.Token != nil {
+			return nil, utils.NewTransactionErr(utils.UnexpectedError, fmt.Errorf("a transaction of type %s cannot transfer a token", u.Type))
+		}
+		return u.signTx(senderSK, &senderKeySet)
+	case common.TxCustomTokenPrivacyType:
+		if u.Token == nil {
+			return nil, utils.NewTransactionErr(utils.UnexpectedError, fmt.Errorf("a transaction of type %s must transfer a token", u.Type))
+		}
+		return u.signTxToken(senderSK, &senderKeySet)
+	default:
+		return nil, utils.NewTransactionErr(utils.UnexpectedError, fmt.Errorf("cannot sign a transaction of type %s", u.Type))
+	}
+}
+
+// initPRV initializes the PRV transaction from its unsigned data, like Init does before proving
+func (u *UnsignedTx) initPRV(senderSK *privacy.PrivateKey, senderKeySet *incognitokey.KeySet) (*Tx, *tx_generic.TxPrivacyInitParams, error) {
+	inputCoins, err := u.PRV.inputCoins(senderKeySet, u.Fee)
+	if err != nil {
+		return nil, nil, utils.NewTransactionErr(utils.WrongInputError, err)
+	}
+	params := tx_generic.NewTxPrivacyInitParams(
+		senderSK,
+		u.PRV.PaymentInfo,
+		inputCoins,
+		u.Fee,
+		true,
+		nil, // no db : the ring is already chosen
+		nil,
+		u.Metadata,
+		u.Info,
+	)
+	if err := tx_generic.ValidateTxParams(params); err != nil {
+		return nil, nil, err
+	}
+	tx := new(Tx)
+	tx.LockTime = u.LockTime
+	if err := tx.InitializeTxAndParams(params); err != nil {
+		return nil, nil, err
+	}
+	return tx, params, nil
+}
+
+func (u *UnsignedTx) signTx(senderSK *privacy.PrivateKey, senderKeySet *incognitokey.KeySet) (*Tx, error) {
+	tx, params, err := u.initPRV(senderSK, senderKeySet)
+	if err != nil {
+		return nil, err
+	}
+	inps, outs, err := tx.provePRV(params)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.signOnRing(inps, outs, params, tx.Hash()[:], u.PRV.Ring, u.PRV.Pi); err != nil {
+		return nil, err
+	}
+	txSize := tx.GetTxActualSize()
+	if txSize > common.MaxTxSize {
+		return nil, utils.NewTransactionErr(utils.ExceedSizeTx, nil, strconv.Itoa(int(txSize)))
+	}
+	return tx, nil
+}
+
+func (u *UnsignedTx) signTxToken(senderSK *privacy.PrivateKey, senderKeySet *incognitokey.KeySet) (*TxToken, error) {
+	// Init PRV Fee
+	tx, txPrivacyParams, err := u.initPRV(senderSK, senderKeySet)
+	if err != nil {
+		return nil, err
+	}
+	tx.SetType(common.TxCustomTokenPrivacyType)
+	inps, outs, err := tx.provePRV(txPrivacyParams)
+	if err != nil {
+		return nil, utils.NewTransactionErr(utils.PrivacyTokenInitPRVError, err)
+	}
+
+	// Init, prove and sign(CA) Token
+	tokenInputs, err := u.Token.inputCoins(senderKeySet, 0)
+	if err != nil {
+		return nil, utils.NewTransactionErr(utils.PrivacyTokenInitTokenDataError, err)
+	}
+	tokenID := u.Token.PropertyID
+	tokenParams := tx_generic.NewTxPrivacyInitParams(
+		senderSK,
+		u.Token.PaymentInfo,
+		tokenInputs,
+		0,
+		true,
+		nil,
+		&tokenID,
+		nil,
+		u.Info, // the token sub-transaction is rebuilt from the fee transaction, Info included
+	)
+	txn := makeTxToken(tx, nil, nil, nil)
+	if err := txn.InitializeTxAndParams(tokenParams); err != nil {
+		return nil, utils.NewTransactionErr(utils.PrivacyTokenInitTokenDataError, err)
+	}
+	txn.SetType(common.TxCustomTokenPrivacyType)
+	tokenInps, tokenOuts, sharedSecrets, isBurning, err := txn.proveCAWithoutSig(tokenParams)
+	if err != nil {
+		return nil, utils.NewTransactionErr(utils.PrivacyTokenInitTokenDataError, err)
+	}
+	if err := txn.signCAOnRing(tokenInps, tokenOuts, sharedSecrets, tokenParams, txn.Hash()[:], u.Token.Ring, u.Token.Pi); err != nil {
+		return nil, utils.NewTransactionErr(utils.PrivacyTokenInitTokenDataError, err)
+	}
+
+	txToken := new(TxToken)
+	txToken.TokenData.Type = u.Token.Type
+	txToken.TokenData.PropertyName = u.Token.PropertyName
+	txToken.TokenData.PropertySymbol = u.Token.PropertySymbol
+	txToken.TokenData.Mintable = u.Token.Mintable
+	if isBurning {
+		// show plain tokenID if this is a burning TX
+		txToken.TokenData.PropertyID = tokenID
+	} else {
+		// tokenID is already hidden in asset tags in coin, here we use the umbrella ID
+		txToken.TokenData.PropertyID = common.ConfidentialAssetID
+	}
+	if err := txToken.SetTxNormal(txn); err != nil {
+		return nil, err
+	}
+
+	tdh, err := txToken.TokenData.Hash()
+	if err != nil {
+		return nil, err
+	}
+	message := common.HashH(append(tx.Hash()[:], tdh[:]...))
+	if err := tx.signOnRing(inps, outs, txPrivacyParams, message[:], u.PRV.Ring, u.PRV.Pi); err != nil {
+		return nil, err
+	}
+	if err := txToken.SetTxBase(tx); err != nil {
+		return nil, err
+	}
+	txSize := txToken.GetTxActualSize()
+	if txSize > common.MaxTxSize {
+		return nil, utils.NewTransactionErr(utils.ExceedSizeTx, nil, strconv.Itoa(int(txSize)))
+	}
+	return txToken, nil
+}
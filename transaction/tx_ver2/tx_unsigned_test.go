@@ -0,0 +1,185 @@
+package tx_ver2
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/privacy"
+	"github.com/incognitochain/incognito-chain/privacy/coin"
+	"github.com/incognitochain/incognito-chain/privacy/key"
+	"github.com/incognitochain/incognito-chain/transaction/tx_generic"
+	"github.com/incognitochain/incognito-chain/transaction/utils"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// readOnlyKeySet keeps the keys a node needs to build an unsigned transaction, without the private key
+func readOnlyKeySet(keySet *incognitokey.KeySet) *incognitokey.KeySet {
+	return &incognitokey.KeySet{
+		PaymentAddress: keySet.PaymentAddress,
+		ReadonlyKey:    keySet.ReadonlyKey,
+		OTAKey:         keySet.OTAKey,
+	}
+}
+
+func createAndStoreCoins(paymentInfo []*privacy.PaymentInfo, keySets []*incognitokey.KeySet, count int, tokenID *common.Hash) []privacy.Coin {
+	pastCoins := make([]privacy.Coin, count)
+	for i := range pastCoins {
+		var tempCoin *coin.CoinV2
+		var err error
+		if tokenID == nil {
+			tempCoin, err = coin.NewCoinFromPaymentInfo(privacy.NewCoinParams().FromPaymentInfo(paymentInfo[i%len(paymentInfo)]))
+		} else {
+			tempCoin, _, err = privacy.NewCoinCA(privacy.NewCoinParams().FromPaymentInfo(paymentInfo[i%len(paymentInfo)]), tokenID)
+		}
+		So(err, ShouldBeNil)
+		tempCoin.ConcealOutputCoin(keySets[i%len(paymentInfo)].PaymentAddress.GetPublicView())
+		So(tempCoin.IsEncrypted(), ShouldBeTrue)
+		pastCoins[i] = tempCoin
+	}
+	dbTokenID := common.PRVCoinID
+	if tokenID != nil {
+		dbTokenID = common.ConfidentialAssetID
+	}
+	So(storeCoins(dummyDB, pastCoins, 0, dbTokenID), ShouldBeNil)
+	return pastCoins
+}
+
+func decryptCoins(coins []privacy.Coin, keySet *incognitokey.KeySet) []privacy.PlainCoin {
+	res := make([]privacy.PlainCoin, len(coins))
+	for i, c := range coins {
+		var err error
+		res[i], err = c.Decrypt(keySet)
+		So(err, ShouldBeNil)
+	}
+	return res
+}
+
+func TestUnsignedTx(t *testing.T) {
+	var dummyPrivateKeys []*privacy.PrivateKey
+	var keySets []*incognitokey.KeySet
+	var paymentInfo []*privacy.PaymentInfo
+	var pastCoins, pastTokenCoins []privacy.Coin
+	var viewKeySet *incognitokey.KeySet
+	tokenID := &common.Hash{57}
+
+	Convey("Unsigned Tx Test", t, func() {
+		Convey("prepare keys & UTXOs", func() {
+			dummyPrivateKeys, keySets, paymentInfo = preparePaymentKeys(3)
+			viewKeySet = readOnlyKeySet(keySets[0])
+			pastCoins = createAndStoreCoins(paymentInfo, keySets, 30, nil)
+			err := statedb.StorePrivacyToken(dummyDB, *tokenID, "Unsigned", "UNS", statedb.InitToken, false, uint64(100000), []byte{}, common.Hash{67})
+			So(err, ShouldBeNil)
+			pastTokenCoins = createAndStoreCoins(paymentInfo, keySets, 30, tokenID)
+		})
+
+		Convey("build PRV transfer with read-only key, sign offline and verify", func() {
+			inputCoins := decryptCoins([]privacy.Coin{pastCoins[0], pastCoins[3]}, viewKeySet)
+			paymentInfoOut := []*privacy.PaymentInfo{key.InitPaymentInfo(keySets[1].PaymentAddress, 5000, []byte("unsigned out"))}
+			params := tx_generic.NewTxPrivacyInitParams(nil, paymentInfoOut, inputCoins, 100, hasPrivacyForPRV, dummyDB, nil, nil, []byte("unsigned"))
+			unsignedTx, err := NewUnsignedTx(params, viewKeySet.PaymentAddress)
+			So(err, ShouldBeNil)
+			// the change goes back to the sender
+			So(len(unsignedTx.PRV.PaymentInfo), ShouldEqual, 2)
+			So(len(unsignedTx.PRV.Ring), ShouldEqual, privacy.RingSize)
+
+			// the unsigned transaction travels to the signer as JSON
+			unsignedJson, err := json.Marshal(unsignedTx)
+			So(err, ShouldBeNil)
+			received := new(UnsignedTx)
+			So(json.Unmarshal(unsignedJson, received), ShouldBeNil)
+
+			// only the sender can sign
+			_, err = received.Sign(dummyPrivateKeys[1])
+			So(err, ShouldNotBeNil)
+
+			signed, err := received.Sign(dummyPrivateKeys[0])
+			So(err, ShouldBeNil)
+			tx, ok := signed.(*Tx)
+			So(ok, ShouldBeTrue)
+			So(tx.GetTxFee(), ShouldEqual, 100)
+			tx, err = tx.startVerifyTx(dummyDB)
+			So(err, ShouldBeNil)
+			isValid, err := tx.ValidateSanityData(nil, nil, nil, 0)
+			So(err, ShouldBeNil)
+			So(isValid, ShouldBeTrue)
+			boolParams := map[string]bool{"hasPrivacy": hasPrivacyForPRV, "isNewTransaction": true}
+			isValid, err = tx.ValidateTxByItself(boolParams, dummyDB, nil, nil, shardID, nil, nil)
+			So(err, ShouldBeNil)
+			So(isValid, ShouldBeTrue)
+			So(tx.ValidateTxWithBlockChain(nil, nil, nil, shardID, dummyDB), ShouldBeNil)
+		})
+
+		Convey("reject unsigned PRV transfer with tampered data", func() {
+			inputCoins := decryptCoins([]privacy.Coin{pastCoins[6]}, viewKeySet)
+			paymentInfoOut := []*privacy.PaymentInfo{key.InitPaymentInfo(keySets[1].PaymentAddress, 1000, []byte{})}
+			params := tx_generic.NewTxPrivacyInitParams(nil, paymentInfoOut, inputCoins, 100, hasPrivacyForPRV, dummyDB, nil, nil, nil)
+			unsignedTx, err := NewUnsignedTx(params, viewKeySet.PaymentAddress)
+			So(err, ShouldBeNil)
+
+			// the outputs no longer match the inputs
+			unsignedTx.PRV.PaymentInfo[0].Amount++
+			_, err = unsignedTx.Sign(dummyPrivateKeys[0])
+			So(err, ShouldNotBeNil)
+			unsignedTx.PRV.PaymentInfo[0].Amount--
+
+			// a coin of someone else is put in the row of the inputs
+			savedMember := unsignedTx.PRV.Ring[unsignedTx.PRV.Pi][0]
+			unsignedTx.PRV.Ring[unsignedTx.PRV.Pi][0] = &RingMember{Index: savedMember.Index, Coin: pastCoins[1].(*coin.CoinV2)}
+			_, err = unsignedTx.Sign(dummyPrivateKeys[0])
+			So(err, ShouldNotBeNil)
+			unsignedTx.PRV.Ring[unsignedTx.PRV.Pi][0] = savedMember
+
+			// a ring row is missing
+			savedRing := unsignedTx.PRV.Ring
+			unsignedTx.PRV.Ring = savedRing[:len(savedRing)-1]
+			if unsignedTx.PRV.Pi == len(savedRing)-1 {
+				unsignedTx.PRV.Pi--
+				unsignedTx.PRV.Ring[unsignedTx.PRV.Pi] = savedRing[len(savedRing)-1]
+			}
+			_, err = unsignedTx.Sign(dummyPrivateKeys[0])
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("build token transfer with read-only key, sign offline and verify", func() {
+			prvInputs := decryptCoins([]privacy.Coin{pastCoins[9]}, viewKeySet)
+			tokenInputs := decryptCoins([]privacy.Coin{pastTokenCoins[0], pastTokenCoins[3]}, viewKeySet)
+			tokenParam := &tx_generic.TokenParam{
+				PropertyID:     tokenID.String(),
+				PropertyName:   "Unsigned",
+				PropertySymbol: "UNS",
+				Amount:         69,
+				TokenTxType:    utils.CustomTokenTransfer,
+				Receiver:       []*privacy.PaymentInfo{{PaymentAddress: keySets[2].PaymentAddress, Amount: 69, Message: []byte("unsigned token")}},
+				TokenInput:     tokenInputs,
+			}
+			params := tx_generic.NewTxTokenParams(nil, []*privacy.PaymentInfo{}, prvInputs, 15, tokenParam, dummyDB, nil,
+				hasPrivacyForPRV, hasPrivacyForToken, shardID, []byte("unsigned token"), dummyDB)
+			unsignedTx, err := NewUnsignedTxToken(params, viewKeySet.PaymentAddress)
+			So(err, ShouldBeNil)
+			So(len(unsignedTx.Token.PaymentInfo), ShouldEqual, 2)
+
+			unsignedJson, err := json.Marshal(unsignedTx)
+			So(err, ShouldBeNil)
+			received := new(UnsignedTx)
+			So(json.Unmarshal(unsignedJson, received), ShouldBeNil)
+
+			signed, err := received.Sign(dummyPrivateKeys[0])
+			So(err, ShouldBeNil)
+			txToken, ok := signed.(*TxToken)
+			So(ok, ShouldBeTrue)
+			txToken, err = txToken.startVerifyTx(dummyDB)
+			So(err, ShouldBeNil)
+			isValid, err := txToken.ValidateSanityData(nil, nil, nil, 0)
+			So(err, ShouldBeNil)
+			So(isValid, ShouldBeTrue)
+			boolParams := map[string]bool{"hasPrivacy": hasPrivacyForToken}
+			isValid, err = txToken.ValidateTxByItself(boolParams, dummyDB, nil, nil, shardID, nil, nil)
+			So(err, ShouldBeNil)
+			So(isValid, ShouldBeTrue)
+			So(txToken.ValidateTxWithBlockChain(nil, nil, nil, shardID, dummyDB), ShouldBeNil)
+		})
+	})
+}
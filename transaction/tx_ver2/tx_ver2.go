@@ -12,7 +12,6 @@ import (
 
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
-	"github.com/incognitochain/incognito-chain/incognitokey"
 	"github.com/incognitochain/incognito-chain/metadata"
 	"github.com/incognitochain/incognito-chain/privacy"
 	errhandler "github.com/incognitochain/incognito-chain/privacy/errorhandler"
@@ -189,11 +188,26 @@ func (tx *Tx) signOnMessage(inp []privacy.PlainCoin, out []*privacy.CoinV2, para
 	}
 	var pi int = int(piBig.Int64())
 	shardID := common.GetShardIDFromLastByte(tx.PubKeyLastByteSender)
-	ring, indexes, commitmentToZero, err := generateMlsagRingWithIndexes(inp, out, params, pi, shardID, ringSize)
+	members, err := pickMlsagRing(inp, params.StateDB, *params.TokenID, pi, shardID, ringSize)
 	if err != nil {
 		utils.Logger.Log.Errorf("generateMlsagRingWithIndexes got error %v ", err)
 		return err
 	}
+	return tx.signOnRing(inp, out, params, hashedMessage, members, pi)
+}
+
+// signOnRing signs with a ring whose coins were already chosen, the input coins being in the row pi
+func (tx *Tx) signOnRing(inp []privacy.PlainCoin, out []*privacy.CoinV2, params *tx_generic.TxPrivacyInitParams, hashedMessage []byte, members [][]*RingMember, pi int) error {
+	if tx.Sig != nil {
+		return utils.NewTransactionErr(utils.UnexpectedError, fmt.Errorf("input transaction must be an unsigned one"))
+	}
+	if err := checkRingShape(members, pi, privacy.RingSize, len(inp)); err != nil {
+		return utils.NewTransactionErr(utils.SignTxError, err)
+	}
+	ring, indexes, commitmentToZero, err := buildMlsagRing(members, out, params.Fee, pi)
+	if err != nil {
+		return err
+	}
 
 	// Set SigPubKey
 	txSigPubKey := new(SigPubKey)
@@ -232,82 +246,63 @@ func (tx *Tx) signOnMessage(inp []privacy.PlainCoin, out []*privacy.CoinV2, para
 }
 
 func (tx *Tx) prove(params *tx_generic.TxPrivacyInitParams) error {
-	var senderKeySet incognitokey.KeySet
-	_ = senderKeySet.InitFromPrivateKey(params.SenderSK)
-	b := senderKeySet.PaymentAddress.Pk[len(senderKeySet.PaymentAddress.Pk)-1]
-	outputCoins, err := utils.NewCoinV2ArrayFromPaymentInfoArray(params.PaymentInfo, int(common.GetShardIDFromLastByte(b)), params.TokenID, params.StateDB)
-	if err != nil {
-		utils.Logger.Log.Errorf("Cannot parse outputCoinV2 to outputCoins, error %v ", err)
-		return err
-	}
-
-	// inputCoins is plainCoin because it may have coinV1 with coinV2
-	inputCoins := params.InputCoins
-
-	tx.Proof, err = privacy.ProveV2(inputCoins, outputCoins, nil, false, params.PaymentInfo)
+	inputCoins, outputCoins, err := tx.provePRV(params)
 	if err != nil {
-		utils.Logger.Log.Errorf("Error in privacy_v2.Prove, error %v ", err)
 		return err
 	}
-
-	if tx.GetMetadata() != nil {
-		if err := tx.GetMetadata().Sign(params.SenderSK, tx); err != nil {
-			utils.Logger.Log.Error("Cannot signOnMessage txMetadata in shouldSignMetadata")
-			return err
-		}
-	}
-
 	err = tx.signOnMessage(inputCoins, outputCoins, params, tx.Hash()[:])
 	return err
 }
 
 // ========== NORMAL VERIFY FUNCTIONS ==========
 
-func generateMlsagRingWithIndexes(inputCoins []privacy.PlainCoin, outputCoins []*privacy.CoinV2, params *tx_generic.TxPrivacyInitParams, pi int, shardID byte, ringSize int) (*mlsag.Ring, [][]*big.Int, *privacy.Point, error) {
-	lenOTA, err := statedb.GetOTACoinLength(params.StateDB, *params.TokenID, shardID)
+// RingMember is a coin of the chain in the ring of a signature, along with the index of its one-time address
+type RingMember struct {
+	Index *big.Int
+	Coin  *privacy.CoinV2
+}
+
+// pickMlsagRing reads the coins of a ring from the database : the row pi holds the input coins
+// and every other row holds random coins of the same token, burnt coins excluded
+func pickMlsagRing(inputCoins []privacy.PlainCoin, db *statedb.StateDB, tokenID common.Hash, pi int, shardID byte, ringSize int) ([][]*RingMember, error) {
+	lenOTA, err := statedb.GetOTACoinLength(db, tokenID, shardID)
 	if err != nil || lenOTA == nil {
 		utils.Logger.Log.Errorf("Getting length of commitment error, either database length ota is empty or has error, error = %v", err)
-		return nil, nil, nil, err
-	}
-	outputCoinsAsGeneric := make([]privacy.Coin, len(outputCoins))
-	for i := 0; i < len(outputCoins); i++ {
-		outputCoinsAsGeneric[i] = outputCoins[i]
+		return nil, err
 	}
-	sumOutputsWithFee := tx_generic.CalculateSumOutputsWithFee(outputCoinsAsGeneric, params.Fee)
-	indexes := make([][]*big.Int, ringSize)
-	ring := make([][]*privacy.Point, ringSize)
-	var commitmentToZero *privacy.Point
+	members := make([][]*RingMember, ringSize)
 	attempts := 0
 	for i := 0; i < ringSize; i++ {
-		sumInputs := new(privacy.Point).Identity()
-		sumInputs.Sub(sumInputs, sumOutputsWithFee)
-
-		row := make([]*privacy.Point, len(inputCoins))
-		rowIndexes := make([]*big.Int, len(inputCoins))
+		row := make([]*RingMember, len(inputCoins))
 		if i == pi {
 			for j := 0; j < len(inputCoins); j++ {
-				row[j] = inputCoins[j].GetPublicKey()
-				publicKeyBytes := inputCoins[j].GetPublicKey().ToBytesS()
-				if rowIndexes[j], err = statedb.GetOTACoinIndex(params.StateDB, *params.TokenID, publicKeyBytes); err != nil {
+				inputCoin_specific, ok := inputCoins[j].(*privacy.CoinV2)
+				if !ok {
+					return nil, fmt.Errorf("cannot cast a coin as v2")
+				}
+				publicKeyBytes := inputCoin_specific.GetPublicKey().ToBytesS()
+				index, err := statedb.GetOTACoinIndex(db, tokenID, publicKeyBytes)
+				if err != nil {
 					utils.Logger.Log.Errorf("Getting commitment index error %v ", err)
-					return nil, nil, nil, err
+					return nil, err
 				}
-				sumInputs.Add(sumInputs, inputCoins[j].GetCommitment())
+				row[j] = &RingMember{Index: index, Coin: inputCoin_specific}
 			}
 		} else {
 			for j := 0; j < len(inputCoins); j++ {
+				var index *big.Int
 				coinDB := new(privacy.CoinV2)
 				for attempts < privacy.MaxPrivacyAttempts { // The chance of infinite loop is negligible
-					rowIndexes[j], _ = common.RandBigIntMaxRange(lenOTA)
-					coinBytes, err := statedb.GetOTACoinByIndex(params.StateDB, *params.TokenID, rowIndexes[j].Uint64(), shardID)
+					index, _ = common.RandBigIntMaxRange(lenOTA)
+					coinBytes, err := statedb.GetOTACoinByIndex(db, tokenID, index.Uint64(), shardID)
 					if err != nil {
 						utils.Logger.Log.Errorf("Get coinv2 by index error %v ", err)
-						return nil, nil, nil, err
+						return nil, err
 					}
 
 					if err = coinDB.SetBytes(coinBytes); err != nil {
 						utils.Logger.Log.Errorf("Cannot parse coinv2 byte error %v ", err)
-						return nil, nil, nil, err
+						return nil, err
 					}
 
 					// we do not use burned coins since they will reduce the privacy level of the transaction.
@@ -317,13 +312,59 @@ func generateMlsagRingWithIndexes(inputCoins []privacy.PlainCoin, outputCoins []
 					attempts++
 				}
 				if attempts == privacy.MaxPrivacyAttempts {
-					return nil, nil, nil, fmt.Errorf("cannot form decoys")
+					return nil, fmt.Errorf("cannot form decoys")
 				}
+				row[j] = &RingMember{Index: index, Coin: coinDB}
+			}
+		}
+		members[i] = row
+	}
+	return members, nil
+}
 
-				row[j] = coinDB.GetPublicKey()
-				sumInputs.Add(sumInputs, coinDB.GetCommitment())
+// checkRingShape makes sure a ring has ringSize rows of numInputs coins, with the row pi in it
+func checkRingShape(members [][]*RingMember, pi int, ringSize int, numInputs int) error {
+	if len(members) != ringSize {
+		return fmt.Errorf("ring has %d rows, expect %d", len(members), ringSize)
+	}
+	if pi < 0 || pi >= ringSize {
+		return fmt.Errorf("ring row %d of the input coins is out of range", pi)
+	}
+	for _, row := range members {
+		if len(row) != numInputs {
+			return fmt.Errorf("ring row has %d coins, expect %d", len(row), numInputs)
+		}
+		for _, member := range row {
+			if member == nil || member.Index == nil || member.Coin == nil {
+				return fmt.Errorf("ring has an empty member")
 			}
 		}
+	}
+	return nil
+}
+
+// buildMlsagRing computes the ring of a signature from its coins : every row ends with the sum
+// of its commitments minus the outputs and the fee, which commits to zero in the row pi
+func buildMlsagRing(members [][]*RingMember, outputCoins []*privacy.CoinV2, fee uint64, pi int) (*mlsag.Ring, [][]*big.Int, *privacy.Point, error) {
+	outputCoinsAsGeneric := make([]privacy.Coin, len(outputCoins))
+	for i := 0; i < len(outputCoins); i++ {
+		outputCoinsAsGeneric[i] = outputCoins[i]
+	}
+	sumOutputsWithFee := tx_generic.CalculateSumOutputsWithFee(outputCoinsAsGeneric, fee)
+	indexes := make([][]*big.Int, len(members))
+	ring := make([][]*privacy.Point, len(members))
+	var commitmentToZero *privacy.Point
+	for i, rowMembers := range members {
+		sumInputs := new(privacy.Point).Identity()
+		sumInputs.Sub(sumInputs, sumOutputsWithFee)
+
+		row := make([]*privacy.Point, len(rowMembers))
+		rowIndexes := make([]*big.Int, len(rowMembers))
+		for j, member := range rowMembers {
+			row[j] = member.Coin.GetPublicKey()
+			rowIndexes[j] = member.Index
+			sumInputs.Add(sumInputs, member.Coin.GetCommitment())
+		}
 		row = append(row, sumInputs)
 		if i == pi {
 			commitmentToZero = sumInputs
@@ -334,6 +375,14 @@ func generateMlsagRingWithIndexes(inputCoins []privacy.PlainCoin, outputCoins []
 	return mlsag.NewRing(ring), indexes, commitmentToZero, nil
 }
 
+func generateMlsagRingWithIndexes(inputCoins []privacy.PlainCoin, outputCoins []*privacy.CoinV2, params *tx_generic.TxPrivacyInitParams, pi int, shardID byte, ringSize int) (*mlsag.Ring, [][]*big.Int, *privacy.Point, error) {
+	members, err := pickMlsagRing(inputCoins, params.StateDB, *params.TokenID, pi, shardID, ringSize)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return buildMlsagRing(members, outputCoins, params.Fee, pi)
+}
+
 func getMLSAGSigFromTxSigAndKeyImages(txSig []byte, keyImages []*privacy.Point) (*mlsag.Sig, error) {
 	mlsagSig, err := new(mlsag.Sig).FromBytes(txSig)
 	if err != nil {
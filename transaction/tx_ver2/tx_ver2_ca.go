@@ -2,7 +2,6 @@ package tx_ver2
 
 import (
 	"fmt"
-	"github.com/incognitochain/incognito-chain/wallet"
 	"math/big"
 
 	"github.com/incognitochain/incognito-chain/common"
@@ -128,19 +127,15 @@ func createPrivKeyMlsagCA(inputCoins []privacy.PlainCoin, outputCoins []*privacy
 	return privKeyMlsag, nil
 }
 
-func generateMlsagRingWithIndexesCA(inputCoins []privacy.PlainCoin, outputCoins []*privacy.CoinV2, params *tx_generic.TxPrivacyInitParams, pi int, shardID byte, ringSize int) (*mlsag.Ring, [][]*big.Int, []*privacy.Point, error) {
-
-	lenOTA, err := statedb.GetOTACoinLength(params.StateDB, common.ConfidentialAssetID, shardID)
-	if err != nil || lenOTA == nil {
-		utils.Logger.Log.Errorf("Getting length of commitment error, either database length ota is empty or has error, error = %v", err)
-		return nil, nil, nil, err
-	}
+// buildMlsagRingCA computes the ring of a confidential asset signature from its coins : every row ends with
+// the sum of its asset tags minus the ones of the outputs, then with the sum of its commitments minus the outputs and the fee
+func buildMlsagRingCA(members [][]*RingMember, outputCoins []*privacy.CoinV2, fee uint64, pi int) (*mlsag.Ring, [][]*big.Int, []*privacy.Point, error) {
 	outputCoinsAsGeneric := make([]privacy.Coin, len(outputCoins))
 	for i := 0; i < len(outputCoins); i++ {
 		outputCoinsAsGeneric[i] = outputCoins[i]
 	}
-	sumOutputsWithFee := tx_generic.CalculateSumOutputsWithFee(outputCoinsAsGeneric, params.Fee)
-	inCount := new(privacy.Scalar).FromUint64(uint64(len(inputCoins)))
+	sumOutputsWithFee := tx_generic.CalculateSumOutputsWithFee(outputCoinsAsGeneric, fee)
+	inCount := new(privacy.Scalar).FromUint64(uint64(len(members[pi])))
 	outCount := new(privacy.Scalar).FromUint64(uint64(len(outputCoins)))
 
 	sumOutputAssetTags := new(privacy.Point).Identity()
@@ -154,74 +149,26 @@ func generateMlsagRingWithIndexesCA(inputCoins []privacy.PlainCoin, outputCoins
 	}
 	sumOutputAssetTags.ScalarMult(sumOutputAssetTags, inCount)
 
-	indexes := make([][]*big.Int, ringSize)
-	ring := make([][]*privacy.Point, ringSize)
+	indexes := make([][]*big.Int, len(members))
+	ring := make([][]*privacy.Point, len(members))
 	var lastTwoColumnsCommitmentToZero []*privacy.Point
-	attempts := 0
-	for i := 0; i < ringSize; i++ {
+	for i, rowMembers := range members {
 		sumInputs := new(privacy.Point).Identity()
 		sumInputs.Sub(sumInputs, sumOutputsWithFee)
 		sumInputAssetTags := new(privacy.Point).Identity()
 
-		row := make([]*privacy.Point, len(inputCoins))
-		rowIndexes := make([]*big.Int, len(inputCoins))
-		if i == pi {
-			for j := 0; j < len(inputCoins); j++ {
-				row[j] = inputCoins[j].GetPublicKey()
-				publicKeyBytes := inputCoins[j].GetPublicKey().ToBytesS()
-				if rowIndexes[j], err = statedb.GetOTACoinIndex(params.StateDB, common.ConfidentialAssetID, publicKeyBytes); err != nil {
-					utils.Logger.Log.Errorf("Getting commitment index error %v ", err)
-					return nil, nil, nil, err
-				}
-				sumInputs.Add(sumInputs, inputCoins[j].GetCommitment())
-				inputCoin_specific, ok := inputCoins[j].(*privacy.CoinV2)
-				if !ok {
-					return nil, nil, nil, fmt.Errorf("cannot cast a coin as v2")
-				}
-				if inputCoin_specific.GetAssetTag() == nil {
-					utils.Logger.Log.Errorf("CA error: missing asset tag for signing in input coin - %v", inputCoin_specific.Bytes())
-					err := utils.NewTransactionErr(utils.SignTxError, fmt.Errorf("cannot sign CA token : an input coin does not have asset tag"))
-					return nil, nil, nil, err
-				}
-				sumInputAssetTags.Add(sumInputAssetTags, inputCoin_specific.GetAssetTag())
-			}
-		} else {
-			for j := 0; j < len(inputCoins); j++ {
-				coinDB := new(privacy.CoinV2)
-				for attempts < privacy.MaxPrivacyAttempts { // The chance of infinite loop is negligible
-					rowIndexes[j], _ = common.RandBigIntMaxRange(lenOTA)
-					coinBytes, err := statedb.GetOTACoinByIndex(params.StateDB, common.ConfidentialAssetID, rowIndexes[j].Uint64(), shardID)
-					if err != nil {
-						utils.Logger.Log.Errorf("Get coinv2 by index error %v ", err)
-						return nil, nil, nil, err
-					}
-
-					if err = coinDB.SetBytes(coinBytes); err != nil {
-						utils.Logger.Log.Errorf("Cannot parse coinv2 byte error %v ", err)
-						return nil, nil, nil, err
-					}
-
-					if coinDB.GetAssetTag() == nil {
-						utils.Logger.Log.Errorf("CA error: missing asset tag for signing in DB coin - %v", coinBytes)
-						err := utils.NewTransactionErr(utils.SignTxError, fmt.Errorf("cannot sign CA token : a CA coin in DB does not have asset tag"))
-						return nil, nil, nil, err
-					}
-
-					// we do not use burned coins since they will reduce the privacy level of the transaction.
-					if !wallet.IsPublicKeyBurningAddress(coinDB.GetPublicKey().ToBytesS()) {
-						break
-					}
-					attempts++
-				}
-
-				if attempts == privacy.MaxPrivacyAttempts {
-					return nil, nil, nil, fmt.Errorf("cannot form decoys CA")
-				}
-
-				row[j] = coinDB.GetPublicKey()
-				sumInputs.Add(sumInputs, coinDB.GetCommitment())
-				sumInputAssetTags.Add(sumInputAssetTags, coinDB.GetAssetTag())
+		row := make([]*privacy.Point, len(rowMembers))
+		rowIndexes := make([]*big.Int, len(rowMembers))
+		for j, member := range rowMembers {
+			if member.Coin.GetAssetTag() == nil {
+				utils.Logger.Log.Errorf("CA error: missing asset tag for signing in ring coin - %v", member.Coin.Bytes())
+				err := utils.NewTransactionErr(utils.SignTxError, fmt.Errorf("cannot sign CA token : a coin in the ring does not have asset tag"))
+				return nil, nil, nil, err
 			}
+			row[j] = member.Coin.GetPublicKey()
+			rowIndexes[j] = member.Index
+			sumInputs.Add(sumInputs, member.Coin.GetCommitment())
+			sumInputAssetTags.Add(sumInputAssetTags, member.Coin.GetAssetTag())
 		}
 		sumInputAssetTags.ScalarMult(sumInputAssetTags, outCount)
 
@@ -239,7 +186,16 @@ func generateMlsagRingWithIndexesCA(inputCoins []privacy.PlainCoin, outputCoins
 	return mlsag.NewRing(ring), indexes, lastTwoColumnsCommitmentToZero, nil
 }
 
-func (tx *Tx) proveCA(params *tx_generic.TxPrivacyInitParams) (bool, error) {
+func generateMlsagRingWithIndexesCA(inputCoins []privacy.PlainCoin, outputCoins []*privacy.CoinV2, params *tx_generic.TxPrivacyInitParams, pi int, shardID byte, ringSize int) (*mlsag.Ring, [][]*big.Int, []*privacy.Point, error) {
+	members, err := pickMlsagRing(inputCoins, params.StateDB, common.ConfidentialAssetID, pi, shardID, ringSize)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return buildMlsagRingCA(members, outputCoins, params.Fee, pi)
+}
+
+// proveCAWithoutSig creates the output coins of a token transfer and proves it, the signature is left to the caller
+func (tx *Tx) proveCAWithoutSig(params *tx_generic.TxPrivacyInitParams) ([]privacy.PlainCoin, []*privacy.CoinV2, []*privacy.Point, bool, error) {
 	var err error
 	var outputCoins []*privacy.CoinV2
 	var sharedSecrets []*privacy.Point
@@ -252,7 +208,7 @@ func (tx *Tx) proveCA(params *tx_generic.TxPrivacyInitParams) (bool, error) {
 		c, ss, err := createUniqueOTACoinCA(inf, int(common.GetShardIDFromLastByte(b)), params.TokenID, params.StateDB)
 		if err != nil {
 			utils.Logger.Log.Errorf("Cannot parse outputCoinV2 to outputCoins, error %v ", err)
-			return false, err
+			return nil, nil, nil, false, err
 		}
 		// the only way err!=nil but ss==nil is a coin meant for burning address
 		if ss == nil {
@@ -265,18 +221,25 @@ func (tx *Tx) proveCA(params *tx_generic.TxPrivacyInitParams) (bool, error) {
 	// first, reject the invalid case. After this, isBurning will correctly determine if TX is burning
 	if numOfCoinsBurned > 1 {
 		utils.Logger.Log.Errorf("Cannot burn multiple coins")
-		return false, utils.NewTransactionErr(utils.UnexpectedError, fmt.Errorf("output must not have more than 1 burned coin"))
+		return nil, nil, nil, false, utils.NewTransactionErr(utils.UnexpectedError, fmt.Errorf("output must not have more than 1 burned coin"))
 	}
 	// outputCoins, err := newCoinV2ArrayFromPaymentInfoArray(params.PaymentInfo, params.TokenID, params.StateDB)
 
-	// inputCoins is plainCoin because it may have coinV1 with coinV2
+	// inputCoins are the decrypted coins v2 of the sender, transactions ver2 only spend coins v1 in conversions
 	inputCoins := params.InputCoins
 	tx.Proof, err = privacy.ProveV2(inputCoins, outputCoins, sharedSecrets, true, params.PaymentInfo)
 	if err != nil {
 		utils.Logger.Log.Errorf("Error in privacy_v2.Prove, error %v ", err)
-		return false, err
+		return nil, nil, nil, false, err
 	}
+	return inputCoins, outputCoins, sharedSecrets, isBurning, nil
+}
 
+func (tx *Tx) proveCA(params *tx_generic.TxPrivacyInitParams) (bool, error) {
+	inputCoins, outputCoins, sharedSecrets, isBurning, err := tx.proveCAWithoutSig(params)
+	if err != nil {
+		return false, err
+	}
 	err = tx.signCA(inputCoins, outputCoins, sharedSecrets, params, tx.Hash()[:])
 	return isBurning, err
 }
@@ -294,7 +257,24 @@ func (tx *Tx) signCA(inp []privacy.PlainCoin, out []*privacy.CoinV2, outputShare
 	}
 	var pi int = int(piBig.Int64())
 	shardID := common.GetShardIDFromLastByte(tx.PubKeyLastByteSender)
-	ring, indexes, commitmentsToZero, err := generateMlsagRingWithIndexesCA(inp, out, params, pi, shardID, ringSize)
+	members, err := pickMlsagRing(inp, params.StateDB, common.ConfidentialAssetID, pi, shardID, ringSize)
+	if err != nil {
+		utils.Logger.Log.Errorf("generateMlsagRingWithIndexes got error %v ", err)
+		return err
+	}
+	return tx.signCAOnRing(inp, out, outputSharedSecrets, params, hashedMessage, members, pi)
+}
+
+// signCAOnRing signs a token transfer with a ring whose coins were already chosen, the input coins being in the row pi
+func (tx *Tx) signCAOnRing(inp []privacy.PlainCoin, out []*privacy.CoinV2, outputSharedSecrets []*privacy.Point, params *tx_generic.TxPrivacyInitParams, hashedMessage []byte, members [][]*RingMember, pi int) error {
+	if tx.Sig != nil {
+		return utils.NewTransactionErr(utils.UnexpectedError, fmt.Errorf("input transaction must be an unsigned one"))
+	}
+	if err := checkRingShape(members, pi, privacy.RingSize, len(inp)); err != nil {
+		return utils.NewTransactionErr(utils.SignTxError, err)
+	}
+	shardID := common.GetShardIDFromLastByte(tx.PubKeyLastByteSender)
+	ring, indexes, commitmentsToZero, err := buildMlsagRingCA(members, out, params.Fee, pi)
 	if err != nil {
 		utils.Logger.Log.Errorf("generateMlsagRingWithIndexes got error %v ", err)
 		return err
@@ -405,6 +385,10 @@ func createUniqueOTACoinCA(paymentInfo *privacy.PaymentInfo, senderShardID int,
 			// c.SetAssetTag(assetTag)
 			return c, nil, nil // No need to check db
 		}
+		// An offline signer has no db, the node checks the uniqueness when the tx is submitted
+		if stateDB == nil {
+			return c, sharedSecret, nil
+		}
 		// Onetimeaddress should be unique
 		publicKeyBytes := c.GetPublicKey().ToBytesS()
 		// here tokenID should always be TokenConfidentialAssetID (for db storage)
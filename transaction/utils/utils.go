@@ -20,6 +20,10 @@ func NewCoinUniqueOTABasedOnPaymentInfo(paymentInfo *privacy.PaymentInfo, sender
 		if common.IsPublicKeyBurningAddress(c.GetPublicKey().ToBytesS()) {
 			return c, nil // No need to check db
 		}
+		// An offline signer has no db, the node checks the uniqueness when the tx is submitted
+		if stateDB == nil {
+			return c, nil
+		}
 		// Onetimeaddress should be unique
 		publicKeyBytes := c.GetPublicKey().ToBytesS()
 		found, _, err := statedb.HasOnetimeAddress(stateDB, *tokenID, publicKeyBytes)
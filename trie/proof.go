@@ -2,13 +2,55 @@ package trie
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/incdb"
+	"golang.org/x/crypto/sha3"
 )
 
+// ProofList collects the encoded nodes of a Merkle proof, from the root to the value.
+// It is the form in which a proof is sent; ProofSet() indexes the nodes for VerifyProof
+type ProofList [][]byte
+
+// Put appends a proof node; the key is the hash of the node and is recomputed by ProofSet()
+func (n *ProofList) Put(key []byte, value []byte) error {
+	*n = append(*n, value)
+	return nil
+}
+
+func (n *ProofList) Delete(key []byte) error {
+	return errors.New("cannot delete from a proof list")
+}
+
+// ProofSet returns the proof nodes by their hash
+func (n ProofList) ProofSet() ProofSet {
+	set := make(ProofSet)
+	for _, enc := range n {
+		hash := sha3.NewLegacyKeccak256()
+		hash.Write(enc)
+		set[string(hash.Sum(nil))] = enc
+	}
+	return set
+}
+
+// ProofSet holds the nodes of a Merkle proof by hash
+type ProofSet map[string][]byte
+
+func (s ProofSet) Has(key []byte) (bool, error) {
+	_, ok := s[string(key)]
+	return ok, nil
+}
+
+func (s ProofSet) Get(key []byte) ([]byte, error) {
+	if enc, ok := s[string(key)]; ok {
+		return enc, nil
+	}
+	return nil, errors.New("proof node not found")
+}
+
 // Prove constructs a merkle proof for key. The result contains all encoded nodes
 // on the path to the value at key. The value itself is also included in the last
 // node and can be retrieved by verifying the proof.
@@ -16,7 +58,7 @@ import (
 // If the trie does not contain a value for key, the returned proof contains all
 // nodes of the longest existing prefix of the key (at least the root node), ending
 // with the node that proves the absence of the key.
-func (t *Trie) Prove(key []byte, fromLevel uint, proofDb incdb.Database) error {
+func (t *Trie) Prove(key []byte, fromLevel uint, proofDb incdb.KeyValueWriter) error {
 	// Collect all nodes on the path to key.
 	key = keybytesToHex(key)
 	var nodes []node
@@ -79,7 +121,7 @@ func (t *Trie) Prove(key []byte, fromLevel uint, proofDb incdb.Database) error {
 // If the trie does not contain a value for key, the returned proof contains all
 // nodes of the longest existing prefix of the key (at least the root node), ending
 // with the node that proves the absence of the key.
-func (t *SecureTrie) Prove(key []byte, fromLevel uint, proofDb incdb.Database) error {
+func (t *SecureTrie) Prove(key []byte, fromLevel uint, proofDb incdb.KeyValueWriter) error {
 	return t.trie.Prove(key, fromLevel, proofDb)
 }
 
@@ -90,14 +132,14 @@ func (t *SecureTrie) Prove(key []byte, fromLevel uint, proofDb incdb.Database) e
 // If the trie does not contain a value for key, the returned proof contains all
 // nodes of the longest existing prefix of the key (at least the root node), ending
 // with the node that proves the absence of the key.
-func (t *PrefixTrie) Prove(key []byte, fromLevel uint, proofDb incdb.Database) error {
+func (t *PrefixTrie) Prove(key []byte, fromLevel uint, proofDb incdb.KeyValueWriter) error {
 	return t.trie.Prove(key, fromLevel, proofDb)
 }
 
 // VerifyProof checks merkle proofs. The given proof must contain the value for
 // key in a trie with the given root hash. VerifyProof returns an error if the
 // proof contains invalid trie nodes or the wrong value.
-func VerifyProof(rootHash common.Hash, key []byte, proofDb incdb.Database) (value []byte, nodes int, err error) {
+func VerifyProof(rootHash common.Hash, key []byte, proofDb incdb.KeyValueReader) (value []byte, nodes int, err error) {
 	key = keybytesToHex(key)
 	wantHash := rootHash
 	for i := 0; ; i++ {
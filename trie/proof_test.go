@@ -0,0 +1,83 @@
+package trie
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/incdb"
+	_ "github.com/incognitochain/incognito-chain/incdb/lvdb"
+)
+
+func TestProof(t *testing.T) {
+	Logger.Init(common.NewBackend(nil).Logger("test", true))
+	dbPath, err := ioutil.TempDir(os.TempDir(), "test_trie_proof_")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dbPath)
+	diskDB, err := incdb.Open("leveldb", dbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	iw := NewIntermediateWriter(diskDB)
+
+	tr, _ := New(common.Hash{}, iw)
+	keys := [][]byte{}
+	for i := 0; i < 500; i++ {
+		key := common.HashH([]byte{byte(i), byte(i >> 8)})
+		keys = append(keys, key[:])
+		tr.Update(key[:], append([]byte("value"), key[:]...))
+	}
+	root, err := tr.Commit(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// reopen the trie so that proof nodes are resolved from the database
+	tr, err = New(root, iw)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, key := range keys {
+		proof := ProofList{}
+		if err := tr.Prove(key, 0, &proof); err != nil {
+			t.Fatalf("prove %x: %v", key, err)
+		}
+		value, _, err := VerifyProof(root, key, proof.ProofSet())
+		if err != nil {
+			t.Fatalf("verify %x: %v", key, err)
+		}
+		if !bytes.Equal(value, append([]byte("value"), key...)) {
+			t.Fatalf("verify %x: got value %x", key, value)
+		}
+
+		// a proof against another root is rejected
+		if _, _, err := VerifyProof(common.HashH(root[:]), key, proof.ProofSet()); err == nil {
+			t.Fatalf("verify %x against a wrong root: expected error", key)
+		}
+		// a tampered node is rejected
+		tampered := ProofList{}
+		for _, node := range proof {
+			tampered = append(tampered, common.CopyBytes(node))
+		}
+		last := tampered[len(tampered)-1]
+		last[len(last)-1] ^= 1
+		if value, _, err := VerifyProof(root, key, tampered.ProofSet()); err == nil && bytes.Equal(value, append([]byte("value"), key...)) {
+			t.Fatalf("verify %x with a tampered proof: expected error", key)
+		}
+	}
+
+	// the proof of a missing key shows its absence
+	missing := common.HashH([]byte("missing"))
+	proof := ProofList{}
+	if err := tr.Prove(missing[:], 0, &proof); err != nil {
+		t.Fatal(err)
+	}
+	value, _, err := VerifyProof(root, missing[:], proof.ProofSet())
+	if err != nil || value != nil {
+		t.Fatalf("verify missing key: got value %x, error %v", value, err)
+	}
+}
@@ -0,0 +1,96 @@
+package trie
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/incdb"
+	"golang.org/x/crypto/sha3"
+)
+
+// syncTrieBloomSize is the size (in megabytes) of the bloom filter of the nodes already in the database
+const syncTrieBloomSize = 64
+
+// maxSyncTrieRetries is the number of fetches in a row that may bring no valid node before SyncTrie gives up
+const maxSyncTrieRetries = 5
+
+// NodeFetcher downloads trie nodes by hash, in the order of hashes. It may return fewer nodes than requested,
+// or nil for the nodes it could not get: they are requested again
+type NodeFetcher func(hashes []common.Hash) ([][]byte, error)
+
+// SyncTrie downloads the trie of root into database through fetch, batchSize nodes at a time,
+// and returns the number of nodes downloaded. Every node is checked against its hash before being processed,
+// so a fetcher cannot forge the content of root. Nodes already in database are not downloaded again,
+// which lets an interrupted sync resume
+func SyncTrie(root common.Hash, database incdb.Database, fetch NodeFetcher, batchSize int) (int, error) {
+	if batchSize <= 0 {
+		return 0, fmt.Errorf("Invalid batch size %d", batchSize)
+	}
+	bloom := NewSyncBloom(syncTrieBloomSize, database)
+	defer bloom.Close()
+	sched := NewSync(root, database, nil, bloom)
+
+	synced, retries := 0, 0
+	queue := []common.Hash{}
+	for sched.Pending() > 0 {
+		if len(queue) < batchSize {
+			queue = append(queue, sched.Missing(batchSize-len(queue))...)
+		}
+		if len(queue) == 0 {
+			return synced, errors.New("Trie sync has pending nodes but none to download")
+		}
+		nodes, err := fetch(queue)
+		if err != nil {
+			retries++
+			if retries > maxSyncTrieRetries {
+				return synced, err
+			}
+			Logger.log.Warnf("Fetch %d trie nodes of root %v fail, retry: %v", len(queue), root.String(), err)
+			continue
+		}
+
+		results := []SyncResult{}
+		missing := []common.Hash{}
+		for i, hash := range queue {
+			if i < len(nodes) && nodes[i] != nil && keccak256Hash(nodes[i]) == hash {
+				results = append(results, SyncResult{Hash: hash, Data: nodes[i]})
+			} else {
+				missing = append(missing, hash)
+			}
+		}
+		if len(results) == 0 {
+			retries++
+			if retries > maxSyncTrieRetries {
+				return synced, fmt.Errorf("Cannot get valid trie nodes of root %v", root.String())
+			}
+			continue
+		}
+		retries = 0
+
+		if _, index, err := sched.Process(results); err != nil {
+			return synced, fmt.Errorf("Process trie node %v: %v", results[index].Hash.String(), err)
+		}
+		batch := database.NewBatch()
+		if err := sched.Commit(batch); err != nil {
+			return synced, err
+		}
+		if err := batch.Write(); err != nil {
+			return synced, err
+		}
+		synced += len(results)
+		if synced/10000 != (synced-len(results))/10000 {
+			Logger.log.Infof("Synced %d trie nodes of root %v, %d pending", synced, root.String(), sched.Pending())
+		}
+		queue = missing
+	}
+	return synced, nil
+}
+
+func keccak256Hash(data []byte) common.Hash {
+	hash := sha3.NewLegacyKeccak256()
+	hash.Write(data)
+	result := common.Hash{}
+	copy(result[:], hash.Sum(nil))
+	return result
+}
@@ -0,0 +1,109 @@
+package trie
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/incdb"
+	_ "github.com/incognitochain/incognito-chain/incdb/lvdb"
+)
+
+func openTestSyncDB(t *testing.T, name string) (incdb.Database, string) {
+	dbPath, err := ioutil.TempDir(os.TempDir(), name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	db, err := incdb.Open("leveldb", dbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db, dbPath
+}
+
+func TestSyncTrie(t *testing.T) {
+	Logger.Init(common.NewBackend(nil).Logger("test", true))
+	srcDB, srcPath := openTestSyncDB(t, "test_trie_sync_src_")
+	defer os.RemoveAll(srcPath)
+	srcIW := NewIntermediateWriter(srcDB)
+	tr, _ := New(common.Hash{}, srcIW)
+	values := map[common.Hash][]byte{}
+	for i := 0; i < 2000; i++ {
+		key := common.HashH([]byte{byte(i), byte(i >> 8)})
+		values[key] = append([]byte("value"), key[:]...)
+		tr.Update(key[:], values[key])
+	}
+	root, err := tr.Commit(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := srcIW.Commit(root, false); err != nil {
+		t.Fatal(err)
+	}
+
+	requests := 0
+	serve := func(tamper bool) NodeFetcher {
+		return func(hashes []common.Hash) ([][]byte, error) {
+			requests++
+			nodes := [][]byte{}
+			for i, hash := range hashes {
+				// leave some nodes out, to be requested again
+				if i%7 == 3 {
+					nodes = append(nodes, nil)
+					continue
+				}
+				node, err := srcDB.Get(hash[:])
+				if err != nil {
+					return nil, err
+				}
+				if tamper {
+					node = append(common.CopyBytes(node), 0)
+				}
+				nodes = append(nodes, node)
+			}
+			return nodes, nil
+		}
+	}
+
+	// forged nodes are rejected
+	badDB, badPath := openTestSyncDB(t, "test_trie_sync_bad_")
+	defer os.RemoveAll(badPath)
+	if _, err := SyncTrie(root, badDB, serve(true), 64); err == nil {
+		t.Fatal("sync with tampered nodes: expected error")
+	}
+	if _, err := SyncTrie(root, badDB, func([]common.Hash) ([][]byte, error) {
+		return nil, errors.New("no peer")
+	}, 64); err == nil {
+		t.Fatal("sync without nodes: expected error")
+	}
+
+	dstDB, dstPath := openTestSyncDB(t, "test_trie_sync_dst_")
+	defer os.RemoveAll(dstPath)
+	synced, err := SyncTrie(root, dstDB, serve(false), 64)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if synced == 0 {
+		t.Fatal("no node synced")
+	}
+	syncedTrie, err := New(root, NewIntermediateWriter(dstDB))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for key, value := range values {
+		got, err := syncedTrie.TryGet(key[:])
+		if err != nil || !bytes.Equal(got, value) {
+			t.Fatalf("get %x from synced trie: got value %x, error %v", key, got, err)
+		}
+	}
+
+	// a synced trie is not downloaded again
+	requests = 0
+	synced, err = SyncTrie(root, dstDB, serve(false), 64)
+	if err != nil || synced != 0 || requests != 0 {
+		t.Fatalf("sync again: synced %d nodes in %d requests, error %v", synced, requests, err)
+	}
+}
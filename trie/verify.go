@@ -0,0 +1,62 @@
+package trie
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/incdb"
+)
+
+// IsTrieNode checks whether enc is the encoding of a trie node whose hash is hash
+func IsTrieNode(hash common.Hash, enc []byte) bool {
+	if !bytes.Equal(common.Keccak256Hash(enc).Bytes(), hash[:]) {
+		return false
+	}
+	_, err := decodeNode(hash[:], enc)
+	return err == nil
+}
+
+// VerifyTrie walks every node of the trie rooted at root in diskdb and checks that
+// each node is present and matches the hash it is referenced by.
+// An empty root is always valid.
+func VerifyTrie(root common.Hash, diskdb incdb.Database) error {
+	if root == (common.Hash{}) || root == emptyRoot {
+		return nil
+	}
+	hashes := []common.Hash{root}
+	for len(hashes) > 0 {
+		hash := hashes[len(hashes)-1]
+		hashes = hashes[:len(hashes)-1]
+		enc, err := diskdb.Get(hash[:])
+		if err != nil || len(enc) == 0 {
+			return &MissingNodeError{NodeHash: hash}
+		}
+		if !bytes.Equal(common.Keccak256Hash(enc).Bytes(), hash[:]) {
+			return fmt.Errorf("trie node %x does not match its hash", hash)
+		}
+		n, err := decodeNode(hash[:], enc)
+		if err != nil {
+			return fmt.Errorf("cannot decode trie node %x: %v", hash, err)
+		}
+		hashes = appendChildHashes(hashes, n)
+	}
+	return nil
+}
+
+// appendChildHashes appends the hashes of the nodes referenced by n, descending into embedded nodes
+func appendChildHashes(hashes []common.Hash, n node) []common.Hash {
+	switch n := n.(type) {
+	case *shortNode:
+		return appendChildHashes(hashes, n.Val)
+	case *fullNode:
+		for _, child := range n.Children {
+			if child != nil {
+				hashes = appendChildHashes(hashes, child)
+			}
+		}
+	case hashNode:
+		hashes = append(hashes, common.BytesToHash(n))
+	}
+	return hashes
+}
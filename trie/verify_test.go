@@ -0,0 +1,60 @@
+package trie
+
+import (
+	"os"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+)
+
+func TestVerifyTrie(t *testing.T) {
+	db, dbPath := openTestSyncDB(t, "test_trie_verify_")
+	defer os.RemoveAll(dbPath)
+	iw := NewIntermediateWriter(db)
+	tr, _ := New(common.Hash{}, iw)
+	for i := 0; i < 500; i++ {
+		key := common.HashH([]byte{byte(i), byte(i >> 8)})
+		tr.Update(key[:], append([]byte("value"), key[:]...))
+	}
+	root, err := tr.Commit(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := iw.Commit(root, false); err != nil {
+		t.Fatal(err)
+	}
+	if err := VerifyTrie(root, db); err != nil {
+		t.Fatalf("Expect a valid trie, got %v", err)
+	}
+	if err := VerifyTrie(common.Hash{}, db); err != nil {
+		t.Fatalf("Expect an empty trie to be valid, got %v", err)
+	}
+	enc, _ := db.Get(root[:])
+	if !IsTrieNode(root, enc) {
+		t.Fatal("Expect the root to be a trie node")
+	}
+
+	// tamper a child of the root
+	rootNode, _ := decodeNode(root[:], enc)
+	child := appendChildHashes(nil, rootNode)[0]
+	childEnc, _ := db.Get(child[:])
+	tampered := append([]byte{}, childEnc...)
+	tampered[len(tampered)-1] ^= 1
+	if IsTrieNode(child, tampered) {
+		t.Fatal("Expect a tampered node not to be a trie node")
+	}
+	if err := db.Put(child[:], tampered); err != nil {
+		t.Fatal(err)
+	}
+	if err := VerifyTrie(root, db); err == nil {
+		t.Fatal("Expect a tampered trie to be rejected")
+	}
+
+	// remove the node
+	if err := db.Delete(child[:]); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := VerifyTrie(root, db).(*MissingNodeError); !ok {
+		t.Fatal("Expect a missing node error")
+	}
+}
@@ -0,0 +1,64 @@
+package txpool
+
+import (
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/pubsub"
+)
+
+// Mempool event types, published on pubsub.MempoolInfoTopic
+const (
+	EventTxAdded   = "added"
+	EventTxRemoved = "removed"
+)
+
+// Reasons of EventTxRemoved
+const (
+	RemoveReasonIncluded    = "included"    // the tx is included in a shard block
+	RemoveReasonDoubleSpend = "doublespend" // another tx spending the same coins is kept instead
+	RemoveReasonTTL         = "ttl"         // the tx stays in pool longer than TxPoolTTL
+	RemoveReasonReplaced    = "replaced"    // a new tx spending the same coins with a better fee replaces it
+	RemoveReasonInvalid     = "invalid"     // the tx is no longer valid with the new view of the shard
+	RemoveReasonManual      = "manual"      // the tx is removed by RPC
+	RemoveReasonEvicted     = "evicted"     // the pool is full and a new tx pays a higher fee rate
+)
+
+// MempoolEvent is a change of the tx pool of one shard. Seq increases by one for every
+// event of a shard, so subscribers can order the events and detect the ones they missed.
+// PoolSize is the number of txs in the pool of the shard after the change.
+type MempoolEvent struct {
+	Type         string
+	Reason       string
+	ShardID      byte
+	Seq          uint64
+	TxHash       string
+	TxType       string
+	MetadataType int
+	Fee          uint64
+	Size         uint64
+	LockTime     int64
+	PoolSize     int
+}
+
+// publishEvent must be called from the loop of Start, which owns tp.Data.
+func (tp *TxsPool) publishEvent(eventType, reason string, txHash string, tx metadata.Transaction) {
+	if tp.ps == nil {
+		return
+	}
+	tp.eventSeq++
+	event := &MempoolEvent{
+		Type:     eventType,
+		Reason:   reason,
+		ShardID:  tp.shardID,
+		Seq:      tp.eventSeq,
+		TxHash:   txHash,
+		PoolSize: len(tp.Data.TxByHash),
+	}
+	if tx != nil {
+		event.TxType = tx.GetType()
+		event.MetadataType = tx.GetMetadataType()
+		event.Fee = tx.GetTxFee()
+		event.Size = tx.GetTxActualSize()
+		event.LockTime = tx.GetLockTime()
+	}
+	tp.ps.PublishMessage(pubsub.NewMessage(pubsub.MempoolInfoTopic, event))
+}
@@ -0,0 +1,96 @@
+package txpool
+
+import (
+	"testing"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/pubsub"
+	"github.com/incognitochain/incognito-chain/transaction/tx_generic"
+	"github.com/incognitochain/incognito-chain/transaction/tx_ver1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testLogWriter struct{}
+
+func (testLogWriter) Write(p []byte) (int, error) {
+	return len(p), nil
+}
+
+func init() {
+	Logger.Init(common.NewBackend(testLogWriter{}).Logger("Txpool log ", true))
+	common.MaxShardNumber = 8
+}
+
+func newEventTestTx(lockTime int64, fee uint64) metadata.Transaction {
+	return &tx_ver1.Tx{
+		TxBase: tx_generic.TxBase{
+			Version:  1,
+			Type:     common.TxNormalType,
+			LockTime: lockTime,
+			Fee:      fee,
+		},
+	}
+}
+
+func nextEvent(t *testing.T, ch pubsub.EventChannel) *MempoolEvent {
+	select {
+	case msg := <-ch:
+		event, ok := msg.Value.(*MempoolEvent)
+		require.True(t, ok)
+		return event
+	case <-time.After(5 * time.Second):
+		t.Fatal("no mempool event")
+	}
+	return nil
+}
+
+func TestMempoolEvents(t *testing.T) {
+	ps := pubsub.NewPubSubManager()
+	go ps.Start()
+	_, ch, err := ps.RegisterNewSubscriber(pubsub.MempoolInfoTopic)
+	require.NoError(t, err)
+	pm, err := NewPoolManager(2, ps, time.Minute, 0)
+	require.NoError(t, err)
+	tp := pm.ShardTxsPool[1].(*TxsPool)
+	go tp.Start()
+	defer tp.Stop()
+
+	txA := newEventTestTx(1, 10)
+	txB := newEventTestTx(2, 20)
+	tp.action <- func(tp *TxsPool) {
+		tp.addTx(txInfoTemp{tx: txA}, nil)
+	}
+	event := nextEvent(t, ch)
+	assert.Equal(t, EventTxAdded, event.Type)
+	assert.Equal(t, byte(1), event.ShardID)
+	assert.Equal(t, uint64(1), event.Seq)
+	assert.Equal(t, txA.Hash().String(), event.TxHash)
+	assert.Equal(t, uint64(10), event.Fee)
+	assert.Equal(t, 1, event.PoolSize)
+
+	tp.action <- func(tp *TxsPool) {
+		tp.addTx(txInfoTemp{tx: txB}, nil)
+	}
+	event = nextEvent(t, ch)
+	assert.Equal(t, uint64(2), event.Seq)
+	assert.Equal(t, 2, event.PoolSize)
+
+	// txs not in pool are removed silently
+	tp.RemoveTxs([]string{common.HashH([]byte("unknown")).String(), txA.Hash().String()})
+	event = nextEvent(t, ch)
+	assert.Equal(t, EventTxRemoved, event.Type)
+	assert.Equal(t, RemoveReasonIncluded, event.Reason)
+	assert.Equal(t, txA.Hash().String(), event.TxHash)
+	assert.Equal(t, uint64(3), event.Seq)
+	assert.Equal(t, 1, event.PoolSize)
+
+	tp.action <- func(tp *TxsPool) {
+		tp.removeTxAndCoins(txB.Hash().String(), RemoveReasonReplaced)
+	}
+	event = nextEvent(t, ch)
+	assert.Equal(t, RemoveReasonReplaced, event.Reason)
+	assert.Equal(t, 0, event.PoolSize)
+}
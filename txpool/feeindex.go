@@ -0,0 +1,138 @@
+package txpool
+
+import (
+	"sort"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/transaction"
+)
+
+// FeeRate is the fee per KB a tx pays, in PRV or in the token of TokenID.
+// Fee rates in different tokens can not be compared.
+type FeeRate struct {
+	TokenID common.Hash
+	Fee     uint64
+	Rate    uint64
+}
+
+func getFeeRate(tx metadata.Transaction) FeeRate {
+	res := FeeRate{
+		TokenID: common.PRVCoinID,
+		Fee:     tx.GetTxFee(),
+	}
+	if res.Fee == 0 && tx.GetType() == common.TxCustomTokenPrivacyType {
+		if txToken, ok := tx.(transaction.TransactionToken); ok {
+			res.TokenID = txToken.GetTxTokenData().PropertyID
+			res.Fee = tx.GetTxFeeToken()
+		}
+	}
+	size := tx.GetTxActualSize()
+	if size == 0 {
+		size = 1
+	}
+	res.Rate = res.Fee / size
+	return res
+}
+
+type feeRateEntry struct {
+	txHash string
+	rate   uint64
+}
+
+// feeRateList is ordered by rate descending, then by tx hash.
+type feeRateList []feeRateEntry
+
+func (l feeRateList) search(e feeRateEntry) int {
+	return sort.Search(len(l), func(i int) bool {
+		if l[i].rate != e.rate {
+			return l[i].rate < e.rate
+		}
+		return l[i].txHash >= e.txHash
+	})
+}
+
+// feeRateIndex orders the txs of the pool by fee rate, with one list for the txs paying
+// fee in PRV and one list per token for the txs paying fee in token. It is only accessed
+// from the loop of Start, like TxsPool.Data.
+type feeRateIndex struct {
+	lists map[common.Hash]feeRateList
+	rates map[string]FeeRate
+}
+
+func newFeeRateIndex() *feeRateIndex {
+	return &feeRateIndex{
+		lists: map[common.Hash]feeRateList{},
+		rates: map[string]FeeRate{},
+	}
+}
+
+func (idx *feeRateIndex) add(txHash string, feeRate FeeRate) {
+	if _, ok := idx.rates[txHash]; ok {
+		idx.remove(txHash)
+	}
+	e := feeRateEntry{txHash: txHash, rate: feeRate.Rate}
+	l := idx.lists[feeRate.TokenID]
+	i := l.search(e)
+	l = append(l, feeRateEntry{})
+	copy(l[i+1:], l[i:])
+	l[i] = e
+	idx.lists[feeRate.TokenID] = l
+	idx.rates[txHash] = feeRate
+}
+
+func (idx *feeRateIndex) remove(txHash string) {
+	feeRate, ok := idx.rates[txHash]
+	if !ok {
+		return
+	}
+	delete(idx.rates, txHash)
+	l := idx.lists[feeRate.TokenID]
+	i := l.search(feeRateEntry{txHash: txHash, rate: feeRate.Rate})
+	if i < len(l) && l[i].txHash == txHash {
+		l = append(l[:i], l[i+1:]...)
+	}
+	if len(l) == 0 {
+		delete(idx.lists, feeRate.TokenID)
+	} else {
+		idx.lists[feeRate.TokenID] = l
+	}
+}
+
+func (idx *feeRateIndex) get(txHash string) (FeeRate, bool) {
+	feeRate, ok := idx.rates[txHash]
+	return feeRate, ok
+}
+
+// lowest returns the tx with the lowest fee rate among the txs paying fee in tokenID.
+func (idx *feeRateIndex) lowest(tokenID common.Hash) (string, uint64, bool) {
+	l := idx.lists[tokenID]
+	if len(l) == 0 {
+		return "", 0, false
+	}
+	return l[len(l)-1].txHash, l[len(l)-1].rate, true
+}
+
+// ordered returns the tx hashes by fee rate descending: the txs paying fee in PRV first,
+// then the txs of every fee token.
+func (idx *feeRateIndex) ordered() []string {
+	res := make([]string, 0, len(idx.rates))
+	for _, e := range idx.lists[common.PRVCoinID] {
+		res = append(res, e.txHash)
+	}
+	tokenIDs := []common.Hash{}
+	for tokenID := range idx.lists {
+		if tokenID != common.PRVCoinID {
+			tokenIDs = append(tokenIDs, tokenID)
+		}
+	}
+	sort.Slice(tokenIDs, func(i, j int) bool {
+		return tokenIDs[i].String() < tokenIDs[j].String()
+	})
+	for _, tokenID := range tokenIDs {
+		for _, e := range idx.lists[tokenID] {
+			res = append(res, e.txHash)
+		}
+	}
+	return res
+}
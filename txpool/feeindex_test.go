@@ -0,0 +1,165 @@
+package txpool
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/privacy"
+	"github.com/incognitochain/incognito-chain/privacy/coin"
+	"github.com/incognitochain/incognito-chain/privacy/operation"
+	zkp "github.com/incognitochain/incognito-chain/privacy/privacy_v1/zeroknowledge"
+	"github.com/incognitochain/incognito-chain/transaction/tx_ver1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newSpendingTestTx returns a tx spending the coins of serialNumbers, paying fee in PRV.
+func newSpendingTestTx(t *testing.T, lockTime int64, fee uint64, serialNumbers ...int64) metadata.Transaction {
+	tx := newEventTestTx(lockTime, fee).(*tx_ver1.Tx)
+	iCoins := []coin.PlainCoin{}
+	for _, sn := range serialNumbers {
+		iCoin := &coin.PlainCoinV1{}
+		iCoin.SetKeyImage(operation.HashToPoint(big.NewInt(sn).Bytes()))
+		iCoins = append(iCoins, iCoin)
+	}
+	prf := &zkp.PaymentProof{}
+	require.NoError(t, prf.SetInputCoins(iCoins))
+	tx.Proof = prf
+	return tx
+}
+
+func TestFeeRateIndex(t *testing.T) {
+	tokenID := common.HashH([]byte("token"))
+	idx := newFeeRateIndex()
+	idx.add("a", FeeRate{TokenID: common.PRVCoinID, Rate: 10})
+	idx.add("b", FeeRate{TokenID: common.PRVCoinID, Rate: 30})
+	idx.add("c", FeeRate{TokenID: tokenID, Rate: 1000})
+	idx.add("d", FeeRate{TokenID: common.PRVCoinID, Rate: 20})
+	idx.add("e", FeeRate{TokenID: common.PRVCoinID, Rate: 20})
+	assert.Equal(t, []string{"b", "d", "e", "a", "c"}, idx.ordered())
+
+	txHash, rate, ok := idx.lowest(common.PRVCoinID)
+	assert.True(t, ok)
+	assert.Equal(t, "a", txHash)
+	assert.Equal(t, uint64(10), rate)
+
+	idx.remove("a")
+	idx.remove("d")
+	idx.remove("unknown")
+	assert.Equal(t, []string{"b", "e", "c"}, idx.ordered())
+	txHash, _, _ = idx.lowest(common.PRVCoinID)
+	assert.Equal(t, "e", txHash)
+
+	idx.remove("c")
+	_, _, ok = idx.lowest(tokenID)
+	assert.False(t, ok)
+	assert.Len(t, idx.lists, 1)
+}
+
+func TestEvictLowestFeeRate(t *testing.T) {
+	tp := NewTxsPool(nil, make(chan metadata.Transaction), time.Minute)
+	tp.maxTx = 2
+	go tp.Start()
+	defer tp.Stop()
+
+	txs := []metadata.Transaction{
+		newEventTestTx(1, 20),
+		newEventTestTx(2, 10),
+		newEventTestTx(3, 5),
+		newEventTestTx(4, 30),
+	}
+	for _, tx := range txs {
+		tx := tx
+		tp.action <- func(tp *TxsPool) {
+			tp.admitTx(txInfoTemp{tx: tx})
+		}
+	}
+	data := tp.snapshotPool()
+	assert.Len(t, data.TxByHash, 2)
+	assert.Contains(t, data.TxByHash, txs[0].Hash().String())
+	assert.Contains(t, data.TxByHash, txs[3].Hash().String())
+
+	cTxs := make(chan *TxInfoDetail, 4)
+	tp.getTxsFromPool(cTxs, nil)
+	assert.Equal(t, txs[3].Hash().String(), (<-cTxs).Hash)
+	assert.Equal(t, txs[0].Hash().String(), (<-cTxs).Hash)
+	assert.Nil(t, <-cTxs)
+}
+
+func TestReplaceByFee(t *testing.T) {
+	tp := NewTxsPool(nil, make(chan metadata.Transaction), time.Minute)
+	go tp.Start()
+	defer tp.Stop()
+	admit := func(tx metadata.Transaction) {
+		tp.action <- func(tp *TxsPool) {
+			tp.admitTx(txInfoTemp{tx: tx})
+		}
+	}
+
+	txA := newSpendingTestTx(t, 1, 100, 1, 2)
+	admit(txA)
+	// not enough fee to replace txA
+	txB := newSpendingTestTx(t, 2, 110, 1, 2)
+	isDoubleSpend, canReplace, oldTx, _ := tp.CheckDoubleSpendWithCurMem(txB)
+	assert.True(t, isDoubleSpend)
+	assert.False(t, canReplace)
+	assert.Equal(t, txA.Hash().String(), oldTx)
+	admit(txB)
+	// does not spend the same serial numbers as txA
+	txC := newSpendingTestTx(t, 3, 1000, 1, 3)
+	_, canReplace, _, _ = tp.CheckDoubleSpendWithCurMem(txC)
+	assert.False(t, canReplace)
+	admit(txC)
+	data := tp.snapshotPool()
+	assert.Len(t, data.TxByHash, 1)
+	assert.Contains(t, data.TxByHash, txA.Hash().String())
+
+	txD := newSpendingTestTx(t, 4, 111, 2, 1)
+	_, canReplace, _, _ = tp.CheckDoubleSpendWithCurMem(txD)
+	assert.True(t, canReplace)
+	admit(txD)
+	data = tp.snapshotPool()
+	assert.Len(t, data.TxByHash, 1)
+	assert.Contains(t, data.TxByHash, txD.Hash().String())
+	// the coins of txA are now spent by txD
+	txE := newSpendingTestTx(t, 5, 100, 3)
+	isDoubleSpend, _, _, _ = tp.CheckDoubleSpendWithCurMem(txE)
+	assert.False(t, isDoubleSpend)
+	isDoubleSpend, _, oldTx, _ = tp.CheckDoubleSpendWithCurMem(txA)
+	assert.True(t, isDoubleSpend)
+	assert.Equal(t, txD.Hash().String(), oldTx)
+}
+
+func TestCheckDoubleSpendUsesReplaceByFee(t *testing.T) {
+	tp := NewTxsPool(nil, make(chan metadata.Transaction), time.Minute)
+	txA := newSpendingTestTx(t, 1, 100, 1, 2)
+	dataHelper := map[[privacy.Ed25519KeySize]byte]struct {
+		Index  uint
+		Detail TxInfoDetail
+	}{}
+	txs := insertTxIntoList(dataHelper, TxInfoDetail{Hash: txA.Hash().String(), Fee: txA.GetTxFee(), Tx: txA}, []metadata.Transaction{})
+
+	// the same txs as in TestReplaceByFee: the block and the pool must agree on the winner
+	testCases := []struct {
+		tx            metadata.Transaction
+		canReplace    bool
+		isDoubleSpend bool
+	}{
+		{newSpendingTestTx(t, 2, 110, 1, 2), false, true},
+		{newSpendingTestTx(t, 3, 1000, 1, 3), false, true},
+		{newSpendingTestTx(t, 4, 111, 2, 1), true, true},
+		{newSpendingTestTx(t, 5, 100, 3), false, false},
+	}
+	for _, tc := range testCases {
+		isDoubleSpend, needToReplace, _, removeIdx := tp.CheckDoubleSpend(dataHelper, tc.tx, &txs)
+		assert.Equal(t, tc.isDoubleSpend, isDoubleSpend)
+		assert.Equal(t, tc.canReplace, needToReplace)
+		assert.Equal(t, tc.canReplace, tp.isReplacement(txA, tc.tx))
+		if tc.canReplace {
+			assert.Contains(t, removeIdx, uint(0))
+		}
+	}
+}
@@ -0,0 +1,260 @@
+package txpool
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/databasemp"
+	"github.com/incognitochain/incognito-chain/databasemp/lvdb"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/transaction"
+	"github.com/pkg/errors"
+)
+
+// ShardViewsGetter returns the views of a shard to validate the txs of its pool with.
+type ShardViewsGetter func(shardID byte) (metadata.ChainRetriever, metadata.ShardViewRetriever, metadata.BeaconViewRetriever, error)
+
+// snapshotDesc is stored with every tx of a snapshot
+type snapshotDesc struct {
+	ShardID   byte
+	StartTime time.Time
+	VTime     time.Duration
+}
+
+type snapshotTx struct {
+	hash string
+	desc snapshotDesc
+	tx   metadata.Transaction
+}
+
+// ResetSnapshot removes the snapshot in db, then SaveSnapshot writes the pools into db.
+func (pm *PoolManager) ResetSnapshot(db databasemp.DatabaseInterface) error {
+	pm.snapshotLock.Lock()
+	defer pm.snapshotLock.Unlock()
+	if err := db.Reset(); err != nil {
+		return err
+	}
+	pm.db = db
+	pm.persisted = map[string]byte{}
+	return nil
+}
+
+// LoadSnapshot restores the txs of the snapshot in db into the pools, then SaveSnapshot writes
+// the pools into db. The txs are validated again with the views returned by getViews, the txs
+// which are invalid or stay longer than the TTL of the pool are removed from the snapshot.
+func (pm *PoolManager) LoadSnapshot(db databasemp.DatabaseInterface, getViews ShardViewsGetter) error {
+	pm.snapshotLock.Lock()
+	defer pm.snapshotLock.Unlock()
+	pm.db = db
+	pm.persisted = map[string]byte{}
+	keys, values, err := db.Load()
+	if err != nil {
+		return err
+	}
+	txsByShard := map[byte][]snapshotTx{}
+	for i, value := range values {
+		stx, err := unmarshalSnapshotTx(value)
+		if err != nil || int(stx.desc.ShardID) >= len(pm.ShardTxsPool) {
+			Logger.Errorf("Remove invalid tx %s from txpool snapshot, error %v", keys[i], err)
+			if err := db.Delete(keys[i]); err != nil {
+				Logger.Error(err)
+			}
+			continue
+		}
+		txsByShard[stx.desc.ShardID] = append(txsByShard[stx.desc.ShardID], *stx)
+	}
+	for shardID, txs := range txsByShard {
+		txPool, ok := pm.ShardTxsPool[shardID].(*TxsPool)
+		if !ok {
+			continue
+		}
+		cView, sView, bcView, err := getViews(shardID)
+		if err != nil {
+			return errors.Wrapf(err, "get views of shard %v", shardID)
+		}
+		restored := txPool.restore(txs, cView, sView, bcView)
+		for _, stx := range txs {
+			if _, ok := restored[stx.hash]; ok {
+				pm.persisted[stx.hash] = shardID
+				continue
+			}
+			txHash, err := common.Hash{}.NewHashFromStr(stx.hash)
+			if err == nil {
+				err = db.RemoveTransaction(txHash)
+			}
+			if err != nil {
+				Logger.Error(err)
+			}
+		}
+		Logger.Infof("Restore %v/%v txs of shard %v from txpool snapshot", len(restored), len(txs), shardID)
+	}
+	return nil
+}
+
+// SaveSnapshot writes the txs of the running pools into the database given to LoadSnapshot or
+// ResetSnapshot. The snapshot of the pools which are not running is kept as it is.
+func (pm *PoolManager) SaveSnapshot() error {
+	pm.snapshotLock.Lock()
+	defer pm.snapshotLock.Unlock()
+	if pm.db == nil {
+		return nil
+	}
+	added, removed := 0, 0
+	for sID, txPool := range pm.ShardTxsPool {
+		if !txPool.IsRunning() {
+			continue
+		}
+		shardID := byte(sID)
+		txsData := txPool.snapshotPool()
+		for txHashStr, tx := range txsData.TxByHash {
+			if _, ok := pm.persisted[txHashStr]; ok {
+				continue
+			}
+			info := txsData.TxInfos[txHashStr]
+			valueTx, err := json.Marshal(tx)
+			if err != nil {
+				return err
+			}
+			valueDesc, err := json.Marshal(snapshotDesc{
+				ShardID:   shardID,
+				StartTime: info.StartTime,
+				VTime:     info.VTime,
+			})
+			if err != nil {
+				return err
+			}
+			if err := pm.db.AddTransaction(tx.Hash(), tx.GetType(), valueTx, valueDesc); err != nil {
+				return err
+			}
+			pm.persisted[txHashStr] = shardID
+			added++
+		}
+		for txHashStr, sID := range pm.persisted {
+			if sID != shardID {
+				continue
+			}
+			if _, ok := txsData.TxByHash[txHashStr]; ok {
+				continue
+			}
+			txHash, err := common.Hash{}.NewHashFromStr(txHashStr)
+			if err != nil {
+				return err
+			}
+			if err := pm.db.RemoveTransaction(txHash); err != nil {
+				return err
+			}
+			delete(pm.persisted, txHashStr)
+			removed++
+		}
+	}
+	Logger.Debugf("Save txpool snapshot, add %v txs, remove %v txs, total %v txs", added, removed, len(pm.persisted))
+	return nil
+}
+
+// RunSnapshot saves the snapshot of the pools every interval until quit is closed.
+// The snapshot is only saved by SaveSnapshot when interval is 0.
+func (pm *PoolManager) RunSnapshot(interval time.Duration, quit <-chan struct{}) {
+	if interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := pm.SaveSnapshot(); err != nil {
+				Logger.Errorf("Save txpool snapshot return error %v", err)
+			}
+		case <-quit:
+			return
+		}
+	}
+}
+
+func unmarshalSnapshotTx(value []byte) (*snapshotTx, error) {
+	values := strings.Split(string(value), string(lvdb.Splitter))
+	if len(values) != 3 {
+		return nil, errors.Errorf("expect 3 values, got %v", len(values))
+	}
+	var tx metadata.Transaction
+	var err error
+	switch values[0] {
+	case common.TxCustomTokenPrivacyType, common.TxTokenConversionType:
+		tx, err = transaction.NewTransactionTokenFromJsonBytes([]byte(values[1]))
+	default:
+		tx, err = transaction.NewTransactionFromJsonBytes([]byte(values[1]))
+	}
+	if err != nil {
+		return nil, err
+	}
+	res := &snapshotTx{
+		hash: tx.Hash().String(),
+		tx:   tx,
+	}
+	if err := json.Unmarshal([]byte(values[2]), &res.desc); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// restore validates the txs of a snapshot like the txs received by the pool, then with the
+// given views like the txs of a new block. It returns the hashes of the txs added into pool.
+func (tp *TxsPool) restore(
+	txs []snapshotTx,
+	cView metadata.ChainRetriever,
+	sView metadata.ShardViewRetriever,
+	bcView metadata.BeaconViewRetriever,
+) map[string]interface{} {
+	res := map[string]interface{}{}
+	validTxs := []txInfoTemp{}
+	mapForChkDbStake := map[string]interface{}{}
+	sDB := sView.GetCopiedTransactionStateDB()
+	for _, stx := range txs {
+		tx := stx.tx
+		age := time.Since(stx.desc.StartTime)
+		if age >= tp.ttl {
+			continue
+		}
+		if !isTxForUser(tx) || tp.isDoubleStake(mapForChkDbStake, tx) {
+			continue
+		}
+		if ok, err := tp.Verifier.LoadCommitment(tx, sView); !ok || err != nil {
+			Logger.Errorf("[txTracing] Restore tx %v return error %v", stx.hash, err)
+			continue
+		}
+		if ok, err := tp.Verifier.ValidateWithoutChainstate(tx); !ok || err != nil {
+			Logger.Errorf("[txTracing] Restore tx %v return error %v", stx.hash, err)
+			continue
+		}
+		if err := tx.CheckData(sDB); err != nil {
+			Logger.Errorf("[txTracing] Restore tx %v return error %v", stx.hash, err)
+			continue
+		}
+		if ok, err := tp.Verifier.ValidateWithChainState(tx, cView, sView, bcView, sView.GetBeaconHeight()); !ok || err != nil {
+			Logger.Errorf("[txTracing] Restore tx %v return error %v", stx.hash, err)
+			continue
+		}
+		tp.Cacher.Add(stx.hash, nil, tp.ttl-age)
+		validTxs = append(validTxs, txInfoTemp{
+			tx:        tx,
+			vt:        stx.desc.VTime,
+			startTime: stx.desc.StartTime,
+		})
+		res[stx.hash] = nil
+	}
+	tp.sttLock.Lock()
+	if !tp.isRunning {
+		tp.restored = append(tp.restored, validTxs...)
+		tp.sttLock.Unlock()
+		return res
+	}
+	tp.sttLock.Unlock()
+	tp.action <- func(tpTemp *TxsPool) {
+		for _, validTx := range validTxs {
+			tpTemp.admitTx(validTx)
+		}
+	}
+	return res
+}
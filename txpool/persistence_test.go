@@ -0,0 +1,123 @@
+package txpool
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/databasemp"
+	_ "github.com/incognitochain/incognito-chain/databasemp/lvdb"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func openSnapshotTestDB(t *testing.T) (databasemp.DatabaseInterface, func()) {
+	dir, err := ioutil.TempDir("", "txpoolsnapshot")
+	require.NoError(t, err)
+	db, err := databasemp.Open("leveldbmempool", dir)
+	require.NoError(t, err)
+	return db, func() {
+		db.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+// snapshotTestVerifier rejects every tx validated without chain state
+type snapshotTestVerifier struct {
+	TxVerifier
+	validated int
+}
+
+func (v *snapshotTestVerifier) LoadCommitment(metadata.Transaction, metadata.ShardViewRetriever) (bool, error) {
+	return true, nil
+}
+
+func (v *snapshotTestVerifier) ValidateWithoutChainstate(metadata.Transaction) (bool, error) {
+	v.validated++
+	return false, nil
+}
+
+type snapshotTestShardView struct {
+	metadata.ShardViewRetriever
+}
+
+func (v *snapshotTestShardView) GetCopiedTransactionStateDB() *statedb.StateDB {
+	return nil
+}
+
+func TestSaveSnapshot(t *testing.T) {
+	db, closeDB := openSnapshotTestDB(t)
+	defer closeDB()
+	pm, err := NewPoolManager(2, nil, time.Minute, 0)
+	require.NoError(t, err)
+	require.NoError(t, pm.ResetSnapshot(db))
+	tp := pm.ShardTxsPool[1].(*TxsPool)
+	go tp.Start()
+	defer tp.Stop()
+
+	txA := newEventTestTx(1, 10)
+	txB := newEventTestTx(2, 20)
+	tp.action <- func(tp *TxsPool) {
+		tp.admitTx(txInfoTemp{tx: txA})
+		tp.admitTx(txInfoTemp{tx: txB})
+	}
+	require.NoError(t, pm.SaveSnapshot())
+	_, values, err := db.Load()
+	require.NoError(t, err)
+	require.Len(t, values, 2)
+	stx, err := unmarshalSnapshotTx(values[0])
+	require.NoError(t, err)
+	assert.Equal(t, byte(1), stx.desc.ShardID)
+	assert.False(t, stx.desc.StartTime.IsZero())
+	assert.Contains(t, []string{txA.Hash().String(), txB.Hash().String()}, stx.hash)
+
+	tp.RemoveTxs([]string{txA.Hash().String()})
+	require.NoError(t, pm.SaveSnapshot())
+	_, values, err = db.Load()
+	require.NoError(t, err)
+	require.Len(t, values, 1)
+	stx, err = unmarshalSnapshotTx(values[0])
+	require.NoError(t, err)
+	assert.Equal(t, txB.Hash().String(), stx.hash)
+}
+
+func TestLoadSnapshot(t *testing.T) {
+	db, closeDB := openSnapshotTestDB(t)
+	defer closeDB()
+	pm, err := NewPoolManager(2, nil, time.Minute, 0)
+	require.NoError(t, err)
+	require.NoError(t, pm.ResetSnapshot(db))
+	tp := pm.ShardTxsPool[0].(*TxsPool)
+	go tp.Start()
+	txA := newEventTestTx(1, 10)
+	txB := newEventTestTx(2, 20)
+	tp.action <- func(tp *TxsPool) {
+		tp.admitTx(txInfoTemp{tx: txA, startTime: time.Now().Add(-2 * time.Minute)})
+		tp.admitTx(txInfoTemp{tx: txB})
+	}
+	require.NoError(t, pm.SaveSnapshot())
+	tp.Stop()
+	require.NoError(t, db.Put([]byte("tx-invalid"), []byte("invalid")))
+
+	// restart, the txs of the snapshot are validated again
+	verifier := &snapshotTestVerifier{}
+	sView := &snapshotTestShardView{}
+	pm, err = NewPoolManager(2, nil, time.Minute, 0)
+	require.NoError(t, err)
+	pm.ShardTxsPool[0].UpdateTxVerifier(verifier)
+	err = pm.LoadSnapshot(db, func(shardID byte) (metadata.ChainRetriever, metadata.ShardViewRetriever, metadata.BeaconViewRetriever, error) {
+		assert.Equal(t, byte(0), shardID)
+		return nil, sView, nil, nil
+	})
+	require.NoError(t, err)
+	// txA expired, txB is invalid now
+	assert.Equal(t, 1, verifier.validated)
+	_, values, err := db.Load()
+	require.NoError(t, err)
+	assert.Len(t, values, 0)
+	assert.Len(t, pm.persisted, 0)
+	assert.Len(t, pm.ShardTxsPool[0].(*TxsPool).restored, 0)
+}
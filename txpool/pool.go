@@ -8,17 +8,24 @@ import (
 
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/metrics"
 	"github.com/incognitochain/incognito-chain/privacy"
+	"github.com/incognitochain/incognito-chain/pubsub"
 	"github.com/incognitochain/incognito-chain/transaction"
 	"github.com/incognitochain/incognito-chain/transaction/tx_generic"
 	"github.com/patrickmn/go-cache"
 	"github.com/pkg/errors"
 )
 
+// defaultReplaceFeeRatio is the ratio between the fee rate of a replacement and the fee rate
+// of the tx it replaces, as in the legacy mempool
+const defaultReplaceFeeRatio = 1.1
+
 type TxInfo struct {
-	Fee   uint64
-	Size  uint64
-	VTime time.Duration
+	Fee       uint64
+	Size      uint64
+	VTime     time.Duration
+	StartTime time.Time
 }
 
 type validateResult struct {
@@ -49,6 +56,8 @@ type CoinsData struct {
 type txInfoTemp struct {
 	tx metadata.Transaction
 	vt time.Duration
+	// startTime is the time the tx entered the pool before a restart
+	startTime time.Time
 }
 
 type TxsPool struct {
@@ -60,9 +69,17 @@ type TxsPool struct {
 	isRunning bool
 	sttLock   *sync.RWMutex
 	cQuit     chan bool
-	better    func(txA, txB metadata.Transaction) bool
 	ttl       time.Duration
 	CData     CoinsData
+	shardID   byte
+	ps        *pubsub.PubSubManager
+	eventSeq  uint64
+	feeIndex  *feeRateIndex
+	// maxTx is the maximum number of txs in pool, 0 for no limit
+	maxTx           uint64
+	replaceFeeRatio float64
+	// restored are the txs of a snapshot, added into pool when it starts
+	restored []txInfoTemp
 }
 
 func NewTxsPool(
@@ -82,24 +99,19 @@ func NewTxsPool(
 		isRunning: false,
 		sttLock:   &sync.RWMutex{},
 		cQuit:     make(chan bool),
-		better: func(txA, txB metadata.Transaction) bool {
-			if txA.GetTxFee() > 0 {
-				return txA.GetTxFee() >= txB.GetTxFee()
-			} else {
-				return txA.GetTxFeeToken() >= txB.GetTxFeeToken()
-			}
-		},
-		ttl: ttl,
+		ttl:       ttl,
 		CData: CoinsData{
 			locker:        &sync.RWMutex{},
 			TxHashByCoin:  map[string]string{},
 			CoinsByTxHash: map[string][]string{},
 		},
+		feeIndex:        newFeeRateIndex(),
+		replaceFeeRatio: defaultReplaceFeeRatio,
 	}
 	removeTx := func(txHash string, arg interface{}) {
 		go func(txPool *TxsPool, target string) {
 			if txPool.IsRunning() {
-				tp.RemoveTx(target)
+				tp.removeTxs([]string{target}, RemoveReasonTTL)
 			}
 			txPool.CData.locker.Lock()
 			if listCoins, ok := txPool.CData.CoinsByTxHash[txHash]; ok {
@@ -138,7 +150,12 @@ func (tp *TxsPool) Start() {
 	}
 	Logger.Infof("Start transaction pool v1")
 	tp.isRunning = true
+	restored := tp.restored
+	tp.restored = nil
 	tp.sttLock.Unlock()
+	for _, validTx := range restored {
+		tp.admitTx(validTx)
+	}
 	cValidTxs := make(chan txInfoTemp, 1024)
 	stopGetTxs := make(chan interface{})
 	go tp.getTxs(stopGetTxs, cValidTxs)
@@ -168,100 +185,113 @@ func (tp *TxsPool) Start() {
 			f(tp)
 			Logger.Debugf("Total txs in pool %v after func\n", len(tp.Data.TxInfos))
 		case validTx := <-cValidTxs:
-			isDoubleSpend, needToRemove, txToRemove, listKeyCoin := tp.CheckDoubleSpendWithCurMem(validTx.tx)
-			if isDoubleSpend {
-				if needToRemove {
-					tp.removeDoubleSpendTx(txToRemove)
-					tp.addTx(validTx, listKeyCoin)
-				}
-			} else {
-				tp.addTx(validTx, listKeyCoin)
-			}
+			tp.admitTx(validTx)
 			total++
 
 		}
 	}
 }
 
+// admitTx adds a tx validated without chain state into the pool. A tx double spending a tx
+// of the pool only enters the pool as its replacement, see CheckDoubleSpendWithCurMem. When
+// the pool has maxTx txs, the tx with the lowest fee rate among the txs paying fee in the same
+// token is evicted if the new tx pays a higher fee rate, otherwise the new tx is dropped.
+// Fee rates in different tokens are not converted, so a tx only evicts txs paying fee in its
+// token: a PRV-fee tx never evicts a token-fee tx, whatever their rates, and is dropped when
+// the pool is full of token-fee txs, and the other way around.
+func (tp *TxsPool) admitTx(validTx txInfoTemp) {
+	txHash := validTx.tx.Hash().String()
+	isDoubleSpend, canReplace, txToReplace, listKeyCoin := tp.CheckDoubleSpendWithCurMem(validTx.tx)
+	if isDoubleSpend {
+		if !canReplace {
+			Logger.Debugf("[txTracing] Drop tx %v, double spend with tx %v in pool", txHash, txToReplace)
+			return
+		}
+		Logger.Infof("Tx %v replaces tx %v by fee", txHash, txToReplace)
+		tp.removeTxAndCoins(txToReplace, RemoveReasonReplaced)
+	} else if (tp.maxTx > 0) && (uint64(len(tp.Data.TxByHash)) >= tp.maxTx) {
+		feeRate := getFeeRate(validTx.tx)
+		txToEvict, rate, ok := tp.feeIndex.lowest(feeRate.TokenID)
+		if !ok || rate >= feeRate.Rate {
+			Logger.Infof("Pool of shard %v is full (%v txs), drop tx %v with fee rate %v", tp.shardID, len(tp.Data.TxByHash), txHash, feeRate.Rate)
+			return
+		}
+		Logger.Infof("Pool of shard %v is full (%v txs), evict tx %v with fee rate %v for tx %v with fee rate %v", tp.shardID, len(tp.Data.TxByHash), txToEvict, rate, txHash, feeRate.Rate)
+		tp.removeTxAndCoins(txToEvict, RemoveReasonEvicted)
+	}
+	tp.addTx(validTx, listKeyCoin)
+}
+
+// CheckDoubleSpendWithCurMem returns whether target spends a coin of a tx in pool, whether
+// target can replace that tx, the hash of that tx and the keys of the coins of target.
+// A replacement must spend exactly the same serial numbers as the tx it replaces, pay
+// its fee in the same token, and pay a higher fee with more than replaceFeeRatio times its fee rate.
 func (tp *TxsPool) CheckDoubleSpendWithCurMem(target metadata.Transaction) (bool, bool, string, []string) {
 	tp.CData.locker.RLock()
 	defer tp.CData.locker.RUnlock()
 	listkey := []string{}
-	isDoubleSpend := false
-	neededToReplace := true
-	txHash := ""
+	conflicts := map[string]metadata.Transaction{}
+	checkCoin := func(key string, isOutput bool, publicKey []byte) {
+		listkey = append(listkey, key)
+		h, ok := tp.CData.TxHashByCoin[key]
+		if !ok {
+			return
+		}
+		if isOutput && common.IsPublicKeyBurningAddress(publicKey) {
+			return
+		}
+		if tx, ok := tp.Data.TxByHash[h]; (ok) && (tx != nil) {
+			conflicts[h] = tx
+		}
+	}
 	prf := target.GetProof()
 	if prf != nil {
 		for _, iCoin := range prf.GetInputCoins() {
-			key := fmt.Sprintf("%v-%v", common.PRVCoinID.String(), string(iCoin.GetKeyImage().ToBytesS()))
-			if h, ok := tp.CData.TxHashByCoin[key]; ok {
-				isDoubleSpend = true
-				if tx, ok := tp.Data.TxByHash[h]; (ok) && (tx != nil) {
-					txHash = tx.Hash().String()
-					if tp.better(tx, target) {
-						neededToReplace = false
-						return isDoubleSpend, neededToReplace, txHash, listkey
-					}
-				}
-			}
-			listkey = append(listkey, key)
+			checkCoin(fmt.Sprintf("%v-%v", common.PRVCoinID.String(), string(iCoin.GetKeyImage().ToBytesS())), false, nil)
 		}
 		for _, oCoin := range prf.GetOutputCoins() {
-			oCoinID := oCoin.GetCoinID()
-			key := fmt.Sprintf("%v-%v", common.PRVCoinID.String(), oCoinID)
-			if h, ok := tp.CData.TxHashByCoin[key]; ok {
-				if common.IsPublicKeyBurningAddress(oCoin.GetPublicKey().ToBytesS()) {
-					continue
-				}
-				isDoubleSpend = true
-				if tx, ok := tp.Data.TxByHash[h]; (ok) && (tx != nil) {
-					txHash = tx.Hash().String()
-					if tp.better(tx, target) {
-						neededToReplace = false
-						return isDoubleSpend, neededToReplace, txHash, listkey
-					}
-				}
-			}
-			listkey = append(listkey, key)
+			checkCoin(fmt.Sprintf("%v-%v", common.PRVCoinID.String(), oCoin.GetCoinID()), true, oCoin.GetPublicKey().ToBytesS())
 		}
 	}
 	if target.GetType() == common.TxCustomTokenPrivacyType {
 		txNormal := target.(transaction.TransactionToken).GetTxNormal()
 		tokenID := target.(transaction.TransactionToken).GetTxTokenData().PropertyID
 		normalPrf := txNormal.GetProof()
-		for _, iCoin := range normalPrf.GetInputCoins() {
-			key := fmt.Sprintf("%v-%v", tokenID.String(), iCoin.GetKeyImage().ToBytes())
-			if h, ok := tp.CData.TxHashByCoin[key]; ok {
-				isDoubleSpend = true
-				if tx, ok := tp.Data.TxByHash[h]; (ok) && (tx != nil) {
-					txHash = tx.Hash().String()
-					if tp.better(tx, target) {
-						neededToReplace = false
-						return isDoubleSpend, neededToReplace, txHash, listkey
-					}
-				}
+		if normalPrf != nil {
+			for _, iCoin := range normalPrf.GetInputCoins() {
+				checkCoin(fmt.Sprintf("%v-%v", tokenID.String(), iCoin.GetKeyImage().ToBytes()), false, nil)
 			}
-			listkey = append(listkey, key)
-		}
-		for _, oCoin := range normalPrf.GetOutputCoins() {
-			key := fmt.Sprintf("%v-%v", tokenID.String(), oCoin.GetCoinID())
-			if h, ok := tp.CData.TxHashByCoin[key]; ok {
-				if common.IsPublicKeyBurningAddress(oCoin.GetPublicKey().ToBytesS()) {
-					continue
-				}
-				isDoubleSpend = true
-				if tx, ok := tp.Data.TxByHash[h]; (ok) && (tx != nil) {
-					txHash = tx.Hash().String()
-					if tp.better(tx, target) {
-						neededToReplace = false
-						return isDoubleSpend, neededToReplace, txHash, listkey
-					}
-				}
+			for _, oCoin := range normalPrf.GetOutputCoins() {
+				checkCoin(fmt.Sprintf("%v-%v", tokenID.String(), oCoin.GetCoinID()), true, oCoin.GetPublicKey().ToBytesS())
 			}
-			listkey = append(listkey, key)
 		}
 	}
-	return isDoubleSpend, neededToReplace, txHash, listkey
+	if len(conflicts) == 0 {
+		return false, false, "", listkey
+	}
+	txHash, tx := "", metadata.Transaction(nil)
+	for h, t := range conflicts {
+		txHash, tx = h, t
+		break
+	}
+	if len(conflicts) > 1 {
+		return true, false, txHash, listkey
+	}
+	return true, tp.isReplacement(tx, target), txHash, listkey
+}
+
+func (tp *TxsPool) isReplacement(oldTx, newTx metadata.Transaction) bool {
+	oldSerialNumbers := oldTx.ListSerialNumbersHashH()
+	newSerialNumbers := newTx.ListSerialNumbersHashH()
+	if (len(oldSerialNumbers) == 0) || (common.HashArrayOfHashArray(oldSerialNumbers) != common.HashArrayOfHashArray(newSerialNumbers)) {
+		return false
+	}
+	oldFeeRate := getFeeRate(oldTx)
+	newFeeRate := getFeeRate(newTx)
+	if oldFeeRate.TokenID != newFeeRate.TokenID {
+		return false
+	}
+	return (newFeeRate.Fee > oldFeeRate.Fee) && (float64(newFeeRate.Rate) > float64(oldFeeRate.Rate)*tp.replaceFeeRatio)
 }
 
 func (tp *TxsPool) addTx(validTx txInfoTemp, listCoinKey []string) {
@@ -269,28 +299,47 @@ func (tp *TxsPool) addTx(validTx txInfoTemp, listCoinKey []string) {
 	tp.CData.locker.Unlock()
 	txH := validTx.tx.Hash().String()
 	tp.Data.TxByHash[txH] = validTx.tx
+	startTime := validTx.startTime
+	if startTime.IsZero() {
+		startTime = time.Now()
+	}
 	tp.Data.TxInfos[txH] = TxInfo{
-		Fee:   validTx.tx.GetTxFee(),
-		Size:  validTx.tx.GetTxActualSize(),
-		VTime: validTx.vt,
+		Fee:       validTx.tx.GetTxFee(),
+		Size:      validTx.tx.GetTxActualSize(),
+		VTime:     validTx.vt,
+		StartTime: startTime,
 	}
+	tp.feeIndex.add(txH, getFeeRate(validTx.tx))
 	tp.CData.CoinsByTxHash[validTx.tx.Hash().String()] = listCoinKey
 	for _, v := range listCoinKey {
 		tp.CData.TxHashByCoin[v] = validTx.tx.Hash().String()
 	}
+	tp.publishEvent(EventTxAdded, "", txH, validTx.tx)
+	tp.updateSizeGauge()
 }
 
-func (tp *TxsPool) removeDoubleSpendTx(txH string) {
+func (tp *TxsPool) removeTxAndCoins(txH string, reason string) {
 	tp.CData.locker.Lock()
 	tp.CData.locker.Unlock()
+	tx, existed := tp.Data.TxByHash[txH]
 	delete(tp.Data.TxByHash, txH)
 	delete(tp.Data.TxInfos, txH)
+	tp.feeIndex.remove(txH)
 	if keyList, ok := tp.CData.CoinsByTxHash[txH]; ok {
 		for _, key := range keyList {
 			delete(tp.CData.TxHashByCoin, key)
 		}
 	}
 	delete(tp.CData.CoinsByTxHash, txH)
+	if existed {
+		tp.publishEvent(EventTxRemoved, reason, txH, tx)
+		tp.updateSizeGauge()
+	}
+}
+
+// updateSizeGauge exports the number of txs in pool as "txpool/<shardID>/size"
+func (tp *TxsPool) updateSizeGauge() {
+	metrics.GetOrRegisterGauge(fmt.Sprintf("txpool/%v/size", tp.shardID), nil).Update(int64(len(tp.Data.TxByHash)))
 }
 
 func (tp *TxsPool) Stop() {
@@ -299,19 +348,27 @@ func (tp *TxsPool) Stop() {
 	}
 }
 
+// RemoveTx removes a tx on request of the user
 func (tp *TxsPool) RemoveTx(txHash string) {
-	tp.action <- func(tpTemp *TxsPool) {
-		Logger.Debugf("Removing tx %v at %v", txHash, time.Now())
-		delete(tpTemp.Data.TxByHash, txHash)
-		delete(tpTemp.Data.TxInfos, txHash)
-	}
+	Logger.Debugf("Removing tx %v at %v", txHash, time.Now())
+	tp.removeTxs([]string{txHash}, RemoveReasonManual)
 }
 
+// RemoveTxs removes the txs included in a new shard block
 func (tp *TxsPool) RemoveTxs(txHashes []string) {
+	tp.removeTxs(txHashes, RemoveReasonIncluded)
+}
+
+func (tp *TxsPool) removeTxs(txHashes []string, reason string) {
 	tp.action <- func(tpTemp *TxsPool) {
-		for _, tx := range txHashes {
-			delete(tpTemp.Data.TxByHash, tx)
-			delete(tpTemp.Data.TxInfos, tx)
+		for _, txHash := range txHashes {
+			tx, ok := tpTemp.Data.TxByHash[txHash]
+			delete(tpTemp.Data.TxByHash, txHash)
+			delete(tpTemp.Data.TxInfos, txHash)
+			tpTemp.feeIndex.remove(txHash)
+			if ok {
+				tpTemp.publishEvent(EventTxRemoved, reason, txHash, tx)
+			}
 		}
 	}
 }
@@ -387,9 +444,10 @@ func (tp *TxsPool) FilterWithNewView(
 	sDB := sView.GetCopiedTransactionStateDB()
 	txsData := tp.snapshotPool()
 	txsToRemove := []string{}
+	txsDoubleSpend := []string{}
 	txsValid := []metadata.Transaction{}
 	defer func() {
-		Logger.Infof("SHARD %v | Filter mempool with bview %v, sview %v; del %v txs, remaining %v \n", sView.GetShardID(), bcView.GetHeight(), sView.GetHeight(), len(txsToRemove), len(txsValid))
+		Logger.Infof("SHARD %v | Filter mempool with bview %v, sview %v; del %v txs, remaining %v \n", sView.GetShardID(), bcView.GetHeight(), sView.GetHeight(), len(txsToRemove)+len(txsDoubleSpend), len(txsValid))
 	}()
 	for txHash, tx := range txsData.TxByHash {
 		if tp.isDoubleStake(mapForChkDbStake, tx) {
@@ -415,11 +473,11 @@ func (tp *TxsPool) FilterWithNewView(
 		}
 		isDoubleSpend, needToReplace, _, removeIdx := tp.CheckDoubleSpend(mapForChkDbSpend, tx, &txsValid)
 		if isDoubleSpend && !needToReplace {
-			txsToRemove = append(txsToRemove, txHash)
+			txsDoubleSpend = append(txsDoubleSpend, txHash)
 			continue
 		}
 		for k := range removeIdx {
-			txsToRemove = append(txsToRemove, txsValid[k].Hash().String())
+			txsDoubleSpend = append(txsDoubleSpend, txsValid[k].Hash().String())
 			txsValid[k] = nil
 		}
 		if info, ok := tp.Data.TxInfos[txHash]; ok {
@@ -439,10 +497,11 @@ func (tp *TxsPool) FilterWithNewView(
 		}
 	}
 	if tp.IsRunning() {
-		tp.RemoveTxs(txsToRemove)
+		tp.removeTxs(txsToRemove, RemoveReasonInvalid)
+		tp.removeTxs(txsDoubleSpend, RemoveReasonDoubleSpend)
 	}
-	if len(txsToRemove) > 0 {
-		Logger.Infof("Remove %+v txs when validate with new sView %v bView %v", txsToRemove, sView.GetHeight(), bcView.GetHeight())
+	if len(txsToRemove)+len(txsDoubleSpend) > 0 {
+		Logger.Infof("Remove %+v invalid txs, %+v double spend txs when validate with new sView %v bView %v", txsToRemove, txsDoubleSpend, sView.GetHeight(), bcView.GetHeight())
 	}
 }
 
@@ -631,7 +690,7 @@ func (tp *TxsPool) checkPrfDoubleSpend(
 				needToReplace = true
 				continue
 			}
-			if tp.better(info.Detail.Tx, tx) {
+			if !tp.isReplacement(info.Detail.Tx, tx) {
 				return true, false, removeIdx, removedInfos
 			} else {
 				removeIdx[info.Index] = nil
@@ -651,7 +710,7 @@ func (tp *TxsPool) checkPrfDoubleSpend(
 			if _, ok := removeIdx[info.Index]; ok {
 				continue
 			}
-			if tp.better(info.Detail.Tx, tx) {
+			if !tp.isReplacement(info.Detail.Tx, tx) {
 				return true, false, removeIdx, removedInfos
 			} else {
 				removeIdx[info.Index] = nil
@@ -772,8 +831,8 @@ func (tp *TxsPool) getTxs(quit <-chan interface{}, cValidTxs chan txInfoTemp) {
 				}
 				if (isValid) && (cValidTxs != nil) {
 					cValidTxs <- txInfoTemp{
-						msg,
-						vTime,
+						tx: msg,
+						vt: vTime,
 					}
 				}
 			}()
@@ -838,7 +897,8 @@ func (tp *TxsPool) getTxsFromPool(
 			close(txCh)
 			Logger.Debug("tx channel is closed")
 		}()
-		for k, v := range tpTemp.Data.TxByHash {
+		for _, k := range tpTemp.feeIndex.ordered() {
+			v := tpTemp.Data.TxByHash[k]
 			select {
 			case <-stopC:
 				return
@@ -1,9 +1,11 @@
 package txpool
 
 import (
+	"sync"
 	"time"
 
 	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/databasemp"
 	"github.com/incognitochain/incognito-chain/metadata"
 	"github.com/incognitochain/incognito-chain/privacy"
 	"github.com/incognitochain/incognito-chain/pubsub"
@@ -14,12 +16,18 @@ type PoolManager struct {
 	ShardTxsPool []TxPool
 	// newRoleEChs  pubsub.EventChannel
 	ps *pubsub.PubSubManager
+
+	// snapshot of the pools, see persistence.go
+	snapshotLock sync.Mutex
+	db           databasemp.DatabaseInterface
+	persisted    map[string]byte
 }
 
 func NewPoolManager(
 	activeShards int,
 	ps *pubsub.PubSubManager,
 	ttl time.Duration,
+	maxTx uint64,
 ) (
 	*PoolManager,
 	error,
@@ -28,7 +36,11 @@ func NewPoolManager(
 		ps: ps,
 	}
 	for i := 0; i < activeShards; i++ {
-		res.ShardTxsPool = append(res.ShardTxsPool, NewTxsPool(nil, make(chan metadata.Transaction, 128), ttl))
+		txsPool := NewTxsPool(nil, make(chan metadata.Transaction, 128), ttl)
+		txsPool.shardID = byte(i)
+		txsPool.ps = ps
+		txsPool.maxTx = maxTx
+		res.ShardTxsPool = append(res.ShardTxsPool, txsPool)
 	}
 
 	return res, nil
@@ -0,0 +1,191 @@
+// genrpcclient generates the methods of a Go RPC client from the OpenRPC document of the node:
+// every typed method with a Go name becomes a method of the client type.
+//
+// The client type must define sendRequest(requestBody []byte) ([]byte, error) and the
+// error type ErrMsg. The document is built from the rpcserver package, or fetched with
+// rpc.discover from a node when -endpoint is set.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/incognitochain/incognito-chain/rpcserver"
+	"github.com/incognitochain/incognito-chain/rpcserver/jsonresult"
+)
+
+var clientTemplate = template.Must(template.New("client").Parse(`// Code generated by utility/genrpcclient from the OpenRPC document of rpcserver. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+{{- range .StdImports}}
+	"{{.}}"
+{{- end}}
+{{range .Imports}}
+	"{{.}}"
+{{- end}}
+)
+{{range .Methods}}
+func (r *{{$.Type}}) {{.GoName}}({{range $i, $p := .Params}}{{if $i}}, {{end}}{{$p.Name}} {{$p.GoType}}{{end}}) (res {{.Result.GoType}}, err error) {
+	err = r.call("{{.Name}}", []interface{}{ {{- range $i, $p := .Params}}{{if $i}}, {{end}}{{$p.Name}}{{end -}} }, &res)
+	return res, err
+}
+{{end}}
+func (r *{{.Type}}) call(method string, params []interface{}, result interface{}) error {
+	requestBody, err := json.Marshal(map[string]interface{}{
+		"jsonrpc": "1.0",
+		"method":  method,
+		"params":  params,
+		"id":      1,
+	})
+	if err != nil {
+		return err
+	}
+	body, err := r.sendRequest(requestBody)
+	if err != nil {
+		return err
+	}
+	resp := struct {
+		Result json.RawMessage
+		Error  *ErrMsg
+	}{}
+	err = json.Unmarshal(body, &resp)
+	if resp.Error != nil && resp.Error.StackTrace != "" {
+		return errors.New(resp.Error.StackTrace)
+	}
+	if err != nil {
+		return err
+	}
+	if len(resp.Result) == 0 {
+		return nil
+	}
+	return json.Unmarshal(resp.Result, result)
+}
+`))
+
+type clientData struct {
+	Package    string
+	Type       string
+	StdImports []string
+	Imports    []string
+	Methods    []jsonresult.OpenRPCMethod
+}
+
+func main() {
+	endpoint := flag.String("endpoint", "", "RPC endpoint of the node to get the OpenRPC document from, empty to build it from rpcserver")
+	pkg := flag.String("package", "devframework", "package of the generated file")
+	clientType := flag.String("type", "RemoteRPCClient", "client type the methods are generated for")
+	output := flag.String("output", "remoteRPCClient.go", "generated file")
+	flag.Parse()
+
+	doc := rpcserver.NewOpenRPCDocument()
+	if *endpoint != "" {
+		var err error
+		doc, err = discover(*endpoint)
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	code, err := generateClient(doc, *pkg, *clientType)
+	if err != nil {
+		panic(err)
+	}
+	if err := ioutil.WriteFile(*output, code, 0644); err != nil {
+		panic(err)
+	}
+	fmt.Println("generated", *output)
+}
+
+// discover gets the OpenRPC document of the node at endpoint
+func discover(endpoint string) (*jsonresult.OpenRPCDocument, error) {
+	requestBody, err := json.Marshal(map[string]interface{}{
+		"jsonrpc": "1.0",
+		"method":  "rpc.discover",
+		"params":  []interface{}{},
+		"id":      1,
+	})
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.Post(endpoint, "application/json", bytes.NewBuffer(requestBody))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	result := struct {
+		Result *jsonresult.OpenRPCDocument
+		Error  *struct {
+			Message    string
+			StackTrace string
+		}
+	}{}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, err
+	}
+	if result.Error != nil {
+		return nil, errors.New(result.Error.StackTrace)
+	}
+	if result.Result == nil {
+		return nil, errors.New("no OpenRPC document from " + endpoint)
+	}
+	return result.Result, nil
+}
+
+func generateClient(doc *jsonresult.OpenRPCDocument, pkg string, clientType string) ([]byte, error) {
+	data := clientData{
+		Package:    pkg,
+		Type:       clientType,
+		StdImports: []string{"encoding/json", "errors"},
+	}
+	imports := make(map[string]bool)
+	for _, method := range doc.Methods {
+		if method.Untyped || method.GoName == "" {
+			continue
+		}
+		if method.Result == nil || method.Result.GoType == "" {
+			return nil, fmt.Errorf("method %s has no Go result type", method.Name)
+		}
+		descriptors := append([]jsonresult.OpenRPCContentDescriptor{*method.Result}, method.Params...)
+		for _, descriptor := range descriptors {
+			if descriptor.GoType == "" {
+				return nil, fmt.Errorf("param %s of method %s has no Go type", descriptor.Name, method.Name)
+			}
+			for _, goImport := range descriptor.GoImports {
+				if !strings.Contains(descriptor.GoType, path.Base(goImport)+".") {
+					return nil, fmt.Errorf("package %s is not named after its path in %s", goImport, descriptor.GoType)
+				}
+				imports[goImport] = true
+			}
+		}
+		data.Methods = append(data.Methods, method)
+	}
+	sort.Slice(data.Methods, func(i, j int) bool {
+		return data.Methods[i].GoName < data.Methods[j].GoName
+	})
+	for goImport := range imports {
+		data.Imports = append(data.Imports, goImport)
+	}
+	sort.Strings(data.Imports)
+
+	code := &bytes.Buffer{}
+	if err := clientTemplate.Execute(code, data); err != nil {
+		return nil, err
+	}
+	return format.Source(code.Bytes())
+}
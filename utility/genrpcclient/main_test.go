@@ -0,0 +1,36 @@
+package main
+
+import (
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/rpcserver"
+)
+
+func TestGenerateClient(t *testing.T) {
+	code, err := generateClient(rpcserver.NewOpenRPCDocument(), "devframework", "RemoteRPCClient")
+	if err != nil {
+		t.Fatalf("Expect no error but get %+v", err)
+	}
+	file, err := parser.ParseFile(token.NewFileSet(), "remoteRPCClient.go", code, 0)
+	if err != nil {
+		t.Fatalf("Expect valid Go code but get %+v", err)
+	}
+	if file.Name.Name != "devframework" {
+		t.Fatalf("Expect package devframework but get %+v", file.Name.Name)
+	}
+	for _, expected := range []string{
+		`func (r *RemoteRPCClient) GetBlockHash(chainID float64, height float64) (res []common.Hash, err error) {`,
+		`err = r.call("getblockhash", []interface{}{chainID, height}, &res)`,
+		`"github.com/incognitochain/incognito-chain/common"`,
+	} {
+		if !strings.Contains(string(code), expected) {
+			t.Fatalf("Expect the generated code to contain %+v", expected)
+		}
+	}
+	if strings.Contains(string(code), "rpc.discover") {
+		t.Fatal("Expect no method without Go name")
+	}
+}
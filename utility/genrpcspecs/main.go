@@ -0,0 +1,989 @@
+// genrpcspecs generates the declarations of the RPC methods of rpcserver from their handlers, for the
+// methods of HttpHandler, LimitedHttpHandler and WsHandler not declared by hand in rpcMethodSpecs:
+// the params are the types the handler asserts on the positional params, following the params through
+// the functions of rpcserver it passes them to, and the result is the type the handler returns when it is
+// the same concrete type on every path, unknown otherwise.
+//
+// The package is type checked from its sources and the export data of its dependencies built by go list,
+// with an empty map in place of the generated file so that a stale one does not stop the generation.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/format"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const rpcserverPath = "github.com/incognitochain/incognito-chain/rpcserver"
+
+// handlerMaps are the handler maps of rpcserver, by precedence when a method is in several of them
+var handlerMaps = []string{"HttpHandler", "LimitedHttpHandler", "WsHandler"}
+
+// handSpecs is the map of the methods declared by hand
+const handSpecs = "rpcMethodSpecs"
+
+// unsubscribeResult is sent by the websocket handlers when the subscription ends, it is not their result
+const unsubscribeResult = rpcserverPath + "/jsonresult.UnsubcribeResult"
+
+func main() {
+	output := flag.String("output", "openrpc_generated.go", "generated file")
+	flag.Parse()
+
+	code, err := generate(rpcserverPath, filepath.Base(*output))
+	if err != nil {
+		panic(err)
+	}
+	if err := ioutil.WriteFile(*output, code, 0644); err != nil {
+		panic(err)
+	}
+	fmt.Println("generated", *output)
+}
+
+// generate returns the generated declarations of the package at path, written in its file output
+func generate(path string, output string) ([]byte, error) {
+	pkg, err := loadPackage(path, output)
+	if err != nil {
+		return nil, err
+	}
+	specs, err := pkg.inferSpecs()
+	if err != nil {
+		return nil, err
+	}
+	return pkg.generateSpecs(specs)
+}
+
+type typedPackage struct {
+	fset  *token.FileSet
+	files []*ast.File
+	pkg   *types.Package
+	info  *types.Info
+	funcs map[*types.Func]*ast.FuncDecl
+}
+
+type listedPackage struct {
+	ImportPath string
+	Name       string
+	Dir        string
+	Export     string
+	GoFiles    []string
+	Imports    []string
+}
+
+// goList returns the packages listed by go list with args
+func goList(args ...string) ([]*listedPackage, error) {
+	cmd := exec.Command("go", append([]string{"list", "-json"}, args...)...)
+	cmd.Stderr = os.Stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("go list %s: %v", strings.Join(args, " "), err)
+	}
+	packages := []*listedPackage{}
+	decoder := json.NewDecoder(bytes.NewReader(out))
+	for decoder.More() {
+		listed := &listedPackage{}
+		if err := decoder.Decode(listed); err != nil {
+			return nil, err
+		}
+		packages = append(packages, listed)
+	}
+	return packages, nil
+}
+
+// loadPackage type checks the package at path, without its generated file output
+func loadPackage(path string, output string) (*typedPackage, error) {
+	listed, err := goList(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(listed) != 1 {
+		return nil, fmt.Errorf("package %s is not listed", path)
+	}
+	target := listed[0]
+	// the package itself is not built, its generated file may not compile
+	deps, err := goList(append([]string{"-export", "-deps"}, target.Imports...)...)
+	if err != nil {
+		return nil, err
+	}
+	exports := make(map[string]string)
+	for _, dep := range deps {
+		exports[dep.ImportPath] = dep.Export
+	}
+
+	p := &typedPackage{
+		fset: token.NewFileSet(),
+		info: &types.Info{
+			Types: make(map[ast.Expr]types.TypeAndValue),
+			Defs:  make(map[*ast.Ident]types.Object),
+			Uses:  make(map[*ast.Ident]types.Object),
+		},
+		funcs: make(map[*types.Func]*ast.FuncDecl),
+	}
+	for _, name := range target.GoFiles {
+		var src interface{}
+		if name == output {
+			src = "package " + target.Name + "\n\nvar generatedRPCMethodSpecs map[string]rpcMethodSpec\n"
+		}
+		file, err := parser.ParseFile(p.fset, filepath.Join(target.Dir, name), src, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+		p.files = append(p.files, file)
+	}
+	lookup := func(importPath string) (io.ReadCloser, error) {
+		export, ok := exports[importPath]
+		if !ok || export == "" {
+			return nil, fmt.Errorf("no export data for %s", importPath)
+		}
+		return os.Open(export)
+	}
+	conf := types.Config{Importer: importer.ForCompiler(p.fset, "gc", lookup)}
+	if p.pkg, err = conf.Check(path, p.fset, p.files, p.info); err != nil {
+		return nil, err
+	}
+	for _, file := range p.files {
+		for _, decl := range file.Decls {
+			if fn, ok := decl.(*ast.FuncDecl); ok && fn.Body != nil {
+				if obj, ok := p.info.Defs[fn.Name].(*types.Func); ok {
+					p.funcs[obj] = fn
+				}
+			}
+		}
+	}
+	return p, nil
+}
+
+type methodSpec struct {
+	// Key is the expression of the method name in the handler map
+	Key     string
+	Name    string
+	Summary string
+	Params  []*paramSpec
+	Result  types.Type
+}
+
+type paramSpec struct {
+	Name     string
+	Type     types.Type
+	Optional bool
+}
+
+// inferSpecs returns the specs of the methods of the handler maps without hand-written spec
+func (p *typedPackage) inferSpecs() ([]*methodSpec, error) {
+	declared := make(map[string]bool)
+	specs := make(map[string]*methodSpec)
+	for _, mapName := range append([]string{handSpecs}, handlerMaps...) {
+		entries, err := p.mapEntries(mapName)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			name, err := p.constantString(entry.Key)
+			if err != nil {
+				return nil, err
+			}
+			if mapName == handSpecs {
+				declared[name] = true
+				continue
+			}
+			if declared[name] || specs[name] != nil {
+				continue
+			}
+			fn, err := p.handlerFunc(entry.Value)
+			if err != nil {
+				return nil, fmt.Errorf("handler of %s: %v", name, err)
+			}
+			specs[name] = p.inferSpec(entry.Key, name, fn, mapName == "WsHandler")
+		}
+	}
+	result := make([]*methodSpec, 0, len(specs))
+	for _, spec := range specs {
+		result = append(result, spec)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result, nil
+}
+
+// mapEntries returns the entries of the package level map mapName, from its literal and its index assignments
+func (p *typedPackage) mapEntries(mapName string) ([]*ast.KeyValueExpr, error) {
+	entries := []*ast.KeyValueExpr{}
+	found := false
+	for _, file := range p.files {
+		ast.Inspect(file, func(node ast.Node) bool {
+			switch node := node.(type) {
+			case *ast.ValueSpec:
+				for i, name := range node.Names {
+					if name.Name != mapName || i >= len(node.Values) || p.info.Defs[name].Parent() != p.pkg.Scope() {
+						continue
+					}
+					if literal, ok := node.Values[i].(*ast.CompositeLit); ok {
+						found = true
+						for _, elt := range literal.Elts {
+							if kv, ok := elt.(*ast.KeyValueExpr); ok {
+								entries = append(entries, kv)
+							}
+						}
+					}
+				}
+			case *ast.AssignStmt:
+				for i, lhs := range node.Lhs {
+					index, ok := lhs.(*ast.IndexExpr)
+					if !ok || len(node.Lhs) != len(node.Rhs) {
+						continue
+					}
+					if ident, ok := index.X.(*ast.Ident); ok && ident.Name == mapName && p.info.Uses[ident].Parent() == p.pkg.Scope() {
+						entries = append(entries, &ast.KeyValueExpr{Key: index.Index, Value: node.Rhs[i]})
+					}
+				}
+			}
+			return true
+		})
+	}
+	if !found {
+		return nil, fmt.Errorf("cannot find map %s", mapName)
+	}
+	return entries, nil
+}
+
+func (p *typedPackage) constantString(expr ast.Expr) (string, error) {
+	tv, ok := p.info.Types[expr]
+	if !ok || tv.Value == nil || tv.Value.Kind() != constant.String {
+		return "", fmt.Errorf("method name %s is not a constant string", p.source(expr))
+	}
+	return constant.StringVal(tv.Value), nil
+}
+
+// handlerFunc returns the declaration of the method expression of a handler map
+func (p *typedPackage) handlerFunc(expr ast.Expr) (*ast.FuncDecl, error) {
+	var ident *ast.Ident
+	switch expr := expr.(type) {
+	case *ast.SelectorExpr:
+		ident = expr.Sel
+	case *ast.Ident:
+		ident = expr
+	default:
+		return nil, fmt.Errorf("unexpected handler %s", p.source(expr))
+	}
+	obj, ok := p.info.Uses[ident].(*types.Func)
+	if !ok || p.funcs[obj] == nil {
+		return nil, fmt.Errorf("cannot find handler %s", p.source(expr))
+	}
+	return p.funcs[obj], nil
+}
+
+func (p *typedPackage) inferSpec(key ast.Expr, name string, fn *ast.FuncDecl, isWs bool) *methodSpec {
+	spec := &methodSpec{
+		Key:     p.source(key),
+		Name:    name,
+		Summary: summary(fn),
+	}
+	if _, ok := key.(*ast.BasicLit); ok {
+		spec.Key = strconv.Quote(name)
+	}
+	reads := newParamReads()
+	if params := funcParams(fn); len(params) > 0 {
+		p.readParams(fn, map[types.Object]paramRole{p.info.Defs[params[0]]: {kind: rawParams}}, reads, map[*ast.FuncDecl]bool{})
+	}
+	spec.Params = reads.params()
+	if isWs {
+		spec.Result = p.wsResult(fn)
+	} else {
+		spec.Result = p.returnedType(fn, map[*ast.FuncDecl]bool{})
+	}
+	return spec
+}
+
+// summary returns the first sentence of the doc comment of fn, without the name of the function
+func summary(fn *ast.FuncDecl) string {
+	text := strings.TrimSpace(fn.Doc.Text())
+	if i := strings.IndexAny(text, "\n"); i >= 0 {
+		text = text[:i]
+	}
+	if i := strings.Index(text, ". "); i >= 0 {
+		text = text[:i]
+	}
+	text = strings.TrimSuffix(text, ".")
+	// the doc comments start with the name of the handler, or of the one they were copied from
+	if fields := strings.Fields(text); len(fields) > 0 &&
+		(strings.EqualFold(fields[0], fn.Name.Name) || strings.HasPrefix(fields[0], "handle")) {
+		text = strings.TrimPrefix(text, fields[0])
+	}
+	text = strings.TrimLeft(text, " -:=#")
+	if strings.Contains(text, "param") {
+		// the first line only describes the params
+		return ""
+	}
+	return strings.TrimSpace(text)
+}
+
+func funcParams(fn *ast.FuncDecl) []*ast.Ident {
+	idents := []*ast.Ident{}
+	for _, field := range fn.Type.Params.List {
+		idents = append(idents, field.Names...)
+	}
+	return idents
+}
+
+const (
+	rawParams = iota
+	arrayParams
+	paramElem
+)
+
+// paramRole is what a variable holds: the raw params, the params as a slice, or the param at index
+type paramRole struct {
+	kind  int
+	index int
+}
+
+// paramReads collects the params read by a handler by index
+type paramReads struct {
+	params_  map[int]*paramSpec
+	required int
+	// lengthChecked is set when the handler checks the number of params
+	lengthChecked bool
+}
+
+func newParamReads() *paramReads {
+	return &paramReads{params_: make(map[int]*paramSpec)}
+}
+
+func (reads *paramReads) read(index int, name string, t types.Type, guarded bool) {
+	param, ok := reads.params_[index]
+	if !ok {
+		param = &paramSpec{Optional: true}
+		reads.params_[index] = param
+	}
+	if param.Name == "" {
+		param.Name = name
+	}
+	if param.Type == nil && t != nil {
+		if _, isInterface := t.Underlying().(*types.Interface); !isInterface {
+			param.Type = t
+		}
+	}
+	param.Optional = param.Optional && guarded
+}
+
+// params returns the params up to the last one read, the ones after the required number are optional
+func (reads *paramReads) params() []*paramSpec {
+	last := -1
+	for index := range reads.params_ {
+		if index > last {
+			last = index
+		}
+	}
+	params := make([]*paramSpec, last+1)
+	names := make(map[string]bool)
+	for i := range params {
+		param, ok := reads.params_[i]
+		if !ok {
+			param = &paramSpec{Optional: true}
+		}
+		if reads.lengthChecked {
+			param.Optional = i >= reads.required
+		} else if i < reads.required {
+			param.Optional = false
+		}
+		if param.Name == "" || param.Name == "_" || names[param.Name] || token.Lookup(param.Name).IsKeyword() {
+			param.Name = fmt.Sprintf("param%d", i)
+		}
+		names[param.Name] = true
+		params[i] = param
+	}
+	// a required param cannot follow an optional one
+	for i := len(params) - 2; i >= 0; i-- {
+		if !params[i+1].Optional {
+			params[i].Optional = false
+		}
+	}
+	return params
+}
+
+// readParams collects the params read by fn from the variables of roles, and by the functions of the package
+// fn passes them to
+func (p *typedPackage) readParams(fn *ast.FuncDecl, roles map[types.Object]paramRole, reads *paramReads, visited map[*ast.FuncDecl]bool) {
+	if visited[fn] {
+		return
+	}
+	visited[fn] = true
+	defer delete(visited, fn)
+
+	roleOf := func(expr ast.Expr) (paramRole, bool) {
+		switch expr := ast.Unparen(expr).(type) {
+		case *ast.Ident:
+			role, ok := roles[p.info.Uses[expr]]
+			return role, ok
+		case *ast.IndexExpr:
+			if role, ok := roles[p.objectOf(expr.X)]; ok && role.kind == arrayParams {
+				if index, ok := p.constantInt(expr.Index); ok {
+					return paramRole{kind: paramElem, index: index}, true
+				}
+			}
+		case *ast.CallExpr:
+			// common.InterfaceSlice(params)
+			if sel, ok := expr.Fun.(*ast.SelectorExpr); ok && sel.Sel.Name == "InterfaceSlice" && len(expr.Args) == 1 {
+				if role, ok := roles[p.objectOf(expr.Args[0])]; ok && role.kind == rawParams {
+					return paramRole{kind: arrayParams}, true
+				}
+			}
+		case *ast.TypeAssertExpr:
+			if role, ok := roles[p.objectOf(expr.X)]; ok && role.kind == rawParams && expr.Type != nil {
+				if slice, ok := p.info.Types[expr.Type].Type.(*types.Slice); ok {
+					if _, ok := slice.Elem().Underlying().(*types.Interface); ok {
+						return paramRole{kind: arrayParams}, true
+					}
+				}
+			}
+		}
+		return paramRole{}, false
+	}
+
+	stack := []ast.Node{}
+	ast.Inspect(fn.Body, func(node ast.Node) bool {
+		if node == nil {
+			stack = stack[:len(stack)-1]
+			return true
+		}
+		stack = append(stack, node)
+		switch node := node.(type) {
+		case *ast.AssignStmt:
+			if len(node.Lhs) == len(node.Rhs) {
+				for i, rhs := range node.Rhs {
+					if role, ok := roleOf(rhs); ok {
+						if obj := p.objectOf(node.Lhs[i]); obj != nil {
+							roles[obj] = role
+						}
+					}
+				}
+			}
+		case *ast.ValueSpec:
+			if len(node.Names) == len(node.Values) {
+				for i, value := range node.Values {
+					if role, ok := roleOf(value); ok {
+						roles[p.info.Defs[node.Names[i]]] = role
+					}
+				}
+			}
+		case *ast.IfStmt:
+			for obj, role := range roles {
+				if role.kind == arrayParams {
+					if required, ok := requiredLength(node.Cond, obj, p.info, p.constantInt); ok && returns(node.Body) {
+						reads.lengthChecked = true
+						if required > reads.required {
+							reads.required = required
+						}
+					}
+				}
+			}
+		case *ast.TypeAssertExpr:
+			if node.Type == nil {
+				break
+			}
+			if role, ok := roleOf(node.X); ok && role.kind == paramElem {
+				reads.read(role.index, assignedName(stack), p.info.Types[node.Type].Type, p.guarded(stack, role.index, roles))
+			}
+		case *ast.IndexExpr:
+			if role, ok := roleOf(node); ok && role.kind == paramElem {
+				reads.read(role.index, "", nil, p.guarded(stack, role.index, roles))
+			}
+		case *ast.CallExpr:
+			callee := p.calledFunc(node)
+			if callee == nil {
+				break
+			}
+			calleeParams := funcParams(callee)
+			calleeRoles := make(map[types.Object]paramRole)
+			for i, arg := range node.Args {
+				if i >= len(calleeParams) {
+					break
+				}
+				if role, ok := roleOf(arg); ok {
+					calleeRoles[p.info.Defs[calleeParams[i]]] = role
+				}
+			}
+			if len(calleeRoles) > 0 {
+				p.readParams(callee, calleeRoles, reads, visited)
+			}
+		}
+		return true
+	})
+}
+
+// guarded tells whether the param at index is read in the body of a condition on the number of params above index
+func (p *typedPackage) guarded(stack []ast.Node, index int, roles map[types.Object]paramRole) bool {
+	for i := len(stack) - 2; i >= 0; i-- {
+		ifStmt, ok := stack[i].(*ast.IfStmt)
+		if !ok || stack[i+1] != ifStmt.Body {
+			continue
+		}
+		for obj, role := range roles {
+			if role.kind != arrayParams {
+				continue
+			}
+			if length, ok := guardedLength(ifStmt.Cond, obj, p.info, p.constantInt); ok && length > index {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// requiredLength returns K when cond is true for less than K params, e.g. len(params) < K
+func requiredLength(cond ast.Expr, obj types.Object, info *types.Info, constantInt func(ast.Expr) (int, bool)) (int, bool) {
+	binary, ok := ast.Unparen(cond).(*ast.BinaryExpr)
+	if !ok {
+		return 0, false
+	}
+	if binary.Op == token.LOR {
+		left, leftOk := requiredLength(binary.X, obj, info, constantInt)
+		right, rightOk := requiredLength(binary.Y, obj, info, constantInt)
+		if left < right {
+			left = right
+		}
+		return left, leftOk || rightOk
+	}
+	if !isLenOf(binary.X, obj, info) {
+		return 0, false
+	}
+	k, ok := constantInt(binary.Y)
+	if !ok {
+		return 0, false
+	}
+	switch binary.Op {
+	case token.LSS, token.NEQ:
+		return k, true
+	case token.LEQ:
+		return k + 1, true
+	case token.EQL:
+		if k == 0 {
+			return 1, true
+		}
+	}
+	return 0, false
+}
+
+// guardedLength returns K when cond is only true for K params or more, e.g. len(params) > K-1
+func guardedLength(cond ast.Expr, obj types.Object, info *types.Info, constantInt func(ast.Expr) (int, bool)) (int, bool) {
+	binary, ok := ast.Unparen(cond).(*ast.BinaryExpr)
+	if !ok {
+		return 0, false
+	}
+	if binary.Op == token.LAND {
+		if length, ok := guardedLength(binary.X, obj, info, constantInt); ok {
+			return length, true
+		}
+		return guardedLength(binary.Y, obj, info, constantInt)
+	}
+	if !isLenOf(binary.X, obj, info) {
+		return 0, false
+	}
+	k, ok := constantInt(binary.Y)
+	if !ok {
+		return 0, false
+	}
+	switch binary.Op {
+	case token.GTR:
+		return k + 1, true
+	case token.GEQ, token.EQL:
+		return k, true
+	}
+	return 0, false
+}
+
+func isLenOf(expr ast.Expr, obj types.Object, info *types.Info) bool {
+	call, ok := ast.Unparen(expr).(*ast.CallExpr)
+	if !ok || len(call.Args) != 1 {
+		return false
+	}
+	if fun, ok := call.Fun.(*ast.Ident); !ok || fun.Name != "len" {
+		return false
+	}
+	arg, ok := ast.Unparen(call.Args[0]).(*ast.Ident)
+	return ok && info.Uses[arg] == obj
+}
+
+// returns tells whether block ends by returning
+func returns(block *ast.BlockStmt) bool {
+	if len(block.List) == 0 {
+		return false
+	}
+	_, ok := block.List[len(block.List)-1].(*ast.ReturnStmt)
+	return ok
+}
+
+// assignedName returns the name of the variable the expression on top of stack is assigned to,
+// through a type conversion
+func assignedName(stack []ast.Node) string {
+	expr := stack[len(stack)-1]
+	for i := len(stack) - 2; i >= 0; i-- {
+		switch parent := stack[i].(type) {
+		case *ast.ParenExpr:
+		case *ast.CallExpr:
+			if len(parent.Args) != 1 || parent.Args[0] != expr {
+				return ""
+			}
+		case *ast.AssignStmt:
+			if len(parent.Rhs) != 1 {
+				for j, rhs := range parent.Rhs {
+					if rhs == expr {
+						if ident, ok := parent.Lhs[j].(*ast.Ident); ok {
+							return ident.Name
+						}
+					}
+				}
+				return ""
+			}
+			if ident, ok := parent.Lhs[0].(*ast.Ident); ok {
+				return ident.Name
+			}
+			return ""
+		case *ast.ValueSpec:
+			return parent.Names[0].Name
+		default:
+			return ""
+		}
+		expr = stack[i]
+	}
+	return ""
+}
+
+func (p *typedPackage) objectOf(expr ast.Expr) types.Object {
+	ident, ok := ast.Unparen(expr).(*ast.Ident)
+	if !ok {
+		return nil
+	}
+	if obj := p.info.Defs[ident]; obj != nil {
+		return obj
+	}
+	return p.info.Uses[ident]
+}
+
+func (p *typedPackage) constantInt(expr ast.Expr) (int, bool) {
+	tv, ok := p.info.Types[expr]
+	if !ok || tv.Value == nil || tv.Value.Kind() != constant.Int {
+		return 0, false
+	}
+	value, ok := constant.Int64Val(tv.Value)
+	return int(value), ok
+}
+
+// calledFunc returns the declaration of the function of the package called by call
+func (p *typedPackage) calledFunc(call *ast.CallExpr) *ast.FuncDecl {
+	var ident *ast.Ident
+	switch fun := ast.Unparen(call.Fun).(type) {
+	case *ast.Ident:
+		ident = fun
+	case *ast.SelectorExpr:
+		ident = fun.Sel
+	default:
+		return nil
+	}
+	obj, ok := p.info.Uses[ident].(*types.Func)
+	if !ok {
+		return nil
+	}
+	return p.funcs[obj]
+}
+
+// returnedType returns the type of the first result of the return statements of fn
+// if it is the same concrete type everywhere
+func (p *typedPackage) returnedType(fn *ast.FuncDecl, visited map[*ast.FuncDecl]bool) types.Type {
+	if visited[fn] {
+		return nil
+	}
+	visited[fn] = true
+	defer delete(visited, fn)
+
+	results := []types.Type{}
+	unknown := false
+	ast.Inspect(fn.Body, func(node ast.Node) bool {
+		if unknown {
+			return false
+		}
+		switch node := node.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.ReturnStmt:
+			if len(node.Results) == 0 {
+				unknown = true
+				return false
+			}
+			expr := ast.Unparen(node.Results[0])
+			if len(node.Results) == 1 {
+				// return httpServer.handleOther(params, closeChan)
+				call, ok := expr.(*ast.CallExpr)
+				if callee := p.calledFunc(call); ok && callee != nil {
+					if t := p.returnedType(callee, visited); t != nil {
+						results = append(results, t)
+						return false
+					}
+				}
+				unknown = true
+				return false
+			}
+			if t := p.valueType(expr); t != nil {
+				results = append(results, t)
+			} else if !p.isNil(expr) {
+				unknown = true
+			}
+			return false
+		}
+		return true
+	})
+	return sameType(results, unknown)
+}
+
+// wsResult returns the type of the results a websocket handler sends, if it is the same concrete type everywhere
+func (p *typedPackage) wsResult(fn *ast.FuncDecl) types.Type {
+	results := []types.Type{}
+	unknown := false
+	ast.Inspect(fn.Body, func(node ast.Node) bool {
+		literal, ok := node.(*ast.CompositeLit)
+		if !ok {
+			return true
+		}
+		if named, ok := p.info.Types[literal].Type.(*types.Named); !ok || named.Obj().Name() != "RpcSubResult" {
+			return true
+		}
+		for _, elt := range literal.Elts {
+			kv, ok := elt.(*ast.KeyValueExpr)
+			if !ok {
+				continue
+			}
+			if key, ok := kv.Key.(*ast.Ident); !ok || key.Name != "Result" {
+				continue
+			}
+			t := p.valueType(ast.Unparen(kv.Value))
+			switch {
+			case t != nil && types.TypeString(t, nil) == unsubscribeResult:
+			case t != nil:
+				results = append(results, t)
+			case !p.isNil(kv.Value):
+				unknown = true
+			}
+		}
+		return true
+	})
+	return sameType(results, unknown)
+}
+
+// valueType returns the concrete type of expr, nil for an interface
+func (p *typedPackage) valueType(expr ast.Expr) types.Type {
+	tv, ok := p.info.Types[expr]
+	if !ok || tv.Type == nil || tv.IsNil() {
+		return nil
+	}
+	t := tv.Type
+	if basic, ok := t.(*types.Basic); ok && basic.Info()&types.IsUntyped != 0 {
+		t = types.Default(t)
+	}
+	if _, ok := t.Underlying().(*types.Interface); ok {
+		return nil
+	}
+	return t
+}
+
+func (p *typedPackage) isNil(expr ast.Expr) bool {
+	tv, ok := p.info.Types[ast.Unparen(expr)]
+	return ok && tv.IsNil()
+}
+
+func sameType(results []types.Type, unknown bool) types.Type {
+	if unknown || len(results) == 0 {
+		return nil
+	}
+	for _, t := range results[1:] {
+		if !types.Identical(t, results[0]) {
+			return nil
+		}
+	}
+	return results[0]
+}
+
+func (p *typedPackage) source(node ast.Node) string {
+	buf := &bytes.Buffer{}
+	if err := format.Node(buf, p.fset, node); err != nil {
+		return fmt.Sprintf("%T", node)
+	}
+	return buf.String()
+}
+
+// generateSpecs returns the Go file of the specs
+func (p *typedPackage) generateSpecs(specs []*methodSpec) ([]byte, error) {
+	imports := newImports(p.pkg)
+	body := &bytes.Buffer{}
+	for _, spec := range specs {
+		fmt.Fprintf(body, "\t%s: {\n", spec.Key)
+		if spec.Summary != "" {
+			fmt.Fprintf(body, "\t\tSummary: %s,\n", strconv.Quote(spec.Summary))
+		}
+		if len(spec.Params) > 0 {
+			fmt.Fprintf(body, "\t\tParams: []rpcParamSpec{\n")
+			for _, param := range spec.Params {
+				fmt.Fprintf(body, "\t\t\t{Name: %s, Value: %s", strconv.Quote(param.Name), imports.zeroValue(param.Type))
+				if param.Optional {
+					fmt.Fprintf(body, ", Optional: true")
+				}
+				fmt.Fprintf(body, "},\n")
+			}
+			fmt.Fprintf(body, "\t\t},\n")
+		}
+		fmt.Fprintf(body, "\t\tResult: %s,\n", imports.zeroValue(spec.Result))
+		fmt.Fprintf(body, "\t},\n")
+	}
+
+	code := &bytes.Buffer{}
+	fmt.Fprintf(code, "// Code generated by utility/genrpcspecs from the handlers of rpcserver. DO NOT EDIT.\n\n")
+	fmt.Fprintf(code, "package %s\n\n", p.pkg.Name())
+	if len(imports.names) > 0 {
+		fmt.Fprintf(code, "import (\n")
+		for _, path := range imports.sortedPaths() {
+			name := imports.names[path]
+			if name == imports.pkgNames[path] {
+				fmt.Fprintf(code, "\t%s\n", strconv.Quote(path))
+			} else {
+				fmt.Fprintf(code, "\t%s %s\n", name, strconv.Quote(path))
+			}
+		}
+		fmt.Fprintf(code, ")\n\n")
+	}
+	fmt.Fprintf(code, "// generatedRPCMethodSpecs declares the methods of the handlers without spec in %s,\n", handSpecs)
+	fmt.Fprintf(code, "// from the params their handler reads and the result it returns\n")
+	fmt.Fprintf(code, "var generatedRPCMethodSpecs = map[string]rpcMethodSpec{\n%s}\n", body.String())
+	formatted, err := format.Source(code.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("%v\n%s", err, code.String())
+	}
+	return formatted, nil
+}
+
+// imports names the packages of the types of the generated file
+type imports struct {
+	pkg      *types.Package
+	names    map[string]string
+	pkgNames map[string]string
+	used     map[string]bool
+}
+
+func newImports(pkg *types.Package) *imports {
+	used := make(map[string]bool)
+	for _, name := range pkg.Scope().Names() {
+		used[name] = true
+	}
+	return &imports{pkg: pkg, names: make(map[string]string), pkgNames: make(map[string]string), used: used}
+}
+
+func (imports *imports) qualifier(pkg *types.Package) string {
+	if pkg == imports.pkg {
+		return ""
+	}
+	if name, ok := imports.names[pkg.Path()]; ok {
+		return name
+	}
+	name := pkg.Name()
+	for i := 2; imports.used[name]; i++ {
+		name = fmt.Sprintf("%s%d", pkg.Name(), i)
+	}
+	imports.used[name] = true
+	imports.names[pkg.Path()] = name
+	imports.pkgNames[pkg.Path()] = pkg.Name()
+	return name
+}
+
+func (imports *imports) sortedPaths() []string {
+	paths := make([]string, 0, len(imports.names))
+	for path := range imports.names {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// zeroValue returns the Go expression of the zero value of t, nil when t cannot be written in the package
+func (imports *imports) zeroValue(t types.Type) string {
+	if t == nil || !imports.expressible(t, make(map[types.Type]bool)) {
+		return "nil"
+	}
+	typeString := types.TypeString(t, imports.qualifier)
+	switch u := t.Underlying().(type) {
+	case *types.Basic:
+		_, named := t.(*types.Named)
+		switch {
+		case u.Info()&types.IsBoolean != 0 && !named:
+			return "false"
+		case u.Info()&types.IsBoolean != 0:
+			return typeString + "(false)"
+		case u.Info()&types.IsString != 0 && !named:
+			return `""`
+		case u.Info()&types.IsString != 0:
+			return typeString + `("")`
+		case u.Info()&types.IsNumeric != 0:
+			return typeString + "(0)"
+		}
+	case *types.Pointer, *types.Slice, *types.Map:
+		return "(" + typeString + ")(nil)"
+	case *types.Struct:
+		return typeString + "{}"
+	case *types.Interface:
+		return "nil"
+	}
+	return "*new(" + typeString + ")"
+}
+
+// expressible tells whether t can be written in the package: its named types are exported or declared
+// in the package, and it is encoded to JSON
+func (imports *imports) expressible(t types.Type, seen map[types.Type]bool) bool {
+	if seen[t] {
+		return true
+	}
+	seen[t] = true
+	switch t := t.(type) {
+	case *types.Named:
+		obj := t.Obj()
+		if obj.Pkg() != nil && obj.Parent() != obj.Pkg().Scope() {
+			// declared in a function
+			return false
+		}
+		if obj.Pkg() != nil && obj.Pkg() != imports.pkg &&
+			(!obj.Exported() || strings.Contains(obj.Pkg().Path(), "/internal/") || strings.HasSuffix(obj.Pkg().Path(), "/internal")) {
+			return false
+		}
+		return true
+	case *types.Basic:
+		return t.Kind() != types.UnsafePointer && t.Kind() != types.Invalid
+	case *types.Pointer:
+		return imports.expressible(t.Elem(), seen)
+	case *types.Slice:
+		return imports.expressible(t.Elem(), seen)
+	case *types.Array:
+		return imports.expressible(t.Elem(), seen)
+	case *types.Map:
+		return imports.expressible(t.Key(), seen) && imports.expressible(t.Elem(), seen)
+	case *types.Struct:
+		for i := 0; i < t.NumFields(); i++ {
+			if !imports.expressible(t.Field(i).Type(), seen) {
+				return false
+			}
+		}
+		return true
+	case *types.Interface:
+		return t.Empty()
+	}
+	return false
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+func TestGenerate(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds the dependencies of rpcserver")
+	}
+	code, err := generate(rpcserverPath, "openrpc_generated.go")
+	if err != nil {
+		t.Fatalf("Expect no error but get %+v", err)
+	}
+	for _, expected := range []string{
+		"subcribeNewShardBlock: {\n\t\tParams: []rpcParamSpec{\n\t\t\t{Name: \"shardID\", Value: float64(0)},\n\t\t},\n\t\tResult: (*jsonresult.GetShardBlockResult)(nil),",
+		`"github.com/incognitochain/incognito-chain/rpcserver/jsonresult"`,
+	} {
+		if !strings.Contains(string(code), expected) {
+			t.Fatalf("Expect the generated code to contain %+v", expected)
+		}
+	}
+	if strings.Contains(string(code), "\trpcDiscover:") {
+		t.Fatal("Expect no method declared by hand")
+	}
+	current, err := ioutil.ReadFile("../../rpcserver/openrpc_generated.go")
+	if err != nil {
+		t.Fatalf("Expect no error but get %+v", err)
+	}
+	if !bytes.Equal(code, current) {
+		t.Fatal("Expect rpcserver/openrpc_generated.go to be up to date, run go generate ./rpcserver")
+	}
+}
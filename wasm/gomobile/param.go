@@ -8,11 +8,12 @@ import (
 	"github.com/incognitochain/incognito-chain/privacy"
 	"github.com/incognitochain/incognito-chain/privacy/coin"
 	"github.com/incognitochain/incognito-chain/transaction"
+	"github.com/incognitochain/incognito-chain/transaction/tx_ver1"
 	"github.com/incognitochain/incognito-chain/wallet"
 	"github.com/pkg/errors"
 )
 
-func InitParamCreatePrivacyTx(args string) (*transaction.TxPrivacyInitParamsForASM, error) {
+func InitParamCreatePrivacyTx(args string) (*tx_ver1.TxPrivacyInitParamsForASM, error) {
 	bytes := []byte(args)
 	println("Bytes: %v\n", bytes)
 
@@ -130,7 +131,7 @@ func InitParamCreatePrivacyTx(args string) (*transaction.TxPrivacyInitParamsForA
 	}
 	println("inputCoinStrs: ", inputCoinStrs)
 
-	inputCoins := make([]*coin.PlainCoinV1, len(inputCoinStrs))
+	inputCoins := make([]privacy.PlainCoin, len(inputCoinStrs))
 	for i := 0; i < len(inputCoins); i++ {
 		tmp, ok := inputCoinStrs[i].(map[string]interface{})
 		if !ok {
@@ -174,8 +175,9 @@ func InitParamCreatePrivacyTx(args string) (*transaction.TxPrivacyInitParamsForA
 			return nil, errors.New("Invalid input coin info param")
 		}
 
-		inputCoins[i] = new(coin.PlainCoinV1).Init()
-		inputCoins[i].ParseCoinObjectToInputCoin(*coinObjTmp)
+		inputCoin := new(coin.PlainCoinV1).Init()
+		inputCoin.ParseCoinObjectToInputCoin(*coinObjTmp)
+		inputCoins[i] = inputCoin
 	}
 
 	println("inputCoins: ", inputCoins)
@@ -242,13 +244,13 @@ func InitParamCreatePrivacyTx(args string) (*transaction.TxPrivacyInitParamsForA
 		sndOutputs[i] = new(privacy.Scalar).FromBytesS(tmp)
 	}
 
-	paramCreateTx := transaction.NewTxPrivacyInitParamsForASM(&senderSK, paymentInfo, inputCoins, uint64(fee), hasPrivacy, nil, nil, infoBytes, commitmentIndices, commitmentBytes, myCommitmentIndices, sndOutputs)
+	paramCreateTx := tx_ver1.NewTxPrivacyInitParamsForASM(&senderSK, paymentInfo, inputCoins, uint64(fee), hasPrivacy, nil, nil, infoBytes, commitmentIndices, commitmentBytes, myCommitmentIndices, sndOutputs)
 	println("paramCreateTx: ", paramCreateTx)
 
 	return paramCreateTx, nil
 }
 
-func InitParamCreatePrivacyTokenTx(args string) (*transaction.TxPrivacyTokenInitParamsForASM, error) {
+func InitParamCreatePrivacyTokenTx(args string) (*tx_ver1.TxPrivacyTokenInitParamsForASM, error) {
 	bytes := []byte(args)
 	println("Bytes: %v\n", bytes)
 
@@ -379,7 +381,7 @@ func InitParamCreatePrivacyTokenTx(args string) (*transaction.TxPrivacyTokenInit
 	}
 	println("inputCoinStrs: ", inputCoinStrs)
 
-	inputCoins := make([]*coin.PlainCoinV1, len(inputCoinStrs))
+	inputCoins := make([]privacy.PlainCoin, len(inputCoinStrs))
 	for i := 0; i < len(inputCoins); i++ {
 		tmp, ok := inputCoinStrs[i].(map[string]interface{})
 		if !ok {
@@ -423,8 +425,9 @@ func InitParamCreatePrivacyTokenTx(args string) (*transaction.TxPrivacyTokenInit
 			return nil, errors.New("Invalid input coin info param")
 		}
 
-		inputCoins[i] = new(coin.PlainCoinV1).Init()
-		inputCoins[i].ParseCoinObjectToInputCoin(*coinObjTmp)
+		inputCoin := new(coin.PlainCoinV1).Init()
+		inputCoin.ParseCoinObjectToInputCoin(*coinObjTmp)
+		inputCoins[i] = inputCoin
 	}
 
 	println("inputCoins: ", inputCoins)
@@ -660,7 +663,7 @@ func InitParamCreatePrivacyTokenTx(args string) (*transaction.TxPrivacyTokenInit
 	}
 	println("tokenInputs: ", tokenInputsParam)
 
-	tokenInputs := make([]*coin.PlainCoinV1, len(tokenInputsParam))
+	tokenInputs := make([]privacy.PlainCoin, len(tokenInputsParam))
 	for i := 0; i < len(tokenInputs); i++ {
 		tmp, ok := tokenInputsParam[i].(map[string]interface{})
 		if !ok {
@@ -704,8 +707,9 @@ func InitParamCreatePrivacyTokenTx(args string) (*transaction.TxPrivacyTokenInit
 			return nil, errors.New("Invalid input coin info param")
 		}
 
-		tokenInputs[i] = new(coin.PlainCoinV1).Init()
-		tokenInputs[i].ParseCoinObjectToInputCoin(*coinObjTmp)
+		tokenInput := new(coin.PlainCoinV1).Init()
+		tokenInput.ParseCoinObjectToInputCoin(*coinObjTmp)
+		tokenInputs[i] = tokenInput
 	}
 
 	println("tokenInputs: ", tokenInputs)
@@ -719,7 +723,7 @@ func InitParamCreatePrivacyTokenTx(args string) (*transaction.TxPrivacyTokenInit
 	println("privacyTokenParam.Amount: ", privacyTokenParam.Amount)
 	//println("privacyTokenParam.PropertySymbol: ", len(privacyTokenParam.PropertySymbol))
 
-	paramCreateTx := transaction.NewTxPrivacyTokenInitParamsForASM(
+	paramCreateTx := tx_ver1.NewTxPrivacyTokenInitParamsForASM(
 		&senderSK, paymentInfo, inputCoins, fee, privacyTokenParam, nil, hasPrivacy, hasPrivacyForPToken, shardID, infoBytes,
 		commitmentIndicesForNativeToken, commitmentBytesForNativeToken, myCommitmentIndicesForNativeToken, sndOutputsForNativeToken,
 		commitmentIndicesForPToken, commitmentBytesForPToken, myCommitmentIndicesForPToken, sndOutputsForPToken)
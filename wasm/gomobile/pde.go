@@ -5,7 +5,7 @@ import (
 	"encoding/json"
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/metadata"
-	"github.com/incognitochain/incognito-chain/transaction"
+	"github.com/incognitochain/incognito-chain/transaction/tx_ver1"
 	"github.com/pkg/errors"
 	"math/big"
 )
@@ -80,8 +80,9 @@ func InitPRVContributionTx(args string, serverTime int64) (string, error) {
 
 	paramCreateTx.SetMetaData(metaData)
 
-	tx := new(transaction.Tx)
-	err = tx.InitForASM(paramCreateTx, serverTime)
+	tx := new(tx_ver1.Tx)
+	tx.LockTime = serverTime
+	err = tx.InitForASM(paramCreateTx)
 
 	if err != nil {
 		println("Can not create tx: ", err)
@@ -135,7 +136,7 @@ func InitPTokenContributionTx(args string, serverTime int64) (string, error) {
 
 	paramCreateTx.SetMetaData(metaData)
 
-	tx := new(transaction.TxTokenBase)
+	tx := new(tx_ver1.TxToken)
 	err = tx.InitForASM(paramCreateTx, serverTime)
 
 	if err != nil {
@@ -150,7 +151,7 @@ func InitPTokenContributionTx(args string, serverTime int64) (string, error) {
 		return "", err
 	}
 
-	lockTimeBytes := common.AddPaddingBigInt(new(big.Int).SetInt64(tx.LockTime), 8)
+	lockTimeBytes := common.AddPaddingBigInt(new(big.Int).SetInt64(serverTime), 8)
 	resBytes := append(txJson, lockTimeBytes...)
 
 	B64Res := base64.StdEncoding.EncodeToString(resBytes)
@@ -199,7 +200,7 @@ func InitPEDTradeRequestMetadataFromParam(metaDataParam map[string]interface{})
 	}
 
 	metaData, err := metadata.NewPDETradeRequest(
-		tokenIDToBuyStr, tokenIDToSellStr, uint64(sellAmount), uint64(minAcceptableAmount), uint64(tradingFee), traderAddressStr, int(metaDataType),
+		tokenIDToBuyStr, tokenIDToSellStr, uint64(sellAmount), uint64(minAcceptableAmount), uint64(tradingFee), traderAddressStr, "", int(metaDataType),
 	)
 	if err != nil {
 		return nil, err
@@ -240,8 +241,9 @@ func InitPRVTradeTx(args string, serverTime int64) (string, error) {
 
 	paramCreateTx.SetMetaData(metaData)
 
-	tx := new(transaction.Tx)
-	err = tx.InitForASM(paramCreateTx, serverTime)
+	tx := new(tx_ver1.Tx)
+	tx.LockTime = serverTime
+	err = tx.InitForASM(paramCreateTx)
 
 	if err != nil {
 		println("Can not create tx: ", err)
@@ -295,7 +297,7 @@ func InitPTokenTradeTx(args string, serverTime int64) (string, error) {
 
 	paramCreateTx.SetMetaData(metaData)
 
-	tx := new(transaction.TxTokenBase)
+	tx := new(tx_ver1.TxToken)
 	err = tx.InitForASM(paramCreateTx, serverTime)
 
 	if err != nil {
@@ -310,7 +312,7 @@ func InitPTokenTradeTx(args string, serverTime int64) (string, error) {
 		return "", err
 	}
 
-	lockTimeBytes := common.AddPaddingBigInt(new(big.Int).SetInt64(tx.LockTime), 8)
+	lockTimeBytes := common.AddPaddingBigInt(new(big.Int).SetInt64(serverTime), 8)
 	resBytes := append(txJson, lockTimeBytes...)
 
 	B64Res := base64.StdEncoding.EncodeToString(resBytes)
@@ -379,8 +381,9 @@ func WithdrawDexTx(args string, serverTime int64) (string, error) {
 
 	paramCreateTx.SetMetaData(metaData)
 
-	tx := new(transaction.Tx)
-	err = tx.InitForASM(paramCreateTx, serverTime)
+	tx := new(tx_ver1.Tx)
+	tx.LockTime = serverTime
+	err = tx.InitForASM(paramCreateTx)
 
 	if err != nil {
 		println("Can not create tx: ", err)
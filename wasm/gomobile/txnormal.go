@@ -7,7 +7,7 @@ import (
 	"github.com/incognitochain/incognito-chain/wallet"
 
 	"github.com/incognitochain/incognito-chain/metadata"
-	"github.com/incognitochain/incognito-chain/transaction"
+	"github.com/incognitochain/incognito-chain/transaction/tx_ver1"
 	"github.com/pkg/errors"
 	"math/big"
 )
@@ -18,8 +18,9 @@ func InitPrivacyTx(args string, serverTime int64) (string, error) {
 		return "", err
 	}
 
-	tx := new(transaction.Tx)
-	err = tx.InitForASM(paramCreateTx, serverTime)
+	tx := new(tx_ver1.Tx)
+	tx.LockTime = serverTime
+	err = tx.InitForASM(paramCreateTx)
 
 	if err != nil {
 		println("Can not create tx: ", err)
@@ -105,8 +106,9 @@ func Staking(args string, serverTime int64) (string, error) {
 
 	paramCreateTx.SetMetaData(metaData)
 
-	tx := new(transaction.Tx)
-	err = tx.InitForASM(paramCreateTx, serverTime)
+	tx := new(tx_ver1.Tx)
+	tx.LockTime = serverTime
+	err = tx.InitForASM(paramCreateTx)
 
 	if err != nil {
 		println("Can not create tx: ", err)
@@ -172,8 +174,9 @@ func StopAutoStaking(args string, serverTime int64) (string, error) {
 
 	paramCreateTx.SetMetaData(metaData)
 
-	tx := new(transaction.Tx)
-	err = tx.InitForASM(paramCreateTx, serverTime)
+	tx := new(tx_ver1.Tx)
+	tx.LockTime = serverTime
+	err = tx.InitForASM(paramCreateTx)
 
 	if err != nil {
 		println("Can not create tx: ", err)
@@ -244,10 +247,10 @@ func InitWithdrawRewardTx(args string, serverTime int64) (string, error) {
 	}
 
 	tmp := &metadata.WithDrawRewardRequest{
-		PaymentAddress: paymentAddress,
-		MetadataBase:   *metadata.NewMetadataBase(int(metaDataType)),
-		TokenID:        *tokenId,
-		Version:        1,
+		PaymentAddress:            paymentAddress,
+		MetadataBaseWithSignature: *metadata.NewMetadataBaseWithSignature(int(metaDataType)),
+		TokenID:                   *tokenId,
+		Version:                   1,
 	}
 
 	paramCreateTx, err := InitParamCreatePrivacyTx(args)
@@ -257,8 +260,9 @@ func InitWithdrawRewardTx(args string, serverTime int64) (string, error) {
 
 	paramCreateTx.SetMetaData(tmp)
 
-	tx := new(transaction.Tx)
-	err = tx.InitForASM(paramCreateTx, serverTime)
+	tx := new(tx_ver1.Tx)
+	tx.LockTime = serverTime
+	err = tx.InitForASM(paramCreateTx)
 
 	if err != nil {
 		println("Can not create tx: ", err)
@@ -4,7 +4,8 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"github.com/incognitochain/incognito-chain/common"
-	"github.com/incognitochain/incognito-chain/metadata"
+	metadataBridge "github.com/incognitochain/incognito-chain/metadata/bridge"
+	"github.com/incognitochain/incognito-chain/transaction/tx_ver1"
 	"github.com/incognitochain/incognito-chain/wallet"
 	"github.com/pkg/errors"
 	"math/big"
@@ -16,7 +17,7 @@ func InitPrivacyTokenTx(args string, serverTime int64) (string, error) {
 		return "", err
 	}
 
-	tx := new(transaction.TxTokenBase)
+	tx := new(tx_ver1.TxToken)
 	err = tx.InitForASM(paramCreateTx, serverTime)
 
 	if err != nil {
@@ -33,7 +34,7 @@ func InitPrivacyTokenTx(args string, serverTime int64) (string, error) {
 
 	tokenIDBytes := tx.TxTokenData.PropertyID.GetBytes()
 
-	lockTimeBytes := common.AddPaddingBigInt(new(big.Int).SetInt64(tx.LockTime), 8)
+	lockTimeBytes := common.AddPaddingBigInt(new(big.Int).SetInt64(serverTime), 8)
 	resBytes := append(txJson, lockTimeBytes...)
 	resBytes = append(resBytes, tokenIDBytes...)
 
@@ -104,7 +105,7 @@ func InitBurningRequestTx(args string, serverTime int64) (string, error) {
 		return "", errors.New("Invalid meta data remote address param")
 	}
 
-	metaData, err := metadata.NewBurningRequest(burnerAddress, uint64(burningAmount), *tokenIDHash, tokenName, remoteAddress, int(metaDataType))
+	metaData, err := metadataBridge.NewBurningRequest(burnerAddress, uint64(burningAmount), *tokenIDHash, tokenName, remoteAddress, int(metaDataType))
 	if err != nil {
 		return "", err
 	}
@@ -116,7 +117,7 @@ func InitBurningRequestTx(args string, serverTime int64) (string, error) {
 
 	paramCreateTx.SetMetaData(metaData)
 
-	tx := new(transaction.TxTokenBase)
+	tx := new(tx_ver1.TxToken)
 	err = tx.InitForASM(paramCreateTx, serverTime)
 
 	if err != nil {
@@ -133,7 +134,7 @@ func InitBurningRequestTx(args string, serverTime int64) (string, error) {
 
 	tokenIDBytes := tx.TxTokenData.PropertyID.GetBytes()
 
-	lockTimeBytes := common.AddPaddingBigInt(new(big.Int).SetInt64(tx.LockTime), 8)
+	lockTimeBytes := common.AddPaddingBigInt(new(big.Int).SetInt64(serverTime), 8)
 	resBytes := append(txJson, lockTimeBytes...)
 	resBytes = append(resBytes, tokenIDBytes...)
 
@@ -0,0 +1,54 @@
+package gomobile
+
+import (
+	"encoding/json"
+
+	"github.com/incognitochain/incognito-chain/common/base58"
+	"github.com/incognitochain/incognito-chain/transaction"
+	"github.com/incognitochain/incognito-chain/wallet"
+	"github.com/pkg/errors"
+)
+
+// SignUnsignedTx signs a transaction built by a node from the read-only keys of the sender.
+// args is {"privateKey": base58 private key, "unsignedTx": UnsignedTx of createunsignedtransaction}
+func SignUnsignedTx(args string) (string, error) {
+	paramMaps := struct {
+		PrivateKey string          `json:"privateKey"`
+		UnsignedTx json.RawMessage `json:"unsignedTx"`
+	}{}
+	err := json.Unmarshal([]byte(args), &paramMaps)
+	if err != nil {
+		println("Error can not unmarshal data : %v\n", err)
+		return "", err
+	}
+
+	keyWallet, err := wallet.Base58CheckDeserialize(paramMaps.PrivateKey)
+	if err != nil {
+		println("Error can not decode sender private key : %v\n", err)
+		return "", err
+	}
+	if len(keyWallet.KeySet.PrivateKey) == 0 {
+		return "", errors.New("Invalid sender private key")
+	}
+
+	tx, err := transaction.SignUnsignedTxJson(paramMaps.UnsignedTx, &keyWallet.KeySet.PrivateKey)
+	if err != nil {
+		println("Can not sign tx: ", err)
+		return "", err
+	}
+
+	// serialize tx json
+	txJson, err := json.Marshal(tx)
+	if err != nil {
+		println("Can not marshal tx: ", err)
+		return "", err
+	}
+	res, err := json.Marshal(map[string]string{
+		"txID":            tx.Hash().String(),
+		"base58CheckData": base58.Base58Check{}.Encode(txJson, 0x00),
+	})
+	if err != nil {
+		return "", err
+	}
+	return string(res), nil
+}
@@ -0,0 +1,754 @@
+package gomobile
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/common/base58"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/metadata"
+	metadataBridge "github.com/incognitochain/incognito-chain/metadata/bridge"
+	metadataCommon "github.com/incognitochain/incognito-chain/metadata/common"
+	metadataPdexv3 "github.com/incognitochain/incognito-chain/metadata/pdexv3"
+	"github.com/incognitochain/incognito-chain/privacy"
+	"github.com/incognitochain/incognito-chain/rpcserver/jsonresult"
+	"github.com/incognitochain/incognito-chain/transaction"
+	"github.com/incognitochain/incognito-chain/wallet"
+	"github.com/pkg/errors"
+)
+
+// The builders of transactions ver2 take their params as JSON:
+//
+//	{
+//		"privateKey": base58 private key of the sender,
+//		"activeShards": number of shards of the network, once per session,
+//		"fee": fee in nano PRV,
+//		"info": info of the transaction (optional),
+//		"prv": {
+//			"inputs": coins to spend, as returned by listoutputcoinsfromcache,
+//			"decoys": RingSize-1 coins per input, as returned by getotacoinsbyindices,
+//			"paramPaymentInfos": [{"paymentAddressStr": ..., "amount": ..., "message": base64 (optional)}]
+//		},
+//		"token": same as "prv" with "tokenID", for a pToken transfer (optional),
+//		"metadata": params of the metadata (optional)
+//	}
+//
+// Coin selection is left to the caller: every input is spent and the change goes back to the sender.
+// The decoys of a pToken are the coins of common.ConfidentialAssetID, those of PRV the coins of PRV.
+// The result is {"txID": ..., "base58CheckData": ...}, to be sent with sendtransaction
+// or sendrawprivacycustomtokentransaction.
+
+// amountParam is an amount given as a JSON number or as a string,
+// a string keeps the precision of the amounts above 2^53 in JavaScript
+type amountParam uint64
+
+func (a *amountParam) UnmarshalJSON(data []byte) error {
+	var raw interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	amount, err := common.AssertAndConvertNumber(raw)
+	if err != nil {
+		return err
+	}
+	*a = amountParam(amount)
+	return nil
+}
+
+type paymentInfoParam struct {
+	PaymentAddressStr string      `json:"paymentAddressStr"`
+	Amount            amountParam `json:"amount"`
+	Message           string      `json:"message"`
+}
+
+type transferParam struct {
+	Inputs       []jsonresult.OutCoin `json:"inputs"`
+	Decoys       []jsonresult.OutCoin `json:"decoys"`
+	PaymentInfos []paymentInfoParam   `json:"paramPaymentInfos"`
+}
+
+type tokenTransferParam struct {
+	transferParam
+	TokenID string `json:"tokenID"`
+}
+
+type txVer2Param struct {
+	PrivateKey   string              `json:"privateKey"`
+	ActiveShards int                 `json:"activeShards"`
+	Fee          amountParam         `json:"fee"`
+	Info         string              `json:"info"`
+	PRV          *transferParam      `json:"prv"`
+	Token        *tokenTransferParam `json:"token"`
+	Metadata     json.RawMessage     `json:"metadata"`
+}
+
+// txVer2Builder holds the parsed params of a transaction ver2 while its metadata is built
+type txVer2Builder struct {
+	keySet       incognitokey.KeySet
+	shardID      byte
+	fee          uint64
+	info         []byte
+	prvPayments  []*privacy.PaymentInfo
+	prv          *transferParam
+	tokenID      *common.Hash
+	tokenPayment []*privacy.PaymentInfo
+	token        *tokenTransferParam
+	metadata     metadata.Metadata
+	rawMetadata  json.RawMessage
+}
+
+func parsePaymentInfos(params []paymentInfoParam) ([]*privacy.PaymentInfo, error) {
+	paymentInfos := make([]*privacy.PaymentInfo, 0, len(params))
+	for _, param := range params {
+		keyWallet, err := wallet.Base58CheckDeserialize(param.PaymentAddressStr)
+		if err != nil {
+			println("Error can not decode payment address : %v\n", err)
+			return nil, err
+		}
+		if len(keyWallet.KeySet.PaymentAddress.Pk) == 0 {
+			return nil, errors.New("Invalid payment info param payment address string")
+		}
+		msgBytes := []byte{}
+		if param.Message != "" {
+			msgBytes, err = base64.StdEncoding.DecodeString(param.Message)
+			if err != nil {
+				return nil, errors.New("Can not decode msg string in payment info")
+			}
+		}
+		paymentInfos = append(paymentInfos, &privacy.PaymentInfo{
+			PaymentAddress: keyWallet.KeySet.PaymentAddress,
+			Amount:         uint64(param.Amount),
+			Message:        msgBytes,
+		})
+	}
+	return paymentInfos, nil
+}
+
+func newTxVer2Builder(args string) (*txVer2Builder, error) {
+	param := &txVer2Param{}
+	err := json.Unmarshal([]byte(args), param)
+	if err != nil {
+		println("Error can not unmarshal data : %v\n", err)
+		return nil, err
+	}
+
+	// the SDK does not load the params of a network like a node does at startup:
+	// the shard of the sender depends on the number of shards and burnt coins are told by the burning addresses
+	if param.ActiveShards > 0 {
+		common.MaxShardNumber = param.ActiveShards
+	}
+	if common.MaxShardNumber <= 0 {
+		return nil, errors.New("Invalid active shards param")
+	}
+	if len(common.BurningAddressByte2) == 0 {
+		if err := wallet.InitPublicKeyBurningAddressByte(); err != nil {
+			return nil, err
+		}
+	}
+
+	keyWallet, err := wallet.Base58CheckDeserialize(param.PrivateKey)
+	if err != nil {
+		println("Error can not decode sender private key : %v\n", err)
+		return nil, err
+	}
+	if len(keyWallet.KeySet.PrivateKey) == 0 {
+		return nil, errors.New("Invalid sender private key")
+	}
+	b := &txVer2Builder{
+		fee:         uint64(param.Fee),
+		info:        []byte(param.Info),
+		prv:         param.PRV,
+		token:       param.Token,
+		rawMetadata: param.Metadata,
+	}
+	if err := b.keySet.InitFromPrivateKey(&keyWallet.KeySet.PrivateKey); err != nil {
+		return nil, err
+	}
+	pk := b.keySet.PaymentAddress.Pk
+	b.shardID = common.GetShardIDFromLastByte(pk[len(pk)-1])
+
+	if b.prv == nil || len(b.prv.Inputs) == 0 {
+		return nil, errors.New("Invalid prv param: the fee must be paid with PRV inputs")
+	}
+	if b.prvPayments, err = parsePaymentInfos(b.prv.PaymentInfos); err != nil {
+		return nil, err
+	}
+	if b.token != nil {
+		tokenID, err := common.Hash{}.NewHashFromStr(b.token.TokenID)
+		if err != nil {
+			return nil, errors.Wrap(err, "Invalid token id param")
+		}
+		if *tokenID == common.PRVCoinID {
+			return nil, errors.New("Invalid token id param: PRV is transferred with the prv param")
+		}
+		b.tokenID = tokenID
+		if b.tokenPayment, err = parsePaymentInfos(b.token.PaymentInfos); err != nil {
+			return nil, err
+		}
+	}
+	return b, nil
+}
+
+// burn adds a payment of amount to the burning address, in the token tokenID of the transaction
+func (b *txVer2Builder) burn(tokenID common.Hash, amount uint64) error {
+	keyWallet, err := wallet.Base58CheckDeserialize(common.BurningAddress2)
+	if err != nil {
+		return err
+	}
+	payment := &privacy.PaymentInfo{
+		PaymentAddress: keyWallet.KeySet.PaymentAddress,
+		Amount:         amount,
+	}
+	if tokenID == common.PRVCoinID {
+		b.prvPayments = append(b.prvPayments, payment)
+		return nil
+	}
+	if b.tokenID == nil || *b.tokenID != tokenID {
+		return errors.Errorf("Invalid token param: expect to spend token %v", tokenID.String())
+	}
+	b.tokenPayment = append(b.tokenPayment, payment)
+	return nil
+}
+
+// otaReceivers generates a one-time address of the sender for every token
+func (b *txVer2Builder) otaReceivers(tokenIDs ...common.Hash) (map[common.Hash]privacy.OTAReceiver, error) {
+	result := make(map[common.Hash]privacy.OTAReceiver)
+	for _, tokenID := range tokenIDs {
+		if _, ok := result[tokenID]; ok {
+			continue
+		}
+		otaReceiver := privacy.OTAReceiver{}
+		if err := otaReceiver.FromAddress(b.keySet.PaymentAddress); err != nil {
+			return nil, err
+		}
+		result[tokenID] = otaReceiver
+	}
+	return result, nil
+}
+
+func (b *txVer2Builder) otaReceiverString() (string, error) {
+	otaReceiver := privacy.OTAReceiver{}
+	if err := otaReceiver.FromAddress(b.keySet.PaymentAddress); err != nil {
+		return "", err
+	}
+	return otaReceiver.String()
+}
+
+// parseCoin parses a coin v2 with its index in the chain
+func parseCoin(outCoin jsonresult.OutCoin) (*privacy.CoinV2, *big.Int, error) {
+	c, index, err := jsonresult.NewCoinFromJsonOutCoin(outCoin)
+	if err != nil {
+		return nil, nil, err
+	}
+	coinV2, ok := c.(*privacy.CoinV2)
+	if !ok {
+		return nil, nil, errors.New("Invalid coin: only coins v2 are spent by transactions ver2")
+	}
+	if index == nil {
+		return nil, nil, errors.Errorf("Invalid coin %v: index is missing", outCoin.PublicKey)
+	}
+	return coinV2, index, nil
+}
+
+// unsignedTransfer spends all the inputs of a transfer, adds the change of the sender to the payments
+// and hides the inputs among the decoys at a random row of the ring
+func (b *txVer2Builder) unsignedTransfer(param *transferParam, payments []*privacy.PaymentInfo, fee uint64) (*transaction.UnsignedTransfer, error) {
+	if len(param.Inputs) == 0 {
+		return nil, errors.New("Invalid inputs: a transfer must spend some coins")
+	}
+	if len(param.Decoys) != (privacy.RingSize-1)*len(param.Inputs) {
+		return nil, errors.Errorf("Invalid decoys: expect %v decoys for %v inputs but get %v", (privacy.RingSize-1)*len(param.Inputs), len(param.Inputs), len(param.Decoys))
+	}
+
+	sumInputValue := uint64(0)
+	inputs := make([]*transaction.RingMember, 0, len(param.Inputs))
+	for _, outCoin := range param.Inputs {
+		inputCoin, index, err := parseCoin(outCoin)
+		if err != nil {
+			return nil, err
+		}
+		if belongs, _ := inputCoin.DoesCoinBelongToKeySet(&b.keySet); !belongs {
+			return nil, errors.Errorf("Invalid input: coin %v does not belong to the sender", outCoin.PublicKey)
+		}
+		// decrypt a copy, the coin of the ring must stay as it is on the chain
+		plainCoinSource, _, err := parseCoin(outCoin)
+		if err != nil {
+			return nil, err
+		}
+		plainCoin, err := plainCoinSource.Decrypt(&b.keySet)
+		if err != nil {
+			return nil, err
+		}
+		sumInputValue += plainCoin.GetValue()
+		inputs = append(inputs, &transaction.RingMember{Index: index, Coin: inputCoin})
+	}
+
+	sumOutputValue := fee
+	for _, payment := range payments {
+		sumOutputValue += payment.Amount
+	}
+	if sumInputValue < sumOutputValue {
+		return nil, errors.Errorf("Invalid inputs: sumInputValue=%v is less than sumOutputValue=%v", sumInputValue, sumOutputValue)
+	}
+	paymentInfos := append([]*privacy.PaymentInfo{}, payments...)
+	if sumInputValue > sumOutputValue {
+		paymentInfos = append(paymentInfos, &privacy.PaymentInfo{
+			PaymentAddress: b.keySet.PaymentAddress,
+			Amount:         sumInputValue - sumOutputValue,
+		})
+	}
+
+	piBig, err := common.RandBigIntMaxRange(big.NewInt(int64(privacy.RingSize)))
+	if err != nil {
+		return nil, err
+	}
+	pi := int(piBig.Int64())
+	ring := make([][]*transaction.RingMember, privacy.RingSize)
+	decoyIndex := 0
+	for i := range ring {
+		if i == pi {
+			ring[i] = inputs
+			continue
+		}
+		row := make([]*transaction.RingMember, len(inputs))
+		for j := range row {
+			decoy, index, err := parseCoin(param.Decoys[decoyIndex])
+			if err != nil {
+				return nil, err
+			}
+			decoyIndex++
+			row[j] = &transaction.RingMember{Index: index, Coin: decoy}
+		}
+		ring[i] = row
+	}
+
+	return &transaction.UnsignedTransfer{
+		Ring:        ring,
+		Pi:          pi,
+		PaymentInfo: paymentInfos,
+	}, nil
+}
+
+// sign builds the transaction from the params and the metadata, then signs it
+func (b *txVer2Builder) sign() (string, error) {
+	if b.metadata == nil && len(b.rawMetadata) != 0 && string(b.rawMetadata) != "null" {
+		md, err := metadata.ParseMetadata(&b.rawMetadata)
+		if err != nil {
+			println("Can not parse metadata: ", err)
+			return "", err
+		}
+		b.metadata = md
+	}
+
+	prvTransfer, err := b.unsignedTransfer(b.prv, b.prvPayments, b.fee)
+	if err != nil {
+		return "", err
+	}
+	unsignedTx := &transaction.UnsignedTx{
+		Version:              transaction.TxVersion2Number,
+		Type:                 common.TxNormalType,
+		LockTime:             time.Now().Unix(),
+		Fee:                  b.fee,
+		Info:                 b.info,
+		PubKeyLastByteSender: b.shardID,
+		Metadata:             b.metadata,
+		PRV:                  prvTransfer,
+	}
+	if b.token != nil {
+		tokenTransfer, err := b.unsignedTransfer(&b.token.transferParam, b.tokenPayment, 0)
+		if err != nil {
+			return "", err
+		}
+		unsignedTx.Type = common.TxCustomTokenPrivacyType
+		unsignedTx.Token = &transaction.UnsignedTokenTransfer{
+			UnsignedTransfer: *tokenTransfer,
+			PropertyID:       *b.tokenID,
+			Type:             transaction.CustomTokenTransfer,
+		}
+	}
+
+	tx, err := unsignedTx.Sign(&b.keySet.PrivateKey)
+	if err != nil {
+		println("Can not sign tx: ", err)
+		return "", err
+	}
+	txJson, err := json.Marshal(tx)
+	if err != nil {
+		println("Can not marshal tx: ", err)
+		return "", err
+	}
+	res, err := json.Marshal(map[string]string{
+		"txID":            tx.Hash().String(),
+		"base58CheckData": base58.Base58Check{}.Encode(txJson, 0x00),
+	})
+	if err != nil {
+		return "", err
+	}
+	return string(res), nil
+}
+
+// readMetadataParams parses the metadata params of a builder into mdReader
+func (b *txVer2Builder) readMetadataParams(mdReader interface{}) error {
+	if len(b.rawMetadata) == 0 {
+		return errors.New("Invalid metadata param: metadata is missing")
+	}
+	if err := json.Unmarshal(b.rawMetadata, mdReader); err != nil {
+		return errors.Wrap(err, "Invalid metadata param")
+	}
+	return nil
+}
+
+// CreateTransactionVer2 builds and signs a PRV or pToken transaction ver2.
+// The metadata param (optional) is the JSON of the metadata with its Type, as in a transaction.
+func CreateTransactionVer2(args string) (string, error) {
+	b, err := newTxVer2Builder(args)
+	if err != nil {
+		return "", err
+	}
+	return b.sign()
+}
+
+// CreatePdexv3TradeTx builds and signs a pDEX v3 trade, the metadata param is
+// {"TradePath", "TokenToSell", "TokenToBuy", "SellAmount", "MinAcceptableAmount", "TradingFee", "FeeInPRV"}.
+// The token param spends the token to sell, unless it is PRV.
+func CreatePdexv3TradeTx(args string) (string, error) {
+	b, err := newTxVer2Builder(args)
+	if err != nil {
+		return "", err
+	}
+	mdReader := &struct {
+		TradePath           []string
+		TokenToSell         common.Hash
+		TokenToBuy          common.Hash
+		SellAmount          amountParam
+		MinAcceptableAmount amountParam
+		TradingFee          amountParam
+		FeeInPRV            bool
+	}{}
+	if err := b.readMetadataParams(mdReader); err != nil {
+		return "", err
+	}
+
+	md, _ := metadataPdexv3.NewTradeRequest(
+		mdReader.TradePath, mdReader.TokenToSell, uint64(mdReader.SellAmount),
+		uint64(mdReader.MinAcceptableAmount), uint64(mdReader.TradingFee), nil,
+		metadataCommon.Pdexv3TradeRequestMeta,
+	)
+	isPRV := md.TokenToSell == common.PRVCoinID
+	tokenList := []common.Hash{md.TokenToSell, mdReader.TokenToBuy}
+	if mdReader.FeeInPRV && !isPRV && mdReader.TokenToBuy != common.PRVCoinID {
+		tokenList = append(tokenList, common.PRVCoinID)
+	}
+	if md.Receiver, err = b.otaReceivers(tokenList...); err != nil {
+		return "", err
+	}
+	b.metadata = md
+
+	// burn the selling amount, plus the trading fee in PRV or in the token to sell
+	if mdReader.FeeInPRV && !isPRV {
+		if err := b.burn(md.TokenToSell, md.SellAmount); err != nil {
+			return "", err
+		}
+		if err := b.burn(common.PRVCoinID, md.TradingFee); err != nil {
+			return "", err
+		}
+	} else if err := b.burn(md.TokenToSell, md.SellAmount+md.TradingFee); err != nil {
+		return "", err
+	}
+	return b.sign()
+}
+
+// CreatePdexv3AddOrderTx builds and signs a pDEX v3 order, the metadata param is
+// {"TokenToSell", "TokenToBuy", "PoolPairID", "SellAmount", "MinAcceptableAmount", "NftID", "ExpiryHeight", "TimeInForce"}.
+func CreatePdexv3AddOrderTx(args string) (string, error) {
+	b, err := newTxVer2Builder(args)
+	if err != nil {
+		return "", err
+	}
+	mdReader := &struct {
+		TokenToSell         common.Hash
+		TokenToBuy          common.Hash
+		PoolPairID          string
+		SellAmount          amountParam
+		MinAcceptableAmount amountParam
+		NftID               common.Hash
+		ExpiryHeight        amountParam
+		TimeInForce         byte
+	}{}
+	if err := b.readMetadataParams(mdReader); err != nil {
+		return "", err
+	}
+
+	md, _ := metadataPdexv3.NewAddOrderRequest(
+		mdReader.TokenToSell, mdReader.PoolPairID, uint64(mdReader.SellAmount),
+		uint64(mdReader.MinAcceptableAmount), nil,
+		mdReader.NftID, metadataCommon.Pdexv3AddOrderRequestMeta,
+	)
+	md.ExpiryHeight = uint64(mdReader.ExpiryHeight)
+	md.TimeInForce = mdReader.TimeInForce
+	if md.Receiver, err = b.otaReceivers(md.TokenToSell, mdReader.TokenToBuy); err != nil {
+		return "", err
+	}
+	b.metadata = md
+
+	if err := b.burn(md.TokenToSell, md.SellAmount); err != nil {
+		return "", err
+	}
+	return b.sign()
+}
+
+// CreatePdexv3AddLiquidityTx builds and signs a pDEX v3 contribution, the metadata param is
+// {"PoolPairID", "PairHash", "TokenID", "NftID", "ContributedAmount", "Amplifier"}.
+func CreatePdexv3AddLiquidityTx(args string) (string, error) {
+	b, err := newTxVer2Builder(args)
+	if err != nil {
+		return "", err
+	}
+	mdReader := &struct {
+		NftID             string
+		TokenID           string
+		PoolPairID        string
+		PairHash          string
+		ContributedAmount amountParam
+		Amplifier         amountParam
+	}{}
+	if err := b.readMetadataParams(mdReader); err != nil {
+		return "", err
+	}
+	tokenID, err := common.Hash{}.NewHashFromStr(mdReader.TokenID)
+	if err != nil {
+		return "", errors.Wrap(err, "Invalid metadata param TokenID")
+	}
+	otaReceiver, err := b.otaReceiverString()
+	if err != nil {
+		return "", err
+	}
+
+	md := metadataPdexv3.NewAddLiquidityRequestWithValue(
+		mdReader.PoolPairID, mdReader.PairHash, otaReceiver, mdReader.TokenID, mdReader.NftID,
+		uint64(mdReader.ContributedAmount), uint(mdReader.Amplifier))
+	b.metadata = md
+
+	if err := b.burn(*tokenID, md.TokenAmount()); err != nil {
+		return "", err
+	}
+	return b.sign()
+}
+
+// CreatePdexv3MintNftTx builds and signs a request to mint a pDEX v3 access NFT, the metadata param is
+// {"Amount"}: the PRV amount required by the pDEX v3 params (MintNftRequireAmount).
+func CreatePdexv3MintNftTx(args string) (string, error) {
+	b, err := newTxVer2Builder(args)
+	if err != nil {
+		return "", err
+	}
+	mdReader := &struct {
+		Amount amountParam
+	}{}
+	if err := b.readMetadataParams(mdReader); err != nil {
+		return "", err
+	}
+	otaReceiver, err := b.otaReceiverString()
+	if err != nil {
+		return "", err
+	}
+
+	md := metadataPdexv3.NewUserMintNftRequestWithValue(otaReceiver, uint64(mdReader.Amount))
+	b.metadata = md
+
+	if err := b.burn(common.PRVCoinID, md.Amount()); err != nil {
+		return "", err
+	}
+	return b.sign()
+}
+
+// CreateBridgeAggUnshieldTx builds and signs an unshielding of a unified token, the metadata param is
+// {"UnifiedTokenID", "Data": [{"IncTokenID", "BurningAmount", "MinExpectedAmount", "RemoteAddress"}], "IsDepositToSC"}.
+// The token param spends the unified token.
+func CreateBridgeAggUnshieldTx(args string) (string, error) {
+	b, err := newTxVer2Builder(args)
+	if err != nil {
+		return "", err
+	}
+	mdReader := &struct {
+		UnifiedTokenID common.Hash
+		Data           []metadataBridge.UnshieldRequestData
+		IsDepositToSC  bool
+	}{}
+	if err := b.readMetadataParams(mdReader); err != nil {
+		return "", err
+	}
+	otaReceiver := privacy.OTAReceiver{}
+	if err := otaReceiver.FromAddress(b.keySet.PaymentAddress); err != nil {
+		return "", err
+	}
+
+	md := metadataBridge.NewUnshieldRequestWithValue(mdReader.UnifiedTokenID, mdReader.Data, otaReceiver, mdReader.IsDepositToSC)
+	b.metadata = md
+
+	burningAmount := uint64(0)
+	for _, data := range md.Data {
+		burningAmount += data.BurningAmount
+	}
+	if err := b.burn(md.UnifiedTokenID, burningAmount); err != nil {
+		return "", err
+	}
+	return b.sign()
+}
+
+// CreatePortalV4UnshieldTx builds and signs a portal v4 unshielding, the metadata param is
+// {"TokenID", "UnshieldAmount", "RemoteAddress"}. The token param spends the portal token.
+func CreatePortalV4UnshieldTx(args string) (string, error) {
+	b, err := newTxVer2Builder(args)
+	if err != nil {
+		return "", err
+	}
+	mdReader := &struct {
+		TokenID        string
+		UnshieldAmount amountParam
+		RemoteAddress  string
+	}{}
+	if err := b.readMetadataParams(mdReader); err != nil {
+		return "", err
+	}
+	tokenID, err := common.Hash{}.NewHashFromStr(mdReader.TokenID)
+	if err != nil {
+		return "", errors.Wrap(err, "Invalid metadata param TokenID")
+	}
+
+	// the refund goes to a one-time address of the sender
+	p := privacy.NewCoinParams().From(&privacy.PaymentInfo{
+		PaymentAddress: b.keySet.PaymentAddress,
+	}, int(b.shardID), privacy.CoinPrivacyTypeMint)
+	otaCoin, err := privacy.NewCoinFromPaymentInfo(p)
+	if err != nil {
+		return "", err
+	}
+	md, err := metadata.NewPortalUnshieldRequest(metadataCommon.PortalV4UnshieldingRequestMeta,
+		base58.Base58Check{}.Encode(otaCoin.GetPublicKey().ToBytesS(), common.ZeroByte),
+		base58.Base58Check{}.Encode(otaCoin.GetTxRandom().Bytes(), common.ZeroByte),
+		mdReader.TokenID, mdReader.RemoteAddress, uint64(mdReader.UnshieldAmount))
+	if err != nil {
+		return "", err
+	}
+	b.metadata = md
+
+	if err := b.burn(*tokenID, md.UnshieldAmount); err != nil {
+		return "", err
+	}
+	return b.sign()
+}
+
+// CreateStakingTx builds and signs a staking request, the metadata param is
+// {"Type", "FunderPaymentAddress", "RewardReceiverPaymentAddress", "StakingAmountShard", "CommitteePublicKey", "AutoReStaking"}.
+func CreateStakingTx(args string) (string, error) {
+	b, err := newTxVer2Builder(args)
+	if err != nil {
+		return "", err
+	}
+	mdReader := &struct {
+		Type                         int
+		FunderPaymentAddress         string
+		RewardReceiverPaymentAddress string
+		StakingAmountShard           amountParam
+		CommitteePublicKey           string
+		AutoReStaking                bool
+	}{}
+	if err := b.readMetadataParams(mdReader); err != nil {
+		return "", err
+	}
+
+	md, err := metadata.NewStakingMetadata(mdReader.Type, mdReader.FunderPaymentAddress, mdReader.RewardReceiverPaymentAddress,
+		uint64(mdReader.StakingAmountShard), mdReader.CommitteePublicKey, mdReader.AutoReStaking)
+	if err != nil {
+		return "", err
+	}
+	b.metadata = md
+
+	if err := b.burn(common.PRVCoinID, md.StakingAmountShard); err != nil {
+		return "", err
+	}
+	return b.sign()
+}
+
+// CreateStopAutoStakingTx builds and signs a request to stop auto staking, the metadata param is
+// {"Type", "CommitteePublicKey"}.
+func CreateStopAutoStakingTx(args string) (string, error) {
+	b, err := newTxVer2Builder(args)
+	if err != nil {
+		return "", err
+	}
+	mdReader := &struct {
+		Type               int
+		CommitteePublicKey string
+	}{}
+	if err := b.readMetadataParams(mdReader); err != nil {
+		return "", err
+	}
+
+	md, err := metadata.NewStopAutoStakingMetadata(mdReader.Type, mdReader.CommitteePublicKey)
+	if err != nil {
+		return "", err
+	}
+	b.metadata = md
+	return b.sign()
+}
+
+// CreateWithdrawRewardTx builds and signs a request to withdraw the rewards of a token, the metadata param is
+// {"PaymentAddress", "TokenID", "Version"}. The request is signed by the sender.
+func CreateWithdrawRewardTx(args string) (string, error) {
+	b, err := newTxVer2Builder(args)
+	if err != nil {
+		return "", err
+	}
+	mdReader := &struct {
+		PaymentAddress string
+		TokenID        string
+		Version        int
+	}{}
+	if err := b.readMetadataParams(mdReader); err != nil {
+		return "", err
+	}
+
+	md, err := metadata.NewWithDrawRewardRequest(mdReader.TokenID, mdReader.PaymentAddress,
+		float64(mdReader.Version), metadata.WithDrawRewardRequestMeta)
+	if err != nil {
+		return "", err
+	}
+	b.metadata = md
+	return b.sign()
+}
+
+// CreateBurningRequestTx builds and signs a burning of a bridge token, the metadata param is
+// {"Type", "BurningAmount", "TokenID", "TokenName", "RemoteAddress"}. The token param spends the bridge token.
+func CreateBurningRequestTx(args string) (string, error) {
+	b, err := newTxVer2Builder(args)
+	if err != nil {
+		return "", err
+	}
+	mdReader := &struct {
+		Type          int
+		BurningAmount amountParam
+		TokenID       common.Hash
+		TokenName     string
+		RemoteAddress string
+	}{}
+	if err := b.readMetadataParams(mdReader); err != nil {
+		return "", err
+	}
+
+	md, err := metadataBridge.NewBurningRequest(b.keySet.PaymentAddress, uint64(mdReader.BurningAmount),
+		mdReader.TokenID, mdReader.TokenName, mdReader.RemoteAddress, mdReader.Type)
+	if err != nil {
+		return "", err
+	}
+	b.metadata = md
+
+	if err := b.burn(md.TokenID, md.BurningAmount); err != nil {
+		return "", err
+	}
+	return b.sign()
+}
@@ -0,0 +1,243 @@
+package gomobile
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/common/base58"
+	"github.com/incognitochain/incognito-chain/config"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/incdb"
+	_ "github.com/incognitochain/incognito-chain/incdb/lvdb"
+	"github.com/incognitochain/incognito-chain/metadata"
+	metadataBridge "github.com/incognitochain/incognito-chain/metadata/bridge"
+	metadataPdexv3 "github.com/incognitochain/incognito-chain/metadata/pdexv3"
+	"github.com/incognitochain/incognito-chain/privacy"
+	"github.com/incognitochain/incognito-chain/rpcserver/jsonresult"
+	"github.com/incognitochain/incognito-chain/transaction"
+	"github.com/incognitochain/incognito-chain/transaction/utils"
+	"github.com/incognitochain/incognito-chain/wallet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testSender   *wallet.KeyWallet
+	testReceiver *wallet.KeyWallet
+	testTokenID  = common.Hash{57}
+)
+
+func init() {
+	logger := common.NewBackend(nil).Logger("test", true)
+	utils.Logger.Init(logger)
+	privacy.LoggerV1.Init(logger)
+	privacy.LoggerV2.Init(logger)
+	config.AbortParam()
+	config.Param().BCHeightBreakPointCoinOrigin = 1000000000000
+	common.MaxShardNumber = 1
+	testSender, _ = wallet.NewMasterKey([]byte("gomobile test sender"))
+	testReceiver, _ = wallet.NewMasterKey([]byte("gomobile test receiver"))
+}
+
+func newTestStateDB(t *testing.T) *statedb.StateDB {
+	dir, err := ioutil.TempDir(os.TempDir(), "gomobile_statedb_")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	diskDB, err := incdb.Open("leveldb", dir)
+	require.NoError(t, err)
+	t.Cleanup(func() { diskDB.Close() })
+	db, err := statedb.NewWithPrefixTrie(common.HexToHash(common.HexEmptyRoot), statedb.NewDatabaseAccessWarper(diskDB))
+	require.NoError(t, err)
+	return db
+}
+
+// storeTestCoins stores count coins of amount to the owner as the chain does and returns them as the RPCs do,
+// the coins of a token are stored with the coins of common.ConfidentialAssetID
+func storeTestCoins(t *testing.T, db *statedb.StateDB, owner *wallet.KeyWallet, amount uint64, count int, tokenID common.Hash) []jsonresult.OutCoin {
+	dbTokenID := common.PRVCoinID
+	if tokenID != common.PRVCoinID {
+		dbTokenID = common.ConfidentialAssetID
+	}
+	coins := make([]*privacy.CoinV2, count)
+	coinsBytes := make([][]byte, count)
+	otas := make([][]byte, count)
+	for i := range coins {
+		p := privacy.NewCoinParams().FromPaymentInfo(&privacy.PaymentInfo{PaymentAddress: owner.KeySet.PaymentAddress, Amount: amount})
+		var err error
+		if tokenID == common.PRVCoinID {
+			coins[i], err = privacy.NewCoinFromPaymentInfo(p)
+		} else {
+			coins[i], _, err = privacy.NewCoinCA(p, &tokenID)
+		}
+		require.NoError(t, err)
+		coins[i].ConcealOutputCoin(owner.KeySet.PaymentAddress.GetPublicView())
+		coinsBytes[i] = coins[i].Bytes()
+		otas[i] = coins[i].GetPublicKey().ToBytesS()
+	}
+	require.NoError(t, statedb.StoreOTACoinsAndOnetimeAddresses(db, dbTokenID, 0, coinsBytes, otas, 0))
+
+	outCoins := make([]jsonresult.OutCoin, count)
+	for i, c := range coins {
+		index, err := statedb.GetOTACoinIndex(db, dbTokenID, otas[i])
+		require.NoError(t, err)
+		outCoins[i] = jsonresult.NewOutCoin(c)
+		outCoins[i].Index = base58.Base58Check{}.Encode(index.Bytes(), common.ZeroByte)
+	}
+	return outCoins
+}
+
+func testTransferParam(t *testing.T, db *statedb.StateDB, amount uint64, tokenID common.Hash, paymentInfos []paymentInfoParam) map[string]interface{} {
+	return map[string]interface{}{
+		"inputs":            storeTestCoins(t, db, testSender, amount, 2, tokenID),
+		"decoys":            storeTestCoins(t, db, testReceiver, amount, 2*(privacy.RingSize-1), tokenID),
+		"paramPaymentInfos": paymentInfos,
+	}
+}
+
+func testTxVer2Args(t *testing.T, prv, token, md map[string]interface{}) string {
+	param := map[string]interface{}{
+		"privateKey":   testSender.Base58CheckSerialize(wallet.PriKeyType),
+		"activeShards": 1,
+		"fee":          "100",
+		"info":         "gomobile test",
+		"prv":          prv,
+		"metadata":     md,
+	}
+	if token != nil {
+		param["token"] = token
+	}
+	args, err := json.Marshal(param)
+	require.NoError(t, err)
+	return string(args)
+}
+
+// verifyTestTx parses the result of a builder and verifies the transaction as a node does
+func verifyTestTx(t *testing.T, db *statedb.StateDB, result string) metadata.Transaction {
+	res := map[string]string{}
+	require.NoError(t, json.Unmarshal([]byte(result), &res))
+	txBytes, _, err := base58.Base58Check{}.Decode(res["base58CheckData"])
+	require.NoError(t, err)
+	choices, err := transaction.DeserializeTransactionJSON(txBytes)
+	require.NoError(t, err)
+	tx := choices.ToTx()
+	require.NotNil(t, tx)
+	assert.Equal(t, res["txID"], tx.Hash().String())
+	assert.Equal(t, int8(transaction.TxVersion2Number), tx.GetVersion())
+	assert.Equal(t, uint64(100), tx.GetTxFee())
+
+	switch txv := tx.(type) {
+	case *transaction.TxVersion2:
+		require.NoError(t, txv.LoadData(db))
+	case *transaction.TxTokenVersion2:
+		require.NoError(t, txv.LoadData(db))
+	}
+	isValid, err := tx.ValidateTxByItself(map[string]bool{"hasPrivacy": true, "isNewTransaction": true}, db, db, nil, 0, nil, nil)
+	require.NoError(t, err)
+	assert.True(t, isValid)
+	return tx
+}
+
+func TestCreateTransactionVer2(t *testing.T) {
+	db := newTestStateDB(t)
+	receiverAddress := testReceiver.Base58CheckSerialize(wallet.PaymentAddressType)
+
+	t.Run("prv transfer", func(t *testing.T) {
+		prv := testTransferParam(t, db, 5000, common.PRVCoinID, []paymentInfoParam{{PaymentAddressStr: receiverAddress, Amount: 7000}})
+		result, err := CreateTransactionVer2(testTxVer2Args(t, prv, nil, nil))
+		require.NoError(t, err)
+		tx := verifyTestTx(t, db, result)
+		assert.Equal(t, common.TxNormalType, tx.GetType())
+		// the receiver and the change of the sender
+		assert.Len(t, tx.GetProof().GetOutputCoins(), 2)
+		assert.Len(t, tx.GetProof().GetInputCoins(), 2)
+	})
+
+	t.Run("token transfer", func(t *testing.T) {
+		require.NoError(t, statedb.StorePrivacyToken(db, testTokenID, "Gomobile", "GMB", statedb.InitToken, false, 100000, []byte{}, common.Hash{67}))
+		prv := testTransferParam(t, db, 5000, common.PRVCoinID, nil)
+		token := testTransferParam(t, db, 300, testTokenID, []paymentInfoParam{{PaymentAddressStr: receiverAddress, Amount: 500}})
+		token["tokenID"] = testTokenID.String()
+		result, err := CreateTransactionVer2(testTxVer2Args(t, prv, token, nil))
+		require.NoError(t, err)
+		tx := verifyTestTx(t, db, result)
+		assert.Equal(t, common.TxCustomTokenPrivacyType, tx.GetType())
+	})
+
+	t.Run("inputs do not pay for the outputs", func(t *testing.T) {
+		prv := testTransferParam(t, db, 5000, common.PRVCoinID, []paymentInfoParam{{PaymentAddressStr: receiverAddress, Amount: 10000}})
+		_, err := CreateTransactionVer2(testTxVer2Args(t, prv, nil, nil))
+		assert.Error(t, err)
+	})
+
+	t.Run("decoys are missing", func(t *testing.T) {
+		prv := testTransferParam(t, db, 5000, common.PRVCoinID, []paymentInfoParam{{PaymentAddressStr: receiverAddress, Amount: 7000}})
+		prv["decoys"] = prv["decoys"].([]jsonresult.OutCoin)[1:]
+		_, err := CreateTransactionVer2(testTxVer2Args(t, prv, nil, nil))
+		assert.Error(t, err)
+	})
+}
+
+func TestCreatePdexv3TradeTx(t *testing.T) {
+	db := newTestStateDB(t)
+	md := map[string]interface{}{
+		"TradePath":           []string{"pool-prv-gmb"},
+		"TokenToSell":         common.PRVCoinID.String(),
+		"TokenToBuy":          testTokenID.String(),
+		"SellAmount":          "6000",
+		"MinAcceptableAmount": "100",
+		"TradingFee":          "20",
+	}
+	prv := testTransferParam(t, db, 5000, common.PRVCoinID, nil)
+	result, err := CreatePdexv3TradeTx(testTxVer2Args(t, prv, nil, md))
+	require.NoError(t, err)
+	tx := verifyTestTx(t, db, result)
+
+	trade, ok := tx.GetMetadata().(*metadataPdexv3.TradeRequest)
+	require.True(t, ok)
+	assert.Equal(t, []string{"pool-prv-gmb"}, trade.TradePath)
+	assert.Equal(t, uint64(6000), trade.SellAmount)
+	assert.Equal(t, uint64(20), trade.TradingFee)
+	// a one-time address of the sender for the bought token and the refund
+	assert.Len(t, trade.Receiver, 2)
+	isBurned, burnedCoin, burnedTokenID, err := tx.GetTxBurnData()
+	require.NoError(t, err)
+	assert.True(t, isBurned)
+	assert.Equal(t, common.PRVCoinID, *burnedTokenID)
+	assert.Equal(t, uint64(6020), burnedCoin.GetValue())
+}
+
+func TestCreateBridgeAggUnshieldTx(t *testing.T) {
+	db := newTestStateDB(t)
+	require.NoError(t, statedb.StorePrivacyToken(db, testTokenID, "Unified", "UNI", statedb.InitToken, false, 100000, []byte{}, common.Hash{67}))
+	md := map[string]interface{}{
+		"UnifiedTokenID": testTokenID.String(),
+		"Data": []metadataBridge.UnshieldRequestData{
+			{IncTokenID: common.Hash{58}, BurningAmount: 400, MinExpectedAmount: 390, RemoteAddress: "c8f1bd3d2bd9dc5fb8e22c32d4e4dd9d6c2d6e2a"},
+			{IncTokenID: common.Hash{59}, BurningAmount: 100, MinExpectedAmount: 90, RemoteAddress: "c8f1bd3d2bd9dc5fb8e22c32d4e4dd9d6c2d6e2a"},
+		},
+		"IsDepositToSC": false,
+	}
+	prv := testTransferParam(t, db, 5000, common.PRVCoinID, nil)
+	token := testTransferParam(t, db, 300, testTokenID, nil)
+	token["tokenID"] = testTokenID.String()
+	result, err := CreateBridgeAggUnshieldTx(testTxVer2Args(t, prv, token, md))
+	require.NoError(t, err)
+	tx := verifyTestTx(t, db, result)
+
+	unshield, ok := tx.GetMetadata().(*metadataBridge.UnshieldRequest)
+	require.True(t, ok)
+	assert.Equal(t, testTokenID, unshield.UnifiedTokenID)
+	assert.Len(t, unshield.Data, 2)
+	isBurned, burnedCoin, burnedTokenID, err := tx.GetTxBurnData()
+	require.NoError(t, err)
+	assert.True(t, isBurned)
+	assert.Equal(t, testTokenID, *burnedTokenID)
+	assert.Equal(t, uint64(500), burnedCoin.GetValue())
+
+	// the unified token must be spent by the token param
+	_, err = CreateBridgeAggUnshieldTx(testTxVer2Args(t, testTransferParam(t, db, 5000, common.PRVCoinID, nil), nil, md))
+	assert.Error(t, err)
+}
@@ -1,9 +0,0 @@
-package gomobile
-
-import "testing"
-
-func TestInitPrivacyTx(t *testing.T) {
-	data :=
-
-		DeriveSerialNumber()
-}
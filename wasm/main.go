@@ -67,8 +67,8 @@ func randomScalars(_ js.Value, args []js.Value) interface{} {
 	return result
 }
 
-func initPrivacyTx(_ js.Value, args []js.Value) interface{} {
-	result, err := gomobile.InitPrivacyTx(args[0].String(), int64(args[1].Int()))
+func signUnsignedTx(_ js.Value, args []js.Value) interface{} {
+	result, err := gomobile.SignUnsignedTx(args[0].String())
 	if err != nil {
 		return nil
 	}
@@ -76,8 +76,108 @@ func initPrivacyTx(_ js.Value, args []js.Value) interface{} {
 	return result
 }
 
-func stopAutoStaking(_ js.Value, args []js.Value) interface{} {
-	result, err := gomobile.StopAutoStaking(args[0].String(), int64(args[1].Int()))
+func createTransactionVer2(_ js.Value, args []js.Value) interface{} {
+	result, err := gomobile.CreateTransactionVer2(args[0].String())
+	if err != nil {
+		return nil
+	}
+
+	return result
+}
+
+func createPdexv3TradeTx(_ js.Value, args []js.Value) interface{} {
+	result, err := gomobile.CreatePdexv3TradeTx(args[0].String())
+	if err != nil {
+		return nil
+	}
+
+	return result
+}
+
+func createPdexv3AddOrderTx(_ js.Value, args []js.Value) interface{} {
+	result, err := gomobile.CreatePdexv3AddOrderTx(args[0].String())
+	if err != nil {
+		return nil
+	}
+
+	return result
+}
+
+func createPdexv3AddLiquidityTx(_ js.Value, args []js.Value) interface{} {
+	result, err := gomobile.CreatePdexv3AddLiquidityTx(args[0].String())
+	if err != nil {
+		return nil
+	}
+
+	return result
+}
+
+func createPdexv3MintNftTx(_ js.Value, args []js.Value) interface{} {
+	result, err := gomobile.CreatePdexv3MintNftTx(args[0].String())
+	if err != nil {
+		return nil
+	}
+
+	return result
+}
+
+func createBridgeAggUnshieldTx(_ js.Value, args []js.Value) interface{} {
+	result, err := gomobile.CreateBridgeAggUnshieldTx(args[0].String())
+	if err != nil {
+		return nil
+	}
+
+	return result
+}
+
+func createPortalV4UnshieldTx(_ js.Value, args []js.Value) interface{} {
+	result, err := gomobile.CreatePortalV4UnshieldTx(args[0].String())
+	if err != nil {
+		return nil
+	}
+
+	return result
+}
+
+func createStakingTx(_ js.Value, args []js.Value) interface{} {
+	result, err := gomobile.CreateStakingTx(args[0].String())
+	if err != nil {
+		return nil
+	}
+
+	return result
+}
+
+func createStopAutoStakingTx(_ js.Value, args []js.Value) interface{} {
+	result, err := gomobile.CreateStopAutoStakingTx(args[0].String())
+	if err != nil {
+		return nil
+	}
+
+	return result
+}
+
+func createWithdrawRewardTx(_ js.Value, args []js.Value) interface{} {
+	result, err := gomobile.CreateWithdrawRewardTx(args[0].String())
+	if err != nil {
+		return nil
+	}
+
+	return result
+}
+
+func createBurningRequestTx(_ js.Value, args []js.Value) interface{} {
+	result, err := gomobile.CreateBurningRequestTx(args[0].String())
+	if err != nil {
+		return nil
+	}
+
+	return result
+}
+
+// Deprecated: initPrivacyTx builds a ver1 transaction, use createTransactionVer2.
+func initPrivacyTx(_ js.Value, args []js.Value) interface{} {
+	result, err := gomobile.InitPrivacyTx(args[0].String(), int64(args[1].Int()))
 	if err != nil {
 		return nil
 	}
@@ -85,6 +185,7 @@ func stopAutoStaking(_ js.Value, args []js.Value) interface{} {
 	return result
 }
 
+// Deprecated: staking builds a ver1 transaction, use createStakingTx.
 func staking(_ js.Value, args []js.Value) interface{} {
 	result, err := gomobile.Staking(args[0].String(), int64(args[1].Int()))
 	if err != nil {
@@ -94,6 +195,17 @@ func staking(_ js.Value, args []js.Value) interface{} {
 	return result
 }
 
+// Deprecated: stopAutoStaking builds a ver1 transaction, use createStopAutoStakingTx.
+func stopAutoStaking(_ js.Value, args []js.Value) interface{} {
+	result, err := gomobile.StopAutoStaking(args[0].String(), int64(args[1].Int()))
+	if err != nil {
+		return nil
+	}
+
+	return result
+}
+
+// Deprecated: initPrivacyTokenTx builds a ver1 transaction, use createTransactionVer2.
 func initPrivacyTokenTx(_ js.Value, args []js.Value) interface{} {
 	result, err := gomobile.InitPrivacyTokenTx(args[0].String(), int64(args[1].Int()))
 	if err != nil {
@@ -103,6 +215,7 @@ func initPrivacyTokenTx(_ js.Value, args []js.Value) interface{} {
 	return result
 }
 
+// Deprecated: initBurningRequestTx builds a ver1 transaction, use createBurningRequestTx.
 func initBurningRequestTx(_ js.Value, args []js.Value) interface{} {
 	result, err := gomobile.InitBurningRequestTx(args[0].String(), int64(args[1].Int()))
 	if err != nil {
@@ -112,6 +225,7 @@ func initBurningRequestTx(_ js.Value, args []js.Value) interface{} {
 	return result
 }
 
+// Deprecated: initWithdrawRewardTx builds a ver1 transaction, use createWithdrawRewardTx.
 func initWithdrawRewardTx(_ js.Value, args []js.Value) interface{} {
 	result, err := gomobile.InitWithdrawRewardTx(args[0].String(), int64(args[1].Int()))
 	if err != nil {
@@ -121,6 +235,7 @@ func initWithdrawRewardTx(_ js.Value, args []js.Value) interface{} {
 	return result
 }
 
+// Deprecated: initPRVContributionTx builds a ver1 transaction, use createPdexv3AddLiquidityTx.
 func initPRVContributionTx(_ js.Value, args []js.Value) interface{} {
 	result, err := gomobile.InitPRVContributionTx(args[0].String(), int64(args[1].Int()))
 	if err != nil {
@@ -130,6 +245,7 @@ func initPRVContributionTx(_ js.Value, args []js.Value) interface{} {
 	return result
 }
 
+// Deprecated: initPTokenContributionTx builds a ver1 transaction, use createPdexv3AddLiquidityTx.
 func initPTokenContributionTx(_ js.Value, args []js.Value) interface{} {
 	result, err := gomobile.InitPTokenContributionTx(args[0].String(), int64(args[1].Int()))
 	if err != nil {
@@ -139,6 +255,7 @@ func initPTokenContributionTx(_ js.Value, args []js.Value) interface{} {
 	return result
 }
 
+// Deprecated: initPRVTradeTx builds a ver1 transaction, use createPdexv3TradeTx.
 func initPRVTradeTx(_ js.Value, args []js.Value) interface{} {
 	result, err := gomobile.InitPRVTradeTx(args[0].String(), int64(args[1].Int()))
 	if err != nil {
@@ -148,6 +265,7 @@ func initPRVTradeTx(_ js.Value, args []js.Value) interface{} {
 	return result
 }
 
+// Deprecated: initPTokenTradeTx builds a ver1 transaction, use createPdexv3TradeTx.
 func initPTokenTradeTx(_ js.Value, args []js.Value) interface{} {
 	result, err := gomobile.InitPTokenTradeTx(args[0].String(), int64(args[1].Int()))
 	if err != nil {
@@ -157,6 +275,7 @@ func initPTokenTradeTx(_ js.Value, args []js.Value) interface{} {
 	return result
 }
 
+// Deprecated: withdrawDexTx builds a ver1 pDEX v1 withdrawal, pDEX v3 has no counterpart in the SDK yet.
 func withdrawDexTx(_ js.Value, args []js.Value) interface{} {
 	result, err := gomobile.WithdrawDexTx(args[0].String(), int64(args[1].Int()))
 	if err != nil {
@@ -217,12 +336,7 @@ func main() {
 	//js.Global().Set("aggregatedRangeProve", js.FuncOf(aggregatedRangeProve))
 	//js.Global().Set("oneOutOfManyProve", js.FuncOf(oneOutOfManyProve))
 
-	js.Global().Set("initPrivacyTx", js.FuncOf(initPrivacyTx))
-	js.Global().Set("staking", js.FuncOf(staking))
-	js.Global().Set("stopAutoStaking", js.FuncOf(stopAutoStaking))
-	js.Global().Set("initPrivacyTokenTx", js.FuncOf(initPrivacyTokenTx))
-	js.Global().Set("initBurningRequestTx", js.FuncOf(initBurningRequestTx))
-	js.Global().Set("initWithdrawRewardTx", js.FuncOf(initWithdrawRewardTx))
+	js.Global().Set("signUnsignedTx", js.FuncOf(signUnsignedTx))
 	js.Global().Set("deriveSerialNumber", js.FuncOf(deriveSerialNumber))
 
 	js.Global().Set("generateKeyFromSeed", js.FuncOf(generateKeyFromSeed))
@@ -230,6 +344,25 @@ func main() {
 	js.Global().Set("randomScalars", js.FuncOf(randomScalars))
 	js.Global().Set("generateBLSKeyPairFromSeed", js.FuncOf(generateBLSKeyPairFromSeed))
 
+	js.Global().Set("createTransactionVer2", js.FuncOf(createTransactionVer2))
+	js.Global().Set("createPdexv3TradeTx", js.FuncOf(createPdexv3TradeTx))
+	js.Global().Set("createPdexv3AddOrderTx", js.FuncOf(createPdexv3AddOrderTx))
+	js.Global().Set("createPdexv3AddLiquidityTx", js.FuncOf(createPdexv3AddLiquidityTx))
+	js.Global().Set("createPdexv3MintNftTx", js.FuncOf(createPdexv3MintNftTx))
+	js.Global().Set("createBridgeAggUnshieldTx", js.FuncOf(createBridgeAggUnshieldTx))
+	js.Global().Set("createPortalV4UnshieldTx", js.FuncOf(createPortalV4UnshieldTx))
+	js.Global().Set("createStakingTx", js.FuncOf(createStakingTx))
+	js.Global().Set("createStopAutoStakingTx", js.FuncOf(createStopAutoStakingTx))
+	js.Global().Set("createWithdrawRewardTx", js.FuncOf(createWithdrawRewardTx))
+	js.Global().Set("createBurningRequestTx", js.FuncOf(createBurningRequestTx))
+
+	// deprecated ver1 builders, kept for the apps still calling them
+	js.Global().Set("initPrivacyTx", js.FuncOf(initPrivacyTx))
+	js.Global().Set("staking", js.FuncOf(staking))
+	js.Global().Set("stopAutoStaking", js.FuncOf(stopAutoStaking))
+	js.Global().Set("initPrivacyTokenTx", js.FuncOf(initPrivacyTokenTx))
+	js.Global().Set("initBurningRequestTx", js.FuncOf(initBurningRequestTx))
+	js.Global().Set("initWithdrawRewardTx", js.FuncOf(initWithdrawRewardTx))
 	js.Global().Set("initPRVContributionTx", js.FuncOf(initPRVContributionTx))
 	js.Global().Set("initPTokenContributionTx", js.FuncOf(initPTokenContributionTx))
 	js.Global().Set("initPRVTradeTx", js.FuncOf(initPRVTradeTx))
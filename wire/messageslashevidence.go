@@ -0,0 +1,58 @@
+package wire
+
+import (
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	peer "github.com/libp2p/go-libp2p-peer"
+)
+
+// MessageSlashEvidence carries a slash evidence instruction of a shard validator to beacon committee
+type MessageSlashEvidence struct {
+	Instruction []string
+}
+
+func NewMessageSlashEvidence(instruction []string) *MessageSlashEvidence {
+	return &MessageSlashEvidence{Instruction: instruction}
+}
+
+func (msg *MessageSlashEvidence) Hash() string {
+	rawBytes, err := msg.JsonSerialize()
+	if err != nil {
+		return ""
+	}
+	return common.HashH(rawBytes).String()
+}
+
+func (msg *MessageSlashEvidence) MessageType() string {
+	return CmdMsgSlashEvidence
+}
+
+func (msg *MessageSlashEvidence) MaxPayloadLength(pver int) int {
+	return MaxTxPayload
+}
+
+func (msg *MessageSlashEvidence) JsonSerialize() ([]byte, error) {
+	jsonBytes, err := json.Marshal(msg)
+	return jsonBytes, err
+}
+
+func (msg *MessageSlashEvidence) JsonDeserialize(jsonStr string) error {
+	jsonDecodeString, _ := hex.DecodeString(jsonStr)
+	err := json.Unmarshal([]byte(jsonDecodeString), msg)
+	return err
+}
+
+func (msg *MessageSlashEvidence) SetSenderID(senderID peer.ID) error {
+	return nil
+}
+
+func (msg *MessageSlashEvidence) SignMsg(_ *incognitokey.KeySet) error {
+	return nil
+}
+
+func (msg *MessageSlashEvidence) VerifyMsgSanity() error {
+	return nil
+}